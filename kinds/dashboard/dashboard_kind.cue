@@ -629,6 +629,8 @@ lineage: schemas: [{
 			name: string
 			// Library panel uid
 			uid: string
+			// Version of the library panel the dashboard is pinned to. The latest version is used when not set.
+			pinnedVersion?: int64
 		} @cuetsy(kind="interface")
 
 		// Matcher is a predicate configuration. Based on the config a set of field(s) or values is filtered in order to apply override / transformation.
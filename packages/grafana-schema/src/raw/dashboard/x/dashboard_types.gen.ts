@@ -851,6 +851,10 @@ export interface LibraryPanelRef {
    * Library panel name
    */
   name: string;
+  /**
+   * Version of the library panel the dashboard is pinned to. The latest version is used when not set.
+   */
+  pinnedVersion?: number;
   /**
    * Library panel uid
    */
@@ -134,6 +134,28 @@ export function QueryHeader({
           options={QUERY_FORMAT_OPTIONS}
         />
 
+        {query.format === QueryFormat.Table && dialect !== 'influx' && (
+          <InlineSwitch
+            id={`sql-stream-${htmlId}`}
+            label="Stream"
+            transparent={true}
+            showLabel={true}
+            value={Boolean(query.stream)}
+            onChange={(ev) => {
+              if (!(ev.target instanceof HTMLInputElement)) {
+                return;
+              }
+
+              reportInteraction('grafana_sql_stream_toggled', {
+                datasource: query.datasource?.type,
+                stream: ev.target.checked,
+              });
+
+              onChange({ ...query, stream: ev.target.checked });
+            }}
+          />
+        )}
+
         {editorMode === EditorMode.Builder && (
           <>
             <InlineSwitch
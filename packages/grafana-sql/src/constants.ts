@@ -70,4 +70,5 @@ export const MACRO_NAMES = [
   '$__unixEpochNanoTo',
   '$__unixEpochGroup',
   '$__unixEpochGroupAlias',
+  '$__param',
 ];
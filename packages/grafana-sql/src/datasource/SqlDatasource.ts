@@ -1,4 +1,4 @@
-import { lastValueFrom, Observable, throwError } from 'rxjs';
+import { lastValueFrom, merge, Observable, throwError } from 'rxjs';
 import { map } from 'rxjs/operators';
 
 import {
@@ -32,10 +32,11 @@ import {
 import { ResponseParser } from '../ResponseParser';
 import { SqlQueryEditor } from '../components/QueryEditor';
 import { MACRO_NAMES } from '../constants';
-import { DB, SQLQuery, SQLOptions, SqlQueryModel, QueryFormat } from '../types';
+import { DB, SQLQuery, SQLOptions, SqlQueryModel, QueryFormat, SQLParamValue } from '../types';
 import migrateAnnotation from '../utils/migration';
 
 import { isSqlDatasourceDatabaseSelectionFeatureFlagEnabled } from './../components/QueryEditorFeatureFlag.utils';
+import { doSqlChannelStream, isStreamingQuery } from './streaming';
 
 export abstract class SqlDatasource extends DataSourceWithBackend<SQLQuery, SQLOptions> {
   id: number;
@@ -104,6 +105,7 @@ export abstract class SqlDatasource extends DataSourceWithBackend<SQLQuery, SQLO
           ...query,
           datasource: this.getRef(),
           rawSql: this.templateSrv.replace(query.rawSql, scopedVars, this.interpolateVariable),
+          params: this.resolveParams(query, scopedVars),
           rawQuery: true,
         };
         return expandedQuery;
@@ -122,9 +124,31 @@ export abstract class SqlDatasource extends DataSourceWithBackend<SQLQuery, SQLO
       datasource: this.getRef(),
       rawSql: this.templateSrv.replace(target.rawSql, scopedVars, this.interpolateVariable),
       format: target.format,
+      params: this.resolveParams(target, scopedVars),
     };
   }
 
+  /**
+   * Resolves the template variables referenced with $__param(name). The backend binds their values
+   * as query arguments instead of interpolating them into the SQL. Values stored on the query, for
+   * example on alert rules, are kept for names that are not template variables.
+   */
+  resolveParams(query: SQLQuery, scopedVars: ScopedVars): Record<string, SQLParamValue> | undefined {
+    const names = [...(query.rawSql ?? '').matchAll(/\$__param\(\s*([_a-zA-Z0-9]+)\s*\)/g)].map((m) => m[1]);
+    if (names.length === 0) {
+      return query.params;
+    }
+
+    const params = { ...query.params };
+    for (const name of names) {
+      this.templateSrv.replace(`\${${name}}`, scopedVars, (value: string | string[]) => {
+        params[name] = value;
+        return '';
+      });
+    }
+    return params;
+  }
+
   query(request: DataQueryRequest<SQLQuery>): Observable<DataQueryResponse> {
     // This logic reenables the previous SQL behavior regarding what databases are available for the user to query.
     if (isSqlDatasourceDatabaseSelectionFeatureFlagEnabled()) {
@@ -149,7 +173,19 @@ export abstract class SqlDatasource extends DataSourceWithBackend<SQLQuery, SQLO
       });
     });
 
-    return super.query(request);
+    const streamingTargets = request.targets.filter((target) => !target.hide && isStreamingQuery(target));
+    if (streamingTargets.length === 0) {
+      return super.query(request);
+    }
+
+    const streams = streamingTargets.map((target) =>
+      doSqlChannelStream(this.applyTemplateVariables(target, request.scopedVars), this.uid, request)
+    );
+    const targets = request.targets.filter((target) => !streamingTargets.includes(target));
+    if (targets.length > 0) {
+      streams.push(super.query({ ...request, targets }));
+    }
+    return merge(...streams);
   }
 
   private checkForDatabaseIssue(request: DataQueryRequest<SQLQuery>) {
@@ -0,0 +1,79 @@
+import { lastValueFrom, of, toArray } from 'rxjs';
+
+import {
+  DataFrameJSON,
+  DataQueryRequest,
+  dateTime,
+  FieldType,
+  LiveChannelEventType,
+  LoadingState,
+} from '@grafana/data';
+
+import { QueryFormat, SQLQuery } from '../types';
+
+import { doSqlChannelStream, isStreamingQuery } from './streaming';
+
+const getStream = jest.fn();
+
+jest.mock('@grafana/runtime', () => ({
+  ...jest.requireActual('@grafana/runtime'),
+  getGrafanaLiveSrv: () => ({ getStream }),
+}));
+
+const chunk = (values: number[], rows: number, done: boolean): DataFrameJSON => ({
+  schema: {
+    refId: 'A',
+    fields: [{ name: 'value', type: FieldType.number }],
+    meta: { custom: { rows, done } },
+  },
+  data: { values: [values] },
+});
+
+const message = (msg: DataFrameJSON) => ({ type: LiveChannelEventType.Message, message: msg });
+
+describe('doSqlChannelStream', () => {
+  const query: SQLQuery = { refId: 'A', format: QueryFormat.Table, rawSql: 'SELECT value FROM metrics', stream: true };
+  const request = {
+    intervalMs: 1000,
+    range: { from: dateTime(1704067200000), to: dateTime(1704070800000) },
+  } as DataQueryRequest<SQLQuery>;
+
+  it('appends the chunks until the query is done', async () => {
+    getStream.mockReturnValue(
+      of(message(chunk([1, 2], 2, false)), message(chunk([3], 3, true)), message(chunk([4], 4, true)))
+    );
+
+    const responses = await lastValueFrom(doSqlChannelStream(query, 'ds-uid', request).pipe(toArray()));
+
+    expect(getStream).toHaveBeenCalledWith(
+      expect.objectContaining({
+        namespace: 'ds-uid',
+        path: expect.stringMatching(/^query\//),
+        data: expect.objectContaining({
+          rawSql: 'SELECT value FROM metrics',
+          intervalMs: 1000,
+          timeRange: { from: '1704067200000', to: '1704070800000' },
+        }),
+      })
+    );
+    expect(responses.map((r) => r.state)).toEqual([LoadingState.Streaming, LoadingState.Done]);
+    expect(responses[1].key).toBe('A');
+    expect(responses[1].data[0].fields[0].values).toEqual([1, 2, 3]);
+  });
+
+  it('throws the error of the query', async () => {
+    getStream.mockReturnValue(
+      of(message({ schema: { fields: [], meta: { custom: { rows: 0, done: true, error: 'db query error: boom' } } } }))
+    );
+
+    await expect(lastValueFrom(doSqlChannelStream(query, 'ds-uid', request))).rejects.toThrow('db query error: boom');
+  });
+});
+
+describe('isStreamingQuery', () => {
+  it('only streams table queries', () => {
+    expect(isStreamingQuery({ refId: 'A', format: QueryFormat.Table, stream: true })).toBe(true);
+    expect(isStreamingQuery({ refId: 'A', format: QueryFormat.Timeseries, stream: true })).toBe(false);
+    expect(isStreamingQuery({ refId: 'A', format: QueryFormat.Table })).toBe(false);
+  });
+});
@@ -0,0 +1,79 @@
+import { map, Observable, takeWhile } from 'rxjs';
+import { v4 as uuidv4 } from 'uuid';
+
+import {
+  DataFrameJSON,
+  DataQueryRequest,
+  DataQueryResponse,
+  LiveChannelScope,
+  LoadingState,
+  StreamingDataFrame,
+} from '@grafana/data';
+import { getGrafanaLiveSrv } from '@grafana/runtime';
+
+import { QueryFormat, SQLQuery } from '../types';
+
+/** Progress sent by the backend in the custom metadata of every frame of a streamed query. */
+interface StreamProgress {
+  rows: number;
+  done: boolean;
+  error?: string;
+}
+
+export function isStreamingQuery(query: SQLQuery): boolean {
+  return Boolean(query.stream) && query.format === QueryFormat.Table;
+}
+
+/**
+ * Runs a table query over a Grafana Live channel. The backend sends the rows in chunks, which are
+ * appended to a single frame until the backend reports that the query is done. Unsubscribing,
+ * for example when the panel is no longer viewed, cancels the query.
+ */
+export function doSqlChannelStream(
+  query: SQLQuery,
+  uid: string,
+  request: DataQueryRequest<SQLQuery>
+): Observable<DataQueryResponse> {
+  let frame: StreamingDataFrame | undefined = undefined;
+  const progressOf = (msg: DataFrameJSON): StreamProgress | undefined => msg.schema?.meta?.custom as StreamProgress;
+
+  return getGrafanaLiveSrv()
+    .getStream<DataFrameJSON>({
+      scope: LiveChannelScope.DataSource,
+      namespace: uid,
+      path: `query/${uuidv4()}`,
+      data: {
+        ...query,
+        intervalMs: request.intervalMs,
+        timeRange: {
+          from: request.range.from.valueOf().toString(),
+          to: request.range.to.valueOf().toString(),
+        },
+      },
+    })
+    .pipe(
+      takeWhile((evt) => !('message' in evt && evt.message && progressOf(evt.message)?.done), true),
+      map((evt) => {
+        let state = LoadingState.Streaming;
+        if ('message' in evt && evt.message) {
+          const progress = progressOf(evt.message);
+          if (progress?.error) {
+            throw new Error(progress.error);
+          }
+          if (!frame) {
+            frame = StreamingDataFrame.fromDataFrameJSON(evt.message, { maxLength: Infinity });
+          } else {
+            frame.push(evt.message);
+          }
+          if (progress?.done) {
+            state = LoadingState.Done;
+          }
+        }
+        return {
+          data: frame ? [frame] : [],
+          key: query.refId,
+          state,
+        };
+      })
+    );
+}
@@ -4,6 +4,7 @@ export type {
   SQLExpression,
   SQLOptions,
   SQLQuery,
+  SQLParamValue,
   SqlQueryModel,
   SQLSelectableValue,
   Func,
@@ -50,6 +50,8 @@ export enum QueryFormat {
   Table = 'table',
 }
 
+export type SQLParamValue = string | number | boolean | null | Array<string | number | boolean | null>;
+
 export interface SQLQuery extends DataQuery {
   alias?: string;
   format?: QueryFormat;
@@ -59,6 +61,10 @@ export interface SQLQuery extends DataQuery {
   sql?: SQLExpression;
   editorMode?: EditorMode;
   rawQuery?: boolean;
+  /** Values bound by the backend for the $__param(name) macros in rawSql. */
+  params?: Record<string, SQLParamValue>;
+  /** Stream the rows of table queries in chunks over Grafana Live. */
+  stream?: boolean;
 }
 
 export interface NameValue {
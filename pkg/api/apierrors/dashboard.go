@@ -39,5 +39,5 @@ func ToDashboardErrorResponse(ctx context.Context, pluginStore pluginstore.Store
 		return response.JSON(http.StatusPreconditionFailed, util.DynMap{"status": "plugin-dashboard", "message": message})
 	}
 
-	return response.Error(http.StatusInternalServerError, "Failed to save dashboard", err)
+	return response.ErrOrFallback(http.StatusInternalServerError, "Failed to save dashboard", err)
 }
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"github.com/grafana/grafana/pkg/services/datasources"
+)
+
+// dataSourceAuditState returns the fields of a data source recorded in the audit log.
+// Secure JSON data is left out so that secrets never end up in the audit log.
+func dataSourceAuditState(ds *datasources.DataSource) map[string]any {
+	if ds == nil {
+		return nil
+	}
+	return map[string]any{
+		"name":            ds.Name,
+		"type":            ds.Type,
+		"access":          ds.Access,
+		"url":             ds.URL,
+		"user":            ds.User,
+		"database":        ds.Database,
+		"basicAuth":       ds.BasicAuth,
+		"basicAuthUser":   ds.BasicAuthUser,
+		"withCredentials": ds.WithCredentials,
+		"isDefault":       ds.IsDefault,
+		"jsonData":        ds.JsonData,
+	}
+}
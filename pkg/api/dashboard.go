@@ -21,6 +21,7 @@ import (
 	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/infra/metrics"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/auditlog"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
@@ -633,6 +634,10 @@ func (hs *HTTPServer) postDashboard(c *contextmodel.ReqContext, cmd dashboards.S
 		return response.Error(http.StatusInternalServerError, "Error while connecting library panels", err)
 	}
 
+	// dashboard content changes are kept in the dashboard versions, the audit log only records the saved version
+	auditlog.SetResource(ctx, dashboards.ScopeDashboardsRoot, dashboard.UID)
+	auditlog.SetChange(ctx, nil, map[string]any{"version": dashboard.Version, "folderUid": dashboard.FolderUID})
+
 	c.TimeRequest(metrics.MApiDashboardSave)
 	return response.JSON(http.StatusOK, util.DynMap{
 		"status":    "success",
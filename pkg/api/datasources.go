@@ -18,6 +18,7 @@ import (
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/auditlog"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	"github.com/grafana/grafana/pkg/services/datasources"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
@@ -185,6 +186,9 @@ func (hs *HTTPServer) DeleteDataSourceById(c *contextmodel.ReqContext) response.
 		return response.Error(http.StatusInternalServerError, "Failed to delete datasource", err)
 	}
 
+	auditlog.SetResource(c.Req.Context(), datasources.ScopeRoot, ds.UID)
+	auditlog.SetChange(c.Req.Context(), dataSourceAuditState(ds), nil)
+
 	hs.Live.HandleDatasourceDelete(c.SignedInUser.GetOrgID(), ds.UID)
 
 	return response.Success("Data source deleted")
@@ -264,6 +268,9 @@ func (hs *HTTPServer) DeleteDataSourceByUID(c *contextmodel.ReqContext) response
 		return response.Error(http.StatusInternalServerError, "Failed to delete datasource", err)
 	}
 
+	auditlog.SetResource(c.Req.Context(), datasources.ScopeRoot, ds.UID)
+	auditlog.SetChange(c.Req.Context(), dataSourceAuditState(ds), nil)
+
 	hs.Live.HandleDatasourceDelete(c.SignedInUser.GetOrgID(), ds.UID)
 
 	return response.JSON(http.StatusOK, util.DynMap{
@@ -314,6 +321,9 @@ func (hs *HTTPServer) DeleteDataSourceByName(c *contextmodel.ReqContext) respons
 		return response.Error(http.StatusInternalServerError, "Failed to delete datasource", err)
 	}
 
+	auditlog.SetResource(c.Req.Context(), datasources.ScopeRoot, dataSource.UID)
+	auditlog.SetChange(c.Req.Context(), dataSourceAuditState(dataSource), nil)
+
 	hs.Live.HandleDatasourceDelete(c.SignedInUser.GetOrgID(), dataSource.UID)
 
 	return response.JSON(http.StatusOK, util.DynMap{
@@ -419,6 +429,9 @@ func (hs *HTTPServer) AddDataSource(c *contextmodel.ReqContext) response.Respons
 	// Required for cases when caller wants to immediately interact with the newly created object
 	hs.accesscontrolService.ClearUserPermissionCache(c.SignedInUser)
 
+	auditlog.SetResource(c.Req.Context(), datasources.ScopeRoot, dataSource.UID)
+	auditlog.SetChange(c.Req.Context(), nil, dataSourceAuditState(dataSource))
+
 	ds := hs.convertModelToDtos(c.Req.Context(), dataSource)
 	return response.JSON(http.StatusOK, util.DynMap{
 		"message":    "Datasource added",
@@ -554,6 +567,9 @@ func (hs *HTTPServer) updateDataSourceByID(c *contextmodel.ReqContext, ds *datas
 		return response.Error(http.StatusInternalServerError, "Failed to query datasource", err)
 	}
 
+	auditlog.SetResource(c.Req.Context(), datasources.ScopeRoot, dataSource.UID)
+	auditlog.SetChange(c.Req.Context(), dataSourceAuditState(ds), dataSourceAuditState(dataSource))
+
 	datasourceDTO := hs.convertModelToDtos(c.Req.Context(), dataSource)
 
 	hs.Live.HandleDatasourceUpdate(c.SignedInUser.GetOrgID(), datasourceDTO.UID)
@@ -58,10 +58,36 @@ type ExecutableNode interface {
 // DataPipeline is an ordered set of nodes returned from DPGraph processing.
 type DataPipeline []Node
 
+// ExecutionStats is the time spent executing the nodes of a pipeline, split into datasource queries and expressions.
+// Machine learning nodes query an external service and are counted as datasource queries.
+type ExecutionStats struct {
+	DatasourceDuration time.Duration
+	ExpressionDuration time.Duration
+}
+
+type executionStatsKey struct{}
+
+// WithExecutionStats returns a context that adds the execution time of the pipelines executed with it to stats.
+func WithExecutionStats(ctx context.Context, stats *ExecutionStats) context.Context {
+	return context.WithValue(ctx, executionStatsKey{}, stats)
+}
+
+func (st *ExecutionStats) observe(nodeType NodeType, d time.Duration) {
+	if st == nil {
+		return
+	}
+	if nodeType == TypeCMDNode {
+		st.ExpressionDuration += d
+		return
+	}
+	st.DatasourceDuration += d
+}
+
 // execute runs all the command/datasource requests in the pipeline return a
 // map of the refId of the of each command
 func (dp *DataPipeline) execute(c context.Context, now time.Time, s *Service) (mathexp.Vars, error) {
 	vars := make(mathexp.Vars)
+	stats, _ := c.Value(executionStatsKey{}).(*ExecutionStats)
 
 	groupByDSFlag := s.features.IsEnabled(c, featuremgmt.FlagSseGroupByDatasource)
 	// Execute datasource nodes first, and grouped by datasource.
@@ -74,7 +100,9 @@ func (dp *DataPipeline) execute(c context.Context, now time.Time, s *Service) (m
 			dsNodes = append(dsNodes, node.(*DSNode))
 		}
 
+		start := time.Now()
 		executeDSNodesGrouped(c, now, vars, s, dsNodes)
+		stats.observe(TypeDatasourceNode, time.Since(start))
 	}
 
 	s.allowLongFrames = hasSqlExpression(*dp)
@@ -115,7 +143,9 @@ func (dp *DataPipeline) execute(c context.Context, now time.Time, s *Service) (m
 			return vars, makeUnexpectedNodeTypeError(node.RefID(), node.NodeType().String())
 		}
 
+		start := time.Now()
 		res, err := execNode.Execute(c, now, vars, s)
+		stats.observe(node.NodeType(), time.Since(start))
 		if err != nil {
 			res.Error = err
 		}
@@ -83,8 +83,11 @@ func TestService(t *testing.T) {
 	pl, err := s.BuildPipeline(req)
 	require.NoError(t, err)
 
-	res, err := s.ExecutePipeline(context.Background(), time.Now(), pl)
+	stats := &ExecutionStats{}
+	res, err := s.ExecutePipeline(WithExecutionStats(context.Background(), stats), time.Now(), pl)
 	require.NoError(t, err)
+	require.Positive(t, stats.DatasourceDuration)
+	require.Positive(t, stats.ExpressionDuration)
 
 	bDF := data.NewFrame("",
 		data.NewField("Time", nil, []time.Time{time.Unix(1, 0)}),
@@ -489,6 +489,9 @@ type LibraryPanelRef struct {
 	// Library panel name
 	Name string `json:"name"`
 
+	// Version of the library panel the dashboard is pinned to. The latest version is used when not set.
+	PinnedVersion *int64 `json:"pinnedVersion,omitempty"`
+
 	// Library panel uid
 	Uid string `json:"uid"`
 }
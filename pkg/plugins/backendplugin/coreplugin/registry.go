@@ -33,6 +33,7 @@ import (
 	"github.com/grafana/grafana/pkg/tsdb/opentsdb"
 	"github.com/grafana/grafana/pkg/tsdb/parca"
 	"github.com/grafana/grafana/pkg/tsdb/prometheus"
+	"github.com/grafana/grafana/pkg/tsdb/sqlite"
 	"github.com/grafana/grafana/pkg/tsdb/tempo"
 	"github.com/grafana/grafana/pkg/tsdb/zipkin"
 )
@@ -53,6 +54,7 @@ const (
 	PostgreSQL      = "grafana-postgresql-datasource"
 	MySQL           = "mysql"
 	MSSQL           = "mssql"
+	SQLite          = "sqlite"
 	Grafana         = "grafana"
 	Pyroscope       = "grafana-pyroscope-datasource"
 	Parca           = "parca"
@@ -95,7 +97,7 @@ func NewRegistry(store map[string]backendplugin.PluginFactoryFunc) *Registry {
 func ProvideCoreRegistry(tracer tracing.Tracer, am *azuremonitor.Service, cw *cloudwatch.CloudWatchService, cm *cloudmonitoring.Service,
 	es *elasticsearch.Service, grap *graphite.Service, idb *influxdb.Service, lk *loki.Service, otsdb *opentsdb.Service,
 	pr *prometheus.Service, t *tempo.Service, td *testdatasource.Service, pg *postgres.Service, my *mysql.Service,
-	ms *mssql.Service, graf *grafanads.Service, pyroscope *pyroscope.Service, parca *parca.Service, zipkin *zipkin.Service) *Registry {
+	ms *mssql.Service, sl *sqlite.Service, graf *grafanads.Service, pyroscope *pyroscope.Service, parca *parca.Service, zipkin *zipkin.Service) *Registry {
 	// Non-optimal global solution to replace plugin SDK default tracer for core plugins.
 	sdktracing.InitDefaultTracer(tracer)
 
@@ -114,6 +116,7 @@ func ProvideCoreRegistry(tracer tracing.Tracer, am *azuremonitor.Service, cw *cl
 		PostgreSQL:      asBackendPlugin(pg),
 		MySQL:           asBackendPlugin(my),
 		MSSQL:           asBackendPlugin(ms),
+		SQLite:          asBackendPlugin(sl),
 		Grafana:         asBackendPlugin(graf),
 		Pyroscope:       asBackendPlugin(pyroscope),
 		Parca:           asBackendPlugin(parca),
@@ -199,7 +202,7 @@ func (l *logWrapper) FromContext(ctx context.Context) sdklog.Logger {
 var ErrCorePluginNotFound = errors.New("core plugin not found")
 
 // NewPlugin factory for creating and initializing a single core plugin.
-// Note: cfg only needed for mssql connection pooling defaults.
+// Note: cfg only needed for mssql connection pooling defaults, the sqlite allowed paths and the testdata fixtures directory.
 func NewPlugin(pluginID string, cfg *setting.Cfg, httpClientProvider *httpclient.Provider, tracer tracing.Tracer, features featuremgmt.FeatureToggles) (*plugins.Plugin, error) {
 	jsonData := plugins.JSONData{
 		ID:       pluginID,
@@ -211,7 +214,7 @@ func NewPlugin(pluginID string, cfg *setting.Cfg, httpClientProvider *httpclient
 	case TestData, TestDataAlias:
 		jsonData.ID = TestData
 		jsonData.AliasIDs = append(jsonData.AliasIDs, TestDataAlias)
-		svc = testdatasource.ProvideService()
+		svc = testdatasource.ProvideService(cfg)
 	case CloudWatch:
 		svc = cloudwatch.ProvideService(httpClientProvider).Executor
 	case CloudMonitoring:
@@ -238,6 +241,8 @@ func NewPlugin(pluginID string, cfg *setting.Cfg, httpClientProvider *httpclient
 		svc = mysql.ProvideService()
 	case MSSQL:
 		svc = mssql.ProvideService(cfg)
+	case SQLite:
+		svc = sqlite.ProvideService(cfg)
 	case Pyroscope:
 		svc = pyroscope.ProvideService(httpClientProvider)
 	case Parca:
@@ -0,0 +1,315 @@
+package querydata
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/grafana/grafana/pkg/promlib/client"
+	"github.com/grafana/grafana/pkg/promlib/models"
+)
+
+const (
+	defaultIncrementalQueryOverlapWindow = 10 * time.Minute
+	// maxCachedSamples bounds the number of samples kept in the result cache of a single data source
+	maxCachedSamples = 2_000_000
+)
+
+// resultCache keeps the most recent range query results of a data source, so that a refresh
+// of the same query only needs to fetch the samples that were added since. Entries are
+// evicted in least recently used order once the cache holds more than maxSamples samples.
+type resultCache struct {
+	mu         sync.Mutex
+	maxSamples int
+	samples    int
+	lru        *list.List
+	entries    map[string]*list.Element
+}
+
+type cachedResult struct {
+	key     string
+	start   time.Time
+	end     time.Time
+	frames  data.Frames
+	samples int
+}
+
+func newResultCache(maxSamples int) *resultCache {
+	return &resultCache{
+		maxSamples: maxSamples,
+		lru:        list.New(),
+		entries:    map[string]*list.Element{},
+	}
+}
+
+func (c *resultCache) get(key string) (*cachedResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(el)
+	return el.Value.(*cachedResult), true
+}
+
+func (c *resultCache) set(key string, start, end time.Time, frames data.Frames) {
+	entry := &cachedResult{key: key, start: start, end: end, frames: copyFrames(frames)}
+	for _, frame := range entry.frames {
+		entry.samples += frame.Rows()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.removeLocked(key)
+	if entry.samples > c.maxSamples {
+		return
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	c.samples += entry.samples
+	for c.samples > c.maxSamples {
+		c.removeLocked(c.lru.Back().Value.(*cachedResult).key)
+	}
+}
+
+func (c *resultCache) delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeLocked(key)
+}
+
+func (c *resultCache) removeLocked(key string) {
+	el, ok := c.entries[key]
+	if !ok {
+		return
+	}
+	c.lru.Remove(el)
+	delete(c.entries, key)
+	c.samples -= el.Value.(*cachedResult).samples
+}
+
+func resultCacheKey(q *models.Query) string {
+	return fmt.Sprintf("%s\x00%s\x00%d\x00%s", q.Expr, q.Step, q.UtcOffsetSec, q.LegendFormat)
+}
+
+// canQueryIncrementally reports whether results of the query can be reused for a later time
+// range. Queries with `@` or `offset` modifiers evaluate data relative to a fixed point or to
+// a shifted range, so the cached samples would not line up with the new ones.
+func canQueryIncrementally(q *models.Query) bool {
+	expr, err := parser.ParseExpr(q.Expr)
+	if err != nil {
+		return false
+	}
+
+	incremental := true
+	parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+		switch n := node.(type) {
+		case *parser.VectorSelector:
+			if n.OriginalOffset != 0 || n.Timestamp != nil || n.StartOrEnd != 0 {
+				incremental = false
+			}
+		case *parser.SubqueryExpr:
+			if n.OriginalOffset != 0 || n.Timestamp != nil || n.StartOrEnd != 0 {
+				incremental = false
+			}
+		}
+		return nil
+	})
+	return incremental
+}
+
+// incrementalRangeQuery runs a range query reusing the cached result of a previous run of the
+// same query. Only the part of the range after the cached result, extended backwards by the
+// overlap window, is requested; the cached samples before it are stitched in front.
+func (s *QueryData) incrementalRangeQuery(ctx context.Context, c *client.Client, q *models.Query, enablePrometheusDataplaneFlag bool) backend.DataResponse {
+	if !canQueryIncrementally(q) {
+		return s.rangeQuery(ctx, c, q, enablePrometheusDataplaneFlag)
+	}
+
+	key := resultCacheKey(q)
+	tr := q.TimeRange()
+
+	var cached *cachedResult
+	fetchQuery := q
+	if entry, ok := s.resultCache.get(key); ok && !entry.start.After(tr.Start) && !entry.end.Before(tr.Start) && !entry.end.After(tr.End) {
+		// align the tail to the step of the full query so the samples share the same timestamps
+		tailStart := models.AlignTimeRange(entry.end.Add(-s.incrementalOverlap), q.Step, q.UtcOffsetSec)
+		if tailStart.After(tr.Start) {
+			tail := *q
+			tail.Start = tailStart
+			fetchQuery = &tail
+			cached = entry
+		}
+	}
+
+	res := s.rangeQuery(ctx, c, fetchQuery, enablePrometheusDataplaneFlag)
+	if res.Error != nil {
+		return res
+	}
+
+	if !cacheableFrames(res.Frames) {
+		s.resultCache.delete(key)
+		if cached != nil {
+			return s.rangeQuery(ctx, c, q, enablePrometheusDataplaneFlag)
+		}
+		return res
+	}
+
+	if cached != nil {
+		s.log.FromContext(ctx).Debug("Reusing cached range query result", "query", q.Expr, "cachedStart", cached.start, "cachedEnd", cached.end, "fetchStart", fetchQuery.Start)
+		res.Frames = stitchFrames(cached.frames, res.Frames, tr.Start, fetchQuery.TimeRange().Start)
+	}
+
+	s.resultCache.set(key, tr.Start, tr.End, res.Frames)
+	return res
+}
+
+// cacheableFrames reports whether the frames are plain time series that can be stitched together.
+func cacheableFrames(frames data.Frames) bool {
+	for _, frame := range frames {
+		if len(frame.Fields) == 0 {
+			continue
+		}
+		if len(frame.Fields) != 2 || frame.Fields[0].Type() != data.FieldTypeTime || frame.Fields[1].Type() != data.FieldTypeFloat64 {
+			return false
+		}
+	}
+	return true
+}
+
+func seriesKey(frame *data.Frame) string {
+	return frame.Name + frame.Fields[1].Labels.String()
+}
+
+// stitchFrames prepends the cached samples in [from, tailStart) to the matching series of the
+// freshly queried tail. Series that only exist in the cache are kept for the cached part of the range.
+func stitchFrames(cached, tail data.Frames, from, tailStart time.Time) data.Frames {
+	inRange := func(t time.Time) bool {
+		return !t.Before(from) && t.Before(tailStart)
+	}
+
+	cachedSeries := map[string]*data.Frame{}
+	for _, frame := range cached {
+		if len(frame.Fields) == 2 {
+			cachedSeries[seriesKey(frame)] = frame
+		}
+	}
+
+	result := make(data.Frames, 0, len(tail))
+	var executedQueryString string
+	for _, frame := range tail {
+		if frame.Meta != nil && frame.Meta.ExecutedQueryString != "" {
+			executedQueryString = frame.Meta.ExecutedQueryString
+		}
+		if len(frame.Fields) != 2 {
+			continue
+		}
+		key := seriesKey(frame)
+		if old, ok := cachedSeries[key]; ok {
+			delete(cachedSeries, key)
+			frame.Fields = prependRows(old, inRange, frame.Fields)
+		}
+		result = append(result, frame)
+	}
+
+	for _, old := range cached {
+		if len(old.Fields) != 2 {
+			continue
+		}
+		if _, ok := cachedSeries[seriesKey(old)]; !ok {
+			continue
+		}
+		frame := &data.Frame{Name: old.Name, RefID: old.RefID, Meta: copyMeta(old.Meta)}
+		if frame.Meta != nil {
+			frame.Meta.ExecutedQueryString = ""
+		}
+		frame.Fields = prependRows(old, inRange, nil)
+		if frame.Rows() > 0 {
+			result = append(result, frame)
+		}
+	}
+
+	if len(result) == 0 {
+		return tail
+	}
+
+	if executedQueryString != "" {
+		if result[0].Meta == nil {
+			result[0].Meta = &data.FrameMeta{}
+		}
+		result[0].Meta.ExecutedQueryString = executedQueryString
+	}
+	return result
+}
+
+// prependRows returns fields holding the rows of src whose time passes keep, followed by the
+// rows of tail. The fields take their name, labels and config from tail if given, else from src.
+func prependRows(src *data.Frame, keep func(time.Time) bool, tail []*data.Field) []*data.Field {
+	template := tail
+	if template == nil {
+		template = src.Fields
+	}
+	out := make([]*data.Field, len(template))
+	for i, f := range template {
+		out[i] = data.NewFieldFromFieldType(f.Type(), 0)
+		out[i].Name = f.Name
+		out[i].Labels = f.Labels
+		out[i].Config = f.Config
+	}
+
+	for row := 0; row < src.Rows(); row++ {
+		if !keep(src.Fields[0].At(row).(time.Time)) {
+			continue
+		}
+		for i := range out {
+			out[i].Append(src.Fields[i].CopyAt(row))
+		}
+	}
+	if tail != nil {
+		for row := 0; row < tail[0].Len(); row++ {
+			for i := range out {
+				out[i].Append(tail[i].At(row))
+			}
+		}
+	}
+	return out
+}
+
+func copyFrames(frames data.Frames) data.Frames {
+	out := make(data.Frames, len(frames))
+	for i, frame := range frames {
+		out[i] = copyFrame(frame)
+	}
+	return out
+}
+
+func copyFrame(frame *data.Frame) *data.Frame {
+	out := &data.Frame{Name: frame.Name, RefID: frame.RefID, Meta: copyMeta(frame.Meta)}
+	for _, f := range frame.Fields {
+		field := data.NewFieldFromFieldType(f.Type(), f.Len())
+		field.Name = f.Name
+		field.Labels = f.Labels.Copy()
+		field.Config = f.Config
+		for row := 0; row < f.Len(); row++ {
+			field.Set(row, f.CopyAt(row))
+		}
+		out.Fields = append(out.Fields, field)
+	}
+	return out
+}
+
+func copyMeta(meta *data.FrameMeta) *data.FrameMeta {
+	if meta == nil {
+		return nil
+	}
+	m := *meta
+	return &m
+}
@@ -0,0 +1,185 @@
+package querydata
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/promlib/models"
+)
+
+func TestCanQueryIncrementally(t *testing.T) {
+	tests := []struct {
+		expr        string
+		incremental bool
+	}{
+		{expr: `rate(http_requests_total{job="api"}[5m])`, incremental: true},
+		{expr: `max_over_time(up[1h:1m])`, incremental: true},
+		{expr: `up offset 1h`, incremental: false},
+		{expr: `rate(http_requests_total[5m] offset 1d)`, incremental: false},
+		{expr: `up @ 1700000000`, incremental: false},
+		{expr: `rate(http_requests_total[5m] @ end())`, incremental: false},
+		{expr: `max_over_time(up[1h:1m] offset 1h)`, incremental: false},
+		{expr: `up{`, incremental: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			require.Equal(t, tt.incremental, canQueryIncrementally(&models.Query{Expr: tt.expr}))
+		})
+	}
+}
+
+func TestResultCacheEviction(t *testing.T) {
+	series := func(rows int) data.Frames {
+		times := make([]time.Time, rows)
+		values := make([]float64, rows)
+		return data.Frames{data.NewFrame("", data.NewField("Time", nil, times), data.NewField("Value", nil, values))}
+	}
+
+	cache := newResultCache(10)
+	cache.set("a", time.Time{}, time.Time{}, series(4))
+	cache.set("b", time.Time{}, time.Time{}, series(4))
+	_, ok := cache.get("a")
+	require.True(t, ok)
+
+	cache.set("c", time.Time{}, time.Time{}, series(4))
+	_, ok = cache.get("b")
+	require.False(t, ok, "least recently used entry should be evicted")
+	_, ok = cache.get("a")
+	require.True(t, ok)
+
+	cache.set("d", time.Time{}, time.Time{}, series(11))
+	_, ok = cache.get("d")
+	require.False(t, ok, "results larger than the cache should not be stored")
+	require.Equal(t, 8, cache.samples)
+}
+
+// seriesRoundTripper answers range queries with a sample per step for every series, recording
+// the requested start times.
+type seriesRoundTripper struct {
+	series []string
+	starts []time.Time
+}
+
+func (rt *seriesRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.ParseForm(); err != nil {
+		return nil, err
+	}
+	parse := func(name string) float64 {
+		v, _ := strconv.ParseFloat(req.Form.Get(name), 64)
+		return v
+	}
+	start, end, step := parse("start"), parse("end"), parse("step")
+	rt.starts = append(rt.starts, time.Unix(int64(start), 0).UTC())
+
+	result := make([]string, 0, len(rt.series))
+	for _, job := range rt.series {
+		var values []string
+		for ts := start; ts <= end; ts += step {
+			values = append(values, fmt.Sprintf(`[%v,"%v"]`, ts, ts))
+		}
+		result = append(result, fmt.Sprintf(`{"metric":{"__name__":"up","job":%q},"values":[%s]}`, job, strings.Join(values, ",")))
+	}
+	body := fmt.Sprintf(`{"status":"success","data":{"resultType":"matrix","result":[%s]}}`, strings.Join(result, ","))
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+	}, nil
+}
+
+func TestIncrementalRangeQuery(t *testing.T) {
+	newQueryData := func(t *testing.T, rt http.RoundTripper, jsonData string) *QueryData {
+		t.Helper()
+		qd, err := New(&http.Client{Transport: rt}, backend.DataSourceInstanceSettings{
+			URL:      "http://localhost:9090",
+			JSONData: json.RawMessage(jsonData),
+		}, log.New())
+		require.NoError(t, err)
+		return qd
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	query := func(from, to time.Time) *backend.QueryDataRequest {
+		return &backend.QueryDataRequest{Queries: []backend.DataQuery{{
+			RefID:     "A",
+			JSON:      []byte(`{"expr":"up","range":true,"interval":"1m"}`),
+			Interval:  time.Minute,
+			TimeRange: backend.TimeRange{From: from, To: to},
+		}}}
+	}
+	run := func(t *testing.T, qd *QueryData, req *backend.QueryDataRequest) data.Frames {
+		t.Helper()
+		res, err := qd.Execute(context.Background(), req)
+		require.NoError(t, err)
+		require.NoError(t, res.Responses["A"].Error)
+		return res.Responses["A"].Frames
+	}
+
+	t.Run("only queries the tail and the overlap window on refresh", func(t *testing.T) {
+		rt := &seriesRoundTripper{series: []string{"a", "b"}}
+		qd := newQueryData(t, rt, `{"incrementalQuerying":true,"incrementalQueryOverlapWindow":"5m"}`)
+
+		run(t, qd, query(start, start.Add(time.Hour)))
+		frames := run(t, qd, query(start.Add(2*time.Minute), start.Add(time.Hour+2*time.Minute)))
+		require.Equal(t, []time.Time{start, start.Add(55 * time.Minute)}, rt.starts)
+
+		full := run(t, newQueryData(t, &seriesRoundTripper{series: []string{"a", "b"}}, `{}`), query(start.Add(2*time.Minute), start.Add(time.Hour+2*time.Minute)))
+		require.Len(t, frames, len(full))
+		for i := range full {
+			require.Equal(t, full[i].Fields[1].Labels, frames[i].Fields[1].Labels)
+			require.Equal(t, full[i].Rows(), frames[i].Rows())
+			for row := 0; row < full[i].Rows(); row++ {
+				require.Equal(t, full[i].Fields[0].At(row), frames[i].Fields[0].At(row))
+				require.Equal(t, full[i].Fields[1].At(row), frames[i].Fields[1].At(row))
+			}
+		}
+	})
+
+	t.Run("keeps series that are missing from the tail", func(t *testing.T) {
+		rt := &seriesRoundTripper{series: []string{"a", "b"}}
+		qd := newQueryData(t, rt, `{"incrementalQuerying":true}`)
+
+		run(t, qd, query(start, start.Add(time.Hour)))
+		rt.series = []string{"a"}
+		frames := run(t, qd, query(start.Add(time.Minute), start.Add(time.Hour+time.Minute)))
+		require.Len(t, frames, 2)
+		require.Equal(t, "b", frames[1].Fields[1].Labels["job"])
+		require.Equal(t, start.Add(49*time.Minute), frames[1].Fields[0].At(frames[1].Rows()-1))
+	})
+
+	t.Run("does not reuse results for queries with offset modifiers", func(t *testing.T) {
+		rt := &seriesRoundTripper{series: []string{"a"}}
+		qd := newQueryData(t, rt, `{"incrementalQuerying":true}`)
+		req := query(start, start.Add(time.Hour))
+		req.Queries[0].JSON = []byte(`{"expr":"up offset 1h","range":true,"interval":"1m"}`)
+
+		run(t, qd, req)
+		req.Queries[0].TimeRange = backend.TimeRange{From: start.Add(time.Minute), To: start.Add(time.Hour + time.Minute)}
+		run(t, qd, req)
+		require.Equal(t, []time.Time{start, start.Add(time.Minute)}, rt.starts)
+	})
+
+	t.Run("does not reuse results when the user identity is forwarded", func(t *testing.T) {
+		rt := &seriesRoundTripper{series: []string{"a"}}
+		qd := newQueryData(t, rt, `{"incrementalQuerying":true}`)
+
+		for _, from := range []time.Time{start, start.Add(time.Minute)} {
+			req := query(from, from.Add(time.Hour))
+			req.SetHTTPHeader(backend.OAuthIdentityTokenHeaderName, "Bearer token")
+			run(t, qd, req)
+		}
+		require.Equal(t, []time.Time{start, start.Add(time.Minute)}, rt.starts)
+	})
+}
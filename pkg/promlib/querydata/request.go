@@ -11,6 +11,7 @@ import (
 
 	"github.com/grafana/dskit/concurrency"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/tracing"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
@@ -45,6 +46,9 @@ type QueryData struct {
 	URL                string
 	TimeInterval       string
 	exemplarSampler    func() exemplar.Sampler
+	// resultCache is only set when incremental querying is enabled for the data source
+	resultCache        *resultCache
+	incrementalOverlap time.Duration
 }
 
 func New(
@@ -73,6 +77,27 @@ func New(
 
 	promClient := client.NewClient(httpClient, httpMethod, settings.URL, queryTimeout)
 
+	incrementalQuerying, err := maputil.GetBoolOptional(jsonData, "incrementalQuerying")
+	if err != nil {
+		return nil, err
+	}
+
+	var cache *resultCache
+	incrementalOverlap := defaultIncrementalQueryOverlapWindow
+	if incrementalQuerying {
+		cache = newResultCache(maxCachedSamples)
+		overlapWindow, err := maputil.GetStringOptional(jsonData, "incrementalQueryOverlapWindow")
+		if err != nil {
+			return nil, err
+		}
+		if overlapWindow != "" {
+			incrementalOverlap, err = gtime.ParseIntervalStringToTimeDuration(overlapWindow)
+			if err != nil {
+				return nil, fmt.Errorf("invalid incremental query overlap window: %w", err)
+			}
+		}
+	}
+
 	// standard deviation sampler is the default for backwards compatibility
 	exemplarSampler := exemplar.NewStandardDeviationSampler
 
@@ -85,6 +110,8 @@ func New(
 		ID:                 settings.ID,
 		URL:                settings.URL,
 		exemplarSampler:    exemplarSampler,
+		resultCache:        cache,
+		incrementalOverlap: incrementalOverlap,
 	}, nil
 }
 
@@ -101,6 +128,9 @@ func (s *QueryData) Execute(ctx context.Context, req *backend.QueryDataRequest)
 		hasPromQLScopeFeatureFlag         = cfg.FeatureToggles().IsEnabled("promQLScope")
 		hasPrometheusDataplaneFeatureFlag = cfg.FeatureToggles().IsEnabled("prometheusDataplane")
 		hasPrometheusRunQueriesInParallel = cfg.FeatureToggles().IsEnabled("prometheusRunQueriesInParallel")
+		// results are shared between everyone using the data source, so they are not reused for
+		// requests forwarding the identity of the user or for alert rules
+		useResultCache = s.resultCache != nil && !fromAlert && req.GetHTTPHeader(backend.OAuthIdentityTokenHeaderName) == "" && req.GetHTTPHeader("Cookie") == ""
 	)
 
 	if hasPrometheusRunQueriesInParallel {
@@ -116,7 +146,7 @@ func (s *QueryData) Execute(ctx context.Context, req *backend.QueryDataRequest)
 
 		_ = concurrency.ForEachJob(ctx, len(req.Queries), concurrentQueryCount, func(ctx context.Context, idx int) error {
 			query := req.Queries[idx]
-			r := s.handleQuery(ctx, query, fromAlert, hasPromQLScopeFeatureFlag, hasPrometheusDataplaneFeatureFlag, true)
+			r := s.handleQuery(ctx, query, fromAlert, hasPromQLScopeFeatureFlag, hasPrometheusDataplaneFeatureFlag, true, useResultCache)
 			if r != nil {
 				m.Lock()
 				result.Responses[query.RefID] = *r
@@ -126,7 +156,7 @@ func (s *QueryData) Execute(ctx context.Context, req *backend.QueryDataRequest)
 		})
 	} else {
 		for _, q := range req.Queries {
-			r := s.handleQuery(ctx, q, fromAlert, hasPromQLScopeFeatureFlag, hasPrometheusDataplaneFeatureFlag, false)
+			r := s.handleQuery(ctx, q, fromAlert, hasPromQLScopeFeatureFlag, hasPrometheusDataplaneFeatureFlag, false, useResultCache)
 			if r != nil {
 				result.Responses[q.RefID] = *r
 			}
@@ -137,7 +167,7 @@ func (s *QueryData) Execute(ctx context.Context, req *backend.QueryDataRequest)
 }
 
 func (s *QueryData) handleQuery(ctx context.Context, bq backend.DataQuery, fromAlert,
-	hasPromQLScopeFeatureFlag, hasPrometheusDataplaneFeatureFlag, hasPrometheusRunQueriesInParallel bool) *backend.DataResponse {
+	hasPromQLScopeFeatureFlag, hasPrometheusDataplaneFeatureFlag, hasPrometheusRunQueriesInParallel, useResultCache bool) *backend.DataResponse {
 	traceCtx, span := s.tracer.Start(ctx, "datasource.prometheus")
 	defer span.End()
 	query, err := models.Parse(span, bq, s.TimeInterval, s.intervalCalculator, fromAlert, hasPromQLScopeFeatureFlag)
@@ -147,7 +177,7 @@ func (s *QueryData) handleQuery(ctx context.Context, bq backend.DataQuery, fromA
 		}
 	}
 
-	r := s.fetch(traceCtx, s.client, query, hasPrometheusDataplaneFeatureFlag, hasPrometheusRunQueriesInParallel)
+	r := s.fetch(traceCtx, s.client, query, hasPrometheusDataplaneFeatureFlag, hasPrometheusRunQueriesInParallel, useResultCache)
 	if r == nil {
 		s.log.FromContext(ctx).Debug("Received nil response from runQuery", "query", query.Expr)
 	}
@@ -155,7 +185,7 @@ func (s *QueryData) handleQuery(ctx context.Context, bq backend.DataQuery, fromA
 }
 
 func (s *QueryData) fetch(traceCtx context.Context, client *client.Client, q *models.Query,
-	enablePrometheusDataplane, hasPrometheusRunQueriesInParallel bool) *backend.DataResponse {
+	enablePrometheusDataplane, hasPrometheusRunQueriesInParallel, useResultCache bool) *backend.DataResponse {
 	logger := s.log.FromContext(traceCtx)
 	logger.Debug("Sending query", "start", q.Start, "end", q.End, "step", q.Step, "query", q.Expr /*, "queryTimeout", s.QueryTimeout*/)
 
@@ -185,18 +215,23 @@ func (s *QueryData) fetch(traceCtx context.Context, client *client.Client, q *mo
 		}
 	}
 
+	rangeQuery := s.rangeQuery
+	if useResultCache {
+		rangeQuery = s.incrementalRangeQuery
+	}
+
 	if q.RangeQuery {
 		if hasPrometheusRunQueriesInParallel {
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
-				res := s.rangeQuery(traceCtx, client, q, enablePrometheusDataplane)
+				res := rangeQuery(traceCtx, client, q, enablePrometheusDataplane)
 				m.Lock()
 				addDataResponse(&res, dr)
 				m.Unlock()
 			}()
 		} else {
-			res := s.rangeQuery(traceCtx, client, q, enablePrometheusDataplane)
+			res := rangeQuery(traceCtx, client, q, enablePrometheusDataplane)
 			addDataResponse(&res, dr)
 		}
 	}
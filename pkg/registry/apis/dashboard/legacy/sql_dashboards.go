@@ -21,6 +21,7 @@ import (
 	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/services/apiserver/endpoints/request"
 	gapiutil "github.com/grafana/grafana/pkg/services/apiserver/utils"
+	"github.com/grafana/grafana/pkg/services/credentialscan"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/provisioning"
 	"github.com/grafana/grafana/pkg/storage/legacysql"
@@ -53,8 +54,9 @@ type dashboardSqlAccess struct {
 	provisioning provisioning.ProvisioningService
 
 	// Use for writing (not reading)
-	dashStore  dashboards.Store
-	softDelete bool
+	dashStore      dashboards.Store
+	credentialScan credentialscan.Service
+	softDelete     bool
 
 	// Typically one... the server wrapper
 	subscribers []chan *resource.WrittenEvent
@@ -65,14 +67,16 @@ func NewDashboardAccess(sql legacysql.LegacyDatabaseProvider,
 	namespacer request.NamespaceMapper,
 	dashStore dashboards.Store,
 	provisioning provisioning.ProvisioningService,
+	credentialScan credentialscan.Service,
 	softDelete bool,
 ) DashboardAccess {
 	return &dashboardSqlAccess{
-		sql:          sql,
-		namespacer:   namespacer,
-		dashStore:    dashStore,
-		provisioning: provisioning,
-		softDelete:   softDelete,
+		sql:            sql,
+		namespacer:     namespacer,
+		dashStore:      dashStore,
+		provisioning:   provisioning,
+		credentialScan: credentialScan,
+		softDelete:     softDelete,
 	}
 }
 
@@ -346,6 +350,9 @@ func (a *dashboardSqlAccess) DeleteDashboard(ctx context.Context, orgId int64, u
 	if err != nil {
 		return nil, false, err
 	}
+	if a.credentialScan != nil {
+		a.credentialScan.DeleteResource(ctx, orgId, credentialscan.KindDashboard, uid)
+	}
 	return dash, true, nil
 }
 
@@ -390,6 +397,18 @@ func (a *dashboardSqlAccess) SaveDashboard(ctx context.Context, orgId int64, das
 	if err != nil {
 		return nil, false, err
 	}
+	if a.credentialScan != nil {
+		err = a.credentialScan.CheckResource(ctx, credentialscan.Resource{
+			OrgID:   orgId,
+			Kind:    credentialscan.KindDashboard,
+			UID:     dash.Name,
+			Name:    dash.Spec.GetNestedString("title"),
+			Content: dash.Spec.UnstructuredContent(),
+		})
+		if err != nil {
+			return nil, false, err
+		}
+	}
 	out, err := a.dashStore.SaveDashboard(ctx, dashboards.SaveDashboardCommand{
 		OrgID:     orgId,
 		Dashboard: simplejson.NewFromAny(dash.Spec.UnstructuredContent()),
@@ -400,6 +419,15 @@ func (a *dashboardSqlAccess) SaveDashboard(ctx context.Context, orgId int64, das
 	if err != nil {
 		return nil, false, err
 	}
+	if a.credentialScan != nil {
+		a.credentialScan.ScanResource(ctx, credentialscan.Resource{
+			OrgID:   orgId,
+			Kind:    credentialscan.KindDashboard,
+			UID:     out.UID,
+			Name:    out.Title,
+			Content: out.Data,
+		})
+	}
 	if out != nil {
 		created = (out.Created.Unix() == out.Updated.Unix()) // and now?
 	}
@@ -20,6 +20,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/apiserver/builder"
 	"github.com/grafana/grafana/pkg/services/apiserver/endpoints/request"
+	"github.com/grafana/grafana/pkg/services/credentialscan"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/provisioning"
@@ -52,6 +53,7 @@ func RegisterAPIService(cfg *setting.Cfg, features featuremgmt.FeatureToggles,
 	accessControl accesscontrol.AccessControl,
 	provisioning provisioning.ProvisioningService,
 	dashStore dashboards.Store,
+	credentialScan credentialscan.Service,
 	reg prometheus.Registerer,
 	sql db.DB,
 	tracing *tracing.TracingService,
@@ -73,7 +75,7 @@ func RegisterAPIService(cfg *setting.Cfg, features featuremgmt.FeatureToggles,
 
 		legacy: &dashboardStorage{
 			resource:       dashboard.DashboardResourceInfo,
-			access:         legacy.NewDashboardAccess(dbp, namespacer, dashStore, provisioning, softDelete),
+			access:         legacy.NewDashboardAccess(dbp, namespacer, dashStore, provisioning, credentialScan, softDelete),
 			tableConverter: dashboard.DashboardResourceInfo.TableConverter(),
 			features:       features,
 		},
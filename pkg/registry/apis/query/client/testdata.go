@@ -38,7 +38,7 @@ func (d *testdataDummy) QueryData(ctx context.Context, req data.QueryDataRequest
 	}
 
 	qdr := &backend.QueryDataRequest{Queries: queries}
-	rsp, err := testdata.ProvideService().QueryData(ctx, qdr)
+	rsp, err := testdata.NewService("").QueryData(ctx, qdr)
 	return query.GetResponseCode(rsp), rsp, err
 }
 
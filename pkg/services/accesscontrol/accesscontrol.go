@@ -3,6 +3,7 @@ package accesscontrol
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -332,6 +333,60 @@ func Reduce(ps []Permission) map[string][]string {
 	return reduced
 }
 
+// ScopeCoverFunc reports whether the scopes granted for an action cover the given scope in ways that can't be
+// told from the text of the scopes, for instance a dashboard inside a granted folder.
+type ScopeCoverFunc func(action string, granted []string, scope string) bool
+
+// Intersect restricts permissions grouped by action to the ones also granted by restriction.
+// When one scope covers the other, the narrower of the two is kept. Scopes are first compared by text and then with
+// covers, if set. A restriction without a scope keeps every scope granted for the action.
+func Intersect(permissions map[string][]string, restriction map[string][]string, covers ScopeCoverFunc) map[string][]string {
+	intersection := make(map[string][]string)
+	for action, restrictedScopes := range restriction {
+		scopes, ok := permissions[action]
+		if !ok {
+			continue
+		}
+
+		seen := make(map[string]bool)
+		keep := func(scope string) {
+			if seen[scope] {
+				return
+			}
+			seen[scope] = true
+			intersection[action] = append(intersection[action], scope)
+		}
+
+		if slices.Contains(restrictedScopes, "") {
+			// The restriction covers every scope of the action
+			for _, scope := range scopes {
+				keep(scope)
+			}
+			continue
+		}
+
+		for _, restricted := range restrictedScopes {
+			if coversScope(action, scopes, restricted, covers) {
+				keep(restricted)
+			}
+		}
+		for _, scope := range scopes {
+			// Unscoped permissions don't cover the restricted scopes
+			if scope != "" && coversScope(action, restrictedScopes, scope, covers) {
+				keep(scope)
+			}
+		}
+	}
+	return intersection
+}
+
+func coversScope(action string, granted []string, scope string, covers ScopeCoverFunc) bool {
+	if EvalPermission(action, scope).Evaluate(map[string][]string{action: granted}) {
+		return true
+	}
+	return covers != nil && covers(action, granted, scope)
+}
+
 func ValidateScope(scope string) bool {
 	prefix, last := scope[:len(scope)-1], scope[len(scope)-1]
 	// verify that last char is either ':' or '/' if last character of scope is '*'
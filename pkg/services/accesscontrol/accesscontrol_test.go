@@ -3,6 +3,7 @@ package accesscontrol
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -154,6 +155,93 @@ func TestGroupScopesByActionContext(t *testing.T) {
 	assert.EqualValues(t, expected, GroupScopesByActionContext(context.Background(), permissions))
 }
 
+func TestIntersect(t *testing.T) {
+	// inFolder covers the dashboards named after the folders they are in
+	inFolder := func(action string, granted []string, scope string) bool {
+		for _, g := range granted {
+			if folder, ok := strings.CutPrefix(g, "folders:uid:"); ok && scope == "dashboards:uid:in-folder-"+folder {
+				return true
+			}
+		}
+		return false
+	}
+
+	tests := []struct {
+		name        string
+		permissions map[string][]string
+		restriction map[string][]string
+		covers      ScopeCoverFunc
+		want        map[string][]string
+	}{
+		{
+			name:        "should drop actions missing from the restriction",
+			permissions: map[string][]string{"dashboards:read": {"dashboards:*"}, "dashboards:write": {"dashboards:*"}},
+			restriction: map[string][]string{"dashboards:read": {"dashboards:*"}},
+			want:        map[string][]string{"dashboards:read": {"dashboards:*"}},
+		},
+		{
+			name:        "should drop actions the permissions do not grant",
+			permissions: map[string][]string{"dashboards:read": {"dashboards:*"}},
+			restriction: map[string][]string{"datasources:query": {"datasources:*"}},
+			want:        map[string][]string{},
+		},
+		{
+			name:        "should narrow wildcard permissions to the restricted scope",
+			permissions: map[string][]string{"dashboards:read": {"dashboards:*"}},
+			restriction: map[string][]string{"dashboards:read": {"dashboards:uid:1", "dashboards:uid:2"}},
+			want:        map[string][]string{"dashboards:read": {"dashboards:uid:1", "dashboards:uid:2"}},
+		},
+		{
+			name:        "should keep narrower permissions under a wildcard restriction",
+			permissions: map[string][]string{"dashboards:read": {"dashboards:uid:1", "folders:uid:2"}},
+			restriction: map[string][]string{"dashboards:read": {"dashboards:*"}},
+			want:        map[string][]string{"dashboards:read": {"dashboards:uid:1"}},
+		},
+		{
+			name:        "should keep permission scopes when the restriction has no scope",
+			permissions: map[string][]string{"dashboards:read": {"dashboards:uid:1"}, "users:read": {""}},
+			restriction: map[string][]string{"dashboards:read": {""}, "users:read": {""}},
+			want:        map[string][]string{"dashboards:read": {"dashboards:uid:1"}, "users:read": {""}},
+		},
+		{
+			name:        "should drop unscoped permissions when the restriction has a scope",
+			permissions: map[string][]string{"dashboards:read": {"", "dashboards:uid:1"}},
+			restriction: map[string][]string{"dashboards:read": {"dashboards:uid:1"}},
+			want:        map[string][]string{"dashboards:read": {"dashboards:uid:1"}},
+		},
+		{
+			name:        "should drop scopes that do not overlap",
+			permissions: map[string][]string{"dashboards:read": {"dashboards:uid:1"}},
+			restriction: map[string][]string{"dashboards:read": {"dashboards:uid:2"}},
+			want:        map[string][]string{},
+		},
+		{
+			name:        "should keep restricted scopes covered through the cover function",
+			permissions: map[string][]string{"dashboards:read": {"folders:uid:1"}},
+			restriction: map[string][]string{"dashboards:read": {"dashboards:uid:in-folder-1", "dashboards:uid:in-folder-2"}},
+			covers:      inFolder,
+			want:        map[string][]string{"dashboards:read": {"dashboards:uid:in-folder-1"}},
+		},
+		{
+			name:        "should keep permission scopes covered through the cover function",
+			permissions: map[string][]string{"dashboards:read": {"dashboards:uid:in-folder-1", "dashboards:uid:in-folder-2"}},
+			restriction: map[string][]string{"dashboards:read": {"folders:uid:1"}},
+			covers:      inFolder,
+			want:        map[string][]string{"dashboards:read": {"dashboards:uid:in-folder-1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Intersect(tt.permissions, tt.restriction, tt.covers)
+			require.Len(t, got, len(tt.want))
+			for action, want := range tt.want {
+				require.ElementsMatch(t, want, got[action])
+			}
+		})
+	}
+}
+
 func BenchmarkGroupScopesByAction(b *testing.B) {
 	// create a big list of permissions with a bunch of duplicates
 	permissions := []Permission{}
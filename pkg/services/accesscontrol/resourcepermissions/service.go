@@ -16,6 +16,7 @@ import (
 	"github.com/grafana/grafana/pkg/plugins"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/accesscontrol/pluginutils"
+	"github.com/grafana/grafana/pkg/services/auditlog"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/licensing"
@@ -28,6 +29,9 @@ import (
 
 var _ pluginaccesscontrol.ActionSetRegistry = (ActionSetService)(nil)
 
+// auditPermissionRemoved is recorded in the audit log when a permission is removed from a user, team or basic role.
+const auditPermissionRemoved = "None"
+
 type Store interface {
 	// SetUserResourcePermission sets permission for managed user role on a resource
 	SetUserResourcePermission(
@@ -225,13 +229,18 @@ func (s *Service) SetUserPermission(ctx context.Context, orgID int64, user acces
 		return nil, err
 	}
 
-	return s.store.SetUserResourcePermission(ctx, orgID, user, SetResourcePermissionCommand{
+	p, err := s.store.SetUserResourcePermission(ctx, orgID, user, SetResourcePermissionCommand{
 		Actions:           actions,
 		Permission:        permission,
 		Resource:          s.options.Resource,
 		ResourceID:        resourceID,
 		ResourceAttribute: s.options.ResourceAttribute,
 	}, s.options.OnSetUser)
+	if err != nil {
+		return nil, err
+	}
+	s.recordPermissionChange(ctx, resourceID, accesscontrol.SetResourcePermissionCommand{UserID: user.ID, Permission: permission})
+	return p, nil
 }
 
 func (s *Service) SetTeamPermission(ctx context.Context, orgID, teamID int64, resourceID, permission string) (*accesscontrol.ResourcePermission, error) {
@@ -251,13 +260,18 @@ func (s *Service) SetTeamPermission(ctx context.Context, orgID, teamID int64, re
 		return nil, err
 	}
 
-	return s.store.SetTeamResourcePermission(ctx, orgID, teamID, SetResourcePermissionCommand{
+	p, err := s.store.SetTeamResourcePermission(ctx, orgID, teamID, SetResourcePermissionCommand{
 		Actions:           actions,
 		Permission:        permission,
 		Resource:          s.options.Resource,
 		ResourceID:        resourceID,
 		ResourceAttribute: s.options.ResourceAttribute,
 	}, s.options.OnSetTeam)
+	if err != nil {
+		return nil, err
+	}
+	s.recordPermissionChange(ctx, resourceID, accesscontrol.SetResourcePermissionCommand{TeamID: teamID, Permission: permission})
+	return p, nil
 }
 
 func (s *Service) SetBuiltInRolePermission(ctx context.Context, orgID int64, builtInRole, resourceID, permission string) (*accesscontrol.ResourcePermission, error) {
@@ -277,13 +291,18 @@ func (s *Service) SetBuiltInRolePermission(ctx context.Context, orgID int64, bui
 		return nil, err
 	}
 
-	return s.store.SetBuiltInResourcePermission(ctx, orgID, builtInRole, SetResourcePermissionCommand{
+	p, err := s.store.SetBuiltInResourcePermission(ctx, orgID, builtInRole, SetResourcePermissionCommand{
 		Actions:           actions,
 		Permission:        permission,
 		Resource:          s.options.Resource,
 		ResourceID:        resourceID,
 		ResourceAttribute: s.options.ResourceAttribute,
 	}, s.options.OnSetBuiltInRole)
+	if err != nil {
+		return nil, err
+	}
+	s.recordPermissionChange(ctx, resourceID, accesscontrol.SetResourcePermissionCommand{BuiltinRole: builtInRole, Permission: permission})
+	return p, nil
 }
 
 func (s *Service) SetPermissions(
@@ -332,11 +351,40 @@ func (s *Service) SetPermissions(
 		})
 	}
 
-	return s.store.SetResourcePermissions(ctx, orgID, dbCommands, ResourceHooks{
+	permissions, err := s.store.SetResourcePermissions(ctx, orgID, dbCommands, ResourceHooks{
 		User:        s.options.OnSetUser,
 		Team:        s.options.OnSetTeam,
 		BuiltInRole: s.options.OnSetBuiltInRole,
 	})
+	if err != nil {
+		return nil, err
+	}
+	for _, cmd := range commands {
+		s.recordPermissionChange(ctx, resourceID, cmd)
+	}
+	return permissions, nil
+}
+
+// recordPermissionChange records a permission set on a resource in the audit log, by user, team or basic role.
+func (s *Service) recordPermissionChange(ctx context.Context, resourceID string, cmd accesscontrol.SetResourcePermissionCommand) {
+	if !auditlog.Recording(ctx) {
+		return
+	}
+
+	assignee := "builtInRole:" + cmd.BuiltinRole
+	if cmd.UserID != 0 {
+		assignee = fmt.Sprintf("user:%d", cmd.UserID)
+	} else if cmd.TeamID != 0 {
+		assignee = fmt.Sprintf("team:%d", cmd.TeamID)
+	}
+
+	permission := cmd.Permission
+	if permission == "" {
+		permission = auditPermissionRemoved
+	}
+
+	auditlog.SetResource(ctx, s.options.Resource, resourceID)
+	auditlog.AddChange(ctx, "permissions."+assignee, nil, permission)
 }
 
 func (s *Service) MapActions(permission accesscontrol.ResourcePermission) string {
@@ -14,6 +14,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
 	"github.com/grafana/grafana/pkg/services/accesscontrol/actest"
+	"github.com/grafana/grafana/pkg/services/auditlog"
 	"github.com/grafana/grafana/pkg/services/authz/zanzana"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/licensing/licensingtest"
@@ -226,6 +227,37 @@ func TestService_SetPermissions(t *testing.T) {
 	}
 }
 
+func TestService_SetPermissionsAuditLog(t *testing.T) {
+	service, usrSvc, teamSvc := setupTestEnvironment(t, Options{
+		Resource:    "dashboards",
+		Assignments: Assignments{Users: true, Teams: true, BuiltInRoles: true},
+		PermissionsToActions: map[string][]string{
+			"View": {"dashboards:read"},
+		},
+	})
+	_, err := usrSvc.Create(context.Background(), &user.CreateUserCommand{Login: "user", OrgID: 1})
+	require.NoError(t, err)
+	_, err = teamSvc.CreateTeam(context.Background(), "team", "", 1)
+	require.NoError(t, err)
+
+	entry := &auditlog.Entry{}
+	ctx := auditlog.WithEntry(context.Background(), entry)
+	_, err = service.SetPermissions(ctx, 1, "dash", []accesscontrol.SetResourcePermissionCommand{
+		{UserID: 1, Permission: "View"},
+		{TeamID: 1, Permission: ""},
+		{BuiltinRole: "Editor", Permission: "View"},
+	}...)
+	require.NoError(t, err)
+
+	assert.Equal(t, "dashboards", entry.ResourceKind)
+	assert.Equal(t, "dash", entry.ResourceUID)
+	assert.Equal(t, []auditlog.Change{
+		{Path: "permissions.user:1", After: "View"},
+		{Path: "permissions.team:1", After: auditPermissionRemoved},
+		{Path: "permissions.builtInRole:Editor", After: "View"},
+	}, entry.Changes)
+}
+
 func TestService_RegisterActionSets(t *testing.T) {
 	type registerActionSetsTest struct {
 		desc               string
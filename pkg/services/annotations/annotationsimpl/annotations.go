@@ -2,6 +2,7 @@ package annotationsimpl
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/grafana/grafana/pkg/services/annotations/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/annotations/annotationsimpl/loki"
@@ -11,17 +12,19 @@ import (
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/infra/tracing"
 	"github.com/grafana/grafana/pkg/services/annotations"
+	"github.com/grafana/grafana/pkg/services/credentialscan"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/tag"
 	"github.com/grafana/grafana/pkg/setting"
 )
 
 type RepositoryImpl struct {
-	db       db.DB
-	authZ    *accesscontrol.AuthService
-	features featuremgmt.FeatureToggles
-	reader   readStore
-	writer   writeStore
+	db             db.DB
+	authZ          *accesscontrol.AuthService
+	features       featuremgmt.FeatureToggles
+	credentialScan credentialscan.Service
+	reader         readStore
+	writer         writeStore
 }
 
 func ProvideService(
@@ -31,6 +34,7 @@ func ProvideService(
 	tagService tag.Service,
 	tracer tracing.Tracer,
 	ruleStore *alertingStore.DBstore,
+	credentialScan credentialscan.Service,
 ) *RepositoryImpl {
 	l := log.New("annotations")
 	l.Debug("Initializing annotations service")
@@ -49,26 +53,111 @@ func ProvideService(
 	}
 
 	return &RepositoryImpl{
-		db:       db,
-		features: features,
-		authZ:    accesscontrol.NewAuthService(db, features),
-		reader:   read,
-		writer:   write,
+		db:             db,
+		features:       features,
+		authZ:          accesscontrol.NewAuthService(db, features),
+		credentialScan: credentialScan,
+		reader:         read,
+		writer:         write,
 	}
 }
 
 func (r *RepositoryImpl) Save(ctx context.Context, item *annotations.Item) error {
-	return r.writer.Add(ctx, item)
+	if err := r.checkForCredentials(ctx, item); err != nil {
+		return err
+	}
+	if err := r.writer.Add(ctx, item); err != nil {
+		return err
+	}
+	r.scanForCredentials(ctx, item)
+	return nil
 }
 
 // SaveMany inserts multiple annotations at once.
 // It does not return IDs associated with created annotations. If you need this functionality, use the single-item Save instead.
+// The annotations are saved in one transaction, so that none is saved if credentials are detected in one of them while
+// saves are blocked, or if one fails to save. Annotations with detected credentials are saved one by one, so that their
+// findings are recorded by ID.
 func (r *RepositoryImpl) SaveMany(ctx context.Context, items []annotations.Item) error {
-	return r.writer.AddMany(ctx, items)
+	if r.credentialScan == nil {
+		return r.writer.AddMany(ctx, items)
+	}
+
+	bulk := make([]annotations.Item, 0, len(items))
+	withCredentials := make([]*annotations.Item, 0)
+	for i := range items {
+		item := &items[i]
+		if err := r.checkForCredentials(ctx, item); err != nil {
+			return err
+		}
+		if isScanned(item) && r.credentialScan.ContainsCredentials(ctx, annotationResource(item)) {
+			withCredentials = append(withCredentials, item)
+		} else {
+			bulk = append(bulk, *item)
+		}
+	}
+
+	err := r.db.InTransaction(ctx, func(ctx context.Context) error {
+		if err := r.writer.AddMany(ctx, bulk); err != nil {
+			return err
+		}
+		for _, item := range withCredentials {
+			if err := r.writer.Add(ctx, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, item := range withCredentials {
+		r.scanForCredentials(ctx, item)
+	}
+	return nil
 }
 
 func (r *RepositoryImpl) Update(ctx context.Context, item *annotations.Item) error {
-	return r.writer.Update(ctx, item)
+	if err := r.checkForCredentials(ctx, item); err != nil {
+		return err
+	}
+	if err := r.writer.Update(ctx, item); err != nil {
+		return err
+	}
+	r.scanForCredentials(ctx, item)
+	return nil
+}
+
+// checkForCredentials checks the text of an annotation about to be saved for leaked credentials.
+func (r *RepositoryImpl) checkForCredentials(ctx context.Context, item *annotations.Item) error {
+	if r.credentialScan == nil || !isScanned(item) {
+		return nil
+	}
+	return r.credentialScan.CheckResource(ctx, annotationResource(item))
+}
+
+// scanForCredentials records the leaked credentials of a saved annotation, by annotation ID.
+func (r *RepositoryImpl) scanForCredentials(ctx context.Context, item *annotations.Item) {
+	if r.credentialScan == nil || item.ID == 0 || !isScanned(item) {
+		return
+	}
+	r.credentialScan.ScanResource(ctx, annotationResource(item))
+}
+
+// isScanned returns false for the annotations written by alerting, its state history and notification attempts.
+// Their text is generated from alert rules and notifications, and blocking them would lose alerting history.
+func isScanned(item *annotations.Item) bool {
+	return item.AlertID == 0 && item.Type != annotations.NotificationHistoryType
+}
+
+func annotationResource(item *annotations.Item) credentialscan.Resource {
+	resource := credentialscan.Resource{OrgID: item.OrgID, Kind: credentialscan.KindAnnotation, Content: item.Text}
+	if item.ID != 0 {
+		resource.UID = strconv.FormatInt(item.ID, 10)
+		resource.Name = resource.UID
+	}
+	return resource
 }
 
 func (r *RepositoryImpl) Find(ctx context.Context, query *annotations.ItemQuery) ([]*annotations.ItemDTO, error) {
@@ -119,7 +208,13 @@ func (r *RepositoryImpl) Find(ctx context.Context, query *annotations.ItemQuery)
 }
 
 func (r *RepositoryImpl) Delete(ctx context.Context, params *annotations.DeleteParams) error {
-	return r.writer.Delete(ctx, params)
+	if err := r.writer.Delete(ctx, params); err != nil {
+		return err
+	}
+	if r.credentialScan != nil && params.ID != 0 {
+		r.credentialScan.DeleteResource(ctx, params.OrgID, credentialscan.KindAnnotation, strconv.FormatInt(params.ID, 10))
+	}
+	return nil
 }
 
 func (r *RepositoryImpl) FindTags(ctx context.Context, query *annotations.TagsQuery) (annotations.FindTagsResult, error) {
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -315,6 +316,11 @@ func (r *xormRepositoryImpl) Get(ctx context.Context, query annotations.ItemQuer
 			sql.WriteString(` AND a.alert_id = 0`)
 		}
 
+		if !slices.Contains(query.Tags, annotations.NotificationHistoryTag) {
+			sql.WriteString(` AND a.type <> ?`)
+			params = append(params, annotations.NotificationHistoryType)
+		}
+
 		if len(query.Tags) > 0 {
 			keyValueFilters := []string{}
 
@@ -474,6 +480,9 @@ func (r *xormRepositoryImpl) GetTags(ctx context.Context, query annotations.Tags
 		sql.WriteString(` AND (` + tagKey + ` ` + r.db.GetDialect().LikeStr() + ` ? OR ` + tagValue + ` ` + r.db.GetDialect().LikeStr() + ` ?)`)
 		params = append(params, `%`+query.Tag+`%`, `%`+query.Tag+`%`)
 
+		sql.WriteString(` AND annotation.type <> ?`)
+		params = append(params, annotations.NotificationHistoryType)
+
 		sql.WriteString(` GROUP BY ` + tagKey + `,` + tagValue)
 		sql.WriteString(` ORDER BY ` + tagKey + `,` + tagValue)
 		sql.WriteString(` ` + r.db.GetDialect().Limit(query.Limit))
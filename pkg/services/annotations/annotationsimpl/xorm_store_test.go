@@ -313,6 +313,47 @@ func TestIntegrationAnnotations(t *testing.T) {
 			assert.Len(t, items, 2)
 		})
 
+		t.Run("Should only find notification history annotations when filtering by their tag", func(t *testing.T) {
+			notification := &annotations.Item{
+				OrgID: 1,
+				Type:  annotations.NotificationHistoryType,
+				Text:  "notification sent",
+				Epoch: 16,
+				Tags:  []string{annotations.NotificationHistoryTag, "receiver:on-call", "deploy"},
+			}
+			require.NoError(t, store.Add(context.Background(), notification))
+			t.Cleanup(func() {
+				require.NoError(t, store.Delete(context.Background(), &annotations.DeleteParams{OrgID: 1, ID: notification.ID}))
+			})
+
+			accRes := &annotation_ac.AccessResources{CanAccessOrgAnnotations: true}
+			items, err := store.Get(context.Background(), annotations.ItemQuery{
+				OrgID:        1,
+				From:         1,
+				To:           25,
+				Tags:         []string{"deploy"},
+				SignedInUser: testUser,
+			}, accRes)
+			require.NoError(t, err)
+			require.Len(t, items, 1)
+			assert.Equal(t, organizationAnnotation1.ID, items[0].ID)
+
+			items, err = store.Get(context.Background(), annotations.ItemQuery{
+				OrgID:        1,
+				From:         1,
+				To:           25,
+				Tags:         []string{annotations.NotificationHistoryTag, "receiver:on-call"},
+				SignedInUser: testUser,
+			}, accRes)
+			require.NoError(t, err)
+			require.Len(t, items, 1)
+			assert.Equal(t, notification.ID, items[0].ID)
+
+			tags, err := store.GetTags(context.Background(), annotations.TagsQuery{OrgID: 1, Tag: "on-call"})
+			require.NoError(t, err)
+			assert.Empty(t, tags.Tags)
+		})
+
 		t.Run("Should find one when all key value tag filters does match", func(t *testing.T) {
 			accRes := &annotation_ac.AccessResources{
 				Dashboards:               map[string]int64{"foo": 1},
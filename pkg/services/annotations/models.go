@@ -5,6 +5,14 @@ import (
 	"github.com/grafana/grafana/pkg/components/simplejson"
 )
 
+// NotificationHistoryTag is the tag of annotations that record the notification attempts of alerting.
+// They are only returned by queries that filter by this tag, and their tags are not listed by tag searches.
+const NotificationHistoryTag = "alerting_notification"
+
+// NotificationHistoryType is the type of annotations that record the notification attempts of alerting.
+// The type column is indexed, so that queries can exclude these annotations without looking up their tags.
+const NotificationHistoryType = "alerting_notification"
+
 type ItemQuery struct {
 	OrgID        int64    `json:"orgId"`
 	From         int64    `json:"from"`
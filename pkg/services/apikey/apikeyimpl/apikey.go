@@ -2,13 +2,17 @@ package apikeyimpl
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/grafana/grafana/pkg/infra/db"
 	"github.com/grafana/grafana/pkg/services/apikey"
+	"github.com/grafana/grafana/pkg/services/auditlog"
 	"github.com/grafana/grafana/pkg/services/quota"
 	"github.com/grafana/grafana/pkg/setting"
 )
 
+const auditKindAPIKeys = "apikeys"
+
 type Service struct {
 	store store
 }
@@ -53,10 +57,35 @@ func (s *Service) GetAPIKeyByHash(ctx context.Context, hash string) (*apikey.API
 	return s.store.GetAPIKeyByHash(ctx, hash)
 }
 func (s *Service) DeleteApiKey(ctx context.Context, cmd *apikey.DeleteCommand) error {
-	return s.store.DeleteApiKey(ctx, cmd)
+	var before *apikey.APIKey
+	if auditlog.Recording(ctx) {
+		// a missing key is reported by the delete itself
+		before, _ = s.store.GetApiKeyById(ctx, &apikey.GetByIDQuery{ApiKeyID: cmd.ID})
+	}
+	if err := s.store.DeleteApiKey(ctx, cmd); err != nil {
+		return err
+	}
+
+	if before != nil {
+		auditlog.SetResource(ctx, auditKindAPIKeys, strconv.FormatInt(before.ID, 10))
+		auditlog.AddChange(ctx, "", apikey.AuditState(before), nil)
+	}
+	return nil
 }
 func (s *Service) AddAPIKey(ctx context.Context, cmd *apikey.AddCommand) (res *apikey.APIKey, err error) {
-	return s.store.AddAPIKey(ctx, cmd)
+	key, err := s.store.AddAPIKey(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	// service account tokens are recorded as a change of their service account
+	if key.ServiceAccountId == nil {
+		auditlog.SetResource(ctx, auditKindAPIKeys, strconv.FormatInt(key.ID, 10))
+		auditlog.AddChange(ctx, "", nil, apikey.AuditState(key))
+	} else {
+		auditlog.AddChange(ctx, "tokens."+key.Name, nil, apikey.AuditState(key))
+	}
+	return key, nil
 }
 func (s *Service) UpdateAPIKeyLastUsedDate(ctx context.Context, tokenID int64) error {
 	return s.store.UpdateAPIKeyLastUsedDate(ctx, tokenID)
@@ -0,0 +1,66 @@
+package apikeyimpl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/apikey"
+	"github.com/grafana/grafana/pkg/services/auditlog"
+	"github.com/grafana/grafana/pkg/services/org"
+)
+
+func TestIntegrationService_AuditLog(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	s := &Service{store: &sqlStore{db: db.InitTestDB(t)}}
+
+	var key *apikey.APIKey
+	t.Run("should record added keys without the key", func(t *testing.T) {
+		entry := &auditlog.Entry{}
+		var err error
+		key, err = s.AddAPIKey(auditlog.WithEntry(context.Background(), entry), &apikey.AddCommand{
+			Name:         "deploy",
+			Role:         org.RoleEditor,
+			OrgID:        1,
+			Key:          "hashed",
+			AllowedCIDRs: apikey.CIDRList{"10.0.0.0/8"},
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, "apikeys", entry.ResourceKind)
+		require.Contains(t, entry.Changes, auditlog.Change{Path: "name", After: "deploy"})
+		require.Contains(t, entry.Changes, auditlog.Change{Path: "allowedCidrs", After: []any{"10.0.0.0/8"}})
+		for _, c := range entry.Changes {
+			require.NotEqual(t, "hashed", c.After)
+		}
+	})
+
+	t.Run("should record service account tokens as a change of their service account", func(t *testing.T) {
+		entry := &auditlog.Entry{ResourceKind: "serviceaccounts", ResourceUID: "1"}
+		serviceAccountID := int64(1)
+		_, err := s.AddAPIKey(auditlog.WithEntry(context.Background(), entry), &apikey.AddCommand{
+			Name:             "token",
+			Role:             org.RoleViewer,
+			OrgID:            1,
+			Key:              "hashed-token",
+			ServiceAccountID: &serviceAccountID,
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, "serviceaccounts", entry.ResourceKind)
+		require.Contains(t, entry.Changes, auditlog.Change{Path: "tokens.token.name", After: "token"})
+	})
+
+	t.Run("should record deleted keys", func(t *testing.T) {
+		entry := &auditlog.Entry{}
+		err := s.DeleteApiKey(auditlog.WithEntry(context.Background(), entry), &apikey.DeleteCommand{ID: key.ID, OrgID: 1})
+		require.NoError(t, err)
+
+		require.Contains(t, entry.Changes, auditlog.Change{Path: "name", Before: "deploy"})
+	})
+}
@@ -125,6 +125,8 @@ func (ss *sqlStore) AddAPIKey(ctx context.Context, cmd *apikey.AddCommand) (res
 			Expires:          expires,
 			ServiceAccountId: cmd.ServiceAccountID,
 			IsRevoked:        &isRevoked,
+			Permissions:      cmd.Permissions,
+			AllowedCIDRs:     cmd.AllowedCIDRs,
 		}
 
 		if _, err := sess.Insert(&t); err != nil {
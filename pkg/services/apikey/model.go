@@ -1,6 +1,7 @@
 package apikey
 
 import (
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -28,10 +29,28 @@ type APIKey struct {
 	Expires          *int64       `db:"expires"`
 	ServiceAccountId *int64       `db:"service_account_id"`
 	IsRevoked        *bool        `xorm:"is_revoked" db:"is_revoked"`
+	// Permissions optionally restricts the key to a subset of its owner's permissions
+	Permissions Permissions `xorm:"permissions" db:"permissions"`
+	// AllowedCIDRs optionally restricts the source networks the key can be used from
+	AllowedCIDRs CIDRList `xorm:"allowed_cidrs" db:"allowed_cidrs"`
 }
 
 func (k APIKey) TableName() string { return "api_key" }
 
+// AuditState returns the fields of a key recorded in the audit log, the key itself is never recorded.
+func AuditState(k *APIKey) map[string]any {
+	if k == nil {
+		return nil
+	}
+	return map[string]any{
+		"name":         k.Name,
+		"role":         k.Role,
+		"expires":      k.Expires,
+		"permissions":  k.Permissions,
+		"allowedCidrs": k.AllowedCIDRs,
+	}
+}
+
 // swagger:model AddAPIKeyCommand
 type AddCommand struct {
 	Name             string       `json:"name" binding:"Required"`
@@ -40,6 +59,61 @@ type AddCommand struct {
 	Key              string       `json:"-"`
 	SecondsToLive    int64        `json:"secondsToLive"`
 	ServiceAccountID *int64       `json:"-"`
+	Permissions      Permissions  `json:"-"`
+	AllowedCIDRs     CIDRList     `json:"-"`
+}
+
+// Permission is an action/scope pair a key can be restricted to.
+type Permission struct {
+	Action string `json:"action"`
+	Scope  string `json:"scope,omitempty"`
+}
+
+// Permissions is the list of permissions a key is restricted to.
+// An empty list means the key is not restricted.
+type Permissions []Permission
+
+// GroupByAction groups the scopes of the permissions by action.
+func (p Permissions) GroupByAction() map[string][]string {
+	grouped := make(map[string][]string, len(p))
+	for _, perm := range p {
+		grouped[perm.Action] = append(grouped[perm.Action], perm.Scope)
+	}
+	return grouped
+}
+
+func (p *Permissions) FromDB(data []byte) error {
+	if len(data) == 0 {
+		*p = nil
+		return nil
+	}
+	return json.Unmarshal(data, p)
+}
+
+func (p *Permissions) ToDB() ([]byte, error) {
+	if p == nil || len(*p) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(p)
+}
+
+// CIDRList is the list of networks, in CIDR notation, a key can be used from.
+// An empty list means the key can be used from anywhere.
+type CIDRList []string
+
+func (l *CIDRList) FromDB(data []byte) error {
+	if len(data) == 0 {
+		*l = nil
+		return nil
+	}
+	return json.Unmarshal(data, l)
+}
+
+func (l *CIDRList) ToDB() ([]byte, error) {
+	if l == nil || len(*l) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(l)
 }
 
 type DeleteCommand struct {
@@ -0,0 +1,168 @@
+package auditlog
+
+import (
+	"context"
+	"time"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// ActorTypeUnauthenticated is the actor type of requests that were not authenticated, such as failed logins and
+// requests with invalid credentials.
+const ActorTypeUnauthenticated = "unauthenticated"
+
+// Entry is a write operation recorded in the audit log.
+type Entry struct {
+	ID        int64     `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	OrgID     int64     `json:"orgId"`
+
+	// ActorType is the identity type of the actor, e.g. user, service-account, api-key, anonymous or unauthenticated
+	ActorType string `json:"actorType"`
+	// ActorID is the typed identifier of the actor, e.g. user:1
+	ActorID string `json:"actorId"`
+	// ActorLogin is the login of the actor. For unauthenticated requests, it is the login they tried to authenticate as.
+	ActorLogin string `json:"actorLogin"`
+
+	// Action is the HTTP method and route of the operation, e.g. POST /api/dashboards/db
+	Action       string `json:"action"`
+	ResourceKind string `json:"resourceKind,omitempty"`
+	ResourceUID  string `json:"resourceUid,omitempty"`
+
+	Path       string `json:"path"`
+	StatusCode int    `json:"statusCode"`
+	// SourceIP is the address of the connection the request was received on
+	SourceIP string `json:"sourceIp"`
+	// ForwardedFor is the X-Forwarded-For header of the request. It is set by the client or by proxies
+	// and cannot be trusted, it is only recorded to help identify clients behind a proxy.
+	ForwardedFor string   `json:"forwardedFor,omitempty"`
+	Changes      []Change `json:"changes,omitempty"`
+}
+
+// Succeeded returns true if the recorded operation was successful.
+func (e *Entry) Succeeded() bool {
+	return e.StatusCode >= 200 && e.StatusCode < 400
+}
+
+type SearchQuery struct {
+	// OrgID limits the results to an organization, all organizations are searched when zero
+	OrgID       int64
+	ActorID     string
+	ActorLogin  string
+	Action      string
+	ResourceUID string
+	From        time.Time
+	To          time.Time
+	Page        int
+	Limit       int
+}
+
+type SearchResult struct {
+	TotalCount int64    `json:"totalCount"`
+	Entries    []*Entry `json:"entries"`
+	Page       int      `json:"page"`
+	PerPage    int      `json:"perPage"`
+}
+
+// Sink writes audit log entries to a destination.
+type Sink interface {
+	Name() string
+	Write(ctx context.Context, entry *Entry) error
+}
+
+type Service interface {
+	// Log writes an entry to every configured sink.
+	Log(ctx context.Context, entry *Entry) error
+	// RegisterSink adds a sink that receives every entry written after registration.
+	RegisterSink(sink Sink)
+	// RouteMiddleware records the write requests served by a route.
+	// It implements routing.RegisterNamedMiddleware.
+	RouteMiddleware(route string) web.Handler
+}
+
+type entryKey struct{}
+
+// WithEntry returns a copy of the context holding the entry of the request being recorded.
+func WithEntry(ctx context.Context, entry *Entry) context.Context {
+	return context.WithValue(ctx, entryKey{}, entry)
+}
+
+// EntryFromContext returns the entry of the request being recorded, if any.
+func EntryFromContext(ctx context.Context) *Entry {
+	if entry, ok := ctx.Value(entryKey{}).(*Entry); ok {
+		return entry
+	}
+	return nil
+}
+
+// Recording returns true if the request of the context is being recorded.
+// Handlers can use it to skip work only needed by the audit log.
+func Recording(ctx context.Context) bool {
+	return EntryFromContext(ctx) != nil
+}
+
+// SetActor sets the actor of the request being recorded, for handlers that authenticate the request themselves,
+// such as logins.
+func SetActor(ctx context.Context, actor identity.Requester) {
+	if entry := EntryFromContext(ctx); entry != nil {
+		entry.OrgID = actor.GetOrgID()
+		entry.ActorType = string(actor.GetIdentityType())
+		entry.ActorID = actor.GetID()
+		entry.ActorLogin = actor.GetLogin()
+	}
+}
+
+// SetAttemptedLogin sets the login that the request being recorded tries to authenticate as. It is kept if the
+// authentication fails.
+func SetAttemptedLogin(ctx context.Context, login string) {
+	if entry := EntryFromContext(ctx); entry != nil {
+		entry.ActorLogin = login
+	}
+}
+
+// SetResource sets the resource affected by the request being recorded.
+func SetResource(ctx context.Context, kind, uid string) {
+	if entry := EntryFromContext(ctx); entry != nil {
+		entry.ResourceKind = kind
+		entry.ResourceUID = uid
+	}
+}
+
+// SetChange records the state of the resource before and after the request being recorded.
+// Either state can be nil when the resource is created or deleted.
+func SetChange(ctx context.Context, before, after any) {
+	entry := EntryFromContext(ctx)
+	if entry == nil {
+		return
+	}
+
+	changes, err := Diff(before, after)
+	if err != nil {
+		return
+	}
+	entry.Changes = changes
+}
+
+// AddChange records the state before and after of one of the resources changed by the request being recorded,
+// for requests that change several resources at once. Unlike SetChange, it keeps the changes recorded before.
+// The paths of its changes are prefixed with key when set, e.g. the UID of the resource.
+func AddChange(ctx context.Context, key string, before, after any) {
+	entry := EntryFromContext(ctx)
+	if entry == nil {
+		return
+	}
+
+	changes, err := Diff(before, after)
+	if err != nil {
+		return
+	}
+	for _, c := range changes {
+		if c.Path == "" {
+			c.Path = key
+		} else if key != "" {
+			c.Path = join(key, c.Path)
+		}
+		entry.Changes = append(entry.Changes, c)
+	}
+}
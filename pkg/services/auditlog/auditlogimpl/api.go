@@ -0,0 +1,47 @@
+package auditlogimpl
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/api/routing"
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/auditlog"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+)
+
+const rootUrl = "/api/admin/audit-log"
+
+func (s *Service) registerAPIEndpoints(routeRegister routing.RouteRegister) {
+	authorize := ac.Middleware(s.accessControl)
+
+	routeRegister.Get(rootUrl, authorize(ac.EvalPermission(ActionRead)), routing.Wrap(s.handleSearch))
+}
+
+// handleSearch returns the audit log entries matching the query, most recent first.
+// Timestamps in the from and to parameters are in epoch milliseconds.
+func (s *Service) handleSearch(c *contextmodel.ReqContext) response.Response {
+	query := auditlog.SearchQuery{
+		OrgID:       c.QueryInt64("orgId"),
+		ActorID:     c.Query("actorId"),
+		ActorLogin:  c.Query("login"),
+		Action:      c.Query("action"),
+		ResourceUID: c.Query("resourceUid"),
+		Page:        c.QueryInt("page"),
+		Limit:       c.QueryInt("perpage"),
+	}
+	if from := c.QueryInt64("from"); from > 0 {
+		query.From = time.UnixMilli(from)
+	}
+	if to := c.QueryInt64("to"); to > 0 {
+		query.To = time.UnixMilli(to)
+	}
+
+	result, err := s.store.Search(c.Req.Context(), query)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "failed to search audit log", err)
+	}
+
+	return response.JSON(http.StatusOK, result)
+}
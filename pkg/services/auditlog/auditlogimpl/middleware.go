@@ -0,0 +1,141 @@
+package auditlogimpl
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana/pkg/services/auditlog"
+	"github.com/grafana/grafana/pkg/services/contexthandler"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// defaultExcludedRoutes are write routes that query or proxy data without changing any state.
+var defaultExcludedRoutes = []string{
+	"/api/ds/query",
+	"/api/frontend-metrics",
+	"/api/live/",
+	"/api/gnet/",
+	"/api/plugin-proxy/",
+	"/api/plugins/:pluginId/resources",
+	"/api/datasources/proxy/",
+	"/api/datasources/:id/resources",
+	"/api/datasources/uid/:uid/resources",
+	"/api/datasources/:id/health",
+	"/api/datasources/uid/:uid/health",
+}
+
+const (
+	// maxForwardedForLength is the size of the forwarded_for column.
+	maxForwardedForLength = 255
+	// maxActorLoginLength is the size of the actor_login column.
+	maxActorLoginLength = 190
+)
+
+// RouteMiddleware records the write requests served by a route, including anonymous requests and requests that
+// failed to authenticate. It implements routing.RegisterNamedMiddleware.
+func (s *Service) RouteMiddleware(route string) web.Handler {
+	if !s.enabled || s.isExcluded(route) {
+		return web.Middleware(func(next http.Handler) http.Handler { return next })
+	}
+
+	return web.Middleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isWriteMethod(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			entry := &auditlog.Entry{
+				Action:      r.Method + " " + route,
+				ResourceUID: resourceUIDFromParams(web.Params(r)),
+				Path:        r.URL.Path,
+				// web.RemoteAddr trusts the X-Real-IP and X-Forwarded-For headers, which would let clients forge the source
+				SourceIP:     connectionIP(r),
+				ForwardedFor: truncate(r.Header.Get("X-Forwarded-For"), maxForwardedForLength),
+			}
+			r = r.WithContext(auditlog.WithEntry(r.Context(), entry))
+
+			next.ServeHTTP(w, r)
+
+			entry.Timestamp = time.Now()
+			entry.StatusCode = web.Rw(w, r).Status()
+			reqCtx := contexthandler.FromContext(r.Context())
+			switch {
+			case entry.ActorType != "":
+				// the handler authenticated the request, e.g. a login
+			case reqCtx != nil && reqCtx.SignedInUser != nil && (reqCtx.IsSignedIn || reqCtx.AllowAnonymous):
+				entry.OrgID = reqCtx.SignedInUser.GetOrgID()
+				entry.ActorType = string(reqCtx.SignedInUser.GetIdentityType())
+				entry.ActorID = reqCtx.SignedInUser.GetID()
+				entry.ActorLogin = reqCtx.SignedInUser.GetLogin()
+			default:
+				entry.ActorType = auditlog.ActorTypeUnauthenticated
+				if username, _, ok := r.BasicAuth(); ok && entry.ActorLogin == "" {
+					entry.ActorLogin = username
+				}
+				// the attempted login is set by the client
+				entry.ActorLogin = truncate(entry.ActorLogin, maxActorLoginLength)
+			}
+
+			// the entry is written even if the client went away before the response was sent
+			if err := s.Log(context.WithoutCancel(r.Context()), entry); err != nil {
+				s.log.Error("Failed to write audit log entry", "action", entry.Action, "error", err)
+			}
+		})
+	})
+}
+
+func (s *Service) isExcluded(route string) bool {
+	for _, prefix := range s.excludedRoutes {
+		if strings.HasPrefix(route, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func connectionIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+func isWriteMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// resourceUIDFromParams returns the identifier of the resource targeted by a route, preferring UIDs over IDs.
+// Handlers can override it with auditlog.SetResource.
+func resourceUIDFromParams(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, suffix := range []string{"uid", "id"} {
+		for _, k := range keys {
+			if strings.HasSuffix(strings.ToLower(k), suffix) && params[k] != "" {
+				return params[k]
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,30 @@
+package auditlogimpl
+
+import (
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+)
+
+const (
+	ActionRead = "auditlog:read"
+)
+
+var (
+	auditLogReaderRole = accesscontrol.RoleDTO{
+		Name:        "fixed:auditlog:reader",
+		DisplayName: "Audit log reader",
+		Description: "Search the audit log of write operations in all organizations",
+		Group:       "Audit log",
+		Permissions: []accesscontrol.Permission{
+			{Action: ActionRead},
+		},
+	}
+)
+
+func (s *Service) declareFixedRoles(ac accesscontrol.Service) error {
+	auditLogReader := accesscontrol.RoleRegistration{
+		Role:   auditLogReaderRole,
+		Grants: []string{accesscontrol.RoleGrafanaAdmin},
+	}
+
+	return ac.DeclareFixedRoles(auditLogReader)
+}
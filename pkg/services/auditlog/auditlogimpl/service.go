@@ -0,0 +1,171 @@
+package auditlogimpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
+
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/auditlog"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+const (
+	defaultRetention = "90d"
+	cleanupInterval  = time.Hour
+)
+
+var _ auditlog.Service = (*Service)(nil)
+
+type Service struct {
+	accessControl ac.AccessControl
+	store         store
+	log           log.Logger
+
+	sinksMu sync.RWMutex
+	sinks   []auditlog.Sink
+
+	enabled bool
+	// excludedRoutes are route prefixes of write requests that do not change any state, such as queries
+	excludedRoutes []string
+	retention      time.Duration
+}
+
+func ProvideService(
+	accessControl ac.AccessControl,
+	accesscontrolService ac.Service,
+	cfg *setting.Cfg,
+	routeRegister routing.RouteRegister,
+	sql db.DB,
+) (*Service, error) {
+	section := cfg.SectionWithEnvOverrides("audit_log")
+	s := &Service{
+		accessControl:  accessControl,
+		store:          &xormStore{db: sql},
+		log:            log.New("auditlog"),
+		enabled:        section.Key("enabled").MustBool(false),
+		excludedRoutes: slices.Concat(defaultExcludedRoutes, strings.Fields(section.Key("excluded_routes").MustString(""))),
+	}
+
+	if !s.enabled {
+		return s, nil
+	}
+
+	retention, err := gtime.ParseDuration(section.Key("retention").MustString(defaultRetention))
+	if err != nil {
+		return nil, fmt.Errorf("invalid audit log retention: %w", err)
+	}
+	s.retention = retention
+
+	for _, name := range strings.Fields(strings.ReplaceAll(section.Key("sinks").MustString(sinkDatabase), ",", " ")) {
+		switch name {
+		case sinkDatabase:
+			s.RegisterSink(&databaseSink{store: s.store})
+		case sinkFile:
+			path := section.Key("file_path").MustString(filepath.Join(cfg.DataPath, "audit", "audit.log"))
+			sink, err := newFileSink(path)
+			if err != nil {
+				return nil, err
+			}
+			s.RegisterSink(sink)
+		case sinkSyslog:
+			sink, err := newSyslogSink(cfg.Raw.Section("audit_log.syslog"))
+			if err != nil {
+				return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+			}
+			s.RegisterSink(sink)
+		default:
+			return nil, fmt.Errorf("unknown audit log sink %q", name)
+		}
+	}
+
+	if err := s.declareFixedRoles(accesscontrolService); err != nil {
+		return nil, err
+	}
+
+	if s.hasSink(sinkDatabase) {
+		s.registerAPIEndpoints(routeRegister)
+	}
+
+	return s, nil
+}
+
+func (s *Service) Run(ctx context.Context) error {
+	if !s.enabled || !s.hasSink(sinkDatabase) || s.retention <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		s.cleanup(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *Service) cleanup(ctx context.Context) {
+	deleted, err := s.store.DeleteOlderThan(ctx, time.Now().Add(-s.retention))
+	if err != nil {
+		s.log.Error("Failed to delete expired audit log entries", "error", err)
+		return
+	}
+	if deleted > 0 {
+		s.log.Debug("Deleted expired audit log entries", "count", deleted)
+	}
+}
+
+// Log writes an entry to every configured sink.
+func (s *Service) Log(ctx context.Context, entry *auditlog.Entry) error {
+	if !s.enabled {
+		return nil
+	}
+
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+
+	s.sinksMu.RLock()
+	defer s.sinksMu.RUnlock()
+
+	var errs []error
+	for _, sink := range s.sinks {
+		if err := sink.Write(ctx, entry); err != nil {
+			errs = append(errs, fmt.Errorf("%s sink: %w", sink.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RegisterSink adds a sink that receives every entry written after registration.
+func (s *Service) RegisterSink(sink auditlog.Sink) {
+	s.sinksMu.Lock()
+	defer s.sinksMu.Unlock()
+	s.sinks = append(s.sinks, sink)
+}
+
+func (s *Service) hasSink(name string) bool {
+	s.sinksMu.RLock()
+	defer s.sinksMu.RUnlock()
+	for _, sink := range s.sinks {
+		if sink.Name() == name {
+			return true
+		}
+	}
+	return false
+}
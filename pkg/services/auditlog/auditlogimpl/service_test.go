@@ -0,0 +1,281 @@
+package auditlogimpl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/auditlog"
+	"github.com/grafana/grafana/pkg/services/contexthandler/ctxkey"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/tests/testsuite"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+func TestMain(m *testing.M) {
+	testsuite.Run(m)
+}
+
+type fakeSink struct {
+	entries []*auditlog.Entry
+}
+
+func (s *fakeSink) Name() string {
+	return "fake"
+}
+
+func (s *fakeSink) Write(_ context.Context, entry *auditlog.Entry) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func setupTestService(t *testing.T) (*Service, *fakeSink) {
+	t.Helper()
+
+	sink := &fakeSink{}
+	s := &Service{
+		log:            log.NewNopLogger(),
+		enabled:        true,
+		excludedRoutes: defaultExcludedRoutes,
+	}
+	s.RegisterSink(sink)
+	return s, sink
+}
+
+// setupTestServer serves a route recorded by the audit log, requests are signed in when signedInUser is set
+// and anonymous when it is an anonymous user.
+func setupTestServer(s *Service, signedInUser *user.SignedInUser, method, route string, handler web.Handler) *web.Macaron {
+	m := web.New()
+	m.UseMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			reqCtx := &contextmodel.ReqContext{
+				Context:        web.FromContext(r.Context()),
+				SignedInUser:   signedInUser,
+				IsSignedIn:     signedInUser != nil && !signedInUser.IsAnonymous,
+				AllowAnonymous: signedInUser != nil && signedInUser.IsAnonymous,
+				Logger:         log.NewNopLogger(),
+			}
+			*reqCtx.Req = *reqCtx.Req.WithContext(context.WithValue(r.Context(), ctxkey.Key{}, reqCtx))
+			next.ServeHTTP(w, reqCtx.Req)
+		})
+	})
+	m.Handle(method, route, []web.Handler{s.RouteMiddleware(route), handler})
+	return m
+}
+
+func TestService_RouteMiddleware(t *testing.T) {
+	signedInUser := &user.SignedInUser{UserID: 1, OrgID: 2, Login: "admin"}
+
+	t.Run("should record write requests with the changes set by the handler", func(t *testing.T) {
+		s, sink := setupTestService(t)
+		m := setupTestServer(s, signedInUser, http.MethodPut, "/api/teams/:teamId", func(c *contextmodel.ReqContext) {
+			auditlog.SetChange(c.Req.Context(), map[string]any{"name": "old"}, map[string]any{"name": "new"})
+			c.Resp.WriteHeader(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodPut, "/api/teams/5", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set("X-Forwarded-For", "192.168.1.1")
+		req.Header.Set("X-Real-Ip", "192.168.1.1")
+		m.ServeHTTP(httptest.NewRecorder(), req)
+
+		require.Len(t, sink.entries, 1)
+		entry := sink.entries[0]
+		assert.Equal(t, "PUT /api/teams/:teamId", entry.Action)
+		assert.Equal(t, "/api/teams/5", entry.Path)
+		assert.Equal(t, "5", entry.ResourceUID)
+		assert.Equal(t, int64(2), entry.OrgID)
+		assert.Equal(t, "user", entry.ActorType)
+		assert.Equal(t, "user:1", entry.ActorID)
+		assert.Equal(t, "admin", entry.ActorLogin)
+		assert.Equal(t, "10.0.0.1", entry.SourceIP)
+		assert.Equal(t, "192.168.1.1", entry.ForwardedFor)
+		assert.Equal(t, http.StatusOK, entry.StatusCode)
+		assert.Equal(t, []auditlog.Change{{Path: "name", Before: "old", After: "new"}}, entry.Changes)
+		assert.False(t, entry.Timestamp.IsZero())
+	})
+
+	t.Run("should record failed write requests", func(t *testing.T) {
+		s, sink := setupTestService(t)
+		m := setupTestServer(s, signedInUser, http.MethodDelete, "/api/dashboards/uid/:uid", func(c *contextmodel.ReqContext) {
+			c.Resp.WriteHeader(http.StatusForbidden)
+		})
+
+		m.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/api/dashboards/uid/abc", nil))
+
+		require.Len(t, sink.entries, 1)
+		assert.Equal(t, "abc", sink.entries[0].ResourceUID)
+		assert.Equal(t, http.StatusForbidden, sink.entries[0].StatusCode)
+		assert.False(t, sink.entries[0].Succeeded())
+	})
+
+	t.Run("should not record read requests", func(t *testing.T) {
+		s, sink := setupTestService(t)
+		m := setupTestServer(s, signedInUser, http.MethodGet, "/api/teams/:teamId", func(c *contextmodel.ReqContext) {
+			require.False(t, auditlog.Recording(c.Req.Context()))
+			c.Resp.WriteHeader(http.StatusOK)
+		})
+
+		m.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/teams/5", nil))
+
+		require.Empty(t, sink.entries)
+	})
+
+	t.Run("should not record excluded routes", func(t *testing.T) {
+		s, sink := setupTestService(t)
+		m := setupTestServer(s, signedInUser, http.MethodPost, "/api/ds/query", func(c *contextmodel.ReqContext) {
+			c.Resp.WriteHeader(http.StatusOK)
+		})
+
+		m.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/ds/query", nil))
+
+		require.Empty(t, sink.entries)
+	})
+
+	t.Run("should record requests that failed to authenticate", func(t *testing.T) {
+		s, sink := setupTestService(t)
+		m := setupTestServer(s, nil, http.MethodPost, "/api/teams", func(c *contextmodel.ReqContext) {
+			c.Resp.WriteHeader(http.StatusUnauthorized)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/teams", nil)
+		req.SetBasicAuth("api_key", "invalid")
+		m.ServeHTTP(httptest.NewRecorder(), req)
+
+		require.Len(t, sink.entries, 1)
+		entry := sink.entries[0]
+		assert.Equal(t, auditlog.ActorTypeUnauthenticated, entry.ActorType)
+		assert.Empty(t, entry.ActorID)
+		assert.Equal(t, "api_key", entry.ActorLogin)
+		assert.Equal(t, http.StatusUnauthorized, entry.StatusCode)
+	})
+
+	t.Run("should record anonymous requests", func(t *testing.T) {
+		s, sink := setupTestService(t)
+		anonymous := &user.SignedInUser{OrgID: 3, IsAnonymous: true}
+		m := setupTestServer(s, anonymous, http.MethodPost, "/api/teams", func(c *contextmodel.ReqContext) {
+			c.Resp.WriteHeader(http.StatusForbidden)
+		})
+
+		m.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/teams", nil))
+
+		require.Len(t, sink.entries, 1)
+		assert.Equal(t, int64(3), sink.entries[0].OrgID)
+		assert.Equal(t, "anonymous", sink.entries[0].ActorType)
+		assert.Equal(t, http.StatusForbidden, sink.entries[0].StatusCode)
+	})
+
+	t.Run("should record failed logins with the attempted login", func(t *testing.T) {
+		s, sink := setupTestService(t)
+		m := setupTestServer(s, nil, http.MethodPost, "/login", func(c *contextmodel.ReqContext) {
+			auditlog.SetAttemptedLogin(c.Req.Context(), "admin")
+			c.Resp.WriteHeader(http.StatusUnauthorized)
+		})
+
+		m.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/login", nil))
+
+		require.Len(t, sink.entries, 1)
+		assert.Equal(t, "POST /login", sink.entries[0].Action)
+		assert.Equal(t, auditlog.ActorTypeUnauthenticated, sink.entries[0].ActorType)
+		assert.Equal(t, "admin", sink.entries[0].ActorLogin)
+		assert.False(t, sink.entries[0].Succeeded())
+	})
+
+	t.Run("should record the actor set by the handler of a login", func(t *testing.T) {
+		s, sink := setupTestService(t)
+		m := setupTestServer(s, nil, http.MethodPost, "/login", func(c *contextmodel.ReqContext) {
+			auditlog.SetAttemptedLogin(c.Req.Context(), "admin")
+			auditlog.SetActor(c.Req.Context(), signedInUser)
+			c.Resp.WriteHeader(http.StatusOK)
+		})
+
+		m.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/login", nil))
+
+		require.Len(t, sink.entries, 1)
+		entry := sink.entries[0]
+		assert.Equal(t, int64(2), entry.OrgID)
+		assert.Equal(t, "user", entry.ActorType)
+		assert.Equal(t, "user:1", entry.ActorID)
+		assert.Equal(t, "admin", entry.ActorLogin)
+		assert.True(t, entry.Succeeded())
+	})
+}
+
+func TestResourceUIDFromParams(t *testing.T) {
+	assert.Equal(t, "", resourceUIDFromParams(map[string]string{}))
+	assert.Equal(t, "abc", resourceUIDFromParams(map[string]string{":uid": "abc"}))
+	assert.Equal(t, "abc", resourceUIDFromParams(map[string]string{":id": "1", ":dashboardUid": "abc"}))
+	assert.Equal(t, "3", resourceUIDFromParams(map[string]string{":teamId": "3", ":userId": "4"}))
+	assert.Equal(t, "", resourceUIDFromParams(map[string]string{":name": "prometheus"}))
+}
+
+func TestIntegrationStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	s := &xormStore{db: db.InitTestDB(t)}
+	ctx := context.Background()
+	now := time.Now()
+
+	entries := []*auditlog.Entry{
+		{OrgID: 1, Timestamp: now.Add(-48 * time.Hour), ActorID: "user:1", ActorLogin: "admin", Action: "POST /api/teams", StatusCode: 200},
+		{OrgID: 1, Timestamp: now.Add(-time.Hour), ActorID: "user:2", ActorLogin: "editor", Action: "PUT /api/teams/:teamId", ResourceUID: "team", StatusCode: 200,
+			Changes: []auditlog.Change{{Path: "name", Before: "old", After: "new"}}},
+		{OrgID: 2, Timestamp: now, ActorID: "service-account:3", ActorLogin: "sa-ci", Action: "POST /api/dashboards/db", ResourceUID: "dash", StatusCode: 200,
+			SourceIP: "10.0.0.1", ForwardedFor: "192.168.1.1, 10.0.0.2"},
+	}
+	for _, e := range entries {
+		require.NoError(t, s.Insert(ctx, e))
+		require.NotZero(t, e.ID)
+	}
+
+	t.Run("should return the most recent entries first", func(t *testing.T) {
+		result, err := s.Search(ctx, auditlog.SearchQuery{})
+		require.NoError(t, err)
+		require.Equal(t, int64(3), result.TotalCount)
+		require.Len(t, result.Entries, 3)
+		assert.Equal(t, "dash", result.Entries[0].ResourceUID)
+		assert.Equal(t, "10.0.0.1", result.Entries[0].SourceIP)
+		assert.Equal(t, "192.168.1.1, 10.0.0.2", result.Entries[0].ForwardedFor)
+		assert.Equal(t, []auditlog.Change{{Path: "name", Before: "old", After: "new"}}, result.Entries[1].Changes)
+	})
+
+	t.Run("should filter entries", func(t *testing.T) {
+		result, err := s.Search(ctx, auditlog.SearchQuery{OrgID: 1, From: now.Add(-2 * time.Hour)})
+		require.NoError(t, err)
+		require.Len(t, result.Entries, 1)
+		assert.Equal(t, "editor", result.Entries[0].ActorLogin)
+
+		result, err = s.Search(ctx, auditlog.SearchQuery{ActorID: "service-account:3"})
+		require.NoError(t, err)
+		require.Len(t, result.Entries, 1)
+		assert.Equal(t, int64(2), result.Entries[0].OrgID)
+	})
+
+	t.Run("should paginate entries", func(t *testing.T) {
+		result, err := s.Search(ctx, auditlog.SearchQuery{Limit: 2, Page: 2})
+		require.NoError(t, err)
+		require.Equal(t, int64(3), result.TotalCount)
+		require.Len(t, result.Entries, 1)
+		assert.Equal(t, "admin", result.Entries[0].ActorLogin)
+	})
+
+	t.Run("should delete expired entries", func(t *testing.T) {
+		deleted, err := s.DeleteOlderThan(ctx, now.Add(-24*time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, int64(1), deleted)
+
+		result, err := s.Search(ctx, auditlog.SearchQuery{})
+		require.NoError(t, err)
+		require.Equal(t, int64(2), result.TotalCount)
+	})
+}
@@ -0,0 +1,71 @@
+package auditlogimpl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/grafana/grafana/pkg/services/auditlog"
+)
+
+const (
+	sinkDatabase = "database"
+	sinkFile     = "file"
+	sinkSyslog   = "syslog"
+)
+
+// databaseSink writes entries to the audit_log table, which backs the query API.
+type databaseSink struct {
+	store store
+}
+
+func (s *databaseSink) Name() string {
+	return sinkDatabase
+}
+
+func (s *databaseSink) Write(ctx context.Context, entry *auditlog.Entry) error {
+	return s.store.Insert(ctx, entry)
+}
+
+// fileSink appends entries to a file, one JSON document per line.
+type fileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newFileSink(path string) (*fileSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	// nolint:gosec
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log file: %w", err)
+	}
+	return &fileSink{file: file}, nil
+}
+
+func (s *fileSink) Name() string {
+	return sinkFile
+}
+
+func (s *fileSink) Write(_ context.Context, entry *auditlog.Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.file.Write(data)
+	return err
+}
+
+func (s *fileSink) Close() error {
+	return s.file.Close()
+}
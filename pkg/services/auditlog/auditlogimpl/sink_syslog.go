@@ -0,0 +1,67 @@
+//go:build !windows && !nacl && !plan9
+// +build !windows,!nacl,!plan9
+
+package auditlogimpl
+
+import (
+	"context"
+	"encoding/json"
+	"log/syslog"
+
+	"gopkg.in/ini.v1"
+
+	"github.com/grafana/grafana/pkg/services/auditlog"
+)
+
+var facilities = map[string]syslog.Priority{
+	"user":   syslog.LOG_USER,
+	"daemon": syslog.LOG_DAEMON,
+	"auth":   syslog.LOG_AUTH,
+	"local0": syslog.LOG_LOCAL0,
+	"local1": syslog.LOG_LOCAL1,
+	"local2": syslog.LOG_LOCAL2,
+	"local3": syslog.LOG_LOCAL3,
+	"local4": syslog.LOG_LOCAL4,
+	"local5": syslog.LOG_LOCAL5,
+	"local6": syslog.LOG_LOCAL6,
+	"local7": syslog.LOG_LOCAL7,
+}
+
+// syslogSink sends entries to syslog as JSON documents.
+type syslogSink struct {
+	writer *syslog.Writer
+}
+
+func newSyslogSink(section *ini.Section) (*syslogSink, error) {
+	facility, ok := facilities[section.Key("facility").MustString("local7")]
+	if !ok {
+		facility = syslog.LOG_LOCAL7
+	}
+
+	writer, err := syslog.Dial(
+		section.Key("network").MustString(""),
+		section.Key("address").MustString(""),
+		facility|syslog.LOG_INFO,
+		section.Key("tag").MustString("grafana-audit"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &syslogSink{writer: writer}, nil
+}
+
+func (s *syslogSink) Name() string {
+	return sinkSyslog
+}
+
+func (s *syslogSink) Write(_ context.Context, entry *auditlog.Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return s.writer.Info(string(data))
+}
+
+func (s *syslogSink) Close() error {
+	return s.writer.Close()
+}
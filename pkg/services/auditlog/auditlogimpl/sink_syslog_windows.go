@@ -0,0 +1,31 @@
+//go:build windows
+// +build windows
+
+package auditlogimpl
+
+import (
+	"context"
+	"errors"
+
+	"gopkg.in/ini.v1"
+
+	"github.com/grafana/grafana/pkg/services/auditlog"
+)
+
+type syslogSink struct{}
+
+func newSyslogSink(section *ini.Section) (*syslogSink, error) {
+	return nil, errors.New("syslog is not supported on windows")
+}
+
+func (s *syslogSink) Name() string {
+	return sinkSyslog
+}
+
+func (s *syslogSink) Write(_ context.Context, entry *auditlog.Entry) error {
+	return nil
+}
+
+func (s *syslogSink) Close() error {
+	return nil
+}
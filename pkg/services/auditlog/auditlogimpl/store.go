@@ -0,0 +1,189 @@
+package auditlogimpl
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/auditlog"
+)
+
+const (
+	defaultSearchLimit = 100
+	maxSearchLimit     = 1000
+)
+
+type store interface {
+	Insert(ctx context.Context, entry *auditlog.Entry) error
+	Search(ctx context.Context, query auditlog.SearchQuery) (auditlog.SearchResult, error)
+	DeleteOlderThan(ctx context.Context, olderThan time.Time) (int64, error)
+}
+
+// entryRow is the database representation of an audit log entry.
+type entryRow struct {
+	ID           int64  `xorm:"pk autoincr 'id'"`
+	OrgID        int64  `xorm:"org_id"`
+	Created      int64  `xorm:"'created'"`
+	ActorType    string `xorm:"actor_type"`
+	ActorID      string `xorm:"actor_id"`
+	ActorLogin   string `xorm:"actor_login"`
+	Action       string `xorm:"action"`
+	ResourceKind string `xorm:"resource_kind"`
+	ResourceUID  string `xorm:"resource_uid"`
+	Path         string `xorm:"path"`
+	StatusCode   int    `xorm:"status_code"`
+	SourceIP     string `xorm:"source_ip"`
+	ForwardedFor string `xorm:"forwarded_for"`
+	Changes      string `xorm:"changes"`
+}
+
+func (entryRow) TableName() string {
+	return "audit_log"
+}
+
+type xormStore struct {
+	db db.DB
+}
+
+func (s *xormStore) Insert(ctx context.Context, entry *auditlog.Entry) error {
+	row := entryRow{
+		OrgID:        entry.OrgID,
+		Created:      entry.Timestamp.UnixMilli(),
+		ActorType:    entry.ActorType,
+		ActorID:      entry.ActorID,
+		ActorLogin:   entry.ActorLogin,
+		Action:       entry.Action,
+		ResourceKind: entry.ResourceKind,
+		ResourceUID:  entry.ResourceUID,
+		Path:         entry.Path,
+		StatusCode:   entry.StatusCode,
+		SourceIP:     entry.SourceIP,
+		ForwardedFor: entry.ForwardedFor,
+	}
+
+	if len(entry.Changes) > 0 {
+		changes, err := json.Marshal(entry.Changes)
+		if err != nil {
+			return err
+		}
+		row.Changes = string(changes)
+	}
+
+	return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		if _, err := sess.Insert(&row); err != nil {
+			return err
+		}
+		entry.ID = row.ID
+		return nil
+	})
+}
+
+func (s *xormStore) Search(ctx context.Context, query auditlog.SearchQuery) (auditlog.SearchResult, error) {
+	if query.Limit <= 0 {
+		query.Limit = defaultSearchLimit
+	}
+	if query.Limit > maxSearchLimit {
+		query.Limit = maxSearchLimit
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+
+	result := auditlog.SearchResult{
+		Entries: make([]*auditlog.Entry, 0),
+		Page:    query.Page,
+		PerPage: query.Limit,
+	}
+
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		filter := func() *xorm.Session {
+			q := sess.Table("audit_log").Where("1 = 1")
+			if query.OrgID != 0 {
+				q = q.And("org_id = ?", query.OrgID)
+			}
+			if query.ActorID != "" {
+				q = q.And("actor_id = ?", query.ActorID)
+			}
+			if query.ActorLogin != "" {
+				q = q.And("actor_login = ?", query.ActorLogin)
+			}
+			if query.Action != "" {
+				q = q.And("action = ?", query.Action)
+			}
+			if query.ResourceUID != "" {
+				q = q.And("resource_uid = ?", query.ResourceUID)
+			}
+			if !query.From.IsZero() {
+				q = q.And("created >= ?", query.From.UnixMilli())
+			}
+			if !query.To.IsZero() {
+				q = q.And("created <= ?", query.To.UnixMilli())
+			}
+			return q
+		}
+
+		total, err := filter().Count(&entryRow{})
+		if err != nil {
+			return err
+		}
+		result.TotalCount = total
+
+		rows := make([]entryRow, 0, query.Limit)
+		offset := (query.Page - 1) * query.Limit
+		if err := filter().Desc("created", "id").Limit(query.Limit, offset).Find(&rows); err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			entry, err := row.toEntry()
+			if err != nil {
+				return err
+			}
+			result.Entries = append(result.Entries, entry)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+func (s *xormStore) DeleteOlderThan(ctx context.Context, olderThan time.Time) (int64, error) {
+	var affected int64
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		res, err := sess.Exec("DELETE FROM audit_log WHERE created < ?", olderThan.UnixMilli())
+		if err != nil {
+			return err
+		}
+		affected, err = res.RowsAffected()
+		return err
+	})
+	return affected, err
+}
+
+func (row entryRow) toEntry() (*auditlog.Entry, error) {
+	entry := &auditlog.Entry{
+		ID:           row.ID,
+		Timestamp:    time.UnixMilli(row.Created),
+		OrgID:        row.OrgID,
+		ActorType:    row.ActorType,
+		ActorID:      row.ActorID,
+		ActorLogin:   row.ActorLogin,
+		Action:       row.Action,
+		ResourceKind: row.ResourceKind,
+		ResourceUID:  row.ResourceUID,
+		Path:         row.Path,
+		StatusCode:   row.StatusCode,
+		SourceIP:     row.SourceIP,
+		ForwardedFor: row.ForwardedFor,
+	}
+
+	if row.Changes != "" {
+		if err := json.Unmarshal([]byte(row.Changes), &entry.Changes); err != nil {
+			return nil, err
+		}
+	}
+	return entry, nil
+}
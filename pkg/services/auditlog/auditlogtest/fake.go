@@ -0,0 +1,25 @@
+package auditlogtest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/services/auditlog"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+type FakeService struct {
+	ExpectedErr error
+	Entries     []*auditlog.Entry
+}
+
+func (s *FakeService) Log(ctx context.Context, entry *auditlog.Entry) error {
+	s.Entries = append(s.Entries, entry)
+	return s.ExpectedErr
+}
+
+func (s *FakeService) RegisterSink(sink auditlog.Sink) {}
+
+func (s *FakeService) RouteMiddleware(route string) web.Handler {
+	return web.Middleware(func(next http.Handler) http.Handler { return next })
+}
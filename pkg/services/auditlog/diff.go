@@ -0,0 +1,94 @@
+package auditlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Change is a value of a resource modified by a write operation.
+type Change struct {
+	// Path is the location of the value in the resource, e.g. jsonData.timeInterval
+	Path   string `json:"path"`
+	Before any    `json:"before,omitempty"`
+	After  any    `json:"after,omitempty"`
+}
+
+// Diff returns the values that differ between two states of a resource.
+// The states are compared by their JSON representation, objects are compared
+// field by field while other values, including arrays, are compared as a whole.
+func Diff(before, after any) ([]Change, error) {
+	b, err := normalize(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := normalize(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]Change, 0)
+	diff("", b, a, &changes)
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
+
+func normalize(v any) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal state: %w", err)
+	}
+	return value, nil
+}
+
+func diff(path string, before, after any, changes *[]Change) {
+	bm, bok := before.(map[string]any)
+	am, aok := after.(map[string]any)
+	if !bok || !aok {
+		// only walk into objects present in both states, other values are compared as a whole
+		if bok && after == nil {
+			for k, v := range bm {
+				diff(join(path, k), v, nil, changes)
+			}
+			return
+		}
+		if aok && before == nil {
+			for k, v := range am {
+				diff(join(path, k), nil, v, changes)
+			}
+			return
+		}
+		if !reflect.DeepEqual(before, after) {
+			*changes = append(*changes, Change{Path: path, Before: before, After: after})
+		}
+		return
+	}
+
+	for k, bv := range bm {
+		diff(join(path, k), bv, am[k], changes)
+	}
+	for k, av := range am {
+		if _, ok := bm[k]; !ok {
+			diff(join(path, k), nil, av, changes)
+		}
+	}
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,105 @@
+package auditlog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		before   any
+		after    any
+		expected []Change
+	}{
+		{
+			name:     "no changes",
+			before:   map[string]any{"name": "prometheus", "jsonData": map[string]any{"timeInterval": "15s"}},
+			after:    map[string]any{"name": "prometheus", "jsonData": map[string]any{"timeInterval": "15s"}},
+			expected: []Change{},
+		},
+		{
+			name:   "nested changes",
+			before: map[string]any{"name": "prometheus", "jsonData": map[string]any{"timeInterval": "15s", "httpMethod": "GET"}},
+			after:  map[string]any{"name": "metrics", "jsonData": map[string]any{"timeInterval": "30s", "manageAlerts": true}},
+			expected: []Change{
+				{Path: "jsonData.httpMethod", Before: "GET"},
+				{Path: "jsonData.manageAlerts", After: true},
+				{Path: "jsonData.timeInterval", Before: "15s", After: "30s"},
+				{Path: "name", Before: "prometheus", After: "metrics"},
+			},
+		},
+		{
+			name:   "arrays are compared as a whole",
+			before: map[string]any{"tags": []string{"a", "b"}},
+			after:  map[string]any{"tags": []string{"a", "c"}},
+			expected: []Change{
+				{Path: "tags", Before: []any{"a", "b"}, After: []any{"a", "c"}},
+			},
+		},
+		{
+			name:   "created resource",
+			before: nil,
+			after:  struct{ Role string }{Role: "Editor"},
+			expected: []Change{
+				{Path: "Role", After: "Editor"},
+			},
+		},
+		{
+			name:   "deleted resource",
+			before: map[string]any{"name": "team"},
+			after:  nil,
+			expected: []Change{
+				{Path: "name", Before: "team"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := Diff(tt.before, tt.after)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, changes)
+		})
+	}
+}
+
+func TestSetChange(t *testing.T) {
+	t.Run("should ignore requests that are not recorded", func(t *testing.T) {
+		ctx := context.Background()
+		SetChange(ctx, nil, map[string]any{"name": "team"})
+		SetResource(ctx, "teams", "1")
+		require.False(t, Recording(ctx))
+	})
+
+	t.Run("should record the resource and its changes", func(t *testing.T) {
+		entry := &Entry{}
+		ctx := WithEntry(context.Background(), entry)
+
+		SetResource(ctx, "teams", "1")
+		SetChange(ctx, map[string]any{"name": "old"}, map[string]any{"name": "new"})
+
+		require.True(t, Recording(ctx))
+		require.Equal(t, "teams", entry.ResourceKind)
+		require.Equal(t, "1", entry.ResourceUID)
+		require.Equal(t, []Change{{Path: "name", Before: "old", After: "new"}}, entry.Changes)
+	})
+}
+
+func TestAddChange(t *testing.T) {
+	entry := &Entry{}
+	ctx := WithEntry(context.Background(), entry)
+
+	AddChange(ctx, "rule-a", map[string]any{"title": "old"}, map[string]any{"title": "new"})
+	AddChange(ctx, "rule-b", nil, map[string]any{"title": "created"})
+	AddChange(ctx, "user:1", "Edit", "Admin")
+
+	require.Equal(t, []Change{
+		{Path: "rule-a.title", Before: "old", After: "new"},
+		{Path: "rule-b.title", After: "created"},
+		{Path: "user:1", Before: "Edit", After: "Admin"},
+	}, entry.Changes)
+}
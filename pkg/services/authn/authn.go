@@ -68,6 +68,8 @@ type ClientParams struct {
 type FetchPermissionsParams struct {
 	// RestrictedActions will restrict the permissions to only these actions
 	RestrictedActions []string
+	// RestrictedPermissions will restrict the permissions to the ones covered by these, grouped by action
+	RestrictedPermissions map[string][]string
 	// AllowedActions will be added to the identity permissions
 	AllowedActions []string
 	// Note: Kept for backwards compatibility, use AllowedActions instead
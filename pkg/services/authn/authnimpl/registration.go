@@ -30,7 +30,8 @@ type Registration struct{}
 func ProvideRegistration(
 	cfg *setting.Cfg, authnSvc authn.Service,
 	orgService org.Service, sessionService auth.UserTokenService,
-	accessControlService accesscontrol.Service, permRegistry permreg.PermissionRegistry,
+	accessControlService accesscontrol.Service, accessControl accesscontrol.AccessControl,
+	permRegistry permreg.PermissionRegistry,
 	apikeyService apikey.Service, userService user.Service,
 	jwtService auth.JWTVerifierService, userProtectionService login.UserProtectionService,
 	loginAttempts loginattempt.Service, quotaService quota.Service,
@@ -43,7 +44,7 @@ func ProvideRegistration(
 	logger := log.New("authn.registration")
 
 	authnSvc.RegisterClient(clients.ProvideRender(renderService))
-	authnSvc.RegisterClient(clients.ProvideAPIKey(apikeyService))
+	authnSvc.RegisterClient(clients.ProvideAPIKey(apikeyService, cfg))
 
 	if cfg.LoginCookieName != "" {
 		authnSvc.RegisterClient(clients.ProvideSession(cfg, sessionService, authInfoService))
@@ -110,7 +111,7 @@ func ProvideRegistration(
 	authnSvc.RegisterPostAuthHook(sync.ProvideOAuthTokenSync(oauthTokenService, sessionService, socialService, tracer).SyncOauthTokenHook, 60)
 	authnSvc.RegisterPostAuthHook(userSync.FetchSyncedUserHook, 100)
 
-	rbacSync := sync.ProvideRBACSync(accessControlService, tracer, permRegistry)
+	rbacSync := sync.ProvideRBACSync(accessControlService, accessControl, tracer, permRegistry)
 	if features.IsEnabledGlobally(featuremgmt.FlagCloudRBACRoles) {
 		authnSvc.RegisterPostAuthHook(rbacSync.SyncCloudRoles, 110)
 		authnSvc.RegisterPreLogoutHook(gcomsso.ProvideGComSSOService(cfg).LogoutHook, 50)
@@ -3,11 +3,13 @@ package sync
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"golang.org/x/exp/maps"
 
 	"github.com/grafana/authlib/claims"
 	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/infra/tracing"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
@@ -22,20 +24,22 @@ var (
 	errSyncPermissionsForbidden = errutil.Forbidden("permissions.sync.forbidden")
 )
 
-func ProvideRBACSync(acService accesscontrol.Service, tracer tracing.Tracer, permRegistry permreg.PermissionRegistry) *RBACSync {
+func ProvideRBACSync(acService accesscontrol.Service, accessControl accesscontrol.AccessControl, tracer tracing.Tracer, permRegistry permreg.PermissionRegistry) *RBACSync {
 	return &RBACSync{
-		ac:           acService,
-		log:          log.New("permissions.sync"),
-		permRegistry: permRegistry,
-		tracer:       tracer,
+		ac:            acService,
+		accessControl: accessControl,
+		log:           log.New("permissions.sync"),
+		permRegistry:  permRegistry,
+		tracer:        tracer,
 	}
 }
 
 type RBACSync struct {
-	ac           accesscontrol.Service
-	permRegistry permreg.PermissionRegistry
-	log          log.Logger
-	tracer       tracing.Tracer
+	ac            accesscontrol.Service
+	accessControl accesscontrol.AccessControl
+	permRegistry  permreg.PermissionRegistry
+	log           log.Logger
+	tracer        tracing.Tracer
 }
 
 func (s *RBACSync) SyncPermissionsHook(ctx context.Context, ident *authn.Identity, _ *authn.Request) error {
@@ -69,11 +73,54 @@ func (s *RBACSync) SyncPermissionsHook(ctx context.Context, ident *authn.Identit
 		}
 		grouped = filtered
 	}
+
+	// Restrict access to the intersection with the list of permissions
+	if restricted := ident.ClientParams.FetchPermissionsParams.RestrictedPermissions; len(restricted) > 0 {
+		grouped = accesscontrol.Intersect(grouped, restricted, s.scopeCovers(ctx, ident.GetOrgID()))
+		// The restriction only lists permissions, so checks on the organization role must not grant anything more
+		if ident.OrgRoles != nil {
+			ident.OrgRoles[ident.GetOrgID()] = org.RoleNone
+		}
+	}
 	ident.Permissions[ident.OrgID] = grouped
 
 	return nil
 }
 
+// scopeCovers returns a function that reports whether granted scopes cover a scope of an action. A wildcard over one of
+// the scope prefixes of the action covers every resource, and other scopes are evaluated with the scope resolvers,
+// so that access inherited from a folder is kept.
+func (s *RBACSync) scopeCovers(ctx context.Context, orgID int64) accesscontrol.ScopeCoverFunc {
+	return func(action string, granted []string, scope string) bool {
+		prefixes, _ := s.permRegistry.GetScopePrefixes(action)
+		for _, g := range granted {
+			wildcard, ok := strings.CutSuffix(g, "*")
+			if !ok {
+				continue
+			}
+			for prefix := range prefixes {
+				if strings.HasPrefix(prefix, wildcard) {
+					return true
+				}
+			}
+		}
+
+		if s.accessControl == nil {
+			return false
+		}
+		requester := &identity.StaticRequester{
+			OrgID:       orgID,
+			Permissions: map[int64]map[string][]string{orgID: {action: granted}},
+		}
+		ok, err := s.accessControl.Evaluate(ctx, requester, accesscontrol.EvalPermission(action, scope))
+		if err != nil {
+			s.log.FromContext(ctx).Warn("Failed to evaluate restricted permission", "action", action, "scope", scope, "error", err)
+			return false
+		}
+		return ok
+	}
+}
+
 func (s *RBACSync) fetchPermissions(ctx context.Context, ident *authn.Identity) ([]accesscontrol.Permission, error) {
 	ctx, span := s.tracer.Start(ctx, "rbac.sync.fetchPermissions")
 	defer span.End()
@@ -12,6 +12,7 @@ import (
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/infra/tracing"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
 	acmock "github.com/grafana/grafana/pkg/services/accesscontrol/mock"
 	permreg "github.com/grafana/grafana/pkg/services/accesscontrol/permreg/test"
 	"github.com/grafana/grafana/pkg/services/authn"
@@ -159,6 +160,22 @@ func TestRBACSync_FetchPermissions(t *testing.T) {
 				accesscontrol.ActionTeamsWrite: {accesscontrol.ScopeTeamsAll},
 			},
 		},
+		{
+			name: "restrict permissions from store to the intersection with restricted permissions",
+			identity: &authn.Identity{
+				ID: "2", Type: claims.TypeServiceAccount, OrgID: 1,
+				ClientParams: authn.ClientParams{
+					SyncPermissions: true,
+					FetchPermissionsParams: authn.FetchPermissionsParams{
+						RestrictedPermissions: map[string][]string{
+							accesscontrol.ActionUsersRead:  {"users:id:1"},
+							accesscontrol.ActionTeamsWrite: {accesscontrol.ScopeTeamsAll},
+						},
+					},
+				},
+			},
+			expectedPermissions: map[string][]string{accesscontrol.ActionUsersRead: {"users:id:1"}},
+		},
 	}
 
 	for _, tt := range testCases {
@@ -176,6 +193,57 @@ func TestRBACSync_FetchPermissions(t *testing.T) {
 	}
 }
 
+func TestRBACSync_RestrictedPermissions(t *testing.T) {
+	accessControl := acimpl.ProvideAccessControlTest()
+	accessControl.RegisterScopeAttributeResolver("dashboards:uid:", accesscontrol.ScopeAttributeResolverFunc(
+		func(ctx context.Context, orgID int64, scope string) ([]string, error) {
+			// Every dashboard is in the folder "f1"
+			return []string{scope, "folders:uid:f1"}, nil
+		}))
+
+	permRegistry := permreg.ProvidePermissionRegistry(t)
+	require.NoError(t, permRegistry.RegisterPermission("dashboards:write", "dashboards:uid:"))
+	require.NoError(t, permRegistry.RegisterPermission("dashboards:write", "folders:uid:"))
+
+	s := &RBACSync{
+		ac: &acmock.Mock{
+			GetUserPermissionsFunc: func(ctx context.Context, siu identity.Requester, o accesscontrol.Options) ([]accesscontrol.Permission, error) {
+				return []accesscontrol.Permission{
+					{Action: "dashboards:read", Scope: "folders:uid:f1"},
+					{Action: "dashboards:write", Scope: "dashboards:*"},
+				}, nil
+			},
+		},
+		accessControl: accessControl,
+		log:           log.NewNopLogger(),
+		tracer:        tracing.InitializeTracerForTest(),
+		permRegistry:  permRegistry,
+	}
+
+	ident := &authn.Identity{
+		ID: "2", Type: claims.TypeServiceAccount, OrgID: 1,
+		OrgRoles: map[int64]org.RoleType{1: org.RoleEditor},
+		ClientParams: authn.ClientParams{
+			SyncPermissions: true,
+			FetchPermissionsParams: authn.FetchPermissionsParams{
+				RestrictedPermissions: map[string][]string{
+					"dashboards:read":  {"dashboards:uid:d1"},
+					"dashboards:write": {"folders:uid:f2"},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, s.SyncPermissionsHook(context.Background(), ident, &authn.Request{}))
+
+	// The dashboard is readable through its folder, and the folder is writable through the wildcard over dashboards
+	assert.Equal(t, map[string][]string{
+		"dashboards:read":  {"dashboards:uid:d1"},
+		"dashboards:write": {"folders:uid:f2"},
+	}, ident.Permissions[1])
+	assert.Equal(t, org.RoleNone, ident.GetOrgRole())
+}
+
 func TestRBACSync_SyncCloudRoles(t *testing.T) {
 	type testCase struct {
 		desc           string
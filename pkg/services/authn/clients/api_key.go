@@ -3,6 +3,7 @@ package clients
 import (
 	"context"
 	"errors"
+	"net"
 	"strconv"
 	"strings"
 	"time"
@@ -17,14 +18,17 @@ import (
 	"github.com/grafana/grafana/pkg/services/authn"
 	"github.com/grafana/grafana/pkg/services/login"
 	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/util"
+	"github.com/grafana/grafana/pkg/web"
 )
 
 var (
-	errAPIKeyInvalid     = errutil.Unauthorized("api-key.invalid", errutil.WithPublicMessage("Invalid API key"))
-	errAPIKeyExpired     = errutil.Unauthorized("api-key.expired", errutil.WithPublicMessage("Expired API key"))
-	errAPIKeyRevoked     = errutil.Unauthorized("api-key.revoked", errutil.WithPublicMessage("Revoked API key"))
-	errAPIKeyOrgMismatch = errutil.Unauthorized("api-key.organization-mismatch", errutil.WithPublicMessage("API key does not belong to the requested organization"))
+	errAPIKeyInvalid      = errutil.Unauthorized("api-key.invalid", errutil.WithPublicMessage("Invalid API key"))
+	errAPIKeyExpired      = errutil.Unauthorized("api-key.expired", errutil.WithPublicMessage("Expired API key"))
+	errAPIKeyRevoked      = errutil.Unauthorized("api-key.revoked", errutil.WithPublicMessage("Revoked API key"))
+	errAPIKeyOrgMismatch  = errutil.Unauthorized("api-key.organization-mismatch", errutil.WithPublicMessage("API key does not belong to the requested organization"))
+	errAPIKeySourceDenied = errutil.Unauthorized("api-key.source-denied", errutil.WithPublicMessage("API key cannot be used from this address"))
 )
 
 var (
@@ -38,16 +42,18 @@ const (
 	metaKeySkipLastUsed = "keySkipLastUsed"
 )
 
-func ProvideAPIKey(apiKeyService apikey.Service) *APIKey {
+func ProvideAPIKey(apiKeyService apikey.Service, cfg *setting.Cfg) *APIKey {
 	return &APIKey{
-		log:           log.New(authn.ClientAPIKey),
-		apiKeyService: apiKeyService,
+		log:            log.New(authn.ClientAPIKey),
+		apiKeyService:  apiKeyService,
+		trustedProxies: cfg.PublicDashboardsTrustedProxies,
 	}
 }
 
 type APIKey struct {
-	log           log.Logger
-	apiKeyService apikey.Service
+	log            log.Logger
+	apiKeyService  apikey.Service
+	trustedProxies []string
 }
 
 func (s *APIKey) Name() string {
@@ -71,6 +77,10 @@ func (s *APIKey) Authenticate(ctx context.Context, r *authn.Request) (*authn.Ide
 		return nil, err
 	}
 
+	if err := validateApiKeySource(r, key, s.trustedProxies); err != nil {
+		return nil, err
+	}
+
 	// Set keyID so we can use it in last used hook
 	r.SetMeta(metaKeyID, strconv.FormatInt(key.ID, 10))
 	if !shouldUpdateLastUsedAt(key) {
@@ -250,6 +260,31 @@ func validateApiKey(orgID int64, key *apikey.APIKey) error {
 	return nil
 }
 
+func validateApiKeySource(r *authn.Request, key *apikey.APIKey, trustedProxies []string) error {
+	if len(key.AllowedCIDRs) == 0 {
+		return nil
+	}
+
+	// X-Forwarded-For and X-Real-IP are set by the client and would let anyone pick the source address the token is
+	// checked against, so only the X-Forwarded-For header added by trusted proxies is used.
+	ip := net.ParseIP(web.ClientIP(r.HTTPRequest, trustedProxies))
+	if ip == nil {
+		return errAPIKeySourceDenied.Errorf("could not determine source address")
+	}
+
+	for _, cidr := range key.AllowedCIDRs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		if network.Contains(ip) {
+			return nil
+		}
+	}
+
+	return errAPIKeySourceDenied.Errorf("source address %s is not allowed", ip)
+}
+
 func newAPIKeyIdentity(key *apikey.APIKey) *authn.Identity {
 	return &authn.Identity{
 		ID:              strconv.FormatInt(key.ID, 10),
@@ -267,8 +302,22 @@ func newServiceAccountIdentity(key *apikey.APIKey) *authn.Identity {
 		Type:            claims.TypeServiceAccount,
 		OrgID:           key.OrgID,
 		AuthenticatedBy: login.APIKeyAuthModule,
-		ClientParams:    authn.ClientParams{FetchSyncedUser: true, SyncPermissions: true},
+		ClientParams: authn.ClientParams{
+			FetchSyncedUser: true,
+			SyncPermissions: true,
+			FetchPermissionsParams: authn.FetchPermissionsParams{
+				RestrictedPermissions: restrictedPermissions(key),
+			},
+		},
+	}
+}
+
+// restrictedPermissions returns the permissions the key is restricted to, grouped by action.
+func restrictedPermissions(key *apikey.APIKey) map[string][]string {
+	if len(key.Permissions) == 0 {
+		return nil
 	}
+	return key.Permissions.GroupByAction()
 }
 
 func shouldUpdateLastUsedAt(key *apikey.APIKey) bool {
@@ -18,6 +18,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/authn"
 	"github.com/grafana/grafana/pkg/services/login"
 	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/setting"
 )
 
 var (
@@ -29,6 +30,7 @@ func TestAPIKey_Authenticate(t *testing.T) {
 	type TestCase struct {
 		desc             string
 		req              *authn.Request
+		trustedProxies   []string
 		expectedKey      *apikey.APIKey
 		expectedErr      error
 		expectedIdentity *authn.Identity
@@ -112,11 +114,128 @@ func TestAPIKey_Authenticate(t *testing.T) {
 			},
 			expectedErr: errAPIKeyOrgMismatch,
 		},
+		{
+			desc: "should restrict permissions for service account token with permissions",
+			req: &authn.Request{HTTPRequest: &http.Request{
+				RemoteAddr: "10.0.0.5:3000",
+				Header: map[string][]string{
+					"Authorization": {"Bearer " + secret},
+				},
+			}},
+			expectedKey: &apikey.APIKey{
+				ID:               1,
+				OrgID:            1,
+				Key:              hash,
+				ServiceAccountId: intPtr(1),
+				Permissions: apikey.Permissions{
+					{Action: "dashboards:read", Scope: "dashboards:uid:abc"},
+					{Action: "dashboards:read", Scope: "folders:uid:def"},
+				},
+				AllowedCIDRs: apikey.CIDRList{"10.0.0.0/24"},
+			},
+			expectedIdentity: &authn.Identity{
+				ID:    "1",
+				Type:  claims.TypeServiceAccount,
+				OrgID: 1,
+				ClientParams: authn.ClientParams{
+					FetchSyncedUser: true,
+					SyncPermissions: true,
+					FetchPermissionsParams: authn.FetchPermissionsParams{
+						RestrictedPermissions: map[string][]string{
+							"dashboards:read": {"dashboards:uid:abc", "folders:uid:def"},
+						},
+					},
+				},
+				AuthenticatedBy: login.APIKeyAuthModule,
+			},
+		},
+		{
+			desc: "should fail for service account token used outside of its allowed networks",
+			req: &authn.Request{HTTPRequest: &http.Request{
+				RemoteAddr: "192.168.1.5:3000",
+				Header: map[string][]string{
+					"Authorization": {"Bearer " + secret},
+				},
+			}},
+			expectedKey: &apikey.APIKey{
+				ID:               1,
+				OrgID:            1,
+				Key:              hash,
+				ServiceAccountId: intPtr(1),
+				AllowedCIDRs:     apikey.CIDRList{"10.0.0.0/24", "172.16.0.0/12"},
+			},
+			expectedErr: errAPIKeySourceDenied,
+		},
+		{
+			desc: "should ignore forwarding headers when checking the allowed networks",
+			req: &authn.Request{HTTPRequest: &http.Request{
+				RemoteAddr: "192.168.1.5:3000",
+				Header: map[string][]string{
+					"Authorization":   {"Bearer " + secret},
+					"X-Forwarded-For": {"10.0.0.5"},
+					"X-Real-Ip":       {"10.0.0.5"},
+				},
+			}},
+			expectedKey: &apikey.APIKey{
+				ID:               1,
+				OrgID:            1,
+				Key:              hash,
+				ServiceAccountId: intPtr(1),
+				AllowedCIDRs:     apikey.CIDRList{"10.0.0.0/24"},
+			},
+			expectedErr: errAPIKeySourceDenied,
+		},
+		{
+			desc: "should check the forwarded address of requests from trusted proxies against the allowed networks",
+			req: &authn.Request{HTTPRequest: &http.Request{
+				RemoteAddr: "192.168.1.5:3000",
+				Header: map[string][]string{
+					"Authorization":   {"Bearer " + secret},
+					"X-Forwarded-For": {"172.16.0.1, 10.0.0.5"},
+				},
+			}},
+			trustedProxies: []string{"192.168.1.0/24"},
+			expectedKey: &apikey.APIKey{
+				ID:               1,
+				OrgID:            1,
+				Key:              hash,
+				ServiceAccountId: intPtr(1),
+				AllowedCIDRs:     apikey.CIDRList{"10.0.0.0/24"},
+			},
+			expectedIdentity: &authn.Identity{
+				ID:              "1",
+				Type:            claims.TypeServiceAccount,
+				OrgID:           1,
+				ClientParams:    authn.ClientParams{FetchSyncedUser: true, SyncPermissions: true},
+				AuthenticatedBy: login.APIKeyAuthModule,
+			},
+		},
+		{
+			desc: "should not use forwarded addresses added before the trusted proxies",
+			req: &authn.Request{HTTPRequest: &http.Request{
+				RemoteAddr: "192.168.1.5:3000",
+				Header: map[string][]string{
+					"Authorization":   {"Bearer " + secret},
+					"X-Forwarded-For": {"10.0.0.5, 172.16.0.1"},
+				},
+			}},
+			trustedProxies: []string{"192.168.1.0/24"},
+			expectedKey: &apikey.APIKey{
+				ID:               1,
+				OrgID:            1,
+				Key:              hash,
+				ServiceAccountId: intPtr(1),
+				AllowedCIDRs:     apikey.CIDRList{"10.0.0.0/24"},
+			},
+			expectedErr: errAPIKeySourceDenied,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
-			c := ProvideAPIKey(&apikeytest.Service{ExpectedAPIKey: tt.expectedKey})
+			cfg := setting.NewCfg()
+			cfg.PublicDashboardsTrustedProxies = tt.trustedProxies
+			c := ProvideAPIKey(&apikeytest.Service{ExpectedAPIKey: tt.expectedKey}, cfg)
 
 			identity, err := c.Authenticate(context.Background(), tt.req)
 			if tt.expectedErr != nil {
@@ -183,7 +302,7 @@ func TestAPIKey_Test(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
-			c := ProvideAPIKey(&apikeytest.Service{})
+			c := ProvideAPIKey(&apikeytest.Service{}, setting.NewCfg())
 			assert.Equal(t, tt.expected, c.Test(context.Background(), tt.req))
 		})
 	}
@@ -277,7 +396,7 @@ func TestAPIKey_ResolveIdentity(t *testing.T) {
 		t.Run(tt.desc, func(t *testing.T) {
 			c := ProvideAPIKey(&apikeytest.Service{
 				ExpectedAPIKey: tt.exptedApiKey,
-			})
+			}, setting.NewCfg())
 
 			identity, err := c.ResolveIdentity(context.Background(), 1, tt.typ, tt.id)
 			if tt.expectedErr != nil {
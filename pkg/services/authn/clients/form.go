@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+	"github.com/grafana/grafana/pkg/services/auditlog"
 	"github.com/grafana/grafana/pkg/services/authn"
 	"github.com/grafana/grafana/pkg/web"
 )
@@ -34,6 +35,8 @@ func (c *Form) Authenticate(ctx context.Context, r *authn.Request) (*authn.Ident
 	if err := web.Bind(r.HTTPRequest, &form); err != nil {
 		return nil, errBadForm.Errorf("failed to parse request: %w", err)
 	}
+	// failed logins are recorded with the login they tried
+	auditlog.SetAttemptedLogin(r.HTTPRequest.Context(), form.Username)
 	return c.client.AuthenticatePassword(ctx, r, form.Username, form.Password)
 }
 
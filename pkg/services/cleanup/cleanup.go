@@ -20,7 +20,10 @@ import (
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/dashboardsnapshots"
 	dashver "github.com/grafana/grafana/pkg/services/dashboardversion"
+	"github.com/grafana/grafana/pkg/services/libraryelements"
 	"github.com/grafana/grafana/pkg/services/ngalert/image"
+	"github.com/grafana/grafana/pkg/services/ngalert/state/historian"
+	"github.com/grafana/grafana/pkg/services/publicdashboards"
 	"github.com/grafana/grafana/pkg/services/queryhistory"
 	"github.com/grafana/grafana/pkg/services/shorturls"
 	tempuser "github.com/grafana/grafana/pkg/services/temp_user"
@@ -36,17 +39,22 @@ type CleanUpService struct {
 	ShortURLService           shorturls.Service
 	QueryHistoryService       queryhistory.Service
 	dashboardVersionService   dashver.Service
+	libraryElementService     *libraryelements.LibraryElementService
 	dashboardSnapshotService  dashboardsnapshots.Service
 	deleteExpiredImageService *image.DeleteExpiredService
+	stateHistoryRetention     *historian.SQLRetentionService
 	tempUserService           tempuser.Service
 	annotationCleaner         annotations.Cleaner
 	dashboardService          dashboards.DashboardService
+	publicDashboardService    publicdashboards.Service
 }
 
 func ProvideService(cfg *setting.Cfg, serverLockService *serverlock.ServerLockService,
 	shortURLService shorturls.Service, sqlstore db.DB, queryHistoryService queryhistory.Service,
 	dashboardVersionService dashver.Service, dashSnapSvc dashboardsnapshots.Service, deleteExpiredImageService *image.DeleteExpiredService,
-	tempUserService tempuser.Service, tracer tracing.Tracer, annotationCleaner annotations.Cleaner, dashboardService dashboards.DashboardService) *CleanUpService {
+	tempUserService tempuser.Service, tracer tracing.Tracer, annotationCleaner annotations.Cleaner, dashboardService dashboards.DashboardService,
+	stateHistoryRetention *historian.SQLRetentionService, publicDashboardService publicdashboards.Service,
+	libraryElementService *libraryelements.LibraryElementService) *CleanUpService {
 	s := &CleanUpService{
 		Cfg:                       cfg,
 		ServerLockService:         serverLockService,
@@ -55,12 +63,15 @@ func ProvideService(cfg *setting.Cfg, serverLockService *serverlock.ServerLockSe
 		store:                     sqlstore,
 		log:                       log.New("cleanup"),
 		dashboardVersionService:   dashboardVersionService,
+		libraryElementService:     libraryElementService,
 		dashboardSnapshotService:  dashSnapSvc,
 		deleteExpiredImageService: deleteExpiredImageService,
+		stateHistoryRetention:     stateHistoryRetention,
 		tempUserService:           tempUserService,
 		tracer:                    tracer,
 		annotationCleaner:         annotationCleaner,
 		dashboardService:          dashboardService,
+		publicDashboardService:    publicDashboardService,
 	}
 	return s
 }
@@ -100,12 +111,15 @@ func (srv *CleanUpService) clean(ctx context.Context) {
 		{"clean up temporary files", srv.cleanUpTmpFiles},
 		{"delete expired snapshots", srv.deleteExpiredSnapshots},
 		{"delete expired dashboard versions", srv.deleteExpiredDashboardVersions},
+		{"delete expired library element versions", srv.deleteExpiredLibraryElementVersions},
 		{"delete expired images", srv.deleteExpiredImages},
+		{"delete expired alert state history", srv.deleteExpiredStateHistory},
 		{"cleanup old annotations", srv.cleanUpOldAnnotations},
 		{"expire old user invites", srv.expireOldUserInvites},
 		{"delete stale query history", srv.deleteStaleQueryHistory},
 		{"expire old email verifications", srv.expireOldVerifications},
 		{"cleanup trash dashboards", srv.cleanUpTrashDashboards},
+		{"delete expired public dashboards", srv.deleteExpiredPublicDashboards},
 	}
 
 	if srv.Cfg.ShortLinkExpiration > 0 {
@@ -219,6 +233,15 @@ func (srv *CleanUpService) deleteExpiredDashboardVersions(ctx context.Context) {
 	}
 }
 
+func (srv *CleanUpService) deleteExpiredLibraryElementVersions(ctx context.Context) {
+	logger := srv.log.FromContext(ctx)
+	if rowsAffected, err := srv.libraryElementService.DeleteExpiredVersions(ctx); err != nil {
+		logger.Error("Failed to delete expired library element versions", "error", err.Error())
+	} else {
+		logger.Debug("Deleted expired library element versions", "rows affected", rowsAffected)
+	}
+}
+
 func (srv *CleanUpService) deleteExpiredImages(ctx context.Context) {
 	logger := srv.log.FromContext(ctx)
 	if !srv.Cfg.UnifiedAlerting.IsEnabled() {
@@ -231,6 +254,18 @@ func (srv *CleanUpService) deleteExpiredImages(ctx context.Context) {
 	}
 }
 
+func (srv *CleanUpService) deleteExpiredStateHistory(ctx context.Context) {
+	logger := srv.log.FromContext(ctx)
+	if !srv.Cfg.UnifiedAlerting.IsEnabled() {
+		return
+	}
+	if rowsAffected, err := srv.stateHistoryRetention.DeleteExpired(ctx); err != nil {
+		logger.Error("Failed to delete expired alert state history", "error", err.Error())
+	} else {
+		logger.Debug("Deleted expired alert state history", "rows affected", rowsAffected)
+	}
+}
+
 func (srv *CleanUpService) expireOldUserInvites(ctx context.Context) {
 	logger := srv.log.FromContext(ctx)
 	maxInviteLifetime := srv.Cfg.UserInviteMaxLifetime
@@ -313,3 +348,12 @@ func (srv *CleanUpService) cleanUpTrashDashboards(ctx context.Context) {
 		logger.Debug("Cleaned up deleted dashboards", "dashboards affected", affected)
 	}
 }
+
+func (srv *CleanUpService) deleteExpiredPublicDashboards(ctx context.Context) {
+	logger := srv.log.FromContext(ctx)
+	if rowsAffected, err := srv.publicDashboardService.DeleteExpired(ctx); err != nil {
+		logger.Error("Failed to delete expired public dashboards", "error", err.Error())
+	} else {
+		logger.Debug("Deleted expired public dashboards", "rows affected", rowsAffected)
+	}
+}
@@ -0,0 +1,64 @@
+package credentialscan
+
+import (
+	"context"
+	"time"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+)
+
+// Kind is the kind of resource a credential was detected in.
+type Kind string
+
+const (
+	KindDashboard    Kind = "dashboard"
+	KindLibraryPanel Kind = "library_panel"
+	KindAnnotation   Kind = "annotation"
+	KindDataSource   Kind = "datasource"
+)
+
+var ErrCredentialsDetected = errutil.ValidationFailed(
+	"credentialscan.credentials-detected",
+	errutil.WithPublicMessage("Credentials were detected in the content. Remove them before saving."),
+)
+
+// Resource is the content of a resource scanned for credentials.
+type Resource struct {
+	OrgID int64
+	Kind  Kind
+	// UID of the resource, or ID for resources without UID such as annotations
+	UID  string
+	Name string
+	// Content is the resource content, either raw JSON or any value that can be marshaled to JSON
+	Content any
+}
+
+// Finding is a credential-like string detected in a resource.
+type Finding struct {
+	Kind         Kind   `json:"kind"`
+	ResourceUID  string `json:"resourceUid"`
+	ResourceName string `json:"resourceName"`
+	// Path is the location of the credential in the resource content, e.g. panels[0].targets[1].expr
+	Path        string `json:"path"`
+	RuleID      string `json:"ruleId"`
+	Description string `json:"description"`
+	// Redacted is the detected credential with all but a few characters masked
+	Redacted   string    `json:"redacted"`
+	DetectedAt time.Time `json:"detectedAt"`
+}
+
+type Service interface {
+	// CheckResource scans a resource that is about to be saved.
+	// It returns ErrCredentialsDetected if credentials were found and saves are blocked.
+	CheckResource(ctx context.Context, resource Resource) error
+	// ContainsCredentials reports whether credentials are detected in a resource, whether saves are blocked or not.
+	// It is used to find the resources of a bulk save that need an ID to record their findings.
+	ContainsCredentials(ctx context.Context, resource Resource) bool
+	// ScanResource scans a resource once it is saved and replaces its recorded findings.
+	// Failures are logged, they never fail the save.
+	ScanResource(ctx context.Context, resource Resource)
+	// DeleteResource removes the recorded findings of a deleted resource.
+	// Failures are logged, they never fail the delete. Resources deleted in bulk, such as the dashboards of a deleted
+	// folder, are removed by the next periodic scan.
+	DeleteResource(ctx context.Context, orgID int64, kind Kind, uid string)
+}
@@ -0,0 +1,46 @@
+package credentialscanimpl
+
+import (
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/api/routing"
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/credentialscan"
+)
+
+const rootUrl = "/api/credential-scan"
+
+func (s *Service) registerAPIEndpoints(routeRegister routing.RouteRegister) {
+	authorize := ac.Middleware(s.accessControl)
+
+	routeRegister.Group(rootUrl, func(subrouter routing.RouteRegister) {
+		subrouter.Get("/findings", authorize(ac.EvalPermission(ActionFindingsRead)), routing.Wrap(s.handleListFindings))
+	})
+}
+
+type listFindingsResponse struct {
+	Findings []credentialscan.Finding `json:"findings"`
+}
+
+func (s *Service) handleListFindings(c *contextmodel.ReqContext) response.Response {
+	kind := credentialscan.Kind(c.Query("kind"))
+
+	findings, err := s.store.List(c.Req.Context(), c.SignedInUser.GetOrgID())
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "failed to list credential findings", err)
+	}
+
+	if kind != "" {
+		filtered := make([]credentialscan.Finding, 0, len(findings))
+		for _, f := range findings {
+			if f.Kind == kind {
+				filtered = append(filtered, f)
+			}
+		}
+		findings = filtered
+	}
+
+	return response.JSON(http.StatusOK, listFindingsResponse{Findings: findings})
+}
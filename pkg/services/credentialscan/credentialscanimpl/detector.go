@@ -0,0 +1,173 @@
+package credentialscanimpl
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/components/satokengen"
+)
+
+// rule detects a kind of credential in a string.
+type rule struct {
+	ID          string
+	Description string
+	Pattern     *regexp.Regexp
+	// Group is the index of the pattern submatch holding the credential, 0 for the whole match
+	Group int
+	// Verify optionally discards false positives
+	Verify func(credential string) bool
+}
+
+var defaultRules = []rule{
+	{
+		ID:          "grafana-token",
+		Description: "Grafana service account or Grafana Cloud token",
+		Pattern:     regexp.MustCompile(`\bgl[a-z]+_[A-Za-z0-9]{32}_[0-9a-f]{8}\b`),
+		Verify: func(credential string) bool {
+			_, err := satokengen.Decode(credential)
+			return err == nil
+		},
+	},
+	{
+		ID:          "grafana-api-key",
+		Description: "Grafana API key",
+		Pattern:     regexp.MustCompile(`\beyJrIjoi[A-Za-z0-9+/]{32,}={0,2}`),
+	},
+	{
+		ID:          "aws-access-key-id",
+		Description: "AWS access key ID",
+		Pattern:     regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+	},
+	{
+		ID:          "aws-secret-access-key",
+		Description: "AWS secret access key",
+		Pattern:     regexp.MustCompile(`(?i)aws_?secret_?access_?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})\b`),
+		Group:       1,
+	},
+	{
+		ID:          "bearer-token",
+		Description: "Bearer token",
+		Pattern:     regexp.MustCompile(`(?i)\bbearer\s+([A-Za-z0-9\-._~+/]{20,}=*)`),
+		Group:       1,
+	},
+	{
+		ID:          "url-password",
+		Description: "Password in URL",
+		Pattern:     regexp.MustCompile(`\b[a-zA-Z][a-zA-Z0-9+.\-]*://[^/\s:@"']+:([^/\s@"']+)@`),
+		Group:       1,
+		Verify: func(credential string) bool {
+			// template variables are interpolated at query time and are not credentials
+			return !strings.HasPrefix(credential, "$")
+		},
+	},
+	{
+		ID:          "private-key",
+		Description: "Private key",
+		Pattern:     regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )*PRIVATE KEY-----`),
+	},
+}
+
+// match is a credential detected at a path of the scanned content.
+type match struct {
+	Path     string
+	Rule     rule
+	Redacted string
+}
+
+type detector struct {
+	rules []rule
+}
+
+func newDetector() *detector {
+	return &detector{rules: defaultRules}
+}
+
+// scan walks the content and returns the credentials found in its string values.
+// The content can either be raw JSON, a string or any value that can be marshaled to JSON.
+func (d *detector) scan(content any) ([]match, error) {
+	value, err := normalize(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []match
+	walk("", value, func(path, s string) {
+		matches = append(matches, d.scanString(path, s)...)
+	})
+	return matches, nil
+}
+
+func (d *detector) scanString(path, s string) []match {
+	var matches []match
+	for _, r := range d.rules {
+		for _, sub := range r.Pattern.FindAllStringSubmatch(s, -1) {
+			credential := sub[r.Group]
+			if r.Verify != nil && !r.Verify(credential) {
+				continue
+			}
+			matches = append(matches, match{Path: path, Rule: r, Redacted: redact(credential)})
+		}
+	}
+	return matches
+}
+
+func normalize(content any) (any, error) {
+	switch v := content.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return v, nil
+	case []byte:
+		if len(v) == 0 {
+			return nil, nil
+		}
+		var value any
+		if err := json.Unmarshal(v, &value); err != nil {
+			return nil, fmt.Errorf("failed to parse content: %w", err)
+		}
+		return value, nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal content: %w", err)
+		}
+		return normalize(data)
+	}
+}
+
+func walk(path string, value any, fn func(path, s string)) {
+	switch v := value.(type) {
+	case string:
+		fn(path, v)
+	case []any:
+		for i, item := range v {
+			walk(fmt.Sprintf("%s[%d]", path, i), item, fn)
+		}
+	case map[string]any:
+		// walk keys in order so findings are stable between scans
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			walk(p, v[k], fn)
+		}
+	}
+}
+
+// redact masks all but the first and last four characters of a credential.
+func redact(credential string) string {
+	const visible = 4
+	if len(credential) <= 2*visible {
+		return strings.Repeat("*", len(credential))
+	}
+	return credential[:visible] + strings.Repeat("*", len(credential)-2*visible) + credential[len(credential)-visible:]
+}
@@ -0,0 +1,31 @@
+package credentialscanimpl
+
+import (
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/org"
+)
+
+const (
+	ActionFindingsRead = "credentialscan.findings:read"
+)
+
+var (
+	findingsReaderRole = accesscontrol.RoleDTO{
+		Name:        "fixed:credentialscan.findings:reader",
+		DisplayName: "Credential scan findings reader",
+		Description: "List credentials detected in the organization dashboards, library panels, annotations and data sources",
+		Group:       "Credential scan",
+		Permissions: []accesscontrol.Permission{
+			{Action: ActionFindingsRead},
+		},
+	}
+)
+
+func (s *Service) declareFixedRoles(ac accesscontrol.Service) error {
+	findingsReader := accesscontrol.RoleRegistration{
+		Role:   findingsReaderRole,
+		Grants: []string{string(org.RoleAdmin)},
+	}
+
+	return ac.DeclareFixedRoles(findingsReader)
+}
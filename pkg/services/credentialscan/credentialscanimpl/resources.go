@@ -0,0 +1,133 @@
+package credentialscanimpl
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/credentialscan"
+)
+
+const listBatchSize = 100
+
+type resourceLister interface {
+	// List calls fn for every resource that can be scanned for credentials.
+	List(ctx context.Context, fn func(resource credentialscan.Resource) error) error
+}
+
+// sqlResourceLister reads resources directly from their tables, across all organizations.
+type sqlResourceLister struct {
+	db db.DB
+}
+
+type dashboardRow struct {
+	ID    int64  `xorm:"id"`
+	OrgID int64  `xorm:"org_id"`
+	UID   string `xorm:"uid"`
+	Title string `xorm:"title"`
+	Data  []byte `xorm:"data"`
+}
+
+type libraryElementRow struct {
+	ID    int64  `xorm:"id"`
+	OrgID int64  `xorm:"org_id"`
+	UID   string `xorm:"uid"`
+	Name  string `xorm:"name"`
+	Model []byte `xorm:"model"`
+}
+
+type annotationRow struct {
+	ID    int64  `xorm:"id"`
+	OrgID int64  `xorm:"org_id"`
+	Text  string `xorm:"text"`
+}
+
+type dataSourceRow struct {
+	ID       int64  `xorm:"id"`
+	OrgID    int64  `xorm:"org_id"`
+	UID      string `xorm:"uid"`
+	Name     string `xorm:"name"`
+	URL      string `xorm:"url"`
+	JSONData []byte `xorm:"json_data"`
+}
+
+func (l *sqlResourceLister) List(ctx context.Context, fn func(resource credentialscan.Resource) error) error {
+	if err := listBatches(ctx, l.db, "dashboard", "id, org_id, uid, title, data", "is_folder = ?", []any{l.db.GetDialect().BooleanStr(false)},
+		func(row dashboardRow) (int64, credentialscan.Resource) {
+			return row.ID, credentialscan.Resource{OrgID: row.OrgID, Kind: credentialscan.KindDashboard, UID: row.UID, Name: row.Title, Content: row.Data}
+		}, fn); err != nil {
+		return err
+	}
+
+	if err := listBatches(ctx, l.db, "library_element", "id, org_id, uid, name, model", "", nil,
+		func(row libraryElementRow) (int64, credentialscan.Resource) {
+			return row.ID, credentialscan.Resource{OrgID: row.OrgID, Kind: credentialscan.KindLibraryPanel, UID: row.UID, Name: row.Name, Content: row.Model}
+		}, fn); err != nil {
+		return err
+	}
+
+	// annotations created by alerting are generated from rule labels and values, only user annotations are scanned
+	if err := listBatches(ctx, l.db, "annotation", "id, org_id, text", "alert_id = 0 AND text <> ''", nil,
+		func(row annotationRow) (int64, credentialscan.Resource) {
+			uid := strconv.FormatInt(row.ID, 10)
+			return row.ID, credentialscan.Resource{OrgID: row.OrgID, Kind: credentialscan.KindAnnotation, UID: uid, Name: uid, Content: row.Text}
+		}, fn); err != nil {
+		return err
+	}
+
+	return listBatches(ctx, l.db, "data_source", "id, org_id, uid, name, url, json_data", "", nil,
+		func(row dataSourceRow) (int64, credentialscan.Resource) {
+			return row.ID, credentialscan.Resource{OrgID: row.OrgID, Kind: credentialscan.KindDataSource, UID: row.UID, Name: row.Name, Content: dataSourceContent(row.URL, row.JSONData)}
+		}, fn)
+}
+
+// dataSourceContent builds the scanned content of a data source from its URL and JSON data.
+func dataSourceContent(url string, jsonData []byte) map[string]any {
+	content := map[string]any{"url": url}
+	if len(jsonData) > 0 {
+		content["jsonData"] = json.RawMessage(jsonData)
+	}
+	return content
+}
+
+// listBatches pages through a table by ascending ID so that large tables are not loaded at once.
+func listBatches[T any](
+	ctx context.Context,
+	store db.DB,
+	table, cols, where string,
+	args []any,
+	convert func(row T) (int64, credentialscan.Resource),
+	fn func(resource credentialscan.Resource) error,
+) error {
+	lastID := int64(0)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rows := make([]T, 0, listBatchSize)
+		err := store.WithDbSession(ctx, func(sess *db.Session) error {
+			q := sess.Table(table).Cols(cols).Where("id > ?", lastID)
+			if where != "" {
+				q = q.And(where, args...)
+			}
+			return q.OrderBy("id").Limit(listBatchSize).Find(&rows)
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			id, resource := convert(row)
+			lastID = id
+			if err := fn(resource); err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < listBatchSize {
+			return nil
+		}
+	}
+}
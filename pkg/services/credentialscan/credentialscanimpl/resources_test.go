@@ -0,0 +1,58 @@
+package credentialscanimpl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/annotations"
+	"github.com/grafana/grafana/pkg/services/credentialscan"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/libraryelements/model"
+)
+
+func TestIntegrationSQLResourceLister_List(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	sqlStore := db.InitTestDB(t)
+	now := time.Now()
+	err := sqlStore.WithDbSession(context.Background(), func(sess *db.Session) error {
+		if _, err := sess.Insert(
+			&dashboards.Dashboard{OrgID: 1, UID: "folder", Title: "Folder", IsFolder: true, Data: simplejson.New(), Created: now, Updated: now},
+			&dashboards.Dashboard{OrgID: 1, UID: "dash", Title: "Dashboard", Data: simplejson.NewFromAny(map[string]any{"title": "Dashboard"}), Created: now, Updated: now},
+			&model.LibraryElement{OrgID: 1, UID: "panel", Name: "Panel", Model: []byte(`{"type": "text"}`), Created: now, Updated: now},
+			&annotations.Item{OrgID: 2, Text: "deployed"},
+			&annotations.Item{OrgID: 2, Text: "Alerting", AlertID: 1},
+			&datasources.DataSource{OrgID: 2, UID: "ds", Name: "Postgres", Type: "postgres", URL: "db:5432", JsonData: simplejson.New(), Created: now, Updated: now},
+		); err != nil {
+			return err
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	var listed []credentialscan.Resource
+	lister := &sqlResourceLister{db: sqlStore}
+	require.NoError(t, lister.List(context.Background(), func(resource credentialscan.Resource) error {
+		listed = append(listed, resource)
+		return nil
+	}))
+
+	require.Len(t, listed, 4)
+	require.Equal(t, credentialscan.KindDashboard, listed[0].Kind)
+	require.Equal(t, "dash", listed[0].UID)
+	require.Equal(t, credentialscan.KindLibraryPanel, listed[1].Kind)
+	require.Equal(t, "panel", listed[1].UID)
+	require.Equal(t, credentialscan.KindAnnotation, listed[2].Kind)
+	require.Equal(t, int64(2), listed[2].OrgID)
+	require.Equal(t, "deployed", listed[2].Content)
+	require.Equal(t, credentialscan.KindDataSource, listed[3].Kind)
+	require.Equal(t, "ds", listed[3].UID)
+}
@@ -0,0 +1,264 @@
+package credentialscanimpl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/serverlock"
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/credentialscan"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+const (
+	defaultScanInterval = 24 * time.Hour
+	minScanInterval     = 10 * time.Minute
+
+	scanActionName = "credential scan"
+)
+
+var _ credentialscan.Service = (*Service)(nil)
+
+type serverLocker interface {
+	LockAndExecute(ctx context.Context, actionName string, maxInterval time.Duration, fn func(ctx context.Context)) error
+}
+
+type Service struct {
+	accessControl ac.AccessControl
+	detector      *detector
+	resources     resourceLister
+	serverLock    serverLocker
+	store         findingsStore
+	log           log.Logger
+
+	// scanMu prevents concurrent full scans, which would race when removing stale findings
+	scanMu sync.Mutex
+
+	enabled    bool
+	scanOnSave bool
+	blockSaves bool
+	interval   time.Duration
+}
+
+func ProvideService(
+	accessControl ac.AccessControl,
+	accesscontrolService ac.Service,
+	cfg *setting.Cfg,
+	kvStore kvstore.KVStore,
+	routeRegister routing.RouteRegister,
+	serverLock *serverlock.ServerLockService,
+	sql db.DB,
+) (*Service, error) {
+	section := cfg.SectionWithEnvOverrides("credential_scan")
+	s := &Service{
+		accessControl: accessControl,
+		detector:      newDetector(),
+		resources:     &sqlResourceLister{db: sql},
+		serverLock:    serverLock,
+		store:         newStore(kvStore),
+		log:           log.New("credentialscan"),
+		enabled:       section.Key("enabled").MustBool(false),
+		scanOnSave:    section.Key("scan_on_save").MustBool(true),
+		blockSaves:    section.Key("block_saves").MustBool(false),
+		interval:      section.Key("interval").MustDuration(defaultScanInterval),
+	}
+
+	if !s.enabled {
+		return s, nil
+	}
+
+	if s.interval < minScanInterval {
+		s.log.Warn("Credential scan interval is too low, increasing to minimum", "interval", s.interval, "minimum", minScanInterval)
+		s.interval = minScanInterval
+	}
+
+	if err := s.declareFixedRoles(accesscontrolService); err != nil {
+		return nil, err
+	}
+
+	s.registerAPIEndpoints(routeRegister)
+
+	return s, nil
+}
+
+func (s *Service) Run(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	// every instance checks often, but the server lock only lets one of them scan per interval
+	ticker := time.NewTicker(minScanInterval)
+	defer ticker.Stop()
+
+	for {
+		err := s.serverLock.LockAndExecute(ctx, scanActionName, s.interval, func(ctx context.Context) {
+			if err := s.scanAll(ctx); err != nil {
+				s.log.Error("Failed to scan resources for credentials", "error", err)
+			}
+		})
+		if err != nil {
+			s.log.Error("Failed to acquire the credential scan lock", "error", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// CheckResource scans a resource that is about to be saved, it only scans when saves are blocked.
+func (s *Service) CheckResource(_ context.Context, resource credentialscan.Resource) error {
+	if !s.enabled || !s.scanOnSave || !s.blockSaves {
+		return nil
+	}
+
+	findings, err := s.scan(resource)
+	if err != nil {
+		// content that cannot be parsed is rejected by the resource service itself
+		s.log.Debug("Failed to scan resource", "kind", resource.Kind, "uid", resource.UID, "error", err)
+		return nil
+	}
+	if len(findings) == 0 {
+		return nil
+	}
+
+	s.log.Info("Credentials detected in resource, blocking save", "orgID", resource.OrgID, "kind", resource.Kind, "uid", resource.UID, "findings", len(findings))
+	paths := make([]string, 0, len(findings))
+	for _, f := range findings {
+		paths = append(paths, fmt.Sprintf("%s (%s)", f.Path, f.Description))
+	}
+	return credentialscan.ErrCredentialsDetected.Errorf("credentials detected at %s", strings.Join(paths, ", "))
+}
+
+// ContainsCredentials reports whether credentials are detected in a resource, it is false when saves are not scanned.
+func (s *Service) ContainsCredentials(_ context.Context, resource credentialscan.Resource) bool {
+	if !s.enabled || !s.scanOnSave {
+		return false
+	}
+
+	findings, err := s.scan(resource)
+	if err != nil {
+		s.log.Debug("Failed to scan resource", "kind", resource.Kind, "uid", resource.UID, "error", err)
+		return false
+	}
+	return len(findings) > 0
+}
+
+// ScanResource scans a saved resource and replaces its recorded findings.
+func (s *Service) ScanResource(ctx context.Context, resource credentialscan.Resource) {
+	if !s.enabled || !s.scanOnSave {
+		return
+	}
+	if resource.UID == "" {
+		s.log.Warn("Cannot record credential findings of a resource without UID", "kind", resource.Kind)
+		return
+	}
+
+	findings, err := s.scan(resource)
+	if err != nil {
+		s.log.Debug("Failed to scan resource", "kind", resource.Kind, "uid", resource.UID, "error", err)
+		return
+	}
+	if len(findings) > 0 {
+		s.log.Info("Credentials detected in resource", "orgID", resource.OrgID, "kind", resource.Kind, "uid", resource.UID, "findings", len(findings))
+	}
+
+	if err := s.store.Set(ctx, resource.OrgID, resource.Kind, resource.UID, findings); err != nil {
+		s.log.Warn("Failed to record credential findings", "kind", resource.Kind, "uid", resource.UID, "error", err)
+	}
+}
+
+// DeleteResource removes the recorded findings of a deleted resource.
+func (s *Service) DeleteResource(ctx context.Context, orgID int64, kind credentialscan.Kind, uid string) {
+	if !s.enabled || uid == "" {
+		return
+	}
+	if err := s.store.Delete(ctx, orgID, resourceKey(kind, uid)); err != nil {
+		s.log.Warn("Failed to remove credential findings of deleted resource", "kind", kind, "uid", uid, "error", err)
+	}
+}
+
+func (s *Service) scan(resource credentialscan.Resource) ([]credentialscan.Finding, error) {
+	matches, err := s.detector.scan(resource.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	findings := make([]credentialscan.Finding, 0, len(matches))
+	for _, m := range matches {
+		findings = append(findings, credentialscan.Finding{
+			Kind:         resource.Kind,
+			ResourceUID:  resource.UID,
+			ResourceName: resource.Name,
+			Path:         m.Path,
+			RuleID:       m.Rule.ID,
+			Description:  m.Rule.Description,
+			Redacted:     m.Redacted,
+			DetectedAt:   now,
+		})
+	}
+	return findings, nil
+}
+
+// scanAll scans every resource of every organization and replaces the recorded findings.
+func (s *Service) scanAll(ctx context.Context) error {
+	s.scanMu.Lock()
+	defer s.scanMu.Unlock()
+
+	start := time.Now()
+	s.log.Debug("Scanning resources for credentials")
+
+	previous, err := s.store.Keys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list recorded findings: %w", err)
+	}
+
+	seen := make(map[int64]map[string]bool)
+	total := 0
+	err = s.resources.List(ctx, func(resource credentialscan.Resource) error {
+		findings, err := s.scan(resource)
+		if err != nil {
+			s.log.Debug("Failed to scan resource", "kind", resource.Kind, "uid", resource.UID, "error", err)
+			return nil
+		}
+		if len(findings) == 0 {
+			return nil
+		}
+
+		if seen[resource.OrgID] == nil {
+			seen[resource.OrgID] = make(map[string]bool)
+		}
+		seen[resource.OrgID][resourceKey(resource.Kind, resource.UID)] = true
+		total += len(findings)
+
+		return s.store.Set(ctx, resource.OrgID, resource.Kind, resource.UID, findings)
+	})
+	if err != nil {
+		return err
+	}
+
+	// remove findings of resources that were fixed or deleted since the last scan
+	for orgID, keys := range previous {
+		for _, key := range keys {
+			if seen[orgID][key] {
+				continue
+			}
+			if err := s.store.Delete(ctx, orgID, key); err != nil {
+				return fmt.Errorf("failed to remove stale findings: %w", err)
+			}
+		}
+	}
+
+	s.log.Info("Finished scanning resources for credentials", "findings", total, "duration", time.Since(start))
+	return nil
+}
@@ -0,0 +1,92 @@
+package credentialscanimpl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	"github.com/grafana/grafana/pkg/services/credentialscan"
+)
+
+const kvNamespace = "credentialscan"
+
+type findingsStore interface {
+	// Set replaces the findings of a resource, findings are removed when empty.
+	Set(ctx context.Context, orgID int64, kind credentialscan.Kind, uid string, findings []credentialscan.Finding) error
+	// List returns the findings of an organization.
+	List(ctx context.Context, orgID int64) ([]credentialscan.Finding, error)
+	// Keys returns the keys of the resources with findings, per organization.
+	Keys(ctx context.Context) (map[int64][]string, error)
+	Delete(ctx context.Context, orgID int64, key string) error
+}
+
+func newStore(kv kvstore.KVStore) *store {
+	return &store{kv: kv}
+}
+
+type store struct {
+	kv kvstore.KVStore
+}
+
+func resourceKey(kind credentialscan.Kind, uid string) string {
+	return fmt.Sprintf("%s/%s", kind, uid)
+}
+
+func (s *store) Set(ctx context.Context, orgID int64, kind credentialscan.Kind, uid string, findings []credentialscan.Finding) error {
+	key := resourceKey(kind, uid)
+	if len(findings) == 0 {
+		return s.kv.Del(ctx, orgID, kvNamespace, key)
+	}
+
+	data, err := json.Marshal(findings)
+	if err != nil {
+		return err
+	}
+	return s.kv.Set(ctx, orgID, kvNamespace, key, string(data))
+}
+
+func (s *store) List(ctx context.Context, orgID int64) ([]credentialscan.Finding, error) {
+	items, err := s.kv.GetAll(ctx, orgID, kvNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]credentialscan.Finding, 0)
+	for _, value := range items[orgID] {
+		var findings []credentialscan.Finding
+		if err := json.Unmarshal([]byte(value), &findings); err != nil {
+			return nil, err
+		}
+		result = append(result, findings...)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		if result[i].ResourceUID != result[j].ResourceUID {
+			return result[i].ResourceUID < result[j].ResourceUID
+		}
+		return result[i].Path < result[j].Path
+	})
+	return result, nil
+}
+
+func (s *store) Keys(ctx context.Context) (map[int64][]string, error) {
+	keys, err := s.kv.Keys(ctx, kvstore.AllOrganizations, kvNamespace, "")
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int64][]string)
+	for _, k := range keys {
+		result[k.OrgId] = append(result[k.OrgId], k.Key)
+	}
+	return result, nil
+}
+
+func (s *store) Delete(ctx context.Context, orgID int64, key string) error {
+	return s.kv.Del(ctx, orgID, kvNamespace, key)
+}
@@ -0,0 +1,32 @@
+package credentialscantest
+
+import (
+	"context"
+
+	"github.com/grafana/grafana/pkg/services/credentialscan"
+)
+
+type FakeService struct {
+	ExpectedErr         error
+	ExpectedCredentials bool
+	Checked             []credentialscan.Resource
+	Scanned             []credentialscan.Resource
+	Deleted             []credentialscan.Resource
+}
+
+func (s *FakeService) CheckResource(ctx context.Context, resource credentialscan.Resource) error {
+	s.Checked = append(s.Checked, resource)
+	return s.ExpectedErr
+}
+
+func (s *FakeService) ScanResource(ctx context.Context, resource credentialscan.Resource) {
+	s.Scanned = append(s.Scanned, resource)
+}
+
+func (s *FakeService) ContainsCredentials(ctx context.Context, resource credentialscan.Resource) bool {
+	return s.ExpectedCredentials
+}
+
+func (s *FakeService) DeleteResource(ctx context.Context, orgID int64, kind credentialscan.Kind, uid string) {
+	s.Deleted = append(s.Deleted, credentialscan.Resource{OrgID: orgID, Kind: kind, UID: uid})
+}
@@ -0,0 +1,213 @@
+package dashboardreport
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+)
+
+var (
+	ErrReportNotFound = errutil.NotFound("dashboardreport.notFound", errutil.WithPublicMessage("Report not found"))
+	ErrInvalidReport  = errutil.BadRequest("dashboardreport.invalid")
+)
+
+const (
+	maxNameLength       = 190
+	maxRecipients       = 100
+	defaultTimeRangeMin = "now-24h"
+	defaultTimeRangeMax = "now"
+)
+
+// Format is the file format of the dashboard attached to a report.
+type Format string
+
+const (
+	FormatPDF Format = "pdf"
+	FormatPNG Format = "png"
+)
+
+// Extension returns the file extension of the format, without the dot.
+func (f Format) Extension() string {
+	return string(f)
+}
+
+// TimeRange is the time range the dashboard of a report is rendered with, e.g. now-7d to now.
+type TimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Spec holds the settings of a report that are set by users.
+type Spec struct {
+	Name         string `json:"name"`
+	DashboardUID string `json:"dashboardUid"`
+	// PanelID renders a single panel of the dashboard instead of the whole dashboard. Only supported by PNG reports.
+	PanelID int64 `json:"panelId,omitempty"`
+	// Variables are the values of the template variables of the dashboard, by variable name.
+	Variables map[string][]string `json:"variables,omitempty"`
+	TimeRange TimeRange           `json:"timeRange"`
+	Format    Format              `json:"format"`
+	// Schedule is a cron expression in the standard five-field format, e.g. 0 8 * * 1 for every Monday at 08:00.
+	Schedule string `json:"schedule"`
+	// Timezone is the IANA time zone of the schedule and of the rendered dashboard. Defaults to UTC.
+	Timezone   string   `json:"timezone,omitempty"`
+	Recipients []string `json:"recipients"`
+	// Message is included in the body of the email.
+	Message string `json:"message,omitempty"`
+	// Paused reports are not sent by the scheduler, but can still be sent manually.
+	Paused bool `json:"paused"`
+}
+
+// SetDefaults fills in the settings that are optional.
+func (s *Spec) SetDefaults() {
+	if s.Format == "" {
+		s.Format = FormatPDF
+	}
+	if s.TimeRange.From == "" {
+		s.TimeRange.From = defaultTimeRangeMin
+	}
+	if s.TimeRange.To == "" {
+		s.TimeRange.To = defaultTimeRangeMax
+	}
+	if s.Timezone == "" {
+		s.Timezone = "UTC"
+	}
+}
+
+// Validate checks that the report can be rendered and delivered.
+func (s *Spec) Validate() error {
+	if s.Name == "" {
+		return ErrInvalidReport.Errorf("name is required")
+	}
+	if len(s.Name) > maxNameLength {
+		return ErrInvalidReport.Errorf("name cannot be longer than %d characters", maxNameLength)
+	}
+	if s.DashboardUID == "" {
+		return ErrInvalidReport.Errorf("dashboard UID is required")
+	}
+	switch s.Format {
+	case FormatPDF:
+		if s.PanelID != 0 {
+			return ErrInvalidReport.Errorf("panel ID is only supported by %s reports", FormatPNG)
+		}
+	case FormatPNG:
+	default:
+		return ErrInvalidReport.Errorf("unsupported format %q, must be one of %s or %s", s.Format, FormatPDF, FormatPNG)
+	}
+	if _, err := s.location(); err != nil {
+		return ErrInvalidReport.Errorf("invalid timezone: %w", err)
+	}
+	if _, err := cron.ParseStandard(s.Schedule); err != nil {
+		return ErrInvalidReport.Errorf("invalid schedule: %w", err)
+	}
+	if len(s.Recipients) == 0 {
+		return ErrInvalidReport.Errorf("at least one recipient is required")
+	}
+	if len(s.Recipients) > maxRecipients {
+		return ErrInvalidReport.Errorf("a report cannot have more than %d recipients", maxRecipients)
+	}
+	for _, recipient := range s.Recipients {
+		if _, err := mail.ParseAddress(recipient); err != nil {
+			return ErrInvalidReport.Errorf("invalid recipient %q: %w", recipient, err)
+		}
+	}
+	return nil
+}
+
+// NextRun returns the first time after the given time the report is scheduled to be sent.
+func (s *Spec) NextRun(after time.Time) (time.Time, error) {
+	loc, err := s.location()
+	if err != nil {
+		return time.Time{}, err
+	}
+	schedule, err := cron.ParseStandard(s.Schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	next := schedule.Next(after.In(loc))
+	if next.IsZero() {
+		return time.Time{}, errors.New("schedule has no upcoming run")
+	}
+	return next, nil
+}
+
+func (s *Spec) location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(s.Timezone)
+}
+
+// Report is a dashboard that is rendered and sent by email to its recipients on a schedule.
+type Report struct {
+	ID    int64  `json:"id"`
+	UID   string `json:"uid"`
+	OrgID int64  `json:"orgId"`
+	Spec
+	CreatedBy int64 `json:"createdBy"`
+	// UpdatedBy is the user that last saved the report. The report is rendered and sent on behalf of this user,
+	// with their permissions at the time it is sent.
+	UpdatedBy int64     `json:"updatedBy"`
+	Created   time.Time `json:"created"`
+	Updated   time.Time `json:"updated"`
+	// NextRun is when the scheduler sends the report next, it is nil for paused reports.
+	NextRun *time.Time `json:"nextRun,omitempty"`
+}
+
+// Trigger is what started a delivery of a report.
+type Trigger string
+
+const (
+	TriggerSchedule Trigger = "schedule"
+	TriggerManual   Trigger = "manual"
+)
+
+// DeliveryStatus is the outcome of a delivery of a report.
+type DeliveryStatus string
+
+const (
+	DeliveryStatusSent   DeliveryStatus = "sent"
+	DeliveryStatusFailed DeliveryStatus = "failed"
+)
+
+// Delivery is a record of a report being rendered and sent.
+type Delivery struct {
+	ID         int64          `json:"id"`
+	ReportUID  string         `json:"reportUid"`
+	Started    time.Time      `json:"started"`
+	DurationMs int64          `json:"durationMs"`
+	Trigger    Trigger        `json:"trigger"`
+	Status     DeliveryStatus `json:"status"`
+	Error      string         `json:"error,omitempty"`
+	Recipients []string       `json:"recipients"`
+}
+
+type CreateReportCommand struct {
+	OrgID  int64
+	UserID int64
+	Spec   Spec
+}
+
+type UpdateReportCommand struct {
+	OrgID  int64
+	UserID int64
+	UID    string
+	Spec   Spec
+}
+
+type Service interface {
+	Create(ctx context.Context, cmd *CreateReportCommand) (*Report, error)
+	Update(ctx context.Context, cmd *UpdateReportCommand) (*Report, error)
+	Delete(ctx context.Context, orgID int64, uid string) error
+	Get(ctx context.Context, orgID int64, uid string) (*Report, error)
+	List(ctx context.Context, orgID int64) ([]*Report, error)
+	// ListDeliveries returns the most recent deliveries of a report, most recent first.
+	ListDeliveries(ctx context.Context, orgID int64, uid string, limit int) ([]*Delivery, error)
+	// Send renders and sends a report right away, outside of its schedule.
+	Send(ctx context.Context, orgID int64, uid string) (*Delivery, error)
+}
@@ -0,0 +1,119 @@
+package dashboardreport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validSpec() Spec {
+	spec := Spec{
+		Name:         "Weekly capacity",
+		DashboardUID: "capacity",
+		Schedule:     "0 8 * * 1",
+		Recipients:   []string{"managers@example.com"},
+	}
+	spec.SetDefaults()
+	return spec
+}
+
+func TestSpecSetDefaults(t *testing.T) {
+	spec := Spec{}
+	spec.SetDefaults()
+
+	assert.Equal(t, FormatPDF, spec.Format)
+	assert.Equal(t, TimeRange{From: "now-24h", To: "now"}, spec.TimeRange)
+	assert.Equal(t, "UTC", spec.Timezone)
+}
+
+func TestSpecValidate(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		mutate func(spec *Spec)
+		err    string
+	}{
+		{
+			desc:   "valid spec",
+			mutate: func(spec *Spec) {},
+		},
+		{
+			desc:   "png with panel",
+			mutate: func(spec *Spec) { spec.Format = FormatPNG; spec.PanelID = 2 },
+		},
+		{
+			desc:   "missing name",
+			mutate: func(spec *Spec) { spec.Name = "" },
+			err:    "name is required",
+		},
+		{
+			desc:   "missing dashboard",
+			mutate: func(spec *Spec) { spec.DashboardUID = "" },
+			err:    "dashboard UID is required",
+		},
+		{
+			desc:   "unsupported format",
+			mutate: func(spec *Spec) { spec.Format = "csv" },
+			err:    "unsupported format",
+		},
+		{
+			desc:   "pdf with panel",
+			mutate: func(spec *Spec) { spec.PanelID = 2 },
+			err:    "panel ID is only supported by png reports",
+		},
+		{
+			desc:   "invalid timezone",
+			mutate: func(spec *Spec) { spec.Timezone = "Mars/Olympus_Mons" },
+			err:    "invalid timezone",
+		},
+		{
+			desc:   "invalid schedule",
+			mutate: func(spec *Spec) { spec.Schedule = "every monday" },
+			err:    "invalid schedule",
+		},
+		{
+			desc:   "no recipients",
+			mutate: func(spec *Spec) { spec.Recipients = nil },
+			err:    "at least one recipient is required",
+		},
+		{
+			desc:   "invalid recipient",
+			mutate: func(spec *Spec) { spec.Recipients = []string{"managers"} },
+			err:    "invalid recipient",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			spec := validSpec()
+			tc.mutate(&spec)
+
+			err := spec.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidReport)
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestSpecNextRun(t *testing.T) {
+	spec := validSpec()
+	spec.Timezone = "Europe/Berlin"
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	// Sunday 2024-03-03 at 12:00 UTC.
+	now := time.Date(2024, 3, 3, 12, 0, 0, 0, time.UTC)
+
+	next, err := spec.NextRun(now)
+	require.NoError(t, err)
+	assert.True(t, next.Equal(time.Date(2024, 3, 4, 8, 0, 0, 0, berlin)), "expected Monday 08:00 in Berlin, got %s", next)
+
+	following, err := spec.NextRun(next)
+	require.NoError(t, err)
+	assert.True(t, following.Equal(time.Date(2024, 3, 11, 8, 0, 0, 0, berlin)), "expected the following Monday, got %s", following)
+}
@@ -0,0 +1,141 @@
+package dashboardreportimpl
+
+import (
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboardreport"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+const rootUrl = "/api/dashboard-reports"
+
+func (s *Service) registerAPIEndpoints(routeRegister routing.RouteRegister) {
+	authorize := ac.Middleware(s.accessControl)
+
+	routeRegister.Group(rootUrl, func(subrouter routing.RouteRegister) {
+		subrouter.Get("/", authorize(ac.EvalPermission(ActionRead)), routing.Wrap(s.handleList))
+		subrouter.Post("/", authorize(ac.EvalPermission(ActionWrite)), routing.Wrap(s.handleCreate))
+		subrouter.Get("/:uid", authorize(ac.EvalPermission(ActionRead)), routing.Wrap(s.handleGet))
+		subrouter.Put("/:uid", authorize(ac.EvalPermission(ActionWrite)), routing.Wrap(s.handleUpdate))
+		subrouter.Delete("/:uid", authorize(ac.EvalPermission(ActionWrite)), routing.Wrap(s.handleDelete))
+		subrouter.Get("/:uid/deliveries", authorize(ac.EvalPermission(ActionRead)), routing.Wrap(s.handleListDeliveries))
+		subrouter.Post("/:uid/send", authorize(ac.EvalPermission(ActionWrite)), routing.Wrap(s.handleSend))
+	})
+}
+
+func (s *Service) handleList(c *contextmodel.ReqContext) response.Response {
+	reports, err := s.List(c.Req.Context(), c.SignedInUser.GetOrgID())
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to list reports", err)
+	}
+	return response.JSON(http.StatusOK, reports)
+}
+
+func (s *Service) handleGet(c *contextmodel.ReqContext) response.Response {
+	report, err := s.Get(c.Req.Context(), c.SignedInUser.GetOrgID(), web.Params(c.Req)[":uid"])
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get report", err)
+	}
+	return response.JSON(http.StatusOK, report)
+}
+
+func (s *Service) handleCreate(c *contextmodel.ReqContext) response.Response {
+	spec := dashboardreport.Spec{}
+	if err := web.Bind(c.Req, &spec); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	if resp := s.authorizeDashboard(c, spec.DashboardUID); resp != nil {
+		return resp
+	}
+
+	userID, _ := identity.UserIdentifier(c.SignedInUser.GetID())
+	report, err := s.Create(c.Req.Context(), &dashboardreport.CreateReportCommand{
+		OrgID:  c.SignedInUser.GetOrgID(),
+		UserID: userID,
+		Spec:   spec,
+	})
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to create report", err)
+	}
+	return response.JSON(http.StatusOK, report)
+}
+
+func (s *Service) handleUpdate(c *contextmodel.ReqContext) response.Response {
+	spec := dashboardreport.Spec{}
+	if err := web.Bind(c.Req, &spec); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	if resp := s.authorizeDashboard(c, spec.DashboardUID); resp != nil {
+		return resp
+	}
+
+	userID, _ := identity.UserIdentifier(c.SignedInUser.GetID())
+	report, err := s.Update(c.Req.Context(), &dashboardreport.UpdateReportCommand{
+		OrgID:  c.SignedInUser.GetOrgID(),
+		UserID: userID,
+		UID:    web.Params(c.Req)[":uid"],
+		Spec:   spec,
+	})
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to update report", err)
+	}
+	return response.JSON(http.StatusOK, report)
+}
+
+// authorizeDashboard checks that the user can read the dashboard of a report.
+// Reports are rendered on behalf of the user that last saved them, without this check users could send themselves
+// dashboards they cannot see through reports saved by someone else.
+func (s *Service) authorizeDashboard(c *contextmodel.ReqContext, uid string) response.Response {
+	if uid == "" {
+		return nil
+	}
+
+	evaluator := ac.EvalPermission(dashboards.ActionDashboardsRead, dashboards.ScopeDashboardsProvider.GetResourceScopeUID(uid))
+	ok, err := s.accessControl.Evaluate(c.Req.Context(), c.SignedInUser, evaluator)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "failed to check dashboard permissions", err)
+	}
+	if !ok {
+		return response.Error(http.StatusForbidden, "you do not have permission to read the dashboard of the report", nil)
+	}
+	return nil
+}
+
+func (s *Service) handleDelete(c *contextmodel.ReqContext) response.Response {
+	if err := s.Delete(c.Req.Context(), c.SignedInUser.GetOrgID(), web.Params(c.Req)[":uid"]); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to delete report", err)
+	}
+	return response.Success("Report deleted")
+}
+
+// handleListDeliveries returns the most recent deliveries of a report, the number is set by the limit parameter.
+func (s *Service) handleListDeliveries(c *contextmodel.ReqContext) response.Response {
+	deliveries, err := s.ListDeliveries(c.Req.Context(), c.SignedInUser.GetOrgID(), web.Params(c.Req)[":uid"], c.QueryInt("limit"))
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to list report deliveries", err)
+	}
+	return response.JSON(http.StatusOK, deliveries)
+}
+
+// handleSend sends a report right away and returns the delivery, which records whether sending failed.
+func (s *Service) handleSend(c *contextmodel.ReqContext) response.Response {
+	report, err := s.Get(c.Req.Context(), c.SignedInUser.GetOrgID(), web.Params(c.Req)[":uid"])
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get report", err)
+	}
+	if resp := s.authorizeDashboard(c, report.DashboardUID); resp != nil {
+		return resp
+	}
+
+	delivery, err := s.Send(c.Req.Context(), report.OrgID, report.UID)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to send report", err)
+	}
+	return response.JSON(http.StatusOK, delivery)
+}
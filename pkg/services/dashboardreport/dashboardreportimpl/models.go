@@ -0,0 +1,47 @@
+package dashboardreportimpl
+
+import (
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/org"
+)
+
+const (
+	ActionRead  = "dashboardreports:read"
+	ActionWrite = "dashboardreports:write"
+)
+
+var (
+	reportReaderRole = accesscontrol.RoleDTO{
+		Name:        "fixed:dashboardreports:reader",
+		DisplayName: "Dashboard reports reader",
+		Description: "Read scheduled dashboard reports and their delivery history",
+		Group:       "Dashboard reports",
+		Permissions: []accesscontrol.Permission{
+			{Action: ActionRead},
+		},
+	}
+
+	reportWriterRole = accesscontrol.RoleDTO{
+		Name:        "fixed:dashboardreports:writer",
+		DisplayName: "Dashboard reports writer",
+		Description: "Create, update, delete and send scheduled dashboard reports",
+		Group:       "Dashboard reports",
+		Permissions: []accesscontrol.Permission{
+			{Action: ActionRead},
+			{Action: ActionWrite},
+		},
+	}
+)
+
+func (s *Service) declareFixedRoles(ac accesscontrol.Service) error {
+	reportReader := accesscontrol.RoleRegistration{
+		Role:   reportReaderRole,
+		Grants: []string{string(org.RoleEditor)},
+	}
+	reportWriter := accesscontrol.RoleRegistration{
+		Role:   reportWriterRole,
+		Grants: []string{string(org.RoleAdmin)},
+	}
+
+	return ac.DeclareFixedRoles(reportReader, reportWriter)
+}
@@ -0,0 +1,177 @@
+package dashboardreportimpl
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+
+	"github.com/grafana/authlib/claims"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/models"
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/dashboardreport"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/notifications"
+	"github.com/grafana/grafana/pkg/services/rendering"
+)
+
+const (
+	emailTemplate = "dashboard_report"
+	renderWidth   = 1200
+	// renderFullHeight renders the whole dashboard instead of the first screen of it.
+	renderFullHeight = -1
+	panelHeight      = 600
+)
+
+// owner resolves the user the report is sent on behalf of, and checks that they can still read its dashboard.
+// Their permissions are resolved again for every delivery, so a report stops being sent when its owner loses access.
+func (s *Service) owner(ctx context.Context, report *dashboardreport.Report) (identity.Requester, error) {
+	owner, err := s.identities.ResolveIdentity(ctx, report.OrgID, identity.NewTypedID(claims.TypeUser, report.UpdatedBy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve the user the report is sent on behalf of: %w", err)
+	}
+
+	evaluator := ac.EvalPermission(dashboards.ActionDashboardsRead, dashboards.ScopeDashboardsProvider.GetResourceScopeUID(report.DashboardUID))
+	ok, err := s.accessControl.Evaluate(ctx, owner, evaluator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check dashboard permissions: %w", err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("user %d, who last saved the report, cannot read dashboard %s", report.UpdatedBy, report.DashboardUID)
+	}
+	return owner, nil
+}
+
+// send renders the dashboard of the report with the permissions of its owner and emails it to the recipients.
+func (s *Service) send(ctx context.Context, report *dashboardreport.Report) error {
+	owner, err := s.owner(ctx, report)
+	if err != nil {
+		return err
+	}
+	ctx = identity.WithRequester(ctx, owner)
+
+	dash, err := s.dashboards.GetDashboard(ctx, &dashboards.GetDashboardQuery{OrgID: report.OrgID, UID: report.DashboardUID})
+	if err != nil {
+		return fmt.Errorf("failed to get dashboard: %w", err)
+	}
+
+	renderType := rendering.RenderPDF
+	width, height := renderWidth, renderFullHeight
+	if report.Format == dashboardreport.FormatPNG {
+		renderType = rendering.RenderPNG
+	}
+	if report.PanelID != 0 {
+		height = panelHeight
+	}
+
+	result, err := s.renderer.Render(ctx, renderType, rendering.Opts{
+		CommonOpts: rendering.CommonOpts{
+			TimeoutOpts: rendering.TimeoutOpts{
+				Timeout: s.renderTimeout,
+			},
+			AuthOpts: rendering.AuthOpts{
+				OrgID:   report.OrgID,
+				UserID:  report.UpdatedBy,
+				OrgRole: owner.GetOrgRole(),
+			},
+			Path:            renderPath(dash, report),
+			Timezone:        report.Timezone,
+			ConcurrentLimit: s.concurrentLimit,
+		},
+		ErrorOpts: rendering.ErrorOpts{
+			ErrorConcurrentLimitReached: true,
+			ErrorRenderUnavailable:      true,
+		},
+		Width:             width,
+		Height:            height,
+		DeviceScaleFactor: s.imageScale,
+		Theme:             models.ThemeLight,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to render dashboard: %w", err)
+	}
+
+	content, err := os.ReadFile(result.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read rendered dashboard: %w", err)
+	}
+
+	err = s.email.SendEmailCommandHandlerSync(ctx, &notifications.SendEmailCommandSync{
+		SendEmailCommand: notifications.SendEmailCommand{
+			To:       report.Recipients,
+			Template: emailTemplate,
+			Data: map[string]any{
+				"ReportName":     report.Name,
+				"DashboardTitle": dash.Title,
+				"DashboardPath":  dashboardPath(dash, report),
+				"Message":        report.Message,
+				"TimeFrom":       report.TimeRange.From,
+				"TimeTo":         report.TimeRange.To,
+			},
+			AttachedFiles: []*notifications.SendEmailAttachFile{
+				{Name: attachmentName(dash, report), Content: content},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// renderPath returns the path of the page the image renderer renders, relative to the root URL.
+func renderPath(dash *dashboards.Dashboard, report *dashboardreport.Report) string {
+	if report.PanelID != 0 {
+		return reportURL(path.Join("d-solo", dash.UID, dash.Slug), "panelId", dash, report).String()
+	}
+
+	u := reportURL(path.Join("d", dash.UID, dash.Slug), "", dash, report)
+	u.RawQuery += "&kiosk"
+	return u.String()
+}
+
+// dashboardPath returns the path of the dashboard linked in the email, relative to the root URL.
+func dashboardPath(dash *dashboards.Dashboard, report *dashboardreport.Report) string {
+	return reportURL(path.Join("d", dash.UID, dash.Slug), "viewPanel", dash, report).String()
+}
+
+// reportURL returns the URL of a dashboard page with the time range and variables of the report.
+// The panel of the report is set in the panelParam query parameter.
+func reportURL(p string, panelParam string, dash *dashboards.Dashboard, report *dashboardreport.Report) *url.URL {
+	q := url.Values{}
+	q.Set("orgId", strconv.FormatInt(dash.OrgID, 10))
+	q.Set("from", report.TimeRange.From)
+	q.Set("to", report.TimeRange.To)
+	if report.Timezone != "" {
+		q.Set("timezone", report.Timezone)
+	}
+	if panelParam != "" && report.PanelID != 0 {
+		q.Set(panelParam, strconv.FormatInt(report.PanelID, 10))
+	}
+
+	names := make([]string, 0, len(report.Variables))
+	for name := range report.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range report.Variables[name] {
+			q.Add("var-"+name, value)
+		}
+	}
+
+	return &url.URL{Path: p, RawQuery: q.Encode()}
+}
+
+func attachmentName(dash *dashboards.Dashboard, report *dashboardreport.Report) string {
+	name := dash.Slug
+	if name == "" {
+		name = "dashboard"
+	}
+	return fmt.Sprintf("%s.%s", name, report.Format.Extension())
+}
@@ -0,0 +1,296 @@
+package dashboardreportimpl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/serverlock"
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/authn"
+	"github.com/grafana/grafana/pkg/services/dashboardreport"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/notifications"
+	"github.com/grafana/grafana/pkg/services/rendering"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const (
+	schedulerInterval    = time.Minute
+	schedulerActionName  = "send scheduled dashboard reports"
+	defaultRenderTimeout = time.Minute
+)
+
+var _ dashboardreport.Service = (*Service)(nil)
+
+// errNoOwner is returned when a report is saved without a user, e.g. with an API key.
+// Reports are sent on behalf of the user that last saved them.
+var errNoOwner = dashboardreport.ErrInvalidReport.Errorf("reports must be saved by a user or a service account")
+
+type serverLocker interface {
+	LockAndExecute(ctx context.Context, actionName string, maxInterval time.Duration, fn func(ctx context.Context)) error
+}
+
+// identityResolver resolves the identity, with permissions, of the user a report is sent on behalf of.
+type identityResolver interface {
+	ResolveIdentity(ctx context.Context, orgID int64, typedID string) (*authn.Identity, error)
+}
+
+type Service struct {
+	accessControl ac.AccessControl
+	identities    identityResolver
+	store         store
+	dashboards    dashboards.DashboardService
+	renderer      rendering.Service
+	email         notifications.EmailSender
+	serverLock    serverLocker
+	log           log.Logger
+	now           func() time.Time
+
+	enabled         bool
+	renderTimeout   time.Duration
+	concurrentLimit int
+	imageScale      float64
+}
+
+func ProvideService(
+	accessControl ac.AccessControl,
+	accesscontrolService ac.Service,
+	cfg *setting.Cfg,
+	routeRegister routing.RouteRegister,
+	authnService authn.Service,
+	sql db.DB,
+	dashboardService dashboards.DashboardService,
+	renderService rendering.Service,
+	emailSender notifications.EmailSender,
+	serverLock *serverlock.ServerLockService,
+) (*Service, error) {
+	section := cfg.SectionWithEnvOverrides("dashboard_reports")
+	s := &Service{
+		accessControl:   accessControl,
+		identities:      authnService,
+		store:           &xormStore{db: sql},
+		dashboards:      dashboardService,
+		renderer:        renderService,
+		email:           emailSender,
+		serverLock:      serverLock,
+		log:             log.New("dashboardreport"),
+		now:             time.Now,
+		enabled:         section.Key("enabled").MustBool(false),
+		renderTimeout:   section.Key("render_timeout").MustDuration(defaultRenderTimeout),
+		concurrentLimit: cfg.RendererConcurrentRequestLimit,
+		imageScale:      cfg.RendererDefaultImageScale,
+	}
+
+	if !s.enabled {
+		return s, nil
+	}
+
+	if err := s.declareFixedRoles(accesscontrolService); err != nil {
+		return nil, err
+	}
+
+	s.registerAPIEndpoints(routeRegister)
+
+	return s, nil
+}
+
+// Run sends the reports that are due every minute. In HA setups only one instance sends the reports of a run.
+func (s *Service) Run(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	ticker := time.NewTicker(schedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// The lock only keeps most instances from listing the due reports. It is released once its interval has
+			// passed, even if the run is still sending reports, so every report is also claimed before it is sent.
+			err := s.serverLock.LockAndExecute(ctx, schedulerActionName, schedulerInterval/2, s.sendDueReports)
+			if err != nil {
+				s.log.Error("Failed to lock and execute scheduled dashboard reports", "error", err)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *Service) sendDueReports(ctx context.Context) {
+	now := s.now()
+	reports, err := s.store.ListDue(ctx, now)
+	if err != nil {
+		s.log.Error("Failed to list scheduled dashboard reports", "error", err)
+		return
+	}
+
+	for _, report := range reports {
+		// The report is claimed by moving its next run before it is sent. Only one run, of any instance, can move it
+		// from the run that was listed as due, so that a report is sent once even if runs overlap, and a report that
+		// fails or takes longer than the scheduler interval to send is not sent again by the next run.
+		current := report.NextRun
+		if err := s.schedule(report, now); err != nil {
+			s.log.Error("Failed to schedule dashboard report, it won't be sent again until it is updated", "orgID", report.OrgID, "uid", report.UID, "error", err)
+		}
+		claimed, err := s.store.ClaimNextRun(ctx, report.ID, current, report.NextRun)
+		if err != nil {
+			s.log.Error("Failed to store next run of dashboard report", "orgID", report.OrgID, "uid", report.UID, "error", err)
+			continue
+		}
+		if !claimed {
+			s.log.Debug("Dashboard report was claimed by another run or updated, skipping it", "orgID", report.OrgID, "uid", report.UID)
+			continue
+		}
+
+		s.deliver(ctx, report, dashboardreport.TriggerSchedule)
+	}
+}
+
+// schedule sets the next run of the report after the given time, or clears it if the report is paused.
+func (s *Service) schedule(report *dashboardreport.Report, after time.Time) error {
+	report.NextRun = nil
+	if report.Paused {
+		return nil
+	}
+
+	next, err := report.Spec.NextRun(after)
+	if err != nil {
+		return err
+	}
+	report.NextRun = &next
+	return nil
+}
+
+// deliver renders and sends the report and records the delivery.
+func (s *Service) deliver(ctx context.Context, report *dashboardreport.Report, trigger dashboardreport.Trigger) *dashboardreport.Delivery {
+	delivery := &dashboardreport.Delivery{
+		ReportUID:  report.UID,
+		Started:    s.now(),
+		Trigger:    trigger,
+		Status:     dashboardreport.DeliveryStatusSent,
+		Recipients: report.Recipients,
+	}
+
+	if err := s.send(ctx, report); err != nil {
+		s.log.Warn("Failed to send dashboard report", "orgID", report.OrgID, "uid", report.UID, "trigger", trigger, "error", err)
+		delivery.Status = dashboardreport.DeliveryStatusFailed
+		delivery.Error = err.Error()
+	}
+	delivery.DurationMs = s.now().Sub(delivery.Started).Milliseconds()
+
+	if err := s.store.InsertDelivery(ctx, report, delivery); err != nil {
+		s.log.Error("Failed to record delivery of dashboard report", "orgID", report.OrgID, "uid", report.UID, "error", err)
+	}
+	return delivery
+}
+
+func (s *Service) Create(ctx context.Context, cmd *dashboardreport.CreateReportCommand) (*dashboardreport.Report, error) {
+	if cmd.UserID == 0 {
+		return nil, errNoOwner
+	}
+	spec := cmd.Spec
+	spec.SetDefaults()
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkDashboard(ctx, cmd.OrgID, spec.DashboardUID); err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	report := &dashboardreport.Report{
+		UID:       util.GenerateShortUID(),
+		OrgID:     cmd.OrgID,
+		Spec:      spec,
+		CreatedBy: cmd.UserID,
+		UpdatedBy: cmd.UserID,
+		Created:   now,
+		Updated:   now,
+	}
+	if err := s.schedule(report, now); err != nil {
+		return nil, dashboardreport.ErrInvalidReport.Errorf("invalid schedule: %w", err)
+	}
+
+	if err := s.store.Insert(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *Service) Update(ctx context.Context, cmd *dashboardreport.UpdateReportCommand) (*dashboardreport.Report, error) {
+	if cmd.UserID == 0 {
+		return nil, errNoOwner
+	}
+	report, err := s.store.Get(ctx, cmd.OrgID, cmd.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := cmd.Spec
+	spec.SetDefaults()
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkDashboard(ctx, cmd.OrgID, spec.DashboardUID); err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	report.Spec = spec
+	report.UpdatedBy = cmd.UserID
+	report.Updated = now
+	if err := s.schedule(report, now); err != nil {
+		return nil, dashboardreport.ErrInvalidReport.Errorf("invalid schedule: %w", err)
+	}
+
+	if err := s.store.Update(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// checkDashboard returns an error if the dashboard of a report does not exist.
+func (s *Service) checkDashboard(ctx context.Context, orgID int64, uid string) error {
+	_, err := s.dashboards.GetDashboard(ctx, &dashboards.GetDashboardQuery{OrgID: orgID, UID: uid})
+	if errors.Is(err, dashboards.ErrDashboardNotFound) {
+		return dashboardreport.ErrInvalidReport.Errorf("dashboard %s not found", uid)
+	}
+	return err
+}
+
+func (s *Service) Delete(ctx context.Context, orgID int64, uid string) error {
+	return s.store.Delete(ctx, orgID, uid)
+}
+
+func (s *Service) Get(ctx context.Context, orgID int64, uid string) (*dashboardreport.Report, error) {
+	return s.store.Get(ctx, orgID, uid)
+}
+
+func (s *Service) List(ctx context.Context, orgID int64) ([]*dashboardreport.Report, error) {
+	return s.store.List(ctx, orgID)
+}
+
+func (s *Service) ListDeliveries(ctx context.Context, orgID int64, uid string, limit int) ([]*dashboardreport.Delivery, error) {
+	report, err := s.store.Get(ctx, orgID, uid)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.ListDeliveries(ctx, report, limit)
+}
+
+// Send renders and sends a report right away, outside of its schedule. Failures to render or send the report are
+// not returned as errors but recorded in the returned delivery, the same way as for scheduled deliveries.
+func (s *Service) Send(ctx context.Context, orgID int64, uid string) (*dashboardreport.Delivery, error) {
+	report, err := s.store.Get(ctx, orgID, uid)
+	if err != nil {
+		return nil, err
+	}
+	return s.deliver(ctx, report, dashboardreport.TriggerManual), nil
+}
@@ -0,0 +1,366 @@
+package dashboardreportimpl
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/authlib/claims"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/authn"
+	"github.com/grafana/grafana/pkg/services/dashboardreport"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/notifications"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/rendering"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/tests/testsuite"
+)
+
+func TestMain(m *testing.M) {
+	testsuite.Run(m)
+}
+
+type fakeIdentityResolver struct {
+	identities map[string]*authn.Identity
+}
+
+func (f *fakeIdentityResolver) ResolveIdentity(_ context.Context, _ int64, typedID string) (*authn.Identity, error) {
+	ident, ok := f.identities[typedID]
+	if !ok {
+		return nil, user.ErrUserNotFound
+	}
+	return ident, nil
+}
+
+type fakeServerLock struct{}
+
+func (fakeServerLock) LockAndExecute(ctx context.Context, _ string, _ time.Duration, fn func(ctx context.Context)) error {
+	fn(ctx)
+	return nil
+}
+
+// listBarrierStore makes concurrent runs wait for each other after listing the due reports, so that they all find the
+// same reports due.
+type listBarrierStore struct {
+	store
+	listed *sync.WaitGroup
+}
+
+func (s *listBarrierStore) ListDue(ctx context.Context, now time.Time) ([]*dashboardreport.Report, error) {
+	reports, err := s.store.ListDue(ctx, now)
+	s.listed.Done()
+	s.listed.Wait()
+	return reports, err
+}
+
+type testEnv struct {
+	service *Service
+	// owner is the identity reports saved by user 2 are sent on behalf of
+	owner    *authn.Identity
+	renderer *rendering.MockService
+	email    *notifications.NotificationServiceMock
+	now      time.Time
+}
+
+func setupTestService(t *testing.T) *testEnv {
+	t.Helper()
+
+	dashboardService := dashboards.NewFakeDashboardService(t)
+	dashboardService.On("GetDashboard", mock.Anything, mock.MatchedBy(func(q *dashboards.GetDashboardQuery) bool {
+		return q.UID == "capacity"
+	})).Return(&dashboards.Dashboard{OrgID: 1, UID: "capacity", Slug: "capacity", Title: "Capacity"}, nil).Maybe()
+	dashboardService.On("GetDashboard", mock.Anything, mock.Anything).Return(nil, dashboards.ErrDashboardNotFound).Maybe()
+
+	env := &testEnv{
+		owner: &authn.Identity{
+			ID:       "2",
+			Type:     claims.TypeUser,
+			OrgID:    1,
+			OrgRoles: map[int64]org.RoleType{1: org.RoleViewer},
+			Permissions: map[int64]map[string][]string{
+				1: {dashboards.ActionDashboardsRead: {dashboards.ScopeDashboardsProvider.GetResourceScopeUID("capacity")}},
+			},
+		},
+		renderer: rendering.NewMockService(gomock.NewController(t)),
+		email:    &notifications.NotificationServiceMock{},
+		// Sunday 2024-03-03 at 12:00 UTC.
+		now: time.Date(2024, 3, 3, 12, 0, 0, 0, time.UTC),
+	}
+	env.service = &Service{
+		accessControl: acimpl.ProvideAccessControlTest(),
+		identities:    &fakeIdentityResolver{identities: map[string]*authn.Identity{"user:2": env.owner}},
+		store:         &xormStore{db: db.InitTestDB(t)},
+		dashboards:    dashboardService,
+		renderer:      env.renderer,
+		email:         env.email,
+		serverLock:    fakeServerLock{},
+		log:           log.NewNopLogger(),
+		now:           func() time.Time { return env.now },
+		enabled:       true,
+		renderTimeout: time.Minute,
+	}
+	return env
+}
+
+func weeklyReport() dashboardreport.Spec {
+	return dashboardreport.Spec{
+		Name:         "Weekly capacity",
+		DashboardUID: "capacity",
+		Variables:    map[string][]string{"cluster": {"eu-west", "us-east"}},
+		TimeRange:    dashboardreport.TimeRange{From: "now-7d", To: "now"},
+		Schedule:     "0 8 * * 1",
+		Recipients:   []string{"managers@example.com"},
+		Message:      "Capacity of last week",
+	}
+}
+
+func TestIntegrationService(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+
+	t.Run("should create reports scheduled for their next run", func(t *testing.T) {
+		env := setupTestService(t)
+
+		report, err := env.service.Create(ctx, &dashboardreport.CreateReportCommand{OrgID: 1, UserID: 2, Spec: weeklyReport()})
+		require.NoError(t, err)
+		require.NotEmpty(t, report.UID)
+		assert.Equal(t, dashboardreport.FormatPDF, report.Format)
+		require.NotNil(t, report.NextRun)
+		assert.True(t, report.NextRun.Equal(time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC)))
+
+		stored, err := env.service.Get(ctx, 1, report.UID)
+		require.NoError(t, err)
+		assert.Equal(t, report.Spec, stored.Spec)
+		assert.Equal(t, int64(2), stored.CreatedBy)
+		assert.Equal(t, int64(2), stored.UpdatedBy)
+		assert.True(t, report.NextRun.Equal(*stored.NextRun))
+
+		_, err = env.service.Get(ctx, 2, report.UID)
+		require.ErrorIs(t, err, dashboardreport.ErrReportNotFound)
+	})
+
+	t.Run("should reject reports saved without a user", func(t *testing.T) {
+		env := setupTestService(t)
+
+		_, err := env.service.Create(ctx, &dashboardreport.CreateReportCommand{OrgID: 1, Spec: weeklyReport()})
+		require.ErrorIs(t, err, dashboardreport.ErrInvalidReport)
+	})
+
+	t.Run("should reject reports of dashboards that do not exist", func(t *testing.T) {
+		env := setupTestService(t)
+
+		spec := weeklyReport()
+		spec.DashboardUID = "missing"
+		_, err := env.service.Create(ctx, &dashboardreport.CreateReportCommand{OrgID: 1, UserID: 2, Spec: spec})
+		require.ErrorIs(t, err, dashboardreport.ErrInvalidReport)
+	})
+
+	t.Run("should not schedule paused reports", func(t *testing.T) {
+		env := setupTestService(t)
+
+		report, err := env.service.Create(ctx, &dashboardreport.CreateReportCommand{OrgID: 1, UserID: 2, Spec: weeklyReport()})
+		require.NoError(t, err)
+
+		spec := report.Spec
+		spec.Paused = true
+		updated, err := env.service.Update(ctx, &dashboardreport.UpdateReportCommand{OrgID: 1, UserID: 3, UID: report.UID, Spec: spec})
+		require.NoError(t, err)
+		assert.Nil(t, updated.NextRun)
+		assert.Equal(t, int64(2), updated.CreatedBy)
+		assert.Equal(t, int64(3), updated.UpdatedBy)
+
+		env.now = time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC)
+		env.service.sendDueReports(ctx)
+
+		deliveries, err := env.service.ListDeliveries(ctx, 1, report.UID, 0)
+		require.NoError(t, err)
+		assert.Empty(t, deliveries)
+	})
+
+	t.Run("should send due reports once and record the delivery", func(t *testing.T) {
+		env := setupTestService(t)
+
+		report, err := env.service.Create(ctx, &dashboardreport.CreateReportCommand{OrgID: 1, UserID: 2, Spec: weeklyReport()})
+		require.NoError(t, err)
+
+		rendered := filepath.Join(t.TempDir(), "report.pdf")
+		require.NoError(t, os.WriteFile(rendered, []byte("%PDF"), 0600))
+
+		env.renderer.EXPECT().Render(gomock.Any(), rendering.RenderPDF, gomock.Any(), nil).DoAndReturn(
+			func(ctx context.Context, _ rendering.RenderType, opts rendering.Opts, _ rendering.Session) (*rendering.RenderResult, error) {
+				requester, err := identity.GetRequester(ctx)
+				require.NoError(t, err)
+				assert.Equal(t, "user:2", requester.GetID())
+				assert.Equal(t, rendering.AuthOpts{OrgID: 1, UserID: 2, OrgRole: org.RoleViewer}, opts.AuthOpts)
+				assert.Equal(t, "d/capacity/capacity?from=now-7d&orgId=1&timezone=UTC&to=now&var-cluster=eu-west&var-cluster=us-east&kiosk", opts.Path)
+				return &rendering.RenderResult{FilePath: rendered}, nil
+			}).Times(1)
+
+		// Not due yet.
+		env.service.sendDueReports(ctx)
+
+		env.now = time.Date(2024, 3, 4, 8, 0, 30, 0, time.UTC)
+		env.service.sendDueReports(ctx)
+		// Already sent for this week.
+		env.service.sendDueReports(ctx)
+
+		assert.Equal(t, []string{"managers@example.com"}, env.email.EmailSync.To)
+		assert.Equal(t, "dashboard_report", env.email.EmailSync.Template)
+		assert.Equal(t, "Weekly capacity", env.email.EmailSync.Data["ReportName"])
+		require.Len(t, env.email.EmailSync.AttachedFiles, 1)
+		assert.Equal(t, "capacity.pdf", env.email.EmailSync.AttachedFiles[0].Name)
+		assert.Equal(t, []byte("%PDF"), env.email.EmailSync.AttachedFiles[0].Content)
+
+		stored, err := env.service.Get(ctx, 1, report.UID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.NextRun)
+		assert.True(t, stored.NextRun.Equal(time.Date(2024, 3, 11, 8, 0, 0, 0, time.UTC)))
+
+		deliveries, err := env.service.ListDeliveries(ctx, 1, report.UID, 0)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, dashboardreport.DeliveryStatusSent, deliveries[0].Status)
+		assert.Equal(t, dashboardreport.TriggerSchedule, deliveries[0].Trigger)
+		assert.Equal(t, []string{"managers@example.com"}, deliveries[0].Recipients)
+	})
+
+	t.Run("should send due reports once when runs overlap", func(t *testing.T) {
+		env := setupTestService(t)
+
+		report, err := env.service.Create(ctx, &dashboardreport.CreateReportCommand{OrgID: 1, UserID: 2, Spec: weeklyReport()})
+		require.NoError(t, err)
+
+		rendered := filepath.Join(t.TempDir(), "report.pdf")
+		require.NoError(t, os.WriteFile(rendered, []byte("%PDF"), 0600))
+		env.renderer.EXPECT().Render(gomock.Any(), rendering.RenderPDF, gomock.Any(), nil).Return(&rendering.RenderResult{FilePath: rendered}, nil).Times(1)
+
+		const runs = 2
+		listed := &sync.WaitGroup{}
+		listed.Add(runs)
+		env.service.store = &listBarrierStore{store: env.service.store, listed: listed}
+
+		env.now = time.Date(2024, 3, 4, 8, 0, 30, 0, time.UTC)
+		var wg sync.WaitGroup
+		for i := 0; i < runs; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				env.service.sendDueReports(ctx)
+			}()
+		}
+		wg.Wait()
+
+		deliveries, err := env.service.ListDeliveries(ctx, 1, report.UID, 0)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, dashboardreport.DeliveryStatusSent, deliveries[0].Status)
+
+		stored, err := env.service.Get(ctx, 1, report.UID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.NextRun)
+		assert.True(t, stored.NextRun.Equal(time.Date(2024, 3, 11, 8, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("should record failed deliveries", func(t *testing.T) {
+		env := setupTestService(t)
+
+		report, err := env.service.Create(ctx, &dashboardreport.CreateReportCommand{OrgID: 1, UserID: 2, Spec: weeklyReport()})
+		require.NoError(t, err)
+
+		env.renderer.EXPECT().Render(gomock.Any(), rendering.RenderPDF, gomock.Any(), nil).Return(nil, rendering.ErrRenderUnavailable)
+
+		delivery, err := env.service.Send(ctx, 1, report.UID)
+		require.NoError(t, err)
+		assert.Equal(t, dashboardreport.DeliveryStatusFailed, delivery.Status)
+		assert.Equal(t, dashboardreport.TriggerManual, delivery.Trigger)
+		assert.Contains(t, delivery.Error, rendering.ErrRenderUnavailable.Error())
+
+		deliveries, err := env.service.ListDeliveries(ctx, 1, report.UID, 0)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, delivery.ID, deliveries[0].ID)
+		assert.Equal(t, delivery.Error, deliveries[0].Error)
+	})
+
+	t.Run("should not send reports once the user they are sent on behalf of loses access", func(t *testing.T) {
+		env := setupTestService(t)
+
+		report, err := env.service.Create(ctx, &dashboardreport.CreateReportCommand{OrgID: 1, UserID: 2, Spec: weeklyReport()})
+		require.NoError(t, err)
+
+		// The renderer mock fails the test if the report is rendered.
+		env.owner.Permissions[1] = map[string][]string{}
+		delivery, err := env.service.Send(ctx, 1, report.UID)
+		require.NoError(t, err)
+		assert.Equal(t, dashboardreport.DeliveryStatusFailed, delivery.Status)
+		assert.Contains(t, delivery.Error, "cannot read dashboard capacity")
+
+		spec := report.Spec
+		spec.Name = "Saved by a deleted user"
+		_, err = env.service.Update(ctx, &dashboardreport.UpdateReportCommand{OrgID: 1, UserID: 3, UID: report.UID, Spec: spec})
+		require.NoError(t, err)
+		delivery, err = env.service.Send(ctx, 1, report.UID)
+		require.NoError(t, err)
+		assert.Equal(t, dashboardreport.DeliveryStatusFailed, delivery.Status)
+		assert.Contains(t, delivery.Error, user.ErrUserNotFound.Error())
+	})
+
+	t.Run("should record failures to send the email", func(t *testing.T) {
+		env := setupTestService(t)
+		env.email.ShouldError = errors.New("smtp unavailable")
+
+		spec := weeklyReport()
+		spec.Format = dashboardreport.FormatPNG
+		spec.PanelID = 4
+		report, err := env.service.Create(ctx, &dashboardreport.CreateReportCommand{OrgID: 1, UserID: 2, Spec: spec})
+		require.NoError(t, err)
+
+		rendered := filepath.Join(t.TempDir(), "report.png")
+		require.NoError(t, os.WriteFile(rendered, []byte("png"), 0600))
+		env.renderer.EXPECT().Render(gomock.Any(), rendering.RenderPNG, gomock.Any(), nil).DoAndReturn(
+			func(_ context.Context, _ rendering.RenderType, opts rendering.Opts, _ rendering.Session) (*rendering.RenderResult, error) {
+				assert.Equal(t, "d-solo/capacity/capacity?from=now-7d&orgId=1&panelId=4&timezone=UTC&to=now&var-cluster=eu-west&var-cluster=us-east", opts.Path)
+				return &rendering.RenderResult{FilePath: rendered}, nil
+			})
+
+		delivery, err := env.service.Send(ctx, 1, report.UID)
+		require.NoError(t, err)
+		assert.Equal(t, dashboardreport.DeliveryStatusFailed, delivery.Status)
+		assert.Contains(t, delivery.Error, "smtp unavailable")
+		assert.Equal(t, "d/capacity/capacity?from=now-7d&orgId=1&timezone=UTC&to=now&var-cluster=eu-west&var-cluster=us-east&viewPanel=4", env.email.EmailSync.Data["DashboardPath"])
+	})
+
+	t.Run("should delete reports with their deliveries", func(t *testing.T) {
+		env := setupTestService(t)
+
+		report, err := env.service.Create(ctx, &dashboardreport.CreateReportCommand{OrgID: 1, UserID: 2, Spec: weeklyReport()})
+		require.NoError(t, err)
+		env.renderer.EXPECT().Render(gomock.Any(), gomock.Any(), gomock.Any(), nil).Return(nil, rendering.ErrRenderUnavailable)
+		_, err = env.service.Send(ctx, 1, report.UID)
+		require.NoError(t, err)
+
+		require.NoError(t, env.service.Delete(ctx, 1, report.UID))
+		require.ErrorIs(t, env.service.Delete(ctx, 1, report.UID), dashboardreport.ErrReportNotFound)
+
+		reports, err := env.service.List(ctx, 1)
+		require.NoError(t, err)
+		assert.Empty(t, reports)
+	})
+}
@@ -0,0 +1,337 @@
+package dashboardreportimpl
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/dashboardreport"
+)
+
+const (
+	defaultDeliveriesLimit = 50
+	maxDeliveriesLimit     = 1000
+)
+
+type store interface {
+	Insert(ctx context.Context, report *dashboardreport.Report) error
+	Update(ctx context.Context, report *dashboardreport.Report) error
+	Delete(ctx context.Context, orgID int64, uid string) error
+	Get(ctx context.Context, orgID int64, uid string) (*dashboardreport.Report, error)
+	List(ctx context.Context, orgID int64) ([]*dashboardreport.Report, error)
+	// ListDue returns the reports that are not paused and scheduled to be sent at or before now, in all organizations.
+	ListDue(ctx context.Context, now time.Time) ([]*dashboardreport.Report, error)
+	// ClaimNextRun moves the next run of a report from current to next, and returns false without changing it if the
+	// next run is no longer current, because another instance claimed the run or the report was updated.
+	ClaimNextRun(ctx context.Context, id int64, current, next *time.Time) (bool, error)
+	InsertDelivery(ctx context.Context, report *dashboardreport.Report, delivery *dashboardreport.Delivery) error
+	ListDeliveries(ctx context.Context, report *dashboardreport.Report, limit int) ([]*dashboardreport.Delivery, error)
+}
+
+// reportRow is the database representation of a report.
+type reportRow struct {
+	ID           int64  `xorm:"pk autoincr 'id'"`
+	UID          string `xorm:"uid"`
+	OrgID        int64  `xorm:"org_id"`
+	Name         string `xorm:"name"`
+	DashboardUID string `xorm:"dashboard_uid"`
+	PanelID      int64  `xorm:"panel_id"`
+	Variables    string `xorm:"variables"`
+	TimeFrom     string `xorm:"time_from"`
+	TimeTo       string `xorm:"time_to"`
+	Format       string `xorm:"format"`
+	Schedule     string `xorm:"schedule"`
+	Timezone     string `xorm:"timezone"`
+	Recipients   string `xorm:"recipients"`
+	Message      string `xorm:"message"`
+	Paused       bool   `xorm:"paused"`
+	CreatedBy    int64  `xorm:"created_by"`
+	UpdatedBy    int64  `xorm:"updated_by"`
+	Created      int64  `xorm:"'created'"`
+	Updated      int64  `xorm:"'updated'"`
+	// NextRun is in epoch milliseconds, zero when the report is not scheduled
+	NextRun int64 `xorm:"next_run"`
+}
+
+func (reportRow) TableName() string {
+	return "dashboard_report"
+}
+
+// deliveryRow is the database representation of a delivery of a report.
+type deliveryRow struct {
+	ID          int64  `xorm:"pk autoincr 'id'"`
+	OrgID       int64  `xorm:"org_id"`
+	ReportID    int64  `xorm:"report_id"`
+	Started     int64  `xorm:"started"`
+	DurationMs  int64  `xorm:"duration_ms"`
+	TriggeredBy string `xorm:"triggered_by"`
+	Status      string `xorm:"status"`
+	Error       string `xorm:"error_message"`
+	Recipients  string `xorm:"recipients"`
+}
+
+func (deliveryRow) TableName() string {
+	return "dashboard_report_delivery"
+}
+
+type xormStore struct {
+	db db.DB
+}
+
+func (s *xormStore) Insert(ctx context.Context, report *dashboardreport.Report) error {
+	row, err := newReportRow(report)
+	if err != nil {
+		return err
+	}
+
+	return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		if _, err := sess.Insert(row); err != nil {
+			return err
+		}
+		report.ID = row.ID
+		return nil
+	})
+}
+
+func (s *xormStore) Update(ctx context.Context, report *dashboardreport.Report) error {
+	row, err := newReportRow(report)
+	if err != nil {
+		return err
+	}
+
+	return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		affected, err := sess.ID(row.ID).AllCols().Omit("id", "uid", "org_id", "created_by", "created").Update(row)
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return dashboardreport.ErrReportNotFound.Errorf("report %s not found", report.UID)
+		}
+		return nil
+	})
+}
+
+func (s *xormStore) Delete(ctx context.Context, orgID int64, uid string) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context) error {
+		return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+			row := reportRow{}
+			has, err := sess.Where("org_id = ? AND uid = ?", orgID, uid).Get(&row)
+			if err != nil {
+				return err
+			}
+			if !has {
+				return dashboardreport.ErrReportNotFound.Errorf("report %s not found", uid)
+			}
+
+			if _, err := sess.Exec("DELETE FROM dashboard_report_delivery WHERE report_id = ?", row.ID); err != nil {
+				return err
+			}
+			_, err = sess.Exec("DELETE FROM dashboard_report WHERE id = ?", row.ID)
+			return err
+		})
+	})
+}
+
+func (s *xormStore) Get(ctx context.Context, orgID int64, uid string) (*dashboardreport.Report, error) {
+	var report *dashboardreport.Report
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		row := reportRow{}
+		has, err := sess.Where("org_id = ? AND uid = ?", orgID, uid).Get(&row)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return dashboardreport.ErrReportNotFound.Errorf("report %s not found", uid)
+		}
+		report, err = row.toReport()
+		return err
+	})
+	return report, err
+}
+
+func (s *xormStore) List(ctx context.Context, orgID int64) ([]*dashboardreport.Report, error) {
+	return s.find(ctx, "org_id = ?", orgID)
+}
+
+func (s *xormStore) ListDue(ctx context.Context, now time.Time) ([]*dashboardreport.Report, error) {
+	return s.find(ctx, "paused = ? AND next_run > 0 AND next_run <= ?", false, now.UnixMilli())
+}
+
+func (s *xormStore) find(ctx context.Context, where string, args ...any) ([]*dashboardreport.Report, error) {
+	reports := make([]*dashboardreport.Report, 0)
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		rows := make([]reportRow, 0)
+		if err := sess.Where(where, args...).Asc("name", "id").Find(&rows); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			report, err := row.toReport()
+			if err != nil {
+				return err
+			}
+			reports = append(reports, report)
+		}
+		return nil
+	})
+	return reports, err
+}
+
+func (s *xormStore) ClaimNextRun(ctx context.Context, id int64, current, next *time.Time) (bool, error) {
+	claimed := false
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		res, err := sess.Exec("UPDATE dashboard_report SET next_run = ? WHERE id = ? AND next_run = ?", toMillis(next), id, toMillis(current))
+		if err != nil {
+			return err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		claimed = affected == 1
+		return nil
+	})
+	return claimed, err
+}
+
+func (s *xormStore) InsertDelivery(ctx context.Context, report *dashboardreport.Report, delivery *dashboardreport.Delivery) error {
+	recipients, err := json.Marshal(delivery.Recipients)
+	if err != nil {
+		return err
+	}
+
+	row := deliveryRow{
+		OrgID:       report.OrgID,
+		ReportID:    report.ID,
+		Started:     delivery.Started.UnixMilli(),
+		DurationMs:  delivery.DurationMs,
+		TriggeredBy: string(delivery.Trigger),
+		Status:      string(delivery.Status),
+		Error:       delivery.Error,
+		Recipients:  string(recipients),
+	}
+
+	return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		if _, err := sess.Insert(&row); err != nil {
+			return err
+		}
+		delivery.ID = row.ID
+		return nil
+	})
+}
+
+func (s *xormStore) ListDeliveries(ctx context.Context, report *dashboardreport.Report, limit int) ([]*dashboardreport.Delivery, error) {
+	if limit <= 0 {
+		limit = defaultDeliveriesLimit
+	}
+	if limit > maxDeliveriesLimit {
+		limit = maxDeliveriesLimit
+	}
+
+	deliveries := make([]*dashboardreport.Delivery, 0)
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		rows := make([]deliveryRow, 0, limit)
+		if err := sess.Where("report_id = ?", report.ID).Desc("started", "id").Limit(limit).Find(&rows); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			delivery := &dashboardreport.Delivery{
+				ID:         row.ID,
+				ReportUID:  report.UID,
+				Started:    time.UnixMilli(row.Started),
+				DurationMs: row.DurationMs,
+				Trigger:    dashboardreport.Trigger(row.TriggeredBy),
+				Status:     dashboardreport.DeliveryStatus(row.Status),
+				Error:      row.Error,
+			}
+			if err := json.Unmarshal([]byte(row.Recipients), &delivery.Recipients); err != nil {
+				return err
+			}
+			deliveries = append(deliveries, delivery)
+		}
+		return nil
+	})
+	return deliveries, err
+}
+
+func newReportRow(report *dashboardreport.Report) (*reportRow, error) {
+	row := &reportRow{
+		ID:           report.ID,
+		UID:          report.UID,
+		OrgID:        report.OrgID,
+		Name:         report.Name,
+		DashboardUID: report.DashboardUID,
+		PanelID:      report.PanelID,
+		TimeFrom:     report.TimeRange.From,
+		TimeTo:       report.TimeRange.To,
+		Format:       string(report.Format),
+		Schedule:     report.Schedule,
+		Timezone:     report.Timezone,
+		Message:      report.Message,
+		Paused:       report.Paused,
+		CreatedBy:    report.CreatedBy,
+		UpdatedBy:    report.UpdatedBy,
+		Created:      report.Created.UnixMilli(),
+		Updated:      report.Updated.UnixMilli(),
+		NextRun:      toMillis(report.NextRun),
+	}
+
+	recipients, err := json.Marshal(report.Recipients)
+	if err != nil {
+		return nil, err
+	}
+	row.Recipients = string(recipients)
+
+	if len(report.Variables) > 0 {
+		variables, err := json.Marshal(report.Variables)
+		if err != nil {
+			return nil, err
+		}
+		row.Variables = string(variables)
+	}
+	return row, nil
+}
+
+func (row reportRow) toReport() (*dashboardreport.Report, error) {
+	report := &dashboardreport.Report{
+		ID:    row.ID,
+		UID:   row.UID,
+		OrgID: row.OrgID,
+		Spec: dashboardreport.Spec{
+			Name:         row.Name,
+			DashboardUID: row.DashboardUID,
+			PanelID:      row.PanelID,
+			TimeRange:    dashboardreport.TimeRange{From: row.TimeFrom, To: row.TimeTo},
+			Format:       dashboardreport.Format(row.Format),
+			Schedule:     row.Schedule,
+			Timezone:     row.Timezone,
+			Message:      row.Message,
+			Paused:       row.Paused,
+		},
+		CreatedBy: row.CreatedBy,
+		UpdatedBy: row.UpdatedBy,
+		Created:   time.UnixMilli(row.Created),
+		Updated:   time.UnixMilli(row.Updated),
+	}
+	if row.NextRun > 0 {
+		nextRun := time.UnixMilli(row.NextRun)
+		report.NextRun = &nextRun
+	}
+
+	if err := json.Unmarshal([]byte(row.Recipients), &report.Recipients); err != nil {
+		return nil, err
+	}
+	if row.Variables != "" {
+		if err := json.Unmarshal([]byte(row.Variables), &report.Variables); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+func toMillis(t *time.Time) int64 {
+	if t == nil {
+		return 0
+	}
+	return t.UnixMilli()
+}
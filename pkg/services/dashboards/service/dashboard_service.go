@@ -19,6 +19,7 @@ import (
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/infra/metrics"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/credentialscan"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/dashboards/dashboardaccess"
 	"github.com/grafana/grafana/pkg/services/datasources"
@@ -59,6 +60,7 @@ type DashboardServiceImpl struct {
 	folderPermissions    accesscontrol.FolderPermissionsService
 	dashboardPermissions accesscontrol.DashboardPermissionsService
 	ac                   accesscontrol.AccessControl
+	credentialScan       credentialscan.Service
 	metrics              *dashboardsMetrics
 }
 
@@ -67,7 +69,7 @@ func ProvideDashboardServiceImpl(
 	cfg *setting.Cfg, dashboardStore dashboards.Store, folderStore folder.FolderStore,
 	features featuremgmt.FeatureToggles, folderPermissionsService accesscontrol.FolderPermissionsService,
 	dashboardPermissionsService accesscontrol.DashboardPermissionsService, ac accesscontrol.AccessControl,
-	folderSvc folder.Service, fStore folder.Store, r prometheus.Registerer,
+	folderSvc folder.Service, fStore folder.Store, r prometheus.Registerer, credentialScan credentialscan.Service,
 ) (*DashboardServiceImpl, error) {
 	dashSvc := &DashboardServiceImpl{
 		cfg:                  cfg,
@@ -79,6 +81,7 @@ func ProvideDashboardServiceImpl(
 		ac:                   ac,
 		folderStore:          folderStore,
 		folderService:        folderSvc,
+		credentialScan:       credentialScan,
 		metrics:              newDashboardsMetrics(r),
 	}
 
@@ -315,12 +318,16 @@ func (dr *DashboardServiceImpl) SaveProvisionedDashboard(ctx context.Context, dt
 	if err != nil {
 		return nil, err
 	}
+	if err := dr.checkForCredentials(ctx, dto.Dashboard); err != nil {
+		return nil, err
+	}
 
 	// dashboard
 	dash, err := dr.dashboardStore.SaveProvisionedDashboard(ctx, *cmd, provisioning)
 	if err != nil {
 		return nil, err
 	}
+	dr.scanForCredentials(ctx, dash)
 
 	if dto.Dashboard.ID == 0 {
 		dr.setDefaultPermissions(ctx, dto, dash, true)
@@ -360,11 +367,15 @@ func (dr *DashboardServiceImpl) SaveDashboard(ctx context.Context, dto *dashboar
 	if err != nil {
 		return nil, err
 	}
+	if err := dr.checkForCredentials(ctx, dto.Dashboard); err != nil {
+		return nil, err
+	}
 
 	dash, err := dr.dashboardStore.SaveDashboard(ctx, *cmd)
 	if err != nil {
 		return nil, fmt.Errorf("saving dashboard failed: %w", err)
 	}
+	dr.scanForCredentials(ctx, dash)
 
 	// new dashboard created
 	if dto.Dashboard.ID == 0 {
@@ -464,8 +475,20 @@ func (dr *DashboardServiceImpl) deleteDashboard(ctx context.Context, dashboardId
 			return dashboards.ErrDashboardCannotDeleteProvisionedDashboard
 		}
 	}
-	cmd := &dashboards.DeleteDashboardCommand{OrgID: orgId, ID: dashboardId}
-	return dr.dashboardStore.DeleteDashboard(ctx, cmd)
+	if dr.credentialScan == nil {
+		return dr.dashboardStore.DeleteDashboard(ctx, &dashboards.DeleteDashboardCommand{OrgID: orgId, ID: dashboardId})
+	}
+
+	// the UID is needed to remove the credential findings of the dashboard once it is deleted
+	ref, err := dr.dashboardStore.GetDashboardUIDByID(ctx, &dashboards.GetDashboardRefByIDQuery{ID: dashboardId})
+	if err != nil {
+		return err
+	}
+	if err := dr.dashboardStore.DeleteDashboard(ctx, &dashboards.DeleteDashboardCommand{OrgID: orgId, ID: dashboardId}); err != nil {
+		return err
+	}
+	dr.credentialScan.DeleteResource(ctx, orgId, credentialscan.KindDashboard, ref.UID)
+	return nil
 }
 
 func (dr *DashboardServiceImpl) ImportDashboard(ctx context.Context, dto *dashboards.SaveDashboardDTO) (
@@ -484,11 +507,15 @@ func (dr *DashboardServiceImpl) ImportDashboard(ctx context.Context, dto *dashbo
 	if err != nil {
 		return nil, err
 	}
+	if err := dr.checkForCredentials(ctx, dto.Dashboard); err != nil {
+		return nil, err
+	}
 
 	dash, err := dr.dashboardStore.SaveDashboard(ctx, *cmd)
 	if err != nil {
 		return nil, err
 	}
+	dr.scanForCredentials(ctx, dash)
 
 	dr.setDefaultPermissions(ctx, dto, dash, false)
 
@@ -505,6 +532,32 @@ func (dr *DashboardServiceImpl) GetDashboardsByPluginID(ctx context.Context, que
 	return dr.dashboardStore.GetDashboardsByPluginID(ctx, query)
 }
 
+// checkForCredentials checks a dashboard about to be saved for leaked credentials.
+func (dr *DashboardServiceImpl) checkForCredentials(ctx context.Context, dash *dashboards.Dashboard) error {
+	if dr.credentialScan == nil || dash.IsFolder {
+		return nil
+	}
+	return dr.credentialScan.CheckResource(ctx, dashboardResource(dash))
+}
+
+// scanForCredentials records the leaked credentials of a saved dashboard, once its UID is assigned.
+func (dr *DashboardServiceImpl) scanForCredentials(ctx context.Context, dash *dashboards.Dashboard) {
+	if dr.credentialScan == nil || dash.IsFolder {
+		return
+	}
+	dr.credentialScan.ScanResource(ctx, dashboardResource(dash))
+}
+
+func dashboardResource(dash *dashboards.Dashboard) credentialscan.Resource {
+	return credentialscan.Resource{
+		OrgID:   dash.OrgID,
+		Kind:    credentialscan.KindDashboard,
+		UID:     dash.UID,
+		Name:    dash.Title,
+		Content: dash.Data,
+	}
+}
+
 func (dr *DashboardServiceImpl) setDefaultPermissions(ctx context.Context, dto *dashboards.SaveDashboardDTO, dash *dashboards.Dashboard, provisioned bool) {
 	ctx, span := tracer.Start(ctx, "dashboards.service.setDefaultPermissions")
 	defer span.End()
@@ -10,6 +10,8 @@ import (
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/credentialscan"
+	"github.com/grafana/grafana/pkg/services/credentialscan/credentialscantest"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/folder"
@@ -164,6 +166,46 @@ func TestDashboardService(t *testing.T) {
 			})
 		})
 
+		t.Run("Credential scan", func(t *testing.T) {
+			dto := &dashboards.SaveDashboardDTO{}
+
+			t.Run("Should record findings with the UID assigned by the store", func(t *testing.T) {
+				credentialScan := &credentialscantest.FakeService{}
+				service.credentialScan = credentialScan
+				defer func() { service.credentialScan = nil }()
+
+				fakeStore.On("ValidateDashboardBeforeSave", mock.Anything, mock.Anything, mock.AnythingOfType("bool")).Return(true, nil).Once()
+				fakeStore.On("SaveDashboard", mock.Anything, mock.AnythingOfType("dashboards.SaveDashboardCommand")).Return(&dashboards.Dashboard{OrgID: 1, UID: "assigned", Title: "Dash", Data: simplejson.New()}, nil).Once()
+
+				dto.Dashboard = dashboards.NewDashboard("Dash")
+				dto.Dashboard.SetID(3)
+				dto.User = &user.SignedInUser{UserID: 1}
+				_, err := service.SaveDashboard(context.Background(), dto, true)
+				require.NoError(t, err)
+
+				require.Len(t, credentialScan.Checked, 1)
+				require.Equal(t, "", credentialScan.Checked[0].UID)
+				require.Len(t, credentialScan.Scanned, 1)
+				require.Equal(t, credentialscan.KindDashboard, credentialScan.Scanned[0].Kind)
+				require.Equal(t, "assigned", credentialScan.Scanned[0].UID)
+			})
+
+			t.Run("Should not save dashboards with credentials when saves are blocked", func(t *testing.T) {
+				credentialScan := &credentialscantest.FakeService{ExpectedErr: credentialscan.ErrCredentialsDetected.Errorf("credentials detected")}
+				service.credentialScan = credentialScan
+				defer func() { service.credentialScan = nil }()
+
+				fakeStore.On("ValidateDashboardBeforeSave", mock.Anything, mock.Anything, mock.AnythingOfType("bool")).Return(true, nil).Once()
+				fakeStore.On("GetProvisionedDataByDashboardID", mock.Anything, mock.AnythingOfType("int64")).Return(nil, nil).Once()
+
+				dto.Dashboard = dashboards.NewDashboard("Dash")
+				dto.User = &user.SignedInUser{UserID: 1}
+				_, err := service.ImportDashboard(context.Background(), dto)
+				require.ErrorIs(t, err, credentialscan.ErrCredentialsDetected)
+				require.Empty(t, credentialScan.Scanned)
+			})
+		})
+
 		t.Run("Given provisioned dashboard", func(t *testing.T) {
 			t.Run("DeleteProvisionedDashboard should delete it", func(t *testing.T) {
 				args := &dashboards.DeleteDashboardCommand{OrgID: 1, ID: 1}
@@ -194,6 +236,20 @@ func TestDashboardService(t *testing.T) {
 				err := service.DeleteDashboard(context.Background(), 1, 1)
 				require.NoError(t, err)
 			})
+
+			t.Run("DeleteDashboard should remove its credential findings", func(t *testing.T) {
+				credentialScan := &credentialscantest.FakeService{}
+				service.credentialScan = credentialScan
+				defer func() { service.credentialScan = nil }()
+
+				args := &dashboards.DeleteDashboardCommand{OrgID: 1, ID: 1}
+				fakeStore.On("DeleteDashboard", mock.Anything, args).Return(nil).Once()
+				fakeStore.On("GetProvisionedDataByDashboardID", mock.Anything, mock.AnythingOfType("int64")).Return(nil, nil).Once()
+				fakeStore.On("GetDashboardUIDByID", mock.Anything, &dashboards.GetDashboardRefByIDQuery{ID: 1}).Return(&dashboards.DashboardRef{UID: "deleted"}, nil).Once()
+				err := service.DeleteDashboard(context.Background(), 1, 1)
+				require.NoError(t, err)
+				require.Equal(t, []credentialscan.Resource{{OrgID: 1, Kind: credentialscan.KindDashboard, UID: "deleted"}}, credentialScan.Deleted)
+			})
 		})
 
 		t.Run("Count dashboards in folder", func(t *testing.T) {
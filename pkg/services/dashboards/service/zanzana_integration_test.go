@@ -70,6 +70,7 @@ func TestIntegrationDashboardServiceZanzana(t *testing.T) {
 			foldertest.NewFakeService(),
 			fStore,
 			nil,
+			nil,
 		)
 		require.NoError(t, err)
 
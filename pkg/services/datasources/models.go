@@ -29,6 +29,7 @@ const (
 	DS_OPENTSDB       = "opentsdb"
 	DS_POSTGRES       = "grafana-postgresql-datasource"
 	DS_PROMETHEUS     = "prometheus"
+	DS_SQLITE         = "sqlite"
 	DS_TEMPO          = "tempo"
 	DS_TESTDATA       = "grafana-testdata-datasource"
 	DS_ZIPKIN         = "zipkin"
@@ -22,6 +22,7 @@ import (
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/plugins"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/credentialscan"
 	"github.com/grafana/grafana/pkg/services/datasources"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/pluginsintegration/adapters"
@@ -51,6 +52,7 @@ type Service struct {
 	pluginStore               pluginstore.Store
 	pluginClient              plugins.Client
 	basePluginContextProvider plugincontext.BasePluginContextProvider
+	credentialScan            credentialscan.Service
 
 	ptc proxyTransportCache
 }
@@ -69,7 +71,7 @@ func ProvideService(
 	db db.DB, secretsService secrets.Service, secretsStore kvstore.SecretsKVStore, cfg *setting.Cfg,
 	features featuremgmt.FeatureToggles, ac accesscontrol.AccessControl, datasourcePermissionsService accesscontrol.DatasourcePermissionsService,
 	quotaService quota.Service, pluginStore pluginstore.Store, pluginClient plugins.Client,
-	basePluginContextProvider plugincontext.BasePluginContextProvider,
+	basePluginContextProvider plugincontext.BasePluginContextProvider, credentialScan credentialscan.Service,
 ) (*Service, error) {
 	dslogger := log.New("datasources")
 	store := &SqlStore{db: db, logger: dslogger, features: features}
@@ -89,6 +91,7 @@ func ProvideService(
 		pluginStore:               pluginStore,
 		pluginClient:              pluginClient,
 		basePluginContextProvider: basePluginContextProvider,
+		credentialScan:            credentialScan,
 	}
 
 	ac.RegisterScopeAttributeResolver(NewNameScopeResolver(store))
@@ -254,6 +257,10 @@ func (s *Service) AddDataSource(ctx context.Context, cmd *datasources.AddDataSou
 		}
 	}
 
+	if err := s.checkForCredentials(ctx, cmd.OrgID, cmd.UID, cmd.Name, cmd.URL, cmd.JsonData); err != nil {
+		return nil, err
+	}
+
 	var dataSource *datasources.DataSource
 	err = s.db.InTransaction(ctx, func(ctx context.Context) error {
 		var err error
@@ -303,6 +310,7 @@ func (s *Service) AddDataSource(ctx context.Context, cmd *datasources.AddDataSou
 	if err != nil {
 		return nil, err
 	}
+	s.scanForCredentials(ctx, dataSource)
 
 	return dataSource, nil
 }
@@ -456,7 +464,7 @@ func getAvailableName(dsType string, dataSources []*datasources.DataSource) stri
 }
 
 func (s *Service) DeleteDataSource(ctx context.Context, cmd *datasources.DeleteDataSourceCommand) error {
-	return s.db.InTransaction(ctx, func(ctx context.Context) error {
+	err := s.db.InTransaction(ctx, func(ctx context.Context) error {
 		cmd.UpdateSecretFn = func() error {
 			return s.SecretsStore.Del(ctx, cmd.OrgID, cmd.Name, kvstore.DataSourceSecretType)
 		}
@@ -467,6 +475,14 @@ func (s *Service) DeleteDataSource(ctx context.Context, cmd *datasources.DeleteD
 
 		return s.permissionsService.DeleteResourcePermissions(ctx, cmd.OrgID, cmd.UID)
 	})
+	if err != nil {
+		return err
+	}
+
+	if s.credentialScan != nil {
+		s.credentialScan.DeleteResource(ctx, cmd.OrgID, credentialscan.KindDataSource, cmd.UID)
+	}
+	return nil
 }
 
 func (s *Service) decryptSecureJsonDataFn(ctx context.Context) func(ds *datasources.DataSource) (map[string]string, error) {
@@ -478,7 +494,7 @@ func (s *Service) decryptSecureJsonDataFn(ctx context.Context) func(ds *datasour
 func (s *Service) UpdateDataSource(ctx context.Context, cmd *datasources.UpdateDataSourceCommand) (*datasources.DataSource, error) {
 	var dataSource *datasources.DataSource
 
-	return dataSource, s.db.InTransaction(ctx, func(ctx context.Context) error {
+	err := s.db.InTransaction(ctx, func(ctx context.Context) error {
 		var err error
 
 		query := &datasources.GetDataSourceQuery{
@@ -560,6 +576,10 @@ func (s *Service) UpdateDataSource(ctx context.Context, cmd *datasources.UpdateD
 			}
 		}
 
+		if err := s.checkForCredentials(ctx, dataSource.OrgID, dataSource.UID, cmd.Name, cmd.URL, cmd.JsonData); err != nil {
+			return err
+		}
+
 		err = s.fillWithSecureJSONData(ctx, cmd, dataSource)
 		if err != nil {
 			return err
@@ -586,6 +606,36 @@ func (s *Service) UpdateDataSource(ctx context.Context, cmd *datasources.UpdateD
 		dataSource, err = s.SQLStore.UpdateDataSource(ctx, cmd)
 		return err
 	})
+	if err == nil {
+		s.scanForCredentials(ctx, dataSource)
+	}
+	return dataSource, err
+}
+
+// checkForCredentials checks the URL and JSON data of a data source about to be saved for leaked credentials.
+func (s *Service) checkForCredentials(ctx context.Context, orgID int64, uid, name, url string, jsonData *simplejson.Json) error {
+	if s.credentialScan == nil {
+		return nil
+	}
+	return s.credentialScan.CheckResource(ctx, dataSourceResource(orgID, uid, name, url, jsonData))
+}
+
+// scanForCredentials records the leaked credentials of a saved data source.
+func (s *Service) scanForCredentials(ctx context.Context, ds *datasources.DataSource) {
+	if s.credentialScan == nil || ds == nil {
+		return
+	}
+	s.credentialScan.ScanResource(ctx, dataSourceResource(ds.OrgID, ds.UID, ds.Name, ds.URL, ds.JsonData))
+}
+
+func dataSourceResource(orgID int64, uid, name, url string, jsonData *simplejson.Json) credentialscan.Resource {
+	return credentialscan.Resource{
+		OrgID:   orgID,
+		Kind:    credentialscan.KindDataSource,
+		UID:     uid,
+		Name:    name,
+		Content: map[string]any{"url": url, "jsonData": jsonData},
+	}
 }
 
 func (s *Service) GetHTTPTransport(ctx context.Context, ds *datasources.DataSource, provider httpclient.Provider,
@@ -3,9 +3,12 @@ package libraryelements
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/components/dashdiffs"
+	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/infra/metrics"
 	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
@@ -28,16 +31,26 @@ func (l *LibraryElementService) registerAPIEndpoints() {
 			entities.Get("/", authorize(ac.EvalPermission(ActionLibraryPanelsRead)), routing.Wrap(l.getAllHandler))
 			entities.Get("/:uid", authorize(ac.EvalPermission(ActionLibraryPanelsRead)), routing.Wrap(l.getHandler))
 			entities.Get("/:uid/connections/", authorize(ac.EvalPermission(ActionLibraryPanelsRead, uidScope)), routing.Wrap(l.getConnectionsHandler))
+			entities.Get("/:uid/connections/outdated", authorize(ac.EvalPermission(ActionLibraryPanelsRead, uidScope)), routing.Wrap(l.getOutdatedConnectionsHandler))
+			entities.Get("/:uid/versions", authorize(ac.EvalPermission(ActionLibraryPanelsRead, uidScope)), routing.Wrap(l.getVersionsHandler))
+			entities.Get("/:uid/versions/:version", authorize(ac.EvalPermission(ActionLibraryPanelsRead, uidScope)), routing.Wrap(l.getVersionHandler))
+			entities.Get("/:uid/diff", authorize(ac.EvalPermission(ActionLibraryPanelsRead, uidScope)), routing.Wrap(l.diffHandler))
 			entities.Get("/name/:name", routing.Wrap(l.getByNameHandler))
 			entities.Patch("/:uid", authorize(ac.EvalPermission(ActionLibraryPanelsWrite, uidScope)), routing.Wrap(l.patchHandler))
+			entities.Post("/:uid/restore", authorize(ac.EvalPermission(ActionLibraryPanelsWrite, uidScope)), routing.Wrap(l.restoreVersionHandler))
 		} else {
 			entities.Post("/", routing.Wrap(l.createHandler))
 			entities.Delete("/:uid", routing.Wrap(l.deleteHandler))
 			entities.Get("/", routing.Wrap(l.getAllHandler))
 			entities.Get("/:uid", routing.Wrap(l.getHandler))
 			entities.Get("/:uid/connections/", routing.Wrap(l.getConnectionsHandler))
+			entities.Get("/:uid/connections/outdated", routing.Wrap(l.getOutdatedConnectionsHandler))
+			entities.Get("/:uid/versions", routing.Wrap(l.getVersionsHandler))
+			entities.Get("/:uid/versions/:version", routing.Wrap(l.getVersionHandler))
+			entities.Get("/:uid/diff", routing.Wrap(l.diffHandler))
 			entities.Get("/name/:name", routing.Wrap(l.getByNameHandler))
 			entities.Patch("/:uid", routing.Wrap(l.patchHandler))
+			entities.Post("/:uid/restore", routing.Wrap(l.restoreVersionHandler))
 		}
 	})
 }
@@ -244,6 +257,15 @@ func (l *LibraryElementService) patchHandler(c *contextmodel.ReqContext) respons
 		return toLibraryElementError(err, "Failed to update library element")
 	}
 
+	if err := l.setFolderOfUpdatedElement(c, &element); err != nil {
+		return response.Error(http.StatusInternalServerError, "failed to get folder", err)
+	}
+
+	return response.JSON(http.StatusOK, model.LibraryElementResponse{Result: element})
+}
+
+// setFolderOfUpdatedElement sets the folder UID and name of a library element returned by an update.
+func (l *LibraryElementService) setFolderOfUpdatedElement(c *contextmodel.ReqContext, element *model.LibraryElementDTO) error {
 	metrics.MFolderIDsServiceCount.WithLabelValues(metrics.LibraryElements).Inc()
 	// nolint:staticcheck
 	if element.FolderID != 0 {
@@ -251,14 +273,13 @@ func (l *LibraryElementService) patchHandler(c *contextmodel.ReqContext) respons
 		// nolint:staticcheck
 		folder, err := l.folderService.Get(c.Req.Context(), &folder.GetFolderQuery{OrgID: c.SignedInUser.GetOrgID(), ID: &element.FolderID, SignedInUser: c.SignedInUser})
 		if err != nil {
-			return response.Error(http.StatusInternalServerError, "failed to get folder", err)
+			return err
 		}
 		element.FolderUID = folder.UID
 		element.Meta.FolderUID = folder.UID
 		element.Meta.FolderName = folder.Title
 	}
-
-	return response.JSON(http.StatusOK, model.LibraryElementResponse{Result: element})
+	return nil
 }
 
 // swagger:route GET /library-elements/{library_element_uid}/connections/ library_elements getLibraryElementConnections
@@ -274,7 +295,7 @@ func (l *LibraryElementService) patchHandler(c *contextmodel.ReqContext) respons
 // 404: notFoundError
 // 500: internalServerError
 func (l *LibraryElementService) getConnectionsHandler(c *contextmodel.ReqContext) response.Response {
-	connections, err := l.getConnections(c.Req.Context(), c.SignedInUser, web.Params(c.Req)[":uid"])
+	connections, err := l.getConnections(c.Req.Context(), c.SignedInUser, web.Params(c.Req)[":uid"], false)
 	if err != nil {
 		return toLibraryElementError(err, "Failed to get connections")
 	}
@@ -282,6 +303,161 @@ func (l *LibraryElementService) getConnectionsHandler(c *contextmodel.ReqContext
 	return response.JSON(http.StatusOK, model.LibraryElementConnectionsResponse{Result: connections})
 }
 
+// swagger:route GET /library-elements/{library_element_uid}/connections/outdated library_elements getLibraryElementOutdatedConnections
+//
+// Get outdated library element connections.
+//
+// Returns the connections of a library element that are pinned to a version older than the latest version of the library element.
+//
+// Responses:
+// 200: getLibraryElementConnectionsResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (l *LibraryElementService) getOutdatedConnectionsHandler(c *contextmodel.ReqContext) response.Response {
+	connections, err := l.getConnections(c.Req.Context(), c.SignedInUser, web.Params(c.Req)[":uid"], true)
+	if err != nil {
+		return toLibraryElementError(err, "Failed to get connections")
+	}
+
+	return response.JSON(http.StatusOK, model.LibraryElementConnectionsResponse{Result: connections})
+}
+
+// swagger:route GET /library-elements/{library_element_uid}/versions library_elements getLibraryElementVersions
+//
+// Get library element versions.
+//
+// Returns all stored versions of a library element, most recent first.
+//
+// Responses:
+// 200: getLibraryElementVersionsResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (l *LibraryElementService) getVersionsHandler(c *contextmodel.ReqContext) response.Response {
+	versions, err := l.getLibraryElementVersions(c.Req.Context(), c.SignedInUser, web.Params(c.Req)[":uid"], 0)
+	if err != nil {
+		return toLibraryElementError(err, "Failed to get library element versions")
+	}
+
+	return response.JSON(http.StatusOK, model.LibraryElementVersionsResponse{Result: versions})
+}
+
+// swagger:route GET /library-elements/{library_element_uid}/versions/{library_element_version} library_elements getLibraryElementVersion
+//
+// Get library element version.
+//
+// Returns a stored version of a library element.
+//
+// Responses:
+// 200: getLibraryElementVersionResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (l *LibraryElementService) getVersionHandler(c *contextmodel.ReqContext) response.Response {
+	version, err := strconv.ParseInt(web.Params(c.Req)[":version"], 10, 64)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "version is invalid", err)
+	}
+
+	result, err := l.getLibraryElementVersion(c.Req.Context(), c.SignedInUser, web.Params(c.Req)[":uid"], version)
+	if err != nil {
+		return toLibraryElementError(err, "Failed to get library element version")
+	}
+
+	return response.JSON(http.StatusOK, model.LibraryElementVersionResponse{Result: result})
+}
+
+// swagger:route GET /library-elements/{library_element_uid}/diff library_elements calculateLibraryElementDiff
+//
+// Perform diff on two library element versions.
+//
+// Responses:
+// 200: calculateLibraryElementDiffResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (l *LibraryElementService) diffHandler(c *contextmodel.ReqContext) response.Response {
+	uid := web.Params(c.Req)[":uid"]
+	base, err := l.getLibraryElementVersion(c.Req.Context(), c.SignedInUser, uid, c.QueryInt64("base"))
+	if err != nil {
+		return toLibraryElementError(err, "Failed to get library element version")
+	}
+	newVersion, err := l.getLibraryElementVersion(c.Req.Context(), c.SignedInUser, uid, c.QueryInt64("new"))
+	if err != nil {
+		return toLibraryElementError(err, "Failed to get library element version")
+	}
+
+	baseData, err := simplejson.NewJson(base.Model)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Unable to compute diff", err)
+	}
+	newData, err := simplejson.NewJson(newVersion.Model)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Unable to compute diff", err)
+	}
+
+	options := dashdiffs.Options{
+		OrgId:    c.SignedInUser.GetOrgID(),
+		DiffType: dashdiffs.ParseDiffType(c.Query("diffType")),
+	}
+	contentType := "text/html"
+	if options.DiffType == dashdiffs.DiffDelta {
+		contentType = "application/json"
+	}
+
+	result, err := dashdiffs.CalculateDiff(c.Req.Context(), &options, baseData, newData)
+	if errors.Is(err, dashdiffs.ErrNilDiff) {
+		if options.DiffType == dashdiffs.DiffDelta {
+			return response.Respond(http.StatusOK, []byte("{}")).SetHeader("Content-Type", contentType)
+		}
+		return response.Respond(http.StatusOK, []byte{}).SetHeader("Content-Type", contentType)
+	}
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Unable to compute diff", err)
+	}
+
+	return response.Respond(http.StatusOK, result.Delta).SetHeader("Content-Type", contentType)
+}
+
+// swagger:route POST /library-elements/{library_element_uid}/restore library_elements restoreLibraryElementVersion
+//
+// Restore a library element to a given version.
+//
+// The name and model of the version are saved as a new version of the library element.
+//
+// Responses:
+// 200: getLibraryElementResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 412: preconditionFailedError
+// 500: internalServerError
+func (l *LibraryElementService) restoreVersionHandler(c *contextmodel.ReqContext) response.Response {
+	cmd := model.RestoreLibraryElementVersionCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	element, err := l.restoreLibraryElementVersion(c.Req.Context(), c.SignedInUser, web.Params(c.Req)[":uid"], cmd.Version)
+	if err != nil {
+		return toLibraryElementError(err, "Failed to restore library element version")
+	}
+
+	if err := l.setFolderOfUpdatedElement(c, &element); err != nil {
+		return response.Error(http.StatusInternalServerError, "failed to get folder", err)
+	}
+
+	return response.JSON(http.StatusOK, model.LibraryElementResponse{Result: element})
+}
+
 // swagger:route GET /library-elements/name/{library_element_name} library_elements getLibraryElementByName
 //
 // Get library element by name.
@@ -333,6 +509,9 @@ func toLibraryElementError(err error, message string) response.Response {
 	if errors.Is(err, model.ErrLibraryElementNotFound) {
 		return response.Error(http.StatusNotFound, model.ErrLibraryElementNotFound.Error(), err)
 	}
+	if errors.Is(err, model.ErrLibraryElementVersionNotFound) {
+		return response.Error(http.StatusNotFound, model.ErrLibraryElementVersionNotFound.Error(), err)
+	}
 	if errors.Is(err, model.ErrLibraryElementDashboardNotFound) {
 		return response.Error(http.StatusNotFound, model.ErrLibraryElementDashboardNotFound.Error(), err)
 	}
@@ -357,7 +536,7 @@ func toLibraryElementError(err error, message string) response.Response {
 	return response.ErrOrFallback(http.StatusInternalServerError, message, err)
 }
 
-// swagger:parameters getLibraryElementByUID getLibraryElementConnections
+// swagger:parameters getLibraryElementByUID getLibraryElementConnections getLibraryElementOutdatedConnections getLibraryElementVersions
 type LibraryElementByUID struct {
 	// in:path
 	// required:true
@@ -385,6 +564,46 @@ type DeleteLibraryElementByUIDParams struct {
 	UID string `json:"library_element_uid"`
 }
 
+// swagger:parameters getLibraryElementVersion
+type GetLibraryElementVersionParams struct {
+	// in:path
+	// required:true
+	UID string `json:"library_element_uid"`
+	// in:path
+	// required:true
+	Version int64 `json:"library_element_version"`
+}
+
+// swagger:parameters calculateLibraryElementDiff
+type CalculateLibraryElementDiffParams struct {
+	// in:path
+	// required:true
+	UID string `json:"library_element_uid"`
+	// The version to compare from.
+	// in:query
+	// required:true
+	Base int64 `json:"base"`
+	// The version to compare to.
+	// in:query
+	// required:true
+	New int64 `json:"new"`
+	// The type of diff to return.
+	// in:query
+	// required:false
+	// Enum: basic,json,delta
+	DiffType string `json:"diffType"`
+}
+
+// swagger:parameters restoreLibraryElementVersion
+type RestoreLibraryElementVersionParams struct {
+	// in:body
+	// required:true
+	Body model.RestoreLibraryElementVersionCommand `json:"body"`
+	// in:path
+	// required:true
+	UID string `json:"library_element_uid"`
+}
+
 // swagger:parameters getLibraryElementByName
 type LibraryElementByNameParams struct {
 	// in:path
@@ -478,3 +697,21 @@ type GetLibraryElementConnectionsResponse struct {
 	// in: body
 	Body model.LibraryElementConnectionsResponse `json:"body"`
 }
+
+// swagger:response getLibraryElementVersionsResponse
+type GetLibraryElementVersionsResponse struct {
+	// in: body
+	Body model.LibraryElementVersionsResponse `json:"body"`
+}
+
+// swagger:response getLibraryElementVersionResponse
+type GetLibraryElementVersionResponse struct {
+	// in: body
+	Body model.LibraryElementVersionResponse `json:"body"`
+}
+
+// swagger:response calculateLibraryElementDiffResponse
+type CalculateLibraryElementDiffResponse struct {
+	// in: body
+	Body []byte `json:"body"`
+}
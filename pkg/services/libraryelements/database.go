@@ -26,6 +26,11 @@ import (
 	"github.com/grafana/grafana/pkg/util"
 )
 
+const (
+	maxVersionsToDeletePerBatch = 100
+	maxVersionDeletionBatches   = 50
+)
+
 const (
 	selectLibraryElementDTOWithMeta = `
 SELECT DISTINCT
@@ -113,6 +118,77 @@ func GetLibraryElement(dialect migrator.Dialect, session *db.Session, uid string
 	return elements[0], nil
 }
 
+// insertLibraryElementVersion stores the current state of a library element as a new version.
+func insertLibraryElementVersion(session *db.Session, element model.LibraryElement, restoredFrom int64) error {
+	version := model.LibraryElementVersion{
+		OrgID:        element.OrgID,
+		ElementID:    element.ID,
+		Version:      element.Version,
+		RestoredFrom: restoredFrom,
+		Name:         element.Name,
+		Model:        element.Model,
+		Created:      element.Updated,
+		CreatedBy:    element.UpdatedBy,
+	}
+	_, err := session.Insert(&version)
+	return err
+}
+
+// DeleteExpiredVersions deletes the stored versions of library elements that are older than the number of
+// versions to keep of the dashboards setting. Versions that dashboards are pinned to are never deleted.
+func (l *LibraryElementService) DeleteExpiredVersions(c context.Context) (int64, error) {
+	versionsToKeep := l.Cfg.DashboardVersionsToKeep
+	if versionsToKeep < 1 {
+		versionsToKeep = 1
+	}
+
+	var deletedRows int64
+	for batch := 0; batch < maxVersionDeletionBatches; batch++ {
+		var versionIDs []any
+		err := l.SQLStore.WithDbSession(c, func(session *db.Session) error {
+			return session.SQL(`SELECT lev.id
+			FROM `+model.LibraryElementVersionTableName+` AS lev, (
+				SELECT element_id, max(version) AS max
+				FROM `+model.LibraryElementVersionTableName+`
+				GROUP BY element_id
+			) AS vtk
+			WHERE lev.element_id=vtk.element_id
+			AND lev.version <= vtk.max - ?
+			AND NOT EXISTS (
+				SELECT 1 FROM `+model.LibraryElementConnectionTableName+` AS lec
+				WHERE lec.element_id=lev.element_id AND lec.pinned_version=lev.version
+			)
+			LIMIT ?`, versionsToKeep, maxVersionsToDeletePerBatch).Find(&versionIDs)
+		})
+		if err != nil {
+			return deletedRows, err
+		}
+		if len(versionIDs) == 0 {
+			return deletedRows, nil
+		}
+
+		var deleted int64
+		err = l.SQLStore.WithDbSession(c, func(session *db.Session) error {
+			deleteSQL := "DELETE FROM " + model.LibraryElementVersionTableName + " WHERE id IN (?" + strings.Repeat(",?", len(versionIDs)-1) + ")"
+			res, err := session.Exec(append([]any{deleteSQL}, versionIDs...)...)
+			if err != nil {
+				return err
+			}
+			deleted, err = res.RowsAffected()
+			return err
+		})
+		if err != nil {
+			return deletedRows, err
+		}
+
+		deletedRows += deleted
+		if deleted < int64(maxVersionsToDeletePerBatch) {
+			break
+		}
+	}
+	return deletedRows, nil
+}
+
 // createLibraryElement adds a library element.
 func (l *LibraryElementService) createLibraryElement(c context.Context, signedInUser identity.Requester, cmd model.CreateLibraryElementCommand) (model.LibraryElementDTO, error) {
 	if err := l.requireSupportedElementKind(cmd.Kind); err != nil {
@@ -169,6 +245,9 @@ func (l *LibraryElementService) createLibraryElement(c context.Context, signedIn
 	if err := syncFieldsWithModel(&element); err != nil {
 		return model.LibraryElementDTO{}, err
 	}
+	if err := l.checkForCredentials(c, element); err != nil {
+		return model.LibraryElementDTO{}, err
+	}
 
 	err = l.SQLStore.WithTransactionalDbSession(c, func(session *db.Session) error {
 		if l.features.IsEnabled(c, featuremgmt.FlagLibraryPanelRBAC) {
@@ -192,7 +271,7 @@ func (l *LibraryElementService) createLibraryElement(c context.Context, signedIn
 			}
 			return err
 		}
-		return nil
+		return insertLibraryElementVersion(session, element, 0)
 	})
 
 	metrics.MFolderIDsServiceCount.WithLabelValues(metrics.LibraryElements).Inc()
@@ -223,6 +302,9 @@ func (l *LibraryElementService) createLibraryElement(c context.Context, signedIn
 			},
 		},
 	}
+	if err == nil {
+		l.scanForCredentials(c, dto)
+	}
 
 	return dto, err
 }
@@ -259,6 +341,10 @@ func (l *LibraryElementService) deleteLibraryElement(c context.Context, signedIn
 			return model.ErrLibraryElementHasConnections
 		}
 
+		if _, err := session.Exec("DELETE FROM "+model.LibraryElementVersionTableName+" WHERE element_id=?", element.ID); err != nil {
+			return err
+		}
+
 		result, err := session.Exec("DELETE FROM library_element WHERE id=?", element.ID)
 		if err != nil {
 			return err
@@ -272,7 +358,11 @@ func (l *LibraryElementService) deleteLibraryElement(c context.Context, signedIn
 		elementID = element.ID
 		return nil
 	})
-	return elementID, err
+	if err != nil {
+		return 0, err
+	}
+	l.removeCredentialFindings(c, signedInUser.GetOrgID(), uid)
+	return elementID, nil
 }
 
 // getLibraryElements gets a Library Element where param == value
@@ -560,6 +650,12 @@ func (l *LibraryElementService) handleFolderIDPatches(ctx context.Context, eleme
 
 // patchLibraryElement updates a Library Element.
 func (l *LibraryElementService) patchLibraryElement(c context.Context, signedInUser identity.Requester, cmd model.PatchLibraryElementCommand, uid string) (model.LibraryElementDTO, error) {
+	return l.updateLibraryElement(c, signedInUser, cmd, uid, 0)
+}
+
+// updateLibraryElement updates a Library Element and stores the result as a new version.
+// restoredFrom is the version the update restores, or 0 for regular updates.
+func (l *LibraryElementService) updateLibraryElement(c context.Context, signedInUser identity.Requester, cmd model.PatchLibraryElementCommand, uid string, restoredFrom int64) (model.LibraryElementDTO, error) {
 	var dto model.LibraryElementDTO
 	if err := l.requireSupportedElementKind(cmd.Kind); err != nil {
 		return model.LibraryElementDTO{}, err
@@ -625,6 +721,9 @@ func (l *LibraryElementService) patchLibraryElement(c context.Context, signedInU
 		if err := syncFieldsWithModel(&libraryElement); err != nil {
 			return err
 		}
+		if err := l.checkForCredentials(c, libraryElement); err != nil {
+			return err
+		}
 		if rowsAffected, err := session.ID(elementInDB.ID).Update(&libraryElement); err != nil {
 			if l.SQLStore.GetDialect().IsUniqueConstraintViolation(err) {
 				return model.ErrLibraryElementAlreadyExists
@@ -633,6 +732,9 @@ func (l *LibraryElementService) patchLibraryElement(c context.Context, signedInU
 		} else if rowsAffected != 1 {
 			return model.ErrLibraryElementNotFound
 		}
+		if err := insertLibraryElementVersion(session, libraryElement, restoredFrom); err != nil {
+			return err
+		}
 
 		metrics.MFolderIDsServiceCount.WithLabelValues(metrics.LibraryElements).Inc()
 		dto = model.LibraryElementDTO{
@@ -664,12 +766,16 @@ func (l *LibraryElementService) patchLibraryElement(c context.Context, signedInU
 		}
 		return nil
 	})
+	if err == nil {
+		l.scanForCredentials(c, dto)
+	}
 
 	return dto, err
 }
 
-// getConnections gets all connections for a Library Element.
-func (l *LibraryElementService) getConnections(c context.Context, signedInUser identity.Requester, uid string) ([]model.LibraryElementConnectionDTO, error) {
+// getConnections gets all connections for a Library Element. When outdated is true, only the connections
+// pinned to a version older than the latest version of the Library Element are returned.
+func (l *LibraryElementService) getConnections(c context.Context, signedInUser identity.Requester, uid string, outdated bool) ([]model.LibraryElementConnectionDTO, error) {
 	connections := make([]model.LibraryElementConnectionDTO, 0)
 	recursiveQueriesAreSupported, err := l.SQLStore.RecursiveQueriesAreSupported()
 	if err != nil {
@@ -688,6 +794,9 @@ func (l *LibraryElementService) getConnections(c context.Context, signedInUser i
 		builder.Write(" LEFT JOIN " + l.SQLStore.GetDialect().Quote("user") + " AS u1 ON lec.created_by = u1.id")
 		builder.Write(" INNER JOIN dashboard AS dashboard on lec.connection_id = dashboard.id")
 		builder.Write(` WHERE lec.element_id=?`, element.ID)
+		if outdated {
+			builder.Write(` AND lec.pinned_version > 0 AND lec.pinned_version < ?`, element.Version)
+		}
 		if signedInUser.GetOrgRole() != org.RoleAdmin {
 			builder.WriteDashboardPermissionFilter(signedInUser, dashboardaccess.PERMISSION_VIEW, "")
 		}
@@ -702,6 +811,7 @@ func (l *LibraryElementService) getConnections(c context.Context, signedInUser i
 				ElementID:     connection.ElementID,
 				ConnectionID:  connection.ConnectionID,
 				ConnectionUID: connection.ConnectionUID,
+				PinnedVersion: connection.PinnedVersion,
 				Created:       connection.Created,
 				CreatedBy: librarypanel.LibraryElementDTOMetaUser{
 					Id:        connection.CreatedBy,
@@ -774,17 +884,32 @@ func (l *LibraryElementService) getElementsForDashboardID(c context.Context, das
 }
 
 // connectElementsToDashboardID adds connections for all elements Library Elements in a Dashboard.
-func (l *LibraryElementService) connectElementsToDashboardID(c context.Context, signedInUser identity.Requester, elementUIDs []string, dashboardID int64) error {
+// elementVersions maps the UIDs of the Library Elements to the version the Dashboard is pinned to,
+// 0 when the Dashboard follows the latest version.
+func (l *LibraryElementService) connectElementsToDashboardID(c context.Context, signedInUser identity.Requester, elementVersions map[string]int64, dashboardID int64) error {
 	err := l.SQLStore.WithTransactionalDbSession(c, func(session *db.Session) error {
 		_, err := session.Exec("DELETE FROM "+model.LibraryElementConnectionTableName+" WHERE kind=1 AND connection_id=?", dashboardID)
 		if err != nil {
 			return err
 		}
-		for _, elementUID := range elementUIDs {
+		for elementUID, pinnedVersion := range elementVersions {
 			element, err := GetLibraryElement(l.SQLStore.GetDialect(), session, elementUID, signedInUser.GetOrgID())
 			if err != nil {
 				return err
 			}
+			if pinnedVersion < 0 || pinnedVersion > element.Version {
+				return fmt.Errorf("%w: %d", model.ErrLibraryElementVersionNotFound, pinnedVersion)
+			}
+			if pinnedVersion > 0 {
+				// versions older than the version history are not stored
+				exists, err := session.Table(model.LibraryElementVersionTableName).Where("element_id=? AND version=?", element.ID, pinnedVersion).Exist()
+				if err != nil {
+					return err
+				}
+				if !exists {
+					return fmt.Errorf("%w: %d", model.ErrLibraryElementVersionNotFound, pinnedVersion)
+				}
+			}
 			metrics.MFolderIDsServiceCount.WithLabelValues(metrics.LibraryElements).Inc()
 			// nolint:staticcheck
 			if err := l.requireViewPermissionsOnFolder(c, signedInUser, element.FolderID); err != nil {
@@ -797,11 +922,12 @@ func (l *LibraryElementService) connectElementsToDashboardID(c context.Context,
 			}
 
 			connection := model.LibraryElementConnection{
-				ElementID:    element.ID,
-				Kind:         1,
-				ConnectionID: dashboardID,
-				Created:      time.Now(),
-				CreatedBy:    userID,
+				ElementID:     element.ID,
+				Kind:          1,
+				ConnectionID:  dashboardID,
+				PinnedVersion: pinnedVersion,
+				Created:       time.Now(),
+				CreatedBy:     userID,
 			}
 			if _, err := session.Insert(&connection); err != nil {
 				if l.SQLStore.GetDialect().IsUniqueConstraintViolation(err) {
@@ -829,7 +955,8 @@ func (l *LibraryElementService) disconnectElementsFromDashboardID(c context.Cont
 
 // deleteLibraryElementsInFolderUID deletes all Library Elements in a folder.
 func (l *LibraryElementService) deleteLibraryElementsInFolderUID(c context.Context, signedInUser identity.Requester, folderUID string) error {
-	return l.SQLStore.WithTransactionalDbSession(c, func(session *db.Session) error {
+	var deletedUIDs []string
+	err := l.SQLStore.WithTransactionalDbSession(c, func(session *db.Session) error {
 		var folderUIDs []struct {
 			ID int64 `xorm:"id"`
 		}
@@ -866,17 +993,23 @@ func (l *LibraryElementService) deleteLibraryElementsInFolderUID(c context.Conte
 		}
 
 		var elementIDs []struct {
-			ID int64 `xorm:"id"`
+			ID  int64  `xorm:"id"`
+			UID string `xorm:"uid"`
 		}
-		err = session.SQL("SELECT id from library_element WHERE folder_id=? AND org_id=?", folderID, signedInUser.GetOrgID()).Find(&elementIDs)
+		err = session.SQL("SELECT id, uid from library_element WHERE folder_id=? AND org_id=?", folderID, signedInUser.GetOrgID()).Find(&elementIDs)
 		if err != nil {
 			return err
 		}
 		for _, elementID := range elementIDs {
+			deletedUIDs = append(deletedUIDs, elementID.UID)
 			_, err := session.Exec("DELETE FROM "+model.LibraryElementConnectionTableName+" WHERE element_id=?", elementID.ID)
 			if err != nil {
 				return err
 			}
+			_, err = session.Exec("DELETE FROM "+model.LibraryElementVersionTableName+" WHERE element_id=?", elementID.ID)
+			if err != nil {
+				return err
+			}
 		}
 		if _, err := session.Exec("DELETE FROM library_element WHERE folder_id=? AND org_id=?", folderID, signedInUser.GetOrgID()); err != nil {
 			return err
@@ -884,4 +1017,94 @@ func (l *LibraryElementService) deleteLibraryElementsInFolderUID(c context.Conte
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	l.removeCredentialFindings(c, signedInUser.GetOrgID(), deletedUIDs...)
+	return nil
+}
+
+// getLibraryElementVersions gets the stored versions of a Library Element, most recent first.
+// A version greater than 0 only returns that version.
+func (l *LibraryElementService) getLibraryElementVersions(c context.Context, signedInUser identity.Requester, uid string, version int64) ([]model.LibraryElementVersionDTO, error) {
+	// getting the element checks that the user can read it
+	element, err := l.getLibraryElementByUid(c, signedInUser, model.GetLibraryElementCommand{UID: uid, FolderName: dashboards.RootFolderName})
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]model.LibraryElementVersionDTO, 0)
+	err = l.SQLStore.WithDbSession(c, func(session *db.Session) error {
+		var libraryElementVersions []model.LibraryElementVersionWithMeta
+		sql := "SELECT lev.*, u1.login AS created_by_name, u1.email AS created_by_email" +
+			" FROM " + model.LibraryElementVersionTableName + " AS lev" +
+			" LEFT JOIN " + l.SQLStore.GetDialect().Quote("user") + " AS u1 ON lev.created_by = u1.id" +
+			" WHERE lev.element_id=? AND lev.org_id=?"
+		params := []any{element.ID, signedInUser.GetOrgID()}
+		if version > 0 {
+			sql += " AND lev.version=?"
+			params = append(params, version)
+		}
+		sql += " ORDER BY lev.version DESC"
+		if err := session.SQL(sql, params...).Find(&libraryElementVersions); err != nil {
+			return err
+		}
+
+		for _, v := range libraryElementVersions {
+			versions = append(versions, model.LibraryElementVersionDTO{
+				ID:           v.ID,
+				ElementUID:   element.UID,
+				Version:      v.Version,
+				RestoredFrom: v.RestoredFrom,
+				Name:         v.Name,
+				Model:        v.Model,
+				Created:      v.Created,
+				CreatedBy: librarypanel.LibraryElementDTOMetaUser{
+					Id:        v.CreatedBy,
+					Name:      v.CreatedByName,
+					AvatarUrl: dtos.GetGravatarUrl(l.Cfg, v.CreatedByEmail),
+				},
+			})
+		}
+		return nil
+	})
+
+	return versions, err
+}
+
+// getLibraryElementVersion gets a stored version of a Library Element.
+func (l *LibraryElementService) getLibraryElementVersion(c context.Context, signedInUser identity.Requester, uid string, version int64) (model.LibraryElementVersionDTO, error) {
+	if version <= 0 {
+		return model.LibraryElementVersionDTO{}, model.ErrLibraryElementVersionNotFound
+	}
+	versions, err := l.getLibraryElementVersions(c, signedInUser, uid, version)
+	if err != nil {
+		return model.LibraryElementVersionDTO{}, err
+	}
+	if len(versions) == 0 {
+		return model.LibraryElementVersionDTO{}, model.ErrLibraryElementVersionNotFound
+	}
+	return versions[0], nil
+}
+
+// restoreLibraryElementVersion replaces the name and model of a Library Element with the ones of a stored version.
+// The restored element is saved as a new version, the history is never rewritten.
+func (l *LibraryElementService) restoreLibraryElementVersion(c context.Context, signedInUser identity.Requester, uid string, version int64) (model.LibraryElementDTO, error) {
+	element, err := l.getLibraryElementByUid(c, signedInUser, model.GetLibraryElementCommand{UID: uid, FolderName: dashboards.RootFolderName})
+	if err != nil {
+		return model.LibraryElementDTO{}, err
+	}
+	restored, err := l.getLibraryElementVersion(c, signedInUser, uid, version)
+	if err != nil {
+		return model.LibraryElementDTO{}, err
+	}
+
+	cmd := model.PatchLibraryElementCommand{
+		FolderID: -1, // nolint:staticcheck
+		Name:     restored.Name,
+		Model:    restored.Model,
+		Kind:     element.Kind,
+		Version:  element.Version,
+	}
+	return l.updateLibraryElement(c, signedInUser, cmd, uid, version)
 }
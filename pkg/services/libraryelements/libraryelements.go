@@ -9,21 +9,23 @@ import (
 	"github.com/grafana/grafana/pkg/infra/db"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/credentialscan"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/folder"
 	"github.com/grafana/grafana/pkg/services/libraryelements/model"
 	"github.com/grafana/grafana/pkg/setting"
 )
 
-func ProvideService(cfg *setting.Cfg, sqlStore db.DB, routeRegister routing.RouteRegister, folderService folder.Service, folderStore folder.Store, features featuremgmt.FeatureToggles, ac accesscontrol.AccessControl) *LibraryElementService {
+func ProvideService(cfg *setting.Cfg, sqlStore db.DB, routeRegister routing.RouteRegister, folderService folder.Service, folderStore folder.Store, features featuremgmt.FeatureToggles, ac accesscontrol.AccessControl, credentialScan credentialscan.Service) *LibraryElementService {
 	l := &LibraryElementService{
-		Cfg:           cfg,
-		SQLStore:      sqlStore,
-		RouteRegister: routeRegister,
-		folderService: folderService,
-		log:           log.New("library-elements"),
-		features:      features,
-		AccessControl: ac,
+		Cfg:            cfg,
+		SQLStore:       sqlStore,
+		RouteRegister:  routeRegister,
+		folderService:  folderService,
+		log:            log.New("library-elements"),
+		features:       features,
+		AccessControl:  ac,
+		credentialScan: credentialScan,
 	}
 
 	l.registerAPIEndpoints()
@@ -38,6 +40,7 @@ type Service interface {
 	GetElement(c context.Context, signedInUser identity.Requester, cmd model.GetLibraryElementCommand) (model.LibraryElementDTO, error)
 	GetElementsForDashboard(c context.Context, dashboardID int64) (map[string]model.LibraryElementDTO, error)
 	ConnectElementsToDashboard(c context.Context, signedInUser identity.Requester, elementUIDs []string, dashboardID int64) error
+	ConnectElementVersionsToDashboard(c context.Context, signedInUser identity.Requester, elementVersions map[string]int64, dashboardID int64) error
 	DisconnectElementsFromDashboard(c context.Context, dashboardID int64) error
 	DeleteLibraryElementsInFolder(c context.Context, signedInUser identity.Requester, folderUID string) error
 	GetAllElements(c context.Context, signedInUser identity.Requester, query model.SearchLibraryElementsQuery) (model.LibraryElementSearchResult, error)
@@ -45,13 +48,14 @@ type Service interface {
 
 // LibraryElementService is the service for the Library Element feature.
 type LibraryElementService struct {
-	Cfg           *setting.Cfg
-	SQLStore      db.DB
-	RouteRegister routing.RouteRegister
-	folderService folder.Service
-	log           log.Logger
-	features      featuremgmt.FeatureToggles
-	AccessControl accesscontrol.AccessControl
+	Cfg            *setting.Cfg
+	SQLStore       db.DB
+	RouteRegister  routing.RouteRegister
+	folderService  folder.Service
+	log            log.Logger
+	features       featuremgmt.FeatureToggles
+	AccessControl  accesscontrol.AccessControl
+	credentialScan credentialscan.Service
 }
 
 var _ Service = (*LibraryElementService)(nil)
@@ -71,9 +75,19 @@ func (l *LibraryElementService) GetElementsForDashboard(c context.Context, dashb
 	return l.getElementsForDashboardID(c, dashboardID)
 }
 
-// ConnectElementsToDashboard connects elements to a specific dashboard.
+// ConnectElementsToDashboard connects elements to a specific dashboard, following their latest version.
 func (l *LibraryElementService) ConnectElementsToDashboard(c context.Context, signedInUser identity.Requester, elementUIDs []string, dashboardID int64) error {
-	return l.connectElementsToDashboardID(c, signedInUser, elementUIDs, dashboardID)
+	elementVersions := make(map[string]int64, len(elementUIDs))
+	for _, elementUID := range elementUIDs {
+		elementVersions[elementUID] = 0
+	}
+	return l.connectElementsToDashboardID(c, signedInUser, elementVersions, dashboardID)
+}
+
+// ConnectElementVersionsToDashboard connects elements to a specific dashboard. elementVersions maps the
+// element UIDs to the version the dashboard is pinned to, or 0 to follow the latest version.
+func (l *LibraryElementService) ConnectElementVersionsToDashboard(c context.Context, signedInUser identity.Requester, elementVersions map[string]int64, dashboardID int64) error {
+	return l.connectElementsToDashboardID(c, signedInUser, elementVersions, dashboardID)
 }
 
 // DisconnectElementsFromDashboard disconnects elements from a specific dashboard.
@@ -111,3 +125,39 @@ func (l *LibraryElementService) addUidToLibraryPanel(model []byte, newUid string
 
 	return updatedModel, nil
 }
+
+// checkForCredentials checks the model of a library element about to be saved for leaked credentials.
+func (l *LibraryElementService) checkForCredentials(c context.Context, element model.LibraryElement) error {
+	if l.credentialScan == nil || len(element.Model) == 0 {
+		return nil
+	}
+	return l.credentialScan.CheckResource(c, libraryElementResource(element.OrgID, element.UID, element.Name, element.Model))
+}
+
+// scanForCredentials records the leaked credentials of a saved library element.
+func (l *LibraryElementService) scanForCredentials(c context.Context, element model.LibraryElementDTO) {
+	if l.credentialScan == nil || len(element.Model) == 0 {
+		return
+	}
+	l.credentialScan.ScanResource(c, libraryElementResource(element.OrgID, element.UID, element.Name, element.Model))
+}
+
+// removeCredentialFindings removes the recorded leaked credentials of deleted library elements.
+func (l *LibraryElementService) removeCredentialFindings(c context.Context, orgID int64, uids ...string) {
+	if l.credentialScan == nil {
+		return
+	}
+	for _, uid := range uids {
+		l.credentialScan.DeleteResource(c, orgID, credentialscan.KindLibraryPanel, uid)
+	}
+}
+
+func libraryElementResource(orgID int64, uid, name string, m json.RawMessage) credentialscan.Resource {
+	return credentialscan.Resource{
+		OrgID:   orgID,
+		Kind:    credentialscan.KindLibraryPanel,
+		UID:     uid,
+		Name:    name,
+		Content: []byte(m),
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/grafana/pkg/kinds/librarypanel"
+	"github.com/grafana/grafana/pkg/services/credentialscan"
+	"github.com/grafana/grafana/pkg/services/credentialscan/credentialscantest"
 	"github.com/grafana/grafana/pkg/services/libraryelements/model"
 	"github.com/grafana/grafana/pkg/util"
 )
@@ -195,4 +197,32 @@ func TestCreateLibraryElement(t *testing.T) {
 				t.Fatalf("Result mismatch (-want +got):\n%s", diff)
 			}
 		})
+
+	testScenario(t, "When an admin creates a library panel, it should be scanned for credentials with its generated UID",
+		func(t *testing.T, sc scenarioContext) {
+			credentialScan := &credentialscantest.FakeService{}
+			sc.service.credentialScan = credentialScan
+			// nolint:staticcheck
+			command := getCreatePanelCommand(sc.folder.ID, sc.folder.UID, "Scanned")
+			sc.reqContext.Req.Body = mockRequestBody(command)
+			resp := sc.service.createHandler(sc.reqContext)
+			var result = validateAndUnMarshalResponse(t, resp)
+
+			require.Len(t, credentialScan.Checked, 1)
+			require.Len(t, credentialScan.Scanned, 1)
+			require.Equal(t, credentialscan.KindLibraryPanel, credentialScan.Scanned[0].Kind)
+			require.Equal(t, result.Result.UID, credentialScan.Scanned[0].UID)
+		})
+
+	testScenario(t, "When an admin creates a library panel with credentials and saves are blocked, it should fail",
+		func(t *testing.T, sc scenarioContext) {
+			credentialScan := &credentialscantest.FakeService{ExpectedErr: credentialscan.ErrCredentialsDetected.Errorf("credentials detected")}
+			sc.service.credentialScan = credentialScan
+			// nolint:staticcheck
+			command := getCreatePanelCommand(sc.folder.ID, sc.folder.UID, "Blocked")
+			sc.reqContext.Req.Body = mockRequestBody(command)
+			resp := sc.service.createHandler(sc.reqContext)
+			require.Equal(t, 400, resp.Status())
+			require.Empty(t, credentialScan.Scanned)
+		})
 }
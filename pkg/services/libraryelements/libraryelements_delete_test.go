@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/credentialscan"
+	"github.com/grafana/grafana/pkg/services/credentialscan/credentialscantest"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/libraryelements/model"
 	"github.com/grafana/grafana/pkg/services/org"
@@ -32,6 +34,19 @@ func TestDeleteLibraryElement(t *testing.T) {
 			require.Equal(t, sc.initialResult.Result.ID, result.ID)
 		})
 
+	scenarioWithPanel(t, "When an admin deletes a library panel, it should remove its credential findings",
+		func(t *testing.T, sc scenarioContext) {
+			credentialScan := &credentialscantest.FakeService{}
+			sc.service.credentialScan = credentialScan
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+			resp := sc.service.deleteHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+
+			require.Len(t, credentialScan.Deleted, 1)
+			require.Equal(t, credentialscan.KindLibraryPanel, credentialScan.Deleted[0].Kind)
+			require.Equal(t, sc.initialResult.Result.UID, credentialScan.Deleted[0].UID)
+		})
+
 	scenarioWithPanel(t, "When an admin tries to delete a library panel in another org, it should fail",
 		func(t *testing.T, sc scenarioContext) {
 			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
@@ -0,0 +1,188 @@
+package libraryelements
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/libraryelements/model"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+func TestLibraryElementVersions(t *testing.T) {
+	patch := func(t *testing.T, sc scenarioContext, name string, version int64) {
+		t.Helper()
+		cmd := model.PatchLibraryElementCommand{
+			FolderID: -1, // nolint:staticcheck
+			Name:     name,
+			Model:    []byte(`{"title": "` + name + `", "type": "text"}`),
+			Kind:     int64(model.PanelElement),
+			Version:  version,
+		}
+		sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+		sc.reqContext.Req.Body = mockRequestBody(cmd)
+		resp := sc.service.patchHandler(sc.reqContext)
+		require.Equal(t, 200, resp.Status())
+	}
+
+	getVersions := func(t *testing.T, sc scenarioContext) []model.LibraryElementVersionDTO {
+		t.Helper()
+		sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+		resp := sc.service.getVersionsHandler(sc.reqContext)
+		require.Equal(t, 200, resp.Status())
+		var result model.LibraryElementVersionsResponse
+		require.NoError(t, json.Unmarshal(resp.Body(), &result))
+		return result.Result
+	}
+
+	scenarioWithPanel(t, "When an admin patches a library panel, it should store a new version",
+		func(t *testing.T, sc scenarioContext) {
+			sc.reqContext.SignedInUser.Permissions[sc.reqContext.OrgID][dashboards.ActionFoldersRead] = []string{dashboards.ScopeFoldersAll}
+			patch(t, sc, "Panel - v2", 1)
+
+			versions := getVersions(t, sc)
+			require.Len(t, versions, 2)
+			require.Equal(t, []int64{2, 1}, []int64{versions[0].Version, versions[1].Version})
+			require.Equal(t, "Panel - v2", versions[0].Name)
+			require.Equal(t, sc.initialResult.Result.Name, versions[1].Name)
+			require.Equal(t, sc.initialResult.Result.UID, versions[1].ElementUID)
+			require.Equal(t, userInDbName, versions[1].CreatedBy.Name)
+
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID, ":version": "1"})
+			resp := sc.service.getVersionHandler(sc.reqContext)
+			require.Equal(t, 200, resp.Status())
+			var result model.LibraryElementVersionResponse
+			require.NoError(t, json.Unmarshal(resp.Body(), &result))
+			require.Equal(t, "Text - Library Panel", simplejson.MustJson(result.Result.Model).Get("title").MustString())
+
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID, ":version": "3"})
+			resp = sc.service.getVersionHandler(sc.reqContext)
+			require.Equal(t, 404, resp.Status())
+		})
+
+	scenarioWithPanel(t, "When an admin compares two versions of a library panel, it should return the diff",
+		func(t *testing.T, sc scenarioContext) {
+			sc.reqContext.SignedInUser.Permissions[sc.reqContext.OrgID][dashboards.ActionFoldersRead] = []string{dashboards.ScopeFoldersAll}
+			patch(t, sc, "Panel - v2", 1)
+
+			diff := func(query string) response.Response {
+				sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+				sc.ctx.Req.URL = &url.URL{RawQuery: query}
+				sc.ctx.Req.Form = nil
+				return sc.service.diffHandler(sc.reqContext)
+			}
+
+			resp := diff("base=1&new=2&diffType=delta")
+			require.Equal(t, 200, resp.Status())
+			delta := simplejson.MustJson(resp.Body())
+			require.Equal(t, []any{"Text - Library Panel", "Panel - v2"}, delta.Get("title").MustArray())
+
+			resp = diff("base=2&new=2&diffType=delta")
+			require.Equal(t, 200, resp.Status())
+			require.JSONEq(t, `{}`, string(resp.Body()))
+
+			resp = diff("base=1&new=5")
+			require.Equal(t, 404, resp.Status())
+		})
+
+	scenarioWithPanel(t, "When an admin restores a version of a library panel, it should store it as a new version",
+		func(t *testing.T, sc scenarioContext) {
+			sc.reqContext.SignedInUser.Permissions[sc.reqContext.OrgID][dashboards.ActionFoldersRead] = []string{dashboards.ScopeFoldersAll}
+			patch(t, sc, "Panel - v2", 1)
+
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": sc.initialResult.Result.UID})
+			sc.reqContext.Req.Body = mockRequestBody(model.RestoreLibraryElementVersionCommand{Version: 1})
+			resp := sc.service.restoreVersionHandler(sc.reqContext)
+			result := validateAndUnMarshalResponse(t, resp)
+			require.Equal(t, int64(3), result.Result.Version)
+			require.Equal(t, sc.initialResult.Result.Name, result.Result.Name)
+			require.Equal(t, sc.initialResult.Result.Model["title"], result.Result.Model["title"])
+			require.Equal(t, sc.initialResult.Result.FolderUID, result.Result.Meta.FolderUID)
+
+			versions := getVersions(t, sc)
+			require.Len(t, versions, 3)
+			require.Equal(t, int64(3), versions[0].Version)
+			require.Equal(t, int64(1), versions[0].RestoredFrom)
+
+			sc.reqContext.Req.Body = mockRequestBody(model.RestoreLibraryElementVersionCommand{Version: 7})
+			resp = sc.service.restoreVersionHandler(sc.reqContext)
+			require.Equal(t, 404, resp.Status())
+		})
+
+	scenarioWithPanel(t, "When library panel versions expire, it should delete them except for pinned versions",
+		func(t *testing.T, sc scenarioContext) {
+			sc.reqContext.SignedInUser.Permissions[sc.reqContext.OrgID][dashboards.ActionFoldersRead] = []string{dashboards.ScopeFoldersAll}
+			for version := int64(1); version < 5; version++ {
+				patch(t, sc, fmt.Sprintf("Panel - v%d", version+1), version)
+			}
+			dash := dashboards.Dashboard{Title: "Pinned", Data: simplejson.NewFromAny(map[string]any{})}
+			// nolint:staticcheck
+			pinned := createDashboard(t, sc.sqlStore, sc.user, &dash, sc.folder.ID, sc.folder.UID)
+			err := sc.service.ConnectElementVersionsToDashboard(sc.reqContext.Req.Context(), sc.reqContext.SignedInUser, map[string]int64{sc.initialResult.Result.UID: 1}, pinned.ID)
+			require.NoError(t, err)
+
+			sc.service.Cfg.DashboardVersionsToKeep = 2
+			deleted, err := sc.service.DeleteExpiredVersions(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, int64(2), deleted)
+
+			versions := getVersions(t, sc)
+			require.Equal(t, []int64{5, 4, 1}, []int64{versions[0].Version, versions[1].Version, versions[2].Version})
+
+			deleted, err = sc.service.DeleteExpiredVersions(context.Background())
+			require.NoError(t, err)
+			require.Zero(t, deleted)
+		})
+
+	scenarioWithPanel(t, "When a dashboard is pinned to an older version of a library panel, it should be returned as outdated",
+		func(t *testing.T, sc scenarioContext) {
+			sc.reqContext.SignedInUser.Permissions[sc.reqContext.OrgID][dashboards.ActionFoldersRead] = []string{dashboards.ScopeFoldersAll}
+			createDashboardWithID := func(title string) *dashboards.Dashboard {
+				dash := dashboards.Dashboard{Title: title, Data: simplejson.NewFromAny(map[string]any{})}
+				// nolint:staticcheck
+				return createDashboard(t, sc.sqlStore, sc.user, &dash, sc.folder.ID, sc.folder.UID)
+			}
+			pinned := createDashboardWithID("Pinned")
+			latest := createDashboardWithID("Latest")
+			uid := sc.initialResult.Result.UID
+
+			err := sc.service.ConnectElementVersionsToDashboard(sc.reqContext.Req.Context(), sc.reqContext.SignedInUser, map[string]int64{uid: 1}, pinned.ID)
+			require.NoError(t, err)
+			err = sc.service.ConnectElementsToDashboard(sc.reqContext.Req.Context(), sc.reqContext.SignedInUser, []string{uid}, latest.ID)
+			require.NoError(t, err)
+			err = sc.service.ConnectElementVersionsToDashboard(sc.reqContext.Req.Context(), sc.reqContext.SignedInUser, map[string]int64{uid: 2}, latest.ID)
+			require.ErrorIs(t, err, model.ErrLibraryElementVersionNotFound)
+
+			sc.ctx.Req = web.SetURLParams(sc.ctx.Req, map[string]string{":uid": uid})
+			resp := sc.service.getOutdatedConnectionsHandler(sc.reqContext)
+			require.Empty(t, validateAndUnMarshalConnectionResponse(t, resp).Result)
+
+			patch(t, sc, "Panel - v2", 1)
+
+			resp = sc.service.getOutdatedConnectionsHandler(sc.reqContext)
+			outdated := validateAndUnMarshalConnectionResponse(t, resp).Result
+			require.Len(t, outdated, 1)
+			require.Equal(t, pinned.UID, outdated[0].ConnectionUID)
+			require.Equal(t, int64(1), outdated[0].PinnedVersion)
+
+			resp = sc.service.getConnectionsHandler(sc.reqContext)
+			require.Len(t, validateAndUnMarshalConnectionResponse(t, resp).Result, 2)
+
+			// versions older than the version history are not stored
+			err = sc.sqlStore.WithDbSession(context.Background(), func(sess *db.Session) error {
+				_, err := sess.Exec("DELETE FROM " + model.LibraryElementVersionTableName + " WHERE version=1")
+				return err
+			})
+			require.NoError(t, err)
+			err = sc.service.ConnectElementVersionsToDashboard(sc.reqContext.Req.Context(), sc.reqContext.SignedInUser, map[string]int64{uid: 1}, pinned.ID)
+			require.ErrorIs(t, err, model.ErrLibraryElementVersionNotFound)
+		})
+}
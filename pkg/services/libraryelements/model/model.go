@@ -103,16 +103,57 @@ type LibraryElementDTOMeta struct {
 	UpdatedBy librarypanel.LibraryElementDTOMetaUser `json:"updatedBy"`
 }
 
-// libraryElementConnection is the model for library element connections.
-type LibraryElementConnection struct {
+// LibraryElementVersion is the model for the stored versions of a library element.
+type LibraryElementVersion struct {
 	ID           int64 `xorm:"pk autoincr 'id'"`
+	OrgID        int64 `xorm:"org_id"`
 	ElementID    int64 `xorm:"element_id"`
-	Kind         int64 `xorm:"kind"`
-	ConnectionID int64 `xorm:"connection_id"`
+	Version      int64
+	RestoredFrom int64
+	Name         string
+	Model        json.RawMessage
 	Created      time.Time
 	CreatedBy    int64
 }
 
+// LibraryElementVersionWithMeta is the model used to retrieve library element versions with additional meta information.
+type LibraryElementVersionWithMeta struct {
+	ID             int64 `xorm:"pk autoincr 'id'"`
+	OrgID          int64 `xorm:"org_id"`
+	ElementID      int64 `xorm:"element_id"`
+	Version        int64
+	RestoredFrom   int64
+	Name           string
+	Model          json.RawMessage
+	Created        time.Time
+	CreatedBy      int64
+	CreatedByName  string
+	CreatedByEmail string
+}
+
+// LibraryElementVersionDTO is the frontend DTO for library element versions.
+type LibraryElementVersionDTO struct {
+	ID           int64                                  `json:"id"`
+	ElementUID   string                                 `json:"elementUid"`
+	Version      int64                                  `json:"version"`
+	RestoredFrom int64                                  `json:"restoredFrom"`
+	Name         string                                 `json:"name"`
+	Model        json.RawMessage                        `json:"model"`
+	Created      time.Time                              `json:"created"`
+	CreatedBy    librarypanel.LibraryElementDTOMetaUser `json:"createdBy"`
+}
+
+// libraryElementConnection is the model for library element connections.
+type LibraryElementConnection struct {
+	ID            int64 `xorm:"pk autoincr 'id'"`
+	ElementID     int64 `xorm:"element_id"`
+	Kind          int64 `xorm:"kind"`
+	ConnectionID  int64 `xorm:"connection_id"`
+	PinnedVersion int64 `xorm:"pinned_version"`
+	Created       time.Time
+	CreatedBy     int64
+}
+
 // libraryElementConnectionWithMeta is the model for library element connections with meta.
 type LibraryElementConnectionWithMeta struct {
 	ID             int64  `xorm:"pk autoincr 'id'"`
@@ -120,6 +161,7 @@ type LibraryElementConnectionWithMeta struct {
 	Kind           int64  `xorm:"kind"`
 	ConnectionID   int64  `xorm:"connection_id"`
 	ConnectionUID  string `xorm:"connection_uid"`
+	PinnedVersion  int64  `xorm:"pinned_version"`
 	Created        time.Time
 	CreatedBy      int64
 	CreatedByName  string
@@ -128,11 +170,13 @@ type LibraryElementConnectionWithMeta struct {
 
 // LibraryElementConnectionDTO is the frontend DTO for element connections.
 type LibraryElementConnectionDTO struct {
-	ID            int64                                  `json:"id"`
-	Kind          int64                                  `json:"kind"`
-	ElementID     int64                                  `json:"elementId"`
-	ConnectionID  int64                                  `json:"connectionId"`
-	ConnectionUID string                                 `json:"connectionUid"`
+	ID            int64  `json:"id"`
+	Kind          int64  `json:"kind"`
+	ElementID     int64  `json:"elementId"`
+	ConnectionID  int64  `json:"connectionId"`
+	ConnectionUID string `json:"connectionUid"`
+	// Version of the library element the connection is pinned to, 0 when the connection follows the latest version.
+	PinnedVersion int64                                  `json:"pinnedVersion"`
 	Created       time.Time                              `json:"created"`
 	CreatedBy     librarypanel.LibraryElementDTOMetaUser `json:"createdBy"`
 }
@@ -156,6 +200,8 @@ var (
 	ErrLibraryElementInvalidUID = errors.New("uid contains illegal characters")
 	// errLibraryElementUIDTooLong is an error for when the uid of a library element is invalid
 	ErrLibraryElementUIDTooLong = errors.New("uid too long, max 40 characters")
+	// ErrLibraryElementVersionNotFound is an error for when a version of a library element can't be found.
+	ErrLibraryElementVersionNotFound = errors.New("library element version could not be found")
 )
 
 // Commands
@@ -208,6 +254,12 @@ type PatchLibraryElementCommand struct {
 	UID string `json:"uid"`
 }
 
+// RestoreLibraryElementVersionCommand is the command for restoring a version of a LibraryElement
+type RestoreLibraryElementVersionCommand struct {
+	// Version of the library element to restore.
+	Version int64 `json:"version" binding:"Required"`
+}
+
 // GetLibraryElementCommand is the command for getting a library element.
 type GetLibraryElementCommand struct {
 	FolderName string
@@ -250,6 +302,16 @@ type LibraryElementConnectionsResponse struct {
 	Result []LibraryElementConnectionDTO `json:"result"`
 }
 
+// LibraryElementVersionsResponse is a response struct for an array of LibraryElementVersionDTO.
+type LibraryElementVersionsResponse struct {
+	Result []LibraryElementVersionDTO `json:"result"`
+}
+
+// LibraryElementVersionResponse is a response struct for LibraryElementVersionDTO.
+type LibraryElementVersionResponse struct {
+	Result LibraryElementVersionDTO `json:"result"`
+}
+
 // DeleteLibraryElementResponse is the response struct for deleting a library element.
 type DeleteLibraryElementResponse struct {
 	ID      int64  `json:"id"`
@@ -267,3 +329,5 @@ const (
 )
 
 const LibraryElementConnectionTableName = "library_element_connection"
+
+const LibraryElementVersionTableName = "library_element_version"
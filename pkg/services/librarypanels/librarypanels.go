@@ -63,27 +63,23 @@ type LibraryPanelService struct {
 var _ Service = (*LibraryPanelService)(nil)
 
 // ConnectLibraryPanelsForDashboard loops through all panels in dashboard JSON and connects any library panels to the dashboard.
+// Library panels with a pinnedVersion are connected to that version, the others follow the latest version.
 func (lps *LibraryPanelService) ConnectLibraryPanelsForDashboard(c context.Context, signedInUser identity.Requester, dash *dashboards.Dashboard) error {
 	panels := dash.Data.Get("panels").MustArray()
-	libraryPanels := make(map[string]string)
+	libraryPanels := make(map[string]int64)
 	err := connectLibraryPanelsRecursively(c, panels, libraryPanels)
 	if err != nil {
 		return err
 	}
 
-	elementUIDs := make([]string, 0, len(libraryPanels))
-	for libraryPanel := range libraryPanels {
-		elementUIDs = append(elementUIDs, libraryPanel)
-	}
-
-	return lps.LibraryElementService.ConnectElementsToDashboard(c, signedInUser, elementUIDs, dash.ID)
+	return lps.LibraryElementService.ConnectElementVersionsToDashboard(c, signedInUser, libraryPanels, dash.ID)
 }
 
 func isLibraryPanelOrRow(panel *simplejson.Json, panelType string) bool {
 	return panel.Interface() != nil || panelType == "row"
 }
 
-func connectLibraryPanelsRecursively(c context.Context, panels []any, libraryPanels map[string]string) error {
+func connectLibraryPanelsRecursively(c context.Context, panels []any, libraryPanels map[string]int64) error {
 	for _, panel := range panels {
 		panelAsJSON := simplejson.NewFromAny(panel)
 		libraryPanel := panelAsJSON.Get("libraryPanel")
@@ -107,9 +103,12 @@ func connectLibraryPanelsRecursively(c context.Context, panels []any, libraryPan
 		if len(UID) == 0 {
 			return errLibraryPanelHeaderUIDMissing
 		}
-		_, exists := libraryPanels[UID]
+		pinnedVersion := libraryPanel.Get("pinnedVersion").MustInt64()
+		existing, exists := libraryPanels[UID]
 		if !exists {
-			libraryPanels[UID] = UID
+			libraryPanels[UID] = pinnedVersion
+		} else if existing != pinnedVersion {
+			return fmt.Errorf("%w: %s", errLibraryPanelPinnedVersionConflict, UID)
 		}
 	}
 
@@ -19,6 +19,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/accesscontrol/actest"
 	acmock "github.com/grafana/grafana/pkg/services/accesscontrol/mock"
 	"github.com/grafana/grafana/pkg/services/accesscontrol/ossaccesscontrol/testutil"
+	"github.com/grafana/grafana/pkg/services/credentialscan/credentialscantest"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/dashboards/database"
 	dashboardservice "github.com/grafana/grafana/pkg/services/dashboards/service"
@@ -235,6 +236,44 @@ func TestConnectLibraryPanelsForDashboard(t *testing.T) {
 			require.EqualError(t, err, errLibraryPanelHeaderUIDMissing.Error())
 		})
 
+	scenarioWithLibraryPanel(t, "When an admin tries to store a dashboard with a pinned library panel, it should connect the pinned version",
+		func(t *testing.T, sc scenarioContext) {
+			libraryPanelWithVersion := func(version int64) map[string]any {
+				return map[string]any{
+					"id":      int64(1),
+					"gridPos": map[string]any{"h": 6, "w": 6, "x": 0, "y": 0},
+					"libraryPanel": map[string]any{
+						"uid":           sc.initialResult.Result.UID,
+						"name":          sc.initialResult.Result.Name,
+						"pinnedVersion": version,
+					},
+				}
+			}
+			dash := dashboards.Dashboard{
+				Title: "Testing pinned library panels",
+				Data:  simplejson.NewFromAny(map[string]any{"panels": []any{libraryPanelWithVersion(1)}}),
+			}
+			dashInDB := createDashboard(t, sc.sqlStore, sc.user, &dash)
+
+			err := sc.service.ConnectLibraryPanelsForDashboard(sc.ctx, sc.user, dashInDB)
+			require.NoError(t, err)
+
+			var pinnedVersions []int64
+			err = sc.sqlStore.WithDbSession(sc.ctx, func(sess *db.Session) error {
+				return sess.SQL("SELECT pinned_version FROM "+model.LibraryElementConnectionTableName+" WHERE connection_id=?", dashInDB.ID).Find(&pinnedVersions)
+			})
+			require.NoError(t, err)
+			require.Equal(t, []int64{1}, pinnedVersions)
+
+			dashInDB.Data = simplejson.NewFromAny(map[string]any{"panels": []any{libraryPanelWithVersion(2)}})
+			err = sc.service.ConnectLibraryPanelsForDashboard(sc.ctx, sc.user, dashInDB)
+			require.ErrorIs(t, err, model.ErrLibraryElementVersionNotFound)
+
+			dashInDB.Data = simplejson.NewFromAny(map[string]any{"panels": []any{libraryPanelWithVersion(1), libraryPanelWithVersion(0)}})
+			err = sc.service.ConnectLibraryPanelsForDashboard(sc.ctx, sc.user, dashInDB)
+			require.ErrorIs(t, err, errLibraryPanelPinnedVersionConflict)
+		})
+
 	scenarioWithLibraryPanel(t, "When an admin tries to store a dashboard with unused/removed library panels, it should disconnect unused/removed library panels",
 		func(t *testing.T, sc scenarioContext) {
 			unused, err := sc.elementService.CreateElement(sc.ctx, sc.user, model.CreateLibraryElementCommand{
@@ -735,6 +774,7 @@ func createDashboard(t *testing.T, sqlStore db.DB, user *user.SignedInUser, dash
 		featuremgmt.WithFeatures(), acmock.NewMockedPermissionsService(), dashPermissionService, ac,
 		foldertest.NewFakeService(), folder.NewFakeStore(),
 		nil,
+		nil,
 	)
 	require.NoError(t, err)
 	dashboard, err := service.SaveDashboard(context.Background(), dashItem, true)
@@ -832,6 +872,7 @@ func testScenario(t *testing.T, desc string, fn func(t *testing.T, sc scenarioCo
 			features, acmock.NewMockedPermissionsService(), dashPermissionService, ac,
 			foldertest.NewFakeService(), folder.NewFakeStore(),
 			nil,
+			nil,
 		)
 		require.NoError(t, err)
 		guardian.InitAccessControlGuardian(cfg, ac, dashService)
@@ -845,7 +886,7 @@ func testScenario(t *testing.T, desc string, fn func(t *testing.T, sc scenarioCo
 		folderService := folderimpl.ProvideService(fStore, ac, bus.ProvideBus(tracing.InitializeTracerForTest()), dashboardStore, folderStore, sqlStore,
 			features, cfg, folderPermissions, supportbundlestest.NewFakeBundleService(), nil, tracing.InitializeTracerForTest())
 
-		elementService := libraryelements.ProvideService(cfg, sqlStore, routing.NewRouteRegister(), folderService, fStore, features, ac)
+		elementService := libraryelements.ProvideService(cfg, sqlStore, routing.NewRouteRegister(), folderService, fStore, features, ac, &credentialscantest.FakeService{})
 		service := LibraryPanelService{
 			Cfg:                   cfg,
 			SQLStore:              sqlStore,
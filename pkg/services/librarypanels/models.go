@@ -9,4 +9,6 @@ var (
 	errLibraryPanelHeaderUIDMissing = errors.New("library panel header is missing required property uid")
 	// errLibraryPanelHeaderNameMissing is an error for when a library panel header is missing the name property.
 	errLibraryPanelHeaderNameMissing = errors.New("library panel header is missing required property name")
+	// errLibraryPanelPinnedVersionConflict is an error for when a library panel is used with different pinned versions in a dashboard.
+	errLibraryPanelPinnedVersionConflict = errors.New("library panel is pinned to different versions in the dashboard")
 )
@@ -19,6 +19,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/ngalert/notifier"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier/legacy_storage"
 	"github.com/grafana/grafana/pkg/services/ngalert/provisioning"
 	"github.com/grafana/grafana/pkg/services/ngalert/sender"
 	"github.com/grafana/grafana/pkg/services/ngalert/state"
@@ -35,6 +36,12 @@ type ExternalAlertmanagerProvider interface {
 	DroppedAlertmanagersFor(orgID int64) []*url.URL
 }
 
+// SilenceScheduleStore stores recurring silences and silences that expire once their alerts resolve.
+type SilenceScheduleStore interface {
+	notifier.RecurringSilenceStore
+	notifier.SilenceExpiryStore
+}
+
 type AlertingStore interface {
 	GetLatestAlertmanagerConfiguration(ctx context.Context, orgID int64) (*models.AlertConfiguration, error)
 }
@@ -76,6 +83,7 @@ type API struct {
 	ConditionValidator   *eval.ConditionValidator
 	FeatureManager       featuremgmt.FeatureToggles
 	Historian            Historian
+	SilenceScheduleStore SilenceScheduleStore
 	Tracer               tracing.Tracer
 	AppUrl               *url.URL
 
@@ -91,6 +99,7 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 		ac:        api.AccessControl,
 	}
 	ruleAuthzService := accesscontrol.NewRuleService(api.AccessControl)
+	silenceAuthzService := accesscontrol.NewSilenceService(api.AccessControl, api.RuleStore)
 
 	// Register endpoints for proxying to Alertmanager-compatible backends.
 	api.RegisterAlertmanagerApiEndpoints(NewForkingAM(
@@ -103,12 +112,13 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 			mam:            api.MultiOrgAlertmanager,
 			featureManager: api.FeatureManager,
 			silenceSvc: notifier.NewSilenceService(
-				accesscontrol.NewSilenceService(api.AccessControl, api.RuleStore),
+				silenceAuthzService,
 				api.TransactionManager,
 				logger,
 				api.MultiOrgAlertmanager,
 				api.RuleStore,
 				ruleAuthzService,
+				api.SilenceScheduleStore,
 			),
 			receiverAuthz: accesscontrol.NewReceiverAccess[ReceiverStatus](api.AccessControl, false),
 		},
@@ -181,5 +191,13 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 		logger:            logger,
 		receiverService:   api.ReceiverService,
 		muteTimingService: api.MuteTimings,
+		recurringSilenceService: notifier.NewRecurringSilenceService(
+			silenceAuthzService,
+			api.TransactionManager,
+			logger,
+			api.SilenceScheduleStore,
+			legacy_storage.NewAlertmanagerConfigStore(api.AlertingStore),
+			api.ProvenanceStore,
+		),
 	}), m)
 }
@@ -21,6 +21,8 @@ type SilenceService interface {
 	CreateSilence(ctx context.Context, user identity.Requester, ps models.Silence) (string, error)
 	UpdateSilence(ctx context.Context, user identity.Requester, ps models.Silence) (string, error)
 	DeleteSilence(ctx context.Context, user identity.Requester, silenceID string) error
+	AuthorizeExpireOnResolve(ctx context.Context, user identity.Requester, ps *models.Silence) error
+	ExpireOnResolve(ctx context.Context, user identity.Requester, silenceID string) error
 	WithAccessControlMetadata(ctx context.Context, user identity.Requester, silencesWithMetadata ...*models.SilenceWithMetadata) error
 	WithRuleMetadata(ctx context.Context, user identity.Requester, silences ...*models.SilenceWithMetadata) error
 }
@@ -77,14 +79,27 @@ func (srv AlertmanagerSrv) RouteCreateSilence(c *contextmodel.ReqContext, postab
 		srv.log.Error("Silence failed validation", "error", err)
 		return ErrResp(http.StatusBadRequest, err, "silence failed validation")
 	}
+	silence := PostableSilenceToSilence(postableSilence)
+	expireOnResolve := c.QueryBool("expireOnResolve")
+	// check the permission before saving the silence, so that the silence is not saved when it cannot expire on resolve.
+	if expireOnResolve {
+		if err := srv.silenceSvc.AuthorizeExpireOnResolve(c.Req.Context(), c.SignedInUser, &silence); err != nil {
+			return response.ErrOrFallback(http.StatusInternalServerError, "failed to make silence expire on resolve", err)
+		}
+	}
 	action := srv.silenceSvc.UpdateSilence
 	if postableSilence.ID == "" {
 		action = srv.silenceSvc.CreateSilence
 	}
-	silenceID, err := action(c.Req.Context(), c.SignedInUser, PostableSilenceToSilence(postableSilence))
+	silenceID, err := action(c.Req.Context(), c.SignedInUser, silence)
 	if err != nil {
 		return response.ErrOrFallback(http.StatusInternalServerError, "failed to create/update silence", err)
 	}
+	if expireOnResolve {
+		if err := srv.silenceSvc.ExpireOnResolve(c.Req.Context(), c.SignedInUser, silenceID); err != nil {
+			return response.ErrOrFallback(http.StatusInternalServerError, "failed to make silence expire on resolve", err)
+		}
+	}
 
 	return response.JSON(http.StatusAccepted, apimodels.PostSilencesOKBody{
 		SilenceID: silenceID,
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"net/http"
+	"net/url"
 	"testing"
 	"time"
 
@@ -162,3 +163,34 @@ func TestRouteCreateSilence(t *testing.T) {
 		})
 	}
 }
+
+func TestRouteCreateSilenceExpireOnResolve(t *testing.T) {
+	t.Run("should not create the silence when the user cannot make it expire on resolve", func(t *testing.T) {
+		sut := createSut(t)
+
+		rc := contextmodel.ReqContext{
+			Context: &web.Context{
+				Req: &http.Request{URL: &url.URL{RawQuery: "expireOnResolve=true"}},
+			},
+			SignedInUser: &user.SignedInUser{
+				Permissions: map[int64]map[string][]string{
+					1: {
+						accesscontrol.ActionAlertingInstanceRead:   {},
+						accesscontrol.ActionAlertingInstanceCreate: {},
+					},
+				},
+				OrgID: 1,
+			},
+		}
+
+		silence := notifier.SilenceToPostableSilence(ngmodels.SilenceGen(ngmodels.SilenceMuts.WithEmptyId())())
+		response := sut.RouteCreateSilence(&rc, *silence)
+		require.Equal(t, http.StatusForbidden, response.Status())
+
+		alertmanagerFor, err := sut.mam.AlertmanagerFor(1)
+		require.NoError(t, err)
+		silences, err := alertmanagerFor.ListSilences(context.Background(), nil)
+		require.NoError(t, err)
+		require.Empty(t, silences)
+	})
+}
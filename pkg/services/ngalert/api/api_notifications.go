@@ -8,13 +8,15 @@ import (
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/infra/log"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
 )
 
 type NotificationSrv struct {
-	logger            log.Logger
-	receiverService   ReceiverService
-	muteTimingService MuteTimingService // defined in api_provisioning.go
+	logger                  log.Logger
+	receiverService         ReceiverService
+	muteTimingService       MuteTimingService // defined in api_provisioning.go
+	recurringSilenceService RecurringSilenceService
 }
 
 type ReceiverService interface {
@@ -22,6 +24,14 @@ type ReceiverService interface {
 	ListReceivers(ctx context.Context, q models.ListReceiversQuery, user identity.Requester) ([]*models.Receiver, error)
 }
 
+type RecurringSilenceService interface {
+	ListRecurringSilences(ctx context.Context, user identity.Requester) ([]*models.RecurringSilence, error)
+	GetRecurringSilence(ctx context.Context, user identity.Requester, uid string) (*models.RecurringSilence, error)
+	CreateRecurringSilence(ctx context.Context, user identity.Requester, r models.RecurringSilence) (models.RecurringSilence, error)
+	UpdateRecurringSilence(ctx context.Context, user identity.Requester, r models.RecurringSilence) (models.RecurringSilence, error)
+	DeleteRecurringSilence(ctx context.Context, user identity.Requester, uid string, callerProvenance models.Provenance) error
+}
+
 func (srv *NotificationSrv) RouteGetTimeInterval(c *contextmodel.ReqContext, name string) response.Response {
 	muteTimeInterval, err := srv.muteTimingService.GetMuteTiming(c.Req.Context(), name, c.OrgID)
 	if err != nil {
@@ -78,3 +88,59 @@ func (srv *NotificationSrv) RouteGetReceivers(c *contextmodel.ReqContext) respon
 
 	return response.JSON(http.StatusOK, gettables)
 }
+
+func (srv *NotificationSrv) RouteGetRecurringSilences(c *contextmodel.ReqContext) response.Response {
+	silences, err := srv.recurringSilenceService.ListRecurringSilences(c.Req.Context(), c.SignedInUser)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get recurring silences", err)
+	}
+	result := make([]definitions.RecurringSilence, 0, len(silences))
+	for _, s := range silences {
+		result = append(result, RecurringSilenceToApi(s))
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+func (srv *NotificationSrv) RouteGetRecurringSilence(c *contextmodel.ReqContext, uid string) response.Response {
+	silence, err := srv.recurringSilenceService.GetRecurringSilence(c.Req.Context(), c.SignedInUser, uid)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get recurring silence", err)
+	}
+	return response.JSON(http.StatusOK, RecurringSilenceToApi(silence))
+}
+
+func (srv *NotificationSrv) RouteCreateRecurringSilence(c *contextmodel.ReqContext, body definitions.RecurringSilence) response.Response {
+	silence, err := RecurringSilenceFromApi(body)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "invalid recurring silence")
+	}
+	silence.CreatedBy = c.SignedInUser.GetLogin()
+	silence.Provenance = models.ProvenanceNone
+	created, err := srv.recurringSilenceService.CreateRecurringSilence(c.Req.Context(), c.SignedInUser, silence)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to create recurring silence", err)
+	}
+	return response.JSON(http.StatusCreated, RecurringSilenceToApi(&created))
+}
+
+func (srv *NotificationSrv) RouteUpdateRecurringSilence(c *contextmodel.ReqContext, body definitions.RecurringSilence, uid string) response.Response {
+	silence, err := RecurringSilenceFromApi(body)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "invalid recurring silence")
+	}
+	silence.UID = uid
+	silence.CreatedBy = c.SignedInUser.GetLogin()
+	silence.Provenance = models.ProvenanceNone
+	updated, err := srv.recurringSilenceService.UpdateRecurringSilence(c.Req.Context(), c.SignedInUser, silence)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to update recurring silence", err)
+	}
+	return response.JSON(http.StatusOK, RecurringSilenceToApi(&updated))
+}
+
+func (srv *NotificationSrv) RouteDeleteRecurringSilence(c *contextmodel.ReqContext, uid string) response.Response {
+	if err := srv.recurringSilenceService.DeleteRecurringSilence(c.Req.Context(), c.SignedInUser, uid, models.ProvenanceNone); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to delete recurring silence", err)
+	}
+	return response.Empty(http.StatusNoContent)
+}
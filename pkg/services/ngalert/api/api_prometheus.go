@@ -472,6 +472,7 @@ func toRuleGroup(log log.Logger, manager state.AlertInstanceManager, sr StatusRe
 			Type:           rule.Type().String(),
 			LastEvaluation: status.EvaluationTimestamp,
 			EvaluationTime: status.EvaluationDuration.Seconds(),
+			EvaluationCost: toRuleEvaluationCost(status.Cost),
 		}
 
 		states := manager.GetStatesForRuleUID(rule.OrgID, rule.UID)
@@ -613,3 +614,18 @@ func errorOrEmpty(err error) string {
 	}
 	return ""
 }
+
+func toRuleEvaluationCost(cost *ngmodels.RuleEvaluationCost) *apimodels.RuleEvaluationCost {
+	if cost == nil {
+		return nil
+	}
+	return &apimodels.RuleEvaluationCost{
+		QueryTime:         cost.QueryDuration.Seconds(),
+		ExpressionTime:    cost.ExpressionDuration.Seconds(),
+		Series:            cost.Series,
+		MissedEvaluations: cost.MissedEvaluations,
+		LateEvaluations:   cost.LateEvaluations,
+		Slow:              cost.Slow,
+		HighCardinality:   cost.HighCardinality,
+	}
+}
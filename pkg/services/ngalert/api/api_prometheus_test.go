@@ -1508,3 +1508,25 @@ func withExpressionsMultiQuery() ngmodels.AlertRuleMutator {
 		r.Data = queries
 	}
 }
+
+func TestToRuleEvaluationCost(t *testing.T) {
+	require.Nil(t, toRuleEvaluationCost(nil))
+
+	cost := toRuleEvaluationCost(&ngmodels.RuleEvaluationCost{
+		Duration:           3 * time.Second,
+		QueryDuration:      2 * time.Second,
+		ExpressionDuration: 500 * time.Millisecond,
+		Series:             10,
+		MissedEvaluations:  1,
+		LateEvaluations:    2,
+		Slow:               true,
+	})
+	require.Equal(t, &apimodels.RuleEvaluationCost{
+		QueryTime:         2,
+		ExpressionTime:    0.5,
+		Series:            10,
+		MissedEvaluations: 1,
+		LateEvaluations:   2,
+		Slow:              true,
+	}, cost)
+}
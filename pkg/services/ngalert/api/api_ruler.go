@@ -397,22 +397,9 @@ func (srv RulerSrv) updateAlertRulesInGroup(c *contextmodel.ReqContext, groupKey
 			return err
 		}
 
-		newOrUpdatedNotificationSettings := groupChanges.NewOrUpdatedNotificationSettings()
-		if len(newOrUpdatedNotificationSettings) > 0 {
-			dbConfig, err = srv.amConfigStore.GetLatestAlertmanagerConfiguration(c.Req.Context(), groupChanges.GroupKey.OrgID)
-			if err != nil {
-				return fmt.Errorf("failed to get latest configuration: %w", err)
-			}
-			cfg, err := notifier.Load([]byte(dbConfig.AlertmanagerConfiguration))
-			if err != nil {
-				return fmt.Errorf("failed to parse configuration: %w", err)
-			}
-			validator := notifier.NewNotificationSettingsValidator(&cfg.AlertmanagerConfig)
-			for _, s := range newOrUpdatedNotificationSettings {
-				if err := validator.Validate(s); err != nil {
-					return errors.Join(ngmodels.ErrAlertRuleFailedValidation, err)
-				}
-			}
+		dbConfig, err = srv.validateNotificationSettings(c.Req.Context(), groupChanges)
+		if err != nil {
+			return err
 		}
 
 		if err := verifyProvisionedRulesNotAffected(c.Req.Context(), srv.provenanceStore, c.SignedInUser.GetOrgID(), groupChanges); err != nil {
@@ -420,6 +407,7 @@ func (srv RulerSrv) updateAlertRulesInGroup(c *contextmodel.ReqContext, groupKey
 		}
 
 		finalChanges = store.UpdateCalculatedRuleFields(groupChanges)
+		updatedBy := ngmodels.UserUID(c.SignedInUser.GetUID())
 		logger.Debug("Updating database with the authorized changes", "add", len(finalChanges.New), "update", len(finalChanges.New), "delete", len(finalChanges.Delete))
 
 		// Delete first as this could prevent future unique constraint violations.
@@ -438,10 +426,12 @@ func (srv RulerSrv) updateAlertRulesInGroup(c *contextmodel.ReqContext, groupKey
 			updates := make([]ngmodels.UpdateRule, 0, len(finalChanges.Update))
 			for _, update := range finalChanges.Update {
 				logger.Debug("Updating rule", "rule_uid", update.New.UID, "diff", update.Diff.String())
-				updates = append(updates, ngmodels.UpdateRule{
+				upd := ngmodels.UpdateRule{
 					Existing: update.Existing,
 					New:      *update.New,
-				})
+				}
+				upd.New.UpdatedBy = &updatedBy
+				updates = append(updates, upd)
 			}
 			err = srv.store.UpdateAlertRules(tranCtx, updates)
 			if err != nil {
@@ -452,6 +442,7 @@ func (srv RulerSrv) updateAlertRulesInGroup(c *contextmodel.ReqContext, groupKey
 		if len(finalChanges.New) > 0 {
 			inserts := make([]ngmodels.AlertRule, 0, len(finalChanges.New))
 			for _, rule := range finalChanges.New {
+				rule.UpdatedBy = &updatedBy
 				inserts = append(inserts, *rule)
 			}
 			added, err := srv.store.InsertAlertRules(tranCtx, inserts)
@@ -485,29 +476,71 @@ func (srv RulerSrv) updateAlertRulesInGroup(c *contextmodel.ReqContext, groupKey
 	})
 
 	if err != nil {
-		if errors.As(err, &errutil.Error{}) {
-			return response.Err(err)
-		} else if errors.Is(err, ngmodels.ErrAlertRuleNotFound) {
-			return ErrResp(http.StatusNotFound, err, "failed to update rule group")
-		} else if errors.Is(err, ngmodels.ErrAlertRuleFailedValidation) || errors.Is(err, errProvisionedResource) {
-			return ErrResp(http.StatusBadRequest, err, "failed to update rule group")
-		} else if errors.Is(err, ngmodels.ErrQuotaReached) {
-			return ErrResp(http.StatusForbidden, err, "")
-		} else if errors.Is(err, store.ErrOptimisticLock) {
-			return ErrResp(http.StatusConflict, err, "")
+		return toRuleChangesErrorResponse(err, "failed to update rule group")
+	}
+
+	srv.refreshAlertmanagerConfig(c, groupKey.OrgID, dbConfig)
+
+	return changesToResponse(finalChanges)
+}
+
+// validateNotificationSettings validates the notification settings and the active time intervals of the new and updated rules
+// against the latest Alertmanager configuration of the organization.
+// Returns the configuration the settings were validated against, or nil if the changes do not contain notification settings.
+func (srv RulerSrv) validateNotificationSettings(ctx context.Context, changes *store.GroupDelta) (*ngmodels.AlertConfiguration, error) {
+	newOrUpdatedNotificationSettings := changes.NewOrUpdatedNotificationSettings()
+	newOrUpdatedActiveTimeIntervals := changes.NewOrUpdatedActiveTimeIntervals()
+	if len(newOrUpdatedNotificationSettings) == 0 && len(newOrUpdatedActiveTimeIntervals) == 0 {
+		return nil, nil
+	}
+	dbConfig, err := srv.amConfigStore.GetLatestAlertmanagerConfiguration(ctx, changes.GroupKey.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest configuration: %w", err)
+	}
+	cfg, err := notifier.Load([]byte(dbConfig.AlertmanagerConfiguration))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse configuration: %w", err)
+	}
+	validator := notifier.NewNotificationSettingsValidator(&cfg.AlertmanagerConfig)
+	for _, s := range newOrUpdatedNotificationSettings {
+		if err := validator.Validate(s); err != nil {
+			return nil, errors.Join(ngmodels.ErrAlertRuleFailedValidation, err)
 		}
-		return ErrResp(http.StatusInternalServerError, err, "failed to update rule group")
 	}
+	if err := validator.ValidateActiveTimeIntervals(newOrUpdatedActiveTimeIntervals); err != nil {
+		return nil, errors.Join(ngmodels.ErrAlertRuleFailedValidation, err)
+	}
+	if len(newOrUpdatedNotificationSettings) == 0 {
+		return nil, nil
+	}
+	return dbConfig, nil
+}
 
+// refreshAlertmanagerConfig applies the configuration that the notification settings of changed rules were validated against.
+func (srv RulerSrv) refreshAlertmanagerConfig(c *contextmodel.ReqContext, orgID int64, dbConfig *ngmodels.AlertConfiguration) {
 	if srv.featureManager.IsEnabled(c.Req.Context(), featuremgmt.FlagAlertingSimplifiedRouting) && dbConfig != nil {
 		// This isn't strictly necessary since the alertmanager config is periodically synced.
-		err := srv.amRefresher.ApplyConfig(c.Req.Context(), groupKey.OrgID, dbConfig)
+		err := srv.amRefresher.ApplyConfig(c.Req.Context(), orgID, dbConfig)
 		if err != nil {
-			srv.log.Warn("Failed to refresh Alertmanager config for org after change in notification settings", "org", c.SignedInUser.GetOrgID(), "error", err)
+			srv.log.Warn("Failed to refresh Alertmanager config for org after change in notification settings", "org", orgID, "error", err)
 		}
 	}
+}
 
-	return changesToResponse(finalChanges)
+// toRuleChangesErrorResponse converts an error returned while changing alert rules into a response.
+func toRuleChangesErrorResponse(err error, message string) response.Response {
+	if errors.As(err, &errutil.Error{}) {
+		return response.Err(err)
+	} else if errors.Is(err, ngmodels.ErrAlertRuleNotFound) || errors.Is(err, ngmodels.ErrAlertRuleVersionNotFound) {
+		return ErrResp(http.StatusNotFound, err, message)
+	} else if errors.Is(err, ngmodels.ErrAlertRuleFailedValidation) || errors.Is(err, errProvisionedResource) {
+		return ErrResp(http.StatusBadRequest, err, message)
+	} else if errors.Is(err, ngmodels.ErrQuotaReached) {
+		return ErrResp(http.StatusForbidden, err, "")
+	} else if errors.Is(err, store.ErrOptimisticLock) {
+		return ErrResp(http.StatusConflict, err, "")
+	}
+	return ErrResp(http.StatusInternalServerError, err, message)
 }
 
 func changesToResponse(finalChanges *store.GroupDelta) response.Response {
@@ -558,26 +591,32 @@ func toGettableExtendedRuleNode(r ngmodels.AlertRule, provenanceRecords map[stri
 
 	gettableExtendedRuleNode := apimodels.GettableExtendedRuleNode{
 		GrafanaManagedAlert: &apimodels.GettableGrafanaRule{
-			ID:                   r.ID,
-			OrgID:                r.OrgID,
-			Title:                r.Title,
-			Condition:            r.Condition,
-			Data:                 ApiAlertQueriesFromAlertQueries(r.Data),
-			Updated:              r.Updated,
-			IntervalSeconds:      r.IntervalSeconds,
-			Version:              r.Version,
-			UID:                  r.UID,
-			NamespaceUID:         r.NamespaceUID,
-			RuleGroup:            r.RuleGroup,
-			NoDataState:          apimodels.NoDataState(r.NoDataState),
-			ExecErrState:         apimodels.ExecutionErrorState(r.ExecErrState),
-			Provenance:           apimodels.Provenance(provenance),
-			IsPaused:             r.IsPaused,
-			NotificationSettings: AlertRuleNotificationSettingsFromNotificationSettings(r.NotificationSettings),
-			Record:               ApiRecordFromModelRecord(r.Record),
-			Metadata:             AlertRuleMetadataFromModelMetadata(r.Metadata),
+			ID:                        r.ID,
+			OrgID:                     r.OrgID,
+			Title:                     r.Title,
+			Condition:                 r.Condition,
+			Data:                      ApiAlertQueriesFromAlertQueries(r.Data),
+			Updated:                   r.Updated,
+			IntervalSeconds:           r.IntervalSeconds,
+			Version:                   r.Version,
+			UID:                       r.UID,
+			NamespaceUID:              r.NamespaceUID,
+			RuleGroup:                 r.RuleGroup,
+			NoDataState:               apimodels.NoDataState(r.NoDataState),
+			ExecErrState:              apimodels.ExecutionErrorState(r.ExecErrState),
+			Provenance:                apimodels.Provenance(provenance),
+			IsPaused:                  r.IsPaused,
+			NotificationSettings:      AlertRuleNotificationSettingsFromNotificationSettings(r.NotificationSettings),
+			Record:                    ApiRecordFromModelRecord(r.Record),
+			Metadata:                  AlertRuleMetadataFromModelMetadata(r.Metadata),
+			ActiveTimeIntervals:       r.ActiveTimeIntervals,
+			Dependencies:              ApiRuleDependenciesFromModelRuleDependencies(r.Dependencies),
+			MissingSeriesEvalsToAlert: r.MissingSeriesEvalsToAlert,
 		},
 	}
+	if r.UpdatedBy != nil {
+		gettableExtendedRuleNode.GrafanaManagedAlert.UpdatedBy = string(*r.UpdatedBy)
+	}
 	forDuration := model.Duration(r.For)
 	gettableExtendedRuleNode.ApiRuleNode = &apimodels.ApiRuleNode{
 		For:         &forDuration,
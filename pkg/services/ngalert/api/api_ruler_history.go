@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -10,11 +11,13 @@ import (
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/infra/log"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
 )
 
 type Historian interface {
 	Query(ctx context.Context, query models.HistoryQuery) (*data.Frame, error)
+	QueryNotifications(ctx context.Context, query models.NotificationLogQuery) ([]models.NotificationLogEntry, error)
 }
 
 type HistorySrv struct {
@@ -31,6 +34,8 @@ func (srv *HistorySrv) RouteQueryStateHistory(c *contextmodel.ReqContext) respon
 	ruleUID := c.Query("ruleUID")
 	dashUID := c.Query("dashboardUID")
 	panelID := c.QueryInt64("panelID")
+	folderUID := c.Query("folderUID")
+	state := c.Query("state")
 
 	labels := make(map[string]string)
 	for k, v := range c.Req.URL.Query() {
@@ -44,6 +49,8 @@ func (srv *HistorySrv) RouteQueryStateHistory(c *contextmodel.ReqContext) respon
 		OrgID:        c.SignedInUser.GetOrgID(),
 		DashboardUID: dashUID,
 		PanelID:      panelID,
+		FolderUID:    folderUID,
+		State:        state,
 		SignedInUser: c.SignedInUser,
 		From:         time.Unix(from, 0),
 		To:           time.Unix(to, 0),
@@ -56,3 +63,41 @@ func (srv *HistorySrv) RouteQueryStateHistory(c *contextmodel.ReqContext) respon
 	}
 	return response.JSON(http.StatusOK, frame)
 }
+
+func (srv *HistorySrv) RouteQueryNotificationLog(c *contextmodel.ReqContext) response.Response {
+	query := models.NotificationLogQuery{
+		OrgID:        c.SignedInUser.GetOrgID(),
+		Receiver:     c.Query("receiver"),
+		Integration:  c.Query("integration"),
+		Status:       models.NotificationStatus(c.Query("status")),
+		Limit:        c.QueryInt("limit"),
+		Labels:       make(map[string]string),
+		SignedInUser: c.SignedInUser,
+	}
+	switch query.Status {
+	case "", models.NotificationStatusSuccess, models.NotificationStatusFailed:
+	default:
+		return ErrResp(http.StatusBadRequest, fmt.Errorf("invalid status %q, must be one of: %s, %s", query.Status, models.NotificationStatusSuccess, models.NotificationStatusFailed), "")
+	}
+	if from := c.QueryInt64("from"); from > 0 {
+		query.From = time.Unix(from, 0)
+	}
+	if to := c.QueryInt64("to"); to > 0 {
+		query.To = time.Unix(to, 0)
+	}
+	for k, v := range c.Req.URL.Query() {
+		if strings.HasPrefix(k, labelQueryPrefix) {
+			query.Labels[k[len(labelQueryPrefix):]] = v[0]
+		}
+	}
+
+	entries, err := srv.hist.QueryNotifications(c.Req.Context(), query)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to query notification log", err)
+	}
+	result := make([]apimodels.NotificationLogEntry, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, NotificationLogEntryToApi(e))
+	}
+	return response.JSON(http.StatusOK, result)
+}
@@ -144,6 +144,10 @@ func validateAlertingRuleFields(in *apimodels.PostableExtendedRuleNode, newRule
 		}
 	}
 
+	newRule.ActiveTimeIntervals = in.GrafanaManagedAlert.ActiveTimeIntervals
+	newRule.Dependencies = ModelRuleDependenciesFromApiRuleDependencies(in.GrafanaManagedAlert.Dependencies)
+	newRule.MissingSeriesEvalsToAlert = in.GrafanaManagedAlert.MissingSeriesEvalsToAlert
+
 	if in.GrafanaManagedAlert.Metadata != nil {
 		newRule.Metadata.EditorSettings = ngmodels.EditorSettings{
 			SimplifiedQueryAndExpressionsSection: in.GrafanaManagedAlert.Metadata.EditorSettings.SimplifiedQueryAndExpressionsSection,
@@ -184,6 +188,9 @@ func validateRecordingRuleFields(in *apimodels.PostableExtendedRuleNode, newRule
 	newRule.Condition = ""
 	newRule.For = 0
 	newRule.NotificationSettings = nil
+	newRule.ActiveTimeIntervals = nil
+	newRule.Dependencies = nil
+	newRule.MissingSeriesEvalsToAlert = 0
 
 	return newRule, nil
 }
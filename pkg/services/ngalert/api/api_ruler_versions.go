@@ -0,0 +1,223 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+)
+
+// RouteGetRuleVersionsByUID returns the stored versions of the alert rule with the given UID, most recent first.
+func (srv RulerSrv) RouteGetRuleVersionsByUID(c *contextmodel.ReqContext, ruleUID string) response.Response {
+	ctx := c.Req.Context()
+	orgID := c.SignedInUser.GetOrgID()
+
+	rule, err := srv.getAuthorizedRuleByUid(ctx, c, ruleUID)
+	if err != nil {
+		if errors.Is(err, ngmodels.ErrAlertRuleNotFound) {
+			return response.Empty(http.StatusNotFound)
+		}
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get rule by UID", err)
+	}
+
+	provenance, err := srv.provenanceStore.GetProvenance(ctx, &rule, orgID)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get rule provenance", err)
+	}
+	provenanceRecords := map[string]ngmodels.Provenance{rule.ResourceID(): provenance}
+
+	versions, err := srv.store.GetAlertRuleVersions(ctx, &ngmodels.GetAlertRuleVersionsQuery{UID: ruleUID, OrgID: orgID})
+	if err != nil {
+		if errors.Is(err, ngmodels.ErrAlertRuleNotFound) {
+			return response.Empty(http.StatusNotFound)
+		}
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get rule versions", err)
+	}
+
+	result := make(apimodels.GettableRuleVersions, 0, len(versions))
+	for _, v := range versions {
+		v.Rule.ID = rule.ID
+		result = append(result, toGettableRuleVersion(*v, provenanceRecords))
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+// RouteGetRuleVersionsDiff returns the changes of the alert rule definition between the versions given in the "from" and "to" query parameters.
+func (srv RulerSrv) RouteGetRuleVersionsDiff(c *contextmodel.ReqContext, ruleUID string) response.Response {
+	ctx := c.Req.Context()
+	from, to := c.QueryInt64("from"), c.QueryInt64("to")
+	if from <= 0 || to <= 0 {
+		return ErrResp(http.StatusBadRequest, errors.New("query parameters 'from' and 'to' must be positive rule versions"), "")
+	}
+
+	if _, err := srv.getAuthorizedRuleByUid(ctx, c, ruleUID); err != nil {
+		if errors.Is(err, ngmodels.ErrAlertRuleNotFound) {
+			return response.Empty(http.StatusNotFound)
+		}
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get rule by UID", err)
+	}
+
+	query := &ngmodels.GetAlertRuleVersionsQuery{UID: ruleUID, OrgID: c.SignedInUser.GetOrgID()}
+	fromVersion, err := srv.store.GetAlertRuleVersion(ctx, query, from)
+	if err != nil {
+		return toRuleVersionErrorResponse(err, from)
+	}
+	toVersion, err := srv.store.GetAlertRuleVersion(ctx, query, to)
+	if err != nil {
+		return toRuleVersionErrorResponse(err, to)
+	}
+
+	return response.JSON(http.StatusOK, apimodels.RuleVersionDiff{
+		From:    from,
+		To:      to,
+		Changes: ruleVersionChanges(fromVersion.Rule, toVersion.Rule),
+	})
+}
+
+// RouteRestoreRuleVersion replaces the definition of the alert rule with the definition stored in the given version.
+// The restored definition is saved as a new version of the rule, the history is never rewritten.
+// The rule keeps its folder, group and position in the group.
+// Returns http.StatusBadRequest if the rule group contains provisioned rules.
+func (srv RulerSrv) RouteRestoreRuleVersion(c *contextmodel.ReqContext, ruleUID string, version int64) response.Response {
+	var restored bool
+	var dbConfig *ngmodels.AlertConfiguration
+	err := srv.xactManager.InTransaction(c.Req.Context(), func(tranCtx context.Context) error {
+		existing, err := srv.getAuthorizedRuleByUid(tranCtx, c, ruleUID)
+		if err != nil {
+			return err
+		}
+		groupKey := existing.GetGroupKey()
+		logger := srv.log.New(append(existing.GetKey().LogContext(), "version", version)...)
+
+		v, err := srv.store.GetAlertRuleVersion(tranCtx, &ngmodels.GetAlertRuleVersionsQuery{UID: existing.UID, OrgID: existing.OrgID}, version)
+		if err != nil {
+			return err
+		}
+		rule := restoredAlertRule(existing, v.Rule)
+
+		diff := existing.Diff(rule, store.AlertRuleFieldsToIgnoreInDiff[:]...)
+		if len(diff) == 0 {
+			logger.Info("Rule already matches the version to restore. Do nothing")
+			return nil
+		}
+
+		group, err := srv.getAuthorizedRuleGroup(tranCtx, c, groupKey)
+		if err != nil {
+			return err
+		}
+		changes := &store.GroupDelta{
+			GroupKey:       groupKey,
+			AffectedGroups: map[ngmodels.AlertRuleGroupKey]ngmodels.RulesGroup{groupKey: group},
+			Update: []store.RuleDelta{
+				{Existing: &existing, New: rule, Diff: diff},
+			},
+		}
+
+		if err := srv.authz.AuthorizeRuleChanges(c.Req.Context(), c.SignedInUser, changes); err != nil {
+			return err
+		}
+		if err := validateQueries(c.Req.Context(), changes, srv.conditionValidator, c.SignedInUser); err != nil {
+			return err
+		}
+		if dbConfig, err = srv.validateNotificationSettings(c.Req.Context(), changes); err != nil {
+			return err
+		}
+		if err := verifyProvisionedRulesNotAffected(c.Req.Context(), srv.provenanceStore, existing.OrgID, changes); err != nil {
+			return err
+		}
+
+		updatedBy := ngmodels.UserUID(c.SignedInUser.GetUID())
+		rule.UpdatedBy = &updatedBy
+		logger.Debug("Restoring rule version", "diff", diff.String())
+		if err := srv.store.UpdateAlertRules(tranCtx, []ngmodels.UpdateRule{
+			{Existing: &existing, New: *rule, RestoredFrom: version},
+		}); err != nil {
+			return fmt.Errorf("failed to update rule: %w", err)
+		}
+		restored = true
+		return nil
+	})
+	if err != nil {
+		return toRuleChangesErrorResponse(err, "failed to restore rule version")
+	}
+
+	srv.refreshAlertmanagerConfig(c, c.SignedInUser.GetOrgID(), dbConfig)
+
+	body := apimodels.UpdateRuleGroupResponse{
+		Message: fmt.Sprintf("rule already matches version %d", version),
+	}
+	if restored {
+		body.Message = fmt.Sprintf("rule restored from version %d", version)
+		body.Updated = []string{ruleUID}
+	}
+	return response.JSON(http.StatusAccepted, body)
+}
+
+// restoredAlertRule returns the current rule with the definition of the given version.
+// The folder, group, position in the group and evaluation interval are kept because they belong to the rule group rather than to the rule.
+func restoredAlertRule(current ngmodels.AlertRule, version ngmodels.AlertRule) *ngmodels.AlertRule {
+	result := version
+	result.ID = current.ID
+	result.OrgID = current.OrgID
+	result.UID = current.UID
+	result.Version = current.Version
+	result.Updated = current.Updated
+	result.UpdatedBy = current.UpdatedBy
+	result.NamespaceUID = current.NamespaceUID
+	result.RuleGroup = current.RuleGroup
+	result.RuleGroupIndex = current.RuleGroupIndex
+	result.IntervalSeconds = current.IntervalSeconds
+	return &result
+}
+
+// ruleVersionChanges returns the fields of the rule definition that differ between the two versions.
+func ruleVersionChanges(from, to ngmodels.AlertRule) []apimodels.RuleVersionChange {
+	// the position in the group changes whenever other rules of the group are added or removed
+	ignore := append(store.AlertRuleFieldsToIgnoreInDiff[:], "RuleGroupIndex")
+	diff := from.Diff(&to, ignore...)
+	result := make([]apimodels.RuleVersionChange, 0, len(diff))
+	for _, d := range diff {
+		result = append(result, apimodels.RuleVersionChange{
+			Path: d.Path,
+			From: diffValue(d.Left),
+			To:   diffValue(d.Right),
+		})
+	}
+	return result
+}
+
+// diffValue returns the value of a field reported by a diff, or nil if the field is missing in one of the versions.
+func diffValue(v reflect.Value) any {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func toGettableRuleVersion(v ngmodels.AlertRuleVersion, provenanceRecords map[string]ngmodels.Provenance) apimodels.GettableRuleVersion {
+	result := apimodels.GettableRuleVersion{
+		Version:       v.Rule.Version,
+		ParentVersion: v.ParentVersion,
+		RestoredFrom:  v.RestoredFrom,
+		Updated:       v.Rule.Updated,
+		Rule:          toGettableExtendedRuleNode(v.Rule, provenanceRecords),
+	}
+	if v.Rule.UpdatedBy != nil {
+		result.UpdatedBy = string(*v.Rule.UpdatedBy)
+	}
+	return result
+}
+
+func toRuleVersionErrorResponse(err error, version int64) response.Response {
+	if errors.Is(err, ngmodels.ErrAlertRuleVersionNotFound) {
+		return ErrResp(http.StatusNotFound, err, "version %d", version)
+	}
+	return ErrResp(http.StatusInternalServerError, err, "failed to get rule version %d", version)
+}
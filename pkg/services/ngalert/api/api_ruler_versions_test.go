@@ -0,0 +1,203 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests/fakes"
+)
+
+// setupRuleVersions stores a rule with three versions. The label "team" is changed in version 2
+// and the condition in version 3, which is the current version of the rule.
+func setupRuleVersions(t *testing.T, orgID int64) (*fakes.RuleStore, *models.AlertRule) {
+	t.Helper()
+	folder := randFolder()
+	ruleStore := fakes.NewRuleStore(t)
+	ruleStore.Folders[orgID] = append(ruleStore.Folders[orgID], folder)
+	gen := models.RuleGen
+	gen = gen.With(gen.WithOrgID(orgID), gen.WithNamespace(folder), gen.WithNoNotificationSettings(), gen.WithIsPaused(false), gen.WithLabel("team", "a"))
+
+	v1 := gen.GenerateRef()
+	v1.Metadata = models.AlertRuleMetadata{} // not copied by models.CopyRule
+	v1.Version = 1
+	v1.Updated = time.Now().Add(-2 * time.Hour)
+	author := models.UserUID("user:author")
+	v1.UpdatedBy = &author
+
+	v2 := models.CopyRule(v1)
+	v2.Version = 2
+	v2.Updated = time.Now().Add(-time.Hour)
+	v2.Labels["team"] = "b"
+
+	current := models.CopyRule(v2)
+	current.Version = 3
+	current.Updated = time.Now()
+	current.Condition = "changed"
+
+	ruleStore.PutRule(context.Background(), current)
+	ruleStore.RuleVersions[current.GetKey()] = []*models.AlertRuleVersion{
+		{Rule: *models.CopyRule(current), ParentVersion: 2},
+		{Rule: *models.CopyRule(v2), ParentVersion: 1},
+		{Rule: *models.CopyRule(v1)},
+	}
+	return ruleStore, current
+}
+
+func createPermissionsForRuleUpdate(rules []*models.AlertRule, orgID int64) map[int64]map[string][]string {
+	permissions := createPermissionsForRules(rules, orgID)
+	for _, rule := range rules {
+		scope := dashboards.ScopeFoldersProvider.GetResourceScopeUID(rule.NamespaceUID)
+		permissions[orgID][ac.ActionAlertingRuleUpdate] = append(permissions[orgID][ac.ActionAlertingRuleUpdate], scope)
+	}
+	return permissions
+}
+
+func TestRouteGetRuleVersionsByUID(t *testing.T) {
+	orgID := rand.Int63()
+	ruleStore, rule := setupRuleVersions(t, orgID)
+
+	t.Run("should return versions of the rule with their author", func(t *testing.T) {
+		req := createRequestContextWithPerms(orgID, createPermissionsForRules([]*models.AlertRule{rule}, orgID), nil)
+
+		response := createService(ruleStore).RouteGetRuleVersionsByUID(req, rule.UID)
+
+		require.Equal(t, http.StatusOK, response.Status())
+		result := apimodels.GettableRuleVersions{}
+		require.NoError(t, json.Unmarshal(response.Body(), &result))
+		require.Len(t, result, 3)
+		assert.Equal(t, []int64{3, 2, 1}, []int64{result[0].Version, result[1].Version, result[2].Version})
+		assert.Equal(t, int64(1), result[1].ParentVersion)
+		assert.Equal(t, "user:author", result[2].UpdatedBy)
+		assert.Equal(t, rule.UID, result[2].Rule.GrafanaManagedAlert.UID)
+		assert.Equal(t, "a", result[2].Rule.Labels["team"])
+	})
+
+	t.Run("should return 403 if the user cannot read the rule", func(t *testing.T) {
+		req := createRequestContextWithPerms(orgID, map[int64]map[string][]string{}, nil)
+
+		response := createService(ruleStore).RouteGetRuleVersionsByUID(req, rule.UID)
+
+		require.Equal(t, http.StatusForbidden, response.Status())
+	})
+}
+
+func TestRouteGetRuleVersionsDiff(t *testing.T) {
+	orgID := rand.Int63()
+	ruleStore, rule := setupRuleVersions(t, orgID)
+	perms := createPermissionsForRules([]*models.AlertRule{rule}, orgID)
+
+	t.Run("should return changes between versions", func(t *testing.T) {
+		req := createRequestContextWithPerms(orgID, perms, nil)
+		req.Req.Form.Set("from", "1")
+		req.Req.Form.Set("to", "3")
+
+		response := createService(ruleStore).RouteGetRuleVersionsDiff(req, rule.UID)
+
+		require.Equal(t, http.StatusOK, response.Status())
+		result := apimodels.RuleVersionDiff{}
+		require.NoError(t, json.Unmarshal(response.Body(), &result))
+		assert.Equal(t, int64(1), result.From)
+		assert.Equal(t, int64(3), result.To)
+		assert.ElementsMatch(t, []apimodels.RuleVersionChange{
+			{Path: "Condition", From: ruleStore.RuleVersions[rule.GetKey()][2].Rule.Condition, To: "changed"},
+			{Path: "Labels[team]", From: "a", To: "b"},
+		}, result.Changes)
+	})
+
+	t.Run("should return 400 if versions are missing", func(t *testing.T) {
+		req := createRequestContextWithPerms(orgID, perms, nil)
+		req.Req.Form.Set("from", "1")
+
+		response := createService(ruleStore).RouteGetRuleVersionsDiff(req, rule.UID)
+
+		require.Equal(t, http.StatusBadRequest, response.Status())
+	})
+
+	t.Run("should return 404 if version does not exist", func(t *testing.T) {
+		req := createRequestContextWithPerms(orgID, perms, nil)
+		req.Req.Form.Set("from", "1")
+		req.Req.Form.Set("to", "10")
+
+		response := createService(ruleStore).RouteGetRuleVersionsDiff(req, rule.UID)
+
+		require.Equal(t, http.StatusNotFound, response.Status())
+	})
+}
+
+func TestRouteRestoreRuleVersion(t *testing.T) {
+	t.Run("should save the definition of the version as a new version", func(t *testing.T) {
+		orgID := rand.Int63()
+		ruleStore, rule := setupRuleVersions(t, orgID)
+		req := createRequestContextWithPerms(orgID, createPermissionsForRuleUpdate([]*models.AlertRule{rule}, orgID), nil)
+		svc := createService(ruleStore)
+		svc.conditionValidator = &recordingConditionValidator{}
+
+		response := svc.RouteRestoreRuleVersion(req, rule.UID, 1)
+
+		require.Equal(t, http.StatusAccepted, response.Status())
+		updates := ruleStore.GetRecordedCommands(func(cmd any) (any, bool) {
+			a, ok := cmd.([]models.UpdateRule)
+			return a, ok
+		})
+		require.Len(t, updates, 1)
+		update := updates[0].([]models.UpdateRule)
+		require.Len(t, update, 1)
+		assert.Equal(t, int64(1), update[0].RestoredFrom)
+		assert.Equal(t, rule.Version, update[0].New.Version)
+		assert.Equal(t, ruleStore.RuleVersions[rule.GetKey()][2].Rule.Condition, update[0].New.Condition)
+		assert.Equal(t, "a", update[0].New.Labels["team"])
+		assert.Equal(t, rule.RuleGroup, update[0].New.RuleGroup)
+	})
+
+	t.Run("should do nothing if the rule matches the version", func(t *testing.T) {
+		orgID := rand.Int63()
+		ruleStore, rule := setupRuleVersions(t, orgID)
+		req := createRequestContextWithPerms(orgID, createPermissionsForRuleUpdate([]*models.AlertRule{rule}, orgID), nil)
+
+		response := createService(ruleStore).RouteRestoreRuleVersion(req, rule.UID, 3)
+
+		require.Equal(t, http.StatusAccepted, response.Status())
+		updates := ruleStore.GetRecordedCommands(func(cmd any) (any, bool) {
+			a, ok := cmd.([]models.UpdateRule)
+			return a, ok
+		})
+		require.Empty(t, updates)
+	})
+
+	t.Run("should reject provisioned rules", func(t *testing.T) {
+		orgID := rand.Int63()
+		ruleStore, rule := setupRuleVersions(t, orgID)
+		req := createRequestContextWithPerms(orgID, createPermissionsForRuleUpdate([]*models.AlertRule{rule}, orgID), nil)
+		provenanceStore := fakes.NewFakeProvisioningStore()
+		require.NoError(t, provenanceStore.SetProvenance(context.Background(), rule, orgID, models.ProvenanceAPI))
+		svc := createServiceWithProvenanceStore(ruleStore, provenanceStore)
+		svc.conditionValidator = &recordingConditionValidator{}
+
+		response := svc.RouteRestoreRuleVersion(req, rule.UID, 1)
+
+		require.Equal(t, http.StatusBadRequest, response.Status())
+	})
+
+	t.Run("should reject users without permission to update the rule", func(t *testing.T) {
+		orgID := rand.Int63()
+		ruleStore, rule := setupRuleVersions(t, orgID)
+		req := createRequestContextWithPerms(orgID, createPermissionsForRules([]*models.AlertRule{rule}, orgID), nil)
+		svc := createService(ruleStore)
+		svc.conditionValidator = &recordingConditionValidator{}
+
+		response := svc.RouteRestoreRuleVersion(req, rule.UID, 1)
+
+		require.Equal(t, http.StatusForbidden, response.Status())
+	})
+}
@@ -17,23 +17,26 @@ import (
 // AlertRuleFromProvisionedAlertRule converts definitions.ProvisionedAlertRule to models.AlertRule
 func AlertRuleFromProvisionedAlertRule(a definitions.ProvisionedAlertRule) (models.AlertRule, error) {
 	rule := models.AlertRule{
-		ID:                   a.ID,
-		UID:                  a.UID,
-		OrgID:                a.OrgID,
-		NamespaceUID:         a.FolderUID,
-		RuleGroup:            a.RuleGroup,
-		Title:                a.Title,
-		Condition:            a.Condition,
-		Data:                 AlertQueriesFromApiAlertQueries(a.Data),
-		Updated:              a.Updated,
-		NoDataState:          models.NoDataState(a.NoDataState),          // TODO there must be a validation
-		ExecErrState:         models.ExecutionErrorState(a.ExecErrState), // TODO there must be a validation
-		For:                  time.Duration(a.For),
-		Annotations:          a.Annotations,
-		Labels:               a.Labels,
-		IsPaused:             a.IsPaused,
-		NotificationSettings: NotificationSettingsFromAlertRuleNotificationSettings(a.NotificationSettings),
-		Record:               ModelRecordFromApiRecord(a.Record),
+		ID:                        a.ID,
+		UID:                       a.UID,
+		OrgID:                     a.OrgID,
+		NamespaceUID:              a.FolderUID,
+		RuleGroup:                 a.RuleGroup,
+		Title:                     a.Title,
+		Condition:                 a.Condition,
+		Data:                      AlertQueriesFromApiAlertQueries(a.Data),
+		Updated:                   a.Updated,
+		NoDataState:               models.NoDataState(a.NoDataState),          // TODO there must be a validation
+		ExecErrState:              models.ExecutionErrorState(a.ExecErrState), // TODO there must be a validation
+		For:                       time.Duration(a.For),
+		Annotations:               a.Annotations,
+		Labels:                    a.Labels,
+		IsPaused:                  a.IsPaused,
+		NotificationSettings:      NotificationSettingsFromAlertRuleNotificationSettings(a.NotificationSettings),
+		Record:                    ModelRecordFromApiRecord(a.Record),
+		ActiveTimeIntervals:       a.ActiveTimeIntervals,
+		Dependencies:              ModelRuleDependenciesFromApiRuleDependencies(a.Dependencies),
+		MissingSeriesEvalsToAlert: a.MissingSeriesEvalsToAlert,
 	}
 
 	if rule.Type() == models.RuleTypeRecording {
@@ -46,24 +49,27 @@ func AlertRuleFromProvisionedAlertRule(a definitions.ProvisionedAlertRule) (mode
 // ProvisionedAlertRuleFromAlertRule converts models.AlertRule to definitions.ProvisionedAlertRule and sets provided provenance status
 func ProvisionedAlertRuleFromAlertRule(rule models.AlertRule, provenance models.Provenance) definitions.ProvisionedAlertRule {
 	return definitions.ProvisionedAlertRule{
-		ID:                   rule.ID,
-		UID:                  rule.UID,
-		OrgID:                rule.OrgID,
-		FolderUID:            rule.NamespaceUID,
-		RuleGroup:            rule.RuleGroup,
-		Title:                rule.Title,
-		For:                  model.Duration(rule.For),
-		Condition:            rule.Condition,
-		Data:                 ApiAlertQueriesFromAlertQueries(rule.Data),
-		Updated:              rule.Updated,
-		NoDataState:          definitions.NoDataState(rule.NoDataState),          // TODO there may be a validation
-		ExecErrState:         definitions.ExecutionErrorState(rule.ExecErrState), // TODO there may be a validation
-		Annotations:          rule.Annotations,
-		Labels:               rule.Labels,
-		Provenance:           definitions.Provenance(provenance), // TODO validate enum conversion?
-		IsPaused:             rule.IsPaused,
-		NotificationSettings: AlertRuleNotificationSettingsFromNotificationSettings(rule.NotificationSettings),
-		Record:               ApiRecordFromModelRecord(rule.Record),
+		ID:                        rule.ID,
+		UID:                       rule.UID,
+		OrgID:                     rule.OrgID,
+		FolderUID:                 rule.NamespaceUID,
+		RuleGroup:                 rule.RuleGroup,
+		Title:                     rule.Title,
+		For:                       model.Duration(rule.For),
+		Condition:                 rule.Condition,
+		Data:                      ApiAlertQueriesFromAlertQueries(rule.Data),
+		Updated:                   rule.Updated,
+		NoDataState:               definitions.NoDataState(rule.NoDataState),          // TODO there may be a validation
+		ExecErrState:              definitions.ExecutionErrorState(rule.ExecErrState), // TODO there may be a validation
+		Annotations:               rule.Annotations,
+		Labels:                    rule.Labels,
+		Provenance:                definitions.Provenance(provenance), // TODO validate enum conversion?
+		IsPaused:                  rule.IsPaused,
+		NotificationSettings:      AlertRuleNotificationSettingsFromNotificationSettings(rule.NotificationSettings),
+		Record:                    ApiRecordFromModelRecord(rule.Record),
+		ActiveTimeIntervals:       rule.ActiveTimeIntervals,
+		Dependencies:              ApiRuleDependenciesFromModelRuleDependencies(rule.Dependencies),
+		MissingSeriesEvalsToAlert: rule.MissingSeriesEvalsToAlert,
 	}
 }
 
@@ -215,6 +221,7 @@ func AlertRuleExportFromAlertRule(rule models.AlertRule) (definitions.AlertRuleE
 		IsPaused:             rule.IsPaused,
 		NotificationSettings: AlertRuleNotificationSettingsExportFromNotificationSettings(rule.NotificationSettings),
 		Record:               AlertRuleRecordExportFromRecord(rule.Record),
+		Dependencies:         AlertRuleDependenciesExportFromRuleDependencies(rule.Dependencies),
 	}
 	if rule.For.Seconds() > 0 {
 		result.ForString = util.Pointer(model.Duration(rule.For).String())
@@ -225,6 +232,12 @@ func AlertRuleExportFromAlertRule(rule models.AlertRule) (definitions.AlertRuleE
 	if rule.Labels != nil {
 		result.Labels = &rule.Labels
 	}
+	if len(rule.ActiveTimeIntervals) > 0 {
+		result.ActiveTimeIntervals = &rule.ActiveTimeIntervals
+	}
+	if rule.MissingSeriesEvalsToAlert > 0 {
+		result.MissingSeriesEvalsToAlert = &rule.MissingSeriesEvalsToAlert
+	}
 	return result, nil
 }
 
@@ -523,6 +536,55 @@ func ApiRecordFromModelRecord(r *models.Record) *definitions.Record {
 	}
 }
 
+// ModelRuleDependenciesFromApiRuleDependencies converts []definitions.RuleDependency to []models.RuleDependency
+func ModelRuleDependenciesFromApiRuleDependencies(deps []definitions.RuleDependency) []models.RuleDependency {
+	if len(deps) == 0 {
+		return nil
+	}
+	result := make([]models.RuleDependency, 0, len(deps))
+	for _, d := range deps {
+		result = append(result, models.RuleDependency{
+			RuleUID:  d.RuleUID,
+			Matchers: d.Matchers,
+		})
+	}
+	return result
+}
+
+// ApiRuleDependenciesFromModelRuleDependencies converts []models.RuleDependency to []definitions.RuleDependency
+func ApiRuleDependenciesFromModelRuleDependencies(deps []models.RuleDependency) []definitions.RuleDependency {
+	if len(deps) == 0 {
+		return nil
+	}
+	result := make([]definitions.RuleDependency, 0, len(deps))
+	for _, d := range deps {
+		result = append(result, definitions.RuleDependency{
+			RuleUID:  d.RuleUID,
+			Matchers: d.Matchers,
+		})
+	}
+	return result
+}
+
+// AlertRuleDependenciesExportFromRuleDependencies converts []models.RuleDependency to []definitions.AlertRuleDependencyExport
+func AlertRuleDependenciesExportFromRuleDependencies(deps []models.RuleDependency) []definitions.AlertRuleDependencyExport {
+	if len(deps) == 0 {
+		return nil
+	}
+	result := make([]definitions.AlertRuleDependencyExport, 0, len(deps))
+	for _, d := range deps {
+		e := definitions.AlertRuleDependencyExport{}
+		if d.RuleUID != "" {
+			e.RuleUID = util.Pointer(d.RuleUID)
+		}
+		if len(d.Matchers) > 0 {
+			e.Matchers = util.Pointer(d.Matchers)
+		}
+		result = append(result, e)
+	}
+	return result
+}
+
 func GettableGrafanaReceiverFromReceiver(r *models.Integration, provenance models.Provenance) (definitions.GettableGrafanaReceiver, error) {
 	out := definitions.GettableGrafanaReceiver{
 		UID:                   r.UID,
@@ -575,3 +637,29 @@ func GettableApiReceiversFromReceivers(recvs []*models.Receiver) ([]*definitions
 	}
 	return out, nil
 }
+
+func NotificationLogEntryToApi(e models.NotificationLogEntry) definitions.NotificationLogEntry {
+	alerts := make([]definitions.NotificationLogAlert, 0, len(e.Alerts))
+	for _, a := range e.Alerts {
+		alerts = append(alerts, definitions.NotificationLogAlert{
+			Fingerprint: a.Fingerprint,
+			Status:      a.Status,
+			Labels:      a.Labels,
+			StartsAt:    a.StartsAt,
+			EndsAt:      a.EndsAt,
+		})
+	}
+	return definitions.NotificationLogEntry{
+		Timestamp:        e.Timestamp,
+		Receiver:         e.Receiver,
+		Integration:      e.Integration,
+		IntegrationIndex: e.IntegrationIndex,
+		GroupKey:         e.GroupKey,
+		GroupLabels:      e.GroupLabels,
+		Alerts:           alerts,
+		Status:           string(e.Status),
+		Error:            e.Error,
+		Retry:            e.Retry,
+		DurationMs:       e.Duration.Milliseconds(),
+	}
+}
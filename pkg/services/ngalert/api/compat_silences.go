@@ -2,6 +2,11 @@ package api
 
 import (
 	"fmt"
+	"time"
+
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/common/model"
 
 	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
@@ -67,3 +72,77 @@ func SilencePermissionToAPI(p models.SilencePermission) (definitions.SilencePerm
 		return "", fmt.Errorf("unknown permission: %s", p)
 	}
 }
+
+// RecurringSilenceFromApi converts definitions.RecurringSilence to models.RecurringSilence.
+func RecurringSilenceFromApi(r definitions.RecurringSilence) (models.RecurringSilence, error) {
+	result := models.RecurringSilence{
+		UID:             r.UID,
+		Matchers:        make(amv2.Matchers, 0, len(r.Matchers)),
+		Comment:         r.Comment,
+		CreatedBy:       r.CreatedBy,
+		Schedule:        r.Schedule,
+		TimeZone:        r.TimeZone,
+		TimeInterval:    r.TimeInterval,
+		ExpireOnResolve: r.ExpireOnResolve,
+		Provenance:      models.Provenance(r.Provenance),
+	}
+	for _, s := range r.Matchers {
+		m, err := labels.ParseMatcher(s)
+		if err != nil {
+			return models.RecurringSilence{}, fmt.Errorf("invalid matcher %q: %w", s, err)
+		}
+		isEqual := m.Type == labels.MatchEqual || m.Type == labels.MatchRegexp
+		isRegex := m.Type == labels.MatchRegexp || m.Type == labels.MatchNotRegexp
+		result.Matchers = append(result.Matchers, &amv2.Matcher{
+			Name:    &m.Name,
+			Value:   &m.Value,
+			IsEqual: &isEqual,
+			IsRegex: &isRegex,
+		})
+	}
+	if r.Duration != "" {
+		d, err := model.ParseDuration(r.Duration)
+		if err != nil {
+			return models.RecurringSilence{}, fmt.Errorf("invalid duration %q: %w", r.Duration, err)
+		}
+		result.Duration = time.Duration(d)
+	}
+	return result, nil
+}
+
+// RecurringSilenceToApi converts models.RecurringSilence to definitions.RecurringSilence.
+func RecurringSilenceToApi(r *models.RecurringSilence) definitions.RecurringSilence {
+	result := definitions.RecurringSilence{
+		UID:             r.UID,
+		Matchers:        make([]string, 0, len(r.Matchers)),
+		Comment:         r.Comment,
+		CreatedBy:       r.CreatedBy,
+		Schedule:        r.Schedule,
+		TimeZone:        r.TimeZone,
+		TimeInterval:    r.TimeInterval,
+		ExpireOnResolve: r.ExpireOnResolve,
+		Provenance:      definitions.Provenance(r.Provenance),
+		SilenceID:       r.SilenceID,
+	}
+	for _, m := range r.Matchers {
+		if m == nil || m.Name == nil || m.Value == nil {
+			continue
+		}
+		matchType := labels.MatchEqual
+		isEqual := m.IsEqual == nil || *m.IsEqual
+		isRegex := m.IsRegex != nil && *m.IsRegex
+		switch {
+		case isEqual && isRegex:
+			matchType = labels.MatchRegexp
+		case !isEqual && isRegex:
+			matchType = labels.MatchNotRegexp
+		case !isEqual:
+			matchType = labels.MatchNotEqual
+		}
+		result.Matchers = append(result.Matchers, (&labels.Matcher{Type: matchType, Name: *m.Name, Value: *m.Value}).String())
+	}
+	if r.Duration > 0 {
+		result.Duration = model.Duration(r.Duration).String()
+	}
+	return result
+}
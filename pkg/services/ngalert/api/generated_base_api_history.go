@@ -18,15 +18,31 @@ import (
 )
 
 type HistoryApi interface {
+	RouteGetNotificationLog(*contextmodel.ReqContext) response.Response
 	RouteGetStateHistory(*contextmodel.ReqContext) response.Response
 }
 
+func (f *HistoryApiHandler) RouteGetNotificationLog(ctx *contextmodel.ReqContext) response.Response {
+	return f.handleRouteGetNotificationLog(ctx)
+}
 func (f *HistoryApiHandler) RouteGetStateHistory(ctx *contextmodel.ReqContext) response.Response {
 	return f.handleRouteGetStateHistory(ctx)
 }
 
 func (api *API) RegisterHistoryApiEndpoints(srv HistoryApi, m *metrics.API) {
 	api.RouteRegister.Group("", func(group routing.RouteRegister) {
+		group.Get(
+			toMacaronPath("/api/v1/notifications/log"),
+			requestmeta.SetOwner(requestmeta.TeamAlerting),
+			requestmeta.SetSLOGroup(requestmeta.SLOGroupHighSlow),
+			api.authorize(http.MethodGet, "/api/v1/notifications/log"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/v1/notifications/log",
+				api.Hooks.Wrap(srv.RouteGetNotificationLog),
+				m,
+			),
+		)
 		group.Get(
 			toMacaronPath("/api/v1/rules/history"),
 			requestmeta.SetOwner(requestmeta.TeamAlerting),
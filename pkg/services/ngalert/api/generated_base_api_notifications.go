@@ -14,17 +14,36 @@ import (
 	"github.com/grafana/grafana/pkg/middleware"
 	"github.com/grafana/grafana/pkg/middleware/requestmeta"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
 	"github.com/grafana/grafana/pkg/web"
 )
 
 type NotificationsApi interface {
+	RouteCreateRecurringSilence(*contextmodel.ReqContext) response.Response
+	RouteDeleteRecurringSilence(*contextmodel.ReqContext) response.Response
 	RouteGetReceiver(*contextmodel.ReqContext) response.Response
 	RouteGetReceivers(*contextmodel.ReqContext) response.Response
+	RouteGetRecurringSilence(*contextmodel.ReqContext) response.Response
+	RouteGetRecurringSilences(*contextmodel.ReqContext) response.Response
 	RouteNotificationsGetTimeInterval(*contextmodel.ReqContext) response.Response
 	RouteNotificationsGetTimeIntervals(*contextmodel.ReqContext) response.Response
+	RouteUpdateRecurringSilence(*contextmodel.ReqContext) response.Response
 }
 
+func (f *NotificationsApiHandler) RouteCreateRecurringSilence(ctx *contextmodel.ReqContext) response.Response {
+	// Parse Request Body
+	conf := apimodels.RecurringSilence{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.handleRouteCreateRecurringSilence(ctx, conf)
+}
+func (f *NotificationsApiHandler) RouteDeleteRecurringSilence(ctx *contextmodel.ReqContext) response.Response {
+	// Parse Path Parameters
+	uIDParam := web.Params(ctx.Req)[":UID"]
+	return f.handleRouteDeleteRecurringSilence(ctx, uIDParam)
+}
 func (f *NotificationsApiHandler) RouteGetReceiver(ctx *contextmodel.ReqContext) response.Response {
 	// Parse Path Parameters
 	nameParam := web.Params(ctx.Req)[":name"]
@@ -33,6 +52,14 @@ func (f *NotificationsApiHandler) RouteGetReceiver(ctx *contextmodel.ReqContext)
 func (f *NotificationsApiHandler) RouteGetReceivers(ctx *contextmodel.ReqContext) response.Response {
 	return f.handleRouteGetReceivers(ctx)
 }
+func (f *NotificationsApiHandler) RouteGetRecurringSilence(ctx *contextmodel.ReqContext) response.Response {
+	// Parse Path Parameters
+	uIDParam := web.Params(ctx.Req)[":UID"]
+	return f.handleRouteGetRecurringSilence(ctx, uIDParam)
+}
+func (f *NotificationsApiHandler) RouteGetRecurringSilences(ctx *contextmodel.ReqContext) response.Response {
+	return f.handleRouteGetRecurringSilences(ctx)
+}
 func (f *NotificationsApiHandler) RouteNotificationsGetTimeInterval(ctx *contextmodel.ReqContext) response.Response {
 	// Parse Path Parameters
 	nameParam := web.Params(ctx.Req)[":name"]
@@ -41,9 +68,43 @@ func (f *NotificationsApiHandler) RouteNotificationsGetTimeInterval(ctx *context
 func (f *NotificationsApiHandler) RouteNotificationsGetTimeIntervals(ctx *contextmodel.ReqContext) response.Response {
 	return f.handleRouteNotificationsGetTimeIntervals(ctx)
 }
+func (f *NotificationsApiHandler) RouteUpdateRecurringSilence(ctx *contextmodel.ReqContext) response.Response {
+	// Parse Path Parameters
+	uIDParam := web.Params(ctx.Req)[":UID"]
+	// Parse Request Body
+	conf := apimodels.RecurringSilence{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.handleRouteUpdateRecurringSilence(ctx, conf, uIDParam)
+}
 
 func (api *API) RegisterNotificationsApiEndpoints(srv NotificationsApi, m *metrics.API) {
 	api.RouteRegister.Group("", func(group routing.RouteRegister) {
+		group.Post(
+			toMacaronPath("/api/v1/notifications/recurring-silences"),
+			requestmeta.SetOwner(requestmeta.TeamAlerting),
+			requestmeta.SetSLOGroup(requestmeta.SLOGroupHighSlow),
+			api.authorize(http.MethodPost, "/api/v1/notifications/recurring-silences"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/v1/notifications/recurring-silences",
+				api.Hooks.Wrap(srv.RouteCreateRecurringSilence),
+				m,
+			),
+		)
+		group.Delete(
+			toMacaronPath("/api/v1/notifications/recurring-silences/{UID}"),
+			requestmeta.SetOwner(requestmeta.TeamAlerting),
+			requestmeta.SetSLOGroup(requestmeta.SLOGroupHighSlow),
+			api.authorize(http.MethodDelete, "/api/v1/notifications/recurring-silences/{UID}"),
+			metrics.Instrument(
+				http.MethodDelete,
+				"/api/v1/notifications/recurring-silences/{UID}",
+				api.Hooks.Wrap(srv.RouteDeleteRecurringSilence),
+				m,
+			),
+		)
 		group.Get(
 			toMacaronPath("/api/v1/notifications/receivers/{Name}"),
 			requestmeta.SetOwner(requestmeta.TeamAlerting),
@@ -68,6 +129,30 @@ func (api *API) RegisterNotificationsApiEndpoints(srv NotificationsApi, m *metri
 				m,
 			),
 		)
+		group.Get(
+			toMacaronPath("/api/v1/notifications/recurring-silences/{UID}"),
+			requestmeta.SetOwner(requestmeta.TeamAlerting),
+			requestmeta.SetSLOGroup(requestmeta.SLOGroupHighSlow),
+			api.authorize(http.MethodGet, "/api/v1/notifications/recurring-silences/{UID}"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/v1/notifications/recurring-silences/{UID}",
+				api.Hooks.Wrap(srv.RouteGetRecurringSilence),
+				m,
+			),
+		)
+		group.Get(
+			toMacaronPath("/api/v1/notifications/recurring-silences"),
+			requestmeta.SetOwner(requestmeta.TeamAlerting),
+			requestmeta.SetSLOGroup(requestmeta.SLOGroupHighSlow),
+			api.authorize(http.MethodGet, "/api/v1/notifications/recurring-silences"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/v1/notifications/recurring-silences",
+				api.Hooks.Wrap(srv.RouteGetRecurringSilences),
+				m,
+			),
+		)
 		group.Get(
 			toMacaronPath("/api/v1/notifications/time-intervals/{name}"),
 			requestmeta.SetOwner(requestmeta.TeamAlerting),
@@ -92,5 +177,17 @@ func (api *API) RegisterNotificationsApiEndpoints(srv NotificationsApi, m *metri
 				m,
 			),
 		)
+		group.Put(
+			toMacaronPath("/api/v1/notifications/recurring-silences/{UID}"),
+			requestmeta.SetOwner(requestmeta.TeamAlerting),
+			requestmeta.SetSLOGroup(requestmeta.SLOGroupHighSlow),
+			api.authorize(http.MethodPut, "/api/v1/notifications/recurring-silences/{UID}"),
+			metrics.Instrument(
+				http.MethodPut,
+				"/api/v1/notifications/recurring-silences/{UID}",
+				api.Hooks.Wrap(srv.RouteUpdateRecurringSilence),
+				m,
+			),
+		)
 	}, middleware.ReqSignedIn)
 }
@@ -3,6 +3,7 @@ package api
 import (
 	"github.com/grafana/grafana/pkg/api/response"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 )
 
 type NotificationsApiHandler struct {
@@ -30,3 +31,23 @@ func (f *NotificationsApiHandler) handleRouteGetReceiver(ctx *contextmodel.ReqCo
 func (f *NotificationsApiHandler) handleRouteGetReceivers(ctx *contextmodel.ReqContext) response.Response {
 	return f.notificationSrv.RouteGetReceivers(ctx)
 }
+
+func (f *NotificationsApiHandler) handleRouteGetRecurringSilences(ctx *contextmodel.ReqContext) response.Response {
+	return f.notificationSrv.RouteGetRecurringSilences(ctx)
+}
+
+func (f *NotificationsApiHandler) handleRouteGetRecurringSilence(ctx *contextmodel.ReqContext, uid string) response.Response {
+	return f.notificationSrv.RouteGetRecurringSilence(ctx, uid)
+}
+
+func (f *NotificationsApiHandler) handleRouteCreateRecurringSilence(ctx *contextmodel.ReqContext, body apimodels.RecurringSilence) response.Response {
+	return f.notificationSrv.RouteCreateRecurringSilence(ctx, body)
+}
+
+func (f *NotificationsApiHandler) handleRouteUpdateRecurringSilence(ctx *contextmodel.ReqContext, body apimodels.RecurringSilence, uid string) response.Response {
+	return f.notificationSrv.RouteUpdateRecurringSilence(ctx, body, uid)
+}
+
+func (f *NotificationsApiHandler) handleRouteDeleteRecurringSilence(ctx *contextmodel.ReqContext, uid string) response.Response {
+	return f.notificationSrv.RouteDeleteRecurringSilence(ctx, uid)
+}
@@ -380,6 +380,14 @@ type CreateSilenceParams struct {
 	Silence PostableSilence
 }
 
+// swagger:parameters RouteCreateGrafanaSilence
+type CreateGrafanaSilenceParams struct {
+	// Expire the silence once it has silenced at least one alert and all alerts it silenced have resolved.
+	// in:query
+	// required:false
+	ExpireOnResolve bool `json:"expireOnResolve"`
+}
+
 // swagger:parameters RouteGetSilence RouteDeleteSilence RouteGetGrafanaSilence RouteDeleteGrafanaSilence
 type GetDeleteSilenceParams struct {
 	// in:path
@@ -20,6 +20,45 @@ import (
 //       403: ForbiddenError
 //       404: description: Not found.
 
+// swagger:route Get /ruler/grafana/api/v1/rule/{RuleUID}/versions ruler RouteGetRuleVersionsByUID
+//
+// Get the stored versions of a rule, most recent first
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: GettableRuleVersions
+//       403: ForbiddenError
+//       404: description: Not found.
+
+// swagger:route Get /ruler/grafana/api/v1/rule/{RuleUID}/versions/diff ruler RouteGetRuleVersionsDiff
+//
+// Get the changes between two versions of a rule
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: RuleVersionDiff
+//       400: ValidationError
+//       403: ForbiddenError
+//       404: description: Not found.
+
+// swagger:route POST /ruler/grafana/api/v1/rule/{RuleUID}/versions/{Version}/restore ruler RouteRestoreRuleVersion
+//
+// Restore a previous version of a rule as its new version
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       202: UpdateRuleGroupResponse
+//       400: ValidationError
+//       403: ForbiddenError
+//       404: description: Not found.
+//       409: PublicError
+
 // swagger:route Get /ruler/grafana/api/v1/rules ruler RouteGetGrafanaRulesConfig
 //
 // List rule groups
@@ -218,12 +257,67 @@ type PathGetRulesParams struct {
 	PanelID int64
 }
 
-// swagger:parameters RouteGetRuleByUID
+// swagger:parameters RouteGetRuleByUID RouteGetRuleVersionsByUID
 type PathGetRuleByUIDParams struct {
 	// in: path
 	RuleUID string
 }
 
+// swagger:parameters RouteGetRuleVersionsDiff
+type GetRuleVersionsDiffParams struct {
+	// in: path
+	RuleUID string
+	// Version to compare from
+	// in: query
+	// required: true
+	From int64 `json:"from"`
+	// Version to compare to
+	// in: query
+	// required: true
+	To int64 `json:"to"`
+}
+
+// swagger:parameters RouteRestoreRuleVersion
+type PathRestoreRuleVersionParams struct {
+	// in: path
+	RuleUID string
+	// in: path
+	Version int64
+}
+
+// swagger:model
+type GettableRuleVersions []GettableRuleVersion
+
+// GettableRuleVersion is a stored version of a Grafana managed rule.
+type GettableRuleVersion struct {
+	Version int64 `json:"version"`
+	// ParentVersion is the version that was replaced by this version. It is omitted for the first version of the rule.
+	ParentVersion int64 `json:"parent_version,omitempty"`
+	// RestoredFrom is the version that this version restores. It is omitted if the version was not created by a restore.
+	RestoredFrom int64     `json:"restored_from,omitempty"`
+	Updated      time.Time `json:"updated"`
+	// UpdatedBy is the identity that created the version. It is omitted if the version was created by the system or provisioning.
+	UpdatedBy string                   `json:"updated_by,omitempty"`
+	Rule      GettableExtendedRuleNode `json:"rule"`
+}
+
+// swagger:model
+type RuleVersionDiff struct {
+	From    int64               `json:"from"`
+	To      int64               `json:"to"`
+	Changes []RuleVersionChange `json:"changes"`
+}
+
+// RuleVersionChange is a field of the rule that differs between two versions.
+type RuleVersionChange struct {
+	// Path to the field, for example Condition, Labels[team] or Data[0].Model
+	Path string `json:"path"`
+	// From is the value in the older version. It is omitted if the field was added.
+	From any `json:"from,omitempty"`
+	// To is the value in the newer version. It is omitted if the field was removed.
+	To any `json:"to,omitempty"`
+}
+
 // swagger:model
 type RuleGroupConfigResponse struct {
 	GettableRuleGroupConfig
@@ -506,6 +600,18 @@ type AlertRuleNotificationSettings struct {
 	MuteTimeIntervals []string `json:"mute_time_intervals,omitempty"`
 }
 
+// RuleDependency is a reference to upstream alert rules in the same organization. Either the rule UID or matchers must be specified.
+// While any of the upstream rules is firing, the dependent rule is evaluated but its notifications are suppressed.
+// swagger:model
+type RuleDependency struct {
+	// UID of the upstream rule. The dependency is removed when the upstream rule is deleted.
+	// example: core-network-down
+	RuleUID string `json:"rule_uid,omitempty" yaml:"rule_uid,omitempty"`
+	// Matchers that select upstream rules by their labels.
+	// example: ["team=network"]
+	Matchers []string `json:"matchers,omitempty" yaml:"matchers,omitempty"`
+}
+
 // swagger:model
 type Record struct {
 	// Name of the recorded metric.
@@ -520,38 +626,45 @@ type Record struct {
 
 // swagger:model
 type PostableGrafanaRule struct {
-	Title                string                         `json:"title" yaml:"title"`
-	Condition            string                         `json:"condition" yaml:"condition"`
-	Data                 []AlertQuery                   `json:"data" yaml:"data"`
-	UID                  string                         `json:"uid" yaml:"uid"`
-	NoDataState          NoDataState                    `json:"no_data_state" yaml:"no_data_state"`
-	ExecErrState         ExecutionErrorState            `json:"exec_err_state" yaml:"exec_err_state"`
-	IsPaused             *bool                          `json:"is_paused" yaml:"is_paused"`
-	NotificationSettings *AlertRuleNotificationSettings `json:"notification_settings" yaml:"notification_settings"`
-	Record               *Record                        `json:"record" yaml:"record"`
-	Metadata             *AlertRuleMetadata             `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	Title                     string                         `json:"title" yaml:"title"`
+	Condition                 string                         `json:"condition" yaml:"condition"`
+	Data                      []AlertQuery                   `json:"data" yaml:"data"`
+	UID                       string                         `json:"uid" yaml:"uid"`
+	NoDataState               NoDataState                    `json:"no_data_state" yaml:"no_data_state"`
+	ExecErrState              ExecutionErrorState            `json:"exec_err_state" yaml:"exec_err_state"`
+	IsPaused                  *bool                          `json:"is_paused" yaml:"is_paused"`
+	NotificationSettings      *AlertRuleNotificationSettings `json:"notification_settings" yaml:"notification_settings"`
+	Record                    *Record                        `json:"record" yaml:"record"`
+	Metadata                  *AlertRuleMetadata             `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	ActiveTimeIntervals       []string                       `json:"active_time_intervals,omitempty" yaml:"active_time_intervals,omitempty"`
+	Dependencies              []RuleDependency               `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+	MissingSeriesEvalsToAlert int64                          `json:"missing_series_evals_to_alert,omitempty" yaml:"missing_series_evals_to_alert,omitempty"`
 }
 
 // swagger:model
 type GettableGrafanaRule struct {
-	ID                   int64                          `json:"id" yaml:"id"`
-	OrgID                int64                          `json:"orgId" yaml:"orgId"`
-	Title                string                         `json:"title" yaml:"title"`
-	Condition            string                         `json:"condition" yaml:"condition"`
-	Data                 []AlertQuery                   `json:"data" yaml:"data"`
-	Updated              time.Time                      `json:"updated" yaml:"updated"`
-	IntervalSeconds      int64                          `json:"intervalSeconds" yaml:"intervalSeconds"`
-	Version              int64                          `json:"version" yaml:"version"`
-	UID                  string                         `json:"uid" yaml:"uid"`
-	NamespaceUID         string                         `json:"namespace_uid" yaml:"namespace_uid"`
-	RuleGroup            string                         `json:"rule_group" yaml:"rule_group"`
-	NoDataState          NoDataState                    `json:"no_data_state" yaml:"no_data_state"`
-	ExecErrState         ExecutionErrorState            `json:"exec_err_state" yaml:"exec_err_state"`
-	Provenance           Provenance                     `json:"provenance,omitempty" yaml:"provenance,omitempty"`
-	IsPaused             bool                           `json:"is_paused" yaml:"is_paused"`
-	NotificationSettings *AlertRuleNotificationSettings `json:"notification_settings,omitempty" yaml:"notification_settings,omitempty"`
-	Record               *Record                        `json:"record,omitempty" yaml:"record,omitempty"`
-	Metadata             *AlertRuleMetadata             `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	ID                        int64                          `json:"id" yaml:"id"`
+	OrgID                     int64                          `json:"orgId" yaml:"orgId"`
+	Title                     string                         `json:"title" yaml:"title"`
+	Condition                 string                         `json:"condition" yaml:"condition"`
+	Data                      []AlertQuery                   `json:"data" yaml:"data"`
+	Updated                   time.Time                      `json:"updated" yaml:"updated"`
+	IntervalSeconds           int64                          `json:"intervalSeconds" yaml:"intervalSeconds"`
+	Version                   int64                          `json:"version" yaml:"version"`
+	UID                       string                         `json:"uid" yaml:"uid"`
+	NamespaceUID              string                         `json:"namespace_uid" yaml:"namespace_uid"`
+	RuleGroup                 string                         `json:"rule_group" yaml:"rule_group"`
+	NoDataState               NoDataState                    `json:"no_data_state" yaml:"no_data_state"`
+	ExecErrState              ExecutionErrorState            `json:"exec_err_state" yaml:"exec_err_state"`
+	Provenance                Provenance                     `json:"provenance,omitempty" yaml:"provenance,omitempty"`
+	IsPaused                  bool                           `json:"is_paused" yaml:"is_paused"`
+	NotificationSettings      *AlertRuleNotificationSettings `json:"notification_settings,omitempty" yaml:"notification_settings,omitempty"`
+	Record                    *Record                        `json:"record,omitempty" yaml:"record,omitempty"`
+	Metadata                  *AlertRuleMetadata             `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	ActiveTimeIntervals       []string                       `json:"active_time_intervals,omitempty" yaml:"active_time_intervals,omitempty"`
+	Dependencies              []RuleDependency               `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+	MissingSeriesEvalsToAlert int64                          `json:"missing_series_evals_to_alert,omitempty" yaml:"missing_series_evals_to_alert,omitempty"`
+	UpdatedBy                 string                         `json:"updated_by,omitempty" yaml:"updated_by,omitempty"`
 }
 
 // AlertQuery represents a single query associated with an alert definition.
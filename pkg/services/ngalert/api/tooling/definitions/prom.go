@@ -176,6 +176,28 @@ type Rule struct {
 	Type           string    `json:"type"`
 	LastEvaluation time.Time `json:"lastEvaluation"`
 	EvaluationTime float64   `json:"evaluationTime"`
+	// EvaluationCost is only returned for Grafana rules that have been evaluated.
+	EvaluationCost *RuleEvaluationCost `json:"evaluationCost,omitempty"`
+}
+
+// RuleEvaluationCost is the cost of the last evaluation of a rule, and the number of evaluations that were missed or
+// late since the rule was scheduled.
+// swagger:model
+type RuleEvaluationCost struct {
+	// Seconds spent querying datasources.
+	QueryTime float64 `json:"queryTime"`
+	// Seconds spent executing expressions.
+	ExpressionTime float64 `json:"expressionTime"`
+	// Number of series returned by the condition.
+	Series int `json:"series"`
+	// Number of evaluations that were skipped because the previous evaluation was still running.
+	MissedEvaluations int64 `json:"missedEvaluations"`
+	// Number of evaluations that did not complete before the next evaluation was due.
+	LateEvaluations int64 `json:"lateEvaluations"`
+	// The last evaluation took longer than the slow rule threshold.
+	Slow bool `json:"slow"`
+	// The last evaluation returned more series than the high cardinality threshold.
+	HighCardinality bool `json:"highCardinality"`
 }
 
 // Alert has info for an alert.
@@ -170,6 +170,17 @@ type ProvisionedAlertRule struct {
 	NotificationSettings *AlertRuleNotificationSettings `json:"notification_settings"`
 	//example: {"metric":"grafana_alerts_ratio", "from":"A"}
 	Record *Record `json:"record"`
+	// Names of time intervals when the rule is evaluated. If empty, the rule is always evaluated. The time intervals must
+	// exist, and cannot be deleted while the rule uses them.
+	// example: ["business-hours"]
+	ActiveTimeIntervals []string `json:"active_time_intervals,omitempty"`
+	// Upstream rules of the rule in the same organization. While any of them is firing, the notifications of the rule are suppressed.
+	Dependencies []RuleDependency `json:"dependencies,omitempty"`
+	// Number of consecutive evaluations a series must be missing from the results before it fires as a missing series
+	// alert with its last known labels. The alert fires until the series returns, the rule is updated, or the series has been
+	// missing for 10 further evaluations. If 0, a missing series is resolved.
+	// example: 3
+	MissingSeriesEvalsToAlert int64 `json:"missing_series_evals_to_alert,omitempty"`
 }
 
 // swagger:route GET /v1/provisioning/folder/{FolderUID}/rule-groups/{Group} provisioning stable RouteGetAlertRuleGroup
@@ -271,12 +282,15 @@ type AlertRuleExport struct {
 	// ForString is used to:
 	// - Only export the for field for HCL if it is non-zero.
 	// - Format the Prometheus model.Duration type properly for HCL.
-	ForString            *string                              `json:"-" yaml:"-" hcl:"for"`
-	Annotations          *map[string]string                   `json:"annotations,omitempty" yaml:"annotations,omitempty" hcl:"annotations"`
-	Labels               *map[string]string                   `json:"labels,omitempty" yaml:"labels,omitempty" hcl:"labels"`
-	IsPaused             bool                                 `json:"isPaused" yaml:"isPaused" hcl:"is_paused"`
-	NotificationSettings *AlertRuleNotificationSettingsExport `json:"notification_settings,omitempty" yaml:"notification_settings,omitempty" hcl:"notification_settings,block"`
-	Record               *AlertRuleRecordExport               `json:"record,omitempty" yaml:"record,omitempty" hcl:"record,block"`
+	ForString                 *string                              `json:"-" yaml:"-" hcl:"for"`
+	Annotations               *map[string]string                   `json:"annotations,omitempty" yaml:"annotations,omitempty" hcl:"annotations"`
+	Labels                    *map[string]string                   `json:"labels,omitempty" yaml:"labels,omitempty" hcl:"labels"`
+	IsPaused                  bool                                 `json:"isPaused" yaml:"isPaused" hcl:"is_paused"`
+	NotificationSettings      *AlertRuleNotificationSettingsExport `json:"notification_settings,omitempty" yaml:"notification_settings,omitempty" hcl:"notification_settings,block"`
+	Record                    *AlertRuleRecordExport               `json:"record,omitempty" yaml:"record,omitempty" hcl:"record,block"`
+	ActiveTimeIntervals       *[]string                            `json:"active_time_intervals,omitempty" yaml:"active_time_intervals,omitempty" hcl:"active_time_intervals"`
+	Dependencies              []AlertRuleDependencyExport          `json:"dependencies,omitempty" yaml:"dependencies,omitempty" hcl:"dependency,block"`
+	MissingSeriesEvalsToAlert *int64                               `json:"missing_series_evals_to_alert,omitempty" yaml:"missing_series_evals_to_alert,omitempty" hcl:"missing_series_evals_to_alert"`
 }
 
 // AlertQueryExport is the provisioned export of models.AlertQuery.
@@ -306,6 +320,12 @@ type AlertRuleNotificationSettingsExport struct {
 	MuteTimeIntervals []string `yaml:"mute_time_intervals,omitempty" json:"mute_time_intervals,omitempty" hcl:"mute_timings"` // TF -> `mute_timings`
 }
 
+// AlertRuleDependencyExport is the provisioned export of models.RuleDependency.
+type AlertRuleDependencyExport struct {
+	RuleUID  *string   `json:"rule_uid,omitempty" yaml:"rule_uid,omitempty" hcl:"rule_uid"`
+	Matchers *[]string `json:"matchers,omitempty" yaml:"matchers,omitempty" hcl:"matchers"`
+}
+
 // Record is the provisioned export of models.Record.
 type AlertRuleRecordExport struct {
 	Metric string `json:"metric" yaml:"metric" hcl:"metric"`
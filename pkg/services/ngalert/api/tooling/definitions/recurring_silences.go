@@ -0,0 +1,104 @@
+package definitions
+
+// swagger:route GET /v1/notifications/recurring-silences notifications RouteGetRecurringSilences
+//
+// Get all recurring silences.
+//
+//     Responses:
+//       200: RecurringSilences
+//       403: ForbiddenError
+
+// swagger:route GET /v1/notifications/recurring-silences/{UID} notifications RouteGetRecurringSilence
+//
+// Get a recurring silence by UID.
+//
+//     Responses:
+//       200: RecurringSilence
+//       403: ForbiddenError
+//       404: NotFound
+
+// swagger:route POST /v1/notifications/recurring-silences notifications RouteCreateRecurringSilence
+//
+// Create a recurring silence. A silence is created in the Alertmanager for every window of its schedule.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       201: RecurringSilence
+//       400: ValidationError
+//       403: ForbiddenError
+
+// swagger:route PUT /v1/notifications/recurring-silences/{UID} notifications RouteUpdateRecurringSilence
+//
+// Update a recurring silence. The changes apply from the next window on.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       200: RecurringSilence
+//       400: ValidationError
+//       403: ForbiddenError
+//       404: NotFound
+
+// swagger:route DELETE /v1/notifications/recurring-silences/{UID} notifications RouteDeleteRecurringSilence
+//
+// Delete a recurring silence. The silence of the current window is not expired.
+//
+//     Responses:
+//       204: description: The recurring silence was deleted successfully.
+//       403: ForbiddenError
+
+// swagger:parameters RouteGetRecurringSilence RouteUpdateRecurringSilence RouteDeleteRecurringSilence
+type RecurringSilenceUIDParam struct {
+	// Recurring silence UID
+	// in:path
+	UID string
+}
+
+// swagger:parameters RouteCreateRecurringSilence RouteUpdateRecurringSilence
+type RecurringSilencePayload struct {
+	// in:body
+	Body RecurringSilence
+}
+
+// swagger:response RecurringSilences
+type RecurringSilencesResponse struct {
+	// in:body
+	Body []RecurringSilence
+}
+
+// RecurringSilence is a silence that is created in the Alertmanager for every window of a schedule. Either schedule and
+// duration, or time interval must be specified.
+// swagger:model
+type RecurringSilence struct {
+	// example: weekly-maintenance
+	UID string `json:"uid,omitempty" yaml:"uid,omitempty"`
+	// Matchers of the alerts to silence.
+	// required: true
+	// example: ["team=network", "env=~prod|staging"]
+	Matchers []string `json:"matchers" yaml:"matchers"`
+	// required: true
+	// example: Weekly maintenance of the core network
+	Comment   string `json:"comment" yaml:"comment"`
+	CreatedBy string `json:"createdBy,omitempty" yaml:"createdBy,omitempty"`
+	// Cron expression in the standard five-field format. Every match starts a window.
+	// example: 0 22 * * SAT
+	Schedule string `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+	// Length of a window that starts at schedule.
+	// example: 4h
+	Duration string `json:"duration,omitempty" yaml:"duration,omitempty"`
+	// IANA name of the time zone the schedule is evaluated in. Defaults to UTC.
+	// example: Europe/Berlin
+	TimeZone string `json:"timeZone,omitempty" yaml:"timeZone,omitempty"`
+	// Name of a time interval. Every contiguous time range of the interval is a window.
+	// example: weekends
+	TimeInterval string `json:"timeInterval,omitempty" yaml:"timeInterval,omitempty"`
+	// Expire the silence of a window early once all alerts it silenced have resolved.
+	ExpireOnResolve bool       `json:"expireOnResolve,omitempty" yaml:"expireOnResolve,omitempty"`
+	Provenance      Provenance `json:"provenance,omitempty" yaml:"-"`
+	// ID of the silence that was created for the most recent window.
+	// readOnly: true
+	SilenceID string `json:"silenceId,omitempty" yaml:"-"`
+}
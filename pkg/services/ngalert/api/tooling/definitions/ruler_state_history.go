@@ -1,6 +1,10 @@
 package definitions
 
-import "github.com/grafana/grafana-plugin-sdk-go/data"
+import (
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
 
 // swagger:route GET /v1/rules/history history RouteGetStateHistory
 //
@@ -50,4 +54,98 @@ type StateHistoryParams struct {
 	DashboardUID string
 	// Filter by dashboard's panel ID. Requires Dashboard UID to be specified.
 	PanelID int64
+	// Filter by the UID of the folder of the rule. Not supported if the state history is configured to use annotations for storage.
+	// in:query
+	// required: false
+	FolderUID string `json:"folderUID"`
+	// Filter by the state the alert instance transitioned to, e.g. Alerting. Not supported if the state history is configured to use annotations for storage.
+	// in:query
+	// required: false
+	State string `json:"state"`
+}
+
+// swagger:route GET /v1/notifications/log history RouteGetNotificationLog
+//
+// Query notification log.
+//
+// Allows to query the notification attempts of contact points, most recent first.
+// In addition to defined query parameters it accepts filter by alert labels. The query parameter name must start with 'labels_'
+//   Example: /v1/notifications/log?labels_myKey1=myValue1&labels_myKey2=myValue2
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: NotificationLog
+//       400: ValidationError
+//       403: ForbiddenError
+//       500: Failure
+
+// swagger:response NotificationLog
+type NotificationLog struct {
+	// in:body
+	Results []NotificationLogEntry `json:"results"`
+}
+
+// NotificationLogEntry is a single attempt of an integration to deliver a notification for a group of alerts.
+// swagger:model
+type NotificationLogEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	// The name of the contact point.
+	Receiver string `json:"receiver"`
+	// The type of the integration, e.g. email or slack.
+	Integration string `json:"integration"`
+	// The position of the integration in the contact point.
+	IntegrationIndex int                    `json:"integrationIndex"`
+	GroupKey         string                 `json:"groupKey"`
+	GroupLabels      map[string]string      `json:"groupLabels,omitempty"`
+	Alerts           []NotificationLogAlert `json:"alerts"`
+	// enum: success,failed
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	// True if a failed attempt is going to be retried.
+	Retry bool `json:"retry,omitempty"`
+	// The duration of the attempt in milliseconds.
+	DurationMs int64 `json:"durationMs"`
+}
+
+// NotificationLogAlert is an alert that was included in a notification.
+// swagger:model
+type NotificationLogAlert struct {
+	Fingerprint string            `json:"fingerprint"`
+	Status      string            `json:"status"`
+	Labels      map[string]string `json:"labels"`
+	StartsAt    time.Time         `json:"startsAt"`
+	EndsAt      time.Time         `json:"endsAt"`
+}
+
+// NotificationLogParams is the struct used as parameters for the RouteGetNotificationLog endpoint.
+//
+// swagger:parameters RouteGetNotificationLog
+type NotificationLogParams struct {
+	// The timestamp of the start point of the time range the log is obtained.
+	// in:query
+	// required: false
+	From int64 `json:"from"`
+	// The timestamp of the end point of the time range the log is obtained.
+	// in:query
+	// required: false
+	To int64 `json:"to"`
+	// Limits the number of records that needs to be returned.
+	// in:query
+	// required: false
+	Limit int `json:"limit"`
+	// Filter by contact point name.
+	// in:query
+	// required: false
+	Receiver string `json:"receiver"`
+	// Filter by integration type.
+	// in:query
+	// required: false
+	Integration string `json:"integration"`
+	// Filter by the outcome of the attempt.
+	// in:query
+	// required: false
+	// enum: success,failed
+	Status string `json:"status"`
 }
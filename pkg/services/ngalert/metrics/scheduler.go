@@ -31,7 +31,12 @@ type Scheduler struct {
 	UpdateSchedulableAlertRulesDuration prometheus.Histogram
 	Ticker                              *ticker.Metrics
 	EvaluationMissed                    *prometheus.CounterVec
+	EvaluationLate                      *prometheus.CounterVec
+	RuleEvaluationDuration              *prometheus.GaugeVec
+	RuleEvaluationSeries                *prometheus.GaugeVec
+	RulesWithoutMetrics                 prometheus.Gauge
 	SimplifiedEditorRules               *prometheus.GaugeVec
+	SchedulerPeers                      prometheus.Gauge
 }
 
 func NewSchedulerMetrics(r prometheus.Registerer) *Scheduler {
@@ -183,6 +188,41 @@ func NewSchedulerMetrics(r prometheus.Registerer) *Scheduler {
 			},
 			[]string{"org", "name"},
 		),
+		EvaluationLate: promauto.With(r).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Subsystem: Subsystem,
+				Name:      "schedule_rule_evaluations_late_total",
+				Help:      "The total number of rule evaluations that did not complete before the next evaluation of the rule was due.",
+			},
+			[]string{"org"},
+		),
+		RuleEvaluationDuration: promauto.With(r).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Subsystem: Subsystem,
+				Name:      "rule_last_evaluation_duration_seconds",
+				Help:      "The time the last evaluation of a rule spent querying datasources and executing expressions. Only exposed if metrics by rule are enabled.",
+			},
+			[]string{"org", "rule_uid", "phase"},
+		),
+		RuleEvaluationSeries: promauto.With(r).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Subsystem: Subsystem,
+				Name:      "rule_last_evaluation_series",
+				Help:      "The number of series returned by the last evaluation of a rule. Only exposed if metrics by rule are enabled.",
+			},
+			[]string{"org", "rule_uid"},
+		),
+		RulesWithoutMetrics: promauto.With(r).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Subsystem: Subsystem,
+				Name:      "rules_without_metrics",
+				Help:      "The number of rules that have no metrics by rule because the limit of rules with metrics is reached.",
+			},
+		),
 		SimplifiedEditorRules: promauto.With(r).NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: Namespace,
@@ -192,5 +232,11 @@ func NewSchedulerMetrics(r prometheus.Registerer) *Scheduler {
 			},
 			[]string{"org", "setting"},
 		),
+		SchedulerPeers: promauto.With(r).NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "scheduler_peers",
+			Help:      "The number of instances that share the evaluation of alert rules, including this one.",
+		}),
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"hash/fnv"
 	"maps"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -30,6 +31,8 @@ import (
 var (
 	// ErrAlertRuleNotFound is an error for an unknown alert rule.
 	ErrAlertRuleNotFound = fmt.Errorf("could not find alert rule")
+	// ErrAlertRuleVersionNotFound is an error for an unknown version of an alert rule.
+	ErrAlertRuleVersionNotFound = errors.New("could not find alert rule version")
 	// ErrAlertRuleFailedGenerateUniqueUID is an error for failure to generate alert rule UID
 	ErrAlertRuleFailedGenerateUniqueUID = errors.New("failed to generate alert rule UID")
 	// ErrCannotEditNamespace is an error returned if the user does not have permissions to edit the namespace
@@ -156,6 +159,10 @@ const (
 	AutogeneratedRouteSettingsHashLabel = "__grafana_route_settings_hash__"
 )
 
+// MissingSeriesEvalsToResolve is the number of further consecutive evaluations a missing series alert fires before it
+// is resolved, if the series does not return.
+const MissingSeriesEvalsToResolve = 10
+
 const (
 	StateReasonMissingSeries = "MissingSeries"
 	StateReasonNoData        = "NoData"
@@ -164,6 +171,11 @@ const (
 	StateReasonUpdated       = "Updated"
 	StateReasonRuleDeleted   = "RuleDeleted"
 	StateReasonKeepLast      = "KeepLast"
+	// StateReasonPausedBySchedule is the reason of the state of a rule that is outside its active time intervals.
+	StateReasonPausedBySchedule = "PausedBySchedule"
+	// StateReasonSuppressed is the reason of a firing or pending state whose notifications are suppressed
+	// because one of the rules it depends on is firing.
+	StateReasonSuppressed = "Suppressed"
 )
 
 func ConcatReasons(reasons ...string) string {
@@ -270,7 +282,23 @@ type AlertRule struct {
 	IsPaused             bool
 	NotificationSettings []NotificationSettings
 	Metadata             AlertRuleMetadata
-}
+	// ActiveTimeIntervals are the names of time intervals of the Alertmanager configuration when the rule is evaluated.
+	// Outside of them, the rule is not evaluated. If empty, the rule is always evaluated.
+	ActiveTimeIntervals []string
+	// Dependencies reference the upstream rules of the rule in the same organization.
+	// While any of them is firing, the rule is evaluated but its notifications are suppressed.
+	Dependencies []RuleDependency
+	// MissingSeriesEvalsToAlert is the number of consecutive evaluations a series must be missing from the results of
+	// the rule before it fires as a missing series alert with its last known labels. The alert fires until the series
+	// returns, the rule is updated, or the series has been missing for MissingSeriesEvalsToResolve further evaluations.
+	// If zero, a missing series is resolved once it is stale.
+	MissingSeriesEvalsToAlert int64
+	// UpdatedBy is the identity that made the last change to the rule. It is nil when the rule was changed by the system or provisioning.
+	UpdatedBy *UserUID
+}
+
+// UserUID is the namespaced identifier of a user or service account, e.g. "user:abc".
+type UserUID string
 
 type AlertRuleMetadata struct {
 	EditorSettings EditorSettings `json:"editor_settings"`
@@ -598,6 +626,28 @@ func (alertRule *AlertRule) ValidateAlertRule(cfg setting.UnifiedAlertingSetting
 			return errors.Join(ErrAlertRuleFailedValidation, fmt.Errorf("invalid notification settings: %w", err))
 		}
 	}
+
+	for idx, interval := range alertRule.ActiveTimeIntervals {
+		if interval == "" {
+			return fmt.Errorf("%w: active time interval at index %d must have a name", ErrAlertRuleFailedValidation, idx)
+		}
+		if slices.Contains(alertRule.ActiveTimeIntervals[:idx], interval) {
+			return fmt.Errorf("%w: active time interval %s is specified more than once", ErrAlertRuleFailedValidation, interval)
+		}
+	}
+
+	for idx, dep := range alertRule.Dependencies {
+		if err := dep.Validate(); err != nil {
+			return fmt.Errorf("%w: invalid dependency at index %d: %s", ErrAlertRuleFailedValidation, idx, err)
+		}
+		if dep.RuleUID != "" && dep.RuleUID == alertRule.UID {
+			return fmt.Errorf("%w: rule cannot depend on itself", ErrAlertRuleFailedValidation)
+		}
+	}
+
+	if alertRule.MissingSeriesEvalsToAlert < 0 {
+		return fmt.Errorf("%w: number of evaluations to alert on missing series must not be negative", ErrAlertRuleFailedValidation)
+	}
 	return nil
 }
 
@@ -659,6 +709,9 @@ func ClearRecordingRuleIgnoredFields(rule *AlertRule) {
 	rule.Condition = ""
 	rule.For = 0
 	rule.NotificationSettings = nil
+	rule.ActiveTimeIntervals = nil
+	rule.Dependencies = nil
+	rule.MissingSeriesEvalsToAlert = 0
 }
 
 // GetAlertRuleByUIDQuery is the query for retrieving/deleting an alert rule by UID and organisation ID.
@@ -730,6 +783,24 @@ type ListNamespaceAlertRulesQuery struct {
 type UpdateRule struct {
 	Existing *AlertRule
 	New      AlertRule
+	// RestoredFrom is the version of the rule that the update restores. It is 0 when the update is not a restore.
+	RestoredFrom int64
+}
+
+// AlertRuleVersion is a snapshot of an alert rule that is stored every time the rule is created or updated.
+// Rule.Version, Rule.Updated and Rule.UpdatedBy describe when and by whom the version was created.
+type AlertRuleVersion struct {
+	Rule AlertRule
+	// ParentVersion is the version of the rule that was replaced by this version. It is 0 for the first version.
+	ParentVersion int64
+	// RestoredFrom is the version that this version restores. It is 0 if the version was not created by a restore.
+	RestoredFrom int64
+}
+
+// GetAlertRuleVersionsQuery is the query for retrieving the stored versions of an alert rule.
+type GetAlertRuleVersionsQuery struct {
+	UID   string
+	OrgID int64
 }
 
 // Condition contains backend expressions and queries and the RefID
@@ -904,4 +975,24 @@ type RuleStatus struct {
 	LastError           error
 	EvaluationTimestamp time.Time
 	EvaluationDuration  time.Duration
+	// Cost is the evaluation cost of the rule. It is nil if the rule has not been evaluated yet.
+	Cost *RuleEvaluationCost
+}
+
+// RuleEvaluationCost is the cost of the last evaluation of a rule, and the number of evaluations that were missed or
+// late since the rule was scheduled.
+type RuleEvaluationCost struct {
+	// Duration is the time it took to query the datasources and execute the expressions.
+	Duration           time.Duration
+	QueryDuration      time.Duration
+	ExpressionDuration time.Duration
+	// Series is the number of series returned by the condition.
+	Series int
+	// MissedEvaluations is the number of evaluations that were skipped because the previous evaluation was still running.
+	MissedEvaluations int64
+	// LateEvaluations is the number of evaluations that did not complete before the next evaluation was due.
+	LateEvaluations int64
+	// Slow and HighCardinality flag rules whose last evaluation exceeded the configured thresholds.
+	Slow            bool
+	HighCardinality bool
 }
@@ -12,9 +12,37 @@ type HistoryQuery struct {
 	OrgID        int64
 	DashboardUID string
 	PanelID      int64
+	FolderUID    string
+	// State filters by the current state of the transition, e.g. Alerting. The state reason is not considered.
+	State        string
 	Labels       map[string]string
 	From         time.Time
 	To           time.Time
 	Limit        int
 	SignedInUser identity.Requester
 }
+
+// StateHistoryEntry is a state transition of an alert instance that is stored in the Grafana database.
+type StateHistoryEntry struct {
+	OrgID        int64
+	RuleUID      string
+	RuleID       int64
+	RuleTitle    string
+	RuleGroup    string
+	FolderUID    string
+	DashboardUID string
+	PanelID      int64
+	Condition    string
+	// Labels are the labels of the alert instance without private labels.
+	Labels map[string]string
+	// Fingerprint identifies the label set of the alert instance.
+	Fingerprint string
+	// Previous and Current are the formatted states, including the state reason.
+	Previous string
+	Current  string
+	// State is the current state without the state reason.
+	State     string
+	Error     string
+	Values    string
+	Timestamp time.Time
+}
@@ -17,6 +17,9 @@ type AlertInstance struct {
 	LastSentAt        *time.Time
 	ResolvedAt        *time.Time
 	ResultFingerprint string
+	// MissingSeriesEvaluations is the number of consecutive evaluations the series has been missing from the results of
+	// a rule that alerts on missing series.
+	MissingSeriesEvaluations int64
 }
 
 type AlertInstanceKey struct {
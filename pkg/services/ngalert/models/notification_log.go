@@ -0,0 +1,98 @@
+package models
+
+import (
+	"time"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+)
+
+// NotificationStatus is the outcome of a notification attempt.
+type NotificationStatus string
+
+const (
+	NotificationStatusSuccess NotificationStatus = "success"
+	NotificationStatusFailed  NotificationStatus = "failed"
+)
+
+// NotificationLogEntry is a single attempt of an integration to deliver a notification for a group of alerts.
+type NotificationLogEntry struct {
+	OrgID     int64     `json:"-"`
+	Timestamp time.Time `json:"timestamp"`
+	// Receiver is the name of the contact point the integration belongs to.
+	Receiver string `json:"receiver"`
+	// Integration is the type of the integration, e.g. email or slack.
+	Integration string `json:"integration"`
+	// IntegrationIndex is the position of the integration in the contact point.
+	IntegrationIndex int                 `json:"integrationIndex"`
+	GroupKey         string              `json:"groupKey"`
+	GroupLabels      map[string]string   `json:"groupLabels,omitempty"`
+	Alerts           []NotificationAlert `json:"alerts"`
+	Status           NotificationStatus  `json:"status"`
+	Error            string              `json:"error,omitempty"`
+	// Retry is true if a failed attempt is going to be retried.
+	Retry    bool          `json:"retry,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// NotificationAlert is an alert that was included in a notification.
+type NotificationAlert struct {
+	Fingerprint string            `json:"fingerprint"`
+	Status      string            `json:"status"`
+	Labels      map[string]string `json:"labels"`
+	StartsAt    time.Time         `json:"startsAt"`
+	EndsAt      time.Time         `json:"endsAt"`
+}
+
+// NotificationLogQuery represents a query for notification attempts.
+type NotificationLogQuery struct {
+	OrgID       int64
+	Receiver    string
+	Integration string
+	Status      NotificationStatus
+	// Labels select attempts that include at least one alert with all of these labels.
+	Labels       map[string]string
+	From         time.Time
+	To           time.Time
+	Limit        int
+	SignedInUser identity.Requester
+}
+
+// Matches returns true if the entry satisfies all filters of the query except for the limit.
+func (q NotificationLogQuery) Matches(e NotificationLogEntry) bool {
+	if q.OrgID != 0 && e.OrgID != q.OrgID {
+		return false
+	}
+	if q.Receiver != "" && e.Receiver != q.Receiver {
+		return false
+	}
+	if q.Integration != "" && e.Integration != q.Integration {
+		return false
+	}
+	if q.Status != "" && e.Status != q.Status {
+		return false
+	}
+	if !q.From.IsZero() && e.Timestamp.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && e.Timestamp.After(q.To) {
+		return false
+	}
+	if len(q.Labels) == 0 {
+		return true
+	}
+	for _, alert := range e.Alerts {
+		if hasLabels(alert.Labels, q.Labels) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasLabels(labels map[string]string, selector map[string]string) bool {
+	for k, v := range selector {
+		if lv, ok := labels[k]; !ok || lv != v {
+			return false
+		}
+	}
+	return true
+}
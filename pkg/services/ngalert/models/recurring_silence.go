@@ -0,0 +1,108 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/robfig/cron/v3"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+)
+
+var ErrRecurringSilenceNotFound = errutil.NotFound("alerting.notifications.silences.recurring.notFound", errutil.WithPublicMessage("Recurring silence not found"))
+
+// RecurringSilence is a silence that is created in the Alertmanager for every window of a schedule.
+// The windows are defined either by a cron expression and a duration, or by the time ranges of a time interval.
+type RecurringSilence struct {
+	UID       string
+	OrgID     int64
+	Matchers  amv2.Matchers
+	Comment   string
+	CreatedBy string
+	// Schedule is a cron expression in the standard five-field format. Every match starts a window.
+	Schedule string
+	// Duration is the length of a window that starts at Schedule.
+	Duration time.Duration
+	// TimeZone is the IANA name of the time zone Schedule is evaluated in. Defaults to UTC.
+	TimeZone string
+	// TimeInterval is the name of a time interval. Every contiguous time range of the interval is a window.
+	TimeInterval string
+	// ExpireOnResolve expires the silence of a window early once all alerts it silenced have resolved.
+	ExpireOnResolve bool
+	Provenance      Provenance
+	Updated         time.Time
+
+	// SilenceID is the ID of the silence that was created for the most recent window.
+	SilenceID string
+	// WindowStart is the start of the most recent window a silence was created for.
+	WindowStart time.Time
+}
+
+// Location returns the time zone of the schedule, UTC if no time zone is set.
+func (s *RecurringSilence) Location() (*time.Location, error) {
+	if s.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(s.TimeZone)
+}
+
+func (s *RecurringSilence) ResourceType() string {
+	return "recurringSilence"
+}
+
+func (s *RecurringSilence) ResourceID() string {
+	return s.UID
+}
+
+// Validate checks that the recurring silence has matchers, a comment and exactly one kind of schedule.
+func (s *RecurringSilence) Validate() error {
+	if len(s.Matchers) == 0 {
+		return errors.New("at least one matcher is required")
+	}
+	if err := s.Matchers.Validate(strfmt.Default); err != nil {
+		return fmt.Errorf("invalid matchers: %w", err)
+	}
+	if s.Comment == "" {
+		return errors.New("comment is required")
+	}
+	switch {
+	case s.Schedule != "" && s.TimeInterval != "":
+		return errors.New("schedule and time interval are mutually exclusive")
+	case s.Schedule != "":
+		if strings.HasPrefix(s.Schedule, "TZ=") || strings.HasPrefix(s.Schedule, "CRON_TZ=") {
+			return errors.New("invalid schedule: use the time zone instead of a time zone prefix")
+		}
+		if _, err := cron.ParseStandard(s.Schedule); err != nil {
+			return fmt.Errorf("invalid schedule: %w", err)
+		}
+		if s.Duration <= 0 {
+			return errors.New("duration must be positive when schedule is set")
+		}
+		if _, err := s.Location(); err != nil {
+			return fmt.Errorf("invalid time zone: %w", err)
+		}
+	case s.TimeInterval != "":
+		if s.Duration != 0 {
+			return errors.New("duration cannot be used with a time interval")
+		}
+		if s.TimeZone != "" {
+			return errors.New("time zone cannot be used with a time interval, the time interval has its own location")
+		}
+	default:
+		return errors.New("either schedule or time interval is required")
+	}
+	return nil
+}
+
+// SilenceExpiry marks a silence that is expired once all alerts that it silenced have resolved.
+type SilenceExpiry struct {
+	OrgID     int64
+	SilenceID string
+	// AlertsSeen is true once the silence has silenced at least one alert. A silence that has not silenced anything yet
+	// is not expired, so it can be created before the alerts start firing.
+	AlertsSeen bool
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestRecurringSilenceValidate(t *testing.T) {
+	matchers := amv2.Matchers{
+		{Name: util.Pointer("team"), Value: util.Pointer("network"), IsEqual: util.Pointer(true), IsRegex: util.Pointer(false)},
+	}
+	testCases := []struct {
+		name        string
+		silence     RecurringSilence
+		expectedErr string
+	}{
+		{
+			name:    "valid schedule",
+			silence: RecurringSilence{Matchers: matchers, Comment: "maintenance", Schedule: "0 22 * * SAT", Duration: 4 * time.Hour},
+		},
+		{
+			name:    "valid schedule with time zone",
+			silence: RecurringSilence{Matchers: matchers, Comment: "maintenance", Schedule: "0 22 * * SAT", Duration: 4 * time.Hour, TimeZone: "Europe/Berlin"},
+		},
+		{
+			name:    "valid time interval",
+			silence: RecurringSilence{Matchers: matchers, Comment: "maintenance", TimeInterval: "weekends"},
+		},
+		{
+			name:        "no matchers",
+			silence:     RecurringSilence{Comment: "maintenance", TimeInterval: "weekends"},
+			expectedErr: "at least one matcher is required",
+		},
+		{
+			name:        "no comment",
+			silence:     RecurringSilence{Matchers: matchers, TimeInterval: "weekends"},
+			expectedErr: "comment is required",
+		},
+		{
+			name:        "no schedule",
+			silence:     RecurringSilence{Matchers: matchers, Comment: "maintenance"},
+			expectedErr: "either schedule or time interval is required",
+		},
+		{
+			name:        "schedule and time interval",
+			silence:     RecurringSilence{Matchers: matchers, Comment: "maintenance", Schedule: "0 22 * * SAT", Duration: time.Hour, TimeInterval: "weekends"},
+			expectedErr: "schedule and time interval are mutually exclusive",
+		},
+		{
+			name:        "invalid schedule",
+			silence:     RecurringSilence{Matchers: matchers, Comment: "maintenance", Schedule: "every saturday", Duration: time.Hour},
+			expectedErr: "invalid schedule",
+		},
+		{
+			name:        "schedule without duration",
+			silence:     RecurringSilence{Matchers: matchers, Comment: "maintenance", Schedule: "0 22 * * SAT"},
+			expectedErr: "duration must be positive when schedule is set",
+		},
+		{
+			name:        "invalid time zone",
+			silence:     RecurringSilence{Matchers: matchers, Comment: "maintenance", Schedule: "0 22 * * SAT", Duration: time.Hour, TimeZone: "Mars/Olympus_Mons"},
+			expectedErr: "invalid time zone",
+		},
+		{
+			name:        "time zone prefix in schedule",
+			silence:     RecurringSilence{Matchers: matchers, Comment: "maintenance", Schedule: "CRON_TZ=Europe/Berlin 0 22 * * SAT", Duration: time.Hour},
+			expectedErr: "use the time zone instead of a time zone prefix",
+		},
+		{
+			name:        "time interval with time zone",
+			silence:     RecurringSilence{Matchers: matchers, Comment: "maintenance", TimeInterval: "weekends", TimeZone: "Europe/Berlin"},
+			expectedErr: "time zone cannot be used with a time interval",
+		},
+		{
+			name:        "time interval with duration",
+			silence:     RecurringSilence{Matchers: matchers, Comment: "maintenance", TimeInterval: "weekends", Duration: time.Hour},
+			expectedErr: "duration cannot be used with a time interval",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.silence.Validate()
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/alertmanager/pkg/labels"
+)
+
+// RuleDependency is a reference to upstream alert rules in the same organization.
+// While any of the upstream rules is firing, the notifications of the dependent rule are suppressed.
+type RuleDependency struct {
+	// RuleUID selects the upstream rule by its UID.
+	RuleUID string `json:"rule_uid,omitempty"`
+	// Matchers select upstream rules by their labels. A rule is never selected as its own upstream by labels.
+	Matchers []string `json:"matchers,omitempty"`
+}
+
+// Validate checks that the dependency references rules either by UID or by matchers, and that matchers are valid.
+func (d RuleDependency) Validate() error {
+	if d.RuleUID == "" && len(d.Matchers) == 0 {
+		return errors.New("either rule UID or matchers must be specified")
+	}
+	if d.RuleUID != "" && len(d.Matchers) > 0 {
+		return errors.New("rule UID and matchers cannot be specified together")
+	}
+	_, err := d.parseMatchers()
+	return err
+}
+
+func (d RuleDependency) parseMatchers() (labels.Matchers, error) {
+	result := make(labels.Matchers, 0, len(d.Matchers))
+	for _, s := range d.Matchers {
+		m, err := labels.ParseMatcher(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid matcher %q: %w", s, err)
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+// selects returns true if the dependency selects the rule as upstream.
+func (d RuleDependency) selects(matchers labels.Matchers, rule *AlertRule) bool {
+	if d.RuleUID != "" {
+		return d.RuleUID == rule.UID
+	}
+	for _, m := range matchers {
+		if !m.Matches(rule.Labels[m.Name]) {
+			return false
+		}
+	}
+	return true
+}
+
+// WithoutRuleUIDs returns the dependencies without those that select one of the given rules by UID,
+// and whether any was removed.
+func WithoutRuleUIDs(dependencies []RuleDependency, uids []string) ([]RuleDependency, bool) {
+	result := make([]RuleDependency, 0, len(dependencies))
+	for _, dep := range dependencies {
+		if dep.RuleUID != "" && slices.Contains(uids, dep.RuleUID) {
+			continue
+		}
+		result = append(result, dep)
+	}
+	return result, len(result) != len(dependencies)
+}
+
+// ResolveRuleDependencies returns the keys of upstream rules of every rule that has dependencies.
+// Only alerting rules of the same organization are selected as upstream. Dependencies with invalid matchers are ignored.
+func ResolveRuleDependencies(rules []*AlertRule) map[AlertRuleKey][]AlertRuleKey {
+	result := make(map[AlertRuleKey][]AlertRuleKey)
+	for _, rule := range rules {
+		if len(rule.Dependencies) == 0 {
+			continue
+		}
+		var upstream []AlertRuleKey
+		for _, dep := range rule.Dependencies {
+			matchers, err := dep.parseMatchers()
+			if err != nil {
+				continue
+			}
+			for _, candidate := range rules {
+				if candidate.OrgID != rule.OrgID || candidate.Type() != RuleTypeAlerting || (dep.RuleUID == "" && candidate.UID == rule.UID) {
+					continue
+				}
+				key := candidate.GetKey()
+				if dep.selects(matchers, candidate) && !slices.Contains(upstream, key) {
+					upstream = append(upstream, key)
+				}
+			}
+		}
+		if len(upstream) > 0 {
+			result[rule.GetKey()] = upstream
+		}
+	}
+	return result
+}
+
+// ValidateRuleDependencies checks that the dependencies of the changed rules reference existing rules, and that the
+// dependencies of all rules do not form cycles, also across rule groups. The rules are expected to be all rules of
+// an organization. Missing upstream rules are only reported for the changed rules, so that saving a rule is not
+// blocked by a dependency of another rule.
+func ValidateRuleDependencies(rules []*AlertRule, changed []AlertRuleKey) error {
+	uids := make(map[string]struct{}, len(rules))
+	for _, rule := range rules {
+		uids[rule.UID] = struct{}{}
+	}
+	for _, rule := range rules {
+		if !slices.Contains(changed, rule.GetKey()) {
+			continue
+		}
+		for _, dep := range rule.Dependencies {
+			if dep.RuleUID == "" {
+				continue
+			}
+			if _, ok := uids[dep.RuleUID]; !ok {
+				return fmt.Errorf("%w: rule %s depends on rule %s that does not exist", ErrAlertRuleFailedValidation, rule.UID, dep.RuleUID)
+			}
+		}
+	}
+
+	graph := ResolveRuleDependencies(rules)
+	const (
+		visiting = iota + 1
+		visited
+	)
+	marks := make(map[AlertRuleKey]int, len(graph))
+	var path []AlertRuleKey
+	var visit func(key AlertRuleKey) error
+	visit = func(key AlertRuleKey) error {
+		switch marks[key] {
+		case visited:
+			return nil
+		case visiting:
+			start := slices.Index(path, key)
+			cycle := make([]string, 0, len(path)-start+1)
+			for _, k := range path[start:] {
+				cycle = append(cycle, k.UID)
+			}
+			cycle = append(cycle, key.UID)
+			return fmt.Errorf("%w: rule dependencies form a cycle: %s", ErrAlertRuleFailedValidation, strings.Join(cycle, " -> "))
+		}
+		marks[key] = visiting
+		path = append(path, key)
+		for _, upstream := range graph[key] {
+			if err := visit(upstream); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		marks[key] = visited
+		return nil
+	}
+
+	keys := make([]AlertRuleKey, 0, len(graph))
+	for key := range graph {
+		keys = append(keys, key)
+	}
+	// sort keys to make the reported cycle stable.
+	slices.SortFunc(keys, func(a, b AlertRuleKey) int {
+		return strings.Compare(a.UID, b.UID)
+	})
+	for _, key := range keys {
+		if err := visit(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleDependencyValidate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		dep    RuleDependency
+		errMsg string
+	}{
+		{name: "rule UID", dep: RuleDependency{RuleUID: "upstream"}},
+		{name: "matchers", dep: RuleDependency{Matchers: []string{"team=network", `severity=~"critical|major"`}}},
+		{name: "empty", dep: RuleDependency{}, errMsg: "either rule UID or matchers must be specified"},
+		{name: "rule UID and matchers", dep: RuleDependency{RuleUID: "upstream", Matchers: []string{"team=network"}}, errMsg: "rule UID and matchers cannot be specified together"},
+		{name: "invalid matcher", dep: RuleDependency{Matchers: []string{"team=~("}}, errMsg: "invalid matcher"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.dep.Validate()
+			if tc.errMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.errMsg)
+		})
+	}
+}
+
+func TestResolveRuleDependencies(t *testing.T) {
+	group := RuleGen.With(RuleMuts.WithOrgID(1), RuleMuts.WithNamespaceUID("folder"), RuleMuts.WithGroupName("group"))
+	network := group.With(RuleMuts.WithLabels(map[string]string{"team": "network"})).GenerateRef()
+	network2 := group.With(RuleMuts.WithLabels(map[string]string{"team": "network"})).GenerateRef()
+	otherOrg := RuleGen.With(RuleMuts.WithOrgID(2), RuleMuts.WithLabels(map[string]string{"team": "network"})).GenerateRef()
+	otherGroup := RuleGen.With(RuleMuts.WithOrgID(1), RuleMuts.WithNamespaceUID("folder"), RuleMuts.WithGroupName("other"), RuleMuts.WithLabels(map[string]string{"team": "network"})).GenerateRef()
+	byUID := group.With(RuleMuts.WithDependencies(RuleDependency{RuleUID: network.UID})).GenerateRef()
+	byLabels := group.With(
+		RuleMuts.WithLabels(map[string]string{"team": "network"}),
+		RuleMuts.WithDependencies(RuleDependency{Matchers: []string{"team=network"}}),
+	).GenerateRef()
+	toOtherGroup := group.With(RuleMuts.WithDependencies(RuleDependency{RuleUID: otherGroup.UID})).GenerateRef()
+
+	result := ResolveRuleDependencies([]*AlertRule{network, network2, otherOrg, otherGroup, byUID, byLabels, toOtherGroup})
+
+	require.Len(t, result, 3)
+	assert.Equal(t, []AlertRuleKey{network.GetKey()}, result[byUID.GetKey()])
+	assert.ElementsMatch(t, []AlertRuleKey{network.GetKey(), network2.GetKey(), otherGroup.GetKey()}, result[byLabels.GetKey()])
+	assert.Equal(t, []AlertRuleKey{otherGroup.GetKey()}, result[toOtherGroup.GetKey()])
+}
+
+func TestValidateRuleDependencies(t *testing.T) {
+	group := RuleGen.With(RuleMuts.WithOrgID(1), RuleMuts.WithNamespaceUID("folder"), RuleMuts.WithGroupName("group"))
+
+	t.Run("should accept a chain", func(t *testing.T) {
+		a := group.With(RuleMuts.WithUID("a")).GenerateRef()
+		b := group.With(RuleMuts.WithUID("b"), RuleMuts.WithDependencies(RuleDependency{RuleUID: "a"})).GenerateRef()
+		c := group.With(RuleMuts.WithUID("c"), RuleMuts.WithDependencies(RuleDependency{RuleUID: "b"})).GenerateRef()
+
+		require.NoError(t, ValidateRuleDependencies([]*AlertRule{a, b, c}, []AlertRuleKey{c.GetKey()}))
+	})
+
+	t.Run("should reject a missing upstream rule", func(t *testing.T) {
+		b := group.With(RuleMuts.WithUID("b"), RuleMuts.WithDependencies(RuleDependency{RuleUID: "a"})).GenerateRef()
+
+		err := ValidateRuleDependencies([]*AlertRule{b}, []AlertRuleKey{b.GetKey()})
+		require.ErrorIs(t, err, ErrAlertRuleFailedValidation)
+		require.ErrorContains(t, err, "rule b depends on rule a that does not exist")
+	})
+
+	t.Run("should accept a missing upstream rule of a rule that is not changed", func(t *testing.T) {
+		b := group.With(RuleMuts.WithUID("b"), RuleMuts.WithDependencies(RuleDependency{RuleUID: "a"})).GenerateRef()
+		c := group.With(RuleMuts.WithUID("c")).GenerateRef()
+
+		require.NoError(t, ValidateRuleDependencies([]*AlertRule{b, c}, []AlertRuleKey{c.GetKey()}))
+	})
+
+	t.Run("should accept an upstream rule of another rule group", func(t *testing.T) {
+		a := RuleGen.With(RuleMuts.WithOrgID(1), RuleMuts.WithUID("a"), RuleMuts.WithNamespaceUID("other-folder"), RuleMuts.WithGroupName("other")).GenerateRef()
+		b := group.With(RuleMuts.WithUID("b"), RuleMuts.WithDependencies(RuleDependency{RuleUID: "a"})).GenerateRef()
+
+		require.NoError(t, ValidateRuleDependencies([]*AlertRule{a, b}, []AlertRuleKey{b.GetKey()}))
+	})
+
+	t.Run("should reject a cycle", func(t *testing.T) {
+		a := group.With(
+			RuleMuts.WithUID("a"),
+			RuleMuts.WithLabels(map[string]string{"team": "network"}),
+			RuleMuts.WithDependencies(RuleDependency{RuleUID: "c"}),
+		).GenerateRef()
+		b := group.With(RuleMuts.WithUID("b"), RuleMuts.WithDependencies(RuleDependency{Matchers: []string{"team=network"}})).GenerateRef()
+		c := group.With(RuleMuts.WithUID("c"), RuleMuts.WithDependencies(RuleDependency{RuleUID: "b"})).GenerateRef()
+
+		err := ValidateRuleDependencies([]*AlertRule{a, b, c}, []AlertRuleKey{a.GetKey()})
+		require.ErrorIs(t, err, ErrAlertRuleFailedValidation)
+		require.ErrorContains(t, err, "a -> c -> b -> a")
+	})
+
+	t.Run("should reject a cycle across rule groups", func(t *testing.T) {
+		other := RuleGen.With(RuleMuts.WithOrgID(1), RuleMuts.WithNamespaceUID("other-folder"), RuleMuts.WithGroupName("other"))
+		a := group.With(RuleMuts.WithUID("a"), RuleMuts.WithDependencies(RuleDependency{RuleUID: "b"})).GenerateRef()
+		b := other.With(RuleMuts.WithUID("b"), RuleMuts.WithDependencies(RuleDependency{RuleUID: "a"})).GenerateRef()
+
+		err := ValidateRuleDependencies([]*AlertRule{a, b}, []AlertRuleKey{b.GetKey()})
+		require.ErrorIs(t, err, ErrAlertRuleFailedValidation)
+		require.ErrorContains(t, err, "a -> b -> a")
+	})
+}
+
+func TestWithoutRuleUIDs(t *testing.T) {
+	deps := []RuleDependency{{RuleUID: "a"}, {Matchers: []string{"team=network"}}, {RuleUID: "b"}}
+
+	result, removed := WithoutRuleUIDs(deps, []string{"a"})
+	require.True(t, removed)
+	require.Equal(t, []RuleDependency{{Matchers: []string{"team=network"}}, {RuleUID: "b"}}, result)
+
+	result, removed = WithoutRuleUIDs(deps, []string{"c"})
+	require.False(t, removed)
+	require.Equal(t, deps, result)
+}
@@ -487,6 +487,30 @@ func (a *AlertRuleMutators) WithNotificationSettings(ns NotificationSettings) Al
 	}
 }
 
+func (a *AlertRuleMutators) WithActiveTimeIntervals(intervals ...string) AlertRuleMutator {
+	return func(rule *AlertRule) {
+		rule.ActiveTimeIntervals = intervals
+	}
+}
+
+func (a *AlertRuleMutators) WithUID(uid string) AlertRuleMutator {
+	return func(rule *AlertRule) {
+		rule.UID = uid
+	}
+}
+
+func (a *AlertRuleMutators) WithMissingSeriesEvalsToAlert(evals int64) AlertRuleMutator {
+	return func(rule *AlertRule) {
+		rule.MissingSeriesEvalsToAlert = evals
+	}
+}
+
+func (a *AlertRuleMutators) WithDependencies(deps ...RuleDependency) AlertRuleMutator {
+	return func(rule *AlertRule) {
+		rule.Dependencies = deps
+	}
+}
+
 func (a *AlertRuleMutators) WithNoNotificationSettings() AlertRuleMutator {
 	return func(rule *AlertRule) {
 		rule.NotificationSettings = nil
@@ -607,21 +631,22 @@ func GenerateGroupKey(orgID int64) AlertRuleGroupKey {
 // CopyRule creates a deep copy of AlertRule
 func CopyRule(r *AlertRule, mutators ...AlertRuleMutator) *AlertRule {
 	result := AlertRule{
-		ID:              r.ID,
-		OrgID:           r.OrgID,
-		Title:           r.Title,
-		Condition:       r.Condition,
-		Updated:         r.Updated,
-		IntervalSeconds: r.IntervalSeconds,
-		Version:         r.Version,
-		UID:             r.UID,
-		NamespaceUID:    r.NamespaceUID,
-		RuleGroup:       r.RuleGroup,
-		RuleGroupIndex:  r.RuleGroupIndex,
-		NoDataState:     r.NoDataState,
-		ExecErrState:    r.ExecErrState,
-		For:             r.For,
-		Record:          r.Record,
+		ID:                        r.ID,
+		OrgID:                     r.OrgID,
+		Title:                     r.Title,
+		Condition:                 r.Condition,
+		Updated:                   r.Updated,
+		IntervalSeconds:           r.IntervalSeconds,
+		Version:                   r.Version,
+		UID:                       r.UID,
+		NamespaceUID:              r.NamespaceUID,
+		RuleGroup:                 r.RuleGroup,
+		RuleGroupIndex:            r.RuleGroupIndex,
+		NoDataState:               r.NoDataState,
+		ExecErrState:              r.ExecErrState,
+		For:                       r.For,
+		Record:                    r.Record,
+		MissingSeriesEvalsToAlert: r.MissingSeriesEvalsToAlert,
 	}
 
 	if r.DashboardUID != nil {
@@ -670,6 +695,22 @@ func CopyRule(r *AlertRule, mutators ...AlertRuleMutator) *AlertRule {
 		result.NotificationSettings = append(result.NotificationSettings, CopyNotificationSettings(s))
 	}
 
+	if r.ActiveTimeIntervals != nil {
+		result.ActiveTimeIntervals = slices.Clone(r.ActiveTimeIntervals)
+	}
+
+	if r.Dependencies != nil {
+		result.Dependencies = make([]RuleDependency, 0, len(r.Dependencies))
+		for _, d := range r.Dependencies {
+			result.Dependencies = append(result.Dependencies, RuleDependency{RuleUID: d.RuleUID, Matchers: slices.Clone(d.Matchers)})
+		}
+	}
+
+	if r.UpdatedBy != nil {
+		updatedBy := *r.UpdatedBy
+		result.UpdatedBy = &updatedBy
+	}
+
 	if len(mutators) > 0 {
 		for _, mutator := range mutators {
 			mutator(&result)
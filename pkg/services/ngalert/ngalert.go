@@ -139,6 +139,8 @@ type AlertNG struct {
 	ImageService        image.ImageService
 	RecordingWriter     schedule.RecordingWriter
 	schedule            schedule.ScheduleService
+	sharder             *schedule.PeerSharder
+	silenceScheduler    *notifier.SilenceScheduler
 	stateManager        *state.Manager
 	folderService       folder.Service
 	dashboardService    dashboards.DashboardService
@@ -181,6 +183,10 @@ func (ng *AlertNG) init() error {
 	remoteOnly := ng.FeatureToggles.IsEnabled(initCtx, featuremgmt.FlagAlertmanagerRemoteOnly)
 	remotePrimary := ng.FeatureToggles.IsEnabled(initCtx, featuremgmt.FlagAlertmanagerRemotePrimary)
 	remoteSecondary := ng.FeatureToggles.IsEnabled(initCtx, featuremgmt.FlagAlertmanagerRemoteSecondary)
+	saveStatePeriodic := ng.FeatureToggles.IsEnabledGlobally(featuremgmt.FlagAlertingSaveStatePeriodic)
+	if err := validateEvaluationSharding(ng.Cfg.UnifiedAlerting, saveStatePeriodic, remoteOnly); err != nil {
+		return err
+	}
 	if ng.Cfg.UnifiedAlerting.RemoteAlertmanager.Enable {
 		autogenFn := remote.NoopAutogenFn
 		if ng.FeatureToggles.IsEnabled(initCtx, featuremgmt.FlagAlertingSimplifiedRouting) {
@@ -309,6 +315,16 @@ func (ng *AlertNG) init() error {
 		}
 	}
 
+	// There are a set of feature toggles available that act as short-circuits for common configurations.
+	// If any are set, override the config accordingly.
+	ApplyStateHistoryFeatureToggles(&ng.Cfg.UnifiedAlerting.StateHistory, ng.FeatureToggles, ng.Log)
+	history, err := configureHistorianBackend(initCtx, ng.Cfg.UnifiedAlerting.StateHistory, ng.annotationsRepo, ng.dashboardService, ng.store, ng.store, ng.Metrics.GetHistorianMetrics(), ng.Log, ng.tracer, ac.NewRuleService(ng.accesscontrol))
+	if err != nil {
+		return err
+	}
+	// The historian records the notification attempts of the internal Alertmanagers as well as state transitions.
+	overrides = append(overrides, notifier.WithNotificationHistorian(history))
+
 	decryptFn := ng.SecretsService.GetDecryptedValue
 	multiOrgMetrics := ng.Metrics.GetMultiOrgAlertmanagerMetrics()
 	moa, err := notifier.NewMultiOrgAlertmanager(
@@ -373,6 +389,9 @@ func (ng *AlertNG) init() error {
 	}
 	ng.RecordingWriter = recordingWriter
 
+	configStore := legacy_storage.NewAlertmanagerConfigStore(ng.store)
+	muteTimingService := provisioning.NewMuteTimingService(configStore, ng.store, ng.store, ng.Log, ng.store, ng.store)
+
 	schedCfg := schedule.SchedulerCfg{
 		MaxAttempts:          ng.Cfg.UnifiedAlerting.MaxAttempts,
 		C:                    clk,
@@ -389,15 +408,16 @@ func (ng *AlertNG) init() error {
 		Tracer:               ng.tracer,
 		Log:                  log.New("ngalert.scheduler"),
 		RecordingWriter:      ng.RecordingWriter,
-	}
+		TimeIntervals:        muteTimingService,
+		EvaluationCost:       ng.Cfg.UnifiedAlerting.EvaluationCost,
 
-	// There are a set of feature toggles available that act as short-circuits for common configurations.
-	// If any are set, override the config accordingly.
-	ApplyStateHistoryFeatureToggles(&ng.Cfg.UnifiedAlerting.StateHistory, ng.FeatureToggles, ng.Log)
-	history, err := configureHistorianBackend(initCtx, ng.Cfg.UnifiedAlerting.StateHistory, ng.annotationsRepo, ng.dashboardService, ng.store, ng.Metrics.GetHistorianMetrics(), ng.Log, ng.tracer, ac.NewRuleService(ng.accesscontrol))
-	if err != nil {
-		return err
+		SequentialGroupEvaluation: ng.Cfg.UnifiedAlerting.SequentialGroupEvaluation,
 	}
+	if ng.Cfg.UnifiedAlerting.EvaluationSharding.Enabled {
+		ng.sharder = schedule.NewPeerSharder(ng.Cfg.UnifiedAlerting.EvaluationSharding, ng.store, clk, ng.Metrics.GetSchedulerMetrics(), log.New("ngalert.scheduler.sharding"))
+		schedCfg.Sharder = ng.sharder
+	}
+
 	cfg := state.ManagerCfg{
 		Metrics:                        ng.Metrics.GetStateMetrics(),
 		ExternalURL:                    appUrl,
@@ -431,7 +451,6 @@ func (ng *AlertNG) init() error {
 	ng.stateManager = stateManager
 	ng.schedule = scheduler
 
-	configStore := legacy_storage.NewAlertmanagerConfigStore(ng.store)
 	receiverService := notifier.NewReceiverService(
 		ac.NewReceiverAccess[*models.Receiver](ng.accesscontrol, false),
 		configStore,
@@ -459,7 +478,6 @@ func (ng *AlertNG) init() error {
 	policyService := provisioning.NewNotificationPolicyService(configStore, ng.store, ng.store, ng.Cfg.UnifiedAlerting, ng.Log)
 	contactPointService := provisioning.NewContactPointService(configStore, ng.SecretsService, ng.store, ng.store, provisioningReceiverService, ng.Log, ng.store, ng.ResourcePermissions)
 	templateService := provisioning.NewTemplateService(configStore, ng.store, ng.store, ng.Log)
-	muteTimingService := provisioning.NewMuteTimingService(configStore, ng.store, ng.store, ng.Log, ng.store)
 	alertRuleService := provisioning.NewAlertRuleService(ng.store, ng.store, ng.folderService, ng.QuotaService, ng.store,
 		int64(ng.Cfg.UnifiedAlerting.DefaultRuleEvaluationInterval.Seconds()),
 		int64(ng.Cfg.UnifiedAlerting.BaseInterval.Seconds()),
@@ -494,11 +512,14 @@ func (ng *AlertNG) init() error {
 		FeatureManager:       ng.FeatureToggles,
 		AppUrl:               appUrl,
 		Historian:            history,
+		SilenceScheduleStore: ng.store,
 		Hooks:                api.NewHooks(ng.Log),
 		Tracer:               ng.tracer,
 	}
 	ng.Api.RegisterAPIEndpoints(ng.Metrics.GetAPIMetrics())
 
+	ng.silenceScheduler = notifier.NewSilenceScheduler(ng.store, ng.MultiOrgAlertmanager, legacy_storage.NewAlertmanagerConfigStore(ng.store), log.New("ngalert.silence-scheduler"))
+
 	if err := RegisterQuotas(ng.Cfg, ng.QuotaService, ng.store); err != nil {
 		return err
 	}
@@ -541,6 +562,11 @@ func (ng *AlertNG) Run(ctx context.Context) error {
 	children.Go(func() error {
 		return ng.AlertsRouter.Run(subCtx)
 	})
+	if ng.silenceScheduler != nil {
+		children.Go(func() error {
+			return ng.silenceScheduler.Run(subCtx)
+		})
+	}
 
 	if ng.Cfg.UnifiedAlerting.ExecuteAlerts {
 		// Only Warm() the state manager if we are actually executing alerts.
@@ -554,6 +580,14 @@ func (ng *AlertNG) Run(ctx context.Context) error {
 		//
 		ng.stateManager.Warm(ctx, ng.store, ng.store)
 
+		if ng.sharder != nil {
+			// Load the peers before the scheduler starts so the first tick evaluates only the rules assigned to this instance.
+			ng.sharder.Refresh(ctx)
+			children.Go(func() error {
+				return ng.sharder.Run(subCtx)
+			})
+		}
+
 		children.Go(func() error {
 			return ng.schedule.Run(subCtx)
 		})
@@ -582,9 +616,10 @@ func (ng *AlertNG) GetHooks() *api.Hooks {
 type Historian interface {
 	api.Historian
 	state.Historian
+	notifier.NotificationHistorian
 }
 
-func configureHistorianBackend(ctx context.Context, cfg setting.UnifiedAlertingStateHistorySettings, ar annotations.Repository, ds dashboards.DashboardService, rs historian.RuleStore, met *metrics.Historian, l log.Logger, tracer tracing.Tracer, ac historian.AccessControl) (Historian, error) {
+func configureHistorianBackend(ctx context.Context, cfg setting.UnifiedAlertingStateHistorySettings, ar annotations.Repository, ds dashboards.DashboardService, hs historian.StateHistoryStore, rs historian.RuleStore, met *metrics.Historian, l log.Logger, tracer tracing.Tracer, ac historian.AccessControl) (Historian, error) {
 	if !cfg.Enabled {
 		met.Info.WithLabelValues("noop").Set(0)
 		return historian.NewNopHistorian(), nil
@@ -599,7 +634,7 @@ func configureHistorianBackend(ctx context.Context, cfg setting.UnifiedAlertingS
 	if backend == historian.BackendTypeMultiple {
 		primaryCfg := cfg
 		primaryCfg.Backend = cfg.MultiPrimary
-		primary, err := configureHistorianBackend(ctx, primaryCfg, ar, ds, rs, met, l, tracer, ac)
+		primary, err := configureHistorianBackend(ctx, primaryCfg, ar, ds, hs, rs, met, l, tracer, ac)
 		if err != nil {
 			return nil, fmt.Errorf("multi-backend target \"%s\" was misconfigured: %w", cfg.MultiPrimary, err)
 		}
@@ -608,7 +643,7 @@ func configureHistorianBackend(ctx context.Context, cfg setting.UnifiedAlertingS
 		for _, b := range cfg.MultiSecondaries {
 			secCfg := cfg
 			secCfg.Backend = b
-			sec, err := configureHistorianBackend(ctx, secCfg, ar, ds, rs, met, l, tracer, ac)
+			sec, err := configureHistorianBackend(ctx, secCfg, ar, ds, hs, rs, met, l, tracer, ac)
 			if err != nil {
 				return nil, fmt.Errorf("multi-backend target \"%s\" was miconfigured: %w", b, err)
 			}
@@ -639,6 +674,10 @@ func configureHistorianBackend(ctx context.Context, cfg setting.UnifiedAlertingS
 		}
 		return backend, nil
 	}
+	if backend == historian.BackendTypeSQL {
+		sqlBackendLogger := log.New("ngalert.state.historian", "backend", "sql")
+		return historian.NewSQLBackend(sqlBackendLogger, hs, met, rs, ac), nil
+	}
 
 	return nil, fmt.Errorf("unrecognized state history backend: %s", backend)
 }
@@ -701,3 +740,21 @@ func createRecordingWriter(featureToggles featuremgmt.FeatureToggles, settings s
 
 	return writer.NoopWriter{}, nil
 }
+
+// validateEvaluationSharding returns an error if evaluation sharding is enabled with settings it does not work with.
+func validateEvaluationSharding(cfg setting.UnifiedAlertingSettings, saveStatePeriodic, remoteOnly bool) error {
+	if !cfg.EvaluationSharding.Enabled {
+		return nil
+	}
+	// Each instance sends only the alerts of the rules it evaluates, and the internal Alertmanagers do not share
+	// alerts, so they must all be sent to the same remote Alertmanager.
+	if !cfg.RemoteAlertmanager.Enable || !remoteOnly {
+		return fmt.Errorf("evaluation sharding requires the remote Alertmanager with the %s feature toggle", featuremgmt.FlagAlertmanagerRemoteOnly)
+	}
+	// The periodic full sync replaces all alert instances with the state of this instance, which holds only the
+	// state of the rules it evaluates.
+	if saveStatePeriodic {
+		return fmt.Errorf("evaluation sharding cannot be used with the %s feature toggle", featuremgmt.FlagAlertingSaveStatePeriodic)
+	}
+	return nil
+}
@@ -60,6 +60,9 @@ type alertmanager struct {
 	orgID     int64
 
 	withAutogen bool
+
+	// notificationHistorian records notification attempts. If it is nil, they are not recorded.
+	notificationHistorian NotificationHistorian
 }
 
 // maintenanceOptions represent the options for components that need maintenance on a frequency within the Alertmanager.
@@ -91,7 +94,7 @@ func (m maintenanceOptions) MaintenanceFunc(state alertingNotify.State) (int64,
 
 func NewAlertmanager(ctx context.Context, orgID int64, cfg *setting.Cfg, store AlertingStore, stateStore stateStore,
 	peer alertingNotify.ClusterPeer, decryptFn alertingNotify.GetDecryptedValueFn, ns notifications.Service,
-	m *metrics.Alertmanager, withAutogen bool,
+	m *metrics.Alertmanager, withAutogen bool, notificationHistorian NotificationHistorian,
 ) (*alertmanager, error) {
 	nflog, err := stateStore.GetNotificationLog(ctx)
 	if err != nil {
@@ -153,6 +156,8 @@ func NewAlertmanager(ctx context.Context, orgID int64, cfg *setting.Cfg, store A
 
 		// TODO: Preferably, logic around autogen would be outside of the specific alertmanager implementation so that remote alertmanager will get it for free.
 		withAutogen: withAutogen,
+
+		notificationHistorian: notificationHistorian,
 	}
 
 	return am, nil
@@ -408,6 +413,10 @@ func (am *alertmanager) buildReceiverIntegrations(receiver *alertingNotify.APIRe
 	if err != nil {
 		return nil, err
 	}
+	// Test notifications build integrations for receivers without a name. They are not recorded.
+	if am.notificationHistorian != nil && receiver.Name != "" {
+		integrations = withNotificationHistory(integrations, receiver.Name, am.orgID, am.notificationHistorian)
+	}
 	return integrations, nil
 }
 
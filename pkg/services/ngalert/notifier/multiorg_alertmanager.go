@@ -103,6 +103,9 @@ type MultiOrgAlertmanager struct {
 	ns      notifications.Service
 
 	receiverResourcePermissions ac.ReceiverPermissionsService
+
+	// notificationHistorian records notification attempts of the internal Alertmanagers.
+	notificationHistorian NotificationHistorian
 }
 
 type OrgAlertmanagerFactory func(ctx context.Context, orgID int64) (Alertmanager, error)
@@ -115,6 +118,13 @@ func WithAlertmanagerOverride(f func(OrgAlertmanagerFactory) OrgAlertmanagerFact
 	}
 }
 
+// WithNotificationHistorian makes the internal Alertmanagers record notification attempts to the historian.
+func WithNotificationHistorian(h NotificationHistorian) Option {
+	return func(moa *MultiOrgAlertmanager) {
+		moa.notificationHistorian = h
+	}
+}
+
 func NewMultiOrgAlertmanager(
 	cfg *setting.Cfg,
 	configStore AlertingStore,
@@ -160,7 +170,7 @@ func NewMultiOrgAlertmanager(
 	moa.factory = func(ctx context.Context, orgID int64) (Alertmanager, error) {
 		m := metrics.NewAlertmanagerMetrics(moa.metrics.GetOrCreateOrgRegistry(orgID), l)
 		stateStore := NewFileStore(orgID, kvStore)
-		return NewAlertmanager(ctx, orgID, moa.settings, moa.configStore, stateStore, moa.peer, moa.decryptFn, moa.ns, m, featureManager.IsEnabled(ctx, featuremgmt.FlagAlertingSimplifiedRouting))
+		return NewAlertmanager(ctx, orgID, moa.settings, moa.configStore, stateStore, moa.peer, moa.decryptFn, moa.ns, m, featureManager.IsEnabled(ctx, featuremgmt.FlagAlertingSimplifiedRouting), moa.notificationHistorian)
 	}
 
 	for _, opt := range opts {
@@ -0,0 +1,93 @@
+package notifier
+
+import (
+	"context"
+	"time"
+
+	alertingNotify "github.com/grafana/alerting/notify"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// NotificationHistorian records the outcome of notification attempts.
+type NotificationHistorian interface {
+	RecordNotification(ctx context.Context, entry models.NotificationLogEntry) <-chan error
+}
+
+// historyNotifier is a notifier that records every notification attempt of the wrapped integration.
+type historyNotifier struct {
+	upstream  *alertingNotify.Integration
+	receiver  string
+	orgID     int64
+	historian NotificationHistorian
+	now       func() time.Time
+}
+
+// withNotificationHistory wraps the integrations of a receiver so that their notification attempts are recorded.
+func withNotificationHistory(integrations []*alertingNotify.Integration, receiver string, orgID int64, historian NotificationHistorian) []*alertingNotify.Integration {
+	result := make([]*alertingNotify.Integration, 0, len(integrations))
+	for _, integration := range integrations {
+		n := &historyNotifier{
+			upstream:  integration,
+			receiver:  receiver,
+			orgID:     orgID,
+			historian: historian,
+			now:       time.Now,
+		}
+		result = append(result, alertingNotify.NewIntegration(n, n, integration.Name(), integration.Index(), receiver))
+	}
+	return result
+}
+
+func (n *historyNotifier) Notify(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+	start := n.now()
+	retry, err := n.upstream.Notify(ctx, alerts...)
+
+	entry := models.NotificationLogEntry{
+		OrgID:            n.orgID,
+		Timestamp:        start,
+		Receiver:         n.receiver,
+		Integration:      n.upstream.Name(),
+		IntegrationIndex: n.upstream.Index(),
+		Alerts:           make([]models.NotificationAlert, 0, len(alerts)),
+		Status:           models.NotificationStatusSuccess,
+		Duration:         n.now().Sub(start),
+	}
+	if key, ok := notify.GroupKey(ctx); ok {
+		entry.GroupKey = key
+	}
+	if groupLabels, ok := notify.GroupLabels(ctx); ok {
+		entry.GroupLabels = make(map[string]string, len(groupLabels))
+		for k, v := range groupLabels {
+			entry.GroupLabels[string(k)] = string(v)
+		}
+	}
+	if err != nil {
+		entry.Status = models.NotificationStatusFailed
+		entry.Error = err.Error()
+		entry.Retry = retry
+	}
+	for _, alert := range alerts {
+		labels := make(map[string]string, len(alert.Labels))
+		for k, v := range alert.Labels {
+			labels[string(k)] = string(v)
+		}
+		entry.Alerts = append(entry.Alerts, models.NotificationAlert{
+			Fingerprint: alert.Fingerprint().String(),
+			Status:      string(alert.StatusAt(start)),
+			Labels:      labels,
+			StartsAt:    alert.StartsAt,
+			EndsAt:      alert.EndsAt,
+		})
+	}
+	// The backends write in the background and log failures, there is no need to wait for the result.
+	n.historian.RecordNotification(ctx, entry)
+
+	return retry, err
+}
+
+func (n *historyNotifier) SendResolved() bool {
+	return n.upstream.SendResolved()
+}
@@ -0,0 +1,99 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	alertingNotify "github.com/grafana/alerting/notify"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+type fakeNotificationHistorian struct {
+	entries []models.NotificationLogEntry
+}
+
+func (f *fakeNotificationHistorian) RecordNotification(_ context.Context, entry models.NotificationLogEntry) <-chan error {
+	f.entries = append(f.entries, entry)
+	errCh := make(chan error)
+	close(errCh)
+	return errCh
+}
+
+type fakeNotifier struct {
+	retry bool
+	err   error
+}
+
+func (f *fakeNotifier) Notify(context.Context, ...*types.Alert) (bool, error) {
+	return f.retry, f.err
+}
+
+func (f *fakeNotifier) SendResolved() bool {
+	return true
+}
+
+func TestWithNotificationHistory(t *testing.T) {
+	now := time.Now()
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "test", "team": "a"},
+			StartsAt: now.Add(-time.Minute),
+			EndsAt:   now.Add(time.Hour),
+		},
+	}
+	ctx := notify.WithGroupKey(context.Background(), "{}:{alertname=\"test\"}")
+	ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": "test"})
+
+	t.Run("records successful notification attempts", func(t *testing.T) {
+		hist := &fakeNotificationHistorian{}
+		n := &fakeNotifier{}
+		integrations := withNotificationHistory([]*alertingNotify.Integration{
+			alertingNotify.NewIntegration(n, n, "email", 1, "team-a"),
+		}, "team-a", 1, hist)
+		require.Len(t, integrations, 1)
+		require.Equal(t, "email", integrations[0].Name())
+		require.Equal(t, 1, integrations[0].Index())
+
+		retry, err := integrations[0].Notify(ctx, alert)
+		require.NoError(t, err)
+		require.False(t, retry)
+
+		require.Len(t, hist.entries, 1)
+		entry := hist.entries[0]
+		require.Equal(t, int64(1), entry.OrgID)
+		require.Equal(t, "team-a", entry.Receiver)
+		require.Equal(t, "email", entry.Integration)
+		require.Equal(t, 1, entry.IntegrationIndex)
+		require.Equal(t, models.NotificationStatusSuccess, entry.Status)
+		require.Equal(t, "{}:{alertname=\"test\"}", entry.GroupKey)
+		require.Equal(t, map[string]string{"alertname": "test"}, entry.GroupLabels)
+		require.Len(t, entry.Alerts, 1)
+		require.Equal(t, "firing", entry.Alerts[0].Status)
+		require.Equal(t, alert.Fingerprint().String(), entry.Alerts[0].Fingerprint)
+		require.Equal(t, map[string]string{"alertname": "test", "team": "a"}, entry.Alerts[0].Labels)
+	})
+
+	t.Run("records failed notification attempts", func(t *testing.T) {
+		hist := &fakeNotificationHistorian{}
+		n := &fakeNotifier{retry: true, err: errors.New("smtp unavailable")}
+		integrations := withNotificationHistory([]*alertingNotify.Integration{
+			alertingNotify.NewIntegration(n, n, "email", 0, "team-a"),
+		}, "team-a", 1, hist)
+
+		retry, err := integrations[0].Notify(ctx, alert)
+		require.ErrorContains(t, err, "smtp unavailable")
+		require.True(t, retry)
+
+		require.Len(t, hist.entries, 1)
+		require.Equal(t, models.NotificationStatusFailed, hist.entries[0].Status)
+		require.Equal(t, "smtp unavailable", hist.entries[0].Error)
+		require.True(t, hist.entries[0].Retry)
+	})
+}
@@ -0,0 +1,253 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/timeinterval"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/provisioning/validation"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// RecurringSilenceStore is the interface for storing recurring silences and the state of their windows.
+type RecurringSilenceStore interface {
+	ListRecurringSilences(ctx context.Context, orgID int64) ([]*models.RecurringSilence, error)
+	GetRecurringSilence(ctx context.Context, orgID int64, uid string) (*models.RecurringSilence, error)
+	InsertRecurringSilence(ctx context.Context, s models.RecurringSilence) error
+	UpdateRecurringSilence(ctx context.Context, s models.RecurringSilence) error
+	DeleteRecurringSilence(ctx context.Context, orgID int64, uid string) error
+	ClaimRecurringSilenceWindow(ctx context.Context, s models.RecurringSilence, windowStart time.Time) (bool, error)
+	SetRecurringSilenceSilenceID(ctx context.Context, orgID int64, uid string, silenceID string) error
+}
+
+// RecurringSilenceService is the authenticated service for managing recurring silences.
+// A user can manage a recurring silence if they are allowed to manage a silence with the same matchers.
+type RecurringSilenceService struct {
+	authz               SilenceAccessControlService
+	xact                transactionManager
+	log                 log.Logger
+	store               RecurringSilenceStore
+	cfgStore            alertmanagerConfigStore
+	provisioningStore   provisoningStore
+	provenanceValidator validation.ProvenanceStatusTransitionValidator
+	now                 func() time.Time
+}
+
+func NewRecurringSilenceService(
+	authz SilenceAccessControlService,
+	xact transactionManager,
+	log log.Logger,
+	store RecurringSilenceStore,
+	cfgStore alertmanagerConfigStore,
+	provisioningStore provisoningStore,
+) *RecurringSilenceService {
+	return &RecurringSilenceService{
+		authz:               authz,
+		xact:                xact,
+		log:                 log,
+		store:               store,
+		cfgStore:            cfgStore,
+		provisioningStore:   provisioningStore,
+		provenanceValidator: validation.ValidateProvenanceRelaxed,
+		now:                 time.Now,
+	}
+}
+
+// ListRecurringSilences returns the recurring silences of the user's organization that the user has access to.
+func (s *RecurringSilenceService) ListRecurringSilences(ctx context.Context, user identity.Requester) ([]*models.RecurringSilence, error) {
+	recurring, err := s.store.ListRecurringSilences(ctx, user.GetOrgID())
+	if err != nil {
+		return nil, err
+	}
+
+	bySilence := make(map[*models.Silence]*models.RecurringSilence, len(recurring))
+	silences := make([]*models.Silence, 0, len(recurring))
+	for _, r := range recurring {
+		sil := recurringSilenceToSilence(r)
+		bySilence[sil] = r
+		silences = append(silences, sil)
+	}
+	allowed, err := s.authz.FilterByAccess(ctx, user, silences...)
+	if err != nil {
+		return nil, err
+	}
+
+	provenances, err := s.provisioningStore.GetProvenances(ctx, user.GetOrgID(), (&models.RecurringSilence{}).ResourceType())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*models.RecurringSilence, 0, len(allowed))
+	for _, sil := range allowed {
+		r := bySilence[sil]
+		r.Provenance = provenances[r.UID]
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// GetRecurringSilence returns a recurring silence by its UID.
+func (s *RecurringSilenceService) GetRecurringSilence(ctx context.Context, user identity.Requester, uid string) (*models.RecurringSilence, error) {
+	r, err := s.store.GetRecurringSilence(ctx, user.GetOrgID(), uid)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authz.AuthorizeReadSilence(ctx, user, recurringSilenceToSilence(r)); err != nil {
+		return nil, err
+	}
+	r.Provenance, err = s.provisioningStore.GetProvenance(ctx, r, user.GetOrgID())
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// CreateRecurringSilence creates a new recurring silence. The provenance of the given silence is the provenance of the caller.
+func (s *RecurringSilenceService) CreateRecurringSilence(ctx context.Context, user identity.Requester, r models.RecurringSilence) (models.RecurringSilence, error) {
+	r.OrgID = user.GetOrgID()
+	if r.UID == "" {
+		r.UID = util.GenerateShortUID()
+	}
+	if err := s.validate(ctx, &r); err != nil {
+		return models.RecurringSilence{}, err
+	}
+	if err := s.authz.AuthorizeCreateSilence(ctx, user, recurringSilenceToSilence(&r)); err != nil {
+		return models.RecurringSilence{}, err
+	}
+	if _, err := s.store.GetRecurringSilence(ctx, r.OrgID, r.UID); err == nil {
+		return models.RecurringSilence{}, WithPublicError(ErrSilencesBadRequest.Errorf("recurring silence with UID %s already exists", r.UID))
+	} else if !errors.Is(err, models.ErrRecurringSilenceNotFound) {
+		return models.RecurringSilence{}, err
+	}
+
+	r.Updated = s.now()
+	r.SilenceID = ""
+	r.WindowStart = time.Time{}
+	err := s.xact.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.store.InsertRecurringSilence(ctx, r); err != nil {
+			return err
+		}
+		return s.provisioningStore.SetProvenance(ctx, &r, r.OrgID, r.Provenance)
+	})
+	if err != nil {
+		return models.RecurringSilence{}, err
+	}
+	return r, nil
+}
+
+// UpdateRecurringSilence updates an existing recurring silence. The silence that was created for the current window is
+// not changed, the new definition applies from the next window on.
+func (s *RecurringSilenceService) UpdateRecurringSilence(ctx context.Context, user identity.Requester, r models.RecurringSilence) (models.RecurringSilence, error) {
+	r.OrgID = user.GetOrgID()
+	if err := s.validate(ctx, &r); err != nil {
+		return models.RecurringSilence{}, err
+	}
+	existing, err := s.store.GetRecurringSilence(ctx, r.OrgID, r.UID)
+	if err != nil {
+		return models.RecurringSilence{}, err
+	}
+	if err := s.authz.AuthorizeUpdateSilence(ctx, user, recurringSilenceToSilence(existing)); err != nil {
+		return models.RecurringSilence{}, err
+	}
+	if err := s.authz.AuthorizeUpdateSilence(ctx, user, recurringSilenceToSilence(&r)); err != nil {
+		return models.RecurringSilence{}, err
+	}
+	storedProvenance, err := s.provisioningStore.GetProvenance(ctx, existing, r.OrgID)
+	if err != nil {
+		return models.RecurringSilence{}, err
+	}
+	if err := s.provenanceValidator(storedProvenance, r.Provenance); err != nil {
+		return models.RecurringSilence{}, err
+	}
+
+	r.Updated = s.now()
+	r.SilenceID = existing.SilenceID
+	r.WindowStart = existing.WindowStart
+	err = s.xact.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.store.UpdateRecurringSilence(ctx, r); err != nil {
+			return err
+		}
+		return s.provisioningStore.SetProvenance(ctx, &r, r.OrgID, r.Provenance)
+	})
+	if err != nil {
+		return models.RecurringSilence{}, err
+	}
+	return r, nil
+}
+
+// DeleteRecurringSilence deletes a recurring silence. The silence that was created for the current window is not expired.
+func (s *RecurringSilenceService) DeleteRecurringSilence(ctx context.Context, user identity.Requester, uid string, callerProvenance models.Provenance) error {
+	existing, err := s.store.GetRecurringSilence(ctx, user.GetOrgID(), uid)
+	if err != nil {
+		if errors.Is(err, models.ErrRecurringSilenceNotFound) {
+			return nil
+		}
+		return err
+	}
+	if err := s.authz.AuthorizeUpdateSilence(ctx, user, recurringSilenceToSilence(existing)); err != nil {
+		return err
+	}
+	storedProvenance, err := s.provisioningStore.GetProvenance(ctx, existing, existing.OrgID)
+	if err != nil {
+		return err
+	}
+	if err := s.provenanceValidator(storedProvenance, callerProvenance); err != nil {
+		return err
+	}
+	return s.xact.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.store.DeleteRecurringSilence(ctx, existing.OrgID, uid); err != nil {
+			return err
+		}
+		return s.provisioningStore.DeleteProvenance(ctx, existing, existing.OrgID)
+	})
+}
+
+func (s *RecurringSilenceService) validate(ctx context.Context, r *models.RecurringSilence) error {
+	if err := r.Validate(); err != nil {
+		return WithPublicError(ErrSilencesBadRequest.Errorf("invalid recurring silence: %w", err))
+	}
+	if r.TimeInterval == "" {
+		return nil
+	}
+	rev, err := s.cfgStore.Get(ctx, r.OrgID)
+	if err != nil {
+		return err
+	}
+	if _, ok := timeIntervalByName(rev.Config, r.TimeInterval); !ok {
+		return WithPublicError(ErrSilencesBadRequest.Errorf("invalid recurring silence: time interval %s does not exist", r.TimeInterval))
+	}
+	return nil
+}
+
+// recurringSilenceToSilence returns a silence with the matchers of the recurring silence. Recurring silences are
+// authorized like the silences they create.
+func recurringSilenceToSilence(r *models.RecurringSilence) *models.Silence {
+	return &models.Silence{
+		Silence: amv2.Silence{
+			Matchers:  r.Matchers,
+			Comment:   &r.Comment,
+			CreatedBy: &r.CreatedBy,
+		},
+	}
+}
+
+// timeIntervalByName returns the time ranges of the time interval or mute time interval with the given name.
+func timeIntervalByName(cfg *definitions.PostableUserConfig, name string) ([]timeinterval.TimeInterval, bool) {
+	for _, ti := range cfg.AlertmanagerConfig.TimeIntervals {
+		if ti.Name == name {
+			return ti.TimeIntervals, true
+		}
+	}
+	for _, mti := range cfg.AlertmanagerConfig.MuteTimeIntervals {
+		if mti.Name == name {
+			return mti.TimeIntervals, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,372 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/go-openapi/strfmt"
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/robfig/cron/v3"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+const (
+	// silenceSchedulerInterval is how often recurring silences and silences that expire on resolve are processed.
+	silenceSchedulerInterval = time.Minute
+	// recurringSilenceLookahead is how long before the start of a window its silence is created, so that the silence
+	// is already in place when the window starts.
+	recurringSilenceLookahead = 10 * time.Minute
+	// maxTimeIntervalWindow is the longest window of a time interval. Time intervals that are always active are split
+	// into windows of this length.
+	maxTimeIntervalWindow = 7 * 24 * time.Hour
+	// defaultRecurringSilenceAuthor is the author of the silences of recurring silences that have no author.
+	defaultRecurringSilenceAuthor = "Grafana"
+)
+
+// SilenceExpiryStore is the interface for storing silences that expire once their alerts resolve.
+type SilenceExpiryStore interface {
+	ListSilenceExpiries(ctx context.Context) ([]models.SilenceExpiry, error)
+	SaveSilenceExpiry(ctx context.Context, e models.SilenceExpiry) error
+	DeleteSilenceExpiry(ctx context.Context, orgID int64, silenceID string) error
+}
+
+type silenceScheduleStore interface {
+	RecurringSilenceStore
+	SilenceExpiryStore
+}
+
+type alertmanagerProvider interface {
+	AlertmanagerFor(orgID int64) (Alertmanager, error)
+}
+
+// SilenceScheduler creates a silence for every window of the recurring silences and expires the silences that should
+// expire once all alerts they silenced have resolved.
+type SilenceScheduler struct {
+	store         silenceScheduleStore
+	silences      SilenceStore
+	alertmanagers alertmanagerProvider
+	cfgStore      alertmanagerConfigStore
+	log           log.Logger
+	clock         clock.Clock
+}
+
+func NewSilenceScheduler(store silenceScheduleStore, moa *MultiOrgAlertmanager, cfgStore alertmanagerConfigStore, log log.Logger) *SilenceScheduler {
+	return &SilenceScheduler{
+		store:         store,
+		silences:      moa,
+		alertmanagers: moa,
+		cfgStore:      cfgStore,
+		log:           log,
+		clock:         clock.New(),
+	}
+}
+
+func (s *SilenceScheduler) Run(ctx context.Context) error {
+	t := s.clock.Ticker(silenceSchedulerInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			s.Tick(ctx)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Tick creates the silences of the windows that are active or start soon, and expires the silences whose alerts have resolved.
+func (s *SilenceScheduler) Tick(ctx context.Context) {
+	s.scheduleRecurringSilences(ctx)
+	s.expireResolvedSilences(ctx)
+}
+
+func (s *SilenceScheduler) scheduleRecurringSilences(ctx context.Context) {
+	recurring, err := s.store.ListRecurringSilences(ctx, 0)
+	if err != nil {
+		s.log.Error("Failed to list recurring silences", "error", err)
+		return
+	}
+	now := s.clock.Now()
+	for _, r := range recurring {
+		logger := s.log.New("org", r.OrgID, "uid", r.UID)
+		start, end, ok, err := s.nextWindow(ctx, r, now)
+		if err != nil {
+			logger.Warn("Failed to compute the next window of the recurring silence", "error", err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if err := s.createWindowSilence(ctx, r, start, end); err != nil {
+			logger.Error("Failed to create the silence of the recurring silence", "windowStart", start, "windowEnd", end, "error", err)
+		}
+	}
+}
+
+// nextWindow returns the earliest window of the recurring silence that has no silence yet and is either active or
+// starts within the lookahead.
+func (s *SilenceScheduler) nextWindow(ctx context.Context, r *models.RecurringSilence, now time.Time) (time.Time, time.Time, bool, error) {
+	if r.Schedule != "" {
+		sched, err := cron.ParseStandard(r.Schedule)
+		if err != nil {
+			return time.Time{}, time.Time{}, false, err
+		}
+		loc, err := r.Location()
+		if err != nil {
+			return time.Time{}, time.Time{}, false, err
+		}
+		start, end, ok := nextScheduleWindow(sched, loc, r.Duration, r.WindowStart, now, recurringSilenceLookahead)
+		return start, end, ok, nil
+	}
+	rev, err := s.cfgStore.Get(ctx, r.OrgID)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, err
+	}
+	intervals, ok := timeIntervalByName(rev.Config, r.TimeInterval)
+	if !ok {
+		return time.Time{}, time.Time{}, false, errors.New("time interval " + r.TimeInterval + " does not exist")
+	}
+	start, end, ok := nextTimeIntervalWindow(intervals, r.WindowStart, now, recurringSilenceLookahead)
+	return start, end, ok, nil
+}
+
+func (s *SilenceScheduler) createWindowSilence(ctx context.Context, r *models.RecurringSilence, start, end time.Time) error {
+	// Claim the window first so that only one of the instances that share the database creates its silence.
+	claimed, err := s.store.ClaimRecurringSilenceWindow(ctx, *r, start)
+	if err != nil || !claimed {
+		return err
+	}
+
+	createdBy := r.CreatedBy
+	if createdBy == "" {
+		createdBy = defaultRecurringSilenceAuthor
+	}
+	silenceID, err := s.silences.CreateSilence(ctx, r.OrgID, models.Silence{
+		Silence: amv2.Silence{
+			Matchers:  r.Matchers,
+			StartsAt:  (*strfmt.DateTime)(&start),
+			EndsAt:    (*strfmt.DateTime)(&end),
+			Comment:   &r.Comment,
+			CreatedBy: &createdBy,
+		},
+	})
+	if err != nil {
+		// Release the window so that the next tick tries again.
+		claimedRecurring := *r
+		claimedRecurring.WindowStart = start
+		if _, releaseErr := s.store.ClaimRecurringSilenceWindow(ctx, claimedRecurring, r.WindowStart); releaseErr != nil {
+			s.log.Warn("Failed to release the window of the recurring silence", "org", r.OrgID, "uid", r.UID, "error", releaseErr)
+		}
+		return err
+	}
+	s.log.Debug("Created silence of recurring silence", "org", r.OrgID, "uid", r.UID, "silenceID", silenceID, "windowStart", start, "windowEnd", end)
+
+	if err := s.store.SetRecurringSilenceSilenceID(ctx, r.OrgID, r.UID, silenceID); err != nil {
+		return err
+	}
+	if r.ExpireOnResolve {
+		return s.store.SaveSilenceExpiry(ctx, models.SilenceExpiry{OrgID: r.OrgID, SilenceID: silenceID})
+	}
+	return nil
+}
+
+func (s *SilenceScheduler) expireResolvedSilences(ctx context.Context) {
+	expiries, err := s.store.ListSilenceExpiries(ctx)
+	if err != nil {
+		s.log.Error("Failed to list silences that expire on resolve", "error", err)
+		return
+	}
+
+	// Alerts are loaded once per organization and tick.
+	alertsByOrg := make(map[int64]amv2.GettableAlerts)
+	for _, e := range expiries {
+		logger := s.log.New("org", e.OrgID, "silenceID", e.SilenceID)
+		silence, err := s.silences.GetSilence(ctx, e.OrgID, e.SilenceID)
+		if err != nil {
+			if errors.Is(err, ErrSilenceNotFound) {
+				s.deleteExpiry(ctx, logger, e)
+				continue
+			}
+			logger.Warn("Failed to get silence that expires on resolve", "error", err)
+			continue
+		}
+		if silence.Status == nil || silence.Status.State == nil {
+			continue
+		}
+		switch *silence.Status.State {
+		case amv2.SilenceStatusStateExpired:
+			s.deleteExpiry(ctx, logger, e)
+			continue
+		case amv2.SilenceStatusStatePending:
+			continue
+		}
+
+		alerts, ok := alertsByOrg[e.OrgID]
+		if !ok {
+			am, err := s.alertmanagers.AlertmanagerFor(e.OrgID)
+			if err != nil {
+				logger.Warn("Failed to get the Alertmanager of the organization", "error", err)
+				continue
+			}
+			alerts, err = am.GetAlerts(ctx, true, true, true, nil, "")
+			if err != nil {
+				logger.Warn("Failed to get alerts", "error", err)
+				continue
+			}
+			alertsByOrg[e.OrgID] = alerts
+		}
+
+		if silencesAnyAlert(e.SilenceID, alerts) {
+			if !e.AlertsSeen {
+				e.AlertsSeen = true
+				if err := s.store.SaveSilenceExpiry(ctx, e); err != nil {
+					logger.Warn("Failed to save that the silence has silenced alerts", "error", err)
+				}
+			}
+			continue
+		}
+		if !e.AlertsSeen {
+			continue
+		}
+		if err := s.silences.DeleteSilence(ctx, e.OrgID, e.SilenceID); err != nil && !errors.Is(err, ErrSilenceNotFound) {
+			logger.Warn("Failed to expire silence whose alerts have resolved", "error", err)
+			continue
+		}
+		logger.Info("Expired silence because all alerts it silenced have resolved")
+		s.deleteExpiry(ctx, logger, e)
+	}
+}
+
+func (s *SilenceScheduler) deleteExpiry(ctx context.Context, logger log.Logger, e models.SilenceExpiry) {
+	if err := s.store.DeleteSilenceExpiry(ctx, e.OrgID, e.SilenceID); err != nil {
+		logger.Warn("Failed to delete the expiry of the silence", "error", err)
+	}
+}
+
+func silencesAnyAlert(silenceID string, alerts amv2.GettableAlerts) bool {
+	for _, alert := range alerts {
+		if alert.Status != nil && slices.Contains(alert.Status.SilencedBy, silenceID) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextScheduleWindow returns the earliest window of the cron schedule in the location that starts after lastStart and
+// is either active at now or starts within the lookahead.
+func nextScheduleWindow(sched cron.Schedule, loc *time.Location, duration time.Duration, lastStart, now time.Time, lookahead time.Duration) (time.Time, time.Time, bool) {
+	from := now.Add(-duration)
+	if lastStart.After(from) {
+		from = lastStart
+	}
+	// The schedule is evaluated in the location of the time it starts from.
+	start := sched.Next(from.In(loc))
+	if start.IsZero() || start.After(now.Add(lookahead)) {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, start.Add(duration), true
+}
+
+// nextTimeIntervalWindow returns the earliest window of the time intervals that starts after lastStart and is either
+// active at now or starts within the lookahead. A window is a contiguous range of minutes that the intervals contain.
+// The intervals can only start or end at the boundaries of their time ranges and days, so the search moves from one
+// boundary to the next instead of checking every minute.
+func nextTimeIntervalWindow(intervals []timeinterval.TimeInterval, lastStart, now time.Time, lookahead time.Duration) (time.Time, time.Time, bool) {
+	contains := func(t time.Time) bool {
+		for _, ti := range intervals {
+			if ti.ContainsTime(t) {
+				return true
+			}
+		}
+		return false
+	}
+	windowEnd := func(start time.Time) time.Time {
+		limit := start.Add(maxTimeIntervalWindow)
+		t := start
+		for contains(t) {
+			t = nextTimeIntervalBoundary(intervals, t)
+			if !t.Before(limit) {
+				return limit
+			}
+		}
+		return t
+	}
+
+	t := now.Truncate(time.Minute)
+	if contains(t) {
+		// Find the start of the active window, but not further back than the window of the last silence.
+		limit := t.Add(-maxTimeIntervalWindow)
+		if lastStart.After(limit) {
+			limit = lastStart
+		}
+		for t.After(limit) && contains(t.Add(-time.Minute)) {
+			t = prevTimeIntervalBoundary(intervals, t)
+			if t.Before(limit) {
+				t = limit
+			}
+		}
+		if t.After(lastStart) {
+			return t, windowEnd(t), true
+		}
+		// The active window has a silence already, continue with the window after it.
+		t = windowEnd(t)
+	}
+	for horizon := now.Add(lookahead); !t.After(horizon); t = nextTimeIntervalBoundary(intervals, t) {
+		if contains(t) {
+			return t, windowEnd(t), true
+		}
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// nextTimeIntervalBoundary returns the earliest boundary of the time intervals after t.
+func nextTimeIntervalBoundary(intervals []timeinterval.TimeInterval, t time.Time) time.Time {
+	next := t.Add(maxTimeIntervalWindow)
+	for _, b := range timeIntervalBoundaries(intervals, t) {
+		if b.After(t) && b.Before(next) {
+			next = b
+		}
+	}
+	return next
+}
+
+// prevTimeIntervalBoundary returns the latest boundary of the time intervals before t.
+func prevTimeIntervalBoundary(intervals []timeinterval.TimeInterval, t time.Time) time.Time {
+	prev := t.Add(-maxTimeIntervalWindow)
+	for _, b := range timeIntervalBoundaries(intervals, t) {
+		if b.Before(t) && b.After(prev) {
+			prev = b
+		}
+	}
+	return prev
+}
+
+// timeIntervalBoundaries returns the times of the day before, the day of and the day after t at which the time
+// intervals can start or end. These are the start of every day, as the weekdays, days of the month, months and years
+// of an interval change at midnight, and the start and end of its time ranges.
+func timeIntervalBoundaries(intervals []timeinterval.TimeInterval, t time.Time) []time.Time {
+	boundaries := make([]time.Time, 0, len(intervals)*3)
+	for _, ti := range intervals {
+		// Intervals without a location use the location of the time, like TimeInterval.ContainsTime.
+		lt := t
+		if ti.Location != nil {
+			lt = t.In(ti.Location.Location)
+		}
+		at := func(day, minute int) time.Time {
+			return time.Date(lt.Year(), lt.Month(), lt.Day()+day, 0, minute, 0, 0, lt.Location()).In(t.Location())
+		}
+		for day := -1; day <= 1; day++ {
+			boundaries = append(boundaries, at(day, 0))
+			for _, tr := range ti.Times {
+				boundaries = append(boundaries, at(day, tr.StartMinute), at(day, tr.EndMinute))
+			}
+		}
+	}
+	return boundaries
+}
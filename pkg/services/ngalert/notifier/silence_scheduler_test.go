@@ -0,0 +1,431 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestNextScheduleWindow(t *testing.T) {
+	sched, err := cron.ParseStandard("0 22 * * SAT")
+	require.NoError(t, err)
+	// Saturday
+	saturday := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	windowStart := saturday.Add(22 * time.Hour)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		loc           *time.Location
+		lastStart     time.Time
+		now           time.Time
+		expectedStart time.Time
+	}{
+		{
+			name: "window starts after the lookahead",
+			now:  windowStart.Add(-time.Hour),
+		},
+		{
+			name:          "window starts within the lookahead",
+			now:           windowStart.Add(-5 * time.Minute),
+			expectedStart: windowStart,
+		},
+		{
+			name:          "window is active",
+			now:           windowStart.Add(time.Hour),
+			expectedStart: windowStart,
+		},
+		{
+			name:      "active window has a silence already",
+			lastStart: windowStart,
+			now:       windowStart.Add(time.Hour),
+		},
+		{
+			name: "window has ended",
+			now:  windowStart.Add(5 * time.Hour),
+		},
+		{
+			name:          "schedule is evaluated in UTC regardless of the location of now",
+			now:           windowStart.Add(time.Hour).In(newYork),
+			expectedStart: windowStart,
+		},
+		{
+			name:          "schedule is evaluated in its time zone",
+			loc:           berlin,
+			now:           windowStart.Add(-time.Hour),
+			expectedStart: windowStart.Add(-2 * time.Hour),
+		},
+		{
+			name: "window in the time zone has ended",
+			loc:  berlin,
+			now:  windowStart.Add(3 * time.Hour),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			loc := tc.loc
+			if loc == nil {
+				loc = time.UTC
+			}
+			start, end, ok := nextScheduleWindow(sched, loc, 4*time.Hour, tc.lastStart, tc.now, recurringSilenceLookahead)
+			if tc.expectedStart.IsZero() {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.True(t, tc.expectedStart.Equal(start), "expected start %s, got %s", tc.expectedStart, start)
+			assert.True(t, tc.expectedStart.Add(4*time.Hour).Equal(end), "expected end %s, got %s", tc.expectedStart.Add(4*time.Hour), end)
+		})
+	}
+}
+
+func TestNextTimeIntervalWindow(t *testing.T) {
+	// 10:00 to 12:00 every day
+	intervals := []timeinterval.TimeInterval{{
+		Times: []timeinterval.TimeRange{{StartMinute: 10 * 60, EndMinute: 12 * 60}},
+	}}
+	day := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	windowStart := day.Add(10 * time.Hour)
+	windowEnd := day.Add(12 * time.Hour)
+
+	testCases := []struct {
+		name          string
+		lastStart     time.Time
+		now           time.Time
+		expectedStart time.Time
+		expectedEnd   time.Time
+	}{
+		{
+			name: "window starts after the lookahead",
+			now:  windowStart.Add(-time.Hour),
+		},
+		{
+			name:          "window starts within the lookahead",
+			now:           windowStart.Add(-5 * time.Minute),
+			expectedStart: windowStart,
+			expectedEnd:   windowEnd,
+		},
+		{
+			name:          "window is active",
+			now:           windowStart.Add(30*time.Minute + 10*time.Second),
+			expectedStart: windowStart,
+			expectedEnd:   windowEnd,
+		},
+		{
+			name:      "active window has a silence already",
+			lastStart: windowStart,
+			now:       windowStart.Add(30 * time.Minute),
+		},
+		{
+			name:          "window of the next day",
+			lastStart:     windowStart,
+			now:           windowStart.Add(24*time.Hour - 5*time.Minute),
+			expectedStart: windowStart.Add(24 * time.Hour),
+			expectedEnd:   windowEnd.Add(24 * time.Hour),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start, end, ok := nextTimeIntervalWindow(intervals, tc.lastStart, tc.now, recurringSilenceLookahead)
+			if tc.expectedStart.IsZero() {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedStart, start)
+			assert.Equal(t, tc.expectedEnd, end)
+		})
+	}
+}
+
+func TestNextTimeIntervalWindowBoundaries(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	// Saturday
+	day := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		intervals     []timeinterval.TimeInterval
+		lastStart     time.Time
+		now           time.Time
+		expectedStart time.Time
+		expectedEnd   time.Time
+	}{
+		{
+			name: "window of several days",
+			intervals: []timeinterval.TimeInterval{{
+				Weekdays: []timeinterval.WeekdayRange{{InclusiveRange: timeinterval.InclusiveRange{Begin: 6, End: 6}}, {InclusiveRange: timeinterval.InclusiveRange{Begin: 0, End: 0}}},
+			}},
+			now:           day.Add(30 * time.Hour),
+			expectedStart: day,
+			expectedEnd:   day.Add(48 * time.Hour),
+		},
+		{
+			name: "window across midnight of two time ranges",
+			intervals: []timeinterval.TimeInterval{{
+				Times: []timeinterval.TimeRange{{StartMinute: 22 * 60, EndMinute: 24 * 60}, {StartMinute: 0, EndMinute: 2 * 60}},
+			}},
+			now:           day.Add(22*time.Hour - 5*time.Minute),
+			expectedStart: day.Add(22 * time.Hour),
+			expectedEnd:   day.Add(26 * time.Hour),
+		},
+		{
+			name: "window of two intervals",
+			intervals: []timeinterval.TimeInterval{
+				{Times: []timeinterval.TimeRange{{StartMinute: 10 * 60, EndMinute: 12 * 60}}},
+				{Times: []timeinterval.TimeRange{{StartMinute: 11 * 60, EndMinute: 14 * 60}}},
+			},
+			now:           day.Add(13 * time.Hour),
+			expectedStart: day.Add(10 * time.Hour),
+			expectedEnd:   day.Add(14 * time.Hour),
+		},
+		{
+			name: "window in the location of the interval",
+			intervals: []timeinterval.TimeInterval{{
+				Times:    []timeinterval.TimeRange{{StartMinute: 10 * 60, EndMinute: 12 * 60}},
+				Location: &timeinterval.Location{Location: berlin},
+			}},
+			now:           day.Add(8*time.Hour - 5*time.Minute),
+			expectedStart: day.Add(8 * time.Hour),
+			expectedEnd:   day.Add(10 * time.Hour),
+		},
+		{
+			name:          "interval that is always active is split into windows",
+			intervals:     []timeinterval.TimeInterval{{}},
+			now:           day,
+			expectedStart: day.Add(-maxTimeIntervalWindow),
+			expectedEnd:   day,
+		},
+		{
+			name:          "next window of an interval that is always active",
+			intervals:     []timeinterval.TimeInterval{{}},
+			lastStart:     day,
+			now:           day.Add(maxTimeIntervalWindow - 5*time.Minute),
+			expectedStart: day.Add(maxTimeIntervalWindow),
+			expectedEnd:   day.Add(2 * maxTimeIntervalWindow),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start, end, ok := nextTimeIntervalWindow(tc.intervals, tc.lastStart, tc.now, recurringSilenceLookahead)
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedStart, start)
+			assert.Equal(t, tc.expectedEnd, end)
+		})
+	}
+}
+
+func TestSilenceSchedulerTick(t *testing.T) {
+	matchers := amv2.Matchers{
+		{Name: util.Pointer("team"), Value: util.Pointer("network"), IsEqual: util.Pointer(true), IsRegex: util.Pointer(false)},
+	}
+	windowStart := time.Date(2024, 6, 1, 22, 0, 0, 0, time.UTC)
+
+	newScheduler := func(store *fakeSilenceScheduleStore, silences *fakeSilenceStore, am *fakeAlertsAlertmanager) *SilenceScheduler {
+		clk := clock.NewMock()
+		clk.Set(windowStart.Add(-5 * time.Minute))
+		return &SilenceScheduler{
+			store:         store,
+			silences:      silences,
+			alertmanagers: fakeAlertmanagerProvider{am: am},
+			log:           log.NewNopLogger(),
+			clock:         clk,
+		}
+	}
+
+	t.Run("creates one silence per window", func(t *testing.T) {
+		store := &fakeSilenceScheduleStore{recurring: []*models.RecurringSilence{{
+			UID:             "maintenance",
+			OrgID:           1,
+			Matchers:        matchers,
+			Comment:         "weekly maintenance",
+			Schedule:        "0 22 * * SAT",
+			Duration:        4 * time.Hour,
+			ExpireOnResolve: true,
+		}}}
+		silences := &fakeSilenceStore{silences: map[string]*models.Silence{}}
+		s := newScheduler(store, silences, &fakeAlertsAlertmanager{})
+
+		s.Tick(context.Background())
+		s.Tick(context.Background())
+
+		require.Len(t, silences.silences, 1)
+		for id, silence := range silences.silences {
+			assert.Equal(t, windowStart, time.Time(*silence.StartsAt))
+			assert.Equal(t, windowStart.Add(4*time.Hour), time.Time(*silence.EndsAt))
+			assert.Equal(t, defaultRecurringSilenceAuthor, *silence.CreatedBy)
+			assert.Equal(t, id, store.recurring[0].SilenceID)
+			assert.Equal(t, []models.SilenceExpiry{{OrgID: 1, SilenceID: id}}, store.expiries)
+		}
+	})
+
+	t.Run("expires silence once its alerts have resolved", func(t *testing.T) {
+		store := &fakeSilenceScheduleStore{expiries: []models.SilenceExpiry{{OrgID: 1, SilenceID: "silence-1"}}}
+		silences := &fakeSilenceStore{silences: map[string]*models.Silence{
+			"silence-1": {Silence: amv2.Silence{Matchers: matchers}, Status: &amv2.SilenceStatus{State: util.Pointer(amv2.SilenceStatusStateActive)}},
+		}}
+		am := &fakeAlertsAlertmanager{}
+		s := newScheduler(store, silences, am)
+
+		// no alerts were silenced yet
+		s.Tick(context.Background())
+		require.Contains(t, silences.silences, "silence-1")
+		require.False(t, store.expiries[0].AlertsSeen)
+
+		am.alerts = definitions.GettableAlerts{{Status: &amv2.AlertStatus{SilencedBy: []string{"silence-1"}}}}
+		s.Tick(context.Background())
+		require.Contains(t, silences.silences, "silence-1")
+		require.True(t, store.expiries[0].AlertsSeen)
+
+		am.alerts = nil
+		s.Tick(context.Background())
+		require.NotContains(t, silences.silences, "silence-1")
+		require.Empty(t, store.expiries)
+	})
+}
+
+type fakeSilenceScheduleStore struct {
+	recurring []*models.RecurringSilence
+	expiries  []models.SilenceExpiry
+}
+
+func (f *fakeSilenceScheduleStore) ListRecurringSilences(_ context.Context, _ int64) ([]*models.RecurringSilence, error) {
+	result := make([]*models.RecurringSilence, 0, len(f.recurring))
+	for _, r := range f.recurring {
+		c := *r
+		result = append(result, &c)
+	}
+	return result, nil
+}
+
+func (f *fakeSilenceScheduleStore) GetRecurringSilence(_ context.Context, orgID int64, uid string) (*models.RecurringSilence, error) {
+	for _, r := range f.recurring {
+		if r.OrgID == orgID && r.UID == uid {
+			c := *r
+			return &c, nil
+		}
+	}
+	return nil, models.ErrRecurringSilenceNotFound.Errorf("")
+}
+
+func (f *fakeSilenceScheduleStore) InsertRecurringSilence(_ context.Context, s models.RecurringSilence) error {
+	f.recurring = append(f.recurring, &s)
+	return nil
+}
+
+func (f *fakeSilenceScheduleStore) UpdateRecurringSilence(_ context.Context, _ models.RecurringSilence) error {
+	return nil
+}
+
+func (f *fakeSilenceScheduleStore) DeleteRecurringSilence(_ context.Context, _ int64, _ string) error {
+	return nil
+}
+
+func (f *fakeSilenceScheduleStore) ClaimRecurringSilenceWindow(_ context.Context, s models.RecurringSilence, windowStart time.Time) (bool, error) {
+	for _, r := range f.recurring {
+		if r.OrgID == s.OrgID && r.UID == s.UID && r.WindowStart.Equal(s.WindowStart) {
+			r.WindowStart = windowStart
+			r.SilenceID = ""
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeSilenceScheduleStore) SetRecurringSilenceSilenceID(_ context.Context, orgID int64, uid string, silenceID string) error {
+	for _, r := range f.recurring {
+		if r.OrgID == orgID && r.UID == uid {
+			r.SilenceID = silenceID
+		}
+	}
+	return nil
+}
+
+func (f *fakeSilenceScheduleStore) ListSilenceExpiries(_ context.Context) ([]models.SilenceExpiry, error) {
+	return append([]models.SilenceExpiry(nil), f.expiries...), nil
+}
+
+func (f *fakeSilenceScheduleStore) SaveSilenceExpiry(_ context.Context, e models.SilenceExpiry) error {
+	for i := range f.expiries {
+		if f.expiries[i].OrgID == e.OrgID && f.expiries[i].SilenceID == e.SilenceID {
+			f.expiries[i] = e
+			return nil
+		}
+	}
+	f.expiries = append(f.expiries, e)
+	return nil
+}
+
+func (f *fakeSilenceScheduleStore) DeleteSilenceExpiry(_ context.Context, orgID int64, silenceID string) error {
+	for i := range f.expiries {
+		if f.expiries[i].OrgID == orgID && f.expiries[i].SilenceID == silenceID {
+			f.expiries = append(f.expiries[:i], f.expiries[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+type fakeSilenceStore struct {
+	silences map[string]*models.Silence
+}
+
+func (f *fakeSilenceStore) ListSilences(_ context.Context, _ int64, _ []string) ([]*models.Silence, error) {
+	return nil, nil
+}
+
+func (f *fakeSilenceStore) GetSilence(_ context.Context, _ int64, id string) (*models.Silence, error) {
+	s, ok := f.silences[id]
+	if !ok {
+		return nil, ErrSilenceNotFound.Errorf("")
+	}
+	return s, nil
+}
+
+func (f *fakeSilenceStore) CreateSilence(_ context.Context, _ int64, ps models.Silence) (string, error) {
+	id := util.GenerateShortUID()
+	f.silences[id] = &ps
+	return id, nil
+}
+
+func (f *fakeSilenceStore) UpdateSilence(_ context.Context, _ int64, ps models.Silence) (string, error) {
+	return *ps.ID, nil
+}
+
+func (f *fakeSilenceStore) DeleteSilence(_ context.Context, _ int64, id string) error {
+	delete(f.silences, id)
+	return nil
+}
+
+type fakeAlertsAlertmanager struct {
+	Alertmanager
+	alerts definitions.GettableAlerts
+}
+
+func (f *fakeAlertsAlertmanager) GetAlerts(_ context.Context, _, _, _ bool, _ []string, _ string) (definitions.GettableAlerts, error) {
+	return f.alerts, nil
+}
+
+type fakeAlertmanagerProvider struct {
+	am Alertmanager
+}
+
+func (f fakeAlertmanagerProvider) AlertmanagerFor(_ int64) (Alertmanager, error) {
+	return f.am, nil
+}
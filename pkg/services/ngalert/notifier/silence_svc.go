@@ -14,12 +14,13 @@ import (
 
 // SilenceService is the authenticated service for managing alertmanager silences.
 type SilenceService struct {
-	authz     SilenceAccessControlService
-	xact      transactionManager
-	log       log.Logger
-	store     SilenceStore
-	ruleStore RuleStore
-	ruleAuthz RuleAccessControlService
+	authz       SilenceAccessControlService
+	xact        transactionManager
+	log         log.Logger
+	store       SilenceStore
+	ruleStore   RuleStore
+	ruleAuthz   RuleAccessControlService
+	expiryStore SilenceExpiryStore
 }
 
 type RuleAccessControlService interface {
@@ -56,14 +57,16 @@ func NewSilenceService(
 	store SilenceStore,
 	ruleStore RuleStore,
 	ruleAuthz RuleAccessControlService,
+	expiryStore SilenceExpiryStore,
 ) *SilenceService {
 	return &SilenceService{
-		authz:     authz,
-		xact:      xact,
-		log:       log,
-		store:     store,
-		ruleStore: ruleStore,
-		ruleAuthz: ruleAuthz,
+		authz:       authz,
+		xact:        xact,
+		log:         log,
+		store:       store,
+		ruleStore:   ruleStore,
+		ruleAuthz:   ruleAuthz,
+		expiryStore: expiryStore,
 	}
 }
 
@@ -154,6 +157,27 @@ func (s *SilenceService) DeleteSilence(ctx context.Context, user identity.Reques
 	return nil
 }
 
+// AuthorizeExpireOnResolve checks that the user can make the silence expire on resolve, which requires permission to
+// update the silence. Callers that create a silence and make it expire on resolve should check it before creating it.
+func (s *SilenceService) AuthorizeExpireOnResolve(ctx context.Context, user identity.Requester, ps *models.Silence) error {
+	return s.authz.AuthorizeUpdateSilence(ctx, user, ps)
+}
+
+// ExpireOnResolve makes the silence expire once it has silenced at least one alert and all alerts it silenced have resolved.
+// The user needs permission to update the silence.
+func (s *SilenceService) ExpireOnResolve(ctx context.Context, user identity.Requester, silenceID string) error {
+	silence, err := s.GetSilence(ctx, user, silenceID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.AuthorizeExpireOnResolve(ctx, user, silence); err != nil {
+		return err
+	}
+
+	return s.expiryStore.SaveSilenceExpiry(ctx, models.SilenceExpiry{OrgID: user.GetOrgID(), SilenceID: silenceID})
+}
+
 // WithAccessControlMetadata adds access control metadata to the given SilenceWithMetadata.
 func (s *SilenceService) WithAccessControlMetadata(ctx context.Context, user identity.Requester, silencesWithMetadata ...*models.SilenceWithMetadata) error {
 	silences := make([]*models.Silence, 0, len(silencesWithMetadata))
@@ -235,6 +235,10 @@ func (n NoValidation) Validate(_ models.NotificationSettings) error {
 	return nil
 }
 
+func (n NoValidation) ValidateActiveTimeIntervals(_ []string) error {
+	return nil
+}
+
 var errInvalidState = fmt.Errorf("invalid state")
 
 // silenceState copied from state in prometheus-alertmanager/silence/silence.go.
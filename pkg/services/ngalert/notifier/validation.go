@@ -36,6 +36,8 @@ func (e ErrorTimeIntervalDoesNotExist) Error() string {
 // NotificationSettingsValidator validates NotificationSettings against the current Alertmanager configuration
 type NotificationSettingsValidator interface {
 	Validate(s models.NotificationSettings) error
+	// ValidateActiveTimeIntervals checks that the active time intervals of an alert rule exist.
+	ValidateActiveTimeIntervals(intervals []string) error
 }
 
 // staticValidator is a NotificationSettingsValidator that uses static pre-fetched values for available receivers and mute timings.
@@ -94,6 +96,17 @@ func (n staticValidator) Validate(settings models.NotificationSettings) error {
 	return errors.Join(errs...)
 }
 
+// ValidateActiveTimeIntervals checks that the active time intervals of an alert rule reference existing time intervals.
+func (n staticValidator) ValidateActiveTimeIntervals(intervals []string) error {
+	var errs []error
+	for _, interval := range intervals {
+		if _, ok := n.availableTimeIntervals[interval]; !ok {
+			errs = append(errs, ErrorTimeIntervalDoesNotExist{ErrorReferenceInvalid: ErrorReferenceInvalid{Reference: interval}})
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // NotificationSettingsValidatorProvider provides a NotificationSettingsValidator for a given orgID.
 type NotificationSettingsValidatorProvider interface {
 	Validator(ctx context.Context, orgID int64) (NotificationSettingsValidator, error)
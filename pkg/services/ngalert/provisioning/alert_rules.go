@@ -220,7 +220,7 @@ func (service *AlertRuleService) CreateAlertRule(ctx context.Context, user ident
 		return models.AlertRule{}, err
 	}
 	rule.Updated = time.Now()
-	if len(rule.NotificationSettings) > 0 {
+	if len(rule.NotificationSettings) > 0 || len(rule.ActiveTimeIntervals) > 0 {
 		validator, err := service.nsValidatorProvider.Validator(ctx, rule.OrgID)
 		if err != nil {
 			return models.AlertRule{}, err
@@ -230,6 +230,9 @@ func (service *AlertRuleService) CreateAlertRule(ctx context.Context, user ident
 				return models.AlertRule{}, err
 			}
 		}
+		if err := validator.ValidateActiveTimeIntervals(rule.ActiveTimeIntervals); err != nil {
+			return models.AlertRule{}, errors.Join(models.ErrAlertRuleFailedValidation, err)
+		}
 	}
 	err = service.xact.InTransaction(ctx, func(ctx context.Context) error {
 		ids, err := service.ruleStore.InsertAlertRules(ctx, []models.AlertRule{
@@ -390,7 +393,8 @@ func (service *AlertRuleService) ReplaceRuleGroup(ctx context.Context, user iden
 	}
 
 	newOrUpdatedNotificationSettings := delta.NewOrUpdatedNotificationSettings()
-	if len(newOrUpdatedNotificationSettings) > 0 {
+	newOrUpdatedActiveTimeIntervals := delta.NewOrUpdatedActiveTimeIntervals()
+	if len(newOrUpdatedNotificationSettings) > 0 || len(newOrUpdatedActiveTimeIntervals) > 0 {
 		validator, err := service.nsValidatorProvider.Validator(ctx, delta.GroupKey.OrgID)
 		if err != nil {
 			return err
@@ -400,6 +404,9 @@ func (service *AlertRuleService) ReplaceRuleGroup(ctx context.Context, user iden
 				return errors.Join(models.ErrAlertRuleFailedValidation, err)
 			}
 		}
+		if err := validator.ValidateActiveTimeIntervals(newOrUpdatedActiveTimeIntervals); err != nil {
+			return errors.Join(models.ErrAlertRuleFailedValidation, err)
+		}
 	}
 
 	return service.persistDelta(ctx, user, delta, provenance)
@@ -587,7 +594,7 @@ func (service *AlertRuleService) UpdateAlertRule(ctx context.Context, user ident
 	if storedProvenance != provenance && storedProvenance != models.ProvenanceNone {
 		return models.AlertRule{}, fmt.Errorf("cannot change provenance from '%s' to '%s'", storedProvenance, provenance)
 	}
-	if len(rule.NotificationSettings) > 0 {
+	if len(rule.NotificationSettings) > 0 || len(rule.ActiveTimeIntervals) > 0 {
 		validator, err := service.nsValidatorProvider.Validator(ctx, rule.OrgID)
 		if err != nil {
 			return models.AlertRule{}, err
@@ -597,6 +604,9 @@ func (service *AlertRuleService) UpdateAlertRule(ctx context.Context, user ident
 				return models.AlertRule{}, err
 			}
 		}
+		if err := validator.ValidateActiveTimeIntervals(rule.ActiveTimeIntervals); err != nil {
+			return models.AlertRule{}, errors.Join(models.ErrAlertRuleFailedValidation, err)
+		}
 	}
 	rule.Updated = time.Now()
 	rule.ID = storedRule.ID
@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/alertmanager/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -31,7 +32,9 @@ import (
 	"github.com/grafana/grafana/pkg/services/folder"
 	"github.com/grafana/grafana/pkg/services/folder/folderimpl"
 	"github.com/grafana/grafana/pkg/services/folder/foldertest"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
 	"github.com/grafana/grafana/pkg/services/ngalert/testutil"
 	"github.com/grafana/grafana/pkg/setting"
@@ -867,6 +870,25 @@ func TestCreateAlertRule(t *testing.T) {
 			require.NoError(t, err)
 		})
 	})
+
+	t.Run("should fail if active time intervals do not exist", func(t *testing.T) {
+		service, ruleStore, _, ac := initServiceWithData(t)
+		service.nsValidatorProvider = timeIntervalsValidatorProvider("mondays")
+		ac.CanWriteAllRulesFunc = func(ctx context.Context, user identity.Requester) (bool, error) {
+			return true, nil
+		}
+
+		rule := gen.With(gen.WithOrgID(orgID), gen.WithNoNotificationSettings(), gen.WithActiveTimeIntervals("mondays", "fridays")).Generate()
+		_, err := service.CreateAlertRule(context.Background(), u, rule, models.ProvenanceAPI)
+		require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+		require.ErrorContains(t, err, "fridays")
+
+		inserts := ruleStore.GetRecordedCommands(func(cmd any) (any, bool) {
+			a, ok := cmd.([]models.AlertRule)
+			return a, ok
+		})
+		require.Empty(t, inserts)
+	})
 }
 
 func TestUpdateAlertRule(t *testing.T) {
@@ -1449,6 +1471,30 @@ func TestReplaceGroup(t *testing.T) {
 			require.Len(t, updates, 1)
 		})
 	})
+
+	t.Run("should fail if active time intervals of changed rules do not exist", func(t *testing.T) {
+		group := models.AlertRuleGroup{
+			Title:      groupKey.RuleGroup,
+			FolderUID:  groupKey.NamespaceUID,
+			Interval:   groupIntervalSeconds,
+			Provenance: groupProvenance,
+		}
+		for _, rule := range rules {
+			r := models.CopyRule(rule)
+			r.ActiveTimeIntervals = []string{"fridays"}
+			group.Rules = append(group.Rules, *r)
+		}
+
+		service, _, _, ac := initServiceWithData(t)
+		service.nsValidatorProvider = timeIntervalsValidatorProvider("mondays")
+		ac.CanWriteAllRulesFunc = func(ctx context.Context, user identity.Requester) (bool, error) {
+			return true, nil
+		}
+
+		err := service.ReplaceRuleGroup(context.Background(), u, group, models.ProvenanceAPI)
+		require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+		require.ErrorContains(t, err, "fridays")
+	})
 }
 
 func TestDeleteRuleGroup(t *testing.T) {
@@ -1712,6 +1758,23 @@ func createDummyGroup(title string, orgID int64) models.AlertRuleGroup {
 	}
 }
 
+type staticValidatorProvider struct {
+	validator notifier.NotificationSettingsValidator
+}
+
+func (p staticValidatorProvider) Validator(_ context.Context, _ int64) (notifier.NotificationSettingsValidator, error) {
+	return p.validator, nil
+}
+
+// timeIntervalsValidatorProvider returns a validator provider for an Alertmanager configuration with the given time intervals.
+func timeIntervalsValidatorProvider(names ...string) staticValidatorProvider {
+	cfg := &definitions.PostableApiAlertingConfig{}
+	for _, name := range names {
+		cfg.MuteTimeIntervals = append(cfg.MuteTimeIntervals, config.MuteTimeInterval{Name: name})
+	}
+	return staticValidatorProvider{validator: notifier.NewNotificationSettingsValidator(cfg)}
+}
+
 func initService(t *testing.T) (*AlertRuleService, *fakes.RuleStore, *fakes.FakeProvisioningStore, *fakeRuleAccessControlService) {
 	t.Helper()
 
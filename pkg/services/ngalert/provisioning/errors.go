@@ -47,15 +47,18 @@ func MakeErrTimeIntervalInvalid(err error) error {
 	return ErrTimeIntervalInvalid.Build(data)
 }
 
-func MakeErrTimeIntervalInUse(usedByRoutes bool, rules []models.AlertRuleKey) error {
+func MakeErrTimeIntervalInUse(usedByRoutes bool, rules []models.AlertRuleKey, recurringSilences []string) error {
 	uids := make([]string, 0, len(rules))
 	for _, key := range rules {
 		uids = append(uids, key.UID)
 	}
-	data := make(map[string]any, 2)
+	data := make(map[string]any, 3)
 	if len(uids) > 0 {
 		data["UsedByRules"] = uids
 	}
+	if len(recurringSilences) > 0 {
+		data["UsedBySilences"] = recurringSilences
+	}
 	if usedByRoutes {
 		data["UsedByRoutes"] = true
 	}
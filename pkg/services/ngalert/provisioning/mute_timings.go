@@ -27,9 +27,16 @@ type MuteTimingService struct {
 	log                    log.Logger
 	validator              validation.ProvenanceStatusTransitionValidator
 	ruleNotificationsStore AlertRuleNotificationSettingsStore
+	recurringSilenceStore  RecurringSilenceTimeIntervalStore
 }
 
-func NewMuteTimingService(config alertmanagerConfigStore, prov ProvisioningStore, xact TransactionManager, log log.Logger, ns AlertRuleNotificationSettingsStore) *MuteTimingService {
+// RecurringSilenceTimeIntervalStore finds and renames the time intervals that recurring silences use as their windows.
+type RecurringSilenceTimeIntervalStore interface {
+	ListRecurringSilencesByTimeInterval(ctx context.Context, orgID int64, timeInterval string) ([]string, error)
+	RenameTimeIntervalInRecurringSilences(ctx context.Context, orgID int64, oldTimeInterval, newTimeInterval string) (int64, error)
+}
+
+func NewMuteTimingService(config alertmanagerConfigStore, prov ProvisioningStore, xact TransactionManager, log log.Logger, ns AlertRuleNotificationSettingsStore, silences RecurringSilenceTimeIntervalStore) *MuteTimingService {
 	return &MuteTimingService{
 		configStore:            config,
 		provenanceStore:        prov,
@@ -37,6 +44,7 @@ func NewMuteTimingService(config alertmanagerConfigStore, prov ProvisioningStore
 		log:                    log,
 		validator:              validation.ValidateProvenanceRelaxed,
 		ruleNotificationsStore: ns,
+		recurringSilenceStore:  silences,
 	}
 }
 
@@ -250,7 +258,9 @@ func (svc *MuteTimingService) DeleteMuteTiming(ctx context.Context, nameOrUID st
 	if isMuteTimeInUseInRoutes(existing.Name, revision.Config.AlertmanagerConfig.Route) {
 		ns, _ := svc.ruleNotificationsStore.ListNotificationSettings(ctx, models.ListNotificationSettingsQuery{OrgID: orgID, TimeIntervalName: existing.Name})
 		// ignore error here because it's not important
-		return MakeErrTimeIntervalInUse(true, maps.Keys(ns))
+		active, _ := svc.ruleNotificationsStore.ListRulesByActiveTimeInterval(ctx, orgID, existing.Name)
+		silences, _ := svc.recurringSilenceStore.ListRecurringSilencesByTimeInterval(ctx, orgID, existing.Name)
+		return MakeErrTimeIntervalInUse(true, append(maps.Keys(ns), active...), silences)
 	}
 
 	err = svc.checkOptimisticConcurrency(existing, models.Provenance(provenance), version, "delete")
@@ -264,8 +274,16 @@ func (svc *MuteTimingService) DeleteMuteTiming(ctx context.Context, nameOrUID st
 		if err != nil {
 			return err
 		}
-		if len(keys) > 0 {
-			return MakeErrTimeIntervalInUse(false, maps.Keys(keys))
+		active, err := svc.ruleNotificationsStore.ListRulesByActiveTimeInterval(ctx, orgID, existing.Name)
+		if err != nil {
+			return err
+		}
+		silences, err := svc.recurringSilenceStore.ListRecurringSilencesByTimeInterval(ctx, orgID, existing.Name)
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 || len(active) > 0 || len(silences) > 0 {
+			return MakeErrTimeIntervalInUse(false, append(maps.Keys(keys), active...), silences)
 		}
 
 		if err := svc.configStore.Save(ctx, revision, orgID); err != nil {
@@ -423,8 +441,13 @@ func (svc *MuteTimingService) renameTimeIntervalInDependentResources(ctx context
 	if !canUpdate || len(invalidProvenance) > 0 {
 		return MakeErrTimeIntervalDependentResourcesProvenance(updatedRoutes > 0, invalidProvenance)
 	}
-	if len(affected) > 0 || updatedRoutes > 0 {
-		svc.log.FromContext(ctx).Info("Updated rules and routes that use renamed time interval", "oldName", oldName, "newName", newName, "rules", len(affected), "routes", updatedRoutes)
+	// recurring silences are not provisioned, so they are always renamed together with the time interval.
+	silences, err := svc.recurringSilenceStore.RenameTimeIntervalInRecurringSilences(ctx, orgID, oldName, newName)
+	if err != nil {
+		return err
+	}
+	if len(affected) > 0 || updatedRoutes > 0 || silences > 0 {
+		svc.log.FromContext(ctx).Info("Updated rules, routes and recurring silences that use renamed time interval", "oldName", oldName, "newName", newName, "rules", len(affected), "routes", updatedRoutes, "recurringSilences", silences)
 	}
 	return nil
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
@@ -722,6 +723,13 @@ func TestUpdateMuteTimings(t *testing.T) {
 			},
 		}
 		sut.ruleNotificationsStore = ruleStore
+		silenceStore := &fakeRecurringSilenceStore{
+			RenameTimeIntervalInRecurringSilencesFn: func(ctx context.Context, orgID int64, old, new string) (int64, error) {
+				assertInTransaction(t, ctx)
+				return 1, nil
+			},
+		}
+		sut.recurringSilenceStore = silenceStore
 
 		interval := expected
 		interval.Name = "another-time-interval"
@@ -748,6 +756,12 @@ func TestUpdateMuteTimings(t *testing.T) {
 		assert.NotNil(t, ruleStore.Calls[0].Args[4])
 		assert.False(t, ruleStore.Calls[0].Args[5].(bool))
 
+		require.Len(t, silenceStore.Calls, 1)
+		assert.Equal(t, "RenameTimeIntervalInRecurringSilences", silenceStore.Calls[0].Method)
+		assert.Equal(t, orgID, silenceStore.Calls[0].Args[1])
+		assert.Equal(t, original.Name, silenceStore.Calls[0].Args[2])
+		assert.Equal(t, interval.Name, silenceStore.Calls[0].Args[3])
+
 		prov.AssertCalled(t, "SetProvenance", mock.Anything, mock.MatchedBy(func(m *definitions.MuteTimeInterval) bool {
 			return m.Name == interval.Name
 		}), orgID, expectedProvenance)
@@ -1023,8 +1037,59 @@ func TestDeleteMuteTimings(t *testing.T) {
 		require.Equal(t, "Get", store.Calls[0].Method)
 		require.Equal(t, orgID, store.Calls[0].Args[1])
 		require.ErrorIs(t, err, ErrTimeIntervalInUse)
-		require.Len(t, ruleNsStore.Calls, 1)
+		require.Len(t, ruleNsStore.Calls, 2)
 		require.Equal(t, "ListNotificationSettings", ruleNsStore.Calls[0].Method)
+		require.Equal(t, "ListRulesByActiveTimeInterval", ruleNsStore.Calls[1].Method)
+	})
+
+	t.Run("returns ErrTimeIntervalInUse if mute timing is an active time interval of rules", func(t *testing.T) {
+		sut, store, prov := createMuteTimingSvcSut()
+		ruleKey := models.GenerateRuleKey(orgID)
+		ruleNsStore := fakeAlertRuleNotificationStore{
+			ListRulesByActiveTimeIntervalFn: func(ctx context.Context, o int64, timeInterval string) ([]models.AlertRuleKey, error) {
+				assertInTransaction(t, ctx)
+				assert.Equal(t, orgID, o)
+				assert.Equal(t, timingToDelete.Name, timeInterval)
+				return []models.AlertRuleKey{ruleKey}, nil
+			},
+		}
+		sut.ruleNotificationsStore = &ruleNsStore
+		store.GetFn = func(ctx context.Context, orgID int64) (*legacy_storage.ConfigRevision, error) {
+			return &legacy_storage.ConfigRevision{Config: initialConfig()}, nil
+		}
+		prov.EXPECT().GetProvenance(mock.Anything, mock.Anything, mock.Anything).Return(models.ProvenanceAPI, nil)
+
+		err := sut.DeleteMuteTiming(context.Background(), timingToDelete.Name, orgID, definitions.Provenance(models.ProvenanceAPI), correctVersion)
+
+		require.ErrorIs(t, err, ErrTimeIntervalInUse)
+		require.Len(t, store.Calls, 1, "the configuration should not be saved")
+		var errUsed errutil.Error
+		require.ErrorAs(t, err, &errUsed)
+		require.Equal(t, []string{ruleKey.UID}, errUsed.PublicPayload["UsedByRules"])
+	})
+
+	t.Run("returns ErrTimeIntervalInUse if mute timing is used by recurring silences", func(t *testing.T) {
+		sut, store, prov := createMuteTimingSvcSut()
+		sut.recurringSilenceStore = &fakeRecurringSilenceStore{
+			ListRecurringSilencesByTimeIntervalFn: func(ctx context.Context, o int64, timeInterval string) ([]string, error) {
+				assertInTransaction(t, ctx)
+				assert.Equal(t, orgID, o)
+				assert.Equal(t, timingToDelete.Name, timeInterval)
+				return []string{"maintenance"}, nil
+			},
+		}
+		store.GetFn = func(ctx context.Context, orgID int64) (*legacy_storage.ConfigRevision, error) {
+			return &legacy_storage.ConfigRevision{Config: initialConfig()}, nil
+		}
+		prov.EXPECT().GetProvenance(mock.Anything, mock.Anything, mock.Anything).Return(models.ProvenanceAPI, nil)
+
+		err := sut.DeleteMuteTiming(context.Background(), timingToDelete.Name, orgID, definitions.Provenance(models.ProvenanceAPI), correctVersion)
+
+		require.ErrorIs(t, err, ErrTimeIntervalInUse)
+		require.Len(t, store.Calls, 1, "the configuration should not be saved")
+		var errUsed errutil.Error
+		require.ErrorAs(t, err, &errUsed)
+		require.Equal(t, []string{"maintenance"}, errUsed.PublicPayload["UsedBySilences"])
 	})
 
 	t.Run("returns ErrVersionConflict if provided version does not match", func(t *testing.T) {
@@ -1240,5 +1305,6 @@ func createMuteTimingSvcSut() (*MuteTimingService, *legacy_storage.AlertmanagerC
 			return nil
 		},
 		ruleNotificationsStore: &fakeAlertRuleNotificationStore{},
+		recurringSilenceStore:  &fakeRecurringSilenceStore{},
 	}, store, prov
 }
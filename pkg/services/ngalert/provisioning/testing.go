@@ -173,6 +173,7 @@ type fakeAlertRuleNotificationStore struct {
 	RenameReceiverInNotificationSettingsFn     func(ctx context.Context, orgID int64, oldReceiver, newReceiver string, validateProvenance func(models.Provenance) bool, dryRun bool) ([]models.AlertRuleKey, []models.AlertRuleKey, error)
 	RenameTimeIntervalInNotificationSettingsFn func(ctx context.Context, orgID int64, old, new string, validate func(models.Provenance) bool, dryRun bool) ([]models.AlertRuleKey, []models.AlertRuleKey, error)
 	ListNotificationSettingsFn                 func(ctx context.Context, q models.ListNotificationSettingsQuery) (map[models.AlertRuleKey][]models.NotificationSettings, error)
+	ListRulesByActiveTimeIntervalFn            func(ctx context.Context, orgID int64, timeInterval string) ([]models.AlertRuleKey, error)
 }
 
 func (f *fakeAlertRuleNotificationStore) RenameReceiverInNotificationSettings(ctx context.Context, orgID int64, oldReceiver, newReceiver string, validateProvenance func(models.Provenance) bool, dryRun bool) ([]models.AlertRuleKey, []models.AlertRuleKey, error) {
@@ -220,6 +221,44 @@ func (f *fakeAlertRuleNotificationStore) ListNotificationSettings(ctx context.Co
 	return nil, nil
 }
 
+func (f *fakeAlertRuleNotificationStore) ListRulesByActiveTimeInterval(ctx context.Context, orgID int64, timeInterval string) ([]models.AlertRuleKey, error) {
+	call := call{
+		Method: "ListRulesByActiveTimeInterval",
+		Args:   []interface{}{ctx, orgID, timeInterval},
+	}
+	f.Calls = append(f.Calls, call)
+
+	if f.ListRulesByActiveTimeIntervalFn != nil {
+		return f.ListRulesByActiveTimeIntervalFn(ctx, orgID, timeInterval)
+	}
+
+	// Default values when no function hook is provided
+	return nil, nil
+}
+
+type fakeRecurringSilenceStore struct {
+	Calls []call
+
+	ListRecurringSilencesByTimeIntervalFn   func(ctx context.Context, orgID int64, timeInterval string) ([]string, error)
+	RenameTimeIntervalInRecurringSilencesFn func(ctx context.Context, orgID int64, oldTimeInterval, newTimeInterval string) (int64, error)
+}
+
+func (f *fakeRecurringSilenceStore) ListRecurringSilencesByTimeInterval(ctx context.Context, orgID int64, timeInterval string) ([]string, error) {
+	f.Calls = append(f.Calls, call{Method: "ListRecurringSilencesByTimeInterval", Args: []interface{}{ctx, orgID, timeInterval}})
+	if f.ListRecurringSilencesByTimeIntervalFn != nil {
+		return f.ListRecurringSilencesByTimeIntervalFn(ctx, orgID, timeInterval)
+	}
+	return nil, nil
+}
+
+func (f *fakeRecurringSilenceStore) RenameTimeIntervalInRecurringSilences(ctx context.Context, orgID int64, oldTimeInterval, newTimeInterval string) (int64, error) {
+	f.Calls = append(f.Calls, call{Method: "RenameTimeIntervalInRecurringSilences", Args: []interface{}{ctx, orgID, oldTimeInterval, newTimeInterval}})
+	if f.RenameTimeIntervalInRecurringSilencesFn != nil {
+		return f.RenameTimeIntervalInRecurringSilencesFn(ctx, orgID, oldTimeInterval, newTimeInterval)
+	}
+	return 0, nil
+}
+
 type fakeReceiverService struct {
 	Calls                                  []call
 	GetReceiversFunc                       func(ctx context.Context, query models.GetReceiversQuery, user identity.Requester) ([]*models.Receiver, error)
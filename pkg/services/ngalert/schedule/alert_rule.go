@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/benbjohnson/clock"
@@ -13,6 +15,7 @@ import (
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/grafana/grafana/pkg/expr"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/infra/tracing"
 	"github.com/grafana/grafana/pkg/services/datasources"
@@ -61,6 +64,7 @@ func newRuleFactory(
 	clock clock.Clock,
 	rrCfg setting.RecordingRuleSettings,
 	met *metrics.Scheduler,
+	costs evaluationCostAccounting,
 	logger log.Logger,
 	tracer tracing.Tracer,
 	recordingWriter RecordingWriter,
@@ -78,6 +82,7 @@ func newRuleFactory(
 				rrCfg,
 				logger,
 				met,
+				costs.newRuleCost(rule.GetKey()),
 				tracer,
 				recordingWriter,
 				evalAppliedHook,
@@ -96,6 +101,7 @@ func newRuleFactory(
 			ruleProvider,
 			clock,
 			met,
+			costs.newRuleCost(rule.GetKey()),
 			logger,
 			tracer,
 			evalAppliedHook,
@@ -111,6 +117,44 @@ type ruleProvider interface {
 	get(ngmodels.AlertRuleKey) *ngmodels.AlertRule
 }
 
+// TimeIntervalProvider provides the time intervals defined in the Alertmanager configuration of an organization.
+type TimeIntervalProvider interface {
+	GetMuteTimings(ctx context.Context, orgID int64) ([]definitions.MuteTimeInterval, error)
+}
+
+// tickTimeIntervals resolves the time intervals of each organization at most once per tick,
+// and shares them between the evaluations of the tick.
+type tickTimeIntervals struct {
+	// ctx is the context of the scheduler, so that a rule that stops does not fail the resolution for other rules.
+	ctx      context.Context
+	provider TimeIntervalProvider
+
+	mtx   sync.Mutex
+	byOrg map[int64]func() ([]definitions.MuteTimeInterval, error)
+}
+
+func newTickTimeIntervals(ctx context.Context, provider TimeIntervalProvider) *tickTimeIntervals {
+	return &tickTimeIntervals{
+		ctx:      ctx,
+		provider: provider,
+		byOrg:    make(map[int64]func() ([]definitions.MuteTimeInterval, error)),
+	}
+}
+
+// get returns the time intervals of the organization. Only the first call for an organization reaches the provider.
+func (t *tickTimeIntervals) get(orgID int64) ([]definitions.MuteTimeInterval, error) {
+	t.mtx.Lock()
+	resolve, ok := t.byOrg[orgID]
+	if !ok {
+		resolve = sync.OnceValues(func() ([]definitions.MuteTimeInterval, error) {
+			return t.provider.GetMuteTimings(t.ctx, orgID)
+		})
+		t.byOrg[orgID] = resolve
+	}
+	t.mtx.Unlock()
+	return resolve()
+}
+
 type alertRule struct {
 	key ngmodels.AlertRuleKeyWithGroup
 
@@ -134,6 +178,7 @@ type alertRule struct {
 	stopAppliedHook stopAppliedFunc
 
 	metrics *metrics.Scheduler
+	cost    *ruleCost
 	logger  log.Logger
 	tracer  tracing.Tracer
 }
@@ -150,6 +195,7 @@ func newAlertRule(
 	ruleProvider ruleProvider,
 	clock clock.Clock,
 	met *metrics.Scheduler,
+	cost *ruleCost,
 	logger log.Logger,
 	tracer tracing.Tracer,
 	evalAppliedHook func(ngmodels.AlertRuleKey, time.Time),
@@ -173,6 +219,7 @@ func newAlertRule(
 		evalAppliedHook:      evalAppliedHook,
 		stopAppliedHook:      stopAppliedHook,
 		metrics:              met,
+		cost:                 cost,
 		logger:               logger.FromContext(ctx),
 		tracer:               tracer,
 	}
@@ -183,7 +230,9 @@ func (a *alertRule) Type() ngmodels.RuleType {
 }
 
 func (a *alertRule) Status() ngmodels.RuleStatus {
-	return a.stateManager.GetStatusForRuleUID(a.key.OrgID, a.key.UID)
+	status := a.stateManager.GetStatusForRuleUID(a.key.OrgID, a.key.UID)
+	status.Cost = a.cost.get()
+	return status
 }
 
 // eval signals the rule evaluation routine to perform the evaluation of the rule. Does nothing if the loop is stopped.
@@ -203,6 +252,7 @@ func (a *alertRule) Eval(eval *Evaluation) (bool, *Evaluation) {
 	var droppedMsg *Evaluation
 	select {
 	case droppedMsg = <-a.evalCh:
+		a.cost.missed()
 	default:
 	}
 
@@ -244,7 +294,10 @@ func (a *alertRule) Run() error {
 	a.logger.Debug("Alert rule routine started")
 
 	var currentFingerprint fingerprint
+	// pausedBySchedule is true when the state of the rule was reset because the rule is outside of its active time intervals.
+	var pausedBySchedule bool
 	defer a.stopApplied()
+	defer a.cost.release()
 	for {
 		select {
 		// used by external services (API) to notify that rule is updated.
@@ -258,6 +311,7 @@ func (a *alertRule) Run() error {
 			// clear the state. So the next evaluation will start from the scratch.
 			a.resetState(grafanaCtx, ctx.IsPaused)
 			currentFingerprint = ctx.Fingerprint
+			pausedBySchedule = false
 		// evalCh - used by the scheduler to signal that evaluation is needed.
 		case ctx, ok := <-a.evalCh:
 			if !ok {
@@ -275,8 +329,13 @@ func (a *alertRule) Run() error {
 
 				evalStart := a.clock.Now()
 				defer func() {
-					evalDuration.Observe(a.clock.Now().Sub(evalStart).Seconds())
+					end := a.clock.Now()
+					evalDuration.Observe(end.Sub(evalStart).Seconds())
+					if end.Sub(ctx.scheduledAt) >= time.Duration(ctx.rule.IntervalSeconds)*time.Second {
+						a.cost.late()
+					}
 					a.evalApplied(ctx.scheduledAt)
+					ctx.markDone()
 				}()
 
 				for attempt := int64(1); attempt <= a.maxAttempts; attempt++ {
@@ -294,6 +353,7 @@ func (a *alertRule) Run() error {
 					needReset = needReset || (currentFingerprint == 0 && isPaused)
 					if needReset {
 						a.resetState(grafanaCtx, isPaused)
+						pausedBySchedule = false
 					}
 					currentFingerprint = f
 					if isPaused {
@@ -301,6 +361,26 @@ func (a *alertRule) Run() error {
 						return
 					}
 
+					if !a.isActive(ctx, logger) {
+						// Reset the state only once when the rule leaves its active time intervals.
+						// This way, the state does not go to NoData or Error while the rule is not evaluated.
+						if !pausedBySchedule {
+							logger.Debug("Clearing the state of the rule because it is outside of its active time intervals")
+							a.resetStateWithReason(grafanaCtx, ctx.rule, ngmodels.StateReasonPausedBySchedule)
+							pausedBySchedule = true
+						}
+						logger.Debug("Skip rule evaluation because it is outside of its active time intervals")
+						return
+					}
+					pausedBySchedule = false
+
+					if err := waitForEvaluations(grafanaCtx, ctx.after, time.Duration(ctx.rule.IntervalSeconds)*time.Second); errors.Is(err, errWaitTimeout) {
+						logger.Warn("Timed out waiting for recording rules of the group to be evaluated")
+					}
+
+					// The rule is still evaluated while suppressed, so that its state stays current. Only notifications are skipped.
+					suppressed := a.isSuppressed(grafanaCtx, ctx, logger)
+
 					// Only increment evaluation counter once, not per-retry.
 					if attempt == 1 {
 						evalTotal.Inc()
@@ -324,7 +404,7 @@ func (a *alertRule) Run() error {
 						return
 					}
 					retry := attempt < a.maxAttempts
-					err := a.evaluate(tracingCtx, ctx, span, retry, logger)
+					err := a.evaluate(tracingCtx, ctx, span, retry, suppressed, logger)
 					// This is extremely confusing - when we exhaust all retry attempts, or we have no retryable errors
 					// we return nil - so technically, this is meaningless to know whether the evaluation has errors or not.
 					span.End()
@@ -355,13 +435,21 @@ func (a *alertRule) Run() error {
 				states := a.stateManager.DeleteStateByRuleUID(ngmodels.WithRuleKey(ctx, a.key.AlertRuleKey), a.key, ngmodels.StateReasonRuleDeleted)
 				a.expireAndSend(grafanaCtx, states)
 			}
+			// hand the state over to the instance the rule is assigned to
+			if errors.Is(grafanaCtx.Err(), errRuleReleased) {
+				ctx, cancelFunc := context.WithTimeout(context.Background(), time.Minute)
+				defer cancelFunc()
+				a.stateManager.ReleaseStateForRule(ngmodels.WithRuleKey(ctx, a.key.AlertRuleKey), a.key)
+			}
 			a.logger.Debug("Stopping alert rule routine")
 			return nil
 		}
 	}
 }
 
-func (a *alertRule) evaluate(ctx context.Context, e *Evaluation, span trace.Span, retry bool, logger log.Logger) error {
+// evaluate evaluates the rule and updates its state. When suppressed is true, the firing and pending states are marked
+// as suppressed and are not sent to the notifier.
+func (a *alertRule) evaluate(ctx context.Context, e *Evaluation, span trace.Span, retry bool, suppressed bool, logger log.Logger) error {
 	orgID := fmt.Sprint(a.key.OrgID)
 	evalAttemptTotal := a.metrics.EvalAttemptTotal.WithLabelValues(orgID)
 	evalAttemptFailures := a.metrics.EvalAttemptFailures.WithLabelValues(orgID)
@@ -375,16 +463,18 @@ func (a *alertRule) evaluate(ctx context.Context, e *Evaluation, span trace.Span
 	ruleEval, err := a.evalFactory.Create(evalCtx, e.rule.GetEvalCondition().WithSource("scheduler").WithFolder(e.folderTitle))
 	var results eval.Results
 	var dur time.Duration
+	var stats expr.ExecutionStats
 	if err != nil {
 		dur = a.clock.Now().Sub(start)
 		logger.Error("Failed to build rule evaluator", "error", err)
 	} else {
-		results, err = ruleEval.Evaluate(ctx, e.scheduledAt)
+		results, err = ruleEval.Evaluate(expr.WithExecutionStats(ctx, &stats), e.scheduledAt)
 		dur = a.clock.Now().Sub(start)
 		if err != nil {
 			logger.Error("Failed to evaluate rule", "error", err, "duration", dur)
 		}
 	}
+	a.cost.observe(dur, stats, len(results))
 
 	evalAttemptTotal.Inc()
 
@@ -438,6 +528,18 @@ func (a *alertRule) evaluate(ctx context.Context, e *Evaluation, span trace.Span
 		))
 	}
 	start = a.clock.Now()
+	if suppressed {
+		logger.Debug("Skip sending alerts because one of the upstream rules is firing")
+		_ = a.stateManager.ProcessSuppressedEvalResults(
+			ctx,
+			e.scheduledAt,
+			e.rule,
+			results,
+			state.GetRuleExtraLabels(logger, e.rule, e.folderTitle, !a.disableGrafanaFolder),
+		)
+		processDuration.Observe(a.clock.Now().Sub(start).Seconds())
+		return nil
+	}
 	_ = a.stateManager.ProcessEvalResults(
 		ctx,
 		e.scheduledAt,
@@ -481,15 +583,85 @@ func (a *alertRule) expireAndSend(ctx context.Context, states []state.StateTrans
 }
 
 func (a *alertRule) resetState(ctx context.Context, isPaused bool) {
-	rule := a.ruleProvider.get(a.key.AlertRuleKey)
 	reason := ngmodels.StateReasonUpdated
 	if isPaused {
 		reason = ngmodels.StateReasonPaused
 	}
+	a.resetStateWithReason(ctx, a.ruleProvider.get(a.key.AlertRuleKey), reason)
+}
+
+func (a *alertRule) resetStateWithReason(ctx context.Context, rule *ngmodels.AlertRule, reason string) {
 	states := a.stateManager.ResetStateByRuleUID(ctx, rule, reason)
 	a.expireAndSend(ctx, states)
 }
 
+// isActive returns false if the rule has active time intervals and none of them contains the scheduled time of the evaluation.
+// If the time intervals cannot be resolved, the rule is considered active so that it is not silently skipped.
+func (a *alertRule) isActive(e *Evaluation, logger log.Logger) bool {
+	rule := e.rule
+	if len(rule.ActiveTimeIntervals) == 0 || e.timeIntervals == nil {
+		return true
+	}
+	now := e.scheduledAt
+	intervals, err := e.timeIntervals.get(rule.OrgID)
+	if err != nil {
+		logger.Warn("Failed to get time intervals. Evaluating the rule", "error", err)
+		return true
+	}
+	found := false
+	for _, interval := range intervals {
+		if !slices.Contains(rule.ActiveTimeIntervals, interval.Name) {
+			continue
+		}
+		found = true
+		for _, ti := range interval.TimeIntervals {
+			if ti.ContainsTime(now) {
+				return true
+			}
+		}
+	}
+	if !found {
+		logger.Warn("None of the active time intervals of the rule exist. Evaluating the rule", "activeTimeIntervals", rule.ActiveTimeIntervals)
+		return true
+	}
+	return false
+}
+
+// isSuppressed waits for the evaluations of upstream rules that are scheduled at the same tick,
+// and returns true if any of the upstream rules is firing. The state of upstream rules evaluated by other instances
+// is read from the instance store, as saved by their last evaluation.
+func (a *alertRule) isSuppressed(ctx context.Context, e *Evaluation, logger log.Logger) bool {
+	if len(e.upstream) == 0 && len(e.remoteUpstream) == 0 {
+		return false
+	}
+	if err := waitForEvaluations(ctx, e.waitFor, time.Duration(e.rule.IntervalSeconds)*time.Second); err != nil {
+		if ctx.Err() != nil {
+			return false
+		}
+		logger.Warn("Timed out waiting for upstream rules to be evaluated")
+	}
+	for _, key := range e.upstream {
+		for _, s := range a.stateManager.GetStatesForRuleUID(key.OrgID, key.UID) {
+			if s.State == eval.Alerting {
+				logger.Debug("Upstream rule is firing", "upstreamRuleUID", key.UID)
+				return true
+			}
+		}
+	}
+	for _, key := range e.remoteUpstream {
+		firing, err := a.stateManager.HasStoredFiringAlerts(ctx, key)
+		if err != nil {
+			logger.Warn("Failed to read the state of an upstream rule evaluated by another instance", "upstreamRuleUID", key.UID, "error", err)
+			continue
+		}
+		if firing {
+			logger.Debug("Upstream rule evaluated by another instance is firing", "upstreamRuleUID", key.UID)
+			return true
+		}
+	}
+	return false
+}
+
 // evalApplied is only used on tests.
 func (a *alertRule) evalApplied(now time.Time) {
 	if a.evalAppliedHook == nil {
@@ -12,6 +12,8 @@ import (
 	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/timeinterval"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	prometheusModel "github.com/prometheus/common/model"
@@ -267,7 +269,7 @@ func TestAlertRule(t *testing.T) {
 }
 
 func blankRuleForTests(ctx context.Context, key models.AlertRuleKeyWithGroup) *alertRule {
-	return newAlertRule(ctx, key, nil, false, 0, nil, nil, nil, nil, nil, nil, log.NewNopLogger(), nil, nil, nil)
+	return newAlertRule(ctx, key, nil, false, 0, nil, nil, nil, nil, nil, nil, nil, log.NewNopLogger(), nil, nil, nil)
 }
 
 func TestRuleRoutine(t *testing.T) {
@@ -378,6 +380,9 @@ func TestRuleRoutine(t *testing.T) {
 				require.Nil(t, status.LastError)
 				require.Equal(t, states[0].LastEvaluationTime, status.EvaluationTimestamp)
 				require.Equal(t, states[0].EvaluationDuration, status.EvaluationDuration)
+				require.NotNil(t, status.Cost)
+				require.Equal(t, 1, status.Cost.Series)
+				require.Zero(t, status.Cost.MissedEvaluations)
 			})
 
 			t.Run("it reports metrics", func(t *testing.T) {
@@ -844,10 +849,148 @@ func TestRuleRoutine(t *testing.T) {
 			sender.AssertNumberOfCalls(t, "Send", calls)
 		}
 	})
+
+	t.Run("when rule is outside of its active time intervals", func(t *testing.T) {
+		rule := gen.With(withQueryForState(t, eval.Alerting), models.RuleMuts.WithActiveTimeIntervals("mondays")).GenerateRef()
+
+		evalAppliedChan := make(chan time.Time)
+
+		sender := NewSyncAlertsSenderMock()
+		sender.EXPECT().Send(mock.Anything, rule.GetKey(), mock.Anything).Return()
+
+		sch, ruleStore, _, _ := createSchedule(evalAppliedChan, sender)
+		timeIntervals := &fakeTimeIntervalProvider{
+			intervals: []definitions.MuteTimeInterval{
+				{
+					MuteTimeInterval: config.MuteTimeInterval{
+						Name: "mondays",
+						TimeIntervals: []timeinterval.TimeInterval{
+							{Weekdays: []timeinterval.WeekdayRange{{InclusiveRange: timeinterval.InclusiveRange{Begin: 1, End: 1}}}},
+						},
+					},
+				},
+			},
+		}
+		sch.stateManager.Put([]*state.State{
+			stateForRule(rule, sch.clock.Now(), eval.Alerting),
+		})
+
+		ruleStore.PutRule(context.Background(), rule)
+		factory := ruleFactoryFromScheduler(sch)
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		ruleInfo := factory.new(ctx, rule)
+
+		go func() {
+			_ = ruleInfo.Run()
+		}()
+
+		sunday := time.Date(2024, time.October, 20, 12, 0, 0, 0, time.UTC)
+		monday := sunday.Add(24 * time.Hour)
+
+		t.Run("it should clear the state and expire firing alerts once", func(t *testing.T) {
+			for i := 0; i < 2; i++ {
+				ruleInfo.Eval(&Evaluation{
+					scheduledAt:   sunday.Add(time.Duration(i) * time.Minute),
+					rule:          rule,
+					timeIntervals: newTickTimeIntervals(ctx, timeIntervals),
+				})
+				waitForTimeChannel(t, evalAppliedChan)
+			}
+
+			require.Empty(t, sch.stateManager.GetStatesForRuleUID(rule.OrgID, rule.UID))
+			sender.AssertNumberOfCalls(t, "Send", 1)
+			args, ok := sender.Calls()[0].Arguments[2].(definitions.PostableAlerts)
+			require.Truef(t, ok, fmt.Sprintf("expected argument of function was supposed to be 'definitions.PostableAlerts' but got %T", sender.Calls()[0].Arguments[2]))
+			require.Len(t, args.PostableAlerts, 1)
+		})
+
+		t.Run("it should evaluate the rule inside of its active time intervals", func(t *testing.T) {
+			ruleInfo.Eval(&Evaluation{
+				scheduledAt:   monday,
+				rule:          rule,
+				timeIntervals: newTickTimeIntervals(ctx, timeIntervals),
+			})
+			waitForTimeChannel(t, evalAppliedChan)
+
+			require.NotEmpty(t, sch.stateManager.GetStatesForRuleUID(rule.OrgID, rule.UID))
+		})
+	})
+
+	t.Run("when an upstream rule is firing", func(t *testing.T) {
+		upstream := gen.With(withQueryForState(t, eval.Alerting)).GenerateRef()
+		rule := gen.With(withQueryForState(t, eval.Alerting), models.RuleMuts.WithDependencies(models.RuleDependency{RuleUID: upstream.UID})).GenerateRef()
+
+		evalAppliedChan := make(chan time.Time)
+
+		sender := NewSyncAlertsSenderMock()
+		sender.EXPECT().Send(mock.Anything, rule.GetKey(), mock.Anything).Return()
+
+		sch, ruleStore, _, _ := createSchedule(evalAppliedChan, sender)
+		sch.stateManager.Put([]*state.State{
+			stateForRule(upstream, sch.clock.Now(), eval.Alerting),
+			stateForRule(rule, sch.clock.Now(), eval.Alerting),
+		})
+
+		ruleStore.PutRule(context.Background(), rule)
+		factory := ruleFactoryFromScheduler(sch)
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		ruleInfo := factory.new(ctx, rule)
+
+		go func() {
+			_ = ruleInfo.Run()
+		}()
+
+		t.Run("it should wait for upstream evaluation, keep the state and not send alerts", func(t *testing.T) {
+			for i := 0; i < 2; i++ {
+				upstreamDone := make(chan struct{})
+				ruleInfo.Eval(&Evaluation{
+					scheduledAt: sch.clock.Now().Add(time.Duration(i) * time.Minute),
+					rule:        rule,
+					upstream:    []models.AlertRuleKey{upstream.GetKey()},
+					waitFor:     []<-chan struct{}{upstreamDone},
+				})
+				select {
+				case <-evalAppliedChan:
+					t.Fatal("Rule was evaluated before the upstream evaluation was done")
+				case <-time.After(100 * time.Millisecond):
+				}
+				close(upstreamDone)
+				waitForTimeChannel(t, evalAppliedChan)
+			}
+
+			states := sch.stateManager.GetStatesForRuleUID(rule.OrgID, rule.UID)
+			require.NotEmpty(t, states)
+			for _, s := range states {
+				require.Equal(t, eval.Alerting, s.State)
+				require.Equal(t, models.StateReasonSuppressed, s.StateReason)
+			}
+			sender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+		})
+
+		t.Run("it should send alerts when the upstream rule is not firing", func(t *testing.T) {
+			sch.stateManager.DeleteStateByRuleUID(context.Background(), upstream.GetKeyWithGroup(), models.StateReasonRuleDeleted)
+
+			ruleInfo.Eval(&Evaluation{
+				scheduledAt: sch.clock.Now().Add(time.Hour),
+				rule:        rule,
+				upstream:    []models.AlertRuleKey{upstream.GetKey()},
+			})
+			waitForTimeChannel(t, evalAppliedChan)
+
+			states := sch.stateManager.GetStatesForRuleUID(rule.OrgID, rule.UID)
+			require.NotEmpty(t, states)
+			for _, s := range states {
+				require.Empty(t, s.StateReason)
+			}
+			sender.AssertNumberOfCalls(t, "Send", 1)
+		})
+	})
 }
 
 func ruleFactoryFromScheduler(sch *schedule) ruleFactory {
-	return newRuleFactory(sch.appURL, sch.disableGrafanaFolder, sch.maxAttempts, sch.alertsSender, sch.stateManager, sch.evaluatorFactory, &sch.schedulableAlertRules, sch.clock, sch.rrCfg, sch.metrics, sch.log, sch.tracer, sch.recordingWriter, sch.evalAppliedFunc, sch.stopAppliedFunc)
+	return newRuleFactory(sch.appURL, sch.disableGrafanaFolder, sch.maxAttempts, sch.alertsSender, sch.stateManager, sch.evaluatorFactory, &sch.schedulableAlertRules, sch.clock, sch.rrCfg, sch.metrics, sch.evaluationCosts, sch.log, sch.tracer, sch.recordingWriter, sch.evalAppliedFunc, sch.stopAppliedFunc)
 }
 
 func stateForRule(rule *models.AlertRule, ts time.Time, evalState eval.State) *state.State {
@@ -879,3 +1022,24 @@ func stateForRule(rule *models.AlertRule, ts time.Time, evalState eval.State) *s
 
 	return s
 }
+
+func TestTickTimeIntervals(t *testing.T) {
+	provider := &fakeTimeIntervalProvider{
+		intervals: []definitions.MuteTimeInterval{{MuteTimeInterval: config.MuteTimeInterval{Name: "mondays"}}},
+	}
+	intervals := newTickTimeIntervals(context.Background(), provider)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(orgID int64) {
+			defer wg.Done()
+			result, err := intervals.get(orgID)
+			assert.NoError(t, err)
+			assert.Len(t, result, 1)
+		}(int64(i%2 + 1))
+	}
+	wg.Wait()
+
+	require.EqualValues(t, 2, provider.calls.Load(), "time intervals should be resolved once per organization")
+}
@@ -0,0 +1,113 @@
+package schedule
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana/pkg/expr"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// evaluationCostAccounting creates the cost accounting of the rules that are scheduled.
+type evaluationCostAccounting struct {
+	cfg         setting.UnifiedAlertingEvaluationCostSettings
+	metrics     *metrics.Scheduler
+	ruleMetrics *ruleMetrics
+}
+
+func newEvaluationCostAccounting(cfg setting.UnifiedAlertingEvaluationCostSettings, met *metrics.Scheduler) evaluationCostAccounting {
+	a := evaluationCostAccounting{cfg: cfg, metrics: met}
+	if cfg.RuleMetricsEnabled && met != nil {
+		a.ruleMetrics = newRuleMetrics(met, cfg.RuleMetricsMaxRules)
+	}
+	return a
+}
+
+func (a evaluationCostAccounting) newRuleCost(key ngmodels.AlertRuleKey) *ruleCost {
+	return &ruleCost{
+		key:         key,
+		cfg:         a.cfg,
+		metrics:     a.metrics,
+		ruleMetrics: a.ruleMetrics,
+	}
+}
+
+// ruleCost accumulates the evaluation cost of a rule. It is safe for concurrent use, and all methods do nothing if
+// the cost is nil.
+type ruleCost struct {
+	key         ngmodels.AlertRuleKey
+	cfg         setting.UnifiedAlertingEvaluationCostSettings
+	metrics     *metrics.Scheduler
+	ruleMetrics *ruleMetrics
+
+	mtx       sync.Mutex
+	cost      ngmodels.RuleEvaluationCost
+	evaluated bool
+}
+
+// observe records the cost of an evaluation of the rule.
+func (c *ruleCost) observe(duration time.Duration, stats expr.ExecutionStats, series int) {
+	if c == nil {
+		return
+	}
+	c.mtx.Lock()
+	c.evaluated = true
+	c.cost.Duration = duration
+	c.cost.QueryDuration = stats.DatasourceDuration
+	c.cost.ExpressionDuration = stats.ExpressionDuration
+	c.cost.Series = series
+	c.cost.Slow = c.cfg.SlowRuleThreshold > 0 && duration >= c.cfg.SlowRuleThreshold
+	c.cost.HighCardinality = c.cfg.HighCardinalityThreshold > 0 && series >= c.cfg.HighCardinalityThreshold
+	cost := c.cost
+	c.mtx.Unlock()
+
+	c.ruleMetrics.observe(c, cost)
+}
+
+// missed records an evaluation that was skipped because the previous evaluation was still running.
+func (c *ruleCost) missed() {
+	if c == nil {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.cost.MissedEvaluations++
+}
+
+// late records an evaluation that did not complete before the next evaluation was due.
+func (c *ruleCost) late() {
+	if c == nil {
+		return
+	}
+	c.mtx.Lock()
+	c.cost.LateEvaluations++
+	c.mtx.Unlock()
+	if c.metrics != nil {
+		c.metrics.EvaluationLate.WithLabelValues(fmt.Sprint(c.key.OrgID)).Inc()
+	}
+}
+
+// get returns a copy of the cost, or nil if the rule has not been evaluated yet.
+func (c *ruleCost) get() *ngmodels.RuleEvaluationCost {
+	if c == nil {
+		return nil
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if !c.evaluated {
+		return nil
+	}
+	cost := c.cost
+	return &cost
+}
+
+// release removes the metrics of the rule.
+func (c *ruleCost) release() {
+	if c == nil {
+		return
+	}
+	c.ruleMetrics.forget(c)
+}
@@ -0,0 +1,130 @@
+package schedule
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestRuleCost(t *testing.T) {
+	cfg := setting.UnifiedAlertingEvaluationCostSettings{
+		SlowRuleThreshold:        10 * time.Second,
+		HighCardinalityThreshold: 100,
+	}
+	key := models.AlertRuleKey{OrgID: 1, UID: "rule"}
+
+	t.Run("is nil until the rule is evaluated", func(t *testing.T) {
+		c := newEvaluationCostAccounting(cfg, nil).newRuleCost(key)
+		c.missed()
+		require.Nil(t, c.get())
+	})
+
+	t.Run("flags rules above the thresholds", func(t *testing.T) {
+		c := newEvaluationCostAccounting(cfg, nil).newRuleCost(key)
+		stats := expr.ExecutionStats{DatasourceDuration: 9 * time.Second, ExpressionDuration: time.Second}
+
+		c.observe(5*time.Second, stats, 99)
+		cost := c.get()
+		require.False(t, cost.Slow)
+		require.False(t, cost.HighCardinality)
+		require.Equal(t, 9*time.Second, cost.QueryDuration)
+		require.Equal(t, time.Second, cost.ExpressionDuration)
+
+		c.observe(10*time.Second, stats, 100)
+		cost = c.get()
+		require.True(t, cost.Slow)
+		require.True(t, cost.HighCardinality)
+		require.Equal(t, 100, cost.Series)
+	})
+
+	t.Run("does not flag rules if the thresholds are disabled", func(t *testing.T) {
+		c := newEvaluationCostAccounting(setting.UnifiedAlertingEvaluationCostSettings{}, nil).newRuleCost(key)
+		c.observe(time.Hour, expr.ExecutionStats{}, 100000)
+		cost := c.get()
+		require.False(t, cost.Slow)
+		require.False(t, cost.HighCardinality)
+	})
+
+	t.Run("counts missed and late evaluations", func(t *testing.T) {
+		c := newEvaluationCostAccounting(cfg, nil).newRuleCost(key)
+		c.missed()
+		c.late()
+		c.late()
+		c.observe(time.Second, expr.ExecutionStats{}, 1)
+		cost := c.get()
+		require.EqualValues(t, 1, cost.MissedEvaluations)
+		require.EqualValues(t, 2, cost.LateEvaluations)
+	})
+
+	t.Run("nil cost does nothing", func(t *testing.T) {
+		var c *ruleCost
+		c.observe(time.Second, expr.ExecutionStats{}, 1)
+		c.missed()
+		c.late()
+		c.release()
+		require.Nil(t, c.get())
+	})
+}
+
+func TestRuleMetrics(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+	met := metrics.NewSchedulerMetrics(reg)
+	costs := newEvaluationCostAccounting(setting.UnifiedAlertingEvaluationCostSettings{
+		RuleMetricsEnabled:  true,
+		RuleMetricsMaxRules: 1,
+	}, met)
+	stats := expr.ExecutionStats{DatasourceDuration: 2 * time.Second, ExpressionDuration: time.Second}
+
+	rule1 := costs.newRuleCost(models.AlertRuleKey{OrgID: 1, UID: "rule-1"})
+	rule2 := costs.newRuleCost(models.AlertRuleKey{OrgID: 1, UID: "rule-2"})
+	rule1.observe(3*time.Second, stats, 5)
+	rule2.observe(3*time.Second, stats, 7)
+
+	expected := `
+# HELP grafana_alerting_rule_last_evaluation_duration_seconds The time the last evaluation of a rule spent querying datasources and executing expressions. Only exposed if metrics by rule are enabled.
+# TYPE grafana_alerting_rule_last_evaluation_duration_seconds gauge
+grafana_alerting_rule_last_evaluation_duration_seconds{org="1",phase="expression",rule_uid="rule-1"} 1
+grafana_alerting_rule_last_evaluation_duration_seconds{org="1",phase="query",rule_uid="rule-1"} 2
+# HELP grafana_alerting_rule_last_evaluation_series The number of series returned by the last evaluation of a rule. Only exposed if metrics by rule are enabled.
+# TYPE grafana_alerting_rule_last_evaluation_series gauge
+grafana_alerting_rule_last_evaluation_series{org="1",rule_uid="rule-1"} 5
+# HELP grafana_alerting_rules_without_metrics The number of rules that have no metrics by rule because the limit of rules with metrics is reached.
+# TYPE grafana_alerting_rules_without_metrics gauge
+grafana_alerting_rules_without_metrics 1
+`
+	names := []string{"grafana_alerting_rule_last_evaluation_duration_seconds", "grafana_alerting_rule_last_evaluation_series", "grafana_alerting_rules_without_metrics"}
+	require.NoError(t, testutil.GatherAndCompare(reg, bytes.NewBufferString(expected), names...))
+
+	t.Run("a restarted rule takes over the metrics", func(t *testing.T) {
+		restarted := costs.newRuleCost(models.AlertRuleKey{OrgID: 1, UID: "rule-1"})
+		restarted.observe(3*time.Second, stats, 6)
+		rule1.release()
+
+		require.Equal(t, 6.0, testutil.ToFloat64(met.RuleEvaluationSeries.WithLabelValues("1", "rule-1")))
+		rule1 = restarted
+	})
+
+	t.Run("released rules make room for other rules", func(t *testing.T) {
+		rule1.release()
+		rule2.observe(3*time.Second, stats, 7)
+
+		expected := `
+# HELP grafana_alerting_rule_last_evaluation_series The number of series returned by the last evaluation of a rule. Only exposed if metrics by rule are enabled.
+# TYPE grafana_alerting_rule_last_evaluation_series gauge
+grafana_alerting_rule_last_evaluation_series{org="1",rule_uid="rule-2"} 7
+# HELP grafana_alerting_rules_without_metrics The number of rules that have no metrics by rule because the limit of rules with metrics is reached.
+# TYPE grafana_alerting_rules_without_metrics gauge
+grafana_alerting_rules_without_metrics 0
+`
+		require.NoError(t, testutil.GatherAndCompare(reg, bytes.NewBufferString(expected), "grafana_alerting_rule_last_evaluation_series", "grafana_alerting_rules_without_metrics"))
+	})
+}
@@ -28,7 +28,8 @@ func JitterStrategyFrom(cfg setting.UnifiedAlertingSettings, toggles featuremgmt
 	if toggles == nil {
 		return strategy
 	}
-	if toggles.IsEnabledGlobally(featuremgmt.FlagJitterAlertRulesWithinGroups) {
+	// Sequential evaluation of a group requires all rules of the group to be scheduled at the same tick.
+	if toggles.IsEnabledGlobally(featuremgmt.FlagJitterAlertRulesWithinGroups) && !cfg.SequentialGroupEvaluation {
 		strategy = JitterByRule
 	}
 	return strategy
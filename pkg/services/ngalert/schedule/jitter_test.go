@@ -6,9 +6,21 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/setting"
 )
 
+func TestJitterStrategyFrom(t *testing.T) {
+	byRule := featuremgmt.WithFeatures(featuremgmt.FlagJitterAlertRulesWithinGroups)
+
+	require.Equal(t, JitterByGroup, JitterStrategyFrom(setting.UnifiedAlertingSettings{}, nil))
+	require.Equal(t, JitterNever, JitterStrategyFrom(setting.UnifiedAlertingSettings{DisableJitter: true}, byRule))
+	require.Equal(t, JitterByRule, JitterStrategyFrom(setting.UnifiedAlertingSettings{}, byRule))
+	require.Equal(t, JitterByGroup, JitterStrategyFrom(setting.UnifiedAlertingSettings{SequentialGroupEvaluation: true}, byRule),
+		"rules of a group should be scheduled at the same tick when the group is evaluated sequentially")
+}
+
 func TestJitter(t *testing.T) {
 	gen := ngmodels.RuleGen
 	genWithInterval10to600 := gen.With(gen.WithIntervalBetween(10, 600))
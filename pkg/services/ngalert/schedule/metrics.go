@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"hash/fnv"
 	"sort"
+	"sync"
 
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
@@ -120,3 +121,70 @@ func (sch *schedule) updateRulesMetrics(alertRules []*models.AlertRule) {
 func makeRuleGroupLabelValue(key models.AlertRuleGroupKeyWithFolderFullpath) string {
 	return fmt.Sprintf("%s;%s", key.FolderFullpath, key.AlertRuleGroupKey.RuleGroup)
 }
+
+// ruleMetrics exposes the evaluation cost of individual rules as metrics labelled by rule. To guard the cardinality of
+// the metrics, only the first maxRules rules that are evaluated get metrics.
+type ruleMetrics struct {
+	metrics  *metrics.Scheduler
+	maxRules int
+
+	mtx      sync.Mutex
+	rules    map[models.AlertRuleKey]*ruleCost
+	rejected map[models.AlertRuleKey]*ruleCost
+}
+
+func newRuleMetrics(met *metrics.Scheduler, maxRules int) *ruleMetrics {
+	return &ruleMetrics{
+		metrics:  met,
+		maxRules: maxRules,
+		rules:    make(map[models.AlertRuleKey]*ruleCost),
+		rejected: make(map[models.AlertRuleKey]*ruleCost),
+	}
+}
+
+func (m *ruleMetrics) observe(owner *ruleCost, cost models.RuleEvaluationCost) {
+	if m == nil {
+		return
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	key := owner.key
+	if _, ok := m.rules[key]; !ok {
+		if len(m.rules) >= m.maxRules {
+			m.rejected[key] = owner
+			m.metrics.RulesWithoutMetrics.Set(float64(len(m.rejected)))
+			return
+		}
+		delete(m.rejected, key)
+		m.metrics.RulesWithoutMetrics.Set(float64(len(m.rejected)))
+	}
+	// The routine of a restarted rule takes over the metrics of the previous routine.
+	m.rules[key] = owner
+
+	orgID := fmt.Sprint(key.OrgID)
+	m.metrics.RuleEvaluationDuration.WithLabelValues(orgID, key.UID, "query").Set(cost.QueryDuration.Seconds())
+	m.metrics.RuleEvaluationDuration.WithLabelValues(orgID, key.UID, "expression").Set(cost.ExpressionDuration.Seconds())
+	m.metrics.RuleEvaluationSeries.WithLabelValues(orgID, key.UID).Set(float64(cost.Series))
+}
+
+// forget removes the metrics of a rule unless they were taken over by another routine of the rule.
+func (m *ruleMetrics) forget(owner *ruleCost) {
+	if m == nil {
+		return
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	key := owner.key
+	if m.rejected[key] == owner {
+		delete(m.rejected, key)
+		m.metrics.RulesWithoutMetrics.Set(float64(len(m.rejected)))
+	}
+	if m.rules[key] != owner {
+		return
+	}
+	delete(m.rules, key)
+	orgID := fmt.Sprint(key.OrgID)
+	m.metrics.RuleEvaluationDuration.DeleteLabelValues(orgID, key.UID, "query")
+	m.metrics.RuleEvaluationDuration.DeleteLabelValues(orgID, key.UID, "expression")
+	m.metrics.RuleEvaluationSeries.DeleteLabelValues(orgID, key.UID)
+}
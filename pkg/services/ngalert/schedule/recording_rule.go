@@ -2,6 +2,7 @@ package schedule
 
 import (
 	context "context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/atomic"
 
+	"github.com/grafana/grafana/pkg/expr"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/infra/tracing"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
@@ -46,6 +48,7 @@ type recordingRule struct {
 	evalFactory eval.EvaluatorFactory
 	cfg         setting.RecordingRuleSettings
 	writer      RecordingWriter
+	cost        *ruleCost
 
 	// Event hooks that are only used in tests.
 	evalAppliedHook evalAppliedFunc
@@ -56,7 +59,7 @@ type recordingRule struct {
 	tracer  tracing.Tracer
 }
 
-func newRecordingRule(parent context.Context, key ngmodels.AlertRuleKey, maxAttempts int64, clock clock.Clock, evalFactory eval.EvaluatorFactory, cfg setting.RecordingRuleSettings, logger log.Logger, metrics *metrics.Scheduler, tracer tracing.Tracer, writer RecordingWriter, evalAppliedHook evalAppliedFunc, stopAppliedHook stopAppliedFunc) *recordingRule {
+func newRecordingRule(parent context.Context, key ngmodels.AlertRuleKey, maxAttempts int64, clock clock.Clock, evalFactory eval.EvaluatorFactory, cfg setting.RecordingRuleSettings, logger log.Logger, metrics *metrics.Scheduler, cost *ruleCost, tracer tracing.Tracer, writer RecordingWriter, evalAppliedHook evalAppliedFunc, stopAppliedHook stopAppliedFunc) *recordingRule {
 	ctx, stop := util.WithCancelCause(ngmodels.WithRuleKey(parent, key))
 	return &recordingRule{
 		key:                 key,
@@ -77,6 +80,7 @@ func newRecordingRule(parent context.Context, key ngmodels.AlertRuleKey, maxAtte
 		metrics:             metrics,
 		tracer:              tracer,
 		writer:              writer,
+		cost:                cost,
 	}
 }
 
@@ -90,6 +94,7 @@ func (r *recordingRule) Status() ngmodels.RuleStatus {
 		LastError:           r.lastError.Load(),
 		EvaluationTimestamp: r.evaluationTimestamp.Load(),
 		EvaluationDuration:  r.evaluationDuration.Load(),
+		Cost:                r.cost.get(),
 	}
 }
 
@@ -98,6 +103,7 @@ func (r *recordingRule) Eval(eval *Evaluation) (bool, *Evaluation) {
 	var droppedMsg *Evaluation
 	select {
 	case droppedMsg = <-r.evalCh:
+		r.cost.missed()
 	default:
 	}
 
@@ -124,7 +130,10 @@ func (r *recordingRule) Run() error {
 	r.logger.Debug("Recording rule routine started")
 
 	defer r.stopApplied()
+	defer r.cost.release()
 
+	// disabledWarned is true when it was already logged that the recording rules subsystem is disabled.
+	var disabledWarned bool
 	for {
 		select {
 		case eval, ok := <-r.evalCh:
@@ -133,8 +142,14 @@ func (r *recordingRule) Run() error {
 				return nil
 			}
 			if !r.cfg.Enabled {
-				r.logger.Warn("Recording rule scheduled but subsystem is not enabled. Skipping")
-				return nil
+				// Keep draining evaluations and signal that they are done, so that the alert rules of the group that
+				// wait for this rule are not blocked until the wait times out.
+				if !disabledWarned {
+					r.logger.Warn("Recording rule scheduled but subsystem is not enabled. Skipping")
+					disabledWarned = true
+				}
+				eval.markDone()
+				continue
 			}
 			// TODO: Skipping the "evalRunning" guard that the alert rule routine does, because it seems to be dead code and impossible to hit.
 			// TODO: Either implement me or remove from alert rules once investigated.
@@ -164,8 +179,12 @@ func (r *recordingRule) doEvaluate(ctx context.Context, ev *Evaluation) {
 		evalDuration.Observe(dur.Seconds())
 		r.evaluationTimestamp.Store(end)
 		r.evaluationDuration.Store(dur)
+		if end.Sub(ev.scheduledAt) >= time.Duration(ev.rule.IntervalSeconds)*time.Second {
+			r.cost.late()
+		}
 
 		r.evaluationDoneTestHook(ev)
+		ev.markDone()
 	}()
 
 	if ev.rule.IsPaused {
@@ -173,6 +192,11 @@ func (r *recordingRule) doEvaluate(ctx context.Context, ev *Evaluation) {
 		return
 	}
 
+	// The results of preceding recording rules of the group must be written before this rule queries them.
+	if err := waitForEvaluations(ctx, ev.after, time.Duration(ev.rule.IntervalSeconds)*time.Second); errors.Is(err, errWaitTimeout) {
+		logger.Warn("Timed out waiting for preceding recording rules of the group to be evaluated")
+	}
+
 	ctx, span := r.tracer.Start(ctx, "recording rule execution", trace.WithAttributes(
 		attribute.String("rule_uid", ev.rule.UID),
 		attribute.Int64("org_id", ev.rule.OrgID),
@@ -236,8 +260,14 @@ func (r *recordingRule) doEvaluate(ctx context.Context, ev *Evaluation) {
 func (r *recordingRule) tryEvaluation(ctx context.Context, ev *Evaluation, logger log.Logger) error {
 	evalStart := r.clock.Now()
 	evalCtx := eval.NewContext(ctx, SchedulerUserFor(ev.rule.OrgID))
-	result, err := r.buildAndExecutePipeline(ctx, evalCtx, ev, logger)
+	var stats expr.ExecutionStats
+	result, err := r.buildAndExecutePipeline(expr.WithExecutionStats(ctx, &stats), evalCtx, ev, logger)
 	evalDur := r.clock.Now().Sub(evalStart)
+	series := 0
+	if result != nil {
+		series = len(result.Responses[ev.rule.Record.From].Frames)
+	}
+	r.cost.observe(evalDur, stats, series)
 	if err != nil {
 		return fmt.Errorf("server side expressions pipeline returned an error: %w", err)
 	}
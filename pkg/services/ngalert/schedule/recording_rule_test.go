@@ -150,13 +150,39 @@ func TestRecordingRule(t *testing.T) {
 			t.Fatal("Run() never exited")
 		}
 	})
+
+	t.Run("Run should mark evaluations done when recording rules are disabled", func(t *testing.T) {
+		rule := blankRecordingRuleForTests(context.Background())
+		rule.cfg.Enabled = false
+		go func() {
+			_ = rule.Run()
+		}()
+		t.Cleanup(func() { rule.Stop(nil) })
+
+		for i := 0; i < 2; i++ {
+			done := make(chan struct{})
+			success, dropped := rule.Eval(&Evaluation{
+				scheduledAt: time.Now(),
+				rule:        gen.GenerateRef(),
+				done:        done,
+			})
+			require.True(t, success)
+			require.Nil(t, dropped)
+
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("evaluation was never marked done")
+			}
+		}
+	})
 }
 
 func blankRecordingRuleForTests(ctx context.Context) *recordingRule {
 	st := setting.RecordingRuleSettings{
 		Enabled: true,
 	}
-	return newRecordingRule(context.Background(), models.AlertRuleKey{}, 0, nil, nil, st, log.NewNopLogger(), nil, nil, writer.FakeWriter{}, nil, nil)
+	return newRecordingRule(context.Background(), models.AlertRuleKey{}, 0, nil, nil, st, log.NewNopLogger(), nil, nil, nil, writer.FakeWriter{}, nil, nil)
 }
 
 func TestRecordingRule_Integration(t *testing.T) {
@@ -18,6 +18,8 @@ import (
 var (
 	errRuleDeleted   = errors.New("rule deleted")
 	errRuleRestarted = errors.New("rule restarted")
+	errRuleReleased  = errors.New("rule released")
+	errWaitTimeout   = errors.New("timed out waiting for evaluations")
 )
 
 type ruleFactory interface {
@@ -96,6 +98,48 @@ type Evaluation struct {
 	scheduledAt time.Time
 	rule        *models.AlertRule
 	folderTitle string
+	// upstream are the keys of rules the rule depends on that are evaluated by this instance.
+	upstream []models.AlertRuleKey
+	// remoteUpstream are the keys of rules the rule depends on that are evaluated by other instances.
+	// Their state is read from the instance store.
+	remoteUpstream []models.AlertRuleKey
+	// waitFor are signaled when the evaluations of upstream rules scheduled at the same tick are processed.
+	waitFor []<-chan struct{}
+	// after are signaled when the evaluations of the rules that precede the rule in its group are processed.
+	// It is set only if the rules of a group are evaluated sequentially.
+	after []<-chan struct{}
+	// done is closed when the evaluation is processed or dropped. It is nil if no other evaluation waits for it.
+	done chan struct{}
+	// timeIntervals resolves the active time intervals of the rule. It is shared by the evaluations of the tick.
+	// If it is nil, the rule is always evaluated.
+	timeIntervals *tickTimeIntervals
+}
+
+// markDone signals evaluations that wait for this evaluation.
+func (e *Evaluation) markDone() {
+	if e.done != nil {
+		close(e.done)
+	}
+}
+
+// waitForEvaluations blocks until all channels are signaled. It returns errWaitTimeout if the timeout expires first,
+// or the context error if the context is cancelled.
+func waitForEvaluations(ctx context.Context, chs []<-chan struct{}, timeout time.Duration) error {
+	if len(chs) == 0 {
+		return nil
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for _, ch := range chs {
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return errWaitTimeout
+		}
+	}
+	return nil
 }
 
 func (e *Evaluation) Fingerprint() fingerprint {
@@ -312,6 +356,19 @@ func (r ruleWithFolder) Fingerprint() fingerprint {
 		writeBytes(tmp)
 	}
 
+	for _, interval := range rule.ActiveTimeIntervals {
+		writeString(interval)
+	}
+
+	for _, dep := range rule.Dependencies {
+		writeString(dep.RuleUID)
+		for _, m := range dep.Matchers {
+			writeString(m)
+		}
+	}
+
+	writeInt(rule.MissingSeriesEvalsToAlert)
+
 	// fields that do not affect the state.
 	// TODO consider removing fields below from the fingerprint
 	writeInt(rule.ID)
@@ -209,6 +209,9 @@ func TestRuleWithFolderFingerprint(t *testing.T) {
 					SimplifiedQueryAndExpressionsSection: false,
 				},
 			},
+			ActiveTimeIntervals:       []string{"interval-1"},
+			Dependencies:              []models.RuleDependency{{RuleUID: "upstream-1"}},
+			MissingSeriesEvalsToAlert: 1,
 		}
 		r2 := &models.AlertRule{
 			ID:        2,
@@ -253,6 +256,9 @@ func TestRuleWithFolderFingerprint(t *testing.T) {
 					SimplifiedQueryAndExpressionsSection: true,
 				},
 			},
+			ActiveTimeIntervals:       []string{"interval-2"},
+			Dependencies:              []models.RuleDependency{{Matchers: []string{"team=network"}}},
+			MissingSeriesEvalsToAlert: 2,
 		}
 
 		excludedFields := map[string]struct{}{
@@ -260,6 +266,7 @@ func TestRuleWithFolderFingerprint(t *testing.T) {
 			"Updated":         {},
 			"IntervalSeconds": {},
 			"Annotations":     {},
+			"UpdatedBy":       {},
 		}
 
 		tp := reflect.TypeOf(rule).Elem()
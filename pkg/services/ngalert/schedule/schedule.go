@@ -1,6 +1,7 @@
 package schedule
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"net/url"
@@ -88,6 +89,9 @@ type schedule struct {
 
 	metrics *metrics.Scheduler
 
+	// evaluationCosts accounts the evaluation cost of every rule.
+	evaluationCosts evaluationCostAccounting
+
 	alertsSender    AlertsSender
 	minRuleInterval time.Duration
 
@@ -100,6 +104,18 @@ type schedule struct {
 	tracer tracing.Tracer
 
 	recordingWriter RecordingWriter
+
+	// sharder decides which rules are evaluated by this instance. If it is nil, all rules are evaluated.
+	sharder RuleSharder
+
+	// timeIntervals resolves the active time intervals of alert rules. If it is nil, rules are always evaluated.
+	timeIntervals TimeIntervalProvider
+
+	// sequentialGroupEval makes rules of a group wait for the recording rules that precede them in the group.
+	sequentialGroupEval bool
+
+	// tickProcessed is true once the first tick is processed. It is only accessed by the scheduling loop.
+	tickProcessed bool
 }
 
 // SchedulerCfg is the scheduler configuration.
@@ -119,6 +135,12 @@ type SchedulerCfg struct {
 	Tracer               tracing.Tracer
 	Log                  log.Logger
 	RecordingWriter      RecordingWriter
+	Sharder              RuleSharder
+	TimeIntervals        TimeIntervalProvider
+	EvaluationCost       setting.UnifiedAlertingEvaluationCostSettings
+	// SequentialGroupEvaluation evaluates recording rules of a group one by one in the order of the group,
+	// and alert rules of the group after the recording rules.
+	SequentialGroupEvaluation bool
 }
 
 // NewScheduler returns a new scheduler.
@@ -138,6 +160,7 @@ func NewScheduler(cfg SchedulerCfg, stateManager *state.Manager) *schedule {
 		evaluatorFactory:      cfg.EvaluatorFactory,
 		ruleStore:             cfg.RuleStore,
 		metrics:               cfg.Metrics,
+		evaluationCosts:       newEvaluationCostAccounting(cfg.EvaluationCost, cfg.Metrics),
 		appURL:                cfg.AppURL,
 		disableGrafanaFolder:  cfg.DisableGrafanaFolder,
 		jitterEvaluations:     cfg.JitterEvaluations,
@@ -148,6 +171,9 @@ func NewScheduler(cfg SchedulerCfg, stateManager *state.Manager) *schedule {
 		alertsSender:          cfg.AlertSender,
 		tracer:                cfg.Tracer,
 		recordingWriter:       cfg.RecordingWriter,
+		sharder:               cfg.Sharder,
+		timeIntervals:         cfg.TimeIntervals,
+		sequentialGroupEval:   cfg.SequentialGroupEvaluation,
 	}
 
 	return &sch
@@ -247,6 +273,8 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 
 	// this is the new current state. rulesDiff contains the previously existing rules that were different between this state and the previous state.
 	alertRules, folderTitles := sch.schedulableAlertRules.all()
+	dependencies := ngmodels.ResolveRuleDependencies(alertRules)
+	alertRules = sch.releaseNotOwnedAlertRules(alertRules)
 
 	// registeredDefinitions is a map used for finding deleted alert rules
 	// initially it is assigned to all known alert rules from the previous cycle
@@ -260,6 +288,10 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 	updatedRules := make([]ngmodels.AlertRuleKeyWithVersion, 0, len(updated)) // this is needed for tests only
 	restartedRules := make([]Rule, 0)
 	missingFolder := make(map[string][]string)
+	var timeIntervals *tickTimeIntervals
+	if sch.timeIntervals != nil {
+		timeIntervals = newTickTimeIntervals(ctx, sch.timeIntervals)
+	}
 	ruleFactory := newRuleFactory(
 		sch.appURL,
 		sch.disableGrafanaFolder,
@@ -271,6 +303,7 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 		sch.clock,
 		sch.rrCfg,
 		sch.metrics,
+		sch.evaluationCosts,
 		sch.log,
 		sch.tracer,
 		sch.recordingWriter,
@@ -299,7 +332,13 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 		}
 
 		if newRoutine && !invalidInterval {
+			// The evaluation of the rule moved to this instance from another one. Load the state the other instance saved.
+			// The state of all rules is loaded at startup, therefore there is nothing to load on the first tick.
+			loadState := sch.sharder != nil && sch.tickProcessed
 			dispatcherGroup.Go(func() error {
+				if loadState {
+					sch.stateManager.LoadStateForRule(ctx, item)
+				}
 				return ruleRoutine.Run()
 			})
 		}
@@ -328,9 +367,10 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 		if isReadyToRun {
 			logger.Debug("Rule is ready to run on the current tick", "tick", tick, "frequency", itemFrequency, "offset", offset)
 			readyToRun = append(readyToRun, readyToRunItem{ruleRoutine: ruleRoutine, Evaluation: Evaluation{
-				scheduledAt: tick,
-				rule:        item,
-				folderTitle: folderTitle,
+				scheduledAt:   tick,
+				rule:          item,
+				folderTitle:   folderTitle,
+				timeIntervals: timeIntervals,
 			}})
 		}
 		if _, isUpdated := updated[key]; isUpdated && !isReadyToRun {
@@ -361,7 +401,19 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 		step = sch.baseInterval.Nanoseconds() / int64(len(readyToRun))
 	}
 
+	owned := make(map[ngmodels.AlertRuleKey]struct{}, len(alertRules))
+	for _, rule := range alertRules {
+		owned[rule.GetKey()] = struct{}{}
+	}
+	linkUpstreamEvaluations(readyToRun, dependencies, owned)
+	if sch.sequentialGroupEval {
+		linkGroupEvaluations(readyToRun)
+	}
 	slices.SortFunc(readyToRun, func(a, b readyToRunItem) int {
+		// dispatch evaluations that do not wait for other evaluations first.
+		if c := cmp.Compare(len(a.waitFor)+len(a.after), len(b.waitFor)+len(b.after)); c != 0 {
+			return c
+		}
 		return strings.Compare(a.rule.UID, b.rule.UID)
 	})
 	for i := range readyToRun {
@@ -370,7 +422,11 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 		time.AfterFunc(time.Duration(int64(i)*step), func() {
 			key := item.rule.GetKey()
 			success, dropped := item.ruleRoutine.Eval(&item.Evaluation)
+			if dropped != nil {
+				dropped.markDone()
+			}
 			if !success {
+				item.markDone()
 				sch.log.Debug("Scheduled evaluation was canceled because evaluation routine was stopped", append(key.LogContext(), "time", tick)...)
 				return
 			}
@@ -393,5 +449,99 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 		toDelete = append(toDelete, key)
 	}
 	sch.deleteAlertRule(toDelete...)
+	sch.tickProcessed = true
 	return readyToRun, registeredDefinitions, updatedRules
 }
+
+// releaseNotOwnedAlertRules stops evaluation of the rules that are assigned to other instances and returns the rules assigned to this one.
+// The state of the stopped rules is saved to the instance store to be picked up by the instances they are assigned to.
+func (sch *schedule) releaseNotOwnedAlertRules(alertRules []*ngmodels.AlertRule) []*ngmodels.AlertRule {
+	if sch.sharder == nil {
+		return alertRules
+	}
+	owned := make([]*ngmodels.AlertRule, 0, len(alertRules))
+	for _, rule := range alertRules {
+		key := rule.GetKey()
+		if sch.sharder.Owns(rule.GetGroupKey()) {
+			owned = append(owned, rule)
+			continue
+		}
+		if ruleRoutine, ok := sch.registry.del(key); ok {
+			sch.log.Info("Alert rule is assigned to another instance. Stopping evaluation", key.LogContext()...)
+			ruleRoutine.Stop(errRuleReleased)
+		} else if !sch.tickProcessed {
+			// the state was loaded at startup but this instance never evaluated the rule.
+			sch.stateManager.ForgetStateForRule(key)
+		}
+	}
+	return owned
+}
+
+// linkUpstreamEvaluations sets the upstream rules of evaluations, and makes evaluations of dependent rules wait for
+// the evaluations of their upstream rules that are scheduled at the same tick. Upstream rules that are not owned by
+// this instance are evaluated by other instances.
+func linkUpstreamEvaluations(items []readyToRunItem, dependencies map[ngmodels.AlertRuleKey][]ngmodels.AlertRuleKey, owned map[ngmodels.AlertRuleKey]struct{}) {
+	if len(dependencies) == 0 {
+		return
+	}
+	indices := make(map[ngmodels.AlertRuleKey]int, len(items))
+	for i := range items {
+		indices[items[i].rule.GetKey()] = i
+	}
+	for i := range items {
+		for _, key := range dependencies[items[i].rule.GetKey()] {
+			if _, ok := owned[key]; !ok {
+				items[i].remoteUpstream = append(items[i].remoteUpstream, key)
+				continue
+			}
+			items[i].upstream = append(items[i].upstream, key)
+			j, ok := indices[key]
+			if !ok {
+				continue
+			}
+			if items[j].done == nil {
+				items[j].done = make(chan struct{})
+			}
+			items[i].waitFor = append(items[i].waitFor, items[j].done)
+		}
+	}
+}
+
+// linkGroupEvaluations makes evaluations of rules wait for the evaluations of recording rules of the same group
+// that are scheduled at the same tick. Recording rules wait for the preceding recording rule in the order of the group,
+// and alert rules wait for all recording rules of the group.
+func linkGroupEvaluations(items []readyToRunItem) {
+	recording := make(map[ngmodels.AlertRuleGroupKey][]int)
+	for i := range items {
+		if items[i].rule.Type() == ngmodels.RuleTypeRecording {
+			key := items[i].rule.GetGroupKey()
+			recording[key] = append(recording[key], i)
+		}
+	}
+	if len(recording) == 0 {
+		return
+	}
+	for _, indices := range recording {
+		slices.SortStableFunc(indices, func(a, b int) int {
+			return cmp.Compare(items[a].rule.RuleGroupIndex, items[b].rule.RuleGroupIndex)
+		})
+	}
+	doneOf := func(j int) <-chan struct{} {
+		if items[j].done == nil {
+			items[j].done = make(chan struct{})
+		}
+		return items[j].done
+	}
+	for i := range items {
+		indices := recording[items[i].rule.GetGroupKey()]
+		if items[i].rule.Type() == ngmodels.RuleTypeRecording {
+			if pos := slices.Index(indices, i); pos > 0 {
+				items[i].after = append(items[i].after, doneOf(indices[pos-1]))
+			}
+			continue
+		}
+		for _, j := range indices {
+			items[i].after = append(items[i].after, doneOf(j))
+		}
+	}
+}
@@ -935,6 +935,93 @@ func TestSchedule_deleteAlertRule(t *testing.T) {
 	})
 }
 
+func TestLinkUpstreamEvaluations(t *testing.T) {
+	upstream := models.RuleGen.GenerateRef()
+	notScheduled := models.RuleGen.GenerateRef()
+	notOwned := models.RuleGen.GenerateRef()
+	dependent := models.RuleGen.GenerateRef()
+	items := []readyToRunItem{
+		{Evaluation: Evaluation{rule: dependent}},
+		{Evaluation: Evaluation{rule: upstream}},
+	}
+	owned := map[models.AlertRuleKey]struct{}{
+		upstream.GetKey():     {},
+		notScheduled.GetKey(): {},
+		dependent.GetKey():    {},
+	}
+
+	linkUpstreamEvaluations(items, map[models.AlertRuleKey][]models.AlertRuleKey{
+		dependent.GetKey(): {upstream.GetKey(), notScheduled.GetKey(), notOwned.GetKey()},
+	}, owned)
+
+	require.Equal(t, []models.AlertRuleKey{upstream.GetKey(), notScheduled.GetKey()}, items[0].upstream)
+	require.Equal(t, []models.AlertRuleKey{notOwned.GetKey()}, items[0].remoteUpstream)
+	require.Len(t, items[0].waitFor, 1)
+	require.Nil(t, items[0].done)
+	require.NotNil(t, items[1].done)
+	require.Empty(t, items[1].waitFor)
+
+	items[1].markDone()
+	select {
+	case <-items[0].waitFor[0]:
+	default:
+		t.Fatal("expected the dependent evaluation to be signaled when the upstream evaluation is done")
+	}
+}
+
+func TestLinkGroupEvaluations(t *testing.T) {
+	groupKey := models.GenerateGroupKey(1)
+	gen := models.RuleGen.With(models.RuleGen.WithGroupKey(groupKey))
+	record1 := gen.With(gen.WithAllRecordingRules(), gen.WithGroupIndex(1)).GenerateRef()
+	record2 := gen.With(gen.WithAllRecordingRules(), gen.WithGroupIndex(2)).GenerateRef()
+	alert := gen.With(gen.WithGroupIndex(3)).GenerateRef()
+	otherGroup := models.RuleGen.GenerateRef()
+	items := []readyToRunItem{
+		{Evaluation: Evaluation{rule: alert}},
+		{Evaluation: Evaluation{rule: record2}},
+		{Evaluation: Evaluation{rule: otherGroup}},
+		{Evaluation: Evaluation{rule: record1}},
+	}
+
+	linkGroupEvaluations(items)
+
+	require.Empty(t, items[3].after, "the first recording rule of the group should not wait")
+	require.Len(t, items[1].after, 1, "the second recording rule should wait for the first one")
+	require.Len(t, items[0].after, 2, "the alert rule should wait for all recording rules of the group")
+	require.Empty(t, items[2].after, "rules of other groups should not wait")
+	require.Nil(t, items[0].done)
+	require.Nil(t, items[2].done)
+
+	items[3].markDone()
+	require.NoError(t, waitForEvaluations(context.Background(), items[1].after, time.Second))
+	require.ErrorIs(t, waitForEvaluations(context.Background(), items[0].after, time.Millisecond), errWaitTimeout)
+	items[1].markDone()
+	require.NoError(t, waitForEvaluations(context.Background(), items[0].after, time.Second))
+}
+
+func TestProcessTicksSharesTimeIntervals(t *testing.T) {
+	ruleStore := newFakeRulesStore()
+	sch := setupScheduler(t, ruleStore, nil, nil, nil, nil)
+	sch.timeIntervals = &fakeTimeIntervalProvider{}
+
+	gen := models.RuleGen
+	gen = gen.With(gen.WithOrgID(1), gen.WithInterval(time.Second), gen.WithActiveTimeIntervals("mondays"))
+	ruleStore.PutRule(context.Background(), gen.GenerateManyRef(2)...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	dispatcherGroup, ctx := errgroup.WithContext(ctx)
+
+	scheduled, _, _ := sch.processTick(ctx, dispatcherGroup, time.Time{}.Add(time.Second))
+	require.Len(t, scheduled, 2)
+	require.NotNil(t, scheduled[0].timeIntervals)
+	require.Same(t, scheduled[0].timeIntervals, scheduled[1].timeIntervals, "evaluations of a tick should share the time intervals")
+
+	next, _, _ := sch.processTick(ctx, dispatcherGroup, time.Time{}.Add(2*time.Second))
+	require.NotEmpty(t, next)
+	require.NotSame(t, scheduled[0].timeIntervals, next[0].timeIntervals, "time intervals should be resolved again on the next tick")
+}
+
 func setupScheduler(t *testing.T, rs *fakeRulesStore, is *state.FakeInstanceStore, registry *prometheus.Registry, senderMock *SyncAlertsSenderMock, evalMock eval.EvaluatorFactory) *schedule {
 	t.Helper()
 	testTracer := tracing.InitializeTracerForTest()
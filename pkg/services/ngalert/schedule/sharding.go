@@ -0,0 +1,222 @@
+package schedule
+
+import (
+	"cmp"
+	"context"
+	"hash/fnv"
+	"slices"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/benbjohnson/clock"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// ringTokensPerPeer is the number of positions each peer takes on the hash ring.
+// More positions spread the rules between peers more evenly.
+const ringTokensPerPeer = 128
+
+// handoffHeartbeats is the number of heartbeat intervals a rule that moved to this instance waits before it is evaluated.
+// The previous owner notices the change of peers within one heartbeat interval. The second one leaves it time to stop
+// evaluating the rule and save its state, so that this instance loads the released state instead of an older one.
+const handoffHeartbeats = 2
+
+// RuleSharder decides which alert rules are evaluated by this instance.
+// All rules of a group are evaluated by the same instance, so that recording rules of the group are evaluated before
+// the alert rules of the group that query their results.
+type RuleSharder interface {
+	// Owns returns true if the alert rules of the group must be evaluated by this instance.
+	Owns(key ngmodels.AlertRuleGroupKey) bool
+}
+
+// SchedulerPeerStore stores the heartbeats of the instances that share the evaluation of alert rules.
+type SchedulerPeerStore interface {
+	SaveSchedulerPeerHeartbeat(ctx context.Context, instanceID string, at time.Time) error
+	GetSchedulerPeers(ctx context.Context, since time.Time) ([]string, error)
+	DeleteSchedulerPeer(ctx context.Context, instanceID string) error
+	DeleteStaleSchedulerPeers(ctx context.Context, before time.Time) error
+}
+
+// PeerSharder assigns alert rule groups to the instances that record heartbeats in the database by consistent hashing of the group key.
+// When an instance joins or leaves, only the rules of its part of the ring move to other instances.
+type PeerSharder struct {
+	instanceID        string
+	heartbeatInterval time.Duration
+	peerTimeout       time.Duration
+
+	store   SchedulerPeerStore
+	clock   clock.Clock
+	metrics *metrics.Scheduler
+	log     log.Logger
+
+	ring atomic.Pointer[hashRing]
+}
+
+func NewPeerSharder(cfg setting.UnifiedAlertingEvaluationShardingSettings, store SchedulerPeerStore, clk clock.Clock, m *metrics.Scheduler, logger log.Logger) *PeerSharder {
+	return &PeerSharder{
+		instanceID:        cfg.InstanceID,
+		heartbeatInterval: cfg.HeartbeatInterval,
+		peerTimeout:       cfg.PeerTimeout,
+		store:             store,
+		clock:             clk,
+		metrics:           m,
+		log:               logger,
+	}
+}
+
+// Owns returns true if the alert rule group is assigned to this instance.
+// No group is assigned to the instance until the peers are loaded by Refresh. A group that was assigned to another peer
+// before the last change of peers is not owned until that peer had time to hand it over.
+func (s *PeerSharder) Owns(key ngmodels.AlertRuleGroupKey) bool {
+	ring := s.ring.Load()
+	if ring == nil || ring.owner(key) != s.instanceID {
+		return false
+	}
+	if ring.previous == nil || !s.clock.Now().Before(ring.handoffUntil) {
+		return true
+	}
+	return ring.previous.owner(key) == s.instanceID
+}
+
+// Run records the heartbeat of this instance and refreshes the peers until the context is canceled.
+// Then, it deletes the heartbeat so the peers take over the rules of this instance without waiting for the peer timeout.
+func (s *PeerSharder) Run(ctx context.Context) error {
+	s.log.Info("Starting sharded evaluation of alert rules", "instance", s.instanceID, "heartbeatInterval", s.heartbeatInterval, "peerTimeout", s.peerTimeout)
+	t := s.clock.Ticker(s.heartbeatInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			s.Refresh(ctx)
+		case <-ctx.Done():
+			// The context is canceled, use a new one to let the peers know that this instance leaves.
+			leaveCtx, cancel := context.WithTimeout(context.Background(), s.heartbeatInterval)
+			defer cancel()
+			if err := s.store.DeleteSchedulerPeer(leaveCtx, s.instanceID); err != nil {
+				s.log.Warn("Failed to delete the heartbeat of the instance", "instance", s.instanceID, "error", err)
+			}
+			return nil
+		}
+	}
+}
+
+// Refresh records the heartbeat of this instance and reassigns the rules if the peers changed.
+// If the peers cannot be loaded, the current assignment is kept.
+func (s *PeerSharder) Refresh(ctx context.Context) {
+	now := s.clock.Now()
+	if err := s.store.SaveSchedulerPeerHeartbeat(ctx, s.instanceID, now); err != nil {
+		s.log.Error("Failed to save the heartbeat of the instance", "instance", s.instanceID, "error", err)
+	}
+	since := now.Add(-s.peerTimeout)
+	if err := s.store.DeleteStaleSchedulerPeers(ctx, since); err != nil {
+		s.log.Warn("Failed to delete heartbeats of the peers that left", "error", err)
+	}
+	peers, err := s.store.GetSchedulerPeers(ctx, since)
+	if err != nil {
+		s.log.Error("Failed to get peers. The assignment of rules is not changed", "error", err)
+		if s.ring.Load() == nil {
+			// Without peers, evaluate all rules as if sharding was disabled rather than none of them.
+			s.setPeers(nil)
+		}
+		return
+	}
+	s.setPeers(peers)
+}
+
+func (s *PeerSharder) setPeers(peers []string) {
+	if !slices.Contains(peers, s.instanceID) {
+		peers = append(peers, s.instanceID)
+	}
+	slices.Sort(peers)
+	current := s.ring.Load()
+	if current != nil && slices.Equal(current.peers, peers) {
+		return
+	}
+	s.log.Info("Peers changed. Reassigning alert rules", "instance", s.instanceID, "peers", peers)
+	now := s.clock.Now()
+	ring := newHashRing(peers)
+	switch {
+	case current == nil:
+		// The instance starts, the rules are evaluated by the other peers.
+		others := slices.DeleteFunc(slices.Clone(peers), func(peer string) bool {
+			return peer == s.instanceID
+		})
+		if len(others) > 0 {
+			ring.previous = newHashRing(others)
+		}
+	case current.previous != nil && now.Before(current.handoffUntil):
+		// The rules of the previous change are not handed over yet.
+		ring.previous = current.previous
+	default:
+		ring.previous = &hashRing{peers: current.peers, tokens: current.tokens}
+	}
+	if ring.previous != nil {
+		ring.handoffUntil = now.Add(handoffHeartbeats * s.heartbeatInterval)
+	}
+	s.ring.Store(ring)
+	s.metrics.SchedulerPeers.Set(float64(len(peers)))
+}
+
+type ringToken struct {
+	hash uint64
+	peer string
+}
+
+// hashRing assigns each alert rule group to the peer that owns the first token at or after the hash of the group key.
+type hashRing struct {
+	peers  []string
+	tokens []ringToken
+
+	// previous is the ring before the last change of peers. Until handoffUntil, the rules it assigns to other peers
+	// are being handed over to their new owners.
+	previous     *hashRing
+	handoffUntil time.Time
+}
+
+func newHashRing(peers []string) *hashRing {
+	tokens := make([]ringToken, 0, len(peers)*ringTokensPerPeer)
+	for _, peer := range peers {
+		for i := 0; i < ringTokensPerPeer; i++ {
+			tokens = append(tokens, ringToken{hash: ringHash(peer + "/" + strconv.Itoa(i)), peer: peer})
+		}
+	}
+	slices.SortFunc(tokens, func(a, b ringToken) int {
+		return cmp.Compare(a.hash, b.hash)
+	})
+	return &hashRing{peers: peers, tokens: tokens}
+}
+
+// owner returns the peer that the alert rule group is assigned to, or an empty string if the ring has no peers.
+func (r *hashRing) owner(key ngmodels.AlertRuleGroupKey) string {
+	if len(r.tokens) == 0 {
+		return ""
+	}
+	h := ringHash(strconv.FormatInt(key.OrgID, 10) + "/" + key.NamespaceUID + "/" + key.RuleGroup)
+	idx := sort.Search(len(r.tokens), func(i int) bool {
+		return r.tokens[i].hash >= h
+	})
+	if idx == len(r.tokens) {
+		idx = 0
+	}
+	return r.tokens[idx].peer
+}
+
+// ringHash returns the position of the string on the ring.
+// FNV alone places similar strings close to each other, so its result is mixed with the finalizer of MurmurHash3.
+func ringHash(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
@@ -0,0 +1,280 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestHashRing(t *testing.T) {
+	keys := make([]models.AlertRuleGroupKey, 0, 3000)
+	for i := 0; i < cap(keys); i++ {
+		keys = append(keys, models.AlertRuleGroupKey{OrgID: int64(i%3 + 1), NamespaceUID: fmt.Sprintf("folder-%d", i%10), RuleGroup: fmt.Sprintf("group-%d", i)})
+	}
+
+	t.Run("should spread rules between peers", func(t *testing.T) {
+		ring := newHashRing([]string{"grafana-0", "grafana-1", "grafana-2"})
+		counts := map[string]int{}
+		for _, key := range keys {
+			counts[ring.owner(key)]++
+		}
+		require.Len(t, counts, 3)
+		for peer, count := range counts {
+			assert.InDeltaf(t, len(keys)/3, count, float64(len(keys))/10, "peer %s owns too many or too few rules", peer)
+		}
+	})
+
+	t.Run("should move rules only to the peer that joins", func(t *testing.T) {
+		before := newHashRing([]string{"grafana-0", "grafana-1", "grafana-2"})
+		after := newHashRing([]string{"grafana-0", "grafana-1", "grafana-2", "grafana-3"})
+		moved := 0
+		for _, key := range keys {
+			if before.owner(key) == after.owner(key) {
+				continue
+			}
+			require.Equal(t, "grafana-3", after.owner(key))
+			moved++
+		}
+		assert.InDelta(t, len(keys)/4, moved, float64(len(keys))/10)
+	})
+
+	t.Run("should return empty owner if there are no peers", func(t *testing.T) {
+		require.Empty(t, newHashRing(nil).owner(keys[0]))
+	})
+}
+
+type fakeSchedulerPeerStore struct {
+	peers []string
+	err   error
+	saved []string
+}
+
+func (f *fakeSchedulerPeerStore) SaveSchedulerPeerHeartbeat(_ context.Context, instanceID string, _ time.Time) error {
+	f.saved = append(f.saved, instanceID)
+	return nil
+}
+
+func (f *fakeSchedulerPeerStore) GetSchedulerPeers(_ context.Context, _ time.Time) ([]string, error) {
+	return f.peers, f.err
+}
+
+func (f *fakeSchedulerPeerStore) DeleteSchedulerPeer(_ context.Context, _ string) error {
+	return nil
+}
+
+func (f *fakeSchedulerPeerStore) DeleteStaleSchedulerPeers(_ context.Context, _ time.Time) error {
+	return nil
+}
+
+func TestPeerSharder(t *testing.T) {
+	key := models.AlertRuleGroupKey{OrgID: 1, NamespaceUID: "folder", RuleGroup: "test"}
+	heartbeat := 10 * time.Second
+	newPeer := func(instanceID string, store *fakeSchedulerPeerStore, clk clock.Clock) *PeerSharder {
+		cfg := setting.UnifiedAlertingEvaluationShardingSettings{
+			Enabled:           true,
+			InstanceID:        instanceID,
+			HeartbeatInterval: heartbeat,
+			PeerTimeout:       time.Minute,
+		}
+		m := metrics.NewNGAlert(prometheus.NewPedanticRegistry()).GetSchedulerMetrics()
+		return NewPeerSharder(cfg, store, clk, m, log.NewNopLogger())
+	}
+	newSharder := func(store *fakeSchedulerPeerStore) *PeerSharder {
+		return newPeer("grafana-0", store, clock.NewMock())
+	}
+
+	t.Run("should not own rules before peers are loaded", func(t *testing.T) {
+		sharder := newSharder(&fakeSchedulerPeerStore{})
+		require.False(t, sharder.Owns(key))
+	})
+
+	t.Run("should own all rules if it is the only peer", func(t *testing.T) {
+		store := &fakeSchedulerPeerStore{}
+		sharder := newSharder(store)
+		sharder.Refresh(context.Background())
+		require.True(t, sharder.Owns(key))
+		require.Equal(t, []string{"grafana-0"}, store.saved)
+	})
+
+	t.Run("should own rules assigned by the ring", func(t *testing.T) {
+		store := &fakeSchedulerPeerStore{peers: []string{"grafana-1", "grafana-0"}}
+		clk := clock.NewMock()
+		sharder := newPeer("grafana-0", store, clk)
+		sharder.Refresh(context.Background())
+		clk.Add(handoffHeartbeats * heartbeat)
+		ring := newHashRing([]string{"grafana-0", "grafana-1"})
+		for i := 0; i < 100; i++ {
+			k := models.AlertRuleGroupKey{OrgID: 1, NamespaceUID: "folder", RuleGroup: fmt.Sprintf("group-%d", i)}
+			require.Equal(t, ring.owner(k) == "grafana-0", sharder.Owns(k))
+		}
+	})
+
+	t.Run("should not own rules of other peers until they hand them over", func(t *testing.T) {
+		ctx := context.Background()
+		store := &fakeSchedulerPeerStore{peers: []string{"grafana-0"}}
+		clk := clock.NewMock()
+		peer0 := newPeer("grafana-0", store, clk)
+		peer0.Refresh(ctx)
+
+		// grafana-1 joins and refreshes before grafana-0 notices it.
+		store.peers = []string{"grafana-0", "grafana-1"}
+		clk.Add(heartbeat / 2)
+		peer1 := newPeer("grafana-1", store, clk)
+		peer1.Refresh(ctx)
+
+		ring := newHashRing(store.peers)
+		var moved []models.AlertRuleGroupKey
+		for i := 0; i < 100; i++ {
+			k := models.AlertRuleGroupKey{OrgID: 1, NamespaceUID: "folder", RuleGroup: fmt.Sprintf("group-%d", i)}
+			if ring.owner(k) == "grafana-1" {
+				moved = append(moved, k)
+			}
+		}
+		require.NotEmpty(t, moved)
+
+		for _, k := range moved {
+			require.True(t, peer0.Owns(k), "the previous owner must keep the rule until it notices the change")
+			require.False(t, peer1.Owns(k), "the rule must not be evaluated by two peers")
+		}
+
+		// grafana-0 notices the change and releases the rules.
+		clk.Add(heartbeat / 2)
+		peer0.Refresh(ctx)
+		for _, k := range moved {
+			require.False(t, peer0.Owns(k))
+			require.False(t, peer1.Owns(k), "the rule must be taken over only after the release")
+		}
+
+		clk.Add(heartbeat / 2)
+		peer1.Refresh(ctx)
+		for _, k := range moved {
+			require.False(t, peer1.Owns(k))
+		}
+
+		clk.Add(heartbeat)
+		peer1.Refresh(ctx)
+		for _, k := range moved {
+			require.False(t, peer0.Owns(k))
+			require.True(t, peer1.Owns(k))
+		}
+	})
+
+	t.Run("should keep waiting for the hand over if peers change again", func(t *testing.T) {
+		ctx := context.Background()
+		store := &fakeSchedulerPeerStore{peers: []string{"grafana-1"}}
+		clk := clock.NewMock()
+		sharder := newPeer("grafana-0", store, clk)
+		sharder.Refresh(ctx)
+		previous := sharder.ring.Load().previous
+		require.NotNil(t, previous)
+
+		clk.Add(heartbeat)
+		store.peers = []string{"grafana-1", "grafana-2"}
+		sharder.Refresh(ctx)
+		ring := sharder.ring.Load()
+		require.Same(t, previous, ring.previous)
+		require.Equal(t, clk.Now().Add(handoffHeartbeats*heartbeat), ring.handoffUntil)
+	})
+
+	t.Run("should keep the assignment if peers cannot be loaded", func(t *testing.T) {
+		store := &fakeSchedulerPeerStore{peers: []string{"grafana-0", "grafana-1"}}
+		sharder := newSharder(store)
+		sharder.Refresh(context.Background())
+		ring := sharder.ring.Load()
+
+		store.err = errors.New("test")
+		sharder.Refresh(context.Background())
+		require.Same(t, ring, sharder.ring.Load())
+	})
+
+	t.Run("should own all rules if peers were never loaded", func(t *testing.T) {
+		sharder := newSharder(&fakeSchedulerPeerStore{err: errors.New("test")})
+		sharder.Refresh(context.Background())
+		require.True(t, sharder.Owns(key))
+	})
+}
+
+type fakeRuleSharder struct {
+	owned map[models.AlertRuleGroupKey]struct{}
+}
+
+func (f *fakeRuleSharder) Owns(key models.AlertRuleGroupKey) bool {
+	_, ok := f.owned[key]
+	return ok
+}
+
+func TestProcessTicksWithSharder(t *testing.T) {
+	ruleStore := newFakeRulesStore()
+	sch := setupScheduler(t, ruleStore, nil, nil, nil, nil)
+	sharder := &fakeRuleSharder{owned: map[models.AlertRuleGroupKey]struct{}{}}
+	sch.sharder = sharder
+	stopped := make(chan models.AlertRuleKey, 2)
+	sch.stopAppliedFunc = func(key models.AlertRuleKey) {
+		stopped <- key
+	}
+
+	gen := models.RuleGen
+	gen = gen.With(gen.WithOrgID(1), gen.WithInterval(time.Second))
+	rule1 := gen.With(gen.WithGroupName("group-1")).GenerateRef()
+	rule2 := gen.With(gen.WithGroupKey(rule1.GetGroupKey()), gen.WithGroupIndex(2)).GenerateRef()
+	rule3 := gen.With(gen.WithGroupName("group-2")).GenerateRef()
+	ruleStore.PutRule(context.Background(), rule1, rule2, rule3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	dispatcherGroup, ctx := errgroup.WithContext(ctx)
+	tick := time.Time{}
+
+	t.Run("should evaluate only rules of owned groups", func(t *testing.T) {
+		sharder.owned[rule1.GetGroupKey()] = struct{}{}
+		tick = tick.Add(time.Second)
+
+		scheduled, deleted, _ := sch.processTick(ctx, dispatcherGroup, tick)
+
+		require.Len(t, scheduled, 2)
+		scheduledKeys := []models.AlertRuleKey{scheduled[0].rule.GetKey(), scheduled[1].rule.GetKey()}
+		require.ElementsMatch(t, []models.AlertRuleKey{rule1.GetKey(), rule2.GetKey()}, scheduledKeys)
+		require.Empty(t, deleted)
+		require.True(t, sch.registry.exists(rule1.GetKey()))
+		require.True(t, sch.registry.exists(rule2.GetKey()))
+		require.False(t, sch.registry.exists(rule3.GetKey()))
+	})
+
+	t.Run("should release groups assigned to another instance", func(t *testing.T) {
+		delete(sharder.owned, rule1.GetGroupKey())
+		sharder.owned[rule3.GetGroupKey()] = struct{}{}
+		tick = tick.Add(time.Second)
+
+		scheduled, deleted, _ := sch.processTick(ctx, dispatcherGroup, tick)
+
+		require.Len(t, scheduled, 1)
+		require.Equal(t, rule3.GetKey(), scheduled[0].rule.GetKey())
+		require.Empty(t, deleted, "released rules should not be deleted")
+		require.False(t, sch.registry.exists(rule1.GetKey()))
+		require.False(t, sch.registry.exists(rule2.GetKey()))
+		require.True(t, sch.registry.exists(rule3.GetKey()))
+		var stoppedKeys []models.AlertRuleKey
+		for len(stoppedKeys) < 2 {
+			select {
+			case key := <-stopped:
+				stoppedKeys = append(stoppedKeys, key)
+			case <-time.After(5 * time.Second):
+				require.Fail(t, "routines of the released group were not stopped")
+			}
+		}
+		require.ElementsMatch(t, []models.AlertRuleKey{rule1.GetKey(), rule2.GetKey()}, stoppedKeys)
+	})
+}
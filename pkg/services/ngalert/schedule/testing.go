@@ -4,6 +4,7 @@ import (
 	"context"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -110,3 +111,14 @@ func (m *SyncAlertsSenderMock) Calls() []mock.Call {
 	defer m.mu.Unlock()
 	return slices.Clone(m.AlertsSenderMock.Calls)
 }
+
+type fakeTimeIntervalProvider struct {
+	intervals []definitions.MuteTimeInterval
+	err       error
+	calls     atomic.Int64
+}
+
+func (f *fakeTimeIntervalProvider) GetMuteTimings(_ context.Context, _ int64) ([]definitions.MuteTimeInterval, error) {
+	f.calls.Add(1)
+	return f.intervals, f.err
+}
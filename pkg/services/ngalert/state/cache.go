@@ -360,28 +360,38 @@ func (c *cache) GetAlertInstances(skipNormalState bool) []ngModels.AlertInstance
 				if skipNormalState && IsNormalStateWithNoReason(v2) {
 					continue
 				}
-				key, err := v2.GetAlertInstanceKey()
+				instance, err := alertInstanceFromState(v2)
 				if err != nil {
 					continue
 				}
-				states = append(states, ngModels.AlertInstance{
-					AlertInstanceKey:  key,
-					Labels:            ngModels.InstanceLabels(v2.Labels),
-					CurrentState:      ngModels.InstanceStateType(v2.State.String()),
-					CurrentReason:     v2.StateReason,
-					LastEvalTime:      v2.LastEvaluationTime,
-					CurrentStateSince: v2.StartsAt,
-					CurrentStateEnd:   v2.EndsAt,
-					ResolvedAt:        v2.ResolvedAt,
-					LastSentAt:        v2.LastSentAt,
-					ResultFingerprint: v2.ResultFingerprint.String(),
-				})
+				states = append(states, instance)
 			}
 		}
 	}
 	return states
 }
 
+// alertInstanceFromState converts the state to the alert instance that is stored in the instance store.
+func alertInstanceFromState(s *State) (ngModels.AlertInstance, error) {
+	key, err := s.GetAlertInstanceKey()
+	if err != nil {
+		return ngModels.AlertInstance{}, err
+	}
+	return ngModels.AlertInstance{
+		AlertInstanceKey:         key,
+		Labels:                   ngModels.InstanceLabels(s.Labels),
+		CurrentState:             ngModels.InstanceStateType(s.State.String()),
+		CurrentReason:            s.StateReason,
+		LastEvalTime:             s.LastEvaluationTime,
+		CurrentStateSince:        s.StartsAt,
+		CurrentStateEnd:          s.EndsAt,
+		ResolvedAt:               s.ResolvedAt,
+		LastSentAt:               s.LastSentAt,
+		ResultFingerprint:        s.ResultFingerprint.String(),
+		MissingSeriesEvaluations: s.MissingSeriesEvaluations,
+	}, nil
+}
+
 // if duplicate labels exist, keep the value from the first set
 func mergeLabels(a, b data.Labels) data.Labels {
 	newLbs := make(data.Labels, len(a)+len(b))
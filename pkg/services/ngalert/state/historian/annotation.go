@@ -49,6 +49,7 @@ type RuleStore interface {
 type AnnotationStore interface {
 	Find(ctx context.Context, query *annotations.ItemQuery) ([]*annotations.ItemDTO, error)
 	Save(ctx context.Context, panel *PanelKey, annotations []annotations.Item, orgID int64, logger log.Logger) error
+	SaveNotifications(ctx context.Context, annotations []annotations.Item, orgID int64) error
 }
 
 func NewAnnotationBackend(
@@ -118,6 +119,9 @@ func (h *AnnotationBackend) Query(ctx context.Context, query ngmodels.HistoryQue
 	if query.Labels != nil {
 		logger.Warn("Annotation state history backend does not support label queries, ignoring that filter")
 	}
+	if query.FolderUID != "" || query.State != "" {
+		logger.Warn("Annotation state history backend does not support folder and state queries, ignoring these filters")
+	}
 
 	rq := ngmodels.GetAlertRuleByUIDQuery{
 		UID:   query.RuleUID,
@@ -197,6 +201,91 @@ func (h *AnnotationBackend) Query(ctx context.Context, query ngmodels.HistoryQue
 	return frame, nil
 }
 
+// RecordNotification writes a notification attempt to annotations of the organization.
+func (h *AnnotationBackend) RecordNotification(ctx context.Context, entry ngmodels.NotificationLogEntry) <-chan error {
+	errCh := make(chan error, 1)
+	item, err := buildNotificationAnnotation(entry)
+	if err != nil {
+		errCh <- err
+		close(errCh)
+		return errCh
+	}
+
+	writeCtx, cancel := newNotificationWriteContext(ctx)
+	go func(ctx context.Context) {
+		defer cancel()
+		defer close(errCh)
+		logger := h.log.FromContext(ctx)
+		if err := h.store.SaveNotifications(ctx, []annotations.Item{item}, entry.OrgID); err != nil {
+			logger.Error("Failed to save notification attempt", "receiver", entry.Receiver, "err", err)
+			errCh <- err
+		}
+	}(writeCtx)
+	return errCh
+}
+
+// QueryNotifications returns notification attempts stored in annotations, most recent first.
+// The receiver, integration and status are filtered by the tags of the annotations. Alert labels are matched after fetching.
+func (h *AnnotationBackend) QueryNotifications(ctx context.Context, query ngmodels.NotificationLogQuery) ([]ngmodels.NotificationLogEntry, error) {
+	logger := h.log.FromContext(ctx)
+	tags := notificationTags(query.Receiver, query.Integration, query.Status)
+	seen := make(map[int64]struct{})
+	return queryNotificationPages(query, h.clock.Now(), func(from, to time.Time, limit int) ([]ngmodels.NotificationLogEntry, int, error) {
+		items, err := h.store.Find(ctx, &annotations.ItemQuery{
+			OrgID:        query.OrgID,
+			Tags:         tags,
+			From:         from.UnixMilli(),
+			To:           to.UnixMilli(),
+			Limit:        int64(limit),
+			SignedInUser: query.SignedInUser,
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to query annotations for notification history: %w", err)
+		}
+
+		entries := make([]ngmodels.NotificationLogEntry, 0, len(items))
+		for _, item := range items {
+			if _, ok := seen[item.ID]; ok || item.Data == nil {
+				continue
+			}
+			seen[item.ID] = struct{}{}
+			raw, err := item.Data.MarshalJSON()
+			if err != nil {
+				logger.Error("Annotation service gave an annotation with unparseable data, skipping", "id", item.ID, "err", err)
+				continue
+			}
+			var entry ngmodels.NotificationLogEntry
+			if err := json.Unmarshal(raw, &entry); err != nil {
+				logger.Error("Annotation does not contain a notification attempt, skipping", "id", item.ID, "err", err)
+				continue
+			}
+			entry.OrgID = query.OrgID
+			entries = append(entries, entry)
+		}
+		return entries, len(items), nil
+	})
+}
+
+func buildNotificationAnnotation(entry ngmodels.NotificationLogEntry) (annotations.Item, error) {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return annotations.Item{}, fmt.Errorf("failed to serialize notification attempt: %w", err)
+	}
+	data, err := simplejson.NewJson(raw)
+	if err != nil {
+		return annotations.Item{}, fmt.Errorf("failed to serialize notification attempt: %w", err)
+	}
+	return annotations.Item{
+		OrgID:    entry.OrgID,
+		Type:     annotations.NotificationHistoryType,
+		Text:     notificationSummary(entry),
+		Tags:     notificationTags(entry.Receiver, entry.Integration, entry.Status),
+		Data:     data,
+		Epoch:    entry.Timestamp.UnixMilli(),
+		EpochEnd: entry.Timestamp.UnixMilli(),
+	}, nil
+}
+
 func buildAnnotations(rule history_model.RuleMeta, states []state.StateTransition, logger log.Logger) []annotations.Item {
 	items := make([]annotations.Item, 0, len(states))
 	for _, state := range states {
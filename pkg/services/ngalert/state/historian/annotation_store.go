@@ -54,6 +54,17 @@ func (s *AnnotationServiceStore) Save(ctx context.Context, panel *PanelKey, anno
 	return nil
 }
 
+// SaveNotifications saves annotations with notification attempts. They are not associated with any dashboard.
+func (s *AnnotationServiceStore) SaveNotifications(ctx context.Context, annotations []annotations.Item, orgID int64) error {
+	org := fmt.Sprint(orgID)
+	s.metrics.WritesTotal.WithLabelValues(org, "annotations").Inc()
+	if err := s.svc.SaveMany(ctx, annotations); err != nil {
+		s.metrics.WritesFailed.WithLabelValues(org, "annotations").Inc()
+		return fmt.Errorf("error saving notification annotation batch: %w", err)
+	}
+	return nil
+}
+
 func (s *AnnotationServiceStore) Find(ctx context.Context, query *annotations.ItemQuery) ([]*annotations.ItemDTO, error) {
 	return s.svc.Find(ctx, query)
 }
@@ -252,6 +252,22 @@ func TestBuildAnnotations(t *testing.T) {
 	})
 }
 
+func TestBuildNotificationAnnotation(t *testing.T) {
+	entry := models.NotificationLogEntry{
+		OrgID:       1,
+		Timestamp:   time.Now(),
+		Receiver:    "on-call",
+		Integration: "email",
+		Status:      models.NotificationStatusFailed,
+	}
+
+	item, err := buildNotificationAnnotation(entry)
+
+	require.NoError(t, err)
+	require.Equal(t, annotations.NotificationHistoryType, item.Type)
+	require.Equal(t, []string{NotificationAnnotationTag, "receiver:on-call", "integration:email", "status:failed"}, item.Tags)
+}
+
 func makeStateTransition() state.StateTransition {
 	return state.StateTransition{
 		State: &state.State{
@@ -286,3 +302,7 @@ func (i *interceptingAnnotationStore) Find(ctx context.Context, query *annotatio
 func (i *interceptingAnnotationStore) Save(ctx context.Context, panel *PanelKey, annotations []annotations.Item, orgID int64, logger log.Logger) error {
 	return nil
 }
+
+func (i *interceptingAnnotationStore) SaveNotifications(ctx context.Context, annotations []annotations.Item, orgID int64) error {
+	return nil
+}
@@ -26,9 +26,10 @@ func shouldRecord(transition state.StateTransition) bool {
 	if transition.StateReason == models.StateReasonMissingSeries && transition.PreviousState == eval.Normal && transition.State.State == eval.Normal {
 		return false
 	}
-	// Do not log transition from Normal (Paused|Updated) to Normal
+	// Do not log transition from Normal (Paused|PausedBySchedule|Updated) to Normal
 	if transition.State.State == eval.Normal && transition.StateReason == "" &&
-		transition.PreviousState == eval.Normal && (transition.PreviousStateReason == models.StateReasonPaused || transition.PreviousStateReason == models.StateReasonUpdated) {
+		transition.PreviousState == eval.Normal && (transition.PreviousStateReason == models.StateReasonPaused ||
+		transition.PreviousStateReason == models.StateReasonPausedBySchedule || transition.PreviousStateReason == models.StateReasonUpdated) {
 		return false
 	}
 	return true
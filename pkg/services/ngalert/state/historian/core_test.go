@@ -45,6 +45,7 @@ func TestShouldRecord(t *testing.T) {
 		"",
 		models.StateReasonMissingSeries,
 		models.StateReasonPaused,
+		models.StateReasonPausedBySchedule,
 		models.StateReasonUpdated,
 		models.StateReasonRuleDeleted,
 		eval.Error.String(),
@@ -68,13 +69,15 @@ func TestShouldRecord(t *testing.T) {
 		transition(eval.Normal, eval.Error.String(), eval.Normal, models.StateReasonMissingSeries):  {},
 		transition(eval.Normal, eval.NoData.String(), eval.Normal, models.StateReasonMissingSeries): {},
 
-		transition(eval.Normal, models.StateReasonPaused, eval.Normal, ""):  {},
-		transition(eval.Normal, models.StateReasonUpdated, eval.Normal, ""): {},
+		transition(eval.Normal, models.StateReasonPaused, eval.Normal, ""):           {},
+		transition(eval.Normal, models.StateReasonPausedBySchedule, eval.Normal, ""): {},
+		transition(eval.Normal, models.StateReasonUpdated, eval.Normal, ""):          {},
 
 		// these transitions are actually not possible
-		transition(eval.Normal, models.StateReasonRuleDeleted, eval.Normal, models.StateReasonMissingSeries): {},
-		transition(eval.Normal, models.StateReasonPaused, eval.Normal, models.StateReasonMissingSeries):      {},
-		transition(eval.Normal, models.StateReasonUpdated, eval.Normal, models.StateReasonMissingSeries):     {},
+		transition(eval.Normal, models.StateReasonRuleDeleted, eval.Normal, models.StateReasonMissingSeries):      {},
+		transition(eval.Normal, models.StateReasonPaused, eval.Normal, models.StateReasonMissingSeries):           {},
+		transition(eval.Normal, models.StateReasonPausedBySchedule, eval.Normal, models.StateReasonMissingSeries): {},
+		transition(eval.Normal, models.StateReasonUpdated, eval.Normal, models.StateReasonMissingSeries):          {},
 	}
 	// add all transitions from reason X(Y) to X(Y) as negative.
 	for _, s := range allStates {
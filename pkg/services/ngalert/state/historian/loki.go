@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -321,6 +322,131 @@ func StatesToStream(rule history_model.RuleMeta, states []state.StateTransition,
 	}
 }
 
+// RecordNotification writes a notification attempt to an external Loki instance.
+func (h *RemoteLokiBackend) RecordNotification(ctx context.Context, entry models.NotificationLogEntry) <-chan error {
+	errCh := make(chan error, 1)
+	stream, err := NotificationToStream(entry, h.externalLabels)
+	if err != nil {
+		errCh <- err
+		close(errCh)
+		return errCh
+	}
+
+	writeCtx, cancel := newNotificationWriteContext(ctx)
+	go func(ctx context.Context) {
+		defer cancel()
+		defer close(errCh)
+		logger := h.log.FromContext(ctx)
+		org := fmt.Sprint(entry.OrgID)
+		h.metrics.WritesTotal.WithLabelValues(org, "loki").Inc()
+		if err := h.client.Push(ctx, []Stream{stream}); err != nil {
+			logger.Error("Failed to save notification attempt", "receiver", entry.Receiver, "error", err)
+			h.metrics.WritesFailed.WithLabelValues(org, "loki").Inc()
+			errCh <- fmt.Errorf("failed to save notification attempt: %w", err)
+		}
+	}(writeCtx)
+	return errCh
+}
+
+// QueryNotifications retrieves notification attempts from an external Loki instance, most recent first.
+func (h *RemoteLokiBackend) QueryNotifications(ctx context.Context, query models.NotificationLogQuery) ([]models.NotificationLogEntry, error) {
+	logQL := BuildNotificationLogQuery(query)
+	if len(logQL) > h.client.MaxQuerySize() {
+		return nil, NewErrLokiQueryTooLong(logQL, h.client.MaxQuerySize())
+	}
+
+	seen := make(map[string]struct{})
+	return queryNotificationPages(query, time.Now().UTC(), func(from, to time.Time, limit int) ([]models.NotificationLogEntry, int, error) {
+		// The end of the range is moved by a nanosecond so that the attempts at the end of the previous page are
+		// fetched again. They are skipped below.
+		res, err := h.client.RangeQuery(ctx, logQL, from.UnixNano(), to.UnixNano()+1, int64(limit))
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var entries []models.NotificationLogEntry
+		fetched := 0
+		for _, stream := range res.Data.Result {
+			for _, sample := range stream.Values {
+				fetched++
+				key := fmt.Sprint(sample.T.UnixNano(), sample.V)
+				if _, ok := seen[key]; ok {
+					continue
+				}
+				seen[key] = struct{}{}
+				var entry LokiNotificationEntry
+				if err := json.Unmarshal([]byte(sample.V), &entry); err != nil {
+					return nil, 0, fmt.Errorf("failed to unmarshal notification attempt: %w", err)
+				}
+				entry.OrgID = query.OrgID
+				entries = append(entries, entry.NotificationLogEntry)
+			}
+		}
+		return entries, fetched, nil
+	})
+}
+
+// LokiNotificationEntry is the log line of a notification attempt.
+type LokiNotificationEntry struct {
+	SchemaVersion int `json:"schemaVersion"`
+	models.NotificationLogEntry
+}
+
+func NotificationToStream(entry models.NotificationLogEntry, externalLabels map[string]string) (Stream, error) {
+	labels := mergeLabels(make(map[string]string), externalLabels)
+	// System-defined labels take precedence over user-defined external labels.
+	labels[StateHistoryLabelKey] = NotificationHistoryLabelValue
+	labels[OrgIDLabel] = fmt.Sprint(entry.OrgID)
+	labels[ReceiverLabel] = entry.Receiver
+
+	jsn, err := json.Marshal(LokiNotificationEntry{SchemaVersion: 1, NotificationLogEntry: entry})
+	if err != nil {
+		return Stream{}, fmt.Errorf("failed to construct history record for notification attempt: %w", err)
+	}
+	return Stream{
+		Stream: labels,
+		Values: []Sample{{T: entry.Timestamp, V: string(jsn)}},
+	}, nil
+}
+
+// BuildNotificationLogQuery converts models.NotificationLogQuery to a Loki query.
+// Alert labels are filtered by line filters that match the serialized labels. Since they do not check that the labels
+// belong to the same alert, the result must still be matched against the query. The time range is not part of the query.
+func BuildNotificationLogQuery(query models.NotificationLogQuery) string {
+	b := strings.Builder{}
+	fmt.Fprintf(&b, `{%s="%d",%s=%q`, OrgIDLabel, query.OrgID, StateHistoryLabelKey, NotificationHistoryLabelValue)
+	if query.Receiver != "" {
+		fmt.Fprintf(&b, `,%s=%q`, ReceiverLabel, query.Receiver)
+	}
+	b.WriteString("}")
+	keys := make([]string, 0, len(query.Labels))
+	for k := range query.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&b, " |= %q", serializedLabel(k, query.Labels[k]))
+	}
+	if query.Integration == "" && query.Status == "" {
+		return b.String()
+	}
+	b.WriteString(" | json")
+	if query.Integration != "" {
+		fmt.Fprintf(&b, " | integration=%q", query.Integration)
+	}
+	if query.Status != "" {
+		fmt.Fprintf(&b, " | status=%q", query.Status)
+	}
+	return b.String()
+}
+
+// serializedLabel returns the label as it appears in the JSON of a log line.
+func serializedLabel(key, value string) string {
+	k, _ := json.Marshal(key)
+	v, _ := json.Marshal(value)
+	return string(k) + ":" + string(v)
+}
+
 func (h *RemoteLokiBackend) recordStreams(ctx context.Context, stream Stream, logger log.Logger) error {
 	if err := h.client.Push(ctx, []Stream{stream}); err != nil {
 		return err
@@ -464,6 +590,14 @@ func buildQueryTail(query models.HistoryQuery) (string, error) {
 		b.WriteString(strconv.FormatInt(query.PanelID, 10))
 	}
 
+	if query.State != "" {
+		b.WriteString(" | current=~")
+		_, err := fmt.Fprintf(&b, "%q", "^"+regexp.QuoteMeta(query.State)+`( \(.*\))?$`)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	requiredSize := 0
 	labelKeys := make([]string, 0, len(query.Labels))
 	for k, v := range query.Labels {
@@ -489,11 +623,25 @@ func queryHasLogFilters(query models.HistoryQuery) bool {
 	return query.RuleUID != "" ||
 		query.DashboardUID != "" ||
 		query.PanelID != 0 ||
+		query.State != "" ||
 		len(query.Labels) > 0
 }
 
 func (h *RemoteLokiBackend) getFolderUIDsForFilter(ctx context.Context, query models.HistoryQuery) ([]string, error) {
-	bypass, err := h.ac.CanReadAllRules(ctx, query.SignedInUser)
+	uids, err := getFolderUIDsForFilter(ctx, h.ac, h.ruleStore, query)
+	if err != nil || query.FolderUID == "" {
+		return uids, err
+	}
+	if len(uids) > 0 && !slices.Contains(uids, query.FolderUID) {
+		return nil, accesscontrol.NewAuthorizationErrorGeneric("read rules in the folder")
+	}
+	return []string{query.FolderUID}, nil
+}
+
+// getFolderUIDsForFilter returns the UIDs of the folders the user can read rules in. It returns nil if the user can
+// read all rules, or if the query is limited to a rule the user can read.
+func getFolderUIDsForFilter(ctx context.Context, ac AccessControl, ruleStore RuleStore, query models.HistoryQuery) ([]string, error) {
+	bypass, err := ac.CanReadAllRules(ctx, query.SignedInUser)
 	if err != nil {
 		return nil, err
 	}
@@ -502,7 +650,7 @@ func (h *RemoteLokiBackend) getFolderUIDsForFilter(ctx context.Context, query mo
 	}
 	// if there is a filter by rule UID, find that rule UID and make sure that user has access to it.
 	if query.RuleUID != "" {
-		rule, err := h.ruleStore.GetAlertRuleByUID(ctx, &models.GetAlertRuleByUIDQuery{
+		rule, err := ruleStore.GetAlertRuleByUID(ctx, &models.GetAlertRuleByUIDQuery{
 			UID:   query.RuleUID,
 			OrgID: query.OrgID,
 		})
@@ -512,17 +660,17 @@ func (h *RemoteLokiBackend) getFolderUIDsForFilter(ctx context.Context, query mo
 		if rule == nil {
 			return nil, models.ErrAlertRuleNotFound
 		}
-		return nil, h.ac.AuthorizeAccessInFolder(ctx, query.SignedInUser, rule)
+		return nil, ac.AuthorizeAccessInFolder(ctx, query.SignedInUser, rule)
 	}
 	// if no filter, then we need to get all namespaces user has access to
-	folders, err := h.ruleStore.GetUserVisibleNamespaces(ctx, query.OrgID, query.SignedInUser)
+	folders, err := ruleStore.GetUserVisibleNamespaces(ctx, query.OrgID, query.SignedInUser)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch folders that user can access: %w", err)
 	}
 	uids := make([]string, 0, len(folders))
 	// now keep only UIDs of folder in which user can read rules.
 	for _, f := range folders {
-		hasAccess, err := h.ac.HasAccessInFolder(ctx, query.SignedInUser, models.Namespace(*f))
+		hasAccess, err := ac.HasAccessInFolder(ctx, query.SignedInUser, models.Namespace(*f))
 		if err != nil {
 			return nil, err
 		}
@@ -249,6 +249,14 @@ func TestBuildLogQuery(t *testing.T) {
 			},
 			exp: []string{`{orgID="123",from="state-history"} | json | panelID=456`},
 		},
+		{
+			name: "filters current state in log line",
+			query: models.HistoryQuery{
+				OrgID: 123,
+				State: "Alerting",
+			},
+			exp: []string{`{orgID="123",from="state-history"} | json | current=~"^Alerting( \\(.*\\))?$"`},
+		},
 		{
 			name: "filters instance labels in log line",
 			query: models.HistoryQuery{
@@ -879,6 +887,87 @@ func TestGetFolderUIDsForFilter(t *testing.T) {
 	})
 }
 
+func TestBuildNotificationLogQuery(t *testing.T) {
+	cases := []struct {
+		name  string
+		query models.NotificationLogQuery
+		exp   string
+	}{
+		{
+			name:  "default includes notification history label and orgID label",
+			query: models.NotificationLogQuery{OrgID: 123},
+			exp:   `{orgID="123",from="notification-history"}`,
+		},
+		{
+			name:  "adds stream label filter for receiver",
+			query: models.NotificationLogQuery{OrgID: 123, Receiver: "my \"receiver\""},
+			exp:   `{orgID="123",from="notification-history",receiver="my \"receiver\""}`,
+		},
+		{
+			name: "filters integration and status in log line",
+			query: models.NotificationLogQuery{
+				OrgID:       123,
+				Integration: "slack",
+				Status:      models.NotificationStatusFailed,
+			},
+			exp: `{orgID="123",from="notification-history"} | json | integration="slack" | status="failed"`,
+		},
+		{
+			name: "filters alert labels by line filters before parsing",
+			query: models.NotificationLogQuery{
+				OrgID:  123,
+				Labels: map[string]string{"team": "a", "severity": "critical"},
+				Status: models.NotificationStatusFailed,
+			},
+			exp: `{orgID="123",from="notification-history"} |= "\"severity\":\"critical\"" |= "\"team\":\"a\"" | json | status="failed"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.exp, BuildNotificationLogQuery(tc.query))
+		})
+	}
+}
+
+func TestRecordNotification(t *testing.T) {
+	entry := models.NotificationLogEntry{
+		OrgID:       1,
+		Timestamp:   time.Now(),
+		Receiver:    "team-a",
+		Integration: "email",
+		Status:      models.NotificationStatusFailed,
+		Error:       "smtp unavailable",
+		Alerts: []models.NotificationAlert{
+			{Fingerprint: "abc", Status: "firing", Labels: map[string]string{"team": "a"}},
+		},
+	}
+
+	t.Run("writes notification attempts to loki", func(t *testing.T) {
+		req := NewFakeRequester()
+		loki := createTestLokiBackend(t, req, metrics.NewHistorianMetrics(prometheus.NewRegistry(), metrics.Subsystem))
+
+		err := <-loki.RecordNotification(context.Background(), entry)
+
+		require.NoError(t, err)
+		require.Contains(t, "/loki/api/v1/push", req.lastRequest.URL.Path)
+		sent := string(readBody(t, req.lastRequest))
+		require.Contains(t, sent, `"from":"notification-history"`)
+		require.Contains(t, sent, `"receiver":"team-a"`)
+		require.Contains(t, sent, "externalLabelValue")
+		require.Contains(t, sent, "smtp unavailable")
+	})
+
+	t.Run("returns error if write fails", func(t *testing.T) {
+		req := NewFakeRequester().WithResponse(badResponse()) //nolint:bodyclose
+		loki := createTestLokiBackend(t, req, metrics.NewHistorianMetrics(prometheus.NewRegistry(), metrics.Subsystem))
+
+		err := <-loki.RecordNotification(context.Background(), entry)
+
+		require.ErrorContains(t, err, "failed to save notification attempt")
+	})
+}
+
 func createTestLokiBackend(t *testing.T, req client.Requester, met *metrics.Historian) *RemoteLokiBackend {
 	url, _ := url.Parse("http://some.url")
 	cfg := LokiConfig{
@@ -12,6 +12,8 @@ import (
 type Backend interface {
 	Record(ctx context.Context, rule history_model.RuleMeta, states []state.StateTransition) <-chan error
 	Query(ctx context.Context, query ngmodels.HistoryQuery) (*data.Frame, error)
+	RecordNotification(ctx context.Context, entry ngmodels.NotificationLogEntry) <-chan error
+	QueryNotifications(ctx context.Context, query ngmodels.NotificationLogQuery) ([]ngmodels.NotificationLogEntry, error)
 }
 
 // MultipleBackend is a state.Historian that records history to multiple backends at once.
@@ -53,6 +55,30 @@ func (h *MultipleBackend) Query(ctx context.Context, query ngmodels.HistoryQuery
 	return h.primary.Query(ctx, query)
 }
 
+func (h *MultipleBackend) RecordNotification(ctx context.Context, entry ngmodels.NotificationLogEntry) <-chan error {
+	jobs := make([]<-chan error, 0, len(h.secondaries)+1) // One extra for the primary.
+	for _, b := range append([]Backend{h.primary}, h.secondaries...) {
+		jobs = append(jobs, b.RecordNotification(ctx, entry))
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(errCh)
+		errs := make([]error, 0)
+		for _, ch := range jobs {
+			err := <-ch
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+		errCh <- Join(errs...)
+	}()
+	return errCh
+}
+
+func (h *MultipleBackend) QueryNotifications(ctx context.Context, query ngmodels.NotificationLogQuery) ([]ngmodels.NotificationLogEntry, error) {
+	return h.primary.QueryNotifications(ctx, query)
+}
+
 // TODO: This is vendored verbatim from the Go standard library.
 // TODO: The grafana project doesn't support go 1.20 yet, so we can't use errors.Join() directly.
 // TODO: Remove this and replace calls with "errors.Join(...)" when go 1.20 becomes the minimum supported version.
@@ -55,12 +55,30 @@ func TestMultipleBackend(t *testing.T) {
 		require.ErrorContains(t, err, "error one")
 		require.ErrorContains(t, err, "error two")
 	})
+
+	t.Run("writes notification attempts to all backends and queries the primary", func(t *testing.T) {
+		one := &fakeBackend{}
+		two := &fakeBackend{}
+		fan := NewMultipleBackend(one, two)
+
+		entry := ngmodels.NotificationLogEntry{Receiver: "on-call", Status: ngmodels.NotificationStatusSuccess}
+		err := <-fan.RecordNotification(context.Background(), entry)
+		require.NoError(t, err)
+
+		require.Equal(t, &entry, one.notification)
+		require.Equal(t, &entry, two.notification)
+		two.notification = nil
+		res, err := fan.QueryNotifications(context.Background(), ngmodels.NotificationLogQuery{})
+		require.NoError(t, err)
+		require.Equal(t, []ngmodels.NotificationLogEntry{entry}, res)
+	})
 }
 
 type fakeBackend struct {
-	resp *data.Frame
-	err  error
-	last []state.StateTransition
+	resp         *data.Frame
+	err          error
+	last         []state.StateTransition
+	notification *ngmodels.NotificationLogEntry
 }
 
 func (f *fakeBackend) Record(ctx context.Context, rule history_model.RuleMeta, states []state.StateTransition) <-chan error {
@@ -76,3 +94,20 @@ func (f *fakeBackend) Record(ctx context.Context, rule history_model.RuleMeta, s
 func (f *fakeBackend) Query(ctx context.Context, query ngmodels.HistoryQuery) (*data.Frame, error) {
 	return f.resp, f.err
 }
+
+func (f *fakeBackend) RecordNotification(ctx context.Context, entry ngmodels.NotificationLogEntry) <-chan error {
+	ch := make(chan error, 1)
+	if f.err != nil {
+		ch <- f.err
+	}
+	f.notification = &entry
+	defer close(ch)
+	return ch
+}
+
+func (f *fakeBackend) QueryNotifications(ctx context.Context, query ngmodels.NotificationLogQuery) ([]ngmodels.NotificationLogEntry, error) {
+	if f.notification == nil {
+		return nil, f.err
+	}
+	return []ngmodels.NotificationLogEntry{*f.notification}, f.err
+}
@@ -25,3 +25,13 @@ func (f *NoOpHistorian) Record(ctx context.Context, _ history_model.RuleMeta, _
 func (f *NoOpHistorian) Query(ctx context.Context, query models.HistoryQuery) (*data.Frame, error) {
 	return data.NewFrame("states"), nil
 }
+
+func (f *NoOpHistorian) RecordNotification(ctx context.Context, _ models.NotificationLogEntry) <-chan error {
+	errCh := make(chan error)
+	close(errCh)
+	return errCh
+}
+
+func (f *NoOpHistorian) QueryNotifications(ctx context.Context, query models.NotificationLogQuery) ([]models.NotificationLogEntry, error) {
+	return []models.NotificationLogEntry{}, nil
+}
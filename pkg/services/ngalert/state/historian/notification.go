@@ -0,0 +1,126 @@
+package historian
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+	"github.com/grafana/grafana/pkg/services/annotations"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+const (
+	// NotificationHistoryLabelValue is the value of the StateHistoryLabelKey label of Loki streams that contain notification attempts.
+	NotificationHistoryLabelValue = "notification-history"
+	ReceiverLabel                 = "receiver"
+
+	// NotificationAnnotationTag is the tag of annotations that contain notification attempts.
+	// These annotations are not returned by annotation queries that do not filter by this tag.
+	NotificationAnnotationTag = annotations.NotificationHistoryTag
+
+	// notificationPageSize is the number of notification attempts fetched from the backends at once when the query
+	// filters by alert labels. The backends only narrow down alert labels, so that they are matched after fetching.
+	notificationPageSize = 1000
+)
+
+// ErrNotificationHistoryUnsupported is returned when notification attempts are queried from a backend that does not record them.
+var ErrNotificationHistoryUnsupported = errutil.BadRequest("alerting.notificationHistoryUnsupported",
+	errutil.WithPublicMessage("The configured state history backend does not record notification attempts."))
+
+// notificationPageFunc fetches the most recent notification attempts in the time range, up to the limit.
+// It returns the attempts that were not returned by previous pages, and the number of fetched records.
+type notificationPageFunc func(from, to time.Time, limit int) ([]models.NotificationLogEntry, int, error)
+
+// queryNotificationPages returns the notification attempts that match the query, most recent first, up to the limit of
+// the query. Pages that end at the oldest attempt fetched so far are fetched until the limit is reached or there are no
+// more attempts, so that attempts filtered out after fetching do not hide older attempts that match.
+func queryNotificationPages(query models.NotificationLogQuery, now time.Time, fetch notificationPageFunc) ([]models.NotificationLogEntry, error) {
+	if query.To.IsZero() {
+		query.To = now
+	}
+	if query.From.IsZero() {
+		query.From = query.To.Add(-defaultQueryRange)
+	}
+	if query.Limit < 1 {
+		query.Limit = defaultPageSize
+	}
+	if query.Limit > maximumPageSize {
+		query.Limit = maximumPageSize
+	}
+	pageSize := query.Limit
+	if len(query.Labels) > 0 {
+		pageSize = notificationPageSize
+	}
+
+	result := make([]models.NotificationLogEntry, 0)
+	to := query.To
+	for {
+		page, fetched, err := fetch(query.From, to, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range page {
+			if query.Matches(e) {
+				result = append(result, e)
+			}
+			if e.Timestamp.Before(to) {
+				to = e.Timestamp
+			}
+		}
+		if len(page) == 0 || fetched < pageSize || len(result) >= query.Limit {
+			break
+		}
+	}
+	return filterNotifications(result, query), nil
+}
+
+// notificationTags returns the annotation tags of notification attempts with the given fields.
+// Empty fields are skipped, so that the result can be used to filter annotations.
+func notificationTags(receiver, integration string, status models.NotificationStatus) []string {
+	tags := []string{NotificationAnnotationTag}
+	if receiver != "" {
+		tags = append(tags, ReceiverLabel+":"+receiver)
+	}
+	if integration != "" {
+		tags = append(tags, "integration:"+integration)
+	}
+	if status != "" {
+		tags = append(tags, "status:"+string(status))
+	}
+	return tags
+}
+
+// newNotificationWriteContext returns a context for writing a notification attempt in the background.
+// Like state history writes, it is detached from the caller so that shutdowns do not interrupt the write.
+func newNotificationWriteContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	writeCtx, cancel := context.WithTimeout(context.Background(), StateHistoryWriteTimeout)
+	return trace.ContextWithSpan(writeCtx, trace.SpanFromContext(ctx)), cancel
+}
+
+// filterNotifications returns the entries that match the query, most recent first, up to the limit of the query.
+func filterNotifications(entries []models.NotificationLogEntry, query models.NotificationLogQuery) []models.NotificationLogEntry {
+	result := make([]models.NotificationLogEntry, 0, len(entries))
+	for _, e := range entries {
+		if query.Matches(e) {
+			result = append(result, e)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b models.NotificationLogEntry) int {
+		return b.Timestamp.Compare(a.Timestamp)
+	})
+	if query.Limit > 0 && len(result) > query.Limit {
+		result = result[:query.Limit]
+	}
+	return result
+}
+
+func notificationSummary(entry models.NotificationLogEntry) string {
+	if entry.Status == models.NotificationStatusFailed {
+		return fmt.Sprintf("Notification of %d alert(s) to %s (%s) failed: %s", len(entry.Alerts), entry.Receiver, entry.Integration, entry.Error)
+	}
+	return fmt.Sprintf("Notification of %d alert(s) sent to %s (%s)", len(entry.Alerts), entry.Receiver, entry.Integration)
+}
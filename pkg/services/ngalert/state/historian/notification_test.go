@@ -0,0 +1,92 @@
+package historian
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/annotations"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func TestQueryNotificationPages(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	entry := func(minutesAgo int, team string) models.NotificationLogEntry {
+		return models.NotificationLogEntry{
+			Timestamp: now.Add(-time.Duration(minutesAgo) * time.Minute),
+			Alerts:    []models.NotificationAlert{{Labels: map[string]string{"team": team}}},
+		}
+	}
+	// stored is sorted from the most recent to the oldest, like the backends return attempts.
+	stored := make([]models.NotificationLogEntry, 0, notificationPageSize+2)
+	for i := 0; i < notificationPageSize; i++ {
+		stored = append(stored, entry(i, "a"))
+	}
+	stored = append(stored, entry(notificationPageSize, "b"), entry(notificationPageSize+1, "b"))
+
+	// fetch returns the attempts at or before the end of the range, like the backends do.
+	var requests []time.Time
+	fetch := func(from, to time.Time, limit int) ([]models.NotificationLogEntry, int, error) {
+		requests = append(requests, to)
+		var page []models.NotificationLogEntry
+		for _, e := range stored {
+			if !e.Timestamp.After(to) && !e.Timestamp.Before(from) && len(page) < limit {
+				page = append(page, e)
+			}
+		}
+		return page, len(page), nil
+	}
+
+	t.Run("should fetch older pages until the attempts that match alert labels are found", func(t *testing.T) {
+		requests = nil
+		result, err := queryNotificationPages(models.NotificationLogQuery{
+			Labels: map[string]string{"team": "b"},
+			From:   now.Add(-24 * time.Hour),
+			To:     now,
+			Limit:  10,
+		}, now, fetch)
+		require.NoError(t, err)
+		require.Equal(t, []models.NotificationLogEntry{stored[notificationPageSize], stored[notificationPageSize+1]}, result)
+		require.Len(t, requests, 2)
+		require.Equal(t, stored[notificationPageSize-1].Timestamp, requests[1])
+	})
+
+	t.Run("should fetch a single page of the size of the limit without alert labels", func(t *testing.T) {
+		requests = nil
+		result, err := queryNotificationPages(models.NotificationLogQuery{
+			From:  now.Add(-24 * time.Hour),
+			To:    now,
+			Limit: 3,
+		}, now, fetch)
+		require.NoError(t, err)
+		require.Equal(t, stored[:3], result)
+		require.Len(t, requests, 1)
+	})
+}
+
+func TestAnnotationBackend_QueryNotifications(t *testing.T) {
+	store := &interceptingAnnotationStore{}
+	sut := createTestAnnotationSutWithStore(t, store)
+
+	_, err := sut.QueryNotifications(context.Background(), models.NotificationLogQuery{
+		OrgID:       1,
+		Receiver:    "on-call",
+		Integration: "email",
+		Status:      models.NotificationStatusFailed,
+		Limit:       10,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{annotations.NotificationHistoryTag, "receiver:on-call", "integration:email", "status:failed"}, store.lastQuery.Tags)
+	require.EqualValues(t, 10, store.lastQuery.Limit)
+	require.NotZero(t, store.lastQuery.From)
+	require.NotZero(t, store.lastQuery.To)
+}
+
+func TestSQLBackend_QueryNotifications(t *testing.T) {
+	sut := &SQLBackend{}
+	_, err := sut.QueryNotifications(context.Background(), models.NotificationLogQuery{OrgID: 1})
+	require.ErrorIs(t, err, ErrNotificationHistoryUnsupported)
+}
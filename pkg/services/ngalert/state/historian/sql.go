@@ -0,0 +1,251 @@
+package historian
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+	history_model "github.com/grafana/grafana/pkg/services/ngalert/state/historian/model"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// stateHistoryCleanupBatchSize is the number of state transitions that are deleted at once by the retention.
+const stateHistoryCleanupBatchSize = 100
+
+type StateHistoryStore interface {
+	SaveStateHistory(ctx context.Context, entries []models.StateHistoryEntry) error
+	QueryStateHistory(ctx context.Context, query models.HistoryQuery, folderUIDs []string) ([]models.StateHistoryEntry, error)
+}
+
+// SQLBackend is a state.Historian that records state history to dedicated tables of the Grafana database.
+// Notification attempts are not recorded by this backend.
+type SQLBackend struct {
+	store     StateHistoryStore
+	clock     clock.Clock
+	metrics   *metrics.Historian
+	log       log.Logger
+	ac        AccessControl
+	ruleStore RuleStore
+}
+
+func NewSQLBackend(logger log.Logger, store StateHistoryStore, metrics *metrics.Historian, ruleStore RuleStore, ac AccessControl) *SQLBackend {
+	return &SQLBackend{
+		store:     store,
+		clock:     clock.New(),
+		metrics:   metrics,
+		log:       logger,
+		ac:        ac,
+		ruleStore: ruleStore,
+	}
+}
+
+// Record writes a number of state transitions for a given rule to the Grafana database.
+func (h *SQLBackend) Record(ctx context.Context, rule history_model.RuleMeta, states []state.StateTransition) <-chan error {
+	logger := h.log.FromContext(ctx)
+	entries := StatesToHistoryEntries(rule, states, logger)
+
+	errCh := make(chan error, 1)
+	if len(entries) == 0 {
+		close(errCh)
+		return errCh
+	}
+
+	// This is a new background job, so let's create a brand new context for it.
+	// We want it to be isolated, i.e. we don't want grafana shutdowns to interrupt this work
+	// immediately but rather try to flush writes.
+	writeCtx := context.Background()
+	writeCtx, cancel := context.WithTimeout(writeCtx, StateHistoryWriteTimeout)
+	writeCtx = history_model.WithRuleData(writeCtx, rule)
+	writeCtx = trace.ContextWithSpan(writeCtx, trace.SpanFromContext(ctx))
+
+	go func(ctx context.Context) {
+		defer cancel()
+		defer close(errCh)
+		logger := h.log.FromContext(ctx)
+		logger.Debug("Saving state history batch", "samples", len(entries))
+		org := fmt.Sprint(rule.OrgID)
+		h.metrics.WritesTotal.WithLabelValues(org, "sql").Inc()
+		h.metrics.TransitionsTotal.WithLabelValues(org).Add(float64(len(entries)))
+
+		if err := h.store.SaveStateHistory(ctx, entries); err != nil {
+			logger.Error("Failed to save alert state history batch", "error", err)
+			h.metrics.WritesFailed.WithLabelValues(org, "sql").Inc()
+			h.metrics.TransitionsFailed.WithLabelValues(org).Add(float64(len(entries)))
+			errCh <- fmt.Errorf("failed to save alert state history batch: %w", err)
+			return
+		}
+		logger.Debug("Done saving alert state history batch", "samples", len(entries))
+	}(writeCtx)
+	return errCh
+}
+
+// Query retrieves state history from the Grafana database and formats it into a dataframe of the same shape as the
+// dataframe of the Loki backend.
+func (h *SQLBackend) Query(ctx context.Context, query models.HistoryQuery) (*data.Frame, error) {
+	uids, err := getFolderUIDsForFilter(ctx, h.ac, h.ruleStore, query)
+	if err != nil {
+		return nil, err
+	}
+
+	now := h.clock.Now().UTC()
+	if query.To.IsZero() {
+		query.To = now
+	}
+	if query.From.IsZero() {
+		query.From = now.Add(-defaultQueryRange)
+	}
+	if query.Limit < 1 {
+		query.Limit = defaultPageSize
+	}
+	if query.Limit > maximumPageSize {
+		query.Limit = maximumPageSize
+	}
+
+	entries, err := h.store.QueryStateHistory(ctx, query, uids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query state history: %w", err)
+	}
+	return historyEntriesToFrame(entries)
+}
+
+// RecordNotification does nothing, notification attempts are not recorded by this backend.
+func (h *SQLBackend) RecordNotification(_ context.Context, _ models.NotificationLogEntry) <-chan error {
+	errCh := make(chan error)
+	close(errCh)
+	return errCh
+}
+
+// QueryNotifications returns an error, notification attempts are not recorded by this backend.
+func (h *SQLBackend) QueryNotifications(_ context.Context, _ models.NotificationLogQuery) ([]models.NotificationLogEntry, error) {
+	return nil, ErrNotificationHistoryUnsupported.Errorf("notification attempts are not recorded by the SQL state history backend")
+}
+
+// StatesToHistoryEntries converts the state transitions of a rule that should be recorded to state history entries.
+func StatesToHistoryEntries(rule history_model.RuleMeta, states []state.StateTransition, logger log.Logger) []models.StateHistoryEntry {
+	entries := make([]models.StateHistoryEntry, 0, len(states))
+	for _, state := range states {
+		if !shouldRecord(state) {
+			continue
+		}
+
+		var values []byte
+		if blob := valuesAsDataBlob(state.State); blob != nil {
+			var err error
+			values, err = blob.MarshalJSON()
+			if err != nil {
+				logger.Error("Failed to construct history record for state, skipping", "error", err)
+				continue
+			}
+		}
+		sanitizedLabels := removePrivateLabels(state.Labels)
+		entry := models.StateHistoryEntry{
+			OrgID:        rule.OrgID,
+			RuleUID:      rule.UID,
+			RuleID:       rule.ID,
+			RuleTitle:    rule.Title,
+			RuleGroup:    rule.Group,
+			FolderUID:    rule.NamespaceUID,
+			DashboardUID: rule.DashboardUID,
+			PanelID:      rule.PanelID,
+			Condition:    rule.Condition,
+			Labels:       sanitizedLabels,
+			Fingerprint:  labelFingerprint(sanitizedLabels),
+			Previous:     state.PreviousFormatted(),
+			Current:      state.Formatted(),
+			State:        state.State.State.String(),
+			Values:       string(values),
+			Timestamp:    state.State.LastEvaluationTime,
+		}
+		if state.State.State == eval.Error && state.Error != nil {
+			entry.Error = state.Error.Error()
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// historyEntriesToFrame formats state history entries like the Loki backend: a line with the transition and the labels
+// of the stream the line would belong to in Loki.
+func historyEntriesToFrame(entries []models.StateHistoryEntry) (*data.Frame, error) {
+	frame := data.NewFrame("states")
+	lbls := data.Labels(map[string]string{})
+
+	times := make([]time.Time, 0, len(entries))
+	lines := make([]json.RawMessage, 0, len(entries))
+	labels := make([]json.RawMessage, 0, len(entries))
+	for _, e := range entries {
+		values := simplejson.New()
+		if e.Values != "" {
+			v, err := simplejson.NewJson([]byte(e.Values))
+			if err != nil {
+				return nil, fmt.Errorf("a transition has invalid values: %w", err)
+			}
+			values = v
+		}
+		line, err := json.Marshal(LokiEntry{
+			SchemaVersion:  1,
+			Previous:       e.Previous,
+			Current:        e.Current,
+			Error:          e.Error,
+			Values:         values,
+			Condition:      e.Condition,
+			DashboardUID:   e.DashboardUID,
+			PanelID:        e.PanelID,
+			Fingerprint:    e.Fingerprint,
+			RuleTitle:      e.RuleTitle,
+			RuleID:         e.RuleID,
+			RuleUID:        e.RuleUID,
+			InstanceLabels: e.Labels,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize transition: %w", err)
+		}
+		streamLbls, err := json.Marshal(map[string]string{
+			StateHistoryLabelKey: StateHistoryLabelValue,
+			OrgIDLabel:           fmt.Sprint(e.OrgID),
+			GroupLabel:           e.RuleGroup,
+			FolderUIDLabel:       e.FolderUID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize stream labels: %w", err)
+		}
+		times = append(times, e.Timestamp)
+		lines = append(lines, line)
+		labels = append(labels, streamLbls)
+	}
+
+	frame.Fields = append(frame.Fields, data.NewField(dfTime, lbls, times))
+	frame.Fields = append(frame.Fields, data.NewField(dfLine, lbls, lines))
+	frame.Fields = append(frame.Fields, data.NewField(dfLabels, lbls, labels))
+	return frame, nil
+}
+
+// SQLRetentionService deletes state history from the Grafana database that is older than the configured retention.
+type SQLRetentionService struct {
+	store  store.StateHistoryAdminStore
+	maxAge time.Duration
+}
+
+func ProvideSQLRetentionService(store *store.DBstore, cfg *setting.Cfg) *SQLRetentionService {
+	return &SQLRetentionService{store: store, maxAge: cfg.UnifiedAlerting.StateHistory.SQLMaxAge}
+}
+
+// DeleteExpired deletes the state transitions that are older than the retention. It returns the number of deleted transitions.
+func (s *SQLRetentionService) DeleteExpired(ctx context.Context) (int64, error) {
+	if s.maxAge <= 0 {
+		return 0, nil
+	}
+	return s.store.DeleteStateHistoryBefore(ctx, time.Now().Add(-s.maxAge), stateHistoryCleanupBatchSize)
+}
@@ -0,0 +1,165 @@
+package historian
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	acfakes "github.com/grafana/grafana/pkg/services/ngalert/accesscontrol/fakes"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests/fakes"
+)
+
+func TestStatesToHistoryEntries(t *testing.T) {
+	rule := createTestRule()
+	now := time.Now()
+
+	t.Run("skips transitions that should not be recorded", func(t *testing.T) {
+		states := []state.StateTransition{{PreviousState: eval.Normal, State: &state.State{State: eval.Normal}}}
+
+		entries := StatesToHistoryEntries(rule, states, log.NewNopLogger())
+
+		require.Empty(t, entries)
+	})
+
+	t.Run("converts transitions", func(t *testing.T) {
+		states := singleFromNormal(&state.State{
+			State:              eval.Alerting,
+			Labels:             data.Labels{"a": "b", "__private__": "c"},
+			LastEvaluationTime: now,
+			Values:             map[string]float64{"A": 1},
+		})
+
+		entries := StatesToHistoryEntries(rule, states, log.NewNopLogger())
+
+		require.Len(t, entries, 1)
+		e := entries[0]
+		require.Equal(t, rule.UID, e.RuleUID)
+		require.Equal(t, rule.NamespaceUID, e.FolderUID)
+		require.Equal(t, rule.Group, e.RuleGroup)
+		require.Equal(t, map[string]string{"a": "b"}, e.Labels)
+		require.NotEmpty(t, e.Fingerprint)
+		require.Equal(t, "Normal", e.Previous)
+		require.Equal(t, "Alerting", e.Current)
+		require.Equal(t, "Alerting", e.State)
+		require.JSONEq(t, `{"A": 1}`, e.Values)
+		require.Equal(t, now, e.Timestamp)
+	})
+
+	t.Run("keeps the error of an errored transition", func(t *testing.T) {
+		states := singleFromNormal(&state.State{State: eval.Error, Error: fmt.Errorf("oh no")})
+
+		entries := StatesToHistoryEntries(rule, states, log.NewNopLogger())
+
+		require.Len(t, entries, 1)
+		require.Equal(t, "oh no", entries[0].Error)
+	})
+}
+
+func TestSQLBackend(t *testing.T) {
+	t.Run("query formats entries like the Loki backend", func(t *testing.T) {
+		store := &fakeStateHistoryStore{}
+		sut := createTestSQLBackend(t, store)
+		ts := time.Unix(1000, 0)
+		store.entries = []models.StateHistoryEntry{{
+			OrgID:       1,
+			RuleUID:     "rule-uid",
+			RuleGroup:   "my-group",
+			FolderUID:   "my-folder",
+			Labels:      map[string]string{"a": "b"},
+			Fingerprint: "fp",
+			Previous:    "Normal",
+			Current:     "Alerting",
+			State:       "Alerting",
+			Values:      `{"A":1}`,
+			Timestamp:   ts,
+		}}
+
+		frame, err := sut.Query(context.Background(), models.HistoryQuery{OrgID: 1, State: "Alerting"})
+
+		require.NoError(t, err)
+		require.Equal(t, 1, frame.Rows())
+		require.Equal(t, ts, frame.Fields[0].At(0))
+
+		var entry LokiEntry
+		require.NoError(t, json.Unmarshal(frame.Fields[1].At(0).(json.RawMessage), &entry))
+		require.Equal(t, "Normal", entry.Previous)
+		require.Equal(t, "Alerting", entry.Current)
+		require.Equal(t, "rule-uid", entry.RuleUID)
+		require.Equal(t, map[string]string{"a": "b"}, entry.InstanceLabels)
+		require.Equal(t, float64(1), entry.Values.Get("A").MustFloat64())
+
+		var lbls map[string]string
+		require.NoError(t, json.Unmarshal(frame.Fields[2].At(0).(json.RawMessage), &lbls))
+		require.Equal(t, "my-folder", lbls[FolderUIDLabel])
+		require.Equal(t, "my-group", lbls[GroupLabel])
+
+		require.Len(t, store.queries, 1)
+		require.Equal(t, "Alerting", store.queries[0].State)
+		require.Equal(t, defaultPageSize, store.queries[0].Limit)
+		require.False(t, store.queries[0].From.IsZero())
+	})
+
+	t.Run("record saves entries", func(t *testing.T) {
+		store := &fakeStateHistoryStore{}
+		sut := createTestSQLBackend(t, store)
+		states := singleFromNormal(&state.State{State: eval.Alerting, Labels: data.Labels{"a": "b"}})
+
+		err := <-sut.Record(context.Background(), createTestRule(), states)
+
+		require.NoError(t, err)
+		require.Len(t, store.saved, 1)
+	})
+
+	t.Run("record returns error if store fails", func(t *testing.T) {
+		store := &fakeStateHistoryStore{err: fmt.Errorf("failed")}
+		sut := createTestSQLBackend(t, store)
+		states := singleFromNormal(&state.State{State: eval.Alerting})
+
+		err := <-sut.Record(context.Background(), createTestRule(), states)
+
+		require.ErrorContains(t, err, "failed")
+	})
+}
+
+func createTestSQLBackend(t *testing.T, store StateHistoryStore) *SQLBackend {
+	met := metrics.NewHistorianMetrics(prometheus.NewRegistry(), metrics.Subsystem)
+	rules := fakes.NewRuleStore(t)
+	ac := &acfakes.FakeRuleService{
+		CanReadAllRulesFunc: func(context.Context, identity.Requester) (bool, error) {
+			return true, nil
+		},
+	}
+	return NewSQLBackend(log.NewNopLogger(), store, met, rules, ac)
+}
+
+type fakeStateHistoryStore struct {
+	entries []models.StateHistoryEntry
+	saved   []models.StateHistoryEntry
+	queries []models.HistoryQuery
+	err     error
+}
+
+func (f *fakeStateHistoryStore) SaveStateHistory(_ context.Context, entries []models.StateHistoryEntry) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.saved = append(f.saved, entries...)
+	return nil
+}
+
+func (f *fakeStateHistoryStore) QueryStateHistory(_ context.Context, query models.HistoryQuery, _ []string) ([]models.StateHistoryEntry, error) {
+	f.queries = append(f.queries, query)
+	return f.entries, f.err
+}
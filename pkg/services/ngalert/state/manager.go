@@ -183,39 +183,7 @@ func (st *Manager) Warm(ctx context.Context, rulesReader RuleReader, instanceRea
 				continue
 			}
 
-			// nil safety.
-			annotations := ruleForEntry.Annotations
-			if annotations == nil {
-				annotations = make(map[string]string)
-			}
-
-			lbs := map[string]string(entry.Labels)
-			cacheID := entry.Labels.Fingerprint()
-			var resultFp data.Fingerprint
-			if entry.ResultFingerprint != "" {
-				fp, err := strconv.ParseUint(entry.ResultFingerprint, 16, 64)
-				if err != nil {
-					st.log.Error("Failed to parse result fingerprint of alert instance", "error", err, "ruleUID", entry.RuleUID)
-				}
-				resultFp = data.Fingerprint(fp)
-			}
-			state := State{
-				AlertRuleUID:         entry.RuleUID,
-				OrgID:                entry.RuleOrgID,
-				CacheID:              cacheID,
-				Labels:               lbs,
-				State:                translateInstanceState(entry.CurrentState),
-				StateReason:          entry.CurrentReason,
-				LastEvaluationString: "",
-				StartsAt:             entry.CurrentStateSince,
-				EndsAt:               entry.CurrentStateEnd,
-				LastEvaluationTime:   entry.LastEvalTime,
-				Annotations:          annotations,
-				ResultFingerprint:    resultFp,
-				ResolvedAt:           entry.ResolvedAt,
-				LastSentAt:           entry.LastSentAt,
-			}
-			st.cache.getOrAdd(state, st.log)
+			st.cache.getOrAdd(st.stateFromAlertInstance(entry, ruleForEntry), st.log)
 			statesCount++
 		}
 	}
@@ -223,6 +191,119 @@ func (st *Manager) Warm(ctx context.Context, rulesReader RuleReader, instanceRea
 	st.log.Info("State cache has been initialized", "states", statesCount, "duration", time.Since(startTime))
 }
 
+// LoadStateForRule replaces the cached state of the rule with the state stored in the instance store.
+// It is used when another instance hands the evaluation of the rule over to this one.
+func (st *Manager) LoadStateForRule(ctx context.Context, rule *ngModels.AlertRule) {
+	if st.instanceStore == nil {
+		return
+	}
+	logger := st.log.FromContext(ctx).New(rule.GetKey().LogContext()...)
+	alertInstances, err := st.instanceStore.ListAlertInstances(ctx, &ngModels.ListAlertInstancesQuery{
+		RuleOrgID: rule.OrgID,
+		RuleUID:   rule.UID,
+	})
+	if err != nil {
+		logger.Error("Unable to fetch state of the rule", "error", err)
+		return
+	}
+	st.cache.removeByRuleUID(rule.OrgID, rule.UID)
+	for _, entry := range alertInstances {
+		st.cache.getOrAdd(st.stateFromAlertInstance(entry, rule), st.log)
+	}
+	logger.Debug("State of the rule has been loaded", "states", len(alertInstances))
+}
+
+// ReleaseStateForRule saves the cached state of the rule to the instance store and removes it from the cache.
+// It is used when this instance hands the evaluation of the rule over to another one.
+func (st *Manager) ReleaseStateForRule(ctx context.Context, ruleKey ngModels.AlertRuleKeyWithGroup) {
+	states := st.cache.removeByRuleUID(ruleKey.OrgID, ruleKey.UID)
+	if st.instanceStore == nil || len(states) == 0 {
+		return
+	}
+	logger := st.log.FromContext(ctx).New(ruleKey.LogContext()...)
+	instances := make([]ngModels.AlertInstance, 0, len(states))
+	for _, s := range states {
+		if st.doNotSaveNormalState && IsNormalStateWithNoReason(s) {
+			continue
+		}
+		instance, err := alertInstanceFromState(s)
+		if err != nil {
+			logger.Error("Failed to create a key for alert state to save it to database. The state will be ignored ", "cacheID", s.CacheID, "error", err, "labels", s.Labels.String())
+			continue
+		}
+		instances = append(instances, instance)
+	}
+	if err := st.instanceStore.SaveAlertInstancesForRule(ctx, ruleKey, instances); err != nil {
+		logger.Error("Failed to save state of the rule", "error", err)
+		return
+	}
+	logger.Debug("State of the rule has been released", "states", len(instances))
+}
+
+// HasStoredFiringAlerts returns true if any alert instance of the rule in the instance store is firing.
+// It is used for rules that are evaluated by another instance, whose state is not in the cache.
+func (st *Manager) HasStoredFiringAlerts(ctx context.Context, ruleKey ngModels.AlertRuleKey) (bool, error) {
+	if st.instanceStore == nil {
+		return false, nil
+	}
+	alertInstances, err := st.instanceStore.ListAlertInstances(ctx, &ngModels.ListAlertInstancesQuery{
+		RuleOrgID: ruleKey.OrgID,
+		RuleUID:   ruleKey.UID,
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, instance := range alertInstances {
+		if instance.CurrentState == ngModels.InstanceStateFiring {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ForgetStateForRule removes the state of the rule from the cache without changing the instance store.
+// It is used when the rule is evaluated by another instance.
+func (st *Manager) ForgetStateForRule(ruleKey ngModels.AlertRuleKey) {
+	st.cache.removeByRuleUID(ruleKey.OrgID, ruleKey.UID)
+}
+
+// stateFromAlertInstance restores the state of an alert instance loaded from the instance store.
+func (st *Manager) stateFromAlertInstance(entry *ngModels.AlertInstance, rule *ngModels.AlertRule) State {
+	// nil safety.
+	annotations := rule.Annotations
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	lbs := map[string]string(entry.Labels)
+	cacheID := entry.Labels.Fingerprint()
+	var resultFp data.Fingerprint
+	if entry.ResultFingerprint != "" {
+		fp, err := strconv.ParseUint(entry.ResultFingerprint, 16, 64)
+		if err != nil {
+			st.log.Error("Failed to parse result fingerprint of alert instance", "error", err, "ruleUID", entry.RuleUID)
+		}
+		resultFp = data.Fingerprint(fp)
+	}
+	return State{
+		AlertRuleUID:             entry.RuleUID,
+		OrgID:                    entry.RuleOrgID,
+		CacheID:                  cacheID,
+		Labels:                   lbs,
+		State:                    translateInstanceState(entry.CurrentState),
+		StateReason:              entry.CurrentReason,
+		LastEvaluationString:     "",
+		StartsAt:                 entry.CurrentStateSince,
+		EndsAt:                   entry.CurrentStateEnd,
+		LastEvaluationTime:       entry.LastEvalTime,
+		Annotations:              annotations,
+		ResultFingerprint:        resultFp,
+		ResolvedAt:               entry.ResolvedAt,
+		LastSentAt:               entry.LastSentAt,
+		MissingSeriesEvaluations: entry.MissingSeriesEvaluations,
+	}
+}
+
 func (st *Manager) Get(orgID int64, alertRuleUID string, stateId data.Fingerprint) *State {
 	return st.cache.get(orgID, alertRuleUID, stateId)
 }
@@ -308,6 +389,31 @@ func (st *Manager) ProcessEvalResults(
 	results eval.Results,
 	extraLabels data.Labels,
 	send Sender,
+) StateTransitions {
+	return st.processEvalResults(ctx, evaluatedAt, alertRule, results, extraLabels, false, send)
+}
+
+// ProcessSuppressedEvalResults updates the current states of the rule like ProcessEvalResults, but does not send them.
+// It is used when an upstream rule is firing. Firing and pending states are marked with the Suppressed state reason,
+// so that the suppression is persisted and recorded in the state history.
+func (st *Manager) ProcessSuppressedEvalResults(
+	ctx context.Context,
+	evaluatedAt time.Time,
+	alertRule *ngModels.AlertRule,
+	results eval.Results,
+	extraLabels data.Labels,
+) StateTransitions {
+	return st.processEvalResults(ctx, evaluatedAt, alertRule, results, extraLabels, true, nil)
+}
+
+func (st *Manager) processEvalResults(
+	ctx context.Context,
+	evaluatedAt time.Time,
+	alertRule *ngModels.AlertRule,
+	results eval.Results,
+	extraLabels data.Labels,
+	suppressed bool,
+	send Sender,
 ) StateTransitions {
 	utcTick := evaluatedAt.UTC().Format(time.RFC3339Nano)
 	ctx, span := st.tracer.Start(ctx, "alert rule state calculation", trace.WithAttributes(
@@ -322,13 +428,16 @@ func (st *Manager) ProcessEvalResults(
 	logger.Debug("State manager processing evaluation results", "resultCount", len(results))
 	states := st.setNextStateForRule(ctx, alertRule, results, extraLabels, logger)
 
-	staleStates := st.deleteStaleStatesFromCache(ctx, logger, evaluatedAt, alertRule)
+	staleStates := st.deleteStaleStatesFromCache(ctx, logger, evaluatedAt, alertRule, results)
 	span.AddEvent("results processed", trace.WithAttributes(
 		attribute.Int64("state_transitions", int64(len(states))),
 		attribute.Int64("stale_states", int64(len(staleStates))),
 	))
 
 	allChanges := StateTransitions(append(states, staleStates...))
+	for _, t := range allChanges {
+		t.StateReason = suppressedStateReason(t.State, suppressed)
+	}
 
 	// It's important that this is done *before* we sync the states to the persister. Otherwise, we will not persist
 	// the LastSentAt field to the store.
@@ -351,6 +460,21 @@ func (st *Manager) ProcessEvalResults(
 	return allChanges
 }
 
+// suppressedStateReason returns the reason of the state with the Suppressed reason added, if the state is firing or pending
+// and its notifications are suppressed, or removed otherwise.
+func suppressedStateReason(s *State, suppressed bool) string {
+	var reasons []string
+	for _, r := range strings.Split(s.StateReason, ", ") {
+		if r != "" && r != ngModels.StateReasonSuppressed {
+			reasons = append(reasons, r)
+		}
+	}
+	if suppressed && (s.State == eval.Alerting || s.State == eval.Pending) {
+		reasons = append(reasons, ngModels.StateReasonSuppressed)
+	}
+	return ngModels.ConcatReasons(reasons...)
+}
+
 // updateLastSentAt returns the subset StateTransitions that need sending and updates their LastSentAt field.
 // Note: This is not idempotent, running this twice can (and usually will) return different results.
 func (st *Manager) updateLastSentAt(states StateTransitions, evaluatedAt time.Time) StateTransitions {
@@ -432,6 +556,7 @@ func (st *Manager) setNextState(ctx context.Context, alertRule *ngModels.AlertRu
 
 	currentState.LastEvaluationTime = result.EvaluatedAt
 	currentState.EvaluationDuration = result.EvaluationDuration
+	currentState.MissingSeriesEvaluations = 0
 	currentState.SetNextValues(result)
 	currentState.LatestResult = &Evaluation{
 		EvaluationTime:  result.EvaluatedAt,
@@ -574,11 +699,17 @@ func translateInstanceState(state ngModels.InstanceStateType) eval.State {
 	}
 }
 
-func (st *Manager) deleteStaleStatesFromCache(ctx context.Context, logger log.Logger, evaluatedAt time.Time, alertRule *ngModels.AlertRule) []StateTransition {
+func (st *Manager) deleteStaleStatesFromCache(ctx context.Context, logger log.Logger, evaluatedAt time.Time, alertRule *ngModels.AlertRule, results eval.Results) []StateTransition {
+	var missingStates []StateTransition
+	// A NoData or Error result of the whole rule says nothing about its series, so they are not counted as missing.
+	if alertRule.MissingSeriesEvalsToAlert > 0 && !isRuleResult(results) {
+		missingStates = st.setMissingSeriesStates(ctx, logger, evaluatedAt, alertRule)
+	}
+
 	// If we are removing two or more stale series it makes sense to share the resolved image as the alert rule is the same.
 	// TODO: We will need to change this when we support images without screenshots as each series will have a different image
 	staleStates := st.cache.deleteRuleStates(alertRule.GetKey(), func(s *State) bool {
-		return stateIsStale(evaluatedAt, s.LastEvaluationTime, alertRule.IntervalSeconds)
+		return stateIsStale(evaluatedAt, s.LastEvaluationTime, alertRule.IntervalSeconds) || missingSeriesIsResolved(evaluatedAt, s, alertRule)
 	})
 	resolvedStates := make([]StateTransition, 0, len(staleStates))
 
@@ -612,7 +743,82 @@ func (st *Manager) deleteStaleStatesFromCache(ctx context.Context, logger log.Lo
 		}
 		resolvedStates = append(resolvedStates, record)
 	}
-	return resolvedStates
+	return append(missingStates, resolvedStates...)
+}
+
+// setMissingSeriesStates updates the states of the series that are missing from the results of a rule that alerts on
+// missing series, so they are not deleted as stale. A series that is missing for the number of evaluations configured
+// in the rule fires with its last known labels until the rule returns it again, or until it has been missing for
+// MissingSeriesEvalsToResolve further evaluations. Then its state is left to be resolved and deleted as stale.
+func (st *Manager) setMissingSeriesStates(ctx context.Context, logger log.Logger, evaluatedAt time.Time, alertRule *ngModels.AlertRule) []StateTransition {
+	var transitions []StateTransition
+	for _, s := range st.cache.getStatesForRuleUID(alertRule.OrgID, alertRule.UID, false) {
+		if !s.LastEvaluationTime.Before(evaluatedAt) || !isSeriesState(s) {
+			continue
+		}
+		s.MissingSeriesEvaluations++
+		if missingSeriesIsResolved(evaluatedAt, s, alertRule) {
+			continue
+		}
+		oldState := s.State
+		oldReason := s.StateReason
+
+		s.LastEvaluationTime = evaluatedAt
+		if s.MissingSeriesEvaluations >= alertRule.MissingSeriesEvalsToAlert && oldReason != ngModels.StateReasonMissingSeries {
+			logger.Info("Detected missing series", "cacheID", s.CacheID, "state", s.State, "evaluations", s.MissingSeriesEvaluations)
+			if oldState == eval.Alerting {
+				s.StateReason = ngModels.StateReasonMissingSeries
+				s.Maintain(alertRule.IntervalSeconds, evaluatedAt)
+			} else {
+				s.SetAlerting(ngModels.StateReasonMissingSeries, evaluatedAt, nextEndsTime(alertRule.IntervalSeconds, evaluatedAt))
+				s.ResolvedAt = nil
+			}
+		} else if oldState == eval.Alerting {
+			s.Maintain(alertRule.IntervalSeconds, evaluatedAt)
+		}
+
+		if shouldTakeImage(s.State, oldState, s.Image, false) {
+			image, err := takeImage(ctx, st.images, alertRule)
+			if err != nil {
+				logger.Warn("Failed to take an image",
+					"dashboard", alertRule.GetDashboardUID(),
+					"panel", alertRule.GetPanelID(),
+					"error", err)
+			} else if image != nil {
+				s.Image = image
+			}
+		}
+
+		st.cache.set(s)
+		transitions = append(transitions, StateTransition{
+			State:               s,
+			PreviousState:       oldState,
+			PreviousStateReason: oldReason,
+		})
+	}
+	return transitions
+}
+
+// missingSeriesIsResolved returns true if the series of the state was not in the results of the evaluation, and it has
+// been missing for long enough for its missing series alert to be resolved.
+func missingSeriesIsResolved(evaluatedAt time.Time, s *State, alertRule *ngModels.AlertRule) bool {
+	return alertRule.MissingSeriesEvalsToAlert > 0 &&
+		s.LastEvaluationTime.Before(evaluatedAt) &&
+		s.MissingSeriesEvaluations >= alertRule.MissingSeriesEvalsToAlert+ngModels.MissingSeriesEvalsToResolve
+}
+
+// isRuleResult returns true if the results are a single NoData or Error result of the whole rule rather than series.
+func isRuleResult(results eval.Results) bool {
+	return len(results) == 1 && (results[0].State == eval.NoData || results[0].State == eval.Error)
+}
+
+// isSeriesState returns true if the state belongs to a series returned by the rule, rather than being the result of
+// NoData or an error.
+func isSeriesState(s *State) bool {
+	if s.State == eval.Error || s.State == eval.NoData {
+		return false
+	}
+	return s.StateReason == "" || s.StateReason == ngModels.StateReasonMissingSeries
 }
 
 func stateIsStale(evaluatedAt time.Time, lastEval time.Time, intervalSeconds int64) bool {
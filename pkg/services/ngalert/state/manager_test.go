@@ -1692,6 +1692,115 @@ func printAllAnnotations(annos map[int64]annotations.Item) string {
 	return b.String()
 }
 
+func TestProcessSuppressedEvalResults(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewMock()
+	historian := &state.FakeHistorian{}
+	cfg := state.ManagerCfg{
+		Metrics:       metrics.NewNGAlert(prometheus.NewPedanticRegistry()).GetStateMetrics(),
+		InstanceStore: &state.FakeInstanceStore{},
+		Images:        &state.NoopImageService{},
+		Clock:         clk,
+		Historian:     historian,
+		Tracer:        tracing.InitializeTracerForTest(),
+		Log:           log.New("ngalert.state.manager"),
+	}
+	st := state.NewManager(cfg, state.NewNoopPersister())
+
+	gen := models.RuleGen
+	rule := gen.With(gen.WithFor(0)).GenerateRef()
+	results := func() eval.Results {
+		return eval.Results{
+			eval.ResultGen(eval.WithState(eval.Alerting), eval.WithEvaluatedAt(clk.Now()), eval.WithLabels(data.Labels{"instance": "firing"}))(),
+			eval.ResultGen(eval.WithState(eval.Normal), eval.WithEvaluatedAt(clk.Now()), eval.WithLabels(data.Labels{"instance": "normal"}))(),
+		}
+	}
+	reasons := func() map[string]string {
+		r := make(map[string]string)
+		for _, s := range st.GetStatesForRuleUID(rule.OrgID, rule.UID) {
+			r[s.Labels["instance"]] = s.StateReason
+		}
+		return r
+	}
+
+	transitions := st.ProcessSuppressedEvalResults(ctx, clk.Now(), rule, results(), nil)
+	require.Len(t, transitions, 2)
+	require.Equal(t, map[string]string{"firing": models.StateReasonSuppressed, "normal": ""}, reasons())
+	require.Len(t, historian.StateTransitions, 2)
+
+	t.Run("should keep the reason and not record a transition while suppressed", func(t *testing.T) {
+		historian.StateTransitions = nil
+		clk.Add(time.Duration(rule.IntervalSeconds) * time.Second)
+		transitions := st.ProcessSuppressedEvalResults(ctx, clk.Now(), rule, results(), nil)
+		for _, tr := range transitions {
+			require.False(t, tr.Changed())
+		}
+		require.Equal(t, map[string]string{"firing": models.StateReasonSuppressed, "normal": ""}, reasons())
+	})
+
+	t.Run("should remove the reason and send the firing state when no longer suppressed", func(t *testing.T) {
+		historian.StateTransitions = nil
+		clk.Add(time.Duration(rule.IntervalSeconds) * time.Second)
+		var sent state.StateTransitions
+		_ = st.ProcessEvalResults(ctx, clk.Now(), rule, results(), nil, func(_ context.Context, states state.StateTransitions) {
+			sent = states
+		})
+		require.Equal(t, map[string]string{"firing": "", "normal": ""}, reasons())
+		require.Len(t, sent, 1)
+		require.Len(t, historian.StateTransitions, 2)
+		var recorded state.StateTransition
+		for _, tr := range historian.StateTransitions {
+			if tr.Labels["instance"] == "firing" {
+				recorded = tr
+			}
+		}
+		require.True(t, recorded.Changed())
+		require.Equal(t, models.StateReasonSuppressed, recorded.PreviousStateReason)
+	})
+}
+
+func TestHasStoredFiringAlerts(t *testing.T) {
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, 1)
+
+	const mainOrgID int64 = 1
+	firing := tests.CreateTestAlertRule(t, ctx, dbstore, 60, mainOrgID)
+	normal := tests.CreateTestAlertRule(t, ctx, dbstore, 60, mainOrgID)
+	for _, instance := range []models.AlertInstance{
+		{
+			AlertInstanceKey: models.AlertInstanceKey{RuleOrgID: firing.OrgID, RuleUID: firing.UID, LabelsHash: "1"},
+			CurrentState:     models.InstanceStateFiring,
+			Labels:           models.InstanceLabels{"test": "1"},
+		},
+		{
+			AlertInstanceKey: models.AlertInstanceKey{RuleOrgID: normal.OrgID, RuleUID: normal.UID, LabelsHash: "2"},
+			CurrentState:     models.InstanceStateNormal,
+			Labels:           models.InstanceLabels{"test": "2"},
+		},
+	} {
+		require.NoError(t, dbstore.SaveAlertInstance(ctx, instance))
+	}
+
+	cfg := state.ManagerCfg{
+		Metrics:       metrics.NewNGAlert(prometheus.NewPedanticRegistry()).GetStateMetrics(),
+		InstanceStore: dbstore,
+		Images:        &state.NoopImageService{},
+		Clock:         clock.NewMock(),
+		Historian:     &state.FakeHistorian{},
+		Tracer:        tracing.InitializeTracerForTest(),
+		Log:           log.New("ngalert.state.manager"),
+	}
+	st := state.NewManager(cfg, state.NewNoopPersister())
+
+	ok, err := st.HasStoredFiringAlerts(ctx, firing.GetKey())
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = st.HasStoredFiringAlerts(ctx, normal.GetKey())
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
 func TestStaleResultsHandler(t *testing.T) {
 	evaluationTime := time.Now().Truncate(time.Second).UTC() // Truncate to the second since we don't store sub-second precision.
 	interval := time.Minute
@@ -1975,6 +2084,190 @@ func TestStaleResults(t *testing.T) {
 	})
 }
 
+func TestMissingSeries(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewMock()
+
+	cfg := state.ManagerCfg{
+		Metrics:       metrics.NewNGAlert(prometheus.NewPedanticRegistry()).GetStateMetrics(),
+		ExternalURL:   nil,
+		InstanceStore: &state.FakeInstanceStore{},
+		Images:        &state.NoopImageService{},
+		Clock:         clk,
+		Historian:     &state.FakeHistorian{},
+		Tracer:        tracing.InitializeTracerForTest(),
+		Log:           log.New("ngalert.state.manager"),
+	}
+	st := state.NewManager(cfg, state.NewNoopPersister())
+
+	gen := models.RuleGen
+	rule := gen.With(gen.WithFor(0), gen.WithMissingSeriesEvalsToAlert(2)).GenerateRef()
+	interval := time.Duration(rule.IntervalSeconds) * time.Second
+
+	present := eval.ResultGen(eval.WithState(eval.Normal))()
+	missing := eval.ResultGen(eval.WithState(eval.Normal))()
+
+	labels := data.Labels{}
+	for key, value := range rule.Labels {
+		labels[key] = value
+	}
+	for key, value := range missing.Instance {
+		labels[key] = value
+	}
+	lbls := models.InstanceLabels(labels)
+	missingID := lbls.Fingerprint()
+
+	evaluate := func(results ...eval.Result) map[data.Fingerprint]state.StateTransition {
+		t.Helper()
+		for i := range results {
+			results[i].EvaluatedAt = clk.Now()
+		}
+		transitions := st.ProcessEvalResults(ctx, clk.Now(), rule, results, nil, nil)
+		byID := make(map[data.Fingerprint]state.StateTransition, len(transitions))
+		for _, tr := range transitions {
+			byID[tr.CacheID] = tr
+		}
+		return byID
+	}
+
+	evaluate(present, missing)
+
+	t.Run("should keep the state of a missing series until the number of evaluations is reached", func(t *testing.T) {
+		clk.Add(interval)
+		transitions := evaluate(present)
+		require.Len(t, transitions, 2)
+		tr := transitions[missingID]
+		require.Equal(t, eval.Normal, tr.State.State)
+		require.Empty(t, tr.StateReason)
+		require.EqualValues(t, 1, tr.MissingSeriesEvaluations)
+	})
+
+	var startsAt time.Time
+	t.Run("should fire a missing series with its last known labels", func(t *testing.T) {
+		clk.Add(interval)
+		transitions := evaluate(present)
+		require.Len(t, transitions, 2)
+		tr := transitions[missingID]
+		require.Equal(t, eval.Normal, tr.PreviousState)
+		require.Equal(t, eval.Alerting, tr.State.State)
+		require.Equal(t, models.StateReasonMissingSeries, tr.StateReason)
+		require.Equal(t, clk.Now(), tr.StartsAt)
+		require.Len(t, st.GetStatesForRuleUID(rule.OrgID, rule.UID), 2)
+		startsAt = tr.StartsAt
+	})
+
+	t.Run("should keep firing while the series is missing, even if it is stale", func(t *testing.T) {
+		clk.Add(2 * interval)
+		transitions := evaluate(present)
+		require.Len(t, transitions, 2)
+		tr := transitions[missingID]
+		require.Equal(t, eval.Alerting, tr.State.State)
+		require.Equal(t, models.StateReasonMissingSeries, tr.StateReason)
+		require.Equal(t, startsAt, tr.StartsAt)
+		require.True(t, tr.EndsAt.After(clk.Now()))
+	})
+
+	t.Run("should resolve the alert when the series returns", func(t *testing.T) {
+		clk.Add(interval)
+		transitions := evaluate(present, missing)
+		require.Len(t, transitions, 2)
+		tr := transitions[missingID]
+		require.Equal(t, eval.Normal, tr.State.State)
+		require.Empty(t, tr.StateReason)
+		require.NotNil(t, tr.ResolvedAt)
+		require.Zero(t, tr.MissingSeriesEvaluations)
+	})
+
+	t.Run("should resolve and delete a missing series that does not return", func(t *testing.T) {
+		evals := rule.MissingSeriesEvalsToAlert + models.MissingSeriesEvalsToResolve
+		for i := int64(1); i < evals; i++ {
+			clk.Add(interval)
+			tr := evaluate(present)[missingID]
+			require.Equal(t, i, tr.MissingSeriesEvaluations)
+			if i >= rule.MissingSeriesEvalsToAlert {
+				require.Equal(t, eval.Alerting, tr.State.State)
+			}
+		}
+
+		clk.Add(interval)
+		transitions := evaluate(present)
+		require.Len(t, transitions, 2)
+		tr := transitions[missingID]
+		require.Equal(t, eval.Alerting, tr.PreviousState)
+		require.Equal(t, eval.Normal, tr.State.State)
+		require.Equal(t, models.StateReasonMissingSeries, tr.StateReason)
+		require.NotNil(t, tr.ResolvedAt)
+		require.Len(t, st.GetStatesForRuleUID(rule.OrgID, rule.UID), 1)
+	})
+}
+
+func TestMissingSeriesRuleResults(t *testing.T) {
+	ctx := context.Background()
+
+	for _, resultState := range []eval.State{eval.NoData, eval.Error} {
+		t.Run(fmt.Sprintf("should not count series as missing when the result is %s", resultState), func(t *testing.T) {
+			clk := clock.NewMock()
+			cfg := state.ManagerCfg{
+				Metrics:       metrics.NewNGAlert(prometheus.NewPedanticRegistry()).GetStateMetrics(),
+				ExternalURL:   nil,
+				InstanceStore: &state.FakeInstanceStore{},
+				Images:        &state.NoopImageService{},
+				Clock:         clk,
+				Historian:     &state.FakeHistorian{},
+				Tracer:        tracing.InitializeTracerForTest(),
+				Log:           log.New("ngalert.state.manager"),
+			}
+			st := state.NewManager(cfg, state.NewNoopPersister())
+
+			gen := models.RuleGen
+			rule := gen.With(gen.WithFor(0), gen.WithMissingSeriesEvalsToAlert(1)).GenerateRef()
+			interval := time.Duration(rule.IntervalSeconds) * time.Second
+
+			series := eval.ResultGen(eval.WithState(eval.Normal))()
+			labels := data.Labels{}
+			for key, value := range rule.Labels {
+				labels[key] = value
+			}
+			for key, value := range series.Instance {
+				labels[key] = value
+			}
+			lbls := models.InstanceLabels(labels)
+			seriesID := lbls.Fingerprint()
+
+			evaluate := func(result eval.Result) map[data.Fingerprint]state.StateTransition {
+				t.Helper()
+				result.EvaluatedAt = clk.Now()
+				transitions := st.ProcessEvalResults(ctx, clk.Now(), rule, eval.Results{result}, nil, nil)
+				byID := make(map[data.Fingerprint]state.StateTransition, len(transitions))
+				for _, tr := range transitions {
+					byID[tr.CacheID] = tr
+				}
+				return byID
+			}
+			ruleResult := eval.ResultGen(eval.WithState(resultState), eval.WithLabels(data.Labels{}))
+
+			evaluate(series)
+
+			clk.Add(interval)
+			transitions := evaluate(ruleResult())
+			require.NotContains(t, transitions, seriesID)
+			for _, s := range st.GetStatesForRuleUID(rule.OrgID, rule.UID) {
+				require.Zero(t, s.MissingSeriesEvaluations)
+				require.NotEqual(t, models.StateReasonMissingSeries, s.StateReason)
+			}
+
+			// the series is resolved as stale instead of firing as missing
+			clk.Add(interval)
+			transitions = evaluate(ruleResult())
+			require.Contains(t, transitions, seriesID)
+			tr := transitions[seriesID]
+			require.Equal(t, eval.Normal, tr.State.State)
+			require.Equal(t, models.StateReasonMissingSeries, tr.StateReason)
+			require.Len(t, st.GetStatesForRuleUID(rule.OrgID, rule.UID), 1)
+		})
+	}
+}
+
 func TestDeleteStateByRuleUID(t *testing.T) {
 	interval := time.Minute
 	ctx := context.Background()
@@ -95,16 +95,17 @@ func (a *SyncStatePersister) saveAlertStates(ctx context.Context, states ...Stat
 			return nil
 		}
 		instance := ngModels.AlertInstance{
-			AlertInstanceKey:  key,
-			Labels:            ngModels.InstanceLabels(s.Labels),
-			CurrentState:      ngModels.InstanceStateType(s.State.State.String()),
-			CurrentReason:     s.StateReason,
-			LastEvalTime:      s.LastEvaluationTime,
-			CurrentStateSince: s.StartsAt,
-			CurrentStateEnd:   s.EndsAt,
-			ResolvedAt:        s.ResolvedAt,
-			LastSentAt:        s.LastSentAt,
-			ResultFingerprint: s.ResultFingerprint.String(),
+			AlertInstanceKey:         key,
+			Labels:                   ngModels.InstanceLabels(s.Labels),
+			CurrentState:             ngModels.InstanceStateType(s.State.State.String()),
+			CurrentReason:            s.StateReason,
+			LastEvalTime:             s.LastEvaluationTime,
+			CurrentStateSince:        s.StartsAt,
+			CurrentStateEnd:          s.EndsAt,
+			ResolvedAt:               s.ResolvedAt,
+			LastSentAt:               s.LastSentAt,
+			ResultFingerprint:        s.ResultFingerprint.String(),
+			MissingSeriesEvaluations: s.MissingSeriesEvaluations,
 		}
 
 		err = a.store.SaveAlertInstance(ctx, instance)
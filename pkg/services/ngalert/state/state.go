@@ -73,6 +73,10 @@ type State struct {
 	LastEvaluationString string
 	LastEvaluationTime   time.Time
 	EvaluationDuration   time.Duration
+
+	// MissingSeriesEvaluations is the number of consecutive evaluations the series of the state has been missing from
+	// the results of the rule. It is only counted for rules that alert on missing series.
+	MissingSeriesEvaluations int64
 }
 
 func (a *State) GetRuleKey() models.AlertRuleKey {
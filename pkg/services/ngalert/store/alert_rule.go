@@ -16,6 +16,7 @@ import (
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/infra/db"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/auditlog"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/folder"
@@ -40,7 +41,25 @@ var (
 // DeleteAlertRulesByUID is a handler for deleting an alert rule.
 func (st DBstore) DeleteAlertRulesByUID(ctx context.Context, orgID int64, ruleUID ...string) error {
 	logger := st.Logger.New("org_id", orgID, "rule_uids", ruleUID)
-	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+	var deleted []alertRule
+	err := st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		if auditlog.Recording(ctx) {
+			if err := sess.Table(alertRule{}).Where("org_id = ?", orgID).In("uid", ruleUID).Find(&deleted); err != nil {
+				return err
+			}
+		}
+
+		dependents, err := st.removeDependenciesOn(sess, orgID, ruleUID)
+		if err != nil {
+			return err
+		}
+		if len(dependents) > 0 {
+			logger.Info("Removed dependencies on deleted alert rules", "dependent_rules", len(dependents))
+			_ = st.Bus.Publish(ctx, &RuleChangeEvent{
+				RuleKeys: dependents,
+			})
+		}
+
 		rows, err := sess.Table(alertRule{}).Where("org_id = ?", orgID).In("uid", ruleUID).Delete(alertRule{})
 		if err != nil {
 			return err
@@ -69,6 +88,19 @@ func (st DBstore) DeleteAlertRulesByUID(ctx context.Context, orgID int64, ruleUI
 		logger.Debug("Deleted alert instances", "count", rows)
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	for _, r := range deleted {
+		rule, err := alertRuleToModelsAlertRule(r, logger)
+		if err != nil {
+			continue
+		}
+		auditlog.AddChange(ctx, rule.UID, alertRuleAuditState(&rule), nil)
+	}
+	setRulesAuditResource(ctx, ruleUID)
+	return nil
 }
 
 // IncreaseVersionForAllRulesInNamespaces Increases version for all rules that have specified namespace. Returns all rules that belong to the namespaces
@@ -183,7 +215,8 @@ func (st DBstore) GetAlertRulesGroupByRuleUID(ctx context.Context, query *ngmode
 func (st DBstore) InsertAlertRules(ctx context.Context, rules []ngmodels.AlertRule) ([]ngmodels.AlertRuleKeyWithId, error) {
 	ids := make([]ngmodels.AlertRuleKeyWithId, 0, len(rules))
 	keys := make([]ngmodels.AlertRuleKey, 0, len(rules))
-	return ids, st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+	inserted := make([]ngmodels.AlertRule, 0, len(rules))
+	err := st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
 		newRules := make([]alertRule, 0, len(rules))
 		ruleVersions := make([]alertRuleVersion, 0, len(rules))
 		for i := range rules {
@@ -209,6 +242,7 @@ func (st DBstore) InsertAlertRules(ctx context.Context, rules []ngmodels.AlertRu
 			}
 			newRules = append(newRules, converted)
 			ruleVersions = append(ruleVersions, alertRuleToAlertRuleVersion(converted))
+			inserted = append(inserted, r)
 		}
 		if len(newRules) > 0 {
 			// we have to insert the rules one by one as otherwise we are
@@ -228,6 +262,10 @@ func (st DBstore) InsertAlertRules(ctx context.Context, rules []ngmodels.AlertRu
 			}
 		}
 
+		if err := st.validateRuleDependencies(sess, keys); err != nil {
+			return err
+		}
+
 		if len(ruleVersions) > 0 {
 			if _, err := sess.Insert(&ruleVersions); err != nil {
 				return fmt.Errorf("failed to create new rule versions: %w", err)
@@ -241,11 +279,22 @@ func (st DBstore) InsertAlertRules(ctx context.Context, rules []ngmodels.AlertRu
 		}
 		return nil
 	})
+	if err != nil {
+		return ids, err
+	}
+
+	uids := make([]string, 0, len(inserted))
+	for i := range inserted {
+		auditlog.AddChange(ctx, inserted[i].UID, nil, alertRuleAuditState(&inserted[i]))
+		uids = append(uids, inserted[i].UID)
+	}
+	setRulesAuditResource(ctx, uids)
+	return ids, nil
 }
 
 // UpdateAlertRules is a handler for updating alert rules.
 func (st DBstore) UpdateAlertRules(ctx context.Context, rules []ngmodels.UpdateRule) error {
-	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+	err := st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
 		err := st.preventIntermediateUniqueConstraintViolations(sess, rules)
 		if err != nil {
 			return fmt.Errorf("failed when preventing intermediate unique constraint violation: %w", err)
@@ -282,9 +331,14 @@ func (st DBstore) UpdateAlertRules(ctx context.Context, rules []ngmodels.UpdateR
 			v := alertRuleToAlertRuleVersion(converted)
 			v.Version++
 			v.ParentVersion = r.Existing.Version
+			v.RestoredFrom = r.RestoredFrom
 			ruleVersions = append(ruleVersions, v)
 			keys = append(keys, ngmodels.AlertRuleKey{OrgID: r.New.OrgID, UID: r.New.UID})
 		}
+
+		if err := st.validateRuleDependencies(sess, keys); err != nil {
+			return err
+		}
 		if len(ruleVersions) > 0 {
 			if _, err := sess.Insert(&ruleVersions); err != nil {
 				return fmt.Errorf("failed to create new rule versions: %w", err)
@@ -305,6 +359,72 @@ func (st DBstore) UpdateAlertRules(ctx context.Context, rules []ngmodels.UpdateR
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	uids := make([]string, 0, len(rules))
+	for i := range rules {
+		auditlog.AddChange(ctx, rules[i].New.UID, alertRuleAuditState(rules[i].Existing), alertRuleAuditState(&rules[i].New))
+		uids = append(uids, rules[i].New.UID)
+	}
+	setRulesAuditResource(ctx, uids)
+	return nil
+}
+
+// setRulesAuditResource records the alert rule changed by the request being recorded, if it changed a single rule.
+func setRulesAuditResource(ctx context.Context, uids []string) {
+	if len(uids) == 1 {
+		auditlog.SetResource(ctx, auditKindAlertRules, uids[0])
+	}
+}
+
+// GetAlertRuleVersions returns the stored versions of the alert rule identified by the query, most recent first.
+// Returns models.ErrAlertRuleNotFound if there are no versions of the rule.
+func (st DBstore) GetAlertRuleVersions(ctx context.Context, query *ngmodels.GetAlertRuleVersionsQuery) (result []*ngmodels.AlertRuleVersion, err error) {
+	err = st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		versions := make([]alertRuleVersion, 0)
+		err := sess.Table(alertRuleVersion{}).Where("rule_org_id = ? AND rule_uid = ?", query.OrgID, query.UID).Desc("version", "id").Find(&versions)
+		if err != nil {
+			return err
+		}
+		if len(versions) == 0 {
+			return ngmodels.ErrAlertRuleNotFound
+		}
+		result = make([]*ngmodels.AlertRuleVersion, 0, len(versions))
+		for _, v := range versions {
+			converted, err := alertRuleVersionToModelsAlertRuleVersion(v, st.Logger)
+			if err != nil {
+				st.Logger.Error("Invalid rule version found in DB store, ignoring it", "func", "GetAlertRuleVersions", "rule_uid", v.RuleUID, "version", v.Version, "error", err)
+				continue
+			}
+			result = append(result, &converted)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// GetAlertRuleVersion returns the given version of the alert rule identified by the query.
+// Returns models.ErrAlertRuleVersionNotFound if the version does not exist or was already deleted.
+func (st DBstore) GetAlertRuleVersion(ctx context.Context, query *ngmodels.GetAlertRuleVersionsQuery, version int64) (result *ngmodels.AlertRuleVersion, err error) {
+	err = st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		v := alertRuleVersion{}
+		has, err := sess.Table(alertRuleVersion{}).Where("rule_org_id = ? AND rule_uid = ? AND version = ?", query.OrgID, query.UID, version).Desc("id").Get(&v)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return ngmodels.ErrAlertRuleVersionNotFound
+		}
+		converted, err := alertRuleVersionToModelsAlertRuleVersion(v, st.Logger)
+		if err != nil {
+			return fmt.Errorf("failed to convert alert rule version %d of rule %s: %w", version, query.UID, err)
+		}
+		result = &converted
+		return nil
+	})
+	return result, err
 }
 
 func (st DBstore) deleteOldAlertRuleVersions(ctx context.Context, ruleUID string, orgID int64, limit int) (int64, error) {
@@ -821,6 +941,96 @@ func (st DBstore) validateAlertRule(alertRule ngmodels.AlertRule) error {
 	return nil
 }
 
+// validateRuleDependencies checks that the dependencies of the given rules reference existing rules, and that the
+// dependencies between rules of their organizations do not form a cycle.
+// The check is skipped for organizations without rules that have dependencies.
+func (st DBstore) validateRuleDependencies(sess *db.Session, keys []ngmodels.AlertRuleKey) error {
+	orgs := make(map[int64]struct{})
+	for _, key := range keys {
+		orgs[key.OrgID] = struct{}{}
+	}
+	for orgID := range orgs {
+		hasDependencies, err := sess.Table(alertRule{}).Where("org_id = ? AND dependencies IS NOT NULL AND dependencies <> ''", orgID).Exist()
+		if err != nil {
+			return fmt.Errorf("failed to check rule dependencies: %w", err)
+		}
+		if !hasDependencies {
+			continue
+		}
+		rules := make([]alertRule, 0)
+		if err := sess.Table(alertRule{}).Where("org_id = ?", orgID).Find(&rules); err != nil {
+			return fmt.Errorf("failed to fetch rules to check dependencies: %w", err)
+		}
+		converted := make([]*ngmodels.AlertRule, 0, len(rules))
+		for _, rule := range rules {
+			r, err := alertRuleToModelsAlertRule(rule, st.Logger)
+			if err != nil {
+				st.Logger.Error("Invalid rule found in DB store, ignoring it", "func", "validateRuleDependencies", "error", err)
+				continue
+			}
+			converted = append(converted, &r)
+		}
+		if err := ngmodels.ValidateRuleDependencies(converted, keys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeDependenciesOn removes the dependencies on the given rules from the other rules of the organization,
+// so that deleting an upstream rule does not leave its dependent rules referencing a rule that does not exist.
+// Every updated rule gets a new version. It returns the keys of the updated rules.
+func (st DBstore) removeDependenciesOn(sess *db.Session, orgID int64, uids []string) ([]ngmodels.AlertRuleKey, error) {
+	rules := make([]alertRule, 0)
+	err := sess.Table(alertRule{}).Where("org_id = ? AND dependencies IS NOT NULL AND dependencies <> ''", orgID).NotIn("uid", uids).Find(&rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rules with dependencies: %w", err)
+	}
+
+	var keys []ngmodels.AlertRuleKey
+	ruleVersions := make([]alertRuleVersion, 0)
+	for _, rule := range rules {
+		var dependencies []ngmodels.RuleDependency
+		if err := json.Unmarshal([]byte(rule.Dependencies), &dependencies); err != nil {
+			st.Logger.Error("Invalid rule dependencies found in DB store, ignoring them", "func", "removeDependenciesOn", "rule_uid", rule.UID, "error", err)
+			continue
+		}
+		dependencies, removed := ngmodels.WithoutRuleUIDs(dependencies, uids)
+		if !removed {
+			continue
+		}
+
+		rule.Dependencies = ""
+		if len(dependencies) > 0 {
+			b, err := json.Marshal(dependencies)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal dependencies of rule %s: %w", rule.UID, err)
+			}
+			rule.Dependencies = string(b)
+		}
+		rule.Updated = TimeNow()
+		parentVersion := rule.Version
+		// xorm increases the version of the rule
+		if updated, err := sess.ID(rule.ID).Cols("dependencies", "updated").Update(&rule); err != nil || updated == 0 {
+			if err != nil {
+				return nil, fmt.Errorf("failed to remove dependencies of rule %s: %w", rule.UID, err)
+			}
+			return nil, fmt.Errorf("%w: alert rule UID %s version %d", ErrOptimisticLock, rule.UID, parentVersion)
+		}
+		v := alertRuleToAlertRuleVersion(rule)
+		v.ParentVersion = parentVersion
+		ruleVersions = append(ruleVersions, v)
+		keys = append(keys, ngmodels.AlertRuleKey{OrgID: rule.OrgID, UID: rule.UID})
+	}
+
+	if len(ruleVersions) > 0 {
+		if _, err := sess.Insert(&ruleVersions); err != nil {
+			return nil, fmt.Errorf("failed to create new rule versions: %w", err)
+		}
+	}
+	return keys, nil
+}
+
 // ListNotificationSettings fetches all notification settings for given organization
 func (st DBstore) ListNotificationSettings(ctx context.Context, q ngmodels.ListNotificationSettingsQuery) (map[ngmodels.AlertRuleKey][]ngmodels.NotificationSettings, error) {
 	var rules []alertRule
@@ -881,21 +1091,53 @@ func (st DBstore) ListNotificationSettings(ctx context.Context, q ngmodels.ListN
 	return result, nil
 }
 
+// ListRulesByActiveTimeInterval returns the keys of the rules of the organization that are evaluated only during the given time interval.
+func (st DBstore) ListRulesByActiveTimeInterval(ctx context.Context, orgID int64, timeInterval string) ([]ngmodels.AlertRuleKey, error) {
+	var rules []alertRule
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		query, err := st.filterByContentInColumn("active_time_intervals", timeInterval, sess.Table(alertRule{}).Select("uid, active_time_intervals").Where("org_id = ?", orgID))
+		if err != nil {
+			return err
+		}
+		return query.Find(&rules)
+	})
+	if err != nil {
+		return nil, err
+	}
+	var result []ngmodels.AlertRuleKey
+	for _, rule := range rules {
+		var intervals []string
+		if err := json.Unmarshal([]byte(rule.ActiveTimeIntervals), &intervals); err != nil {
+			return nil, fmt.Errorf("failed to parse active time intervals of rule %s: %w", rule.UID, err)
+		}
+		// remove false-positive hits of the content filter
+		if slices.Contains(intervals, timeInterval) {
+			result = append(result, ngmodels.AlertRuleKey{OrgID: orgID, UID: rule.UID})
+		}
+	}
+	return result, nil
+}
+
 func (st DBstore) filterByContentInNotificationSettings(value string, sess *xorm.Session) (*xorm.Session, error) {
+	return st.filterByContentInColumn("notification_settings", value, sess)
+}
+
+// filterByContentInColumn filters rules by a string value in a column that contains JSON.
+func (st DBstore) filterByContentInColumn(column string, value string, sess *xorm.Session) (*xorm.Session, error) {
 	if value == "" {
 		return sess, nil
 	}
 	// marshall string according to JSON rules so we follow escaping rules.
 	b, err := json.Marshal(value)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshall string for notification settings content filter: %w", err)
+		return nil, fmt.Errorf("failed to marshall string for %s content filter: %w", column, err)
 	}
 	var search = string(b)
 	if st.SQLStore.GetDialect().DriverName() != migrator.SQLite {
 		// this escapes escaped double quote (\") to \\\"
 		search = strings.ReplaceAll(strings.ReplaceAll(search, `\`, `\\`), `"`, `\"`)
 	}
-	return sess.And(fmt.Sprintf("notification_settings %s ?", st.SQLStore.GetDialect().LikeStr()), "%"+search+"%"), nil
+	return sess.And(fmt.Sprintf("%s %s ?", column, st.SQLStore.GetDialect().LikeStr()), "%"+search+"%"), nil
 }
 
 func (st DBstore) RenameReceiverInNotificationSettings(ctx context.Context, orgID int64, oldReceiver, newReceiver string, validateProvenance func(ngmodels.Provenance) bool, dryRun bool) ([]ngmodels.AlertRuleKey, []ngmodels.AlertRuleKey, error) {
@@ -958,7 +1200,8 @@ func (st DBstore) RenameReceiverInNotificationSettings(ctx context.Context, orgI
 	return result, nil, st.UpdateAlertRules(ctx, updates)
 }
 
-// RenameTimeIntervalInNotificationSettings renames all rules that use old time interval name to the new name.
+// RenameTimeIntervalInNotificationSettings renames all rules that use old time interval name to the new name,
+// both in their notification settings and in their active time intervals.
 // Before renaming, it checks that all rules that need to be updated have allowed provenance status, and skips updating
 // if at least one rule does not have allowed provenance.
 // It returns a tuple:
@@ -980,6 +1223,25 @@ func (st DBstore) RenameTimeIntervalInNotificationSettings(
 	if err != nil {
 		return nil, nil, err
 	}
+	active, err := st.ListRulesByActiveTimeInterval(ctx, orgID, oldTimeInterval)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(active) > 0 {
+		uids := make([]string, 0, len(active))
+		for _, key := range active {
+			if !slices.ContainsFunc(rules, func(rule *ngmodels.AlertRule) bool { return rule.UID == key.UID }) {
+				uids = append(uids, key.UID)
+			}
+		}
+		if len(uids) > 0 {
+			activeRules, err := st.ListAlertRules(ctx, &ngmodels.ListAlertRulesQuery{OrgID: orgID, RuleUIDs: uids})
+			if err != nil {
+				return nil, nil, err
+			}
+			rules = append(rules, activeRules...)
+		}
+	}
 	if len(rules) == 0 {
 		return nil, nil, nil
 	}
@@ -1018,6 +1280,11 @@ func (st DBstore) RenameTimeIntervalInNotificationSettings(
 				}
 			}
 		}
+		for idx := range r.ActiveTimeIntervals {
+			if r.ActiveTimeIntervals[idx] == oldTimeInterval {
+				r.ActiveTimeIntervals[idx] = newTimeInterval
+			}
+		}
 
 		updates = append(updates, ngmodels.UpdateRule{
 			Existing: rule,
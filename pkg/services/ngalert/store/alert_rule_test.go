@@ -1141,6 +1141,104 @@ func TestIntegrationAlertRulesNotificationSettings(t *testing.T) {
 	})
 }
 
+func TestIntegrationListRulesByActiveTimeInterval(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	sqlStore := db.InitTestDB(t)
+	folderService := setupFolderService(t, sqlStore, setting.NewCfg(), featuremgmt.WithFeatures())
+	cfg := setting.NewCfg()
+	cfg.UnifiedAlerting.BaseInterval = 1 * time.Second
+	store := createTestStore(sqlStore, folderService, log.New("test-dbstore"), cfg.UnifiedAlerting, &fakeBus{})
+
+	searchName := `name-%"-👍'test`
+	gen := models.RuleGen
+	gen = gen.With(gen.WithOrgID(1), gen.WithIntervalMatching(store.Cfg.BaseInterval))
+
+	rulesWithInterval := gen.With(gen.WithActiveTimeIntervals("other", searchName)).GenerateMany(3)
+	rulesWithSimilarInterval := gen.With(gen.WithActiveTimeIntervals(searchName + "-2")).GenerateMany(2)
+	rulesWithoutInterval := gen.GenerateMany(2)
+	rulesInOtherOrg := gen.With(gen.WithOrgID(2), gen.WithActiveTimeIntervals(searchName)).GenerateMany(2)
+
+	rules := append(append(append(rulesWithInterval, rulesWithSimilarInterval...), rulesWithoutInterval...), rulesInOtherOrg...)
+	_, err := store.InsertAlertRules(context.Background(), rules)
+	require.NoError(t, err)
+
+	result, err := store.ListRulesByActiveTimeInterval(context.Background(), 1, searchName)
+	require.NoError(t, err)
+	expected := make([]models.AlertRuleKey, 0, len(rulesWithInterval))
+	for _, rule := range rulesWithInterval {
+		expected = append(expected, rule.GetKey())
+	}
+	require.ElementsMatch(t, expected, result)
+
+	result, err = store.ListRulesByActiveTimeInterval(context.Background(), 1, "not-found")
+	require.NoError(t, err)
+	require.Empty(t, result)
+}
+
+func TestIntegrationRenameTimeIntervalInActiveTimeIntervals(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	sqlStore := db.InitTestDB(t)
+	folderService := setupFolderService(t, sqlStore, setting.NewCfg(), featuremgmt.WithFeatures())
+	cfg := setting.NewCfg()
+	cfg.UnifiedAlerting.BaseInterval = 1 * time.Second
+	store := createTestStore(sqlStore, folderService, log.New("test-dbstore"), cfg.UnifiedAlerting, &fakeBus{})
+
+	gen := models.RuleGen
+	gen = gen.With(gen.WithOrgID(1), gen.WithIntervalMatching(store.Cfg.BaseInterval), gen.WithNoNotificationSettings())
+	activeRules := gen.With(gen.WithActiveTimeIntervals("other", "business-hours")).GenerateMany(2)
+	bothRule := gen.With(
+		gen.WithActiveTimeIntervals("business-hours"),
+		gen.WithNotificationSettings(models.NotificationSettings{Receiver: "receiver", MuteTimeIntervals: []string{"business-hours"}}),
+	).Generate()
+	otherRule := gen.With(gen.WithActiveTimeIntervals("other")).Generate()
+	_, err := store.InsertAlertRules(context.Background(), append(activeRules, bothRule, otherRule))
+	require.NoError(t, err)
+
+	alwaysTrue := func(p models.Provenance) bool { return true }
+
+	t.Run("should not rename in dry run", func(t *testing.T) {
+		affected, invalidProvenance, err := store.RenameTimeIntervalInNotificationSettings(context.Background(), 1, "business-hours", "office-hours", alwaysTrue, true)
+		require.NoError(t, err)
+		require.Empty(t, invalidProvenance)
+		require.Len(t, affected, 3)
+
+		active, err := store.ListRulesByActiveTimeInterval(context.Background(), 1, "business-hours")
+		require.NoError(t, err)
+		require.Len(t, active, 3)
+	})
+
+	t.Run("should rename active time intervals and notification settings", func(t *testing.T) {
+		affected, invalidProvenance, err := store.RenameTimeIntervalInNotificationSettings(context.Background(), 1, "business-hours", "office-hours", alwaysTrue, false)
+		require.NoError(t, err)
+		require.Empty(t, invalidProvenance)
+		require.ElementsMatch(t, []models.AlertRuleKey{activeRules[0].GetKey(), activeRules[1].GetKey(), bothRule.GetKey()}, affected)
+
+		active, err := store.ListRulesByActiveTimeInterval(context.Background(), 1, "business-hours")
+		require.NoError(t, err)
+		require.Empty(t, active)
+
+		renamed, err := store.GetAlertRuleByUID(context.Background(), &models.GetAlertRuleByUIDQuery{OrgID: 1, UID: activeRules[0].UID})
+		require.NoError(t, err)
+		require.Equal(t, []string{"other", "office-hours"}, renamed.ActiveTimeIntervals)
+
+		renamed, err = store.GetAlertRuleByUID(context.Background(), &models.GetAlertRuleByUIDQuery{OrgID: 1, UID: bothRule.UID})
+		require.NoError(t, err)
+		require.Equal(t, []string{"office-hours"}, renamed.ActiveTimeIntervals)
+		require.Equal(t, []string{"office-hours"}, renamed.NotificationSettings[0].MuteTimeIntervals)
+
+		untouched, err := store.GetAlertRuleByUID(context.Background(), &models.GetAlertRuleByUIDQuery{OrgID: 1, UID: otherRule.UID})
+		require.NoError(t, err)
+		require.Equal(t, []string{"other"}, untouched.ActiveTimeIntervals)
+		require.EqualValues(t, 1, untouched.Version)
+	})
+}
+
 func TestIntegrationListNotificationSettings(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
@@ -1684,3 +1782,171 @@ func (f *fakeBus) Publish(ctx context.Context, msg bus.Msg) error {
 
 	return nil
 }
+
+func TestIntegration_GetAlertRuleVersions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	cfg := setting.NewCfg()
+	cfg.UnifiedAlerting = setting.UnifiedAlertingSettings{
+		BaseInterval: time.Duration(rand.Int63n(100)+1) * time.Second,
+	}
+	sqlStore := db.InitTestDB(t)
+	folderService := setupFolderService(t, sqlStore, cfg, featuremgmt.WithFeatures())
+	b := &fakeBus{}
+	store := createTestStore(sqlStore, folderService, &logtest.Fake{}, cfg.UnifiedAlerting, b)
+	generator := models.RuleGen
+	generator = generator.With(generator.WithIntervalMatching(store.Cfg.BaseInterval), generator.WithUniqueOrgID())
+
+	rule := createRule(t, store, generator)
+	author := models.UserUID("user:author")
+	updated := models.CopyRule(rule)
+	updated.Title = util.GenerateShortUID()
+	updated.UpdatedBy = &author
+	err := store.UpdateAlertRules(context.Background(), []models.UpdateRule{{
+		Existing: rule,
+		New:      *updated,
+	}})
+	require.NoError(t, err)
+
+	updated.Version++
+	restored := models.CopyRule(rule)
+	restored.Version = updated.Version
+	err = store.UpdateAlertRules(context.Background(), []models.UpdateRule{{
+		Existing:     updated,
+		New:          *restored,
+		RestoredFrom: updated.Version,
+	}})
+	require.NoError(t, err)
+
+	query := &models.GetAlertRuleVersionsQuery{UID: rule.UID, OrgID: rule.OrgID}
+
+	t.Run("should return versions most recent first", func(t *testing.T) {
+		versions, err := store.GetAlertRuleVersions(context.Background(), query)
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+
+		assert.Equal(t, rule.Version+2, versions[0].Rule.Version)
+		assert.Equal(t, rule.Title, versions[0].Rule.Title)
+		assert.Equal(t, rule.Version+1, versions[0].ParentVersion)
+		assert.Equal(t, rule.Version+1, versions[0].RestoredFrom)
+		assert.Nil(t, versions[0].Rule.UpdatedBy)
+
+		assert.Equal(t, rule.Version+1, versions[1].Rule.Version)
+		assert.Equal(t, updated.Title, versions[1].Rule.Title)
+		assert.Equal(t, rule.Version, versions[1].ParentVersion)
+		assert.Zero(t, versions[1].RestoredFrom)
+		require.NotNil(t, versions[1].Rule.UpdatedBy)
+		assert.Equal(t, author, *versions[1].Rule.UpdatedBy)
+	})
+
+	t.Run("should return a single version", func(t *testing.T) {
+		version, err := store.GetAlertRuleVersion(context.Background(), query, rule.Version+1)
+		require.NoError(t, err)
+		assert.Equal(t, updated.Title, version.Rule.Title)
+
+		_, err = store.GetAlertRuleVersion(context.Background(), query, rule.Version+10)
+		require.ErrorIs(t, err, models.ErrAlertRuleVersionNotFound)
+	})
+
+	t.Run("should return not found for unknown rule", func(t *testing.T) {
+		_, err := store.GetAlertRuleVersions(context.Background(), &models.GetAlertRuleVersionsQuery{UID: "unknown", OrgID: rule.OrgID})
+		require.ErrorIs(t, err, models.ErrAlertRuleNotFound)
+	})
+}
+
+func TestIntegration_RuleDependencies(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	sqlStore := db.InitTestDB(t)
+	cfg := setting.NewCfg()
+	cfg.UnifiedAlerting.BaseInterval = 1 * time.Second
+	folderService := setupFolderService(t, sqlStore, cfg, featuremgmt.WithFeatures())
+	store := createTestStore(sqlStore, folderService, log.New("test-dbstore"), cfg.UnifiedAlerting, &fakeBus{})
+
+	gen := models.RuleGen.With(
+		models.RuleGen.WithOrgID(1),
+		models.RuleGen.WithIntervalMatching(store.Cfg.BaseInterval),
+		models.RuleGen.WithNamespaceUID("folder"),
+		models.RuleGen.WithGroupName("group"),
+	)
+	upstream := gen.With(models.RuleGen.WithLabels(map[string]string{"team": "network"})).Generate()
+	dependent := gen.With(models.RuleGen.WithDependencies(models.RuleDependency{Matchers: []string{"team=network"}})).Generate()
+
+	_, err := store.InsertAlertRules(context.Background(), []models.AlertRule{upstream, dependent})
+	require.NoError(t, err)
+
+	t.Run("should store dependencies", func(t *testing.T) {
+		rule, err := store.GetAlertRuleByUID(context.Background(), &models.GetAlertRuleByUIDQuery{OrgID: 1, UID: dependent.UID})
+		require.NoError(t, err)
+		require.Equal(t, dependent.Dependencies, rule.Dependencies)
+	})
+
+	t.Run("should reject a rule that depends on a missing rule", func(t *testing.T) {
+		rule := gen.With(models.RuleGen.WithDependencies(models.RuleDependency{RuleUID: "missing"})).Generate()
+		_, err := store.InsertAlertRules(context.Background(), []models.AlertRule{rule})
+		require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+	})
+
+	t.Run("should accept a rule that depends on a rule of another rule group and reject cycles across groups", func(t *testing.T) {
+		rule := gen.With(
+			models.RuleGen.WithGroupName("other"),
+			models.RuleGen.WithDependencies(models.RuleDependency{RuleUID: upstream.UID}),
+		).Generate()
+		_, err := store.InsertAlertRules(context.Background(), []models.AlertRule{rule})
+		require.NoError(t, err)
+
+		existing, err := store.GetAlertRuleByUID(context.Background(), &models.GetAlertRuleByUIDQuery{OrgID: 1, UID: upstream.UID})
+		require.NoError(t, err)
+		updated := models.CopyRule(existing, models.RuleGen.WithDependencies(models.RuleDependency{RuleUID: rule.UID}))
+		err = store.UpdateAlertRules(context.Background(), []models.UpdateRule{{Existing: existing, New: *updated}})
+		require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+		require.ErrorContains(t, err, "cycle")
+	})
+
+	t.Run("should reject an update that creates a cycle", func(t *testing.T) {
+		existing, err := store.GetAlertRuleByUID(context.Background(), &models.GetAlertRuleByUIDQuery{OrgID: 1, UID: upstream.UID})
+		require.NoError(t, err)
+		updated := models.CopyRule(existing, models.RuleGen.WithDependencies(models.RuleDependency{RuleUID: dependent.UID}))
+
+		err = store.UpdateAlertRules(context.Background(), []models.UpdateRule{{Existing: existing, New: *updated}})
+		require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+		require.ErrorContains(t, err, "cycle")
+
+		rule, err := store.GetAlertRuleByUID(context.Background(), &models.GetAlertRuleByUIDQuery{OrgID: 1, UID: upstream.UID})
+		require.NoError(t, err)
+		require.Empty(t, rule.Dependencies)
+	})
+
+	t.Run("should remove dependencies on a deleted rule and keep accepting saves", func(t *testing.T) {
+		deleted := gen.Generate()
+		other := gen.Generate()
+		dependsOnDeleted := gen.With(models.RuleGen.WithDependencies(
+			models.RuleDependency{RuleUID: deleted.UID},
+			models.RuleDependency{Matchers: []string{"team=network"}},
+		)).Generate()
+		_, err := store.InsertAlertRules(context.Background(), []models.AlertRule{deleted, other, dependsOnDeleted})
+		require.NoError(t, err)
+		before, err := store.GetAlertRuleByUID(context.Background(), &models.GetAlertRuleByUIDQuery{OrgID: 1, UID: dependsOnDeleted.UID})
+		require.NoError(t, err)
+
+		require.NoError(t, store.DeleteAlertRulesByUID(context.Background(), 1, deleted.UID))
+
+		after, err := store.GetAlertRuleByUID(context.Background(), &models.GetAlertRuleByUIDQuery{OrgID: 1, UID: dependsOnDeleted.UID})
+		require.NoError(t, err)
+		require.Equal(t, []models.RuleDependency{{Matchers: []string{"team=network"}}}, after.Dependencies)
+		require.Equal(t, before.Version+1, after.Version)
+		versions, err := store.GetAlertRuleVersions(context.Background(), &models.GetAlertRuleVersionsQuery{OrgID: 1, UID: dependsOnDeleted.UID})
+		require.NoError(t, err)
+		require.Equal(t, after.Version, versions[0].Rule.Version)
+		require.Equal(t, before.Version, versions[0].ParentVersion)
+
+		existing, err := store.GetAlertRuleByUID(context.Background(), &models.GetAlertRuleByUIDQuery{OrgID: 1, UID: other.UID})
+		require.NoError(t, err)
+		updated := models.CopyRule(existing)
+		updated.Title = "updated after delete"
+		require.NoError(t, store.UpdateAlertRules(context.Background(), []models.UpdateRule{{Existing: existing, New: *updated}}))
+	})
+}
@@ -68,7 +68,13 @@ type SaveCallback func() error
 // SaveAlertmanagerConfigurationWithCallback creates an alertmanager configuration version and then executes a callback.
 // If the callback results in error it rolls back the transaction.
 func (st DBstore) SaveAlertmanagerConfigurationWithCallback(ctx context.Context, cmd *models.SaveAlertmanagerConfigurationCmd, callback SaveCallback) error {
-	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+	var previous string
+	err := st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		var err error
+		if previous, err = previousConfigurationForAudit(ctx, sess, cmd.OrgID); err != nil {
+			return err
+		}
+
 		config := models.AlertConfiguration{
 			AlertmanagerConfiguration: cmd.AlertmanagerConfiguration,
 			ConfigurationHash:         fmt.Sprintf("%x", md5.Sum([]byte(cmd.AlertmanagerConfiguration))),
@@ -105,11 +111,23 @@ func (st DBstore) SaveAlertmanagerConfigurationWithCallback(ctx context.Context,
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	recordContactPointChanges(ctx, previous, cmd.AlertmanagerConfiguration)
+	return nil
 }
 
 // UpdateAlertmanagerConfiguration replaces an alertmanager configuration with optimistic locking. It assumes that an existing revision of the configuration exists in the store, and will return an error otherwise.
 func (st *DBstore) UpdateAlertmanagerConfiguration(ctx context.Context, cmd *models.SaveAlertmanagerConfigurationCmd) error {
-	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+	var previous string
+	err := st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		var err error
+		if previous, err = previousConfigurationForAudit(ctx, sess, cmd.OrgID); err != nil {
+			return err
+		}
+
 		config := models.AlertConfiguration{
 			AlertmanagerConfiguration: cmd.AlertmanagerConfiguration,
 			ConfigurationHash:         fmt.Sprintf("%x", md5.Sum([]byte(cmd.AlertmanagerConfiguration))),
@@ -137,6 +155,12 @@ func (st *DBstore) UpdateAlertmanagerConfiguration(ctx context.Context, cmd *mod
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	recordContactPointChanges(ctx, previous, cmd.AlertmanagerConfiguration)
+	return nil
 }
 
 // MarkConfigurationAsApplied sets the `last_applied` field of the last config with the given hash to the current UNIX timestamp.
@@ -0,0 +1,122 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/auditlog"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+const auditKindAlertRules = "alert.rules"
+
+// alertRuleAuditState returns the fields of an alert rule recorded in the audit log.
+// Fields maintained by Grafana, such as the version and update time, are left out.
+func alertRuleAuditState(r *ngmodels.AlertRule) map[string]any {
+	if r == nil {
+		return nil
+	}
+	return map[string]any{
+		"title":                r.Title,
+		"folderUid":            r.NamespaceUID,
+		"ruleGroup":            r.RuleGroup,
+		"condition":            r.Condition,
+		"data":                 r.Data,
+		"intervalSeconds":      r.IntervalSeconds,
+		"record":               r.Record,
+		"noDataState":          r.NoDataState,
+		"execErrState":         r.ExecErrState,
+		"for":                  r.For.String(),
+		"annotations":          r.Annotations,
+		"labels":               r.Labels,
+		"isPaused":             r.IsPaused,
+		"notificationSettings": r.NotificationSettings,
+		"activeTimeIntervals":  r.ActiveTimeIntervals,
+		"dependencies":         r.Dependencies,
+	}
+}
+
+// contactPointsAuditState returns the contact points of an Alertmanager configuration recorded in the audit log,
+// by contact point name and integration UID. Only the names of secure settings are recorded, never their values.
+func contactPointsAuditState(configuration string) map[string]any {
+	var cfg struct {
+		AlertmanagerConfig struct {
+			Receivers []struct {
+				Name         string `json:"name"`
+				Integrations []struct {
+					UID                   string          `json:"uid"`
+					Name                  string          `json:"name"`
+					Type                  string          `json:"type"`
+					DisableResolveMessage bool            `json:"disableResolveMessage"`
+					Settings              json.RawMessage `json:"settings"`
+					SecureSettings        map[string]any  `json:"secureSettings"`
+				} `json:"grafana_managed_receiver_configs"`
+			} `json:"receivers"`
+		} `json:"alertmanager_config"`
+	}
+	if err := json.Unmarshal([]byte(configuration), &cfg); err != nil {
+		return nil
+	}
+
+	state := make(map[string]any, len(cfg.AlertmanagerConfig.Receivers))
+	for _, receiver := range cfg.AlertmanagerConfig.Receivers {
+		integrations := make(map[string]any, len(receiver.Integrations))
+		for _, integration := range receiver.Integrations {
+			secureFields := make([]string, 0, len(integration.SecureSettings))
+			for k := range integration.SecureSettings {
+				secureFields = append(secureFields, k)
+			}
+			sort.Strings(secureFields)
+
+			integrations[integration.UID] = map[string]any{
+				"name":                  integration.Name,
+				"type":                  integration.Type,
+				"disableResolveMessage": integration.DisableResolveMessage,
+				"settings":              integration.Settings,
+				"secureFields":          secureFields,
+			}
+		}
+		state[receiver.Name] = integrations
+	}
+	return state
+}
+
+// previousConfigurationForAudit returns the Alertmanager configuration about to be replaced,
+// only when the request is recorded by the audit log.
+func previousConfigurationForAudit(ctx context.Context, sess *db.Session, orgID int64) (string, error) {
+	if !auditlog.Recording(ctx) {
+		return "", nil
+	}
+
+	previous := ngmodels.AlertConfiguration{}
+	if _, err := sess.Table("alert_configuration").Where("org_id = ?", orgID).Get(&previous); err != nil {
+		return "", err
+	}
+	return previous.AlertmanagerConfiguration, nil
+}
+
+// recordContactPointChanges records the contact points changed between two Alertmanager configurations in the audit log.
+func recordContactPointChanges(ctx context.Context, before, after string) {
+	if !auditlog.Recording(ctx) {
+		return
+	}
+
+	b := contactPointsAuditState(before)
+	a := contactPointsAuditState(after)
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	for name := range b {
+		if _, ok := a[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		auditlog.AddChange(ctx, name, b[name], a[name])
+	}
+}
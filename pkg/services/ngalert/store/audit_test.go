@@ -0,0 +1,102 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/auditlog"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestIntegrationAlertRuleAuditLog(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	sqlStore := db.InitTestDB(t)
+	cfg := setting.NewCfg()
+	cfg.UnifiedAlerting.BaseInterval = 1 * time.Second
+	folderService := setupFolderService(t, sqlStore, cfg, featuremgmt.WithFeatures())
+	store := createTestStore(sqlStore, folderService, log.New("test-dbstore"), cfg.UnifiedAlerting, &fakeBus{})
+	gen := models.RuleGen.With(models.RuleGen.WithOrgID(1), models.RuleGen.WithIntervalMatching(store.Cfg.BaseInterval))
+
+	rule := gen.Generate()
+	rule.UID = "audited"
+	rule.Title = "created"
+
+	t.Run("should record created rules", func(t *testing.T) {
+		entry := &auditlog.Entry{}
+		_, err := store.InsertAlertRules(auditlog.WithEntry(context.Background(), entry), []models.AlertRule{rule})
+		require.NoError(t, err)
+
+		require.Equal(t, "alert.rules", entry.ResourceKind)
+		require.Equal(t, "audited", entry.ResourceUID)
+		require.Contains(t, entry.Changes, auditlog.Change{Path: "audited.title", After: "created"})
+	})
+
+	t.Run("should record the fields changed by updates", func(t *testing.T) {
+		existing, err := store.GetAlertRuleByUID(context.Background(), &models.GetAlertRuleByUIDQuery{OrgID: 1, UID: "audited"})
+		require.NoError(t, err)
+		updated := models.CopyRule(existing)
+		updated.Title = "updated"
+
+		entry := &auditlog.Entry{}
+		err = store.UpdateAlertRules(auditlog.WithEntry(context.Background(), entry), []models.UpdateRule{{Existing: existing, New: *updated}})
+		require.NoError(t, err)
+
+		require.Equal(t, []auditlog.Change{{Path: "audited.title", Before: "created", After: "updated"}}, entry.Changes)
+	})
+
+	t.Run("should record deleted rules", func(t *testing.T) {
+		entry := &auditlog.Entry{}
+		err := store.DeleteAlertRulesByUID(auditlog.WithEntry(context.Background(), entry), 1, "audited")
+		require.NoError(t, err)
+
+		require.Equal(t, "audited", entry.ResourceUID)
+		require.Contains(t, entry.Changes, auditlog.Change{Path: "audited.title", Before: "updated"})
+	})
+}
+
+func TestIntegrationContactPointAuditLog(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	store := &DBstore{
+		SQLStore: db.InitTestDB(t),
+		Logger:   log.NewNopLogger(),
+	}
+
+	configWithURL := func(url string) string {
+		return `{"alertmanager_config": {"receivers": [{"name": "ops", "grafana_managed_receiver_configs": [
+			{"uid": "webhook-uid", "name": "ops", "type": "webhook", "settings": {"url": "` + url + `"}, "secureSettings": {"password": "c2VjcmV0"}}
+		]}]}}`
+	}
+
+	require.NoError(t, store.SaveAlertmanagerConfiguration(context.Background(), &models.SaveAlertmanagerConfigurationCmd{
+		AlertmanagerConfiguration: configWithURL("http://before"),
+		OrgID:                     1,
+	}))
+
+	entry := &auditlog.Entry{}
+	err := store.SaveAlertmanagerConfiguration(auditlog.WithEntry(context.Background(), entry), &models.SaveAlertmanagerConfigurationCmd{
+		AlertmanagerConfiguration: configWithURL("http://after"),
+		OrgID:                     1,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []auditlog.Change{{Path: "ops.webhook-uid.settings.url", Before: "http://before", After: "http://after"}}, entry.Changes)
+
+	t.Run("should only record the names of secure settings", func(t *testing.T) {
+		state := contactPointsAuditState(configWithURL("http://after"))
+		integration := state["ops"].(map[string]any)["webhook-uid"].(map[string]any)
+		require.Equal(t, []string{"password"}, integration["secureFields"])
+	})
+}
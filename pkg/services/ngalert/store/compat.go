@@ -17,22 +17,28 @@ func alertRuleToModelsAlertRule(ar alertRule, l log.Logger) (models.AlertRule, e
 	}
 
 	result := models.AlertRule{
-		ID:              ar.ID,
-		OrgID:           ar.OrgID,
-		Title:           ar.Title,
-		Condition:       ar.Condition,
-		Data:            data,
-		Updated:         ar.Updated,
-		IntervalSeconds: ar.IntervalSeconds,
-		Version:         ar.Version,
-		UID:             ar.UID,
-		NamespaceUID:    ar.NamespaceUID,
-		DashboardUID:    ar.DashboardUID,
-		PanelID:         ar.PanelID,
-		RuleGroup:       ar.RuleGroup,
-		RuleGroupIndex:  ar.RuleGroupIndex,
-		For:             ar.For,
-		IsPaused:        ar.IsPaused,
+		ID:                        ar.ID,
+		OrgID:                     ar.OrgID,
+		Title:                     ar.Title,
+		Condition:                 ar.Condition,
+		Data:                      data,
+		Updated:                   ar.Updated,
+		IntervalSeconds:           ar.IntervalSeconds,
+		Version:                   ar.Version,
+		UID:                       ar.UID,
+		NamespaceUID:              ar.NamespaceUID,
+		DashboardUID:              ar.DashboardUID,
+		PanelID:                   ar.PanelID,
+		RuleGroup:                 ar.RuleGroup,
+		RuleGroupIndex:            ar.RuleGroupIndex,
+		For:                       ar.For,
+		IsPaused:                  ar.IsPaused,
+		MissingSeriesEvalsToAlert: ar.MissingSeriesEvalsToAlert,
+	}
+
+	if ar.UpdatedBy != nil {
+		updatedBy := models.UserUID(*ar.UpdatedBy)
+		result.UpdatedBy = &updatedBy
 	}
 
 	if ar.NoDataState != "" {
@@ -88,6 +94,20 @@ func alertRuleToModelsAlertRule(ar alertRule, l log.Logger) (models.AlertRule, e
 		}
 	}
 
+	if ar.ActiveTimeIntervals != "" {
+		err = json.Unmarshal([]byte(ar.ActiveTimeIntervals), &result.ActiveTimeIntervals)
+		if err != nil {
+			return models.AlertRule{}, fmt.Errorf("failed to parse active time intervals: %w", err)
+		}
+	}
+
+	if ar.Dependencies != "" {
+		err = json.Unmarshal([]byte(ar.Dependencies), &result.Dependencies)
+		if err != nil {
+			return models.AlertRule{}, fmt.Errorf("failed to parse dependencies: %w", err)
+		}
+	}
+
 	return result, nil
 }
 
@@ -101,23 +121,29 @@ func parseNotificationSettings(s string) ([]models.NotificationSettings, error)
 
 func alertRuleFromModelsAlertRule(ar models.AlertRule) (alertRule, error) {
 	result := alertRule{
-		ID:              ar.ID,
-		OrgID:           ar.OrgID,
-		Title:           ar.Title,
-		Condition:       ar.Condition,
-		Updated:         ar.Updated,
-		IntervalSeconds: ar.IntervalSeconds,
-		Version:         ar.Version,
-		UID:             ar.UID,
-		NamespaceUID:    ar.NamespaceUID,
-		DashboardUID:    ar.DashboardUID,
-		PanelID:         ar.PanelID,
-		RuleGroup:       ar.RuleGroup,
-		RuleGroupIndex:  ar.RuleGroupIndex,
-		NoDataState:     ar.NoDataState.String(),
-		ExecErrState:    ar.ExecErrState.String(),
-		For:             ar.For,
-		IsPaused:        ar.IsPaused,
+		ID:                        ar.ID,
+		OrgID:                     ar.OrgID,
+		Title:                     ar.Title,
+		Condition:                 ar.Condition,
+		Updated:                   ar.Updated,
+		IntervalSeconds:           ar.IntervalSeconds,
+		Version:                   ar.Version,
+		UID:                       ar.UID,
+		NamespaceUID:              ar.NamespaceUID,
+		DashboardUID:              ar.DashboardUID,
+		PanelID:                   ar.PanelID,
+		RuleGroup:                 ar.RuleGroup,
+		RuleGroupIndex:            ar.RuleGroupIndex,
+		NoDataState:               ar.NoDataState.String(),
+		ExecErrState:              ar.ExecErrState.String(),
+		For:                       ar.For,
+		IsPaused:                  ar.IsPaused,
+		MissingSeriesEvalsToAlert: ar.MissingSeriesEvalsToAlert,
+	}
+
+	if ar.UpdatedBy != nil {
+		updatedBy := string(*ar.UpdatedBy)
+		result.UpdatedBy = &updatedBy
 	}
 
 	// Serialize complex types to JSON strings
@@ -165,32 +191,93 @@ func alertRuleFromModelsAlertRule(ar models.AlertRule) (alertRule, error) {
 	}
 	result.Metadata = string(metadata)
 
+	if len(ar.ActiveTimeIntervals) > 0 {
+		activeTimeIntervals, err := json.Marshal(ar.ActiveTimeIntervals)
+		if err != nil {
+			return alertRule{}, fmt.Errorf("failed to marshal active time intervals: %w", err)
+		}
+		result.ActiveTimeIntervals = string(activeTimeIntervals)
+	}
+
+	if len(ar.Dependencies) > 0 {
+		dependencies, err := json.Marshal(ar.Dependencies)
+		if err != nil {
+			return alertRule{}, fmt.Errorf("failed to marshal dependencies: %w", err)
+		}
+		result.Dependencies = string(dependencies)
+	}
+
 	return result, nil
 }
 
 func alertRuleToAlertRuleVersion(rule alertRule) alertRuleVersion {
 	return alertRuleVersion{
-		RuleOrgID:            rule.OrgID,
-		RuleUID:              rule.UID,
-		RuleNamespaceUID:     rule.NamespaceUID,
-		RuleGroup:            rule.RuleGroup,
-		RuleGroupIndex:       rule.RuleGroupIndex,
-		ParentVersion:        0,
-		RestoredFrom:         0,
-		Version:              rule.Version,
-		Created:              rule.Updated, // assuming the Updated time as the creation time
-		Title:                rule.Title,
-		Condition:            rule.Condition,
-		Data:                 rule.Data,
-		IntervalSeconds:      rule.IntervalSeconds,
-		Record:               rule.Record,
-		NoDataState:          rule.NoDataState,
-		ExecErrState:         rule.ExecErrState,
-		For:                  rule.For,
-		Annotations:          rule.Annotations,
-		Labels:               rule.Labels,
-		IsPaused:             rule.IsPaused,
-		NotificationSettings: rule.NotificationSettings,
-		Metadata:             rule.Metadata,
+		RuleOrgID:                 rule.OrgID,
+		RuleUID:                   rule.UID,
+		RuleNamespaceUID:          rule.NamespaceUID,
+		RuleGroup:                 rule.RuleGroup,
+		RuleGroupIndex:            rule.RuleGroupIndex,
+		ParentVersion:             0,
+		RestoredFrom:              0,
+		Version:                   rule.Version,
+		Created:                   rule.Updated, // assuming the Updated time as the creation time
+		Title:                     rule.Title,
+		Condition:                 rule.Condition,
+		Data:                      rule.Data,
+		IntervalSeconds:           rule.IntervalSeconds,
+		Record:                    rule.Record,
+		NoDataState:               rule.NoDataState,
+		ExecErrState:              rule.ExecErrState,
+		For:                       rule.For,
+		Annotations:               rule.Annotations,
+		Labels:                    rule.Labels,
+		IsPaused:                  rule.IsPaused,
+		NotificationSettings:      rule.NotificationSettings,
+		Metadata:                  rule.Metadata,
+		ActiveTimeIntervals:       rule.ActiveTimeIntervals,
+		Dependencies:              rule.Dependencies,
+		MissingSeriesEvalsToAlert: rule.MissingSeriesEvalsToAlert,
+		CreatedBy:                 rule.UpdatedBy,
+	}
+}
+
+func alertRuleVersionToModelsAlertRuleVersion(v alertRuleVersion, l log.Logger) (models.AlertRuleVersion, error) {
+	rule, err := alertRuleToModelsAlertRule(alertRule{
+		OrgID:                     v.RuleOrgID,
+		Title:                     v.Title,
+		Condition:                 v.Condition,
+		Data:                      v.Data,
+		Updated:                   v.Created,
+		IntervalSeconds:           v.IntervalSeconds,
+		Version:                   v.Version,
+		UID:                       v.RuleUID,
+		NamespaceUID:              v.RuleNamespaceUID,
+		RuleGroup:                 v.RuleGroup,
+		RuleGroupIndex:            v.RuleGroupIndex,
+		Record:                    v.Record,
+		NoDataState:               v.NoDataState,
+		ExecErrState:              v.ExecErrState,
+		For:                       v.For,
+		Annotations:               v.Annotations,
+		Labels:                    v.Labels,
+		IsPaused:                  v.IsPaused,
+		NotificationSettings:      v.NotificationSettings,
+		Metadata:                  v.Metadata,
+		ActiveTimeIntervals:       v.ActiveTimeIntervals,
+		Dependencies:              v.Dependencies,
+		MissingSeriesEvalsToAlert: v.MissingSeriesEvalsToAlert,
+		UpdatedBy:                 v.CreatedBy,
+	}, l)
+	if err != nil {
+		return models.AlertRuleVersion{}, err
+	}
+	// the version table does not store the dashboard and panel, they are restored from the annotations
+	if err := rule.SetDashboardAndPanelFromAnnotations(); err != nil {
+		l.Warn("Invalid dashboard and panel annotations in alert rule version", append(rule.GetKey().LogContext(), "version", v.Version, "error", err)...)
 	}
+	return models.AlertRuleVersion{
+		Rule:          rule,
+		ParentVersion: v.ParentVersion,
+		RestoredFrom:  v.RestoredFrom,
+	}, nil
 }
@@ -74,12 +74,13 @@ func (st DBstore) SaveAlertInstance(ctx context.Context, alertInstance models.Al
 			nullableTimeToUnix(alertInstance.ResolvedAt),
 			nullableTimeToUnix(alertInstance.LastSentAt),
 			alertInstance.ResultFingerprint,
+			alertInstance.MissingSeriesEvaluations,
 		)
 
 		upsertSQL := st.SQLStore.GetDialect().UpsertSQL(
 			"alert_instance",
 			[]string{"rule_org_id", "rule_uid", "labels_hash"},
-			[]string{"rule_org_id", "rule_uid", "labels", "labels_hash", "current_state", "current_reason", "current_state_since", "current_state_end", "last_eval_time", "resolved_at", "last_sent_at", "result_fingerprint"})
+			[]string{"rule_org_id", "rule_uid", "labels", "labels_hash", "current_state", "current_reason", "current_state_since", "current_state_end", "last_eval_time", "resolved_at", "last_sent_at", "result_fingerprint", "missing_series_evaluations"})
 		_, err = sess.SQL(upsertSQL, params...).Query()
 		if err != nil {
 			return err
@@ -211,10 +212,48 @@ func (st DBstore) DeleteAlertInstances(ctx context.Context, keys ...models.Alert
 	return err
 }
 
-// SaveAlertInstancesForRule is not implemented for instance database store.
+// SaveAlertInstancesForRule replaces the alert instances of the rule with the given instances in one transaction.
 func (st DBstore) SaveAlertInstancesForRule(ctx context.Context, key models.AlertRuleKeyWithGroup, instances []models.AlertInstance) error {
-	st.Logger.Error("SaveAlertInstancesForRule is not implemented for instance database store.")
-	return errors.New("method SaveAlertInstancesForRule is not implemented for instance database store")
+	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		if _, err := sess.Exec("DELETE FROM alert_instance WHERE rule_org_id = ? AND rule_uid = ?", key.OrgID, key.UID); err != nil {
+			return fmt.Errorf("failed to delete alert instances of the rule: %w", err)
+		}
+		for _, alertInstance := range instances {
+			if alertInstance.RuleOrgID != key.OrgID || alertInstance.RuleUID != key.UID {
+				return fmt.Errorf("alert instance of rule %s does not belong to rule %s", alertInstance.RuleUID, key.UID)
+			}
+			if err := models.ValidateAlertInstance(alertInstance); err != nil {
+				st.Logger.Warn("Failed to validate alert instance, skipping", "err", err, "rule_uid", alertInstance.RuleUID)
+				continue
+			}
+			labelTupleJSON, err := alertInstance.Labels.StringKey()
+			if err != nil {
+				st.Logger.Warn("Failed to generate alert instance labels key, skipping", "err", err, "rule_uid", alertInstance.RuleUID)
+				continue
+			}
+
+			_, err = sess.Exec(
+				"INSERT INTO alert_instance (rule_org_id, rule_uid, labels, labels_hash, current_state, current_reason, current_state_since, current_state_end, last_eval_time, resolved_at, last_sent_at, result_fingerprint, missing_series_evaluations) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)",
+				alertInstance.RuleOrgID,
+				alertInstance.RuleUID,
+				labelTupleJSON,
+				alertInstance.LabelsHash,
+				alertInstance.CurrentState,
+				alertInstance.CurrentReason,
+				alertInstance.CurrentStateSince.Unix(),
+				alertInstance.CurrentStateEnd.Unix(),
+				alertInstance.LastEvalTime.Unix(),
+				nullableTimeToUnix(alertInstance.ResolvedAt),
+				nullableTimeToUnix(alertInstance.LastSentAt),
+				alertInstance.ResultFingerprint,
+				alertInstance.MissingSeriesEvaluations,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to insert into alert_instance table: %w", err)
+			}
+		}
+		return nil
+	})
 }
 
 func (st DBstore) DeleteAlertInstancesByRule(ctx context.Context, key models.AlertRuleKeyWithGroup) error {
@@ -245,7 +284,7 @@ func (st DBstore) FullSync(ctx context.Context, instances []models.AlertInstance
 			}
 
 			_, err = sess.Exec(
-				"INSERT INTO alert_instance (rule_org_id, rule_uid, labels, labels_hash, current_state, current_reason, current_state_since, current_state_end, last_eval_time, resolved_at, last_sent_at) VALUES (?,?,?,?,?,?,?,?,?,?,?)",
+				"INSERT INTO alert_instance (rule_org_id, rule_uid, labels, labels_hash, current_state, current_reason, current_state_since, current_state_end, last_eval_time, resolved_at, last_sent_at, missing_series_evaluations) VALUES (?,?,?,?,?,?,?,?,?,?,?,?)",
 				alertInstance.RuleOrgID,
 				alertInstance.RuleUID,
 				labelTupleJSON,
@@ -257,6 +296,7 @@ func (st DBstore) FullSync(ctx context.Context, instances []models.AlertInstance
 				alertInstance.LastEvalTime.Unix(),
 				nullableTimeToUnix(alertInstance.ResolvedAt),
 				nullableTimeToUnix(alertInstance.LastSentAt),
+				alertInstance.MissingSeriesEvaluations,
 			)
 			if err != nil {
 				return fmt.Errorf("failed to insert into alert_instance table: %w", err)
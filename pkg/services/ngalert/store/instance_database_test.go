@@ -95,9 +95,10 @@ func TestIntegrationAlertInstanceOperations(t *testing.T) {
 				RuleUID:    alertRule1.UID,
 				LabelsHash: hash,
 			},
-			CurrentState:  models.InstanceStateFiring,
-			CurrentReason: string(models.InstanceStateError),
-			Labels:        labels,
+			CurrentState:             models.InstanceStateFiring,
+			CurrentReason:            string(models.InstanceStateError),
+			Labels:                   labels,
+			MissingSeriesEvaluations: 3,
 		}
 		err := dbstore.SaveAlertInstance(ctx, instance)
 		require.NoError(t, err)
@@ -114,6 +115,7 @@ func TestIntegrationAlertInstanceOperations(t *testing.T) {
 		require.Equal(t, alertRule1.OrgID, alerts[0].RuleOrgID)
 		require.Equal(t, alertRule1.UID, alerts[0].RuleUID)
 		require.Equal(t, instance.CurrentReason, alerts[0].CurrentReason)
+		require.Equal(t, instance.MissingSeriesEvaluations, alerts[0].MissingSeriesEvaluations)
 	})
 
 	t.Run("can save and read new alert instance with no labels", func(t *testing.T) {
@@ -371,6 +373,74 @@ func TestIntegrationFullSync(t *testing.T) {
 	})
 }
 
+func TestIntegrationSaveAlertInstancesForRule(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+
+	orgID := int64(1)
+	ruleKey := models.AlertRuleKeyWithGroup{AlertRuleKey: models.AlertRuleKey{OrgID: orgID, UID: "a"}, RuleGroup: "group"}
+
+	stored := generateTestAlertInstance(orgID, "a")
+	other := generateTestAlertInstance(orgID, "b")
+	require.NoError(t, dbstore.SaveAlertInstance(ctx, stored))
+	require.NoError(t, dbstore.SaveAlertInstance(ctx, other))
+
+	t.Run("replaces the instances of the rule", func(t *testing.T) {
+		first := generateTestAlertInstance(orgID, "a")
+		first.LabelsHash = "first"
+		first.Labels = models.InstanceLabels{"instance": "first"}
+		second := generateTestAlertInstance(orgID, "a")
+		second.LabelsHash = "second"
+		second.Labels = models.InstanceLabels{"instance": "second"}
+		second.CurrentState = models.InstanceStatePending
+
+		require.NoError(t, dbstore.SaveAlertInstancesForRule(ctx, ruleKey, []models.AlertInstance{first, second}))
+
+		res, err := dbstore.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: orgID, RuleUID: "a"})
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		byHash := map[string]*models.AlertInstance{}
+		for _, instance := range res {
+			byHash[instance.LabelsHash] = instance
+		}
+		require.Contains(t, byHash, "first")
+		require.Contains(t, byHash, "second")
+		require.Equal(t, models.InstanceStatePending, byHash["second"].CurrentState)
+		require.Equal(t, second.Labels, byHash["second"].Labels)
+		require.Equal(t, second.ResultFingerprint, byHash["second"].ResultFingerprint)
+	})
+
+	t.Run("does not change the instances of other rules", func(t *testing.T) {
+		res, err := dbstore.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: orgID, RuleUID: "b"})
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		require.Equal(t, other.LabelsHash, res[0].LabelsHash)
+	})
+
+	t.Run("removes the instances of the rule when there are none", func(t *testing.T) {
+		require.NoError(t, dbstore.SaveAlertInstancesForRule(ctx, ruleKey, nil))
+
+		res, err := dbstore.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: orgID, RuleUID: "a"})
+		require.NoError(t, err)
+		require.Empty(t, res)
+	})
+
+	t.Run("rejects instances of other rules without changing the stored instances", func(t *testing.T) {
+		require.NoError(t, dbstore.SaveAlertInstance(ctx, stored))
+
+		err := dbstore.SaveAlertInstancesForRule(ctx, ruleKey, []models.AlertInstance{generateTestAlertInstance(orgID, "b")})
+		require.Error(t, err)
+
+		res, err := dbstore.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: orgID, RuleUID: "a"})
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		require.Equal(t, stored.LabelsHash, res[0].LabelsHash)
+	})
+}
+
 func generateTestAlertInstance(orgID int64, ruleID string) models.AlertInstance {
 	return models.AlertInstance{
 		AlertInstanceKey: models.AlertInstanceKey{
@@ -4,29 +4,33 @@ import "time"
 
 // alertRule represents a record in alert_rule table
 type alertRule struct {
-	ID                   int64 `xorm:"pk autoincr 'id'"`
-	OrgID                int64 `xorm:"org_id"`
-	Title                string
-	Condition            string
-	Data                 string
-	Updated              time.Time
-	IntervalSeconds      int64
-	Version              int64   `xorm:"version"` // this tag makes xorm add optimistic lock (see https://xorm.io/docs/chapter-06/1.lock/)
-	UID                  string  `xorm:"uid"`
-	NamespaceUID         string  `xorm:"namespace_uid"`
-	DashboardUID         *string `xorm:"dashboard_uid"`
-	PanelID              *int64  `xorm:"panel_id"`
-	RuleGroup            string
-	RuleGroupIndex       int `xorm:"rule_group_idx"`
-	Record               string
-	NoDataState          string
-	ExecErrState         string
-	For                  time.Duration
-	Annotations          string
-	Labels               string
-	IsPaused             bool
-	NotificationSettings string `xorm:"notification_settings"`
-	Metadata             string `xorm:"metadata"`
+	ID                        int64 `xorm:"pk autoincr 'id'"`
+	OrgID                     int64 `xorm:"org_id"`
+	Title                     string
+	Condition                 string
+	Data                      string
+	Updated                   time.Time
+	IntervalSeconds           int64
+	Version                   int64   `xorm:"version"` // this tag makes xorm add optimistic lock (see https://xorm.io/docs/chapter-06/1.lock/)
+	UID                       string  `xorm:"uid"`
+	NamespaceUID              string  `xorm:"namespace_uid"`
+	DashboardUID              *string `xorm:"dashboard_uid"`
+	PanelID                   *int64  `xorm:"panel_id"`
+	RuleGroup                 string
+	RuleGroupIndex            int `xorm:"rule_group_idx"`
+	Record                    string
+	NoDataState               string
+	ExecErrState              string
+	For                       time.Duration
+	Annotations               string
+	Labels                    string
+	IsPaused                  bool
+	NotificationSettings      string  `xorm:"notification_settings"`
+	Metadata                  string  `xorm:"metadata"`
+	ActiveTimeIntervals       string  `xorm:"active_time_intervals"`
+	Dependencies              string  `xorm:"dependencies"`
+	MissingSeriesEvalsToAlert int64   `xorm:"missing_series_evals_to_alert"`
+	UpdatedBy                 *string `xorm:"updated_by"`
 }
 
 func (a alertRule) TableName() string {
@@ -55,14 +59,113 @@ type alertRuleVersion struct {
 	ExecErrState    string
 	// ideally this field should have been apimodels.ApiDuration
 	// but this is currently not possible because of circular dependencies
-	For                  time.Duration
-	Annotations          string
-	Labels               string
-	IsPaused             bool
-	NotificationSettings string `xorm:"notification_settings"`
-	Metadata             string `xorm:"metadata"`
+	For                       time.Duration
+	Annotations               string
+	Labels                    string
+	IsPaused                  bool
+	NotificationSettings      string  `xorm:"notification_settings"`
+	Metadata                  string  `xorm:"metadata"`
+	ActiveTimeIntervals       string  `xorm:"active_time_intervals"`
+	Dependencies              string  `xorm:"dependencies"`
+	MissingSeriesEvalsToAlert int64   `xorm:"missing_series_evals_to_alert"`
+	CreatedBy                 *string `xorm:"created_by"`
 }
 
 func (a alertRuleVersion) TableName() string {
 	return "alert_rule_version"
 }
+
+// schedulerPeer represents a record in alert_scheduler_peer table
+type schedulerPeer struct {
+	ID            int64  `xorm:"pk autoincr 'id'"`
+	InstanceID    string `xorm:"instance_id"`
+	LastHeartbeat int64  `xorm:"last_heartbeat"`
+}
+
+func (p schedulerPeer) TableName() string {
+	return "alert_scheduler_peer"
+}
+
+// recurringSilence represents a record in alert_recurring_silence table
+type recurringSilence struct {
+	ID              int64     `xorm:"pk autoincr 'id'"`
+	OrgID           int64     `xorm:"org_id"`
+	UID             string    `xorm:"uid"`
+	Matchers        string    `xorm:"matchers"`
+	Comment         string    `xorm:"comment"`
+	CreatedBy       string    `xorm:"created_by"`
+	Schedule        string    `xorm:"schedule"`
+	DurationSeconds int64     `xorm:"duration_seconds"`
+	TimeZone        string    `xorm:"time_zone"`
+	TimeInterval    string    `xorm:"time_interval"`
+	ExpireOnResolve bool      `xorm:"expire_on_resolve"`
+	SilenceID       string    `xorm:"silence_id"`
+	WindowStart     int64     `xorm:"window_start"`
+	Updated         time.Time `xorm:"updated"`
+}
+
+func (s recurringSilence) TableName() string {
+	return "alert_recurring_silence"
+}
+
+// silenceExpiry represents a record in alert_silence_expiry table
+type silenceExpiry struct {
+	ID         int64  `xorm:"pk autoincr 'id'"`
+	OrgID      int64  `xorm:"org_id"`
+	SilenceID  string `xorm:"silence_id"`
+	AlertsSeen bool   `xorm:"alerts_seen"`
+}
+
+func (s silenceExpiry) TableName() string {
+	return "alert_silence_expiry"
+}
+
+// stateHistoryLabelSet represents a record in alert_state_history_label_set table
+type stateHistoryLabelSet struct {
+	ID          int64  `xorm:"pk autoincr 'id'"`
+	OrgID       int64  `xorm:"org_id"`
+	Fingerprint string `xorm:"fingerprint"`
+	Labels      string `xorm:"labels"`
+	Updated     int64  `xorm:"'updated'"`
+}
+
+func (s stateHistoryLabelSet) TableName() string {
+	return "alert_state_history_label_set"
+}
+
+// stateHistoryLabel represents a record in alert_state_history_label table
+type stateHistoryLabel struct {
+	ID         int64 `xorm:"pk autoincr 'id'"`
+	OrgID      int64 `xorm:"org_id"`
+	LabelSetID int64 `xorm:"label_set_id"`
+	Hash       int64 `xorm:"hash"`
+}
+
+func (s stateHistoryLabel) TableName() string {
+	return "alert_state_history_label"
+}
+
+// stateHistory represents a record in alert_state_history table
+type stateHistory struct {
+	ID            int64  `xorm:"pk autoincr 'id'"`
+	OrgID         int64  `xorm:"org_id"`
+	RuleUID       string `xorm:"rule_uid"`
+	RuleID        int64  `xorm:"rule_id"`
+	RuleTitle     string `xorm:"rule_title"`
+	RuleGroup     string `xorm:"rule_group"`
+	FolderUID     string `xorm:"folder_uid"`
+	DashboardUID  string `xorm:"dashboard_uid"`
+	PanelID       int64  `xorm:"panel_id"`
+	RuleCondition string `xorm:"rule_condition"`
+	LabelSetID    int64  `xorm:"label_set_id"`
+	PrevState     string `xorm:"prev_state"`
+	NewState      string `xorm:"new_state"`
+	State         string `xorm:"state"`
+	Error         string `xorm:"error"`
+	StateValues   string `xorm:"state_values"`
+	Epoch         int64  `xorm:"epoch"`
+}
+
+func (s stateHistory) TableName() string {
+	return "alert_state_history"
+}
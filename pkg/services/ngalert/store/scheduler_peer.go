@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+)
+
+// SaveSchedulerPeerHeartbeat records that the instance that evaluates alert rules was alive at the given time.
+func (st DBstore) SaveSchedulerPeerHeartbeat(ctx context.Context, instanceID string, at time.Time) error {
+	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		peer := schedulerPeer{InstanceID: instanceID, LastHeartbeat: at.Unix()}
+		updated, err := sess.Where("instance_id = ?", instanceID).Cols("last_heartbeat").Update(&peer)
+		if err != nil {
+			return fmt.Errorf("failed to update heartbeat: %w", err)
+		}
+		if updated > 0 {
+			return nil
+		}
+		if _, err := sess.Insert(&peer); err != nil {
+			return fmt.Errorf("failed to insert heartbeat: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetSchedulerPeers returns the identifiers of the instances whose last heartbeat is not older than the given time.
+func (st DBstore) GetSchedulerPeers(ctx context.Context, since time.Time) ([]string, error) {
+	var peers []string
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Table(schedulerPeer{}).Where("last_heartbeat >= ?", since.Unix()).Cols("instance_id").OrderBy("instance_id").Find(&peers)
+	})
+	return peers, err
+}
+
+// DeleteSchedulerPeer deletes the heartbeat of the instance with the given identifier.
+func (st DBstore) DeleteSchedulerPeer(ctx context.Context, instanceID string) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Where("instance_id = ?", instanceID).Delete(&schedulerPeer{})
+		return err
+	})
+}
+
+// DeleteStaleSchedulerPeers deletes the heartbeats of the instances whose last heartbeat is older than the given time.
+func (st DBstore) DeleteStaleSchedulerPeers(ctx context.Context, before time.Time) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Where("last_heartbeat < ?", before.Unix()).Delete(&schedulerPeer{})
+		return err
+	})
+}
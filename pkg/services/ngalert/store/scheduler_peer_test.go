@@ -0,0 +1,41 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/tests"
+)
+
+func TestIntegrationSchedulerPeers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+	now := time.Now()
+
+	require.NoError(t, dbstore.SaveSchedulerPeerHeartbeat(ctx, "instance-b", now))
+	require.NoError(t, dbstore.SaveSchedulerPeerHeartbeat(ctx, "instance-a", now.Add(-time.Hour)))
+	// the second heartbeat of the same instance updates the existing record
+	require.NoError(t, dbstore.SaveSchedulerPeerHeartbeat(ctx, "instance-a", now))
+	require.NoError(t, dbstore.SaveSchedulerPeerHeartbeat(ctx, "instance-c", now.Add(-time.Hour)))
+
+	peers, err := dbstore.GetSchedulerPeers(ctx, now.Add(-time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, []string{"instance-a", "instance-b"}, peers)
+
+	require.NoError(t, dbstore.DeleteStaleSchedulerPeers(ctx, now.Add(-time.Minute)))
+	peers, err = dbstore.GetSchedulerPeers(ctx, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"instance-a", "instance-b"}, peers)
+
+	require.NoError(t, dbstore.DeleteSchedulerPeer(ctx, "instance-a"))
+	peers, err = dbstore.GetSchedulerPeers(ctx, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"instance-b"}, peers)
+}
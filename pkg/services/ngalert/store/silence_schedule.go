@@ -0,0 +1,249 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// ListRecurringSilences returns the recurring silences of the organization. If orgID is 0, it returns the recurring
+// silences of all organizations.
+func (st DBstore) ListRecurringSilences(ctx context.Context, orgID int64) ([]*models.RecurringSilence, error) {
+	var result []*models.RecurringSilence
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		q := sess.Table(recurringSilence{})
+		if orgID > 0 {
+			q = q.Where("org_id = ?", orgID)
+		}
+		var rows []recurringSilence
+		if err := q.OrderBy("org_id, uid").Find(&rows); err != nil {
+			return err
+		}
+		result = make([]*models.RecurringSilence, 0, len(rows))
+		for _, row := range rows {
+			s, err := recurringSilenceToModel(row)
+			if err != nil {
+				return err
+			}
+			result = append(result, s)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// GetRecurringSilence returns the recurring silence with the given UID or models.ErrRecurringSilenceNotFound.
+func (st DBstore) GetRecurringSilence(ctx context.Context, orgID int64, uid string) (*models.RecurringSilence, error) {
+	var result *models.RecurringSilence
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		var row recurringSilence
+		has, err := sess.Where("org_id = ? AND uid = ?", orgID, uid).Get(&row)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrRecurringSilenceNotFound.Errorf("")
+		}
+		result, err = recurringSilenceToModel(row)
+		return err
+	})
+	return result, err
+}
+
+// InsertRecurringSilence stores a new recurring silence.
+func (st DBstore) InsertRecurringSilence(ctx context.Context, s models.RecurringSilence) error {
+	row, err := recurringSilenceFromModel(s)
+	if err != nil {
+		return err
+	}
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		if _, err := sess.Insert(&row); err != nil {
+			return fmt.Errorf("failed to insert recurring silence: %w", err)
+		}
+		return nil
+	})
+}
+
+// UpdateRecurringSilence updates the definition of a recurring silence. It does not change the silence that was
+// created for the most recent window.
+func (st DBstore) UpdateRecurringSilence(ctx context.Context, s models.RecurringSilence) error {
+	row, err := recurringSilenceFromModel(s)
+	if err != nil {
+		return err
+	}
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		updated, err := sess.Where("org_id = ? AND uid = ?", s.OrgID, s.UID).
+			Cols("matchers", "comment", "created_by", "schedule", "duration_seconds", "time_zone", "time_interval", "expire_on_resolve", "updated").
+			Update(&row)
+		if err != nil {
+			return fmt.Errorf("failed to update recurring silence: %w", err)
+		}
+		if updated == 0 {
+			return models.ErrRecurringSilenceNotFound.Errorf("")
+		}
+		return nil
+	})
+}
+
+// DeleteRecurringSilence deletes the recurring silence with the given UID.
+func (st DBstore) DeleteRecurringSilence(ctx context.Context, orgID int64, uid string) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Where("org_id = ? AND uid = ?", orgID, uid).Delete(&recurringSilence{})
+		return err
+	})
+}
+
+// ListRecurringSilencesByTimeInterval returns the UIDs of the recurring silences of the organization whose windows are
+// the time ranges of the given time interval.
+func (st DBstore) ListRecurringSilencesByTimeInterval(ctx context.Context, orgID int64, timeInterval string) ([]string, error) {
+	var uids []string
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Table(recurringSilence{}).Where("org_id = ? AND time_interval = ?", orgID, timeInterval).OrderBy("uid").Cols("uid").Find(&uids)
+	})
+	return uids, err
+}
+
+// RenameTimeIntervalInRecurringSilences renames the time interval of the recurring silences of the organization that use it.
+// It returns the number of updated recurring silences.
+func (st DBstore) RenameTimeIntervalInRecurringSilences(ctx context.Context, orgID int64, oldTimeInterval, newTimeInterval string) (int64, error) {
+	var updated int64
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		var err error
+		updated, err = sess.Table(recurringSilence{}).
+			Where("org_id = ? AND time_interval = ?", orgID, oldTimeInterval).
+			Update(map[string]any{"time_interval": newTimeInterval, "updated": time.Now()})
+		return err
+	})
+	return updated, err
+}
+
+// ClaimRecurringSilenceWindow records that a silence is going to be created for the window that starts at windowStart.
+// It returns false if the window was already claimed, for example by another instance, since the recurring silence was read.
+func (st DBstore) ClaimRecurringSilenceWindow(ctx context.Context, s models.RecurringSilence, windowStart time.Time) (bool, error) {
+	var claimed bool
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		updated, err := sess.Table(recurringSilence{}).
+			Where("org_id = ? AND uid = ? AND window_start = ?", s.OrgID, s.UID, windowStartToUnix(s.WindowStart)).
+			Update(map[string]any{"window_start": windowStartToUnix(windowStart), "silence_id": ""})
+		claimed = updated > 0
+		return err
+	})
+	return claimed, err
+}
+
+// SetRecurringSilenceSilenceID records the ID of the silence that was created for the most recent window.
+func (st DBstore) SetRecurringSilenceSilenceID(ctx context.Context, orgID int64, uid string, silenceID string) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Table(recurringSilence{}).Where("org_id = ? AND uid = ?", orgID, uid).Update(map[string]any{"silence_id": silenceID})
+		return err
+	})
+}
+
+// ListSilenceExpiries returns all silences that are expired once their alerts resolve.
+func (st DBstore) ListSilenceExpiries(ctx context.Context) ([]models.SilenceExpiry, error) {
+	var result []models.SilenceExpiry
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		var rows []silenceExpiry
+		if err := sess.OrderBy("org_id, silence_id").Find(&rows); err != nil {
+			return err
+		}
+		result = make([]models.SilenceExpiry, 0, len(rows))
+		for _, row := range rows {
+			result = append(result, models.SilenceExpiry{OrgID: row.OrgID, SilenceID: row.SilenceID, AlertsSeen: row.AlertsSeen})
+		}
+		return nil
+	})
+	return result, err
+}
+
+// SaveSilenceExpiry stores the expiry of a silence, replacing the existing one.
+func (st DBstore) SaveSilenceExpiry(ctx context.Context, e models.SilenceExpiry) error {
+	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		row := silenceExpiry{OrgID: e.OrgID, SilenceID: e.SilenceID, AlertsSeen: e.AlertsSeen}
+		updated, err := sess.Where("org_id = ? AND silence_id = ?", e.OrgID, e.SilenceID).Cols("alerts_seen").Update(&row)
+		if err != nil {
+			return fmt.Errorf("failed to update silence expiry: %w", err)
+		}
+		if updated > 0 {
+			return nil
+		}
+		has, err := sess.Where("org_id = ? AND silence_id = ?", e.OrgID, e.SilenceID).Exist(&silenceExpiry{})
+		if err != nil || has {
+			return err
+		}
+		if _, err := sess.Insert(&row); err != nil {
+			return fmt.Errorf("failed to insert silence expiry: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteSilenceExpiry deletes the expiry of a silence.
+func (st DBstore) DeleteSilenceExpiry(ctx context.Context, orgID int64, silenceID string) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Where("org_id = ? AND silence_id = ?", orgID, silenceID).Delete(&silenceExpiry{})
+		return err
+	})
+}
+
+func recurringSilenceToModel(row recurringSilence) (*models.RecurringSilence, error) {
+	var matchers amv2.Matchers
+	if err := json.Unmarshal([]byte(row.Matchers), &matchers); err != nil {
+		return nil, fmt.Errorf("failed to parse matchers of recurring silence %s: %w", row.UID, err)
+	}
+	s := &models.RecurringSilence{
+		UID:             row.UID,
+		OrgID:           row.OrgID,
+		Matchers:        matchers,
+		Comment:         row.Comment,
+		CreatedBy:       row.CreatedBy,
+		Schedule:        row.Schedule,
+		Duration:        time.Duration(row.DurationSeconds) * time.Second,
+		TimeZone:        row.TimeZone,
+		TimeInterval:    row.TimeInterval,
+		ExpireOnResolve: row.ExpireOnResolve,
+		Updated:         row.Updated,
+		SilenceID:       row.SilenceID,
+	}
+	if row.WindowStart > 0 {
+		s.WindowStart = time.Unix(row.WindowStart, 0)
+	}
+	return s, nil
+}
+
+func recurringSilenceFromModel(s models.RecurringSilence) (recurringSilence, error) {
+	matchers, err := json.Marshal(s.Matchers)
+	if err != nil {
+		return recurringSilence{}, fmt.Errorf("failed to serialize matchers: %w", err)
+	}
+	row := recurringSilence{
+		OrgID:           s.OrgID,
+		UID:             s.UID,
+		Matchers:        string(matchers),
+		Comment:         s.Comment,
+		CreatedBy:       s.CreatedBy,
+		Schedule:        s.Schedule,
+		DurationSeconds: int64(s.Duration.Seconds()),
+		TimeZone:        s.TimeZone,
+		TimeInterval:    s.TimeInterval,
+		ExpireOnResolve: s.ExpireOnResolve,
+		SilenceID:       s.SilenceID,
+		WindowStart:     windowStartToUnix(s.WindowStart),
+		Updated:         s.Updated,
+	}
+	return row, nil
+}
+
+// windowStartToUnix returns the start of a window in seconds. A recurring silence without windows is stored as 0.
+func windowStartToUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
@@ -0,0 +1,165 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestIntegrationRecurringSilences(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+
+	silence := models.RecurringSilence{
+		UID:   "maintenance",
+		OrgID: 1,
+		Matchers: amv2.Matchers{
+			{Name: util.Pointer("team"), Value: util.Pointer("network"), IsEqual: util.Pointer(true), IsRegex: util.Pointer(false)},
+		},
+		Comment:  "weekly maintenance",
+		Schedule: "0 22 * * SAT",
+		Duration: 4 * time.Hour,
+		TimeZone: "Europe/Berlin",
+		Updated:  time.Now().Truncate(time.Second),
+	}
+	require.NoError(t, dbstore.InsertRecurringSilence(ctx, silence))
+
+	_, err := dbstore.GetRecurringSilence(ctx, 2, silence.UID)
+	require.ErrorIs(t, err, models.ErrRecurringSilenceNotFound)
+
+	stored, err := dbstore.GetRecurringSilence(ctx, 1, silence.UID)
+	require.NoError(t, err)
+	require.Equal(t, silence.Matchers, stored.Matchers)
+	require.Equal(t, silence.Duration, stored.Duration)
+	require.Equal(t, silence.TimeZone, stored.TimeZone)
+	require.True(t, stored.WindowStart.IsZero())
+
+	t.Run("only one claim of a window succeeds", func(t *testing.T) {
+		windowStart := time.Date(2024, 6, 1, 22, 0, 0, 0, time.UTC)
+		claimed, err := dbstore.ClaimRecurringSilenceWindow(ctx, *stored, windowStart)
+		require.NoError(t, err)
+		require.True(t, claimed)
+
+		claimed, err = dbstore.ClaimRecurringSilenceWindow(ctx, *stored, windowStart)
+		require.NoError(t, err)
+		require.False(t, claimed)
+
+		require.NoError(t, dbstore.SetRecurringSilenceSilenceID(ctx, 1, silence.UID, "silence-1"))
+		stored, err = dbstore.GetRecurringSilence(ctx, 1, silence.UID)
+		require.NoError(t, err)
+		require.Equal(t, "silence-1", stored.SilenceID)
+		require.True(t, windowStart.Equal(stored.WindowStart))
+	})
+
+	t.Run("update keeps the state of the window", func(t *testing.T) {
+		updated := *stored
+		updated.Comment = "monthly maintenance"
+		updated.TimeZone = "UTC"
+		updated.SilenceID = ""
+		updated.WindowStart = time.Time{}
+		require.NoError(t, dbstore.UpdateRecurringSilence(ctx, updated))
+
+		result, err := dbstore.GetRecurringSilence(ctx, 1, silence.UID)
+		require.NoError(t, err)
+		require.Equal(t, "monthly maintenance", result.Comment)
+		require.Equal(t, "UTC", result.TimeZone)
+		require.Equal(t, "silence-1", result.SilenceID)
+	})
+
+	t.Run("list filters by organization", func(t *testing.T) {
+		other := silence
+		other.OrgID = 2
+		require.NoError(t, dbstore.InsertRecurringSilence(ctx, other))
+
+		all, err := dbstore.ListRecurringSilences(ctx, 0)
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+
+		org2, err := dbstore.ListRecurringSilences(ctx, 2)
+		require.NoError(t, err)
+		require.Len(t, org2, 1)
+		require.EqualValues(t, 2, org2[0].OrgID)
+	})
+
+	require.NoError(t, dbstore.DeleteRecurringSilence(ctx, 1, silence.UID))
+	_, err = dbstore.GetRecurringSilence(ctx, 1, silence.UID)
+	require.ErrorIs(t, err, models.ErrRecurringSilenceNotFound)
+}
+
+func TestIntegrationSilenceExpiries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+
+	require.NoError(t, dbstore.SaveSilenceExpiry(ctx, models.SilenceExpiry{OrgID: 1, SilenceID: "a"}))
+	require.NoError(t, dbstore.SaveSilenceExpiry(ctx, models.SilenceExpiry{OrgID: 1, SilenceID: "b"}))
+	// saving the same silence again updates the existing record
+	require.NoError(t, dbstore.SaveSilenceExpiry(ctx, models.SilenceExpiry{OrgID: 1, SilenceID: "a", AlertsSeen: true}))
+
+	expiries, err := dbstore.ListSilenceExpiries(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []models.SilenceExpiry{
+		{OrgID: 1, SilenceID: "a", AlertsSeen: true},
+		{OrgID: 1, SilenceID: "b"},
+	}, expiries)
+
+	require.NoError(t, dbstore.DeleteSilenceExpiry(ctx, 1, "a"))
+	expiries, err = dbstore.ListSilenceExpiries(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []models.SilenceExpiry{{OrgID: 1, SilenceID: "b"}}, expiries)
+}
+
+func TestIntegrationRecurringSilencesByTimeInterval(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+
+	matchers := amv2.Matchers{
+		{Name: util.Pointer("team"), Value: util.Pointer("network"), IsEqual: util.Pointer(true), IsRegex: util.Pointer(false)},
+	}
+	for _, s := range []models.RecurringSilence{
+		{UID: "b", OrgID: 1, Matchers: matchers, Comment: "weekends", TimeInterval: "weekends"},
+		{UID: "a", OrgID: 1, Matchers: matchers, Comment: "weekends", TimeInterval: "weekends"},
+		{UID: "c", OrgID: 1, Matchers: matchers, Comment: "nights", TimeInterval: "nights"},
+		{UID: "d", OrgID: 2, Matchers: matchers, Comment: "weekends", TimeInterval: "weekends"},
+	} {
+		require.NoError(t, dbstore.InsertRecurringSilence(ctx, s))
+	}
+
+	uids, err := dbstore.ListRecurringSilencesByTimeInterval(ctx, 1, "weekends")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, uids)
+
+	updated, err := dbstore.RenameTimeIntervalInRecurringSilences(ctx, 1, "weekends", "saturdays")
+	require.NoError(t, err)
+	require.EqualValues(t, 2, updated)
+
+	uids, err = dbstore.ListRecurringSilencesByTimeInterval(ctx, 1, "weekends")
+	require.NoError(t, err)
+	require.Empty(t, uids)
+
+	renamed, err := dbstore.GetRecurringSilence(ctx, 1, "a")
+	require.NoError(t, err)
+	require.Equal(t, "saturdays", renamed.TimeInterval)
+
+	other, err := dbstore.GetRecurringSilence(ctx, 2, "d")
+	require.NoError(t, err)
+	require.Equal(t, "weekends", other.TimeInterval)
+}
@@ -0,0 +1,498 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strings"
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// StateHistoryAdminStore is the store for the retention of the SQL state history backend.
+type StateHistoryAdminStore interface {
+	// DeleteStateHistoryBefore deletes state transitions that happened before the given time. It returns the number of
+	// deleted transitions.
+	DeleteStateHistoryBefore(ctx context.Context, before time.Time, batchSize int) (int64, error)
+}
+
+// SaveStateHistory stores state transitions of alert instances. The label set of every alert instance is stored once
+// per organization and referenced by its transitions.
+func (st DBstore) SaveStateHistory(ctx context.Context, entries []models.StateHistoryEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	labelSets := make(map[labelSetKey]map[string]string)
+	for _, e := range entries {
+		labelSets[labelSetKey{orgID: e.OrgID, fingerprint: e.Fingerprint}] = e.Labels
+	}
+	labelSetIDs, err := st.getOrCreateStateHistoryLabelSets(ctx, labelSets)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]stateHistory, 0, len(entries))
+	for _, e := range entries {
+		rows = append(rows, stateHistory{
+			OrgID:         e.OrgID,
+			RuleUID:       e.RuleUID,
+			RuleID:        e.RuleID,
+			RuleTitle:     e.RuleTitle,
+			RuleGroup:     e.RuleGroup,
+			FolderUID:     e.FolderUID,
+			DashboardUID:  e.DashboardUID,
+			PanelID:       e.PanelID,
+			RuleCondition: e.Condition,
+			LabelSetID:    labelSetIDs[labelSetKey{orgID: e.OrgID, fingerprint: e.Fingerprint}],
+			PrevState:     e.Previous,
+			NewState:      e.Current,
+			State:         e.State,
+			Error:         e.Error,
+			StateValues:   e.Values,
+			Epoch:         e.Timestamp.UnixMilli(),
+		})
+	}
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		if _, err := sess.InsertMulti(&rows); err != nil {
+			return fmt.Errorf("failed to insert state history: %w", err)
+		}
+		return nil
+	})
+}
+
+type labelSetKey struct {
+	orgID       int64
+	fingerprint string
+}
+
+// stateHistoryLabelSetRefreshInterval is how often the time a label set was last used is refreshed. The retention
+// cleanup keeps label sets that were used within this interval before the cutoff, so a label set cannot be deleted
+// while transitions that reference it are being saved.
+const stateHistoryLabelSetRefreshInterval = time.Hour
+
+// stateHistoryLabelSetBatchSize is the number of label sets or label index rows that are loaded or inserted by a
+// single query.
+const stateHistoryLabelSetBatchSize = 100
+
+// getOrCreateStateHistoryLabelSets returns the IDs of the label sets with the given keys. The existing label sets are
+// loaded and refreshed in batches, and the label sets that do not exist are created with their label index in bulk.
+func (st DBstore) getOrCreateStateHistoryLabelSets(ctx context.Context, labelSets map[labelSetKey]map[string]string) (map[labelSetKey]int64, error) {
+	now := time.Now()
+	ids, err := st.getStateHistoryLabelSetIDs(ctx, labelSets, now)
+	if err != nil {
+		return nil, err
+	}
+	missing := make(map[labelSetKey]map[string]string)
+	for key, labels := range labelSets {
+		if _, ok := ids[key]; !ok {
+			missing[key] = labels
+		}
+	}
+	if len(missing) == 0 {
+		return ids, nil
+	}
+
+	created, err := st.createStateHistoryLabelSets(ctx, missing, now)
+	if err != nil {
+		if !st.SQLStore.GetDialect().IsUniqueConstraintViolation(err) {
+			return nil, fmt.Errorf("failed to insert state history label sets: %w", err)
+		}
+		// Some label sets were created concurrently, for example by another instance.
+		created, err = st.getStateHistoryLabelSetIDs(ctx, missing, now)
+		if err != nil {
+			return nil, err
+		}
+		for key := range missing {
+			if _, ok := created[key]; !ok {
+				return nil, fmt.Errorf("state history label set %s does not exist", key.fingerprint)
+			}
+		}
+	}
+	for key, id := range created {
+		ids[key] = id
+	}
+	return ids, nil
+}
+
+// getStateHistoryLabelSetIDs returns the IDs of the existing label sets with the given keys, and refreshes the time
+// they were last used if it is older than the refresh interval. Label sets that are deleted by the retention cleanup
+// before they are refreshed are not returned.
+func (st DBstore) getStateHistoryLabelSetIDs(ctx context.Context, labelSets map[labelSetKey]map[string]string, now time.Time) (map[labelSetKey]int64, error) {
+	fingerprints := make(map[int64][]string)
+	for key := range labelSets {
+		fingerprints[key.orgID] = append(fingerprints[key.orgID], key.fingerprint)
+	}
+
+	ids := make(map[labelSetKey]int64, len(labelSets))
+	var stale []int64
+	staleKeys := make(map[int64]labelSetKey)
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		for orgID, orgFingerprints := range fingerprints {
+			for batch := range slices.Chunk(orgFingerprints, stateHistoryLabelSetBatchSize) {
+				var rows []stateHistoryLabelSet
+				if err := sess.Table(stateHistoryLabelSet{}).Cols("id", "fingerprint", "updated").
+					Where("org_id = ?", orgID).In("fingerprint", batch).Find(&rows); err != nil {
+					return err
+				}
+				for _, row := range rows {
+					key := labelSetKey{orgID: orgID, fingerprint: row.Fingerprint}
+					ids[key] = row.ID
+					if row.Updated < now.Add(-stateHistoryLabelSetRefreshInterval).UnixMilli() {
+						stale = append(stale, row.ID)
+						staleKeys[row.ID] = key
+					}
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for batch := range slices.Chunk(stale, stateHistoryLabelSetBatchSize) {
+		refreshed, err := st.refreshStateHistoryLabelSets(ctx, batch, now)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range batch {
+			if !slices.Contains(refreshed, id) {
+				// The label set was deleted by the retention cleanup after it was loaded.
+				delete(ids, staleKeys[id])
+			}
+		}
+	}
+	return ids, nil
+}
+
+// createStateHistoryLabelSets creates the given label sets and their label index, and returns their IDs.
+func (st DBstore) createStateHistoryLabelSets(ctx context.Context, labelSets map[labelSetKey]map[string]string, now time.Time) (map[labelSetKey]int64, error) {
+	sets := make([]stateHistoryLabelSet, 0, len(labelSets))
+	for key, labels := range labelSets {
+		raw, err := json.Marshal(labels)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize labels: %w", err)
+		}
+		sets = append(sets, stateHistoryLabelSet{OrgID: key.orgID, Fingerprint: key.fingerprint, Labels: string(raw), Updated: now.UnixMilli()})
+	}
+
+	var ids map[labelSetKey]int64
+	err := st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		for batch := range slices.Chunk(sets, stateHistoryLabelSetBatchSize) {
+			if _, err := sess.InsertMulti(&batch); err != nil {
+				return err
+			}
+		}
+
+		// The IDs of rows inserted in bulk are not returned by all databases, they are loaded by fingerprint.
+		fingerprints := make(map[int64][]string)
+		for key := range labelSets {
+			fingerprints[key.orgID] = append(fingerprints[key.orgID], key.fingerprint)
+		}
+		ids = make(map[labelSetKey]int64, len(labelSets))
+		index := make([]stateHistoryLabel, 0)
+		for orgID, orgFingerprints := range fingerprints {
+			for batch := range slices.Chunk(orgFingerprints, stateHistoryLabelSetBatchSize) {
+				var rows []stateHistoryLabelSet
+				if err := sess.Table(stateHistoryLabelSet{}).Cols("id", "fingerprint").
+					Where("org_id = ?", orgID).In("fingerprint", batch).Find(&rows); err != nil {
+					return err
+				}
+				for _, row := range rows {
+					key := labelSetKey{orgID: orgID, fingerprint: row.Fingerprint}
+					ids[key] = row.ID
+					for name, value := range labelSets[key] {
+						index = append(index, stateHistoryLabel{OrgID: orgID, LabelSetID: row.ID, Hash: stateHistoryLabelHash(name, value)})
+					}
+				}
+			}
+		}
+
+		for batch := range slices.Chunk(index, stateHistoryLabelSetBatchSize) {
+			if _, err := sess.InsertMulti(&batch); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return ids, err
+}
+
+// refreshStateHistoryLabelSets sets the time the label sets were last used. It returns the IDs of the label sets that
+// still exist.
+func (st DBstore) refreshStateHistoryLabelSets(ctx context.Context, ids []int64, now time.Time) ([]int64, error) {
+	args := make([]any, 0, len(ids)+2)
+	args = append(args, "UPDATE alert_state_history_label_set SET updated = ? WHERE id IN (?"+strings.Repeat(",?", len(ids)-1)+")", now.UnixMilli())
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	var refreshed []int64
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		res, err := sess.Exec(args...)
+		if err != nil {
+			return fmt.Errorf("failed to refresh state history label sets: %w", err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		// MySQL does not count rows whose values did not change, but the time is only refreshed after an interval.
+		if affected == int64(len(ids)) {
+			refreshed = ids
+			return nil
+		}
+		return sess.Table(stateHistoryLabelSet{}).In("id", ids).Cols("id").Find(&refreshed)
+	})
+	return refreshed, err
+}
+
+// QueryStateHistory returns the most recent state transitions that match the query in chronological order.
+// If folderUIDs is not empty, only transitions of rules in these folders are returned.
+func (st DBstore) QueryStateHistory(ctx context.Context, query models.HistoryQuery, folderUIDs []string) ([]models.StateHistoryEntry, error) {
+	var result []models.StateHistoryEntry
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		// Label filters look up label sets by the hash of the label, so transitions of label sets whose hash collided
+		// with the hash of a label in the query are dropped after they are loaded. Pages are loaded until the limit is
+		// filled with matching transitions.
+		var matched []stateHistory
+		labelSets := make(map[int64]parsedLabelSet)
+		var last *stateHistory
+		for {
+			var rows []stateHistory
+			if err := st.stateHistoryQuery(sess, query, folderUIDs, last).Find(&rows); err != nil {
+				return err
+			}
+			if len(rows) == 0 {
+				break
+			}
+			sets, err := getStateHistoryLabelSets(sess, rows)
+			if err != nil {
+				return err
+			}
+			for id, set := range sets {
+				labelSets[id] = set
+			}
+			for _, row := range rows {
+				if query.Limit > 0 && len(matched) == query.Limit {
+					break
+				}
+				if matchesLabels(labelSets[row.LabelSetID].labels, query.Labels) {
+					matched = append(matched, row)
+				}
+			}
+			if query.Limit <= 0 || len(rows) < query.Limit || len(matched) == query.Limit {
+				break
+			}
+			last = &rows[len(rows)-1]
+		}
+
+		result = make([]models.StateHistoryEntry, 0, len(matched))
+		for i := len(matched) - 1; i >= 0; i-- {
+			row := matched[i]
+			set := labelSets[row.LabelSetID]
+			result = append(result, models.StateHistoryEntry{
+				OrgID:        row.OrgID,
+				RuleUID:      row.RuleUID,
+				RuleID:       row.RuleID,
+				RuleTitle:    row.RuleTitle,
+				RuleGroup:    row.RuleGroup,
+				FolderUID:    row.FolderUID,
+				DashboardUID: row.DashboardUID,
+				PanelID:      row.PanelID,
+				Condition:    row.RuleCondition,
+				Labels:       set.labels,
+				Fingerprint:  set.fingerprint,
+				Previous:     row.PrevState,
+				Current:      row.NewState,
+				State:        row.State,
+				Error:        row.Error,
+				Values:       row.StateValues,
+				Timestamp:    time.UnixMilli(row.Epoch),
+			})
+		}
+		return nil
+	})
+	return result, err
+}
+
+// stateHistoryQuery returns the query of a page of the most recent transitions that match the query, that happened
+// before the given transition if it is not nil.
+func (st DBstore) stateHistoryQuery(sess *db.Session, query models.HistoryQuery, folderUIDs []string, before *stateHistory) *xorm.Session {
+	q := sess.Table(stateHistory{}).Where("org_id = ?", query.OrgID)
+	if !query.From.IsZero() {
+		q = q.And("epoch >= ?", query.From.UnixMilli())
+	}
+	if !query.To.IsZero() {
+		q = q.And("epoch <= ?", query.To.UnixMilli())
+	}
+	if query.RuleUID != "" {
+		q = q.And("rule_uid = ?", query.RuleUID)
+	}
+	if query.FolderUID != "" {
+		q = q.And("folder_uid = ?", query.FolderUID)
+	}
+	if len(folderUIDs) > 0 {
+		q = q.In("folder_uid", folderUIDs)
+	}
+	if query.DashboardUID != "" {
+		q = q.And("dashboard_uid = ?", query.DashboardUID)
+	}
+	if query.PanelID != 0 {
+		q = q.And("panel_id = ?", query.PanelID)
+	}
+	if query.State != "" {
+		q = q.And("state = ?", query.State)
+	}
+	// Every label filter is an index lookup by the hash of the label.
+	names := make([]string, 0, len(query.Labels))
+	for name := range query.Labels {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		q = q.And("label_set_id IN (SELECT label_set_id FROM alert_state_history_label WHERE org_id = ? AND hash = ?)",
+			query.OrgID, stateHistoryLabelHash(name, query.Labels[name]))
+	}
+	if before != nil {
+		q = q.And("(epoch < ? OR (epoch = ? AND id < ?))", before.Epoch, before.Epoch, before.ID)
+	}
+	q = q.Desc("epoch", "id")
+	if query.Limit > 0 {
+		q = q.Limit(query.Limit)
+	}
+	return q
+}
+
+type parsedLabelSet struct {
+	fingerprint string
+	labels      map[string]string
+}
+
+func getStateHistoryLabelSets(sess *db.Session, rows []stateHistory) (map[int64]parsedLabelSet, error) {
+	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		if !slices.Contains(ids, row.LabelSetID) {
+			ids = append(ids, row.LabelSetID)
+		}
+	}
+	var sets []stateHistoryLabelSet
+	if err := sess.In("id", ids).Find(&sets); err != nil {
+		return nil, err
+	}
+	result := make(map[int64]parsedLabelSet, len(sets))
+	for _, set := range sets {
+		labels := make(map[string]string)
+		if err := json.Unmarshal([]byte(set.Labels), &labels); err != nil {
+			return nil, fmt.Errorf("failed to parse state history label set %d: %w", set.ID, err)
+		}
+		result[set.ID] = parsedLabelSet{fingerprint: set.Fingerprint, labels: labels}
+	}
+	return result, nil
+}
+
+// DeleteStateHistoryBefore deletes state transitions that happened before the given time in batches of the given size,
+// and the label sets that are no longer referenced. It returns the number of deleted transitions.
+func (st DBstore) DeleteStateHistoryBefore(ctx context.Context, before time.Time, batchSize int) (int64, error) {
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		// Loading the IDs first avoids deadlocks of batched sub-queries with concurrent inserts on MySQL.
+		ids, err := st.fetchIDs(ctx, "alert_state_history", "epoch < ? ORDER BY id "+st.SQLStore.GetDialect().Limit(int64(batchSize)), before.UnixMilli())
+		if err != nil {
+			return total, err
+		}
+		if len(ids) == 0 {
+			break
+		}
+		affected, err := st.deleteByIDs(ctx, "alert_state_history", "id", ids, "")
+		total += affected
+		if err != nil {
+			return total, err
+		}
+	}
+
+	// Label sets that are not referenced by any transition and were not used recently are deleted with their label
+	// index. A label set that is being referenced by transitions that are not saved yet was created or refreshed
+	// within the refresh interval, and the condition is checked again when the label set is deleted.
+	labelSetsBefore := before.Add(-stateHistoryLabelSetRefreshInterval).UnixMilli()
+	unused := "updated < ? AND NOT EXISTS (SELECT 1 FROM alert_state_history h WHERE h.label_set_id = alert_state_history_label_set.id)"
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		ids, err := st.fetchIDs(ctx, "alert_state_history_label_set", unused+" "+st.SQLStore.GetDialect().Limit(int64(batchSize)), labelSetsBefore)
+		if err != nil {
+			return total, err
+		}
+		if len(ids) == 0 {
+			break
+		}
+		if _, err := st.deleteByIDs(ctx, "alert_state_history_label_set", "id", ids, unused, labelSetsBefore); err != nil {
+			return total, err
+		}
+		// Only the label index of the label sets that were deleted is deleted.
+		if _, err := st.deleteByIDs(ctx, "alert_state_history_label", "label_set_id", ids,
+			"NOT EXISTS (SELECT 1 FROM alert_state_history_label_set s WHERE s.id = alert_state_history_label.label_set_id)"); err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+func (st DBstore) fetchIDs(ctx context.Context, table, condition string, args ...any) ([]int64, error) {
+	ids := make([]int64, 0)
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.SQL(fmt.Sprintf("SELECT id FROM %s WHERE %s", table, condition), args...).Find(&ids)
+	})
+	return ids, err
+}
+
+// deleteByIDs deletes the rows of the table with the given IDs that match the optional condition.
+func (st DBstore) deleteByIDs(ctx context.Context, table, column string, ids []int64, condition string, conditionArgs ...any) (int64, error) {
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s IN (?%s)", table, column, strings.Repeat(",?", len(ids)-1))
+	if condition != "" {
+		query += " AND " + condition
+	}
+	args := make([]any, 0, len(ids)+len(conditionArgs)+1)
+	args = append(args, query)
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	args = append(args, conditionArgs...)
+	var affected int64
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		res, err := sess.Exec(args...)
+		if err != nil {
+			return err
+		}
+		affected, err = res.RowsAffected()
+		return err
+	})
+	return affected, err
+}
+
+// stateHistoryLabelHash returns the hash of a label that is used to look up label sets by label.
+func stateHistoryLabelHash(name, value string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	_, _ = h.Write([]byte{0xff})
+	_, _ = h.Write([]byte(value))
+	return int64(h.Sum64())
+}
+
+func matchesLabels(labels, matchers map[string]string) bool {
+	for name, value := range matchers {
+		if labels[name] != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,205 @@
+package store_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests"
+)
+
+func TestIntegrationStateHistory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+
+	now := time.Now().Truncate(time.Millisecond)
+	entry := func(ruleUID, folderUID, state string, labels map[string]string, fingerprint string, ts time.Time) models.StateHistoryEntry {
+		return models.StateHistoryEntry{
+			OrgID:       1,
+			RuleUID:     ruleUID,
+			RuleTitle:   ruleUID,
+			RuleGroup:   "group",
+			FolderUID:   folderUID,
+			Labels:      labels,
+			Fingerprint: fingerprint,
+			Previous:    "Normal",
+			Current:     state,
+			State:       state,
+			Values:      `{"A":1}`,
+			Timestamp:   ts,
+		}
+	}
+	webLabels := map[string]string{"service": "web", "env": "prod"}
+	dbLabels := map[string]string{"service": "db", "env": "prod"}
+	require.NoError(t, dbstore.SaveStateHistory(ctx, []models.StateHistoryEntry{
+		entry("rule-1", "folder-1", "Alerting", webLabels, "web", now.Add(-3*time.Hour)),
+		entry("rule-1", "folder-1", "Normal", webLabels, "web", now.Add(-2*time.Hour)),
+		entry("rule-2", "folder-2", "Alerting", dbLabels, "db", now.Add(-time.Hour)),
+	}))
+	// The label set of an existing instance is reused.
+	require.NoError(t, dbstore.SaveStateHistory(ctx, []models.StateHistoryEntry{
+		entry("rule-1", "folder-1", "Alerting", webLabels, "web", now),
+	}))
+
+	t.Run("returns transitions in chronological order", func(t *testing.T) {
+		res, err := dbstore.QueryStateHistory(ctx, models.HistoryQuery{OrgID: 1}, nil)
+		require.NoError(t, err)
+		require.Len(t, res, 4)
+		for i := 1; i < len(res); i++ {
+			require.True(t, res[i-1].Timestamp.Before(res[i].Timestamp))
+		}
+		require.Equal(t, webLabels, res[0].Labels)
+		require.Equal(t, "web", res[0].Fingerprint)
+		require.Equal(t, `{"A":1}`, res[0].Values)
+		require.True(t, now.Add(-3*time.Hour).Equal(res[0].Timestamp))
+	})
+
+	t.Run("filters transitions", func(t *testing.T) {
+		testCases := []struct {
+			name       string
+			query      models.HistoryQuery
+			folderUIDs []string
+			expected   int
+		}{
+			{name: "by organization", query: models.HistoryQuery{OrgID: 2}},
+			{name: "by rule", query: models.HistoryQuery{OrgID: 1, RuleUID: "rule-2"}, expected: 1},
+			{name: "by folder", query: models.HistoryQuery{OrgID: 1, FolderUID: "folder-1"}, expected: 3},
+			{name: "by accessible folders", query: models.HistoryQuery{OrgID: 1}, folderUIDs: []string{"folder-2"}, expected: 1},
+			{name: "by state", query: models.HistoryQuery{OrgID: 1, State: "Alerting"}, expected: 3},
+			{name: "by label", query: models.HistoryQuery{OrgID: 1, Labels: map[string]string{"env": "prod"}}, expected: 4},
+			{name: "by labels", query: models.HistoryQuery{OrgID: 1, Labels: map[string]string{"env": "prod", "service": "db"}}, expected: 1},
+			{name: "by unknown label", query: models.HistoryQuery{OrgID: 1, Labels: map[string]string{"service": "cache"}}},
+			{name: "by time range", query: models.HistoryQuery{OrgID: 1, From: now.Add(-150 * time.Minute), To: now.Add(-time.Minute)}, expected: 2},
+			{name: "with limit", query: models.HistoryQuery{OrgID: 1, Limit: 2}, expected: 2},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				res, err := dbstore.QueryStateHistory(ctx, tc.query, tc.folderUIDs)
+				require.NoError(t, err)
+				require.Len(t, res, tc.expected)
+			})
+		}
+	})
+
+	t.Run("limit keeps the most recent transitions", func(t *testing.T) {
+		res, err := dbstore.QueryStateHistory(ctx, models.HistoryQuery{OrgID: 1, Limit: 1}, nil)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		require.True(t, now.Equal(res[0].Timestamp))
+	})
+
+	t.Run("fills the limit when label hashes collide", func(t *testing.T) {
+		// Index the db label set by the hash of service=web, which is the only hash of the web label set that the
+		// db label set does not have.
+		err := dbstore.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+			_, err := sess.Exec(`INSERT INTO alert_state_history_label (org_id, label_set_id, hash)
+				SELECT 1, db.id, l.hash FROM alert_state_history_label l, alert_state_history_label_set web, alert_state_history_label_set db
+				WHERE web.fingerprint = 'web' AND db.fingerprint = 'db' AND l.label_set_id = web.id
+				AND l.hash NOT IN (SELECT hash FROM alert_state_history_label WHERE label_set_id = db.id)`)
+			return err
+		})
+		require.NoError(t, err)
+
+		res, err := dbstore.QueryStateHistory(ctx, models.HistoryQuery{OrgID: 1, To: now.Add(-30 * time.Minute), Labels: map[string]string{"service": "web"}, Limit: 1}, nil)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		require.Equal(t, webLabels, res[0].Labels)
+		require.True(t, now.Add(-2*time.Hour).Equal(res[0].Timestamp))
+	})
+
+	t.Run("deletes transitions before a time and unreferenced label sets", func(t *testing.T) {
+		deleted, err := dbstore.DeleteStateHistoryBefore(ctx, now.Add(-30*time.Minute), 1)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, deleted)
+
+		res, err := dbstore.QueryStateHistory(ctx, models.HistoryQuery{OrgID: 1}, nil)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		require.Equal(t, webLabels, res[0].Labels)
+
+		res, err = dbstore.QueryStateHistory(ctx, models.HistoryQuery{OrgID: 1, Labels: map[string]string{"service": "db"}}, nil)
+		require.NoError(t, err)
+		require.Empty(t, res)
+	})
+
+	countLabelSets := func(t *testing.T) int64 {
+		t.Helper()
+		var count int64
+		err := dbstore.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+			var err error
+			count, err = sess.Table("alert_state_history_label_set").Count()
+			return err
+		})
+		require.NoError(t, err)
+		return count
+	}
+	setLabelSetsUpdated := func(t *testing.T, updated time.Time) {
+		t.Helper()
+		err := dbstore.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+			_, err := sess.Exec("UPDATE alert_state_history_label_set SET updated = ?", updated.UnixMilli())
+			return err
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("keeps unreferenced label sets that were used recently", func(t *testing.T) {
+		// The label set of the deleted transitions was created by the first save.
+		require.EqualValues(t, 2, countLabelSets(t))
+
+		setLabelSetsUpdated(t, now.Add(-2*time.Hour))
+		_, err := dbstore.DeleteStateHistoryBefore(ctx, now.Add(-30*time.Minute), 1)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, countLabelSets(t))
+	})
+
+	t.Run("refreshes or recreates label sets that are reused", func(t *testing.T) {
+		setLabelSetsUpdated(t, now.Add(-2*time.Hour))
+		require.NoError(t, dbstore.SaveStateHistory(ctx, []models.StateHistoryEntry{
+			entry("rule-1", "folder-1", "Normal", webLabels, "web", now.Add(time.Minute)),
+		}))
+		// The label set is kept because it was refreshed.
+		_, err := dbstore.DeleteStateHistoryBefore(ctx, now.Add(time.Hour), 1)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, countLabelSets(t))
+
+		// The label set is deleted by the cleanup after it was loaded by a save.
+		setLabelSetsUpdated(t, now.Add(-2*time.Hour))
+		_, err = dbstore.DeleteStateHistoryBefore(ctx, now.Add(time.Hour), 1)
+		require.NoError(t, err)
+		require.Zero(t, countLabelSets(t))
+		require.NoError(t, dbstore.SaveStateHistory(ctx, []models.StateHistoryEntry{
+			entry("rule-1", "folder-1", "Alerting", webLabels, "web", now.Add(2*time.Minute)),
+		}))
+
+		res, err := dbstore.QueryStateHistory(ctx, models.HistoryQuery{OrgID: 1, Labels: map[string]string{"service": "web"}}, nil)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		require.Equal(t, webLabels, res[0].Labels)
+	})
+	t.Run("creates many label sets at once", func(t *testing.T) {
+		entries := make([]models.StateHistoryEntry, 0, 250)
+		for i := 0; i < 250; i++ {
+			instance := fmt.Sprintf("instance-%d", i)
+			entries = append(entries, entry("rule-3", "folder-1", "Alerting", map[string]string{"instance": instance}, instance, now.Add(3*time.Minute)))
+		}
+		require.NoError(t, dbstore.SaveStateHistory(ctx, entries))
+		// The label sets are reused by the next save.
+		require.NoError(t, dbstore.SaveStateHistory(ctx, entries))
+		require.EqualValues(t, 251, countLabelSets(t))
+
+		res, err := dbstore.QueryStateHistory(ctx, models.HistoryQuery{OrgID: 1, Labels: map[string]string{"instance": "instance-120"}}, nil)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		require.Equal(t, "instance-120", res[0].Fingerprint)
+		require.Equal(t, map[string]string{"instance": "instance-120"}, res[0].Labels)
+	})
+}
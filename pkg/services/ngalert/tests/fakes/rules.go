@@ -25,6 +25,8 @@ type RuleStore struct {
 	Hook        func(cmd any) error // use Hook if you need to intercept some query and return an error
 	RecordedOps []any
 	Folders     map[int64][]*folder.Folder
+	// RuleVersions contains the versions of rules returned by GetAlertRuleVersions, most recent first
+	RuleVersions map[models.AlertRuleKey][]*models.AlertRuleVersion
 }
 
 type GenericRecordedQuery struct {
@@ -34,8 +36,9 @@ type GenericRecordedQuery struct {
 
 func NewRuleStore(t *testing.T) *RuleStore {
 	return &RuleStore{
-		t:     t,
-		Rules: map[int64][]*models.AlertRule{},
+		t:            t,
+		Rules:        map[int64][]*models.AlertRule{},
+		RuleVersions: map[models.AlertRuleKey][]*models.AlertRuleVersion{},
 		Hook: func(any) error {
 			return nil
 		},
@@ -144,6 +147,35 @@ func (f *RuleStore) GetAlertRuleByUID(_ context.Context, q *models.GetAlertRuleB
 	return nil, models.ErrAlertRuleNotFound
 }
 
+func (f *RuleStore) GetAlertRuleVersions(_ context.Context, q *models.GetAlertRuleVersionsQuery) ([]*models.AlertRuleVersion, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.RecordedOps = append(f.RecordedOps, *q)
+	if err := f.Hook(*q); err != nil {
+		return nil, err
+	}
+	versions, ok := f.RuleVersions[models.AlertRuleKey{OrgID: q.OrgID, UID: q.UID}]
+	if !ok {
+		return nil, models.ErrAlertRuleNotFound
+	}
+	return versions, nil
+}
+
+func (f *RuleStore) GetAlertRuleVersion(_ context.Context, q *models.GetAlertRuleVersionsQuery, version int64) (*models.AlertRuleVersion, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.RecordedOps = append(f.RecordedOps, *q)
+	if err := f.Hook(*q); err != nil {
+		return nil, err
+	}
+	for _, v := range f.RuleVersions[models.AlertRuleKey{OrgID: q.OrgID, UID: q.UID}] {
+		if v.Rule.Version == version {
+			return v, nil
+		}
+	}
+	return nil, models.ErrAlertRuleVersionNotFound
+}
+
 func (f *RuleStore) GetAlertRulesGroupByRuleUID(_ context.Context, q *models.GetAlertRulesGroupByRuleUIDQuery) ([]*models.AlertRule, error) {
 	f.mtx.Lock()
 	defer f.mtx.Unlock()
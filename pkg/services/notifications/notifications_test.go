@@ -132,6 +132,34 @@ func TestSendEmailSync(t *testing.T) {
 		require.Equal(t, []byte("text file content"), file.Content)
 	})
 
+	t.Run("When sending a dashboard report", func(t *testing.T) {
+		ns, mailer := createSut(t, bus)
+		cmd := &SendEmailCommandSync{
+			SendEmailCommand: SendEmailCommand{
+				To:       []string{"asdf@grafana.com"},
+				Template: "dashboard_report",
+				Data: map[string]any{
+					"ReportName":     "Weekly capacity",
+					"DashboardTitle": "Capacity",
+					"DashboardPath":  "d/capacity/capacity?orgId=1",
+					"Message":        "See attached",
+					"TimeFrom":       "now-7d",
+					"TimeTo":         "now",
+				},
+			},
+		}
+
+		err := ns.SendEmailCommandHandlerSync(context.Background(), cmd)
+		require.NoError(t, err)
+
+		require.NotEmpty(t, mailer.Sent)
+		sent := mailer.Sent[len(mailer.Sent)-1]
+		require.Equal(t, "Weekly capacity", sent.Subject)
+		require.Contains(t, sent.Body["text/html"], "d/capacity/capacity?orgId=1")
+		require.Contains(t, sent.Body["text/html"], "See attached")
+		require.Contains(t, sent.Body["text/plain"], "The Capacity dashboard from now-7d to now")
+	})
+
 	t.Run("When SMTP disabled in configuration", func(t *testing.T) {
 		cfg := createSmtpConfig()
 		cfg.Smtp.Enabled = false
@@ -18,6 +18,10 @@ import (
 
 var _ PluginRequestConfigProvider = (*RequestConfigProvider)(nil)
 
+// sqlStreamRowLimit is read by the sqlstream package of the SQL data sources, which has no
+// counterpart in the plugin SDK for it.
+const sqlStreamRowLimit = "GF_SQL_STREAM_ROW_LIMIT"
+
 type PluginRequestConfigProvider interface {
 	PluginRequestConfig(ctx context.Context, pluginID string, externalService *auth.ExternalService) map[string]string
 }
@@ -158,6 +162,10 @@ func (s *RequestConfigProvider) PluginRequestConfig(ctx context.Context, pluginI
 	m[backend.SQLMaxIdleConnsDefault] = strconv.Itoa(s.cfg.SQLDatasourceMaxIdleConnsDefault)
 	m[backend.SQLMaxConnLifetimeSecondsDefault] = strconv.Itoa(s.cfg.SQLDatasourceMaxConnLifetimeDefault)
 
+	if s.cfg.SQLDatasourceStreamRowLimit > 0 {
+		m[sqlStreamRowLimit] = strconv.FormatInt(s.cfg.SQLDatasourceStreamRowLimit, 10)
+	}
+
 	if s.cfg.ResponseLimit > 0 {
 		m[backend.ResponseLimit] = strconv.FormatInt(s.cfg.ResponseLimit, 10)
 	}
@@ -194,6 +194,7 @@ func TestRequestConfigProvider_PluginRequestConfig_SQL(t *testing.T) {
 		cfg.SqlDatasourceMaxOpenConnsDefault = 24
 		cfg.SqlDatasourceMaxIdleConnsDefault = 25
 		cfg.SqlDatasourceMaxConnLifetimeDefault = 26
+		cfg.SqlDatasourceStreamRowLimit = 27
 
 		pCfg, err := ProvidePluginInstanceConfig(cfg, setting.ProvideProvider(cfg), featuremgmt.WithFeatures())
 		require.NoError(t, err)
@@ -204,6 +205,7 @@ func TestRequestConfigProvider_PluginRequestConfig_SQL(t *testing.T) {
 			"GF_SQL_MAX_OPEN_CONNS_DEFAULT":            "24",
 			"GF_SQL_MAX_IDLE_CONNS_DEFAULT":            "25",
 			"GF_SQL_MAX_CONN_LIFETIME_SECONDS_DEFAULT": "26",
+			"GF_SQL_STREAM_ROW_LIMIT":                  "27",
 		})
 	})
 
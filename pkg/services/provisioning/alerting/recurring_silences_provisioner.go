@@ -0,0 +1,78 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/api"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+type RecurringSilenceService interface {
+	GetRecurringSilence(ctx context.Context, user identity.Requester, uid string) (*models.RecurringSilence, error)
+	CreateRecurringSilence(ctx context.Context, user identity.Requester, r models.RecurringSilence) (models.RecurringSilence, error)
+	UpdateRecurringSilence(ctx context.Context, user identity.Requester, r models.RecurringSilence) (models.RecurringSilence, error)
+	DeleteRecurringSilence(ctx context.Context, user identity.Requester, uid string, callerProvenance models.Provenance) error
+}
+
+type RecurringSilencesProvisioner interface {
+	Provision(ctx context.Context, files []*AlertingFile) error
+	Unprovision(ctx context.Context, files []*AlertingFile) error
+}
+
+type defaultRecurringSilencesProvisioner struct {
+	logger                  log.Logger
+	recurringSilenceService RecurringSilenceService
+}
+
+func NewRecurringSilencesProvisioner(logger log.Logger,
+	recurringSilenceService RecurringSilenceService) RecurringSilencesProvisioner {
+	return &defaultRecurringSilencesProvisioner{
+		logger:                  logger,
+		recurringSilenceService: recurringSilenceService,
+	}
+}
+
+func (c *defaultRecurringSilencesProvisioner) Provision(ctx context.Context,
+	files []*AlertingFile) error {
+	for _, file := range files {
+		for _, recurringSilence := range file.RecurringSilences {
+			silence, err := api.RecurringSilenceFromApi(recurringSilence.RecurringSilence)
+			if err != nil {
+				return fmt.Errorf("recurring silence %s: %w", recurringSilence.RecurringSilence.UID, err)
+			}
+			silence.Provenance = models.ProvenanceFile
+			user := provisionerUser(recurringSilence.OrgID)
+			_, err = c.recurringSilenceService.GetRecurringSilence(ctx, user, silence.UID)
+			if err == nil {
+				if _, err := c.recurringSilenceService.UpdateRecurringSilence(ctx, user, silence); err != nil {
+					return err
+				}
+				continue
+			}
+			if !errors.Is(err, models.ErrRecurringSilenceNotFound) {
+				return err
+			}
+			if _, err := c.recurringSilenceService.CreateRecurringSilence(ctx, user, silence); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *defaultRecurringSilencesProvisioner) Unprovision(ctx context.Context,
+	files []*AlertingFile) error {
+	for _, file := range files {
+		for _, deleteRecurringSilence := range file.DeleteRecurringSilences {
+			err := c.recurringSilenceService.DeleteRecurringSilence(ctx, provisionerUser(deleteRecurringSilence.OrgID), deleteRecurringSilence.UID, models.ProvenanceFile)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
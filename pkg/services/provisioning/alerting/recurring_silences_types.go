@@ -0,0 +1,58 @@
+package alerting
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/provisioning/values"
+)
+
+type RecurringSilenceV1 struct {
+	OrgID            values.Int64Value            `json:"orgId" yaml:"orgId"`
+	RecurringSilence definitions.RecurringSilence `json:",inline" yaml:",inline"`
+}
+
+func (v1 *RecurringSilenceV1) mapToModel() (RecurringSilence, error) {
+	if strings.TrimSpace(v1.RecurringSilence.UID) == "" {
+		return RecurringSilence{}, errors.New("recurring silence missing uid")
+	}
+	orgID := v1.OrgID.Value()
+	if orgID < 1 {
+		orgID = 1
+	}
+	return RecurringSilence{
+		OrgID:            orgID,
+		RecurringSilence: v1.RecurringSilence,
+	}, nil
+}
+
+type RecurringSilence struct {
+	OrgID            int64
+	RecurringSilence definitions.RecurringSilence
+}
+
+type DeleteRecurringSilenceV1 struct {
+	OrgID values.Int64Value  `json:"orgId" yaml:"orgId"`
+	UID   values.StringValue `json:"uid" yaml:"uid"`
+}
+
+func (v1 *DeleteRecurringSilenceV1) mapToModel() (DeleteRecurringSilence, error) {
+	uid := strings.TrimSpace(v1.UID.Value())
+	if uid == "" {
+		return DeleteRecurringSilence{}, errors.New("delete recurring silence missing uid")
+	}
+	orgID := v1.OrgID.Value()
+	if orgID < 1 {
+		orgID = 1
+	}
+	return DeleteRecurringSilence{
+		OrgID: orgID,
+		UID:   uid,
+	}, nil
+}
+
+type DeleteRecurringSilence struct {
+	OrgID int64
+	UID   string
+}
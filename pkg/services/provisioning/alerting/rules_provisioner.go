@@ -165,6 +165,9 @@ var provisionerUser = func(orgID int64) identity.Requester {
 			{Action: dashboards.ActionFoldersRead, Scope: dashboards.ScopeFoldersAll},
 			{Action: accesscontrol.ActionAlertingProvisioningReadSecrets, Scope: dashboards.ScopeFoldersAll},
 			{Action: accesscontrol.ActionAlertingProvisioningWrite, Scope: dashboards.ScopeFoldersAll},
+			{Action: accesscontrol.ActionAlertingInstanceRead},
+			{Action: accesscontrol.ActionAlertingInstanceCreate},
+			{Action: accesscontrol.ActionAlertingInstanceUpdate},
 		},
 	)
 }
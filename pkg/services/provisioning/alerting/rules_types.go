@@ -62,21 +62,24 @@ func (ruleGroupV1 *AlertRuleGroupV1) MapToModel() (models.AlertRuleGroupWithFold
 }
 
 type AlertRuleV1 struct {
-	UID                  values.StringValue      `json:"uid" yaml:"uid"`
-	Title                values.StringValue      `json:"title" yaml:"title"`
-	Condition            values.StringValue      `json:"condition" yaml:"condition"`
-	Data                 []QueryV1               `json:"data" yaml:"data"`
-	DasboardUID          values.StringValue      `json:"dasboardUid" yaml:"dasboardUid"` // TODO: Grandfathered typo support. TODO: This should be removed in V2.
-	DashboardUID         values.StringValue      `json:"dashboardUid" yaml:"dashboardUid"`
-	PanelID              values.Int64Value       `json:"panelId" yaml:"panelId"`
-	NoDataState          values.StringValue      `json:"noDataState" yaml:"noDataState"`
-	ExecErrState         values.StringValue      `json:"execErrState" yaml:"execErrState"`
-	For                  values.StringValue      `json:"for" yaml:"for"`
-	Annotations          values.StringMapValue   `json:"annotations" yaml:"annotations"`
-	Labels               values.StringMapValue   `json:"labels" yaml:"labels"`
-	IsPaused             values.BoolValue        `json:"isPaused" yaml:"isPaused"`
-	NotificationSettings *NotificationSettingsV1 `json:"notification_settings" yaml:"notification_settings"`
-	Record               *RecordV1               `json:"record" yaml:"record"`
+	UID                       values.StringValue      `json:"uid" yaml:"uid"`
+	Title                     values.StringValue      `json:"title" yaml:"title"`
+	Condition                 values.StringValue      `json:"condition" yaml:"condition"`
+	Data                      []QueryV1               `json:"data" yaml:"data"`
+	DasboardUID               values.StringValue      `json:"dasboardUid" yaml:"dasboardUid"` // TODO: Grandfathered typo support. TODO: This should be removed in V2.
+	DashboardUID              values.StringValue      `json:"dashboardUid" yaml:"dashboardUid"`
+	PanelID                   values.Int64Value       `json:"panelId" yaml:"panelId"`
+	NoDataState               values.StringValue      `json:"noDataState" yaml:"noDataState"`
+	ExecErrState              values.StringValue      `json:"execErrState" yaml:"execErrState"`
+	For                       values.StringValue      `json:"for" yaml:"for"`
+	Annotations               values.StringMapValue   `json:"annotations" yaml:"annotations"`
+	Labels                    values.StringMapValue   `json:"labels" yaml:"labels"`
+	IsPaused                  values.BoolValue        `json:"isPaused" yaml:"isPaused"`
+	NotificationSettings      *NotificationSettingsV1 `json:"notification_settings" yaml:"notification_settings"`
+	Record                    *RecordV1               `json:"record" yaml:"record"`
+	ActiveTimeIntervals       []values.StringValue    `json:"active_time_intervals" yaml:"active_time_intervals"`
+	Dependencies              []RuleDependencyV1      `json:"dependencies" yaml:"dependencies"`
+	MissingSeriesEvalsToAlert values.Int64Value       `json:"missing_series_evals_to_alert" yaml:"missing_series_evals_to_alert"`
 }
 
 func withFallback(value, fallback string) *string {
@@ -162,9 +165,37 @@ func (rule *AlertRuleV1) mapToModel(orgID int64) (models.AlertRule, error) {
 		}
 		alertRule.Record = &record
 	}
+	for _, value := range rule.ActiveTimeIntervals {
+		if value.Value() == "" {
+			continue
+		}
+		alertRule.ActiveTimeIntervals = append(alertRule.ActiveTimeIntervals, value.Value())
+	}
+	for _, dep := range rule.Dependencies {
+		alertRule.Dependencies = append(alertRule.Dependencies, dep.mapToModel())
+	}
+	alertRule.MissingSeriesEvalsToAlert = rule.MissingSeriesEvalsToAlert.Value()
 	return alertRule, nil
 }
 
+type RuleDependencyV1 struct {
+	RuleUID  values.StringValue   `json:"rule_uid" yaml:"rule_uid"`
+	Matchers []values.StringValue `json:"matchers" yaml:"matchers"`
+}
+
+func (depV1 *RuleDependencyV1) mapToModel() models.RuleDependency {
+	dep := models.RuleDependency{
+		RuleUID: depV1.RuleUID.Value(),
+	}
+	for _, m := range depV1.Matchers {
+		if m.Value() == "" {
+			continue
+		}
+		dep.Matchers = append(dep.Matchers, m.Value())
+	}
+	return dep
+}
+
 type QueryV1 struct {
 	RefID             values.StringValue       `json:"refId" yaml:"refId"`
 	QueryType         values.StringValue       `json:"queryType" yaml:"queryType"`
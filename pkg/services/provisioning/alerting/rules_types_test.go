@@ -200,6 +200,38 @@ func TestRules(t *testing.T) {
 		require.Len(t, ruleMapped.NotificationSettings, 1)
 		require.Equal(t, models.NotificationSettings{Receiver: "test-receiver"}, ruleMapped.NotificationSettings[0])
 	})
+	t.Run("a rule with active time intervals should map them and skip empty ones", func(t *testing.T) {
+		rule := validRuleV1(t)
+		rule.ActiveTimeIntervals = []values.StringValue{
+			stringToStringValue("business-hours"),
+			stringToStringValue(""),
+			stringToStringValue("weekends"),
+		}
+		ruleMapped, err := rule.mapToModel(1)
+		require.NoError(t, err)
+		require.Equal(t, []string{"business-hours", "weekends"}, ruleMapped.ActiveTimeIntervals)
+	})
+	t.Run("a rule with dependencies should map them", func(t *testing.T) {
+		rule := validRuleV1(t)
+		rule.Dependencies = []RuleDependencyV1{
+			{RuleUID: stringToStringValue("upstream")},
+			{Matchers: []values.StringValue{stringToStringValue("team=network"), stringToStringValue("")}},
+		}
+		ruleMapped, err := rule.mapToModel(1)
+		require.NoError(t, err)
+		require.Equal(t, []models.RuleDependency{
+			{RuleUID: "upstream"},
+			{Matchers: []string{"team=network"}},
+		}, ruleMapped.Dependencies)
+	})
+	t.Run("a rule with missing series evaluations to alert should map it", func(t *testing.T) {
+		rule := validRuleV1(t)
+		err := yaml.Unmarshal([]byte("3"), &rule.MissingSeriesEvalsToAlert)
+		require.NoError(t, err)
+		ruleMapped, err := rule.mapToModel(1)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), ruleMapped.MissingSeriesEvalsToAlert)
+	})
 }
 
 func TestNotificationsSettingsV1MapToModel(t *testing.T) {
@@ -15,32 +15,36 @@ type OrgID int64
 
 type AlertingFile struct {
 	configVersion
-	Filename            string
-	Groups              []models.AlertRuleGroupWithFolderFullpath
-	DeleteRules         []RuleDelete
-	ContactPoints       []ContactPoint
-	DeleteContactPoints []DeleteContactPoint
-	Policies            []NotificiationPolicy
-	ResetPolicies       []OrgID
-	MuteTimes           []MuteTime
-	DeleteMuteTimes     []DeleteMuteTime
-	Templates           []Template
-	DeleteTemplates     []DeleteTemplate
+	Filename                string
+	Groups                  []models.AlertRuleGroupWithFolderFullpath
+	DeleteRules             []RuleDelete
+	ContactPoints           []ContactPoint
+	DeleteContactPoints     []DeleteContactPoint
+	Policies                []NotificiationPolicy
+	ResetPolicies           []OrgID
+	MuteTimes               []MuteTime
+	DeleteMuteTimes         []DeleteMuteTime
+	Templates               []Template
+	DeleteTemplates         []DeleteTemplate
+	RecurringSilences       []RecurringSilence
+	DeleteRecurringSilences []DeleteRecurringSilence
 }
 
 type AlertingFileV1 struct {
 	configVersion
-	Filename            string
-	Groups              []AlertRuleGroupV1      `json:"groups" yaml:"groups"`
-	DeleteRules         []RuleDeleteV1          `json:"deleteRules" yaml:"deleteRules"`
-	ContactPoints       []ContactPointV1        `json:"contactPoints" yaml:"contactPoints"`
-	DeleteContactPoints []DeleteContactPointV1  `json:"deleteContactPoints" yaml:"deleteContactPoints"`
-	Policies            []NotificiationPolicyV1 `json:"policies" yaml:"policies"`
-	ResetPolicies       []values.Int64Value     `json:"resetPolicies" yaml:"resetPolicies"`
-	MuteTimes           []MuteTimeV1            `json:"muteTimes" yaml:"muteTimes"`
-	DeleteMuteTimes     []DeleteMuteTimeV1      `json:"deleteMuteTimes" yaml:"deleteMuteTimes"`
-	Templates           []TemplateV1            `json:"templates" yaml:"templates"`
-	DeleteTemplates     []DeleteTemplateV1      `json:"deleteTemplates" yaml:"deleteTemplates"`
+	Filename                string
+	Groups                  []AlertRuleGroupV1         `json:"groups" yaml:"groups"`
+	DeleteRules             []RuleDeleteV1             `json:"deleteRules" yaml:"deleteRules"`
+	ContactPoints           []ContactPointV1           `json:"contactPoints" yaml:"contactPoints"`
+	DeleteContactPoints     []DeleteContactPointV1     `json:"deleteContactPoints" yaml:"deleteContactPoints"`
+	Policies                []NotificiationPolicyV1    `json:"policies" yaml:"policies"`
+	ResetPolicies           []values.Int64Value        `json:"resetPolicies" yaml:"resetPolicies"`
+	MuteTimes               []MuteTimeV1               `json:"muteTimes" yaml:"muteTimes"`
+	DeleteMuteTimes         []DeleteMuteTimeV1         `json:"deleteMuteTimes" yaml:"deleteMuteTimes"`
+	Templates               []TemplateV1               `json:"templates" yaml:"templates"`
+	DeleteTemplates         []DeleteTemplateV1         `json:"deleteTemplates" yaml:"deleteTemplates"`
+	RecurringSilences       []RecurringSilenceV1       `json:"recurringSilences" yaml:"recurringSilences"`
+	DeleteRecurringSilences []DeleteRecurringSilenceV1 `json:"deleteRecurringSilences" yaml:"deleteRecurringSilences"`
 }
 
 func (fileV1 *AlertingFileV1) MapToModel() (AlertingFile, error) {
@@ -61,6 +65,9 @@ func (fileV1 *AlertingFileV1) MapToModel() (AlertingFile, error) {
 	if err := fileV1.mapTemplates(&alertingFile); err != nil {
 		return AlertingFile{}, fmt.Errorf("failure parsing templates: %w", err)
 	}
+	if err := fileV1.mapRecurringSilences(&alertingFile); err != nil {
+		return AlertingFile{}, fmt.Errorf("failure parsing recurring silences: %w", err)
+	}
 	return alertingFile, nil
 }
 
@@ -78,6 +85,24 @@ func (fileV1 *AlertingFileV1) mapTemplates(alertingFile *AlertingFile) error {
 	return nil
 }
 
+func (fileV1 *AlertingFileV1) mapRecurringSilences(alertingFile *AlertingFile) error {
+	for _, rsV1 := range fileV1.RecurringSilences {
+		rs, err := rsV1.mapToModel()
+		if err != nil {
+			return err
+		}
+		alertingFile.RecurringSilences = append(alertingFile.RecurringSilences, rs)
+	}
+	for _, deleteV1 := range fileV1.DeleteRecurringSilences {
+		delReq, err := deleteV1.mapToModel()
+		if err != nil {
+			return err
+		}
+		alertingFile.DeleteRecurringSilences = append(alertingFile.DeleteRecurringSilences, delReq)
+	}
+	return nil
+}
+
 func (fileV1 *AlertingFileV1) mapMuteTimes(alertingFile *AlertingFile) error {
 	for _, mtV1 := range fileV1.MuteTimes {
 		alertingFile.MuteTimes = append(alertingFile.MuteTimes, mtV1.mapToModel())
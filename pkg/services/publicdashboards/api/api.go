@@ -76,7 +76,7 @@ func (api *Api) RegisterAPIEndpoints() {
 		apiRoute.Get("/", routing.Wrap(api.ViewPublicDashboard))
 		apiRoute.Get("/annotations", routing.Wrap(api.GetPublicAnnotations))
 		apiRoute.Post("/panels/:panelId/query", routing.Wrap(api.QueryPublicDashboard))
-	}, api.Middleware.HandleApi)
+	}, api.Middleware.HandleApi, RestrictPublicDashboardAccess(api.PublicDashboardService, api.cfg.PublicDashboardsTrustedProxies))
 
 	// Auth endpoints
 	auth := accesscontrol.Middleware(api.accessControl)
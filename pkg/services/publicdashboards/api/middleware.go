@@ -2,10 +2,12 @@ package api
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/grafana/grafana/pkg/infra/metrics"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	"github.com/grafana/grafana/pkg/services/publicdashboards"
+	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
 	"github.com/grafana/grafana/pkg/services/publicdashboards/validation"
 	"github.com/grafana/grafana/pkg/web"
 )
@@ -62,6 +64,34 @@ func RequiresExistingAccessToken(publicDashboardService publicdashboards.Service
 	}
 }
 
+// RestrictPublicDashboardAccess Middleware to reject requests to expired public dashboards and requests from IP
+// addresses which are not in the allow-list of the public dashboard. The X-Forwarded-For header is only used for
+// connections from trustedProxies, because it can be set by the client.
+func RestrictPublicDashboardAccess(publicDashboardService publicdashboards.Service, trustedProxies []string) func(c *contextmodel.ReqContext) {
+	return func(c *contextmodel.ReqContext) {
+		accessToken, ok := web.Params(c.Req)[":accessToken"]
+		if !ok || !validation.IsValidAccessToken(accessToken) {
+			return
+		}
+
+		// the handlers respond when the public dashboard cannot be found
+		pubdash, err := publicDashboardService.FindByAccessToken(c.Req.Context(), accessToken)
+		if err != nil {
+			return
+		}
+
+		if pubdash.IsExpired(time.Now()) {
+			c.WriteErr(ErrPublicDashboardExpired.Errorf("RestrictPublicDashboardAccess: public dashboard %s has expired", pubdash.Uid))
+			return
+		}
+
+		if !pubdash.AllowedIPs.Allows(web.ClientIP(c.Req, trustedProxies)) {
+			c.WriteErr(ErrIPNotAllowed.Errorf("RestrictPublicDashboardAccess: IP address not allowed for public dashboard %s", pubdash.Uid))
+			return
+		}
+	}
+}
+
 func CountPublicDashboardRequest() func(c *contextmodel.ReqContext) {
 	return func(c *contextmodel.ReqContext) {
 		metrics.MPublicDashboardRequestCount.Inc()
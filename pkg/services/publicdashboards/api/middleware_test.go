@@ -5,11 +5,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"errors"
 
+	"github.com/grafana/grafana/pkg/infra/log"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	"github.com/grafana/grafana/pkg/services/publicdashboards"
+	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
 	"github.com/grafana/grafana/pkg/services/publicdashboards/service"
 	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/grafana/grafana/pkg/web"
@@ -83,6 +86,114 @@ func TestRequiresExistingAccessToken(t *testing.T) {
 	}
 }
 
+func TestRestrictPublicDashboardAccess(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		Name                 string
+		PublicDashboard      *PublicDashboard
+		FindErr              error
+		RemoteAddr           string
+		ForwardedFor         string
+		TrustedProxies       []string
+		ExpectedResponseCode int
+	}{
+		{
+			Name:                 "Returns 200 when public dashboard has no restrictions",
+			PublicDashboard:      &PublicDashboard{Uid: "pubdash"},
+			RemoteAddr:           "203.0.113.7:4321",
+			ExpectedResponseCode: http.StatusOK,
+		},
+		{
+			Name:                 "Returns 200 when public dashboard has not expired",
+			PublicDashboard:      &PublicDashboard{Uid: "pubdash", ExpiresAt: &future},
+			RemoteAddr:           "203.0.113.7:4321",
+			ExpectedResponseCode: http.StatusOK,
+		},
+		{
+			Name:                 "Returns 403 when public dashboard has expired",
+			PublicDashboard:      &PublicDashboard{Uid: "pubdash", ExpiresAt: &past},
+			RemoteAddr:           "203.0.113.7:4321",
+			ExpectedResponseCode: http.StatusForbidden,
+		},
+		{
+			Name:                 "Returns 200 when IP address is allowed",
+			PublicDashboard:      &PublicDashboard{Uid: "pubdash", AllowedIPs: AllowedIPs{"203.0.113.0/24"}},
+			RemoteAddr:           "203.0.113.7:4321",
+			ExpectedResponseCode: http.StatusOK,
+		},
+		{
+			Name:                 "Returns 403 when IP address is not allowed",
+			PublicDashboard:      &PublicDashboard{Uid: "pubdash", AllowedIPs: AllowedIPs{"10.0.0.0/8"}},
+			RemoteAddr:           "203.0.113.7:4321",
+			ExpectedResponseCode: http.StatusForbidden,
+		},
+		{
+			Name:                 "Returns 403 when only the forwarded IP address is allowed",
+			PublicDashboard:      &PublicDashboard{Uid: "pubdash", AllowedIPs: AllowedIPs{"10.0.0.0/8"}},
+			RemoteAddr:           "203.0.113.7:4321",
+			ForwardedFor:         "10.0.0.1",
+			ExpectedResponseCode: http.StatusForbidden,
+		},
+		{
+			Name:                 "Returns 403 when the forwarded IP address is allowed but the proxy is not trusted",
+			PublicDashboard:      &PublicDashboard{Uid: "pubdash", AllowedIPs: AllowedIPs{"10.0.0.0/8"}},
+			RemoteAddr:           "203.0.113.7:4321",
+			ForwardedFor:         "10.0.0.1",
+			TrustedProxies:       []string{"192.168.0.0/16"},
+			ExpectedResponseCode: http.StatusForbidden,
+		},
+		{
+			Name:                 "Returns 200 when the forwarded IP address of a trusted proxy is allowed",
+			PublicDashboard:      &PublicDashboard{Uid: "pubdash", AllowedIPs: AllowedIPs{"10.0.0.0/8"}},
+			RemoteAddr:           "192.168.1.2:4321",
+			ForwardedFor:         "10.0.0.1",
+			TrustedProxies:       []string{"192.168.0.0/16"},
+			ExpectedResponseCode: http.StatusOK,
+		},
+		{
+			Name:                 "Returns 200 when the client IP address is forwarded by a chain of trusted proxies",
+			PublicDashboard:      &PublicDashboard{Uid: "pubdash", AllowedIPs: AllowedIPs{"10.0.0.0/8"}},
+			RemoteAddr:           "192.168.1.2:4321",
+			ForwardedFor:         "10.0.0.1, 192.168.1.3",
+			TrustedProxies:       []string{"192.168.0.0/16"},
+			ExpectedResponseCode: http.StatusOK,
+		},
+		{
+			Name:                 "Returns 403 when an allowed IP address is forged in front of the client IP address",
+			PublicDashboard:      &PublicDashboard{Uid: "pubdash", AllowedIPs: AllowedIPs{"10.0.0.0/8"}},
+			RemoteAddr:           "192.168.1.2:4321",
+			ForwardedFor:         "10.0.0.1, 203.0.113.7",
+			TrustedProxies:       []string{"192.168.0.0/16"},
+			ExpectedResponseCode: http.StatusForbidden,
+		},
+		{
+			Name:                 "Returns 200 and leaves the response to the handler when public dashboard is not found",
+			FindErr:              ErrPublicDashboardNotFound.Errorf("not found"),
+			RemoteAddr:           "203.0.113.7:4321",
+			ExpectedResponseCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			publicdashboardService := &publicdashboards.FakePublicDashboardService{}
+			publicdashboardService.On("FindByAccessToken", mock.Anything, validAccessToken).Return(tt.PublicDashboard, tt.FindErr)
+			params := map[string]string{":accessToken": validAccessToken}
+			restrict := RestrictPublicDashboardAccess(publicdashboardService, tt.TrustedProxies)
+			mw := func(c *contextmodel.ReqContext) {
+				c.Req.RemoteAddr = tt.RemoteAddr
+				c.Req.Header.Set("X-Forwarded-For", tt.ForwardedFor)
+				restrict(c)
+			}
+			ctx := &contextmodel.ReqContext{Logger: log.NewNopLogger()}
+			_, resp := runMw(t, ctx, "GET", "/api/public/dashboards/myAccesstoken", params, mw)
+			require.Equal(t, tt.ExpectedResponseCode, resp.Code)
+		})
+	}
+}
+
 func TestSetPublicDashboardOrgIdOnContext(t *testing.T) {
 	tests := []struct {
 		Name          string
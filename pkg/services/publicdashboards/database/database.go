@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/grafana/grafana/pkg/infra/db"
 	"github.com/grafana/grafana/pkg/infra/log"
@@ -191,9 +192,9 @@ func (d *PublicDashboardStoreImpl) ExistsEnabledByDashboardUid(ctx context.Conte
 func (d *PublicDashboardStoreImpl) ExistsEnabledByAccessToken(ctx context.Context, accessToken string) (bool, error) {
 	hasPublicDashboard := false
 	err := d.sqlStore.WithDbSession(ctx, func(dbSession *db.Session) error {
-		sql := "SELECT COUNT(*) FROM dashboard_public WHERE access_token=? AND is_enabled=true"
+		sql := "SELECT COUNT(*) FROM dashboard_public WHERE access_token=? AND is_enabled=true AND (expires_at IS NULL OR expires_at > ?)"
 
-		result, err := dbSession.SQL(sql, accessToken).Count()
+		result, err := dbSession.SQL(sql, accessToken, time.Now().UTC().Format("2006-01-02 15:04:05")).Count()
 		if err != nil {
 			return err
 		}
@@ -247,12 +248,30 @@ func (d *PublicDashboardStoreImpl) Update(ctx context.Context, cmd SavePublicDas
 			return err
 		}
 
-		sqlResult, err := sess.Exec("UPDATE dashboard_public SET is_enabled = ?, annotations_enabled = ?, time_selection_enabled = ?, share = ?, time_settings = ?, updated_by = ?, updated_at = ? WHERE uid = ?",
+		allowedIPsJSON, err := cmd.PublicDashboard.AllowedIPs.ToDB()
+		if err != nil {
+			return err
+		}
+
+		var expiresAt any
+		if cmd.PublicDashboard.ExpiresAt != nil {
+			expiresAt = cmd.PublicDashboard.ExpiresAt.UTC().Format("2006-01-02 15:04:05")
+		}
+
+		var allowedIPs any
+		if allowedIPsJSON != nil {
+			allowedIPs = string(allowedIPsJSON)
+		}
+
+		sqlResult, err := sess.Exec("UPDATE dashboard_public SET is_enabled = ?, annotations_enabled = ?, time_selection_enabled = ?, share = ?, time_settings = ?, expires_at = ?, allowed_ips = ?, query_rate_limit = ?, updated_by = ?, updated_at = ? WHERE uid = ?",
 			cmd.PublicDashboard.IsEnabled,
 			cmd.PublicDashboard.AnnotationsEnabled,
 			cmd.PublicDashboard.TimeSelectionEnabled,
 			cmd.PublicDashboard.Share,
 			string(timeSettingsJSON),
+			expiresAt,
+			allowedIPs,
+			cmd.PublicDashboard.QueryRateLimit,
 			cmd.PublicDashboard.UpdatedBy,
 			cmd.PublicDashboard.UpdatedAt.UTC().Format("2006-01-02 15:04:05"),
 			cmd.PublicDashboard.Uid)
@@ -296,6 +315,36 @@ func (d *PublicDashboardStoreImpl) FindByFolder(ctx context.Context, orgId int64
 	return pubdashes, nil
 }
 
+// FindExpired Returns the public dashboards with an expiry date before the given time
+func (d *PublicDashboardStoreImpl) FindExpired(ctx context.Context, now time.Time) ([]*PublicDashboard, error) {
+	var pubdashes []*PublicDashboard
+
+	err := d.sqlStore.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Where("expires_at IS NOT NULL AND expires_at <= ?", now.UTC().Format("2006-01-02 15:04:05")).Find(&pubdashes)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pubdashes, nil
+}
+
+// IncrementViewCount Increments the number of times the public dashboard has been viewed by the given count
+func (d *PublicDashboardStoreImpl) IncrementViewCount(ctx context.Context, uid string, count int64) error {
+	return d.sqlStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Exec("UPDATE dashboard_public SET view_count = view_count + ? WHERE uid = ?", count, uid)
+		return err
+	})
+}
+
+// IncrementQueryCount Increments the number of panel queries made through the public dashboard by the given count
+func (d *PublicDashboardStoreImpl) IncrementQueryCount(ctx context.Context, uid string, count int64) error {
+	return d.sqlStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Exec("UPDATE dashboard_public SET query_count = query_count + ? WHERE uid = ?", count, uid)
+		return err
+	})
+}
+
 func (d *PublicDashboardStoreImpl) GetMetrics(ctx context.Context) (*Metrics, error) {
 	metrics := &Metrics{
 		TotalPublicDashboards: []*TotalPublicDashboard{},
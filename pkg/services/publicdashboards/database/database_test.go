@@ -740,6 +740,100 @@ func TestIntegrationDelete(t *testing.T) {
 	})
 }
 
+func TestIntegrationAccessRestrictions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	var publicdashboardStore *PublicDashboardStoreImpl
+	var savedPublicDashboard *PublicDashboard
+
+	setup := func() {
+		sqlStore, cfg := db.InitTestDBWithCfg(t)
+		dashboardStore, err := dashboardsDB.ProvideDashboardStore(sqlStore, cfg, featuremgmt.WithFeatures(), tagimpl.ProvideService(sqlStore), quotatest.New(false, nil))
+		require.NoError(t, err)
+		publicdashboardStore = ProvideStore(sqlStore, cfg, featuremgmt.WithFeatures())
+		savedDashboard := insertTestDashboard(t, dashboardStore, "testDashie", 1, "", true)
+		savedPublicDashboard = insertPublicDashboard(t, publicdashboardStore, savedDashboard.UID, savedDashboard.OrgID, true, PublicShareType)
+	}
+
+	update := func(t *testing.T, expiresAt *time.Time, allowedIPs AllowedIPs) *PublicDashboard {
+		t.Helper()
+		pubdash := *savedPublicDashboard
+		pubdash.ExpiresAt = expiresAt
+		pubdash.AllowedIPs = allowedIPs
+		pubdash.QueryRateLimit = 60
+		pubdash.UpdatedAt = DefaultTime
+		_, err := publicdashboardStore.Update(context.Background(), SavePublicDashboardCommand{PublicDashboard: pubdash})
+		require.NoError(t, err)
+
+		updated, err := publicdashboardStore.Find(context.Background(), savedPublicDashboard.Uid)
+		require.NoError(t, err)
+		return updated
+	}
+
+	t.Run("Update stores the access restrictions", func(t *testing.T) {
+		setup()
+		expiresAt := DefaultTime.Add(time.Hour)
+
+		updated := update(t, &expiresAt, AllowedIPs{"10.0.0.0/8", "203.0.113.7"})
+		require.NotNil(t, updated.ExpiresAt)
+		assert.True(t, expiresAt.Equal(*updated.ExpiresAt))
+		assert.Equal(t, AllowedIPs{"10.0.0.0/8", "203.0.113.7"}, updated.AllowedIPs)
+		assert.EqualValues(t, 60, updated.QueryRateLimit)
+
+		updated = update(t, nil, nil)
+		assert.Nil(t, updated.ExpiresAt)
+		assert.Empty(t, updated.AllowedIPs)
+	})
+
+	t.Run("FindExpired returns public dashboards which have expired", func(t *testing.T) {
+		setup()
+
+		pubdashes, err := publicdashboardStore.FindExpired(context.Background(), time.Now())
+		require.NoError(t, err)
+		assert.Empty(t, pubdashes)
+
+		expiresAt := DefaultTime.Add(time.Hour)
+		update(t, &expiresAt, nil)
+
+		pubdashes, err = publicdashboardStore.FindExpired(context.Background(), time.Now())
+		require.NoError(t, err)
+		assert.Empty(t, pubdashes)
+
+		pubdashes, err = publicdashboardStore.FindExpired(context.Background(), expiresAt.Add(time.Minute))
+		require.NoError(t, err)
+		require.Len(t, pubdashes, 1)
+		assert.Equal(t, savedPublicDashboard.Uid, pubdashes[0].Uid)
+	})
+
+	t.Run("ExistsEnabledByAccessToken is false when the public dashboard has expired", func(t *testing.T) {
+		setup()
+		expiresAt := DefaultTime.Add(-time.Hour)
+		update(t, &expiresAt, nil)
+
+		exists, err := publicdashboardStore.ExistsEnabledByAccessToken(context.Background(), savedPublicDashboard.AccessToken)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("Increments the view and query counts", func(t *testing.T) {
+		setup()
+
+		require.NoError(t, publicdashboardStore.IncrementViewCount(context.Background(), savedPublicDashboard.Uid, 1))
+		require.NoError(t, publicdashboardStore.IncrementQueryCount(context.Background(), savedPublicDashboard.Uid, 2))
+
+		pubdash, err := publicdashboardStore.Find(context.Background(), savedPublicDashboard.Uid)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, pubdash.ViewCount)
+		assert.EqualValues(t, 2, pubdash.QueryCount)
+
+		// updates do not reset the counts
+		pubdash = update(t, nil, nil)
+		assert.EqualValues(t, 1, pubdash.ViewCount)
+		assert.EqualValues(t, 2, pubdash.QueryCount)
+	})
+}
+
 func TestFindByFolder(t *testing.T) {
 	t.Run("returns nil when dashboard is not a folder", func(t *testing.T) {
 		sqlStore, cfg := db.InitTestDBWithCfg(t)
@@ -23,6 +23,13 @@ var (
 	ErrDashboardIsPublic                   = errutil.BadRequest("publicdashboards.dashboardIsPublic", errutil.WithPublicMessage("Dashboard is already public"))
 	ErrPublicDashboardUidExists            = errutil.BadRequest("publicdashboards.uidExists", errutil.WithPublicMessage("Dashboard Uid already exists"))
 	ErrPublicDashboardAccessTokenExists    = errutil.BadRequest("publicdashboards.accessTokenExists", errutil.WithPublicMessage("Dashboard Access Token already exists"))
+	ErrInvalidExpiresAt                    = errutil.BadRequest("publicdashboards.invalidExpiresAt", errutil.WithPublicMessage("Expiry date should be in the future"))
+	ErrInvalidAllowedIPs                   = errutil.BadRequest("publicdashboards.invalidAllowedIps", errutil.WithPublicMessage("Allowed IPs should be IP addresses or CIDR ranges"))
+	ErrInvalidQueryRateLimit               = errutil.BadRequest("publicdashboards.invalidQueryRateLimit", errutil.WithPublicMessage("Query rate limit should not be negative"))
 
 	ErrPublicDashboardNotEnabled = errutil.Forbidden("publicdashboards.notEnabled", errutil.WithPublicMessage("Dashboard paused"))
+	ErrPublicDashboardExpired    = errutil.Forbidden("publicdashboards.expired", errutil.WithPublicMessage("Dashboard expired"))
+	ErrIPNotAllowed              = errutil.Forbidden("publicdashboards.ipNotAllowed", errutil.WithPublicMessage("Access from this IP address is not allowed"))
+
+	ErrQueryRateLimitExceeded = errutil.TooManyRequests("publicdashboards.queryRateLimitExceeded", errutil.WithPublicMessage("Too many queries, try again later"))
 )
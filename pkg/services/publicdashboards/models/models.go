@@ -2,6 +2,8 @@ package models
 
 import (
 	"encoding/json"
+	"net"
+	"strings"
 	"time"
 
 	"github.com/grafana/grafana/pkg/kinds/dashboard"
@@ -54,6 +56,18 @@ type PublicDashboard struct {
 	AnnotationsEnabled   bool          `json:"annotationsEnabled" xorm:"annotations_enabled"`
 	Share                ShareType     `json:"share" xorm:"share"`
 	Recipients           []EmailDTO    `json:"recipients,omitempty" xorm:"-"`
+	//access restrictions
+	ExpiresAt      *time.Time `json:"expiresAt" xorm:"expires_at"`
+	AllowedIPs     AllowedIPs `json:"allowedIps" xorm:"allowed_ips"`
+	QueryRateLimit int64      `json:"queryRateLimit" xorm:"query_rate_limit"`
+	//usage counters, only updated through the store increment methods
+	ViewCount  int64 `json:"viewCount" xorm:"view_count"`
+	QueryCount int64 `json:"queryCount" xorm:"query_count"`
+}
+
+// IsExpired returns true if the public dashboard has an expiry date which is before the given time
+func (pd PublicDashboard) IsExpired(now time.Time) bool {
+	return pd.ExpiresAt != nil && !pd.ExpiresAt.After(now)
 }
 
 type PublicDashboardDTO struct {
@@ -63,6 +77,12 @@ type PublicDashboardDTO struct {
 	IsEnabled            *bool     `json:"isEnabled"`
 	AnnotationsEnabled   *bool     `json:"annotationsEnabled"`
 	Share                ShareType `json:"share"`
+	// ExpiresAt is the expiry date in epoch milliseconds, 0 removes the expiry date
+	ExpiresAt *int64 `json:"expiresAt"`
+	// AllowedIPs replaces the allow-list when set, an empty list removes it
+	AllowedIPs []string `json:"allowedIps"`
+	// QueryRateLimit is the maximum number of panel queries per minute, 0 removes the limit
+	QueryRateLimit *int64 `json:"queryRateLimit"`
 }
 
 type EmailDTO struct {
@@ -134,6 +154,48 @@ func (ts *TimeSettings) ToDB() ([]byte, error) {
 	return json.Marshal(ts)
 }
 
+// AllowedIPs is a list of IP addresses and CIDR ranges allowed to access a public dashboard. An empty list allows
+// access from any address.
+type AllowedIPs []string
+
+func (ips *AllowedIPs) FromDB(data []byte) error {
+	if len(data) == 0 {
+		*ips = nil
+		return nil
+	}
+	return json.Unmarshal(data, ips)
+}
+
+func (ips *AllowedIPs) ToDB() ([]byte, error) {
+	if len(*ips) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(ips)
+}
+
+// Allows returns true if the list is empty or the address matches one of its entries
+func (ips AllowedIPs) Allows(addr string) bool {
+	if len(ips) == 0 {
+		return true
+	}
+
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for _, entry := range ips {
+		if strings.Contains(entry, "/") {
+			if _, ipNet, err := net.ParseCIDR(entry); err == nil && ipNet.Contains(ip) {
+				return true
+			}
+		} else if allowed := net.ParseIP(entry); allowed != nil && allowed.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // DTO for transforming user input in the api
 type SavePublicDashboardDTO struct {
 	Uid             string
@@ -2,6 +2,7 @@ package models
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -9,3 +10,25 @@ import (
 func TestPublicDashboardTableName(t *testing.T) {
 	assert.Equal(t, "dashboard_public", PublicDashboard{}.TableName())
 }
+
+func TestPublicDashboardIsExpired(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	assert.False(t, PublicDashboard{}.IsExpired(now))
+	assert.True(t, PublicDashboard{ExpiresAt: &past}.IsExpired(now))
+	assert.False(t, PublicDashboard{ExpiresAt: &future}.IsExpired(now))
+}
+
+func TestAllowedIPsAllows(t *testing.T) {
+	assert.True(t, AllowedIPs{}.Allows("203.0.113.7"))
+
+	ips := AllowedIPs{"10.0.0.0/8", "203.0.113.7", "2001:db8::/32"}
+	assert.True(t, ips.Allows("10.1.2.3"))
+	assert.True(t, ips.Allows("203.0.113.7"))
+	assert.True(t, ips.Allows("2001:db8::1"))
+	assert.False(t, ips.Allows("203.0.113.8"))
+	assert.False(t, ips.Allows("192.168.0.1"))
+	assert.False(t, ips.Allows("not-an-ip"))
+}
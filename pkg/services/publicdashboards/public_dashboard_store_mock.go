@@ -7,6 +7,8 @@ import (
 
 	models "github.com/grafana/grafana/pkg/services/publicdashboards/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // FakePublicDashboardStore is an autogenerated mock type for the Store type
@@ -240,6 +242,32 @@ func (_m *FakePublicDashboardStore) FindByFolder(ctx context.Context, orgId int6
 	return r0, r1
 }
 
+// FindExpired provides a mock function with given fields: ctx, now
+func (_m *FakePublicDashboardStore) FindExpired(ctx context.Context, now time.Time) ([]*models.PublicDashboard, error) {
+	ret := _m.Called(ctx, now)
+
+	var r0 []*models.PublicDashboard
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]*models.PublicDashboard, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*models.PublicDashboard); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PublicDashboard)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMetrics provides a mock function with given fields: ctx
 func (_m *FakePublicDashboardStore) GetMetrics(ctx context.Context) (*models.Metrics, error) {
 	ret := _m.Called(ctx)
@@ -290,6 +318,34 @@ func (_m *FakePublicDashboardStore) GetOrgIdByAccessToken(ctx context.Context, a
 	return r0, r1
 }
 
+// IncrementQueryCount provides a mock function with given fields: ctx, uid, count
+func (_m *FakePublicDashboardStore) IncrementQueryCount(ctx context.Context, uid string, count int64) error {
+	ret := _m.Called(ctx, uid, count)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, uid, count)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IncrementViewCount provides a mock function with given fields: ctx, uid, count
+func (_m *FakePublicDashboardStore) IncrementViewCount(ctx context.Context, uid string, count int64) error {
+	ret := _m.Called(ctx, uid, count)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, uid, count)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, cmd
 func (_m *FakePublicDashboardStore) Update(ctx context.Context, cmd models.SavePublicDashboardCommand) (int64, error) {
 	ret := _m.Called(ctx, cmd)
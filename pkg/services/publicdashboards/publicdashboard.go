@@ -2,6 +2,7 @@ package publicdashboards
 
 import (
 	"context"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana/pkg/api/dtos"
@@ -38,6 +39,7 @@ type Service interface {
 
 	ExistsEnabledByAccessToken(ctx context.Context, accessToken string) (bool, error)
 	ExistsEnabledByDashboardUid(ctx context.Context, dashboardUid string) (bool, error)
+	DeleteExpired(ctx context.Context) (int64, error)
 }
 
 // ServiceWrapper these methods have different behavior between OSS and Enterprise. The latter would call the OSS service first
@@ -62,6 +64,9 @@ type Store interface {
 	FindByFolder(ctx context.Context, orgId int64, folderUid string) ([]*PublicDashboard, error)
 	ExistsEnabledByAccessToken(ctx context.Context, accessToken string) (bool, error)
 	ExistsEnabledByDashboardUid(ctx context.Context, dashboardUid string) (bool, error)
+	FindExpired(ctx context.Context, now time.Time) ([]*PublicDashboard, error)
+	IncrementViewCount(ctx context.Context, uid string, count int64) error
+	IncrementQueryCount(ctx context.Context, uid string, count int64) error
 	GetMetrics(ctx context.Context) (*Metrics, error)
 }
 
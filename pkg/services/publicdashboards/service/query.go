@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
@@ -19,6 +21,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/publicdashboards/validation"
 	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/grafana/grafana/pkg/tsdb/grafanads"
+	"golang.org/x/time/rate"
 )
 
 // FindAnnotations returns annotations for a public dashboard
@@ -139,6 +142,17 @@ func (pd *PublicDashboardServiceImpl) GetQueryDataResponse(ctx context.Context,
 		return nil, models.ErrPanelQueriesNotFound.Errorf("GetQueryDataResponse: failed to extract queries from panel")
 	}
 
+	// the result cache is used even when skipDSCache is set, so that viewers cannot bypass it
+	cacheKey := queryCacheKey(publicDashboard, dashboard, panelId, queryDto)
+	if res, ok := pd.getCachedQueryDataResponse(cacheKey); ok {
+		return res, nil
+	}
+
+	if !pd.queryLimiters.allow(publicDashboard.Uid, publicDashboard.QueryRateLimit) {
+		return nil, models.ErrQueryRateLimitExceeded.Errorf("GetQueryDataResponse: query rate limit of %d per minute exceeded for public dashboard %s", publicDashboard.QueryRateLimit, publicDashboard.Uid)
+	}
+	pd.queryCounts.add(publicDashboard.Uid, 1)
+
 	anonymousUser := buildAnonymousUser(ctx, dashboard, pd.features)
 	res, err := pd.QueryDataService.QueryData(ctx, anonymousUser, skipDSCache, metricReq)
 
@@ -150,10 +164,125 @@ func (pd *PublicDashboardServiceImpl) GetQueryDataResponse(ctx context.Context,
 	LogQuerySuccess(reqDatasources, pd.log)
 
 	sanitizeMetadataFromQueryData(res)
+	pd.cacheQueryDataResponse(cacheKey, res)
 
 	return res, nil
 }
 
+// queryCacheKey identifies the result of a panel query by the time range before it is resolved, so that relative
+// time ranges like now-6h share the cached result until it expires
+func queryCacheKey(publicDashboard *models.PublicDashboard, dashboard *dashboards.Dashboard, panelId int64, reqDTO models.PublicDashboardQueryDTO) string {
+	from, to, timezone := getTimeRangeValuesOrDefault(reqDTO, dashboard, publicDashboard.TimeSelectionEnabled)
+	return fmt.Sprintf("%s-%d-%d-%s-%s-%s-%d-%d", publicDashboard.Uid, dashboard.Version, panelId, from, to, timezone, reqDTO.IntervalMs, reqDTO.MaxDataPoints)
+}
+
+func (pd *PublicDashboardServiceImpl) getCachedQueryDataResponse(key string) (*backend.QueryDataResponse, bool) {
+	if pd.queryCache == nil {
+		return nil, false
+	}
+	cached, ok := pd.queryCache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return cached.(*backend.QueryDataResponse), true
+}
+
+// cacheQueryDataResponse caches query results without errors, so that failures are retried on the next request
+func (pd *PublicDashboardServiceImpl) cacheQueryDataResponse(key string, res *backend.QueryDataResponse) {
+	if pd.queryCache == nil {
+		return
+	}
+	for _, r := range res.Responses {
+		if r.Error != nil {
+			return
+		}
+	}
+	pd.queryCache.SetDefault(key, res)
+}
+
+// queryRateLimiters holds the query rate limiter of each public dashboard with a query rate limit. The limiters are
+// kept in memory, so the limits apply to each Grafana instance separately.
+type queryRateLimiters struct {
+	mu       sync.Mutex
+	limiters map[string]*queryRateLimiter
+}
+
+type queryRateLimiter struct {
+	limit   int64
+	limiter *rate.Limiter
+}
+
+// allow returns true if a query is allowed for a public dashboard with a limit of queries per minute, where 0 means
+// that queries are not limited
+func (l *queryRateLimiters) allow(uid string, limit int64) bool {
+	if limit <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limiters == nil {
+		l.limiters = make(map[string]*queryRateLimiter)
+	}
+
+	// the limiter is replaced when the limit of the public dashboard changes
+	ql, ok := l.limiters[uid]
+	if !ok || ql.limit != limit {
+		ql = &queryRateLimiter{
+			limit:   limit,
+			limiter: rate.NewLimiter(rate.Every(time.Minute/time.Duration(limit)), int(limit)),
+		}
+		l.limiters[uid] = ql
+	}
+
+	return ql.limiter.Allow()
+}
+
+// usageCounters holds the number of views or panel queries of each public dashboard that were not written to the store
+// yet, so that requests do not wait for a write to the database
+type usageCounters struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func (c *usageCounters) add(uid string, count int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.counts == nil {
+		c.counts = make(map[string]int64)
+	}
+	c.counts[uid] += count
+}
+
+// take returns the counts and resets them
+func (c *usageCounters) take() map[string]int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counts := c.counts
+	c.counts = nil
+	return counts
+}
+
+// flushUsageCounts writes the view and query counts to the store. Counts that fail to be written are kept for the next
+// flush.
+func (pd *PublicDashboardServiceImpl) flushUsageCounts(ctx context.Context) {
+	for uid, count := range pd.viewCounts.take() {
+		if err := pd.store.IncrementViewCount(ctx, uid, count); err != nil {
+			pd.log.Warn("Failed to increment public dashboard view count", "publicDashboardUid", uid, "error", err)
+			pd.viewCounts.add(uid, count)
+		}
+	}
+	for uid, count := range pd.queryCounts.take() {
+		if err := pd.store.IncrementQueryCount(ctx, uid, count); err != nil {
+			pd.log.Warn("Failed to increment public dashboard query count", "publicDashboardUid", uid, "error", err)
+			pd.queryCounts.add(uid, count)
+		}
+	}
+}
+
 // buildMetricRequest merges public dashboard parameters with dashboard and returns a metrics request to be sent to query backend
 func (pd *PublicDashboardServiceImpl) buildMetricRequest(dashboard *dashboards.Dashboard, publicDashboard *models.PublicDashboard, panelId int64, reqDTO models.PublicDashboardQueryDTO) (dtos.MetricRequest, error) {
 	// group queries by panel
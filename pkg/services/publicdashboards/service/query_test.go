@@ -13,6 +13,7 @@ import (
 
 	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/localcache"
 	dashboard2 "github.com/grafana/grafana/pkg/kinds/dashboard"
 	"github.com/grafana/grafana/pkg/services/annotations"
 	"github.com/grafana/grafana/pkg/services/dashboards"
@@ -729,6 +730,127 @@ func TestGetQueryDataResponse(t *testing.T) {
 	})
 }
 
+func TestGetQueryDataResponseWithAccessRestrictions(t *testing.T) {
+	publicDashboardQueryDTO := PublicDashboardQueryDTO{
+		IntervalMs:    int64(1),
+		MaxDataPoints: int64(1),
+	}
+
+	setup := func(t *testing.T, queryRateLimit int64, queryResponse *backend.QueryDataResponse) (*PublicDashboardServiceImpl, *query.FakeQueryService, *PublicDashboard) {
+		t.Helper()
+		fakeDashboardService := &dashboards.FakeDashboardService{}
+		service, sqlStore, _ := newPublicDashboardServiceImpl(t, nil, fakeDashboardService, nil)
+		fakeQueryService := &query.FakeQueryService{}
+		fakeQueryService.On("QueryData", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(queryResponse, nil)
+		service.QueryDataService = fakeQueryService
+
+		dashboardStore, err := dashboardsDB.ProvideDashboardStore(sqlStore, service.cfg, featuremgmt.WithFeatures(), tagimpl.ProvideService(sqlStore), quotatest.New(false, nil))
+		require.NoError(t, err)
+		customPanels := []any{
+			map[string]any{
+				"id":         1,
+				"datasource": map[string]any{"uid": "ds1"},
+				"targets":    []any{map[string]any{"refId": "A", "datasource": map[string]any{"uid": "ds1"}}},
+			}}
+		dashboard := insertTestDashboard(t, dashboardStore, "testDashWithRestrictions", 1, 0, "", true, []map[string]any{}, customPanels)
+		fakeDashboardService.On("GetDashboard", mock.Anything, mock.Anything, mock.Anything).Return(dashboard, nil)
+
+		dto := &SavePublicDashboardDTO{
+			DashboardUid: dashboard.UID,
+			UserId:       7,
+			OrgID:        dashboard.OrgID,
+			PublicDashboard: &PublicDashboardDTO{
+				IsEnabled:      util.Pointer(true),
+				QueryRateLimit: &queryRateLimit,
+			},
+		}
+		pubdash, err := service.Create(context.Background(), SignedInUser, dto)
+		require.NoError(t, err)
+
+		return service, fakeQueryService, pubdash
+	}
+
+	t.Run("Returns an error when the query rate limit is exceeded", func(t *testing.T) {
+		service, _, pubdash := setup(t, 2, &backend.QueryDataResponse{})
+
+		for i := 0; i < 2; i++ {
+			_, err := service.GetQueryDataResponse(context.Background(), false, publicDashboardQueryDTO, 1, pubdash.AccessToken)
+			require.NoError(t, err)
+		}
+		_, err := service.GetQueryDataResponse(context.Background(), false, publicDashboardQueryDTO, 1, pubdash.AccessToken)
+		require.ErrorIs(t, err, ErrQueryRateLimitExceeded)
+
+		// queries are counted when the counts are flushed, rejected queries are not counted
+		updated, err := service.store.Find(context.Background(), pubdash.Uid)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, updated.QueryCount)
+
+		service.flushUsageCounts(context.Background())
+		updated, err = service.store.Find(context.Background(), pubdash.Uid)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, updated.QueryCount)
+	})
+
+	t.Run("Returns cached query results without counting them against the query rate limit", func(t *testing.T) {
+		service, fakeQueryService, pubdash := setup(t, 1, &backend.QueryDataResponse{})
+		service.queryCache = localcache.New(time.Minute, time.Minute)
+
+		for i := 0; i < 3; i++ {
+			resp, err := service.GetQueryDataResponse(context.Background(), false, publicDashboardQueryDTO, 1, pubdash.AccessToken)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+		}
+		fakeQueryService.AssertNumberOfCalls(t, "QueryData", 1)
+
+		// cached query results are not counted
+		service.flushUsageCounts(context.Background())
+		updated, err := service.store.Find(context.Background(), pubdash.Uid)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, updated.QueryCount)
+
+		// a query with different parameters is not cached
+		otherDTO := publicDashboardQueryDTO
+		otherDTO.MaxDataPoints = 2
+		_, err = service.GetQueryDataResponse(context.Background(), false, otherDTO, 1, pubdash.AccessToken)
+		require.ErrorIs(t, err, ErrQueryRateLimitExceeded)
+	})
+
+	t.Run("Does not cache query results with errors", func(t *testing.T) {
+		failed := &backend.QueryDataResponse{Responses: backend.Responses{"A": {Error: errors.New("query failed")}}}
+		service, fakeQueryService, pubdash := setup(t, 0, failed)
+		service.queryCache = localcache.New(time.Minute, time.Minute)
+
+		for i := 0; i < 2; i++ {
+			_, err := service.GetQueryDataResponse(context.Background(), false, publicDashboardQueryDTO, 1, pubdash.AccessToken)
+			require.NoError(t, err)
+		}
+		fakeQueryService.AssertNumberOfCalls(t, "QueryData", 2)
+	})
+}
+
+func TestFlushUsageCounts(t *testing.T) {
+	fakeStore := &FakePublicDashboardStore{}
+	service, _, _ := newPublicDashboardServiceImpl(t, fakeStore, nil, nil)
+	service.queryCounts.add("uid1", 1)
+	service.queryCounts.add("uid1", 1)
+
+	service.viewCounts.add("uid1", 1)
+
+	fakeStore.On("IncrementViewCount", mock.Anything, "uid1", int64(1)).Return(nil).Once()
+	fakeStore.On("IncrementQueryCount", mock.Anything, "uid1", int64(2)).Return(errors.New("db error")).Once()
+	service.flushUsageCounts(context.Background())
+
+	// the counts that failed to be written are written by the next flush
+	service.queryCounts.add("uid1", 1)
+	fakeStore.On("IncrementQueryCount", mock.Anything, "uid1", int64(3)).Return(nil).Once()
+	service.flushUsageCounts(context.Background())
+	fakeStore.AssertExpectations(t)
+
+	service.flushUsageCounts(context.Background())
+	fakeStore.AssertNumberOfCalls(t, "IncrementViewCount", 1)
+	fakeStore.AssertNumberOfCalls(t, "IncrementQueryCount", 2)
+}
+
 func TestFindAnnotations(t *testing.T) {
 	color := "red"
 	name := "annoName"
@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/otel"
 
 	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/infra/localcache"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/infra/metrics"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
@@ -43,8 +44,15 @@ type PublicDashboardServiceImpl struct {
 	serviceWrapper     publicdashboards.ServiceWrapper
 	dashboardService   dashboards.DashboardService
 	license            licensing.Licensing
+	queryCache         *localcache.CacheService
+	queryLimiters      queryRateLimiters
+	viewCounts         usageCounters
+	queryCounts        usageCounters
 }
 
+// usageCountFlushInterval is how often the view and query counts of public dashboards are written to the store
+const usageCountFlushInterval = 30 * time.Second
+
 var LogPrefix = "publicdashboards.service"
 var tracer = otel.Tracer("github.com/grafana/grafana/pkg/services/publicdashboards/service")
 
@@ -65,6 +73,12 @@ func ProvideService(
 	dashboardService dashboards.DashboardService,
 	license licensing.Licensing,
 ) *PublicDashboardServiceImpl {
+	// query results are only cached when a cache TTL is configured
+	var queryCache *localcache.CacheService
+	if cfg.PublicDashboardsQueryCacheTTL > 0 {
+		queryCache = localcache.New(cfg.PublicDashboardsQueryCacheTTL, 2*cfg.PublicDashboardsQueryCacheTTL)
+	}
+
 	return &PublicDashboardServiceImpl{
 		log:                log.New(LogPrefix),
 		cfg:                cfg,
@@ -77,6 +91,23 @@ func ProvideService(
 		serviceWrapper:     serviceWrapper,
 		dashboardService:   dashboardService,
 		license:            license,
+		queryCache:         queryCache,
+	}
+}
+
+// Run writes the view and query counts of public dashboards to the store periodically
+func (pd *PublicDashboardServiceImpl) Run(ctx context.Context) error {
+	ticker := time.NewTicker(usageCountFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// the counts of the last interval are written on shutdown
+			pd.flushUsageCounts(context.Background())
+			return ctx.Err()
+		case <-ticker.C:
+			pd.flushUsageCounts(ctx)
+		}
 	}
 }
 
@@ -110,6 +141,8 @@ func (pd *PublicDashboardServiceImpl) GetPublicDashboardForView(ctx context.Cont
 
 	sanitizeData(dash.Data)
 
+	pd.viewCounts.add(pubdash.Uid, 1)
+
 	return &dtos.DashboardFullWithMeta{Meta: meta, Dashboard: dash.Data}, nil
 }
 
@@ -177,6 +210,10 @@ func (pd *PublicDashboardServiceImpl) FindEnabledPublicDashboardAndDashboardByAc
 		return nil, nil, ErrPublicDashboardNotEnabled.Errorf("FindEnabledPublicDashboardAndDashboardByAccessToken: Public dashboard is not enabled accessToken: %s", accessToken)
 	}
 
+	if pubdash.IsExpired(time.Now()) {
+		return nil, nil, ErrPublicDashboardExpired.Errorf("FindEnabledPublicDashboardAndDashboardByAccessToken: Public dashboard has expired accessToken: %s", accessToken)
+	}
+
 	if !pd.license.FeatureEnabled(FeaturePublicDashboardsEmailSharing) && pubdash.Share == EmailShareType {
 		return nil, nil, ErrPublicDashboardNotFound.Errorf("FindEnabledPublicDashboardAndDashboardByAccessToken: Dashboard not found accessToken: %s", accessToken)
 	}
@@ -411,6 +448,26 @@ func (pd *PublicDashboardServiceImpl) Delete(ctx context.Context, uid string, da
 	return pd.serviceWrapper.Delete(ctx, uid)
 }
 
+// DeleteExpired deletes the public dashboards which have expired and returns how many were deleted
+func (pd *PublicDashboardServiceImpl) DeleteExpired(ctx context.Context) (int64, error) {
+	ctx, span := tracer.Start(ctx, "publicdashboards.DeleteExpired")
+	defer span.End()
+	pubdashes, err := pd.store.FindExpired(ctx, time.Now())
+	if err != nil {
+		return 0, ErrInternalServerError.Errorf("DeleteExpired: failed to find expired public dashboards: %w", err)
+	}
+
+	var deleted int64
+	for _, pubdash := range pubdashes {
+		if err := pd.serviceWrapper.Delete(ctx, pubdash.Uid); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+
+	return deleted, nil
+}
+
 func (pd *PublicDashboardServiceImpl) DeleteByDashboard(ctx context.Context, dashboard *dashboards.Dashboard) error {
 	ctx, span := tracer.Start(ctx, "publicdashboards.DeleteByDashboard")
 	defer span.End()
@@ -558,6 +615,9 @@ func (pd *PublicDashboardServiceImpl) newCreatePublicDashboard(ctx context.Conte
 		UpdatedBy:            dto.UserId,
 		UpdatedAt:            now,
 		AccessToken:          accessToken,
+		ExpiresAt:            expiresAtOrDefault(dto.PublicDashboard.ExpiresAt, nil),
+		AllowedIPs:           dto.PublicDashboard.AllowedIPs,
+		QueryRateLimit:       returnInt64OrDefault(dto.PublicDashboard.QueryRateLimit, 0),
 	}, nil
 }
 
@@ -572,6 +632,11 @@ func newUpdatePublicDashboard(dto *SavePublicDashboardDTO, pd *PublicDashboard)
 		share = pd.Share
 	}
 
+	allowedIPs := pd.AllowedIPs
+	if pubdashDTO.AllowedIPs != nil {
+		allowedIPs = pubdashDTO.AllowedIPs
+	}
+
 	return &PublicDashboard{
 		Uid:                  pd.Uid,
 		IsEnabled:            isEnabled,
@@ -579,6 +644,9 @@ func newUpdatePublicDashboard(dto *SavePublicDashboardDTO, pd *PublicDashboard)
 		TimeSelectionEnabled: timeSelectionEnabled,
 		TimeSettings:         pd.TimeSettings,
 		Share:                share,
+		ExpiresAt:            expiresAtOrDefault(pubdashDTO.ExpiresAt, pd.ExpiresAt),
+		AllowedIPs:           allowedIPs,
+		QueryRateLimit:       returnInt64OrDefault(pubdashDTO.QueryRateLimit, pd.QueryRateLimit),
 		UpdatedBy:            dto.UserId,
 		UpdatedAt:            time.Now(),
 	}
@@ -591,3 +659,24 @@ func returnValueOrDefault(value *bool, defaultValue bool) bool {
 
 	return defaultValue
 }
+
+func returnInt64OrDefault(value *int64, defaultValue int64) int64 {
+	if value != nil {
+		return *value
+	}
+
+	return defaultValue
+}
+
+// expiresAtOrDefault converts an expiry date in epoch milliseconds, where 0 removes the expiry date
+func expiresAtOrDefault(value *int64, defaultValue *time.Time) *time.Time {
+	if value == nil {
+		return defaultValue
+	}
+	if *value == 0 {
+		return nil
+	}
+
+	expiresAt := time.UnixMilli(*value)
+	return &expiresAt
+}
@@ -416,6 +416,9 @@ func TestGetPublicDashboardForView(t *testing.T) {
 				// hide the timepicker if the time selection is disabled
 				assert.Equal(t, test.StoreResp.pd.TimeSelectionEnabled, !dashboardFullWithMeta.Dashboard.Get("timepicker").Get("hidden").MustBool())
 
+				// views are counted in memory until the counts are flushed
+				assert.Equal(t, map[string]int64{test.StoreResp.pd.Uid: 1}, service.viewCounts.take())
+
 				for _, panelObj := range dashboardFullWithMeta.Dashboard.Get("panels").MustArray() {
 					panel := simplejson.NewFromAny(panelObj)
 
@@ -551,6 +554,17 @@ func TestGetEnabledPublicDashboard(t *testing.T) {
 			ErrResp:  ErrPublicDashboardNotFound,
 			DashResp: nil,
 		},
+		{
+			Name:        "returns ErrPublicDashboardExpired when the public dashboard has expired",
+			AccessToken: "abc123",
+			StoreResp: &storeResp{
+				pd:  &PublicDashboard{AccessToken: "abcdToken", IsEnabled: true, ExpiresAt: util.Pointer(time.Now().Add(-time.Minute))},
+				d:   &dashboards.Dashboard{UID: "mydashboard"},
+				err: nil,
+			},
+			ErrResp:  ErrPublicDashboardExpired,
+			DashResp: nil,
+		},
 	}
 
 	for _, test := range testCases {
@@ -1057,6 +1071,48 @@ func TestUpdatePublicDashboard(t *testing.T) {
 		assert.Equal(t, &TimeSettings{}, updatedPubdash.TimeSettings)
 	})
 
+	t.Run("Updating access restrictions", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+		dto := &SavePublicDashboardDTO{
+			DashboardUid: dashboard2.UID,
+			UserId:       7,
+			PublicDashboard: &PublicDashboardDTO{
+				ExpiresAt:      util.Pointer(expiresAt.UnixMilli()),
+				AllowedIPs:     []string{"10.0.0.0/8"},
+				QueryRateLimit: util.Pointer(int64(30)),
+			},
+		}
+
+		savedPubdash, err := service.Create(context.Background(), SignedInUser, dto)
+		require.NoError(t, err)
+		require.NotNil(t, savedPubdash.ExpiresAt)
+		assert.True(t, expiresAt.Equal(*savedPubdash.ExpiresAt))
+		assert.Equal(t, AllowedIPs{"10.0.0.0/8"}, savedPubdash.AllowedIPs)
+		assert.EqualValues(t, 30, savedPubdash.QueryRateLimit)
+
+		// fields which are not set keep their values
+		dto = &SavePublicDashboardDTO{
+			Uid:             savedPubdash.Uid,
+			DashboardUid:    dashboard2.UID,
+			UserId:          8,
+			PublicDashboard: &PublicDashboardDTO{QueryRateLimit: util.Pointer(int64(60))},
+		}
+		updatedPubdash, err := service.Update(context.Background(), SignedInUser, dto)
+		require.NoError(t, err)
+		require.NotNil(t, updatedPubdash.ExpiresAt)
+		assert.True(t, expiresAt.Equal(*updatedPubdash.ExpiresAt))
+		assert.Equal(t, AllowedIPs{"10.0.0.0/8"}, updatedPubdash.AllowedIPs)
+		assert.EqualValues(t, 60, updatedPubdash.QueryRateLimit)
+
+		// a zero expiry date and an empty allow-list remove the restrictions
+		dto.PublicDashboard = &PublicDashboardDTO{ExpiresAt: util.Pointer(int64(0)), AllowedIPs: []string{}}
+		updatedPubdash, err = service.Update(context.Background(), SignedInUser, dto)
+		require.NoError(t, err)
+		assert.Nil(t, updatedPubdash.ExpiresAt)
+		assert.Empty(t, updatedPubdash.AllowedIPs)
+		assert.EqualValues(t, 60, updatedPubdash.QueryRateLimit)
+	})
+
 	t.Run("Should fail when public dashboard uid does not match dashboard uid", func(t *testing.T) {
 		isEnabled := true
 
@@ -1666,6 +1722,31 @@ func TestDeleteByDashboard(t *testing.T) {
 	})
 }
 
+func TestDeleteExpired(t *testing.T) {
+	t.Run("will delete the expired pubdashes", func(t *testing.T) {
+		store := NewFakePublicDashboardStore(t)
+		pd := &PublicDashboardServiceImpl{store: store, serviceWrapper: ProvideServiceWrapper(store)}
+		pubdash1 := &PublicDashboard{Uid: "1", OrgId: 1}
+		pubdash2 := &PublicDashboard{Uid: "2", OrgId: 1}
+		store.On("FindExpired", mock.Anything, mock.Anything).Return([]*PublicDashboard{pubdash1, pubdash2}, nil)
+		store.On("Delete", mock.Anything, "1").Return(int64(1), nil)
+		store.On("Delete", mock.Anything, "2").Return(int64(1), nil)
+
+		deleted, err := pd.DeleteExpired(context.Background())
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, deleted)
+	})
+
+	t.Run("will return an error when finding the expired pubdashes fails", func(t *testing.T) {
+		store := NewFakePublicDashboardStore(t)
+		pd := &PublicDashboardServiceImpl{store: store, serviceWrapper: ProvideServiceWrapper(store)}
+		store.On("FindExpired", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+
+		_, err := pd.DeleteExpired(context.Background())
+		assert.ErrorIs(t, err, ErrInternalServerError)
+	})
+}
+
 func TestGenerateAccessToken(t *testing.T) {
 	accessToken, err := GenerateAccessToken()
 
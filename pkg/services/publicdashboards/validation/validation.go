@@ -1,6 +1,10 @@
 package validation
 
 import (
+	"net"
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
 	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
@@ -13,6 +17,21 @@ func ValidatePublicDashboard(dto *SavePublicDashboardDTO) error {
 		return ErrInvalidShareType.Errorf("ValidateSavePublicDashboard: invalid share type")
 	}
 
+	// 0 removes the expiry date
+	if dto.PublicDashboard.ExpiresAt != nil && *dto.PublicDashboard.ExpiresAt != 0 && !time.UnixMilli(*dto.PublicDashboard.ExpiresAt).After(time.Now()) {
+		return ErrInvalidExpiresAt.Errorf("ValidateSavePublicDashboard: expiry date is in the past")
+	}
+
+	for _, entry := range dto.PublicDashboard.AllowedIPs {
+		if !IsValidIPOrCIDR(entry) {
+			return ErrInvalidAllowedIPs.Errorf("ValidateSavePublicDashboard: invalid allowed IP %s", entry)
+		}
+	}
+
+	if dto.PublicDashboard.QueryRateLimit != nil && *dto.PublicDashboard.QueryRateLimit < 0 {
+		return ErrInvalidQueryRateLimit.Errorf("ValidateSavePublicDashboard: query rate limit is negative")
+	}
+
 	return nil
 }
 
@@ -53,6 +72,15 @@ func IsValidShortUID(uid string) bool {
 	return uid != "" && util.IsValidShortUID(uid)
 }
 
+// IsValidIPOrCIDR checks that the value is an IP address or a CIDR range
+func IsValidIPOrCIDR(value string) bool {
+	if strings.Contains(value, "/") {
+		_, _, err := net.ParseCIDR(value)
+		return err == nil
+	}
+	return net.ParseIP(value) != nil
+}
+
 func IsValidShareType(shareType ShareType) bool {
 	for _, t := range ValidShareTypes {
 		if t == shareType {
@@ -2,6 +2,7 @@ package validation
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/grafana/grafana/pkg/services/publicdashboards/models"
 	"github.com/stretchr/testify/assert"
@@ -22,6 +23,39 @@ func TestValidatePublicDashboard(t *testing.T) {
 		err := ValidatePublicDashboard(dto)
 		require.Error(t, err)
 	})
+
+	t.Run("Returns error when expiry date is in the past", func(t *testing.T) {
+		past := time.Now().Add(-time.Hour).UnixMilli()
+		dto := &SavePublicDashboardDTO{DashboardUid: "abc123", UserId: 1, PublicDashboard: &PublicDashboardDTO{ExpiresAt: &past}}
+
+		err := ValidatePublicDashboard(dto)
+		require.ErrorIs(t, err, ErrInvalidExpiresAt)
+	})
+
+	t.Run("Returns no error when expiry date is in the future or removed", func(t *testing.T) {
+		future := time.Now().Add(time.Hour).UnixMilli()
+		dto := &SavePublicDashboardDTO{DashboardUid: "abc123", UserId: 1, PublicDashboard: &PublicDashboardDTO{ExpiresAt: &future}}
+		require.NoError(t, ValidatePublicDashboard(dto))
+
+		removed := int64(0)
+		dto.PublicDashboard.ExpiresAt = &removed
+		require.NoError(t, ValidatePublicDashboard(dto))
+	})
+
+	t.Run("Returns error when allowed IPs are invalid", func(t *testing.T) {
+		dto := &SavePublicDashboardDTO{DashboardUid: "abc123", UserId: 1, PublicDashboard: &PublicDashboardDTO{AllowedIPs: []string{"10.0.0.0/8", "not-an-ip"}}}
+
+		err := ValidatePublicDashboard(dto)
+		require.ErrorIs(t, err, ErrInvalidAllowedIPs)
+	})
+
+	t.Run("Returns error when query rate limit is negative", func(t *testing.T) {
+		limit := int64(-1)
+		dto := &SavePublicDashboardDTO{DashboardUid: "abc123", UserId: 1, PublicDashboard: &PublicDashboardDTO{QueryRateLimit: &limit}}
+
+		err := ValidatePublicDashboard(dto)
+		require.ErrorIs(t, err, ErrInvalidQueryRateLimit)
+	})
 }
 
 func TestValidateQueryPublicDashboardRequest(t *testing.T) {
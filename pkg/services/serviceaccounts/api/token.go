@@ -8,6 +8,7 @@ import (
 	"github.com/grafana/grafana/pkg/api/dtos"
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/components/satokengen"
+	"github.com/grafana/grafana/pkg/services/apikey"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	"github.com/grafana/grafana/pkg/services/serviceaccounts"
 	"github.com/grafana/grafana/pkg/web"
@@ -36,6 +37,11 @@ type TokenDTO struct {
 	HasExpired bool `json:"hasExpired"`
 	// example: false
 	IsRevoked *bool `json:"isRevoked"`
+	// Permissions the token is restricted to, empty if it has all the service account permissions
+	Permissions apikey.Permissions `json:"permissions,omitempty"`
+	// Networks the token can be used from, empty if it can be used from anywhere
+	// example: ["10.0.0.0/8"]
+	AllowedCIDRs apikey.CIDRList `json:"allowedCidrs,omitempty"`
 }
 
 func hasExpired(expiration *int64) bool {
@@ -104,6 +110,8 @@ func (api *ServiceAccountsAPI) ListTokens(ctx *contextmodel.ReqContext) response
 			HasExpired:             isExpired,
 			LastUsedAt:             token.LastUsedAt,
 			IsRevoked:              token.IsRevoked,
+			Permissions:            token.Permissions,
+			AllowedCIDRs:           token.AllowedCIDRs,
 		}
 	}
 
@@ -147,6 +155,10 @@ func (api *ServiceAccountsAPI) CreateToken(c *contextmodel.ReqContext) response.
 	// Force affected service account to be the one referenced in the URL
 	cmd.OrgId = c.SignedInUser.GetOrgID()
 
+	if err := cmd.Validate(); err != nil {
+		return response.Err(err)
+	}
+
 	if api.cfg.ApiKeyMaxSecondsToLive != -1 {
 		if cmd.SecondsToLive == 0 {
 			return response.Error(http.StatusBadRequest, "Number of seconds before expiration should be set", nil)
@@ -105,6 +105,23 @@ func TestServiceAccountsAPI_CreateToken(t *testing.T) {
 			permissions:  []accesscontrol.Permission{{Action: serviceaccounts.ActionWrite, Scope: "serviceaccounts:id:1"}},
 			expectedCode: http.StatusBadRequest,
 		},
+		{
+			desc:           "should be able to create token restricted to a subset of permissions and networks",
+			id:             1,
+			body:           `{"name": "test", "permissions": [{"action": "dashboards:read", "scope": "dashboards:uid:abc"}], "allowedCidrs": ["10.0.0.0/8"]}`,
+			tokenTTL:       -1,
+			permissions:    []accesscontrol.Permission{{Action: serviceaccounts.ActionWrite, Scope: "serviceaccounts:id:1"}},
+			expectedAPIKey: &apikey.APIKey{},
+			expectedCode:   http.StatusOK,
+		},
+		{
+			desc:         "should not be able to create token with an invalid allowed network",
+			id:           1,
+			body:         `{"name": "test", "allowedCidrs": ["not-a-network"]}`,
+			tokenTTL:     -1,
+			permissions:  []accesscontrol.Permission{{Action: serviceaccounts.ActionWrite, Scope: "serviceaccounts:id:1"}},
+			expectedCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
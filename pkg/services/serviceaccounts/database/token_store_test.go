@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/grafana/pkg/components/apikeygen"
+	"github.com/grafana/grafana/pkg/services/apikey"
 	"github.com/grafana/grafana/pkg/services/serviceaccounts"
 	"github.com/grafana/grafana/pkg/services/serviceaccounts/tests"
 )
@@ -73,6 +74,41 @@ func TestStore_AddServiceAccountToken(t *testing.T) {
 	}
 }
 
+func TestStore_AddServiceAccountToken_WithRestrictions(t *testing.T) {
+	saToCreate := tests.TestUser{Login: "servicetestwithrestrictions@admin", IsServiceAccount: true}
+	db, store := setupTestDatabase(t)
+	sa := tests.SetupUserServiceAccount(t, db, store.cfg, saToCreate)
+
+	keyName := t.Name()
+	key, err := apikeygen.New(sa.OrgID, keyName)
+	require.NoError(t, err)
+
+	cmd := serviceaccounts.AddServiceAccountTokenCommand{
+		Name:         keyName,
+		OrgId:        sa.OrgID,
+		Key:          key.HashedKey,
+		Permissions:  apikey.Permissions{{Action: "dashboards:read", Scope: "dashboards:uid:abc"}},
+		AllowedCIDRs: apikey.CIDRList{"10.0.0.0/8"},
+	}
+
+	_, err = store.AddServiceAccountToken(context.Background(), sa.ID, &cmd)
+	require.NoError(t, err)
+
+	keys, err := store.ListTokens(context.Background(), &serviceaccounts.GetSATokensQuery{
+		OrgID:            &sa.OrgID,
+		ServiceAccountID: &sa.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.Equal(t, cmd.Permissions, keys[0].Permissions)
+	require.Equal(t, cmd.AllowedCIDRs, keys[0].AllowedCIDRs)
+
+	fetched, err := store.apiKeyService.GetAPIKeyByHash(context.Background(), key.HashedKey)
+	require.NoError(t, err)
+	require.Equal(t, cmd.Permissions, fetched.Permissions)
+	require.Equal(t, cmd.AllowedCIDRs, fetched.AllowedCIDRs)
+}
+
 func TestStore_AddServiceAccountToken_WrongServiceAccount(t *testing.T) {
 	saToCreate := tests.TestUser{Login: "servicetestwithTeam@admin", IsServiceAccount: true}
 	db, store := setupTestDatabase(t)
@@ -16,6 +16,7 @@ import (
 	"github.com/grafana/grafana/pkg/infra/usagestats"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/apikey"
+	"github.com/grafana/grafana/pkg/services/auditlog"
 	"github.com/grafana/grafana/pkg/services/org"
 	"github.com/grafana/grafana/pkg/services/serviceaccounts"
 	"github.com/grafana/grafana/pkg/services/serviceaccounts/database"
@@ -27,6 +28,8 @@ import (
 const (
 	metricsCollectionInterval = time.Minute * 30
 	defaultSecretScanInterval = time.Minute * 5
+
+	auditKindServiceAccounts = "serviceaccounts"
 )
 
 type ServiceAccountsService struct {
@@ -190,6 +193,8 @@ func (sa *ServiceAccountsService) CreateServiceAccount(ctx context.Context, orgI
 		return nil, err
 	}
 
+	auditlog.SetResource(ctx, auditKindServiceAccounts, serviceAccount.UID)
+	auditlog.AddChange(ctx, "", nil, serviceAccountAuditState(serviceAccount.Name, serviceAccount.Role, serviceAccount.IsDisabled))
 	return serviceAccount, nil
 }
 
@@ -222,13 +227,22 @@ func (sa *ServiceAccountsService) DeleteServiceAccount(ctx context.Context, orgI
 	if err := validServiceAccountID(serviceAccountID); err != nil {
 		return err
 	}
+	before := sa.serviceAccountForAudit(ctx, orgID, serviceAccountID)
 	if err := sa.store.DeleteServiceAccount(ctx, orgID, serviceAccountID); err != nil {
 		return err
 	}
 	if err := sa.acService.DeleteUserPermissions(ctx, orgID, serviceAccountID); err != nil {
 		return err
 	}
-	return sa.permissions.DeleteResourcePermissions(ctx, orgID, fmt.Sprintf("%d", serviceAccountID))
+	if err := sa.permissions.DeleteResourcePermissions(ctx, orgID, fmt.Sprintf("%d", serviceAccountID)); err != nil {
+		return err
+	}
+
+	if before != nil {
+		auditlog.SetResource(ctx, auditKindServiceAccounts, before.UID)
+		auditlog.AddChange(ctx, "", serviceAccountAuditState(before.Name, before.Role, before.IsDisabled), nil)
+	}
+	return nil
 }
 
 func (sa *ServiceAccountsService) EnableServiceAccount(ctx context.Context, orgID, serviceAccountID int64, enable bool) error {
@@ -238,7 +252,12 @@ func (sa *ServiceAccountsService) EnableServiceAccount(ctx context.Context, orgI
 	if err := validServiceAccountID(serviceAccountID); err != nil {
 		return err
 	}
-	return sa.store.EnableServiceAccount(ctx, orgID, serviceAccountID, enable)
+	if err := sa.store.EnableServiceAccount(ctx, orgID, serviceAccountID, enable); err != nil {
+		return err
+	}
+
+	auditlog.AddChange(ctx, "", nil, map[string]any{"isDisabled": !enable})
+	return nil
 }
 
 func (sa *ServiceAccountsService) UpdateServiceAccount(ctx context.Context, orgID int64, serviceAccountID int64, saForm *serviceaccounts.UpdateServiceAccountForm) (*serviceaccounts.ServiceAccountProfileDTO, error) {
@@ -248,7 +267,19 @@ func (sa *ServiceAccountsService) UpdateServiceAccount(ctx context.Context, orgI
 	if err := validServiceAccountID(serviceAccountID); err != nil {
 		return nil, err
 	}
-	return sa.store.UpdateServiceAccount(ctx, orgID, serviceAccountID, saForm)
+	before := sa.serviceAccountForAudit(ctx, orgID, serviceAccountID)
+	updated, err := sa.store.UpdateServiceAccount(ctx, orgID, serviceAccountID, saForm)
+	if err != nil {
+		return nil, err
+	}
+
+	if before != nil {
+		auditlog.SetResource(ctx, auditKindServiceAccounts, updated.UID)
+		auditlog.AddChange(ctx, "",
+			serviceAccountAuditState(before.Name, before.Role, before.IsDisabled),
+			serviceAccountAuditState(updated.Name, updated.Role, updated.IsDisabled))
+	}
+	return updated, nil
 }
 
 func (sa *ServiceAccountsService) SearchOrgServiceAccounts(ctx context.Context, query *serviceaccounts.SearchOrgServiceAccountsQuery) (*serviceaccounts.SearchOrgServiceAccountsResult, error) {
@@ -280,7 +311,51 @@ func (sa *ServiceAccountsService) DeleteServiceAccountToken(ctx context.Context,
 	if err := validServiceAccountTokenID(tokenID); err != nil {
 		return err
 	}
-	return sa.store.DeleteServiceAccountToken(ctx, orgID, serviceAccountID, tokenID)
+
+	var before *apikey.APIKey
+	if auditlog.Recording(ctx) {
+		tokens, err := sa.store.ListTokens(ctx, &serviceaccounts.GetSATokensQuery{OrgID: &orgID, ServiceAccountID: &serviceAccountID})
+		if err != nil {
+			return err
+		}
+		for i := range tokens {
+			if tokens[i].ID == tokenID {
+				before = &tokens[i]
+			}
+		}
+	}
+
+	if err := sa.store.DeleteServiceAccountToken(ctx, orgID, serviceAccountID, tokenID); err != nil {
+		return err
+	}
+
+	if before != nil {
+		auditlog.AddChange(ctx, "tokens."+before.Name, apikey.AuditState(before), nil)
+	}
+	return nil
+}
+
+// serviceAccountForAudit returns a service account about to be changed, only when the request is recorded by the audit log.
+func (sa *ServiceAccountsService) serviceAccountForAudit(ctx context.Context, orgID, serviceAccountID int64) *serviceaccounts.ServiceAccountProfileDTO {
+	if !auditlog.Recording(ctx) {
+		return nil
+	}
+
+	profile, err := sa.store.RetrieveServiceAccount(ctx, &serviceaccounts.GetServiceAccountQuery{OrgID: orgID, ID: serviceAccountID})
+	if err != nil {
+		sa.log.Debug("Failed to retrieve service account for the audit log", "serviceAccountID", serviceAccountID, "error", err)
+		return nil
+	}
+	return profile
+}
+
+// serviceAccountAuditState returns the fields of a service account recorded in the audit log.
+func serviceAccountAuditState(name, role string, isDisabled bool) map[string]any {
+	return map[string]any{
+		"name":       name,
+		"role":       role,
+		"isDisabled": isDisabled,
+	}
 }
 
 func (sa *ServiceAccountsService) MigrateApiKey(ctx context.Context, orgID, keyID int64) error {
@@ -10,6 +10,7 @@ import (
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/accesscontrol/actest"
 	"github.com/grafana/grafana/pkg/services/apikey"
+	"github.com/grafana/grafana/pkg/services/auditlog"
 	"github.com/grafana/grafana/pkg/services/org"
 	"github.com/grafana/grafana/pkg/services/serviceaccounts"
 	"github.com/grafana/grafana/pkg/tests/testsuite"
@@ -19,6 +20,7 @@ type FakeServiceAccountStore struct {
 	ExpectedServiceAccountID                int64
 	ExpectedServiceAccountDTO               *serviceaccounts.ServiceAccountDTO
 	ExpectedServiceAccountProfileDTO        *serviceaccounts.ServiceAccountProfileDTO
+	ExpectedUpdatedServiceAccountProfileDTO *serviceaccounts.ServiceAccountProfileDTO
 	ExpectedSearchServiceAccountQueryResult *serviceaccounts.SearchOrgServiceAccountsResult
 	ExpectedStats                           *serviceaccounts.Stats
 	expectedMigratedResults                 *serviceaccounts.MigrationResult
@@ -62,6 +64,9 @@ func (f *FakeServiceAccountStore) SearchOrgServiceAccounts(ctx context.Context,
 // UpdateServiceAccount is a fake updating a service account.
 func (f *FakeServiceAccountStore) UpdateServiceAccount(ctx context.Context, orgID, serviceAccountID int64,
 	saForm *serviceaccounts.UpdateServiceAccountForm) (*serviceaccounts.ServiceAccountProfileDTO, error) {
+	if f.ExpectedUpdatedServiceAccountProfileDTO != nil {
+		return f.ExpectedUpdatedServiceAccountProfileDTO, f.ExpectedError
+	}
 	return f.ExpectedServiceAccountProfileDTO, f.ExpectedError
 }
 
@@ -160,3 +165,56 @@ func TestProvideServiceAccount_DeleteServiceAccount(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestProvideServiceAccount_AuditLog(t *testing.T) {
+	storeMock := newServiceAccountStoreFake()
+	svc := ServiceAccountsService{
+		acService:   actest.FakeService{},
+		permissions: &actest.FakePermissionsService{},
+		store:       storeMock,
+		db:          db.InitTestDB(t),
+		log:         log.NewNopLogger(),
+	}
+
+	t.Run("should record created service accounts", func(t *testing.T) {
+		storeMock.ExpectedServiceAccountDTO = &serviceaccounts.ServiceAccountDTO{Id: 1, UID: "sa-uid", Name: "ci", Role: string(org.RoleViewer)}
+		entry := &auditlog.Entry{}
+
+		_, err := svc.CreateServiceAccount(auditlog.WithEntry(context.Background(), entry), 1, &serviceaccounts.CreateServiceAccountForm{Name: "ci"})
+		require.NoError(t, err)
+
+		require.Equal(t, "serviceaccounts", entry.ResourceKind)
+		require.Equal(t, "sa-uid", entry.ResourceUID)
+		require.Contains(t, entry.Changes, auditlog.Change{Path: "role", After: "Viewer"})
+	})
+
+	t.Run("should record the fields changed by updates", func(t *testing.T) {
+		storeMock.ExpectedServiceAccountProfileDTO = &serviceaccounts.ServiceAccountProfileDTO{Id: 1, UID: "sa-uid", Name: "ci", Role: string(org.RoleViewer)}
+		storeMock.ExpectedUpdatedServiceAccountProfileDTO = &serviceaccounts.ServiceAccountProfileDTO{Id: 1, UID: "sa-uid", Name: "ci", Role: string(org.RoleAdmin)}
+		entry := &auditlog.Entry{}
+
+		_, err := svc.UpdateServiceAccount(auditlog.WithEntry(context.Background(), entry), 1, 1, &serviceaccounts.UpdateServiceAccountForm{})
+		require.NoError(t, err)
+
+		require.Equal(t, "sa-uid", entry.ResourceUID)
+		require.Equal(t, []auditlog.Change{{Path: "role", Before: "Viewer", After: "Admin"}}, entry.Changes)
+	})
+
+	t.Run("should record deleted tokens without their key", func(t *testing.T) {
+		storeMock.ExpectedAPIKeys = []apikey.APIKey{{ID: 3, Name: "deploy", Key: "hashed", Role: org.RoleEditor}}
+		entry := &auditlog.Entry{}
+
+		err := svc.DeleteServiceAccountToken(auditlog.WithEntry(context.Background(), entry), 1, 1, 3)
+		require.NoError(t, err)
+
+		require.Contains(t, entry.Changes, auditlog.Change{Path: "tokens.deploy.name", Before: "deploy"})
+		for _, c := range entry.Changes {
+			require.NotEqual(t, "hashed", c.Before)
+		}
+	})
+
+	t.Run("should not record anything when the request is not recorded", func(t *testing.T) {
+		_, err := svc.UpdateServiceAccount(context.Background(), 1, 1, &serviceaccounts.UpdateServiceAccountForm{})
+		require.NoError(t, err)
+	})
+}
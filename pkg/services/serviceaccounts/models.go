@@ -2,12 +2,14 @@ package serviceaccounts
 
 import (
 	"fmt"
+	"net"
 	"strings"
 	"time"
 
 	"github.com/grafana/grafana/pkg/apimachinery/errutil"
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/apikey"
 	"github.com/grafana/grafana/pkg/services/org"
 )
 
@@ -42,6 +44,8 @@ var (
 	ErrServiceAccountTokenNotFound       = errutil.NotFound("serviceaccounts.ErrTokenNotFound", errutil.WithPublicMessage("service account token not found"))
 	ErrInvalidTokenExpiration            = errutil.ValidationFailed("serviceaccounts.ErrInvalidInput", errutil.WithPublicMessage("invalid SecondsToLive value"))
 	ErrDuplicateToken                    = errutil.BadRequest("serviceaccounts.ErrTokenAlreadyExists", errutil.WithPublicMessage("service account token with given name already exists in the organization"))
+	ErrInvalidTokenPermissions           = errutil.ValidationFailed("serviceaccounts.ErrInvalidTokenPermissions", errutil.WithPublicMessage("invalid service account token permissions"))
+	ErrInvalidTokenCIDR                  = errutil.ValidationFailed("serviceaccounts.ErrInvalidTokenCIDR", errutil.WithPublicMessage("invalid service account token allowed CIDR"))
 )
 
 type MigrationResult struct {
@@ -111,6 +115,31 @@ type AddServiceAccountTokenCommand struct {
 	OrgId         int64  `json:"-"`
 	Key           string `json:"-"`
 	SecondsToLive int64  `json:"secondsToLive"`
+	// Permissions optionally restricts the token to a subset of the service account's permissions
+	Permissions apikey.Permissions `json:"permissions,omitempty"`
+	// AllowedCIDRs optionally restricts the networks the token can be used from
+	// example: ["10.0.0.0/8"]
+	AllowedCIDRs apikey.CIDRList `json:"allowedCidrs,omitempty"`
+}
+
+// Validate checks that the token permissions and allowed networks are well-formed.
+func (cmd *AddServiceAccountTokenCommand) Validate() error {
+	for _, p := range cmd.Permissions {
+		if p.Action == "" {
+			return ErrInvalidTokenPermissions.Errorf("permission action cannot be empty")
+		}
+		if p.Scope != "" && !accesscontrol.ValidateScope(p.Scope) {
+			return ErrInvalidTokenPermissions.Errorf("invalid scope %s for action %s", p.Scope, p.Action)
+		}
+	}
+
+	for _, cidr := range cmd.AllowedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return ErrInvalidTokenCIDR.Errorf("invalid CIDR %s: %w", cidr, err)
+		}
+	}
+
+	return nil
 }
 
 type SearchOrgServiceAccountsQuery struct {
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/apikey"
 )
 
 func TestIsExternalServiceAccount(t *testing.T) {
@@ -40,3 +42,52 @@ func TestIsExternalServiceAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestAddServiceAccountTokenCommand_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     AddServiceAccountTokenCommand
+		wantErr error
+	}{
+		{
+			name: "no restrictions",
+			cmd:  AddServiceAccountTokenCommand{Name: "token"},
+		},
+		{
+			name: "valid permissions and networks",
+			cmd: AddServiceAccountTokenCommand{
+				Name: "token",
+				Permissions: apikey.Permissions{
+					{Action: "dashboards:read", Scope: "dashboards:*"},
+					{Action: "users:read"},
+				},
+				AllowedCIDRs: apikey.CIDRList{"10.0.0.0/8", "2001:db8::/32"},
+			},
+		},
+		{
+			name:    "empty action",
+			cmd:     AddServiceAccountTokenCommand{Name: "token", Permissions: apikey.Permissions{{Scope: "dashboards:*"}}},
+			wantErr: ErrInvalidTokenPermissions,
+		},
+		{
+			name:    "invalid scope",
+			cmd:     AddServiceAccountTokenCommand{Name: "token", Permissions: apikey.Permissions{{Action: "dashboards:read", Scope: "dashboards*"}}},
+			wantErr: ErrInvalidTokenPermissions,
+		},
+		{
+			name:    "invalid network",
+			cmd:     AddServiceAccountTokenCommand{Name: "token", AllowedCIDRs: apikey.CIDRList{"10.0.0.1"}},
+			wantErr: ErrInvalidTokenCIDR,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cmd.Validate()
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
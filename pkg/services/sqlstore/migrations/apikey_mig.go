@@ -100,4 +100,13 @@ func addApiKeyMigrations(mg *Migrator) {
 	mg.AddMigration("Add is_revoked column to api_key table", NewAddColumnMigration(apiKeyV2, &Column{
 		Name: "is_revoked", Type: DB_Bool, Nullable: true, Default: "0",
 	}))
+
+	// permissions and allowed_cidrs optionally restrict what a service account token can do and where it can be used from.
+	mg.AddMigration("Add permissions column to api_key table", NewAddColumnMigration(apiKeyV2, &Column{
+		Name: "permissions", Type: DB_Text, Nullable: true,
+	}))
+
+	mg.AddMigration("Add allowed_cidrs column to api_key table", NewAddColumnMigration(apiKeyV2, &Column{
+		Name: "allowed_cidrs", Type: DB_Text, Nullable: true,
+	}))
 }
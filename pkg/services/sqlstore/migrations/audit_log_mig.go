@@ -0,0 +1,37 @@
+package migrations
+
+import . "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+func addAuditLogMigrations(mg *Migrator) {
+	auditLogV1 := Table{
+		Name: "audit_log",
+		Columns: []*Column{
+			{Name: "id", Type: DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: DB_BigInt, Nullable: false},
+			{Name: "created", Type: DB_BigInt, Nullable: false},
+			{Name: "actor_type", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "actor_id", Type: DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "actor_login", Type: DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "action", Type: DB_NVarchar, Length: 255, Nullable: false},
+			{Name: "resource_kind", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "resource_uid", Type: DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "path", Type: DB_Text, Nullable: false},
+			{Name: "status_code", Type: DB_Int, Nullable: false},
+			{Name: "source_ip", Type: DB_NVarchar, Length: 64, Nullable: false},
+			{Name: "changes", Type: DB_MediumText, Nullable: true},
+		},
+		Indices: []*Index{
+			{Cols: []string{"org_id", "created"}},
+			{Cols: []string{"created"}},
+			{Cols: []string{"actor_id"}},
+			{Cols: []string{"resource_uid"}},
+		},
+	}
+
+	mg.AddMigration("create audit_log table", NewAddTableMigration(auditLogV1))
+	addTableIndicesMigrations(mg, "v1", auditLogV1)
+
+	mg.AddMigration("add forwarded_for column to audit_log", NewAddColumnMigration(auditLogV1, &Column{
+		Name: "forwarded_for", Type: DB_NVarchar, Length: 255, Nullable: true,
+	}))
+}
@@ -96,4 +96,37 @@ func addPublicDashboardMigration(mg *Migrator) {
 	mg.AddMigration("backfill empty share column fields with default of public", NewRawSQLMigration(
 		"UPDATE dashboard_public SET share='public' WHERE share=''",
 	))
+
+	mg.AddMigration("add expires_at column", NewAddColumnMigration(dashboardPublicCfgV2, &Column{
+		Name:     "expires_at",
+		Type:     DB_DateTime,
+		Nullable: true,
+	}))
+
+	mg.AddMigration("add allowed_ips column", NewAddColumnMigration(dashboardPublicCfgV2, &Column{
+		Name:     "allowed_ips",
+		Type:     DB_Text,
+		Nullable: true,
+	}))
+
+	mg.AddMigration("add query_rate_limit column", NewAddColumnMigration(dashboardPublicCfgV2, &Column{
+		Name:     "query_rate_limit",
+		Type:     DB_BigInt,
+		Nullable: false,
+		Default:  "0",
+	}))
+
+	mg.AddMigration("add view_count column", NewAddColumnMigration(dashboardPublicCfgV2, &Column{
+		Name:     "view_count",
+		Type:     DB_BigInt,
+		Nullable: false,
+		Default:  "0",
+	}))
+
+	mg.AddMigration("add query_count column", NewAddColumnMigration(dashboardPublicCfgV2, &Column{
+		Name:     "query_count",
+		Type:     DB_BigInt,
+		Nullable: false,
+		Default:  "0",
+	}))
 }
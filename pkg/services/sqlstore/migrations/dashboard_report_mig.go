@@ -0,0 +1,63 @@
+package migrations
+
+import . "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+func addDashboardReportMigrations(mg *Migrator) {
+	dashboardReportV1 := Table{
+		Name: "dashboard_report",
+		Columns: []*Column{
+			{Name: "id", Type: DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "uid", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "org_id", Type: DB_BigInt, Nullable: false},
+			{Name: "name", Type: DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "dashboard_uid", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "panel_id", Type: DB_BigInt, Nullable: false, Default: "0"},
+			{Name: "variables", Type: DB_Text, Nullable: true},
+			{Name: "time_from", Type: DB_NVarchar, Length: 64, Nullable: false},
+			{Name: "time_to", Type: DB_NVarchar, Length: 64, Nullable: false},
+			{Name: "format", Type: DB_NVarchar, Length: 16, Nullable: false},
+			{Name: "schedule", Type: DB_NVarchar, Length: 255, Nullable: false},
+			{Name: "timezone", Type: DB_NVarchar, Length: 64, Nullable: false},
+			{Name: "recipients", Type: DB_Text, Nullable: false},
+			{Name: "message", Type: DB_Text, Nullable: true},
+			{Name: "paused", Type: DB_Bool, Nullable: false, Default: "0"},
+			{Name: "created_by", Type: DB_BigInt, Nullable: false},
+			{Name: "created", Type: DB_BigInt, Nullable: false},
+			{Name: "updated", Type: DB_BigInt, Nullable: false},
+			{Name: "next_run", Type: DB_BigInt, Nullable: false, Default: "0"},
+		},
+		Indices: []*Index{
+			{Cols: []string{"org_id", "uid"}, Type: UniqueIndex},
+			{Cols: []string{"next_run"}},
+		},
+	}
+
+	mg.AddMigration("create dashboard_report table", NewAddTableMigration(dashboardReportV1))
+	addTableIndicesMigrations(mg, "v1", dashboardReportV1)
+
+	dashboardReportDeliveryV1 := Table{
+		Name: "dashboard_report_delivery",
+		Columns: []*Column{
+			{Name: "id", Type: DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: DB_BigInt, Nullable: false},
+			{Name: "report_id", Type: DB_BigInt, Nullable: false},
+			{Name: "started", Type: DB_BigInt, Nullable: false},
+			{Name: "duration_ms", Type: DB_BigInt, Nullable: false},
+			{Name: "triggered_by", Type: DB_NVarchar, Length: 16, Nullable: false},
+			{Name: "status", Type: DB_NVarchar, Length: 16, Nullable: false},
+			{Name: "error_message", Type: DB_Text, Nullable: true},
+			{Name: "recipients", Type: DB_Text, Nullable: false},
+		},
+		Indices: []*Index{
+			{Cols: []string{"report_id", "started"}},
+		},
+	}
+
+	mg.AddMigration("create dashboard_report_delivery table", NewAddTableMigration(dashboardReportDeliveryV1))
+	addTableIndicesMigrations(mg, "v1", dashboardReportDeliveryV1)
+
+	mg.AddMigration("add updated_by column to dashboard_report", NewAddColumnMigration(dashboardReportV1, &Column{
+		Name: "updated_by", Type: DB_BigInt, Nullable: false, Default: "0",
+	}))
+	mg.AddMigration("set updated_by of dashboard_report to created_by", NewRawSQLMigration("UPDATE dashboard_report SET updated_by = created_by"))
+}
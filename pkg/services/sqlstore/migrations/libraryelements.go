@@ -84,3 +84,56 @@ func addLibraryElementsMigrations(mg *migrator.Migrator) {
 
 	mg.AddMigration("add index library_element org_id-folder_uid-name-kind", migrator.NewAddIndexMigration(libraryElementsV1, &migrator.Index{Cols: []string{"org_id", "folder_uid", "name", "kind"}, Type: migrator.UniqueIndex}))
 }
+
+// addLibraryElementVersionMigrations defines database migrations for the version history of library elements.
+func addLibraryElementVersionMigrations(mg *migrator.Migrator) {
+	libraryElementVersionV1 := migrator.Table{
+		Name: model.LibraryElementVersionTableName,
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "element_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "version", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "restored_from", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "name", Type: migrator.DB_NVarchar, Length: 150, Nullable: false},
+			{Name: "model", Type: migrator.DB_Text, Nullable: false},
+			{Name: "created", Type: migrator.DB_DateTime, Nullable: false},
+			{Name: "created_by", Type: migrator.DB_BigInt, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"element_id", "version"}, Type: migrator.UniqueIndex},
+		},
+	}
+
+	mg.AddMigration("create "+model.LibraryElementVersionTableName+" table v1", migrator.NewAddTableMigration(libraryElementVersionV1))
+	mg.AddMigration("add unique index "+model.LibraryElementVersionTableName+" element_id-version", migrator.NewAddIndexMigration(libraryElementVersionV1, libraryElementVersionV1.Indices[0]))
+	mg.AddMigration("alter "+model.LibraryElementVersionTableName+" model to mediumtext", migrator.NewRawSQLMigration("").
+		Mysql("ALTER TABLE "+model.LibraryElementVersionTableName+" MODIFY model MEDIUMTEXT NOT NULL;"))
+
+	const saveExistingElementsSQL = `INSERT INTO ` + model.LibraryElementVersionTableName + `
+(
+	org_id,
+	element_id,
+	version,
+	restored_from,
+	name,
+	model,
+	created,
+	created_by
+)
+SELECT
+	library_element.org_id,
+	library_element.id,
+	library_element.version,
+	0,
+	library_element.name,
+	library_element.model,
+	library_element.updated,
+	library_element.updated_by
+FROM library_element;`
+	mg.AddMigration("save existing library elements in "+model.LibraryElementVersionTableName+" table v1", migrator.NewRawSQLMigration(saveExistingElementsSQL))
+
+	mg.AddMigration("add "+model.LibraryElementConnectionTableName+" pinned_version", migrator.NewAddColumnMigration(migrator.Table{Name: model.LibraryElementConnectionTableName}, &migrator.Column{
+		Name: "pinned_version", Type: migrator.DB_BigInt, Nullable: false, Default: "0",
+	}))
+}
@@ -140,6 +140,26 @@ func (oss *OSSMigrations) AddMigration(mg *Migrator) {
 	externalsession.AddMigration(mg)
 
 	accesscontrol.AddReceiverCreateScopeMigration(mg)
+
+	addAuditLogMigrations(mg)
+
+	ualert.AddRuleAuthorColumns(mg)
+
+	ualert.AddSchedulerPeerMigrations(mg)
+
+	ualert.AddRuleActiveTimeIntervalsColumns(mg)
+
+	ualert.AddRuleDependenciesColumns(mg)
+
+	ualert.AddSilenceScheduleMigrations(mg)
+
+	ualert.AddStateHistoryMigrations(mg)
+
+	ualert.AddRuleMissingSeriesColumns(mg)
+
+	addLibraryElementVersionMigrations(mg)
+
+	addDashboardReportMigrations(mg)
 }
 
 func addStarMigrations(mg *Migrator) {
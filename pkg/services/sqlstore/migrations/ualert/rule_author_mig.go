@@ -0,0 +1,15 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddRuleAuthorColumns adds columns to store the identity that changed an alert rule and created an alert rule version.
+func AddRuleAuthorColumns(mg *migrator.Migrator) {
+	mg.AddMigration(
+		"add updated_by column to alert_rule table",
+		migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule"}, &migrator.Column{Name: "updated_by", Type: migrator.DB_NVarchar, Length: 190, Nullable: true}),
+	)
+	mg.AddMigration(
+		"add created_by column to alert_rule_version table",
+		migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule_version"}, &migrator.Column{Name: "created_by", Type: migrator.DB_NVarchar, Length: 190, Nullable: true}),
+	)
+}
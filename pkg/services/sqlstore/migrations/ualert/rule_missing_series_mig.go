@@ -0,0 +1,30 @@
+package ualert
+
+import (
+	"github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+)
+
+// AddRuleMissingSeriesColumns creates a column for the number of evaluations to alert on missing series in the alert_rule and alert_rule_version tables,
+// and a column for the number of evaluations a series has been missing in the alert_instance table.
+func AddRuleMissingSeriesColumns(mg *migrator.Migrator) {
+	mg.AddMigration("add missing_series_evals_to_alert column to alert_rule table", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule"}, &migrator.Column{
+		Name:     "missing_series_evals_to_alert",
+		Type:     migrator.DB_BigInt,
+		Nullable: false,
+		Default:  "0",
+	}))
+
+	mg.AddMigration("add missing_series_evals_to_alert column to alert_rule_version table", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule_version"}, &migrator.Column{
+		Name:     "missing_series_evals_to_alert",
+		Type:     migrator.DB_BigInt,
+		Nullable: false,
+		Default:  "0",
+	}))
+
+	mg.AddMigration("add missing_series_evaluations column to alert_instance table", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_instance"}, &migrator.Column{
+		Name:     "missing_series_evaluations",
+		Type:     migrator.DB_BigInt,
+		Nullable: false,
+		Default:  "0",
+	}))
+}
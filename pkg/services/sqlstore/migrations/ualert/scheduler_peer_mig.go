@@ -0,0 +1,21 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddSchedulerPeerMigrations creates the table where the instances that share evaluation of alert rules record their heartbeats.
+func AddSchedulerPeerMigrations(mg *migrator.Migrator) {
+	peerTable := migrator.Table{
+		Name: "alert_scheduler_peer",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "instance_id", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "last_heartbeat", Type: migrator.DB_BigInt, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"instance_id"}, Type: migrator.UniqueIndex},
+		},
+	}
+
+	mg.AddMigration("create alert_scheduler_peer table", migrator.NewAddTableMigration(peerTable))
+	mg.AddMigration("add unique index on instance_id to alert_scheduler_peer table", migrator.NewAddIndexMigration(peerTable, peerTable.Indices[0]))
+}
@@ -0,0 +1,54 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddSilenceScheduleMigrations creates the tables for recurring silences and silences that expire once their alerts resolve.
+func AddSilenceScheduleMigrations(mg *migrator.Migrator) {
+	recurringTable := migrator.Table{
+		Name: "alert_recurring_silence",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "uid", Type: migrator.DB_NVarchar, Length: UIDMaxLength, Nullable: false},
+			{Name: "matchers", Type: migrator.DB_Text, Nullable: false},
+			{Name: "comment", Type: migrator.DB_Text, Nullable: false},
+			{Name: "created_by", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "schedule", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "duration_seconds", Type: migrator.DB_BigInt, Nullable: false, Default: "0"},
+			{Name: "time_interval", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "expire_on_resolve", Type: migrator.DB_Bool, Nullable: false, Default: "0"},
+			{Name: "silence_id", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "window_start", Type: migrator.DB_BigInt, Nullable: false, Default: "0"},
+			{Name: "updated", Type: migrator.DB_DateTime, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "uid"}, Type: migrator.UniqueIndex},
+		},
+	}
+
+	mg.AddMigration("create alert_recurring_silence table", migrator.NewAddTableMigration(recurringTable))
+	mg.AddMigration("add unique index on org_id and uid to alert_recurring_silence table", migrator.NewAddIndexMigration(recurringTable, recurringTable.Indices[0]))
+	mg.AddMigration("add time_zone column to alert_recurring_silence table", migrator.NewAddColumnMigration(recurringTable, &migrator.Column{
+		Name:     "time_zone",
+		Type:     migrator.DB_NVarchar,
+		Length:   DefaultFieldMaxLength,
+		Nullable: false,
+		Default:  "''",
+	}))
+
+	expiryTable := migrator.Table{
+		Name: "alert_silence_expiry",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "silence_id", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "alerts_seen", Type: migrator.DB_Bool, Nullable: false, Default: "0"},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "silence_id"}, Type: migrator.UniqueIndex},
+		},
+	}
+
+	mg.AddMigration("create alert_silence_expiry table", migrator.NewAddTableMigration(expiryTable))
+	mg.AddMigration("add unique index on org_id and silence_id to alert_silence_expiry table", migrator.NewAddIndexMigration(expiryTable, expiryTable.Indices[0]))
+}
@@ -0,0 +1,82 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddStateHistoryMigrations creates the tables of the SQL state history backend.
+// Label sets of alert instances are stored once and referenced by the transitions. Every label of a set is indexed by
+// the hash of its name and value.
+func AddStateHistoryMigrations(mg *migrator.Migrator) {
+	labelSetTable := migrator.Table{
+		Name: "alert_state_history_label_set",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "fingerprint", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "labels", Type: migrator.DB_Text, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "fingerprint"}, Type: migrator.UniqueIndex},
+		},
+	}
+
+	mg.AddMigration("create alert_state_history_label_set table", migrator.NewAddTableMigration(labelSetTable))
+	mg.AddMigration("add unique index on org_id and fingerprint to alert_state_history_label_set table", migrator.NewAddIndexMigration(labelSetTable, labelSetTable.Indices[0]))
+
+	labelTable := migrator.Table{
+		Name: "alert_state_history_label",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "label_set_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "hash", Type: migrator.DB_BigInt, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "hash"}, Type: migrator.IndexType},
+			{Cols: []string{"label_set_id"}, Type: migrator.IndexType},
+		},
+	}
+
+	mg.AddMigration("create alert_state_history_label table", migrator.NewAddTableMigration(labelTable))
+	mg.AddMigration("add index on org_id and hash to alert_state_history_label table", migrator.NewAddIndexMigration(labelTable, labelTable.Indices[0]))
+	mg.AddMigration("add index on label_set_id to alert_state_history_label table", migrator.NewAddIndexMigration(labelTable, labelTable.Indices[1]))
+
+	historyTable := migrator.Table{
+		Name: "alert_state_history",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "rule_uid", Type: migrator.DB_NVarchar, Length: UIDMaxLength, Nullable: false},
+			{Name: "rule_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "rule_title", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "rule_group", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "folder_uid", Type: migrator.DB_NVarchar, Length: UIDMaxLength, Nullable: false},
+			{Name: "dashboard_uid", Type: migrator.DB_NVarchar, Length: UIDMaxLength, Nullable: false},
+			{Name: "panel_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "rule_condition", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "label_set_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "prev_state", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "new_state", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "state", Type: migrator.DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "error", Type: migrator.DB_Text, Nullable: true},
+			{Name: "state_values", Type: migrator.DB_Text, Nullable: true},
+			{Name: "epoch", Type: migrator.DB_BigInt, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "epoch"}, Type: migrator.IndexType},
+			{Cols: []string{"org_id", "rule_uid", "epoch"}, Type: migrator.IndexType},
+			{Cols: []string{"org_id", "folder_uid", "epoch"}, Type: migrator.IndexType},
+			{Cols: []string{"label_set_id", "epoch"}, Type: migrator.IndexType},
+		},
+	}
+
+	mg.AddMigration("create alert_state_history table", migrator.NewAddTableMigration(historyTable))
+	mg.AddMigration("add index on org_id and epoch to alert_state_history table", migrator.NewAddIndexMigration(historyTable, historyTable.Indices[0]))
+	mg.AddMigration("add index on org_id, rule_uid and epoch to alert_state_history table", migrator.NewAddIndexMigration(historyTable, historyTable.Indices[1]))
+	mg.AddMigration("add index on org_id, folder_uid and epoch to alert_state_history table", migrator.NewAddIndexMigration(historyTable, historyTable.Indices[2]))
+	mg.AddMigration("add index on label_set_id and epoch to alert_state_history table", migrator.NewAddIndexMigration(historyTable, historyTable.Indices[3]))
+
+	// The time a label set was last used protects label sets that are being referenced from the retention cleanup.
+	mg.AddMigration("add updated column to alert_state_history_label_set table", migrator.NewAddColumnMigration(labelSetTable, &migrator.Column{
+		Name: "updated", Type: migrator.DB_BigInt, Nullable: false, Default: "0",
+	}))
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/grafana/grafana/pkg/infra/usagestats"
 	"github.com/grafana/grafana/pkg/login/social"
 	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/auditlog"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/licensing"
 	"github.com/grafana/grafana/pkg/services/secrets"
@@ -30,6 +31,8 @@ import (
 
 var _ ssosettings.Service = (*Service)(nil)
 
+const auditKindSSOSettings = "settings.sso"
+
 type Service struct {
 	logger           log.Logger
 	cfg              *setting.Cfg
@@ -224,6 +227,9 @@ func (s *Service) Upsert(ctx context.Context, settings *models.SSOSettings, requ
 		return err
 	}
 
+	auditlog.SetResource(ctx, auditKindSSOSettings, settings.Provider)
+	auditlog.AddChange(ctx, "", removeSecrets(storedSettings.Settings), removeSecrets(settingsWithSecrets))
+
 	// make a copy of current settings for reload operation and apply overrides
 	reloadSettings := *settings
 	reloadSettings.Settings = overrideMaps(storedSettings.Settings, settingsWithSecrets)
@@ -247,11 +253,21 @@ func (s *Service) Delete(ctx context.Context, provider string) error {
 		return ssosettings.ErrInvalidProvider.Errorf("provider %s not found in reloadables", provider)
 	}
 
+	var before *models.SSOSettings
+	if auditlog.Recording(ctx) {
+		before, _ = s.GetForProvider(ctx, provider)
+	}
+
 	err := s.store.Delete(ctx, provider)
 	if err != nil {
 		return err
 	}
 
+	auditlog.SetResource(ctx, auditKindSSOSettings, provider)
+	if before != nil {
+		auditlog.AddChange(ctx, "", removeSecrets(before.Settings), nil)
+	}
+
 	// When deleting settings for SAML, clear the Settings table
 	if provider == social.SAMLProviderName {
 		samlSettings := setting.SettingsRemovals{
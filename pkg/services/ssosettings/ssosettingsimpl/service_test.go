@@ -21,6 +21,7 @@ import (
 	"github.com/grafana/grafana/pkg/login/social"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/auditlog"
 	"github.com/grafana/grafana/pkg/services/authz/zanzana"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/licensing/licensingtest"
@@ -1052,6 +1053,55 @@ func TestService_Upsert(t *testing.T) {
 		require.EqualValues(t, settings, env.store.ActualSSOSettings)
 	})
 
+	t.Run("records the changed settings in the audit log without secrets", func(t *testing.T) {
+		t.Parallel()
+
+		env := setupTestEnv(t, false, false, false, false)
+
+		provider := social.AzureADProviderName
+		settings := models.SSOSettings{
+			Provider: provider,
+			Settings: map[string]any{
+				"client_id":     "new-client-id",
+				"client_secret": "new-client-secret",
+				"enabled":       true,
+			},
+		}
+
+		reloadable := ssosettingstests.NewMockReloadable(t)
+		reloadable.On("Validate", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		reloadable.On("Reload", mock.Anything, mock.Anything).Return(nil).Maybe()
+		env.reloadables[provider] = reloadable
+		env.secrets.On("Encrypt", mock.Anything, mock.Anything, mock.Anything).Return([]byte("encrypted-client-secret"), nil).Once()
+		env.secrets.On("Decrypt", mock.Anything, []byte("encrypted-current-client-secret"), mock.Anything).Return([]byte("current-client-secret"), nil).Once()
+
+		env.store.UpsertFn = func(ctx context.Context, settings *models.SSOSettings) error {
+			return nil
+		}
+		env.store.GetFn = func(ctx context.Context, provider string) (*models.SSOSettings, error) {
+			return &models.SSOSettings{
+				ID:       "someid",
+				Provider: provider,
+				Settings: map[string]any{
+					"client_id":     "client-id",
+					"client_secret": base64.RawStdEncoding.EncodeToString([]byte("encrypted-current-client-secret")),
+					"enabled":       true,
+				},
+			}, nil
+		}
+
+		entry := &auditlog.Entry{}
+		err := env.service.Upsert(auditlog.WithEntry(context.Background(), entry), &settings, &user.SignedInUser{})
+		require.NoError(t, err)
+
+		require.Equal(t, provider, entry.ResourceUID)
+		require.Contains(t, entry.Changes, auditlog.Change{Path: "client_id", Before: "client-id", After: "new-client-id"})
+		for _, c := range entry.Changes {
+			require.NotContains(t, []any{"current-client-secret", "new-client-secret"}, c.Before)
+			require.NotContains(t, []any{"current-client-secret", "new-client-secret"}, c.After)
+		}
+	})
+
 	t.Run("successfully upsert SSO settings for LDAP", func(t *testing.T) {
 		t.Parallel()
 
@@ -9,6 +9,7 @@ import (
 	"github.com/grafana/grafana/pkg/api/dtos"
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/auditlog"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	"github.com/grafana/grafana/pkg/services/dashboards/dashboardaccess"
 	"github.com/grafana/grafana/pkg/services/preference/prefapi"
@@ -87,6 +88,16 @@ func (tapi *TeamAPI) updateTeam(c *contextmodel.ReqContext) response.Response {
 		return response.Error(http.StatusBadRequest, "teamId is invalid", err)
 	}
 
+	// the previous state is only needed by the audit log
+	var before map[string]any
+	if auditlog.Recording(c.Req.Context()) {
+		existing, err := tapi.teamService.GetTeamByID(c.Req.Context(), &team.GetTeamByIDQuery{OrgID: cmd.OrgID, ID: cmd.ID})
+		if err == nil {
+			before = map[string]any{"name": existing.Name, "email": existing.Email}
+			auditlog.SetResource(c.Req.Context(), "teams", existing.UID)
+		}
+	}
+
 	if err := tapi.teamService.UpdateTeam(c.Req.Context(), &cmd); err != nil {
 		if errors.Is(err, team.ErrTeamNameTaken) {
 			return response.Error(http.StatusBadRequest, "Team name taken", err)
@@ -94,6 +105,8 @@ func (tapi *TeamAPI) updateTeam(c *contextmodel.ReqContext) response.Response {
 		return response.Error(http.StatusInternalServerError, "Failed to update Team", err)
 	}
 
+	auditlog.SetChange(c.Req.Context(), before, map[string]any{"name": cmd.Name, "email": cmd.Email})
+
 	return response.Success("Team updated")
 }
 
@@ -344,6 +344,11 @@ type Cfg struct {
 	SqlDatasourceMaxOpenConnsDefault    int
 	SqlDatasourceMaxIdleConnsDefault    int
 	SqlDatasourceMaxConnLifetimeDefault int
+	SqlDatasourceStreamRowLimit         int64
+
+	// SQLite data source
+	SQLiteDatasourceAllowedPaths []string
+	SQLiteDatasourceReadOnly     bool
 
 	// Snapshots
 	SnapshotEnabled      bool
@@ -497,7 +502,11 @@ type Cfg struct {
 	DatabaseInstrumentQueries bool
 
 	// Public dashboards
-	PublicDashboardsEnabled bool
+	PublicDashboardsEnabled       bool
+	PublicDashboardsQueryCacheTTL time.Duration
+	// PublicDashboardsTrustedProxies are the IP addresses and CIDR ranges of the proxies whose X-Forwarded-For
+	// headers are used to find the client IP address of public dashboard requests
+	PublicDashboardsTrustedProxies []string
 
 	// Cloud Migration
 	CloudMigration CloudMigrationSettings
@@ -1994,6 +2003,11 @@ func (cfg *Cfg) readSqlDataSourceSettings() {
 	cfg.SqlDatasourceMaxOpenConnsDefault = sqlDatasources.Key("max_open_conns_default").MustInt(100)
 	cfg.SqlDatasourceMaxIdleConnsDefault = sqlDatasources.Key("max_idle_conns_default").MustInt(100)
 	cfg.SqlDatasourceMaxConnLifetimeDefault = sqlDatasources.Key("max_conn_lifetime_default").MustInt(14400)
+	cfg.SqlDatasourceStreamRowLimit = sqlDatasources.Key("stream_row_limit").MustInt64(10000000)
+
+	sqliteDatasource := cfg.Raw.Section("sqlite_datasource")
+	cfg.SQLiteDatasourceAllowedPaths = util.SplitString(sqliteDatasource.Key("allowed_paths").String())
+	cfg.SQLiteDatasourceReadOnly = sqliteDatasource.Key("read_only").MustBool(true)
 }
 
 func GetAllowedOriginGlobs(originPatterns []string) ([]glob.Glob, error) {
@@ -2049,6 +2063,8 @@ func (cfg *Cfg) readLiveSettings(iniFile *ini.File) error {
 func (cfg *Cfg) readPublicDashboardsSettings() {
 	publicDashboards := cfg.Raw.Section("public_dashboards")
 	cfg.PublicDashboardsEnabled = publicDashboards.Key("enabled").MustBool(true)
+	cfg.PublicDashboardsQueryCacheTTL = publicDashboards.Key("query_cache_ttl").MustDuration(0)
+	cfg.PublicDashboardsTrustedProxies = util.SplitString(publicDashboards.Key("trusted_proxies").MustString(""))
 }
 
 func (cfg *Cfg) DefaultOrgID() int64 {
@@ -2,6 +2,7 @@ package setting
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -45,17 +46,22 @@ const (
 	}
 }
 `
-	alertingDefaultInitializationTimeout    = 30 * time.Second
-	evaluatorDefaultEvaluationTimeout       = 30 * time.Second
-	schedulerDefaultAdminConfigPollInterval = time.Minute
-	schedulerDefaultExecuteAlerts           = true
-	schedulerDefaultMaxAttempts             = 1
-	schedulerDefaultLegacyMinInterval       = 1
-	screenshotsDefaultCapture               = false
-	screenshotsDefaultCaptureTimeout        = 10 * time.Second
-	screenshotsMaxCaptureTimeout            = 30 * time.Second
-	screenshotsDefaultMaxConcurrent         = 5
-	screenshotsDefaultUploadImageStorage    = false
+	alertingDefaultInitializationTimeout     = 30 * time.Second
+	evaluatorDefaultEvaluationTimeout        = 30 * time.Second
+	schedulerDefaultAdminConfigPollInterval  = time.Minute
+	schedulerDefaultExecuteAlerts            = true
+	schedulerDefaultMaxAttempts              = 1
+	schedulerDefaultLegacyMinInterval        = 1
+	screenshotsDefaultCapture                = false
+	screenshotsDefaultCaptureTimeout         = 10 * time.Second
+	screenshotsMaxCaptureTimeout             = 30 * time.Second
+	screenshotsDefaultMaxConcurrent          = 5
+	screenshotsDefaultUploadImageStorage     = false
+	shardingDefaultHeartbeatInterval         = 10 * time.Second
+	shardingDefaultPeerTimeout               = time.Minute
+	evaluationCostDefaultSlowRuleThreshold   = 30 * time.Second
+	evaluationCostDefaultHighCardinality     = 1000
+	evaluationCostDefaultMaxRulesWithMetrics = 1000
 	// SchedulerBaseInterval base interval of the scheduler. Controls how often the scheduler fetches database for new changes as well as schedules evaluation of a rule
 	// changing this value is discouraged because this could cause existing alert definition
 	// with intervals that are not exactly divided by this number not to be evaluated
@@ -97,6 +103,7 @@ type UnifiedAlertingSettings struct {
 	EvaluationTimeout               time.Duration
 	EvaluationResultLimit           int
 	DisableJitter                   bool
+	SequentialGroupEvaluation       bool
 	ExecuteAlerts                   bool
 	DefaultConfiguration            string
 	Enabled                         *bool // determines whether unified alerting is enabled. If it is nil then user did not define it and therefore its value will be determined during migration. Services should not use it directly.
@@ -112,6 +119,8 @@ type UnifiedAlertingSettings struct {
 	StateHistory                  UnifiedAlertingStateHistorySettings
 	RemoteAlertmanager            RemoteAlertmanagerSettings
 	RecordingRules                RecordingRuleSettings
+	EvaluationSharding            UnifiedAlertingEvaluationShardingSettings
+	EvaluationCost                UnifiedAlertingEvaluationCostSettings
 
 	// MaxStateSaveConcurrency controls the number of goroutines (per rule) that can save alert state in parallel.
 	MaxStateSaveConcurrency   int
@@ -156,6 +165,30 @@ type UnifiedAlertingScreenshotSettings struct {
 	UploadExternalImageStorage bool
 }
 
+// UnifiedAlertingEvaluationShardingSettings configures the distribution of alert rule evaluation between
+// the Grafana instances that share the database.
+type UnifiedAlertingEvaluationShardingSettings struct {
+	Enabled bool
+	// InstanceID identifies this instance among the instances that share the database.
+	InstanceID string
+	// HeartbeatInterval is the interval at which the instance updates its heartbeat and refreshes the list of peers.
+	HeartbeatInterval time.Duration
+	// PeerTimeout is the time after the last heartbeat when a peer is considered gone.
+	PeerTimeout time.Duration
+}
+
+// UnifiedAlertingEvaluationCostSettings configures the accounting of the evaluation cost of individual alert rules.
+type UnifiedAlertingEvaluationCostSettings struct {
+	// SlowRuleThreshold is the evaluation duration from which a rule is flagged as slow. 0 disables the flag.
+	SlowRuleThreshold time.Duration
+	// HighCardinalityThreshold is the number of series from which a rule is flagged as high cardinality. 0 disables the flag.
+	HighCardinalityThreshold int
+	// RuleMetricsEnabled enables metrics labelled by rule.
+	RuleMetricsEnabled bool
+	// RuleMetricsMaxRules is the maximum number of rules that have metrics labelled by rule.
+	RuleMetricsMaxRules int
+}
+
 type UnifiedAlertingReservedLabelSettings struct {
 	DisabledLabels map[string]struct{}
 }
@@ -176,6 +209,8 @@ type UnifiedAlertingStateHistorySettings struct {
 	MultiPrimary          string
 	MultiSecondaries      []string
 	ExternalLabels        map[string]string
+	// SQLMaxAge is how long state history is kept by the "sql" backend. 0 keeps it forever.
+	SQLMaxAge time.Duration
 }
 
 // IsEnabled returns true if UnifiedAlertingSettings.Enabled is either nil or true.
@@ -324,6 +359,8 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 	// We can consider removing the knob entirely in a release after 10.4.
 	uaCfg.DisableJitter = ua.Key("disable_jitter").MustBool(false)
 
+	uaCfg.SequentialGroupEvaluation = ua.Key("sequential_group_evaluation").MustBool(false)
+
 	// The base interval of the scheduler for evaluating alerts.
 	// 1. It is used by the internal scheduler's timer to tick at this interval.
 	// 2. to spread evaluations of rules that need to be evaluated at the current tick T. In other words, the evaluation of rules at the tick T will be evenly spread in the interval from T to T+scheduler_tick_interval.
@@ -413,6 +450,56 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 	}
 	uaCfg.ReservedLabels = uaCfgReservedLabels
 
+	sharding := iniFile.Section("unified_alerting.evaluation_sharding")
+	uaCfgSharding := UnifiedAlertingEvaluationShardingSettings{
+		Enabled:    sharding.Key("enabled").MustBool(false),
+		InstanceID: sharding.Key("instance_id").MustString(""),
+	}
+	if uaCfgSharding.InstanceID == "" {
+		uaCfgSharding.InstanceID, _ = os.Hostname()
+	}
+	uaCfgSharding.HeartbeatInterval, err = gtime.ParseDuration(valueAsString(sharding, "heartbeat_interval", shardingDefaultHeartbeatInterval.String()))
+	if err != nil {
+		return fmt.Errorf("failed to parse setting 'heartbeat_interval' as duration: %w", err)
+	}
+	uaCfgSharding.PeerTimeout, err = gtime.ParseDuration(valueAsString(sharding, "peer_timeout", shardingDefaultPeerTimeout.String()))
+	if err != nil {
+		return fmt.Errorf("failed to parse setting 'peer_timeout' as duration: %w", err)
+	}
+	if uaCfgSharding.Enabled {
+		if uaCfgSharding.InstanceID == "" {
+			return fmt.Errorf("setting 'instance_id' must be set when evaluation sharding is enabled")
+		}
+		if uaCfgSharding.HeartbeatInterval <= 0 {
+			return fmt.Errorf("value of setting 'heartbeat_interval' must be positive")
+		}
+		if uaCfgSharding.PeerTimeout <= uaCfgSharding.HeartbeatInterval {
+			return fmt.Errorf("value of setting 'peer_timeout' must be greater than 'heartbeat_interval' (%v)", uaCfgSharding.HeartbeatInterval)
+		}
+	}
+	uaCfg.EvaluationSharding = uaCfgSharding
+
+	evaluationCost := iniFile.Section("unified_alerting.evaluation_cost")
+	uaCfgEvaluationCost := UnifiedAlertingEvaluationCostSettings{
+		HighCardinalityThreshold: evaluationCost.Key("high_cardinality_threshold").MustInt(evaluationCostDefaultHighCardinality),
+		RuleMetricsEnabled:       evaluationCost.Key("rule_metrics_enabled").MustBool(false),
+		RuleMetricsMaxRules:      evaluationCost.Key("rule_metrics_max_rules").MustInt(evaluationCostDefaultMaxRulesWithMetrics),
+	}
+	uaCfgEvaluationCost.SlowRuleThreshold, err = gtime.ParseDuration(valueAsString(evaluationCost, "slow_rule_threshold", evaluationCostDefaultSlowRuleThreshold.String()))
+	if err != nil {
+		return fmt.Errorf("failed to parse setting 'slow_rule_threshold' as duration: %w", err)
+	}
+	if uaCfgEvaluationCost.SlowRuleThreshold < 0 {
+		return fmt.Errorf("value of setting 'slow_rule_threshold' must not be negative")
+	}
+	if uaCfgEvaluationCost.HighCardinalityThreshold < 0 {
+		return fmt.Errorf("value of setting 'high_cardinality_threshold' must not be negative")
+	}
+	if uaCfgEvaluationCost.RuleMetricsMaxRules < 0 {
+		return fmt.Errorf("value of setting 'rule_metrics_max_rules' must not be negative")
+	}
+	uaCfg.EvaluationCost = uaCfgEvaluationCost
+
 	stateHistory := iniFile.Section("unified_alerting.state_history")
 	stateHistoryLabels := iniFile.Section("unified_alerting.state_history.external_labels")
 	uaCfgStateHistory := UnifiedAlertingStateHistorySettings{
@@ -430,6 +517,13 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 		MultiSecondaries:      splitTrim(stateHistory.Key("secondaries").MustString(""), ","),
 		ExternalLabels:        stateHistoryLabels.KeysHash(),
 	}
+	stateHistorySQL := iniFile.Section("unified_alerting.state_history.sql")
+	if maxAge := stateHistorySQL.Key("max_age").MustString(""); maxAge != "" {
+		uaCfgStateHistory.SQLMaxAge, err = gtime.ParseDuration(maxAge)
+		if err != nil {
+			return fmt.Errorf("value of setting 'max_age' in section [unified_alerting.state_history.sql] is invalid: %w", err)
+		}
+	}
 	uaCfg.StateHistory = uaCfgStateHistory
 
 	rr := iniFile.Section("recording_rules")
@@ -350,3 +350,82 @@ func TestHARedisTLSSettings(t *testing.T) {
 	require.Equal(t, cipherSuites, cfg.UnifiedAlerting.HARedisTLSConfig.CipherSuites)
 	require.Equal(t, minVersion, cfg.UnifiedAlerting.HARedisTLSConfig.MinVersion)
 }
+
+func TestEvaluationShardingSettings(t *testing.T) {
+	t.Run("should read settings", func(t *testing.T) {
+		f := ini.Empty()
+		section, err := f.NewSection("unified_alerting.evaluation_sharding")
+		require.NoError(t, err)
+		_, err = section.NewKey("enabled", "true")
+		require.NoError(t, err)
+		_, err = section.NewKey("instance_id", "grafana-0")
+		require.NoError(t, err)
+		_, err = section.NewKey("heartbeat_interval", "5s")
+		require.NoError(t, err)
+
+		cfg := NewCfg()
+		require.NoError(t, cfg.ReadUnifiedAlertingSettings(f))
+
+		require.True(t, cfg.UnifiedAlerting.EvaluationSharding.Enabled)
+		require.Equal(t, "grafana-0", cfg.UnifiedAlerting.EvaluationSharding.InstanceID)
+		require.Equal(t, 5*time.Second, cfg.UnifiedAlerting.EvaluationSharding.HeartbeatInterval)
+		require.Equal(t, shardingDefaultPeerTimeout, cfg.UnifiedAlerting.EvaluationSharding.PeerTimeout)
+	})
+
+	t.Run("should fail if peer timeout is not greater than heartbeat interval", func(t *testing.T) {
+		f := ini.Empty()
+		section, err := f.NewSection("unified_alerting.evaluation_sharding")
+		require.NoError(t, err)
+		_, err = section.NewKey("enabled", "true")
+		require.NoError(t, err)
+		_, err = section.NewKey("heartbeat_interval", "1m")
+		require.NoError(t, err)
+
+		cfg := NewCfg()
+		require.Error(t, cfg.ReadUnifiedAlertingSettings(f))
+	})
+}
+
+func TestEvaluationCostSettings(t *testing.T) {
+	t.Run("should use defaults", func(t *testing.T) {
+		cfg := NewCfg()
+		require.NoError(t, cfg.ReadUnifiedAlertingSettings(ini.Empty()))
+
+		require.Equal(t, evaluationCostDefaultSlowRuleThreshold, cfg.UnifiedAlerting.EvaluationCost.SlowRuleThreshold)
+		require.Equal(t, evaluationCostDefaultHighCardinality, cfg.UnifiedAlerting.EvaluationCost.HighCardinalityThreshold)
+		require.False(t, cfg.UnifiedAlerting.EvaluationCost.RuleMetricsEnabled)
+	})
+
+	t.Run("should read settings", func(t *testing.T) {
+		f := ini.Empty()
+		section, err := f.NewSection("unified_alerting.evaluation_cost")
+		require.NoError(t, err)
+		_, err = section.NewKey("slow_rule_threshold", "0")
+		require.NoError(t, err)
+		_, err = section.NewKey("high_cardinality_threshold", "50")
+		require.NoError(t, err)
+		_, err = section.NewKey("rule_metrics_enabled", "true")
+		require.NoError(t, err)
+		_, err = section.NewKey("rule_metrics_max_rules", "10")
+		require.NoError(t, err)
+
+		cfg := NewCfg()
+		require.NoError(t, cfg.ReadUnifiedAlertingSettings(f))
+
+		require.Zero(t, cfg.UnifiedAlerting.EvaluationCost.SlowRuleThreshold)
+		require.Equal(t, 50, cfg.UnifiedAlerting.EvaluationCost.HighCardinalityThreshold)
+		require.True(t, cfg.UnifiedAlerting.EvaluationCost.RuleMetricsEnabled)
+		require.Equal(t, 10, cfg.UnifiedAlerting.EvaluationCost.RuleMetricsMaxRules)
+	})
+
+	t.Run("should fail if slow rule threshold is negative", func(t *testing.T) {
+		f := ini.Empty()
+		section, err := f.NewSection("unified_alerting.evaluation_cost")
+		require.NoError(t, err)
+		_, err = section.NewKey("slow_rule_threshold", "-1s")
+		require.NoError(t, err)
+
+		cfg := NewCfg()
+		require.Error(t, cfg.ReadUnifiedAlertingSettings(f))
+	})
+}
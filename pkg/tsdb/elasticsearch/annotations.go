@@ -0,0 +1,238 @@
+package elasticsearch
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/grafana/grafana-plugin-sdk-go/experimental/errorsource"
+
+	es "github.com/grafana/grafana/pkg/tsdb/elasticsearch/client"
+)
+
+const (
+	annotationQueryType = "annotations"
+	// annotationPageSize is the number of documents requested per page of an annotation query
+	annotationPageSize     = 1000
+	defaultAnnotationLimit = 10000
+	// defaultAnnotationTextField is kept for annotations created before the text field was configurable
+	defaultAnnotationTextField = "tags"
+)
+
+// executeAnnotationQuery fetches the documents matching the Lucene query in pages, newest first,
+// until all of them are fetched or the annotation limit is exceeded, and returns them as an
+// annotation frame.
+func (e *elasticsearchDataQuery) executeAnnotationQuery(q *Query) backend.DataResponse {
+	settings := annotationSettingsWithDefaults(q.Annotation, e.client.GetConfiguredFields().TimeField)
+	from := q.TimeRange.From.UnixNano() / int64(time.Millisecond)
+	to := q.TimeRange.To.UnixNano() / int64(time.Millisecond)
+
+	timeFields := []string{settings.TimeField}
+	if settings.TimeEndField != "" {
+		timeFields = append(timeFields, settings.TimeEndField)
+	}
+
+	var hits []map[string]any
+	var searchAfter []any
+	for {
+		ms := e.client.MultiSearch()
+		b := ms.Search(q.Interval, q.TimeRange)
+		// request one document more than the limit to know whether the results are truncated
+		pageSize := min(annotationPageSize, settings.Limit-len(hits)+1)
+		b.Size(pageSize)
+		b.Sort(es.SortOrderDesc, settings.TimeField, "boolean")
+		b.Sort(es.SortOrderDesc, "_doc", "")
+		for _, value := range searchAfter {
+			b.AddSearchAfter(value)
+		}
+		filters := b.Query().Bool().Filter()
+		filters.AddAnyDateRangeFilter(timeFields, to, from, es.DateFormatEpochMS)
+		filters.AddQueryStringFilter(q.RawQuery, true)
+
+		req, err := ms.Build()
+		if err != nil {
+			return errorsource.Response(errorsource.PluginError(err, false))
+		}
+		res, err := e.client.ExecuteMultisearch(req)
+		if err != nil {
+			return errorsource.Response(err)
+		}
+		if len(res.Responses) == 0 {
+			break
+		}
+		page := res.Responses[0]
+		if page.Error != nil {
+			return errorsource.Response(errorsource.PluginError(errors.New(getErrorFromElasticResponse(page)), false))
+		}
+		if page.Hits == nil {
+			break
+		}
+
+		hits = append(hits, page.Hits.Hits...)
+		if len(page.Hits.Hits) < pageSize || len(hits) > settings.Limit {
+			break
+		}
+		sortValues, ok := page.Hits.Hits[len(page.Hits.Hits)-1]["sort"].([]any)
+		if !ok {
+			break
+		}
+		searchAfter = sortValues
+	}
+
+	truncated := len(hits) > settings.Limit
+	if truncated {
+		hits = hits[:settings.Limit]
+	}
+
+	frame := annotationHitsToFrame(hits, settings)
+	frame.RefID = q.RefID
+	if truncated {
+		frame.AppendNotices(data.Notice{
+			Severity: data.NoticeSeverityWarning,
+			Text:     fmt.Sprintf("Query returned more than %d annotations, only the most recent %d are shown.", settings.Limit, settings.Limit),
+		})
+	}
+	return backend.DataResponse{Frames: data.Frames{frame}}
+}
+
+func annotationSettingsWithDefaults(settings *AnnotationSettings, defaultTimeField string) AnnotationSettings {
+	s := AnnotationSettings{}
+	if settings != nil {
+		s = *settings
+	}
+	if s.TimeField == "" {
+		s.TimeField = defaultTimeField
+	}
+	if s.TextField == "" {
+		s.TextField = defaultAnnotationTextField
+	}
+	if s.Limit <= 0 {
+		s.Limit = defaultAnnotationLimit
+	}
+	return s
+}
+
+// annotationHitsToFrame converts the documents to an annotation frame with a time, text and tags
+// field, and a timeEnd field when an end time field is configured. Documents without a valid time
+// are skipped.
+func annotationHitsToFrame(hits []map[string]any, settings AnnotationSettings) *data.Frame {
+	times := make([]time.Time, 0, len(hits))
+	timeEnds := make([]*time.Time, 0, len(hits))
+	texts := make([]string, 0, len(hits))
+	tags := make([]string, 0, len(hits))
+
+	for _, hit := range hits {
+		source, _ := hit["_source"].(map[string]any)
+		fields, _ := hit["fields"].(map[string]any)
+
+		t, ok := parseAnnotationTime(lookupHitField(source, fields, settings.TimeField))
+		if !ok {
+			continue
+		}
+		times = append(times, t)
+
+		var timeEnd *time.Time
+		if settings.TimeEndField != "" {
+			if t, ok := parseAnnotationTime(lookupHitField(source, fields, settings.TimeEndField)); ok {
+				timeEnd = &t
+			}
+		}
+		timeEnds = append(timeEnds, timeEnd)
+
+		text := annotationString(lookupHitField(source, fields, settings.TextField))
+		// legacy support for the title field
+		if settings.TitleField != "" {
+			if title := annotationString(lookupHitField(source, fields, settings.TitleField)); title != "" {
+				text = title + "\n" + text
+			}
+		}
+		texts = append(texts, text)
+
+		tags = append(tags, annotationString(lookupHitField(source, fields, settings.TagsField)))
+	}
+
+	frame := data.NewFrame("",
+		data.NewField("time", nil, times),
+		data.NewField("text", nil, texts),
+		data.NewField("tags", nil, tags),
+	)
+	if settings.TimeEndField != "" {
+		frame.Fields = append(frame.Fields, data.NewField("timeEnd", nil, timeEnds))
+	}
+	return frame
+}
+
+// lookupHitField returns the value of the field in the document source, following nested objects
+// for dotted field names, and falls back to the fields returned by the fields API.
+func lookupHitField(source, fields map[string]any, field string) any {
+	if field == "" {
+		return nil
+	}
+	if v, ok := source[field]; ok {
+		return v
+	}
+
+	var current any = source
+	for _, name := range strings.Split(field, ".") {
+		obj, ok := current.(map[string]any)
+		if !ok {
+			current = nil
+			break
+		}
+		current = obj[name]
+	}
+	if current != nil {
+		return current
+	}
+
+	if values, ok := fields[field].([]any); ok && len(values) > 0 {
+		return values[0]
+	}
+	return nil
+}
+
+func parseAnnotationTime(v any) (time.Time, bool) {
+	switch v := v.(type) {
+	case float64:
+		return time.UnixMilli(int64(v)).UTC(), true
+	case string:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02 15:04:05", time.DateOnly} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t.UTC(), true
+			}
+		}
+		if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return time.UnixMilli(ms).UTC(), true
+		}
+	case []any:
+		if len(v) > 0 {
+			return parseAnnotationTime(v[0])
+		}
+	}
+	return time.Time{}, false
+}
+
+// annotationString returns the value as text. Multiple values, such as a list of tags, are joined
+// with commas.
+func annotationString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, annotationString(item))
+		}
+		return strings.Join(values, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
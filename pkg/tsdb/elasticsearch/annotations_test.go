@@ -0,0 +1,150 @@
+package elasticsearch
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	es "github.com/grafana/grafana/pkg/tsdb/elasticsearch/client"
+)
+
+// annotationPage returns a response with count documents, one second apart and newest first,
+// starting at first seconds before the given time.
+func annotationPage(start time.Time, first, count int) *es.MultiSearchResponse {
+	hits := make([]map[string]any, 0, count)
+	for i := first; i < first+count; i++ {
+		ts := float64(start.Add(-time.Duration(i) * time.Second).UnixMilli())
+		hits = append(hits, map[string]any{
+			"_source": map[string]any{
+				"@timestamp": ts,
+				"message":    fmt.Sprintf("event %d", i),
+				"labels":     []any{"deploy", "prod"},
+			},
+			"sort": []any{ts, float64(i)},
+		})
+	}
+	return &es.MultiSearchResponse{Responses: []*es.SearchResponse{{Hits: &es.SearchResponseHits{Hits: hits}}}}
+}
+
+func TestExecuteAnnotationQuery(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	t.Run("builds a sorted search filtered by the query and the time range", func(t *testing.T) {
+		c := newFakeClient()
+		c.multiSearchPages = []*es.MultiSearchResponse{annotationPage(to, 0, 2)}
+
+		res, err := executeElasticsearchDataQuery(c, `{
+			"queryType": "annotations",
+			"query": "tags:deploy",
+			"annotation": { "textField": "message", "tagsField": "labels" }
+		}`, from, to)
+		require.NoError(t, err)
+		require.Len(t, c.multisearchRequests, 1)
+
+		sr := c.multisearchRequests[0].Requests[0]
+		require.Equal(t, annotationPageSize, sr.Size)
+		require.Equal(t, map[string]string{"order": "desc", "unmapped_type": "boolean"}, sr.Sort["@timestamp"])
+		require.Nil(t, sr.CustomProps["search_after"])
+		require.Len(t, sr.Query.Bool.Filters, 2)
+		rangeFilter := sr.Query.Bool.Filters[0].(*es.RangeFilter)
+		require.Equal(t, "@timestamp", rangeFilter.Key)
+		require.Equal(t, from.UnixMilli(), rangeFilter.Gte)
+		require.Equal(t, to.UnixMilli(), rangeFilter.Lte)
+		require.Equal(t, "tags:deploy", sr.Query.Bool.Filters[1].(*es.QueryStringFilter).Query)
+
+		frames := res.Responses["A"].Frames
+		require.Len(t, frames, 1)
+		frame := frames[0]
+		require.Equal(t, "A", frame.RefID)
+		require.Equal(t, 2, frame.Rows())
+		require.Equal(t, to, frame.Fields[0].At(0))
+		require.Equal(t, "event 0", frame.Fields[1].At(0))
+		require.Equal(t, "deploy,prod", frame.Fields[2].At(0))
+		require.Nil(t, frame.Meta)
+	})
+
+	t.Run("filters on the start or end time when an end time field is set", func(t *testing.T) {
+		c := newFakeClient()
+		c.multiSearchPages = []*es.MultiSearchResponse{{Responses: []*es.SearchResponse{{Hits: &es.SearchResponseHits{Hits: []map[string]any{
+			{"_source": map[string]any{"start": "2024-01-01T00:10:00Z", "event": map[string]any{"end": "2024-01-01T00:20:00Z"}, "title": "Deploy", "text": "v1.2.3"}},
+		}}}}}}
+
+		res, err := executeElasticsearchDataQuery(c, `{
+			"queryType": "annotations",
+			"annotation": { "timeField": "start", "timeEndField": "event.end", "textField": "text", "titleField": "title" }
+		}`, from, to)
+		require.NoError(t, err)
+
+		anyFilter := c.multisearchRequests[0].Requests[0].Query.Bool.Filters[0].(*es.AnyFilter)
+		require.Len(t, anyFilter.Filters, 2)
+		require.Equal(t, "event.end", anyFilter.Filters[1].(*es.RangeFilter).Key)
+
+		frame := res.Responses["A"].Frames[0]
+		require.Len(t, frame.Fields, 4)
+		require.Equal(t, time.Date(2024, 1, 1, 0, 10, 0, 0, time.UTC), frame.Fields[0].At(0))
+		require.Equal(t, "Deploy\nv1.2.3", frame.Fields[1].At(0))
+		require.Equal(t, "timeEnd", frame.Fields[3].Name)
+		require.Equal(t, time.Date(2024, 1, 1, 0, 20, 0, 0, time.UTC), *frame.Fields[3].At(0).(*time.Time))
+	})
+
+	t.Run("follows the sort values of the last document of full pages", func(t *testing.T) {
+		c := newFakeClient()
+		c.multiSearchPages = []*es.MultiSearchResponse{
+			annotationPage(to, 0, annotationPageSize),
+			annotationPage(to, annotationPageSize, 5),
+		}
+
+		res, err := executeElasticsearchDataQuery(c, `{"queryType": "annotations", "annotation": { "textField": "message" }}`, from, to)
+		require.NoError(t, err)
+		require.Len(t, c.multisearchRequests, 2)
+		lastTime := float64(to.Add(-(annotationPageSize - 1) * time.Second).UnixMilli())
+		require.Equal(t, []any{lastTime, float64(annotationPageSize - 1)}, c.multisearchRequests[1].Requests[0].CustomProps["search_after"])
+
+		frame := res.Responses["A"].Frames[0]
+		require.Equal(t, annotationPageSize+5, frame.Rows())
+		require.Nil(t, frame.Meta)
+	})
+
+	t.Run("stops at the limit with a notice", func(t *testing.T) {
+		c := newFakeClient()
+		c.multiSearchPages = []*es.MultiSearchResponse{annotationPage(to, 0, 4)}
+
+		res, err := executeElasticsearchDataQuery(c, `{"queryType": "annotations", "annotation": { "limit": 3 }}`, from, to)
+		require.NoError(t, err)
+		require.Len(t, c.multisearchRequests, 1)
+		require.Equal(t, 4, c.multisearchRequests[0].Requests[0].Size)
+
+		frame := res.Responses["A"].Frames[0]
+		require.Equal(t, 3, frame.Rows())
+		require.Len(t, frame.Meta.Notices, 1)
+		require.Equal(t, data.NoticeSeverityWarning, frame.Meta.Notices[0].Severity)
+		require.Contains(t, frame.Meta.Notices[0].Text, "more than 3 annotations")
+	})
+
+	t.Run("returns the error of a failing page", func(t *testing.T) {
+		c := newFakeClient()
+		c.multiSearchPages = []*es.MultiSearchResponse{
+			{Responses: []*es.SearchResponse{{Error: map[string]any{"type": "query_shard_exception", "reason": "failed to create query"}}}},
+		}
+
+		res, err := executeElasticsearchDataQuery(c, `{"queryType": "annotations", "query": "a:("}`, from, to)
+		require.NoError(t, err)
+		require.ErrorContains(t, res.Responses["A"].Error, "failed to create query")
+	})
+}
+
+func TestParseAnnotationTime(t *testing.T) {
+	expected := time.Date(2024, 1, 1, 0, 10, 0, 0, time.UTC)
+	for _, v := range []any{float64(expected.UnixMilli()), "2024-01-01T00:10:00.000Z", "2024-01-01 00:10:00", fmt.Sprint(expected.UnixMilli()), []any{"2024-01-01T00:10:00Z"}} {
+		parsed, ok := parseAnnotationTime(v)
+		require.True(t, ok, "%v", v)
+		require.Equal(t, expected, parsed)
+	}
+
+	_, ok := parseAnnotationTime("yesterday")
+	require.False(t, ok)
+}
@@ -37,6 +37,7 @@ type DatasourceInfo struct {
 	ConfiguredFields           ConfiguredFields
 	Interval                   string
 	MaxConcurrentShardRequests int64
+	MaxCompositeBuckets        int
 	IncludeFrozen              bool
 }
 
@@ -51,6 +52,7 @@ type Client interface {
 	GetConfiguredFields() ConfiguredFields
 	ExecuteMultisearch(r *MultiSearchRequest) (*MultiSearchResponse, error)
 	MultiSearch() *MultiSearchRequestBuilder
+	ExecuteEsql(r *EsqlRequest) (*EsqlResponse, error)
 }
 
 // NewClient creates a new elasticsearch client
@@ -97,7 +99,7 @@ func (c *baseClientImpl) executeBatchRequest(uriPath, uriQuery string, requests
 	if err != nil {
 		return nil, err
 	}
-	return c.executeRequest(http.MethodPost, uriPath, uriQuery, bytes)
+	return c.executeRequest(http.MethodPost, uriPath, uriQuery, "application/x-ndjson", bytes)
 }
 
 func (c *baseClientImpl) encodeBatchRequests(requests []*multiRequest) ([]byte, error) {
@@ -129,7 +131,7 @@ func (c *baseClientImpl) encodeBatchRequests(requests []*multiRequest) ([]byte,
 	return payload.Bytes(), nil
 }
 
-func (c *baseClientImpl) executeRequest(method, uriPath, uriQuery string, body []byte) (*http.Response, error) {
+func (c *baseClientImpl) executeRequest(method, uriPath, uriQuery, contentType string, body []byte) (*http.Response, error) {
 	c.logger.Debug("Sending request to Elasticsearch", "url", c.ds.URL)
 	u, err := url.Parse(c.ds.URL)
 	if err != nil {
@@ -148,7 +150,7 @@ func (c *baseClientImpl) executeRequest(method, uriPath, uriQuery string, body [
 		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/x-ndjson")
+	req.Header.Set("Content-Type", contentType)
 
 	//nolint:bodyclose
 	resp, err := c.ds.HTTPClient.Do(req)
@@ -264,3 +266,64 @@ func (c *baseClientImpl) getMultiSearchQueryParameters() string {
 func (c *baseClientImpl) MultiSearch() *MultiSearchRequestBuilder {
 	return NewMultiSearchRequestBuilder()
 }
+
+func (c *baseClientImpl) ExecuteEsql(r *EsqlRequest) (*EsqlResponse, error) {
+	var err error
+	_, span := tracing.DefaultTracer().Start(c.ctx, "datasource.elasticsearch.queryData.executeEsql", trace.WithAttributes(
+		attribute.String("url", c.ds.URL),
+	))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = c.checkEsqlIndices(r); err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	res, err := c.executeRequest(http.MethodPost, "_query", "", "application/json", body)
+	if err != nil {
+		c.logger.Error("Error received from Elasticsearch", "error", err, "status", "error", "duration", time.Since(start), "stage", StageDatabaseRequest)
+		return nil, err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			c.logger.Warn("Failed to close response body", "error", err)
+		}
+	}()
+
+	c.logger.Info("Response received from Elasticsearch", "status", "ok", "statusCode", res.StatusCode, "contentLength", res.ContentLength, "duration", time.Since(start), "stage", StageDatabaseRequest)
+
+	dec := json.NewDecoder(res.Body)
+	dec.UseNumber()
+	if res.StatusCode/100 != 2 {
+		var errRes struct {
+			Error struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		}
+		if decodeErr := dec.Decode(&errRes); decodeErr != nil || errRes.Error.Reason == "" {
+			err = exp.DownstreamError(fmt.Errorf("ES|QL query failed with status %d", res.StatusCode), false)
+			return nil, err
+		}
+		err = exp.DownstreamError(fmt.Errorf("%s: %s", errRes.Error.Type, errRes.Error.Reason), false)
+		return nil, err
+	}
+
+	var er EsqlResponse
+	if err = dec.Decode(&er); err != nil {
+		c.logger.Error("Failed to decode response from Elasticsearch", "error", err, "duration", time.Since(start))
+		return nil, err
+	}
+	return &er, nil
+}
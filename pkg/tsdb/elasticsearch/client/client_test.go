@@ -3,6 +3,7 @@ package es
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -183,6 +184,63 @@ func TestClient_ExecuteMultisearch(t *testing.T) {
 	})
 }
 
+func TestClient_ExecuteEsql(t *testing.T) {
+	newClient := func(t *testing.T, handler http.HandlerFunc) Client {
+		t.Helper()
+		ts := httptest.NewServer(handler)
+		t.Cleanup(ts.Close)
+
+		ds := DatasourceInfo{
+			URL:        ts.URL,
+			HTTPClient: ts.Client(),
+			Database:   "logs-*",
+		}
+		c, err := NewClient(context.Background(), &ds, log.New())
+		require.NoError(t, err)
+		return c
+	}
+
+	t.Run("Sends the query to the query endpoint", func(t *testing.T) {
+		var request *http.Request
+		var requestBody []byte
+		c := newClient(t, func(rw http.ResponseWriter, r *http.Request) {
+			request = r
+			var err error
+			requestBody, err = io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			rw.Header().Set("Content-Type", "application/json")
+			_, err = rw.Write([]byte(`{"columns": [{"name": "count", "type": "long"}], "values": [[9007199254740993]]}`))
+			require.NoError(t, err)
+		})
+
+		res, err := c.ExecuteEsql(&EsqlRequest{Query: "FROM logs-* | STATS count = COUNT(*)", Columnar: true})
+		require.NoError(t, err)
+
+		require.NotNil(t, request)
+		assert.Equal(t, http.MethodPost, request.Method)
+		assert.Equal(t, "/_query", request.URL.Path)
+		assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"query": "FROM logs-* | STATS count = COUNT(*)", "columnar": true}`, string(requestBody))
+
+		require.Len(t, res.Columns, 1)
+		assert.Equal(t, EsqlColumn{Name: "count", Type: "long"}, res.Columns[0])
+		assert.Equal(t, json.Number("9007199254740993"), res.Values[0][0])
+	})
+
+	t.Run("Returns the Elasticsearch error reason", func(t *testing.T) {
+		c := newClient(t, func(rw http.ResponseWriter, r *http.Request) {
+			rw.Header().Set("Content-Type", "application/json")
+			rw.WriteHeader(http.StatusBadRequest)
+			_, err := rw.Write([]byte(`{"error": {"type": "verification_exception", "reason": "Unknown column [nope]"}, "status": 400}`))
+			require.NoError(t, err)
+		})
+
+		_, err := c.ExecuteEsql(&EsqlRequest{Query: "FROM logs-* | KEEP nope"})
+		require.EqualError(t, err, "verification_exception: Unknown column [nope]")
+	})
+}
+
 func TestClient_Index(t *testing.T) {
 	tt := []struct {
 		name                string
@@ -0,0 +1,170 @@
+package es
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	exp "github.com/grafana/grafana-plugin-sdk-go/experimental/errorsource"
+)
+
+// checkEsqlIndices returns an error when the ES|QL query reads an index that is not covered
+// by the index pattern of the data source. Queries of data sources without an index pattern
+// are not restricted, like search requests that query all indices in that case.
+func (c *baseClientImpl) checkEsqlIndices(r *EsqlRequest) error {
+	indices, err := c.indexPattern.GetIndices(r.TimeRange)
+	if err != nil {
+		return err
+	}
+
+	var patterns []string
+	for _, index := range indices {
+		for _, p := range strings.Split(index, ",") {
+			if p = strings.TrimSpace(p); p != "" && !strings.HasPrefix(p, "-") {
+				patterns = append(patterns, p)
+			}
+		}
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	for _, target := range esqlIndexTargets(r.Query) {
+		// exclusions only narrow down the indices that are read
+		if strings.HasPrefix(target, "-") {
+			continue
+		}
+		if !indexCovered(target, patterns) {
+			return exp.DownstreamError(fmt.Errorf("ES|QL query reads index %q that is not part of the index pattern %q of the data source", target, strings.Join(patterns, ",")), false)
+		}
+	}
+	return nil
+}
+
+// indexCovered reports whether every index matched by target is matched by one of the
+// patterns. Wildcards in the target are matched by wildcards of the pattern only.
+func indexCovered(target string, patterns []string) bool {
+	for _, p := range patterns {
+		expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(p), `\*`, ".*") + "$"
+		if ok, _ := regexp.MatchString(expr, target); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// esqlIndexTargets returns the indices read by the FROM and TS source commands and by the
+// LOOKUP JOIN processing commands of an ES|QL query.
+func esqlIndexTargets(query string) []string {
+	var targets []string
+	for i, command := range esqlCommands(query) {
+		tokens := esqlTokens(command)
+		if len(tokens) == 0 {
+			continue
+		}
+		keyword := strings.ToUpper(tokens[0])
+		switch {
+		case i == 0 && (keyword == "FROM" || keyword == "TS"):
+			for _, token := range tokens[1:] {
+				if strings.EqualFold(token, "METADATA") {
+					break
+				}
+				if token != "," {
+					targets = append(targets, token)
+				}
+			}
+		case keyword == "LOOKUP" && len(tokens) > 2 && strings.EqualFold(tokens[1], "JOIN"):
+			targets = append(targets, tokens[2])
+		}
+	}
+	return targets
+}
+
+// esqlCommands splits an ES|QL query into its commands, dropping comments.
+func esqlCommands(query string) []string {
+	var commands []string
+	var current strings.Builder
+	for i := 0; i < len(query); {
+		switch {
+		case query[i] == '"':
+			_, n := readEsqlString(query[i:])
+			current.WriteString(query[i : i+n])
+			i += n
+		case strings.HasPrefix(query[i:], "//"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end == -1 {
+				end = len(query) - i
+			}
+			current.WriteByte(' ')
+			i += end
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end == -1 {
+				end = len(query) - i - 4
+			}
+			current.WriteByte(' ')
+			i += end + 4
+		case query[i] == '|':
+			commands = append(commands, current.String())
+			current.Reset()
+			i++
+		default:
+			current.WriteByte(query[i])
+			i++
+		}
+	}
+	return append(commands, current.String())
+}
+
+// esqlTokens splits a command into words, commas and unquoted strings.
+func esqlTokens(command string) []string {
+	var tokens []string
+	for i := 0; i < len(command); {
+		switch c := command[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == ',':
+			tokens = append(tokens, ",")
+			i++
+		case c == '"':
+			s, n := readEsqlString(command[i:])
+			tokens = append(tokens, s)
+			i += n
+		default:
+			j := i
+			for j < len(command) && !strings.ContainsRune(" \t\n\r,\"", rune(command[j])) {
+				j++
+			}
+			tokens = append(tokens, command[i:j])
+			i = j
+		}
+	}
+	return tokens
+}
+
+// readEsqlString reads the quoted string at the start of s and returns its content and the
+// number of bytes it spans, including the quotes.
+func readEsqlString(s string) (string, int) {
+	if strings.HasPrefix(s, `"""`) {
+		end := strings.Index(s[3:], `"""`)
+		if end == -1 {
+			return s[3:], len(s)
+		}
+		return s[3 : 3+end], end + 6
+	}
+	var content strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				content.WriteByte(s[i])
+			}
+		case '"':
+			return content.String(), i + 1
+		default:
+			content.WriteByte(s[i])
+		}
+	}
+	return content.String(), len(s)
+}
@@ -0,0 +1,82 @@
+package es
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEsqlIndexTargets(t *testing.T) {
+	testCases := []struct {
+		name    string
+		query   string
+		targets []string
+	}{
+		{name: "single index", query: "FROM logs-* | STATS count = COUNT(*)", targets: []string{"logs-*"}},
+		{name: "several indices", query: "from logs-a, logs-b,logs-c | LIMIT 10", targets: []string{"logs-a", "logs-b", "logs-c"}},
+		{name: "metadata", query: "FROM logs-* METADATA _id, _index | KEEP _id", targets: []string{"logs-*"}},
+		{name: "quoted index", query: `FROM "logs|a", """logs-b""" | LIMIT 1`, targets: []string{"logs|a", "logs-b"}},
+		{name: "time series source", query: "TS metrics-* | STATS max(cpu)", targets: []string{"metrics-*"}},
+		{name: "lookup join", query: "FROM logs-* | LOOKUP JOIN hosts ON host.name", targets: []string{"logs-*", "hosts"}},
+		{name: "comments", query: "// FROM secrets\nFROM /* secrets */ logs-* | WHERE message == \"FROM secrets | x\"", targets: []string{"logs-*"}},
+		{name: "row", query: "ROW a = 1", targets: nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.targets, esqlIndexTargets(tc.query))
+		})
+	}
+}
+
+func TestClient_CheckEsqlIndices(t *testing.T) {
+	newClient := func(t *testing.T, interval, database string) *baseClientImpl {
+		t.Helper()
+		c, err := NewClient(context.Background(), &DatasourceInfo{Interval: interval, Database: database}, log.New())
+		require.NoError(t, err)
+		return c.(*baseClientImpl)
+	}
+
+	t.Run("accepts indices of the index pattern", func(t *testing.T) {
+		c := newClient(t, noInterval, "logs-*,metrics")
+		for _, query := range []string{
+			"FROM logs-* | LIMIT 1",
+			"FROM logs-app-*, metrics | LIMIT 1",
+			"FROM logs-*, -logs-secret | LIMIT 1",
+			"ROW a = 1",
+		} {
+			require.NoError(t, c.checkEsqlIndices(&EsqlRequest{Query: query}), query)
+		}
+	})
+
+	t.Run("rejects indices outside of the index pattern", func(t *testing.T) {
+		c := newClient(t, noInterval, "logs-*")
+		for _, query := range []string{
+			"FROM secrets | LIMIT 1",
+			"FROM logs-*, * | LIMIT 1",
+			"FROM l* | LIMIT 1",
+			"FROM remote:logs-* | LIMIT 1",
+			"FROM logs-* | LOOKUP JOIN secrets ON id",
+		} {
+			require.ErrorContains(t, c.checkEsqlIndices(&EsqlRequest{Query: query}), "is not part of the index pattern", query)
+		}
+	})
+
+	t.Run("uses the indices of the time range for interval patterns", func(t *testing.T) {
+		c := newClient(t, "Daily", "[logs-]YYYY.MM.DD")
+		timeRange := backend.TimeRange{
+			From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC),
+		}
+		require.NoError(t, c.checkEsqlIndices(&EsqlRequest{Query: "FROM logs-2024.01.01, logs-2024.01.02 | LIMIT 1", TimeRange: timeRange}))
+		require.Error(t, c.checkEsqlIndices(&EsqlRequest{Query: "FROM logs-2024.01.03 | LIMIT 1", TimeRange: timeRange}))
+	})
+
+	t.Run("does not restrict data sources without an index pattern", func(t *testing.T) {
+		c := newClient(t, noInterval, "")
+		require.NoError(t, c.checkEsqlIndices(&EsqlRequest{Query: "FROM anything | LIMIT 1"}))
+	})
+}
@@ -67,6 +67,26 @@ type MultiSearchResponse struct {
 	Responses []*SearchResponse `json:"responses"`
 }
 
+// EsqlRequest represents an ES|QL query request
+type EsqlRequest struct {
+	Query     string            `json:"query"`
+	Columnar  bool              `json:"columnar"`
+	TimeRange backend.TimeRange `json:"-"`
+}
+
+// EsqlColumn represents a column of an ES|QL query response
+type EsqlColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// EsqlResponse represents an ES|QL query response. When the request is columnar
+// Values holds one slice per column, otherwise one slice per row.
+type EsqlResponse struct {
+	Columns []EsqlColumn `json:"columns"`
+	Values  [][]any      `json:"values"`
+}
+
 // Query represents a query
 type Query struct {
 	Bool *BoolQuery `json:"bool"`
@@ -140,6 +160,24 @@ func (f *RangeFilter) MarshalJSON() ([]byte, error) {
 	return json.Marshal(root)
 }
 
+// AnyFilter represents a search filter matching documents that match at least one of its filters
+type AnyFilter struct {
+	Filter
+	Filters []Filter
+}
+
+// MarshalJSON returns the JSON encoding of the any filter.
+func (f *AnyFilter) MarshalJSON() ([]byte, error) {
+	root := map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should":               f.Filters,
+			"minimum_should_match": 1,
+		},
+	}
+
+	return json.Marshal(root)
+}
+
 // Aggregation represents an aggregation
 type Aggregation interface{}
 
@@ -245,6 +283,13 @@ type TermsAggregation struct {
 	Missing     *string                `json:"missing,omitempty"`
 }
 
+// CompositeAggregation represents a composite aggregation
+type CompositeAggregation struct {
+	Size    int              `json:"size"`
+	Sources []map[string]any `json:"sources"`
+	After   map[string]any   `json:"after,omitempty"`
+}
+
 // NestedAggregation represents a nested aggregation
 type NestedAggregation struct {
 	Path string `json:"path"`
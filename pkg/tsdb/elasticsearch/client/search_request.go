@@ -287,6 +287,25 @@ func (b *FilterQueryBuilder) AddDateRangeFilter(timeField string, lte, gte int64
 	return b
 }
 
+// AddAnyDateRangeFilter adds a new filter matching documents where any of the time fields is in the time range
+func (b *FilterQueryBuilder) AddAnyDateRangeFilter(timeFields []string, lte, gte int64, format string) *FilterQueryBuilder {
+	if len(timeFields) == 1 {
+		return b.AddDateRangeFilter(timeFields[0], lte, gte, format)
+	}
+
+	filter := &AnyFilter{}
+	for _, timeField := range timeFields {
+		filter.Filters = append(filter.Filters, &RangeFilter{
+			Key:    timeField,
+			Lte:    lte,
+			Gte:    gte,
+			Format: format,
+		})
+	}
+	b.filters = append(b.filters, filter)
+	return b
+}
+
 // AddQueryStringFilter adds a new query string filter
 func (b *FilterQueryBuilder) AddQueryStringFilter(querystring string, analyseWildcard bool) *FilterQueryBuilder {
 	if len(strings.TrimSpace(querystring)) == 0 {
@@ -305,6 +324,7 @@ type AggBuilder interface {
 	Histogram(key, field string, fn func(a *HistogramAgg, b AggBuilder)) AggBuilder
 	DateHistogram(key, field string, fn func(a *DateHistogramAgg, b AggBuilder)) AggBuilder
 	Terms(key, field string, fn func(a *TermsAggregation, b AggBuilder)) AggBuilder
+	Composite(key string, fn func(a *CompositeAggregation, b AggBuilder)) AggBuilder
 	Nested(key, path string, fn func(a *NestedAggregation, b AggBuilder)) AggBuilder
 	Filters(key string, fn func(a *FiltersAggregation, b AggBuilder)) AggBuilder
 	GeoHashGrid(key, field string, fn func(a *GeoHashGridAggregation, b AggBuilder)) AggBuilder
@@ -416,6 +436,24 @@ func (b *aggBuilderImpl) Terms(key, field string, fn func(a *TermsAggregation, b
 	return b
 }
 
+func (b *aggBuilderImpl) Composite(key string, fn func(a *CompositeAggregation, b AggBuilder)) AggBuilder {
+	innerAgg := &CompositeAggregation{}
+	aggDef := newAggDef(key, &aggContainer{
+		Type:        "composite",
+		Aggregation: innerAgg,
+	})
+
+	if fn != nil {
+		builder := newAggBuilder()
+		aggDef.builders = append(aggDef.builders, builder)
+		fn(innerAgg, builder)
+	}
+
+	b.aggDefs = append(b.aggDefs, aggDef)
+
+	return b
+}
+
 func (b *aggBuilderImpl) Nested(key, field string, fn func(a *NestedAggregation, b AggBuilder)) AggBuilder {
 	innerAgg := &NestedAggregation{
 		Path: field,
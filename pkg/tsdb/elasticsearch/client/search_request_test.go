@@ -110,6 +110,28 @@ func TestSearchRequest(t *testing.T) {
 		})
 	})
 
+	t.Run("When adding a date range filter on several fields", func(t *testing.T) {
+		b := setup()
+		b.Query().Bool().Filter().AddAnyDateRangeFilter([]string{timeField, "end"}, 10, 5, DateFormatEpochMS)
+
+		sr, err := b.Build()
+		require.Nil(t, err)
+
+		t.Run("When marshal to JSON should generate correct json", func(t *testing.T) {
+			body, err := json.Marshal(sr)
+			require.Nil(t, err)
+			json, err := simplejson.NewJson(body)
+			require.Nil(t, err)
+
+			anyFilter := json.GetPath("query", "bool", "filter", "bool")
+			require.Equal(t, 1, anyFilter.Get("minimum_should_match").MustInt())
+			require.Len(t, anyFilter.Get("should").MustArray(), 2)
+			endRangeFilter := anyFilter.Get("should").GetIndex(1).GetPath("range", "end")
+			require.Equal(t, int64(5), endRangeFilter.Get("gte").MustInt64())
+			require.Equal(t, int64(10), endRangeFilter.Get("lte").MustInt64())
+		})
+	})
+
 	t.Run("When adding doc value field", func(t *testing.T) {
 		b := setup()
 		b.AddDocValueField(timeField)
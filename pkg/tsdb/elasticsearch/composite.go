@@ -0,0 +1,285 @@
+package elasticsearch
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	es "github.com/grafana/grafana/pkg/tsdb/elasticsearch/client"
+)
+
+const (
+	// compositePageSize is the number of buckets requested per page of a composite aggregation
+	compositePageSize = 1000
+	// defaultCompositeMaxBuckets is the bucket limit of composite aggregations when the data source doesn't set one
+	defaultCompositeMaxBuckets = 10000
+)
+
+var compositeOrderMetricRegex = regexp.MustCompile(`^(\d+)`)
+
+// isCompositeTermsAgg reports whether the terms aggregation should fetch all of its buckets
+// with a paginated composite aggregation instead of the top `size` buckets.
+func isCompositeTermsAgg(bucketAgg *BucketAgg) bool {
+	if bucketAgg.Type != termsType {
+		return false
+	}
+	if composite, err := bucketAgg.Settings.Get("composite").Bool(); err == nil {
+		return composite
+	}
+	return bucketAgg.Settings.Get("composite").MustString() == "true"
+}
+
+// compositeTermsAgg returns the terms aggregation of the query that is paginated, if any.
+func compositeTermsAgg(q *Query) *BucketAgg {
+	if isLogsQuery(q) || isDocumentQuery(q) || len(q.BucketAggs) == 0 || !isCompositeTermsAgg(q.BucketAggs[0]) {
+		return nil
+	}
+	return q.BucketAggs[0]
+}
+
+// compositeMaxBuckets returns the bucket limit of the query, which can't exceed the limit of the data source.
+func compositeMaxBuckets(bucketAgg *BucketAgg, limit int) int {
+	maxBuckets, err := bucketAgg.Settings.Get("maxBuckets").Int()
+	if err != nil || maxBuckets <= 0 {
+		maxBuckets = stringToIntWithDefaultValue(bucketAgg.Settings.Get("maxBuckets").MustString(), limit)
+	}
+	if maxBuckets <= 0 || maxBuckets > limit {
+		return limit
+	}
+	return maxBuckets
+}
+
+// compositeTermsSize returns the number of top terms to keep once all buckets are fetched, 0 keeps all of them.
+func compositeTermsSize(bucketAgg *BucketAgg) int {
+	if size, err := bucketAgg.Settings.Get("size").Int(); err == nil {
+		return size
+	}
+	return stringToIntWithDefaultValue(bucketAgg.Settings.Get("size").MustString(), 0)
+}
+
+func compositePageSizeFor(maxBuckets int) int {
+	// request one bucket more than the limit to know whether the results are truncated
+	return min(compositePageSize, maxBuckets+1)
+}
+
+// compositeMaxPages returns the number of pages needed to exceed the bucket limit, no more pages are requested.
+func compositeMaxPages(maxBuckets int) int {
+	return maxBuckets/compositePageSize + 1
+}
+
+func addCompositeTermsAgg(aggBuilder es.AggBuilder, bucketAgg *BucketAgg, metrics []*MetricAgg, after map[string]any, maxBuckets int) es.AggBuilder {
+	aggBuilder.Composite(bucketAgg.ID, func(a *es.CompositeAggregation, b es.AggBuilder) {
+		source := map[string]any{"field": bucketAgg.Field}
+		orderBy := bucketAgg.Settings.Get("orderBy").MustString()
+		if orderBy == "_term" || orderBy == "_key" {
+			source["order"] = bucketAgg.Settings.Get("order").MustString("desc")
+		}
+		if _, err := bucketAgg.Settings.Get("missing").String(); err == nil {
+			source["missing_bucket"] = true
+		}
+
+		a.Sources = []map[string]any{{bucketAgg.ID: map[string]any{"terms": source}}}
+		a.Size = compositePageSizeFor(maxBuckets)
+		a.After = after
+
+		// composite aggregations are always sorted by key, ordering by a metric is done once all pages are fetched
+		if metricID := compositeOrderMetricRegex.FindString(orderBy); metricID != "" {
+			for _, m := range metrics {
+				if m.ID == metricID && m.Type != countType {
+					b.Metric(m.ID, m.Type, m.Field, nil)
+					break
+				}
+			}
+		}
+
+		aggBuilder = b
+	})
+
+	return aggBuilder
+}
+
+// compositeResult collects the pages of the composite aggregation of a single query.
+type compositeResult struct {
+	query     *Query
+	bucketAgg *BucketAgg
+	response  *es.SearchResponse
+	buckets   []any
+	afterKey  map[string]any
+	pages     int
+	hasMore   bool
+}
+
+func (r *compositeResult) addPage(res *es.SearchResponse) {
+	agg, _ := res.Aggregations[r.bucketAgg.ID].(map[string]any)
+	page, _ := agg["buckets"].([]any)
+	r.buckets = append(r.buckets, page...)
+	r.afterKey, _ = agg["after_key"].(map[string]any)
+	r.pages++
+	maxBuckets := r.query.compositeMaxBuckets
+	r.hasMore = r.afterKey != nil && len(page) >= compositePageSizeFor(maxBuckets) && len(r.buckets) <= maxBuckets &&
+		r.pages < compositeMaxPages(maxBuckets)
+}
+
+// paginateCompositeAggs follows the after_key of paginated terms aggregations until all buckets
+// are fetched or the bucket limit is exceeded, which bounds the number of pages. The pages are merged into the first response and
+// converted to the format of a terms aggregation, so that they are parsed like any other terms
+// aggregation. It returns the bucket limit of every query whose buckets were truncated.
+func (e *elasticsearchDataQuery) paginateCompositeAggs(queries []*Query, responses []*es.SearchResponse) (map[string]int, error) {
+	results := make([]*compositeResult, 0)
+	for i, q := range queries {
+		bucketAgg := compositeTermsAgg(q)
+		if bucketAgg == nil || i >= len(responses) || responses[i] == nil || responses[i].Error != nil {
+			continue
+		}
+		r := &compositeResult{query: q, bucketAgg: bucketAgg, response: responses[i]}
+		r.addPage(responses[i])
+		results = append(results, r)
+	}
+
+	for {
+		pending := make([]*compositeResult, 0, len(results))
+		for _, r := range results {
+			if r.hasMore {
+				pending = append(pending, r)
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+
+		ms := e.client.MultiSearch()
+		for _, r := range pending {
+			r.query.compositeAfter = r.afterKey
+			from := r.query.TimeRange.From.UnixNano() / int64(time.Millisecond)
+			to := r.query.TimeRange.To.UnixNano() / int64(time.Millisecond)
+			if err := e.processQuery(r.query, ms, from, to); err != nil {
+				return nil, err
+			}
+		}
+		req, err := ms.Build()
+		if err != nil {
+			return nil, err
+		}
+
+		e.logger.Debug("Fetching next page of composite aggregations", "queriesLength", len(pending), "stage", es.StageDatabaseRequest)
+		res, err := e.client.ExecuteMultisearch(req)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, r := range pending {
+			r.hasMore = false
+			if i >= len(res.Responses) || res.Responses[i] == nil {
+				continue
+			}
+			if res.Responses[i].Error != nil {
+				// report the error of the failing page instead of partial buckets
+				r.response.Error = res.Responses[i].Error
+				continue
+			}
+			r.addPage(res.Responses[i])
+		}
+	}
+
+	truncated := map[string]int{}
+	for _, r := range results {
+		if r.response.Error != nil {
+			continue
+		}
+		maxBuckets := r.query.compositeMaxBuckets
+		if len(r.buckets) > maxBuckets {
+			truncated[r.query.RefID] = maxBuckets
+		}
+		r.response.Aggregations[r.bucketAgg.ID] = map[string]any{
+			"buckets": compositeBucketsToTerms(r.bucketAgg, r.query.Metrics, r.buckets, maxBuckets),
+		}
+	}
+	return truncated, nil
+}
+
+// compositeBucketsToTerms replaces the composite key of every bucket by the term, applies the
+// minimum document count, ordering and size of the terms aggregation and keeps at most maxBuckets buckets.
+// The buckets are ordered before they are truncated, but when the bucket limit was exceeded only the
+// buckets of the first terms in term order were fetched, so the top terms overall may be missing.
+func compositeBucketsToTerms(bucketAgg *BucketAgg, metrics []*MetricAgg, buckets []any, maxBuckets int) []any {
+	missing, missingErr := bucketAgg.Settings.Get("missing").String()
+	minDocCount := bucketAgg.Settings.Get("min_doc_count").MustInt(0)
+	terms := make([]any, 0, len(buckets))
+	for _, b := range buckets {
+		bucket, ok := b.(map[string]any)
+		if !ok {
+			continue
+		}
+		if minDocCount > 1 && simplejson.NewFromAny(bucket).Get("doc_count").MustInt(0) < minDocCount {
+			continue
+		}
+		key, _ := bucket["key"].(map[string]any)
+		term := key[bucketAgg.ID]
+		if term == nil && missingErr == nil {
+			term = missing
+		}
+		bucket["key"] = term
+		terms = append(terms, bucket)
+	}
+
+	orderBy := bucketAgg.Settings.Get("orderBy").MustString()
+	desc := bucketAgg.Settings.Get("order").MustString("desc") == "desc"
+	var sortValue func(bucket *simplejson.Json) float64
+	if orderBy == "_count" {
+		sortValue = func(bucket *simplejson.Json) float64 {
+			return bucket.Get("doc_count").MustFloat64()
+		}
+	} else if metricID := compositeOrderMetricRegex.FindString(orderBy); metricID != "" {
+		for _, m := range metrics {
+			if m.ID != metricID {
+				continue
+			}
+			if m.Type == countType {
+				sortValue = func(bucket *simplejson.Json) float64 {
+					return bucket.Get("doc_count").MustFloat64()
+				}
+			} else {
+				sortValue = func(bucket *simplejson.Json) float64 {
+					return bucket.GetPath(metricID, "value").MustFloat64()
+				}
+			}
+			break
+		}
+	}
+	if sortValue != nil {
+		sort.SliceStable(terms, func(i, j int) bool {
+			a, b := sortValue(simplejson.NewFromAny(terms[i])), sortValue(simplejson.NewFromAny(terms[j]))
+			if desc {
+				return a > b
+			}
+			return a < b
+		})
+	}
+
+	limit := maxBuckets
+	if size := compositeTermsSize(bucketAgg); size > 0 && size < limit {
+		limit = size
+	}
+	if len(terms) > limit {
+		terms = terms[:limit]
+	}
+	return terms
+}
+
+func addCompositeLimitNotices(result *backend.QueryDataResponse, truncated map[string]int) {
+	for refID, maxBuckets := range truncated {
+		res, ok := result.Responses[refID]
+		if !ok || len(res.Frames) == 0 {
+			continue
+		}
+		res.Frames[0].AppendNotices(data.Notice{
+			Severity: data.NoticeSeverityWarning,
+			Text:     fmt.Sprintf("Terms aggregation returned more than %d buckets, only %d are shown. The buckets were fetched in term order, so the order applies to the fetched buckets only and the top terms may be missing. Increase the bucket limit to include all terms.", maxBuckets, maxBuckets),
+		})
+	}
+}
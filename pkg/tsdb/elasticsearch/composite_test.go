@@ -0,0 +1,215 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	es "github.com/grafana/grafana/pkg/tsdb/elasticsearch/client"
+)
+
+// compositePage returns a response with count buckets of the composite aggregation "2", named
+// after the position of the term starting at first.
+func compositePage(t *testing.T, first, count int, afterKey bool) *es.MultiSearchResponse {
+	t.Helper()
+	buckets := make([]any, 0, count)
+	for i := first; i < first+count; i++ {
+		buckets = append(buckets, map[string]any{
+			"key":       map[string]any{"2": fmt.Sprintf("host-%04d", i)},
+			"doc_count": 1,
+			"3":         map[string]any{"buckets": []any{map[string]any{"key": 1526406600000, "doc_count": 1}}},
+		})
+	}
+	agg := map[string]any{"buckets": buckets}
+	if afterKey {
+		agg["after_key"] = map[string]any{"2": fmt.Sprintf("host-%04d", first+count-1)}
+	}
+
+	// round trip through JSON to get the types of a decoded response
+	body, err := json.Marshal(es.MultiSearchResponse{Responses: []*es.SearchResponse{{Aggregations: map[string]any{"2": agg}}}})
+	require.NoError(t, err)
+	var res es.MultiSearchResponse
+	require.NoError(t, json.Unmarshal(body, &res))
+	return &res
+}
+
+func TestCompositeTermsAggregation(t *testing.T) {
+	from := time.Date(2018, 5, 15, 17, 50, 0, 0, time.UTC)
+	to := time.Date(2018, 5, 15, 17, 55, 0, 0, time.UTC)
+
+	query := func(settings string) string {
+		return `{
+			"metrics": [{ "type": "count", "id": "1" }],
+			"bucketAggs": [
+				{ "type": "terms", "id": "2", "field": "host", "settings": ` + settings + ` },
+				{ "type": "date_histogram", "id": "3", "field": "@timestamp", "settings": { "interval": "1m" } }
+			]
+		}`
+	}
+
+	t.Run("builds a composite aggregation for the first terms aggregation", func(t *testing.T) {
+		c := newFakeClient()
+		_, err := executeElasticsearchDataQuery(c, query(`{ "composite": true, "orderBy": "_term", "order": "asc", "missing": "none" }`), from, to)
+		require.NoError(t, err)
+
+		sr := c.multisearchRequests[0].Requests[0]
+		require.Equal(t, "composite", sr.Aggs[0].Aggregation.Type)
+		compositeAgg := sr.Aggs[0].Aggregation.Aggregation.(*es.CompositeAggregation)
+		require.Equal(t, compositePageSize, compositeAgg.Size)
+		require.Nil(t, compositeAgg.After)
+		require.Equal(t, []map[string]any{{"2": map[string]any{"terms": map[string]any{"field": "host", "order": "asc", "missing_bucket": true}}}}, compositeAgg.Sources)
+		require.Equal(t, "date_histogram", sr.Aggs[0].Aggregation.Aggs[0].Aggregation.Type)
+	})
+
+	t.Run("keeps terms aggregations without the composite setting", func(t *testing.T) {
+		c := newFakeClient()
+		_, err := executeElasticsearchDataQuery(c, query(`{ "size": "5" }`), from, to)
+		require.NoError(t, err)
+		require.Equal(t, "terms", c.multisearchRequests[0].Requests[0].Aggs[0].Aggregation.Type)
+	})
+
+	t.Run("follows the after key and merges the pages", func(t *testing.T) {
+		c := newFakeClient()
+		c.multiSearchPages = []*es.MultiSearchResponse{
+			compositePage(t, 0, compositePageSize, true),
+			compositePage(t, compositePageSize, 5, false),
+		}
+
+		res, err := executeElasticsearchDataQuery(c, query(`{ "composite": true }`), from, to)
+		require.NoError(t, err)
+		require.Len(t, c.multisearchRequests, 2)
+		compositeAgg := c.multisearchRequests[1].Requests[0].Aggs[0].Aggregation.Aggregation.(*es.CompositeAggregation)
+		require.Equal(t, map[string]any{"2": "host-0999"}, compositeAgg.After)
+
+		frames := res.Responses["A"].Frames
+		require.Len(t, frames, compositePageSize+5)
+		require.Equal(t, "host-1004", frames[len(frames)-1].Name)
+		for _, frame := range frames {
+			require.Nil(t, frame.Meta.Notices)
+		}
+	})
+
+	t.Run("stops at the bucket limit with a notice", func(t *testing.T) {
+		c := newFakeClient()
+		c.multiSearchPages = []*es.MultiSearchResponse{compositePage(t, 0, 3, true)}
+
+		res, err := executeElasticsearchDataQuery(c, query(`{ "composite": true, "maxBuckets": "2" }`), from, to)
+		require.NoError(t, err)
+		require.Len(t, c.multisearchRequests, 1)
+		require.Equal(t, 3, c.multisearchRequests[0].Requests[0].Aggs[0].Aggregation.Aggregation.(*es.CompositeAggregation).Size)
+
+		frames := res.Responses["A"].Frames
+		require.Len(t, frames, 2)
+		require.Len(t, frames[0].Meta.Notices, 1)
+		require.Equal(t, data.NoticeSeverityWarning, frames[0].Meta.Notices[0].Severity)
+		require.Contains(t, frames[0].Meta.Notices[0].Text, "more than 2 buckets")
+		require.Contains(t, frames[0].Meta.Notices[0].Text, "the top terms may be missing")
+	})
+
+	t.Run("clamps the bucket limit to the limit of the data source", func(t *testing.T) {
+		c := newFakeClient()
+		c.multiSearchPages = []*es.MultiSearchResponse{compositePage(t, 0, 3, true)}
+
+		dataRequest := backend.QueryDataRequest{Queries: []backend.DataQuery{{
+			JSON:      json.RawMessage(query(`{ "composite": true, "maxBuckets": "50000" }`)),
+			TimeRange: backend.TimeRange{From: from, To: to},
+			RefID:     "A",
+		}}}
+		dataQuery := newElasticsearchDataQuery(context.Background(), c, &dataRequest, log.New())
+		dataQuery.maxCompositeBuckets = 2
+		res, err := dataQuery.execute()
+		require.NoError(t, err)
+		require.Len(t, c.multisearchRequests, 1)
+		require.Equal(t, 3, c.multisearchRequests[0].Requests[0].Aggs[0].Aggregation.Aggregation.(*es.CompositeAggregation).Size)
+
+		frames := res.Responses["A"].Frames
+		require.Len(t, frames, 2)
+		require.Contains(t, frames[0].Meta.Notices[0].Text, "more than 2 buckets")
+	})
+
+	t.Run("keeps the top terms limited by size once all pages are fetched", func(t *testing.T) {
+		c := newFakeClient()
+		c.multiSearchPages = []*es.MultiSearchResponse{
+			compositePage(t, 0, compositePageSize, true),
+			compositePage(t, compositePageSize, 5, false),
+		}
+
+		res, err := executeElasticsearchDataQuery(c, query(`{ "composite": true, "size": "3", "orderBy": "_term", "order": "desc" }`), from, to)
+		require.NoError(t, err)
+		require.Len(t, c.multisearchRequests, 2)
+
+		frames := res.Responses["A"].Frames
+		require.Len(t, frames, 3)
+		for _, frame := range frames {
+			require.Nil(t, frame.Meta.Notices)
+		}
+	})
+
+	t.Run("returns the error of a failing page", func(t *testing.T) {
+		c := newFakeClient()
+		c.multiSearchPages = []*es.MultiSearchResponse{
+			compositePage(t, 0, compositePageSize, true),
+			{Responses: []*es.SearchResponse{{Error: map[string]any{"type": "search_phase_execution_exception", "reason": "too many buckets"}}}},
+		}
+
+		res, err := executeElasticsearchDataQuery(c, query(`{ "composite": true }`), from, to)
+		require.NoError(t, err)
+		require.Error(t, res.Responses["A"].Error)
+	})
+}
+
+func TestCompositeBucketsToTerms(t *testing.T) {
+	buckets := func() []any {
+		var b []any
+		require.NoError(t, json.Unmarshal([]byte(`[
+			{"key": {"2": "a"}, "doc_count": 1, "4": {"value": 30}},
+			{"key": {"2": null}, "doc_count": 5, "4": {"value": 10}},
+			{"key": {"2": "c"}, "doc_count": 3, "4": {"value": 20}}
+		]`), &b))
+		return b
+	}
+	keys := func(terms []any) []any {
+		out := make([]any, 0, len(terms))
+		for _, term := range terms {
+			out = append(out, term.(map[string]any)["key"])
+		}
+		return out
+	}
+	bucketAgg := func(settings map[string]any) *BucketAgg {
+		return &BucketAgg{ID: "2", Type: termsType, Field: "host", Settings: simplejson.NewFromAny(settings)}
+	}
+	metrics := []*MetricAgg{{ID: "1", Type: countType}, {ID: "4", Type: "avg", Field: "value"}}
+
+	t.Run("orders by document count", func(t *testing.T) {
+		terms := compositeBucketsToTerms(bucketAgg(map[string]any{"orderBy": "_count", "order": "desc", "missing": "unknown"}), metrics, buckets(), 10)
+		require.Equal(t, []any{"unknown", "c", "a"}, keys(terms))
+	})
+
+	t.Run("orders by metric", func(t *testing.T) {
+		terms := compositeBucketsToTerms(bucketAgg(map[string]any{"orderBy": "4", "order": "asc"}), metrics, buckets(), 10)
+		require.Equal(t, []any{nil, "c", "a"}, keys(terms))
+	})
+
+	t.Run("applies the size after ordering the buckets", func(t *testing.T) {
+		terms := compositeBucketsToTerms(bucketAgg(map[string]any{"orderBy": "4", "order": "desc", "size": "2"}), metrics, buckets(), 10)
+		require.Equal(t, []any{"a", "c"}, keys(terms))
+	})
+
+	t.Run("keeps key order and applies limit and minimum document count", func(t *testing.T) {
+		terms := compositeBucketsToTerms(bucketAgg(map[string]any{"orderBy": "_term", "min_doc_count": 2}), metrics, buckets(), 1)
+		require.Equal(t, []any{nil}, keys(terms))
+	})
+
+	t.Run("orders the buckets before applying the limit", func(t *testing.T) {
+		terms := compositeBucketsToTerms(bucketAgg(map[string]any{"orderBy": "_count", "order": "desc"}), metrics, buckets(), 2)
+		require.Equal(t, []any{nil, "c"}, keys(terms))
+	})
+}
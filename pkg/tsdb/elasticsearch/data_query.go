@@ -27,6 +27,8 @@ type elasticsearchDataQuery struct {
 	logger               log.Logger
 	ctx                  context.Context
 	keepLabelsInResponse bool
+	// maxCompositeBuckets is the highest bucket limit a paginated terms aggregation can set
+	maxCompositeBuckets int
 }
 
 var newElasticsearchDataQuery = func(ctx context.Context, client es.Client, req *backend.QueryDataRequest, logger log.Logger) *elasticsearchDataQuery {
@@ -41,6 +43,7 @@ var newElasticsearchDataQuery = func(ctx context.Context, client es.Client, req
 		// To maintain backward compatibility, it is necessary to keep labels in responses for alerting and expressions queries.
 		// Historically, these labels have been used in alerting rules and transformations.
 		keepLabelsInResponse: fromAlert || fromExpression,
+		maxCompositeBuckets:  defaultCompositeMaxBuckets,
 	}
 }
 
@@ -55,9 +58,31 @@ func (e *elasticsearchDataQuery) execute() (*backend.QueryDataResponse, error) {
 		return errorsource.AddPluginErrorToResponse(e.dataQueries[0].RefID, response, err), nil
 	}
 
+	// ES|QL queries are sent to their own endpoint and annotation queries are paginated on their own,
+	// all other queries are combined in a multisearch request
+	searchQueries := make([]*Query, 0, len(queries))
+	for _, q := range queries {
+		if q.QueryType == esqlQueryType {
+			response.Responses[q.RefID] = e.executeEsqlQuery(q)
+			continue
+		}
+		if q.QueryType == annotationQueryType {
+			response.Responses[q.RefID] = e.executeAnnotationQuery(q)
+			continue
+		}
+		searchQueries = append(searchQueries, q)
+	}
+	if len(searchQueries) == 0 {
+		return response, nil
+	}
+	queries = searchQueries
+
 	ms := e.client.MultiSearch()
 
 	for _, q := range queries {
+		if bucketAgg := compositeTermsAgg(q); bucketAgg != nil {
+			q.compositeMaxBuckets = compositeMaxBuckets(bucketAgg, e.maxCompositeBuckets)
+		}
 		from := q.TimeRange.From.UnixNano() / int64(time.Millisecond)
 		to := q.TimeRange.To.UnixNano() / int64(time.Millisecond)
 		if err := e.processQuery(q, ms, from, to); err != nil {
@@ -81,7 +106,20 @@ func (e *elasticsearchDataQuery) execute() (*backend.QueryDataResponse, error) {
 		return errorsource.AddErrorToResponse(e.dataQueries[0].RefID, response, err), nil
 	}
 
-	return parseResponse(e.ctx, res.Responses, queries, e.client.GetConfiguredFields(), e.keepLabelsInResponse, e.logger)
+	truncated, err := e.paginateCompositeAggs(queries, res.Responses)
+	if err != nil {
+		return errorsource.AddErrorToResponse(e.dataQueries[0].RefID, response, err), nil
+	}
+
+	result, err := parseResponse(e.ctx, res.Responses, queries, e.client.GetConfiguredFields(), e.keepLabelsInResponse, e.logger)
+	if err != nil {
+		return result, err
+	}
+	addCompositeLimitNotices(result, truncated)
+	for refID, r := range response.Responses {
+		result.Responses[refID] = r
+	}
+	return result, nil
 }
 
 func (e *elasticsearchDataQuery) processQuery(q *Query, ms *es.MultiSearchRequestBuilder, from, to int64) error {
@@ -405,10 +443,15 @@ func processTimeSeriesQuery(q *Query, b *es.SearchRequestBuilder, from, to int64
 	aggBuilder := b.Agg()
 	// Process buckets
 	// iterate backwards to create aggregations bottom-down
-	for _, bucketAgg := range q.BucketAggs {
+	for i, bucketAgg := range q.BucketAggs {
 		bucketAgg.Settings = simplejson.NewFromAny(
 			bucketAgg.generateSettingsForDSL(),
 		)
+		// composite aggregations can't have a bucket aggregation as parent, so only the first terms aggregation is paginated
+		if i == 0 && isCompositeTermsAgg(bucketAgg) {
+			aggBuilder = addCompositeTermsAgg(aggBuilder, bucketAgg, q.Metrics, q.compositeAfter, q.compositeMaxBuckets)
+			continue
+		}
 		switch bucketAgg.Type {
 		case dateHistType:
 			aggBuilder = addDateHistogramAgg(aggBuilder, bucketAgg, from, to, defaultTimeField)
@@ -1802,9 +1802,14 @@ func TestSettingsCasting(t *testing.T) {
 type fakeClient struct {
 	configuredFields    es.ConfiguredFields
 	multiSearchResponse *es.MultiSearchResponse
+	// multiSearchPages, when set, are returned one per multisearch request instead of multiSearchResponse
+	multiSearchPages    []*es.MultiSearchResponse
 	multiSearchError    error
 	builder             *es.MultiSearchRequestBuilder
 	multisearchRequests []*es.MultiSearchRequest
+	esqlResponse        *es.EsqlResponse
+	esqlError           error
+	esqlRequests        []*es.EsqlRequest
 }
 
 func newFakeClient() *fakeClient {
@@ -1827,9 +1832,19 @@ func (c *fakeClient) GetConfiguredFields() es.ConfiguredFields {
 
 func (c *fakeClient) ExecuteMultisearch(r *es.MultiSearchRequest) (*es.MultiSearchResponse, error) {
 	c.multisearchRequests = append(c.multisearchRequests, r)
+	if len(c.multiSearchPages) > 0 {
+		page := c.multiSearchPages[0]
+		c.multiSearchPages = c.multiSearchPages[1:]
+		return page, c.multiSearchError
+	}
 	return c.multiSearchResponse, c.multiSearchError
 }
 
+func (c *fakeClient) ExecuteEsql(r *es.EsqlRequest) (*es.EsqlResponse, error) {
+	c.esqlRequests = append(c.esqlRequests, r)
+	return c.esqlResponse, c.esqlError
+}
+
 func (c *fakeClient) MultiSearch() *es.MultiSearchRequestBuilder {
 	c.builder = es.NewMultiSearchRequestBuilder()
 	return c.builder
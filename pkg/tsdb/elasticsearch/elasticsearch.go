@@ -70,6 +70,9 @@ func queryData(ctx context.Context, req *backend.QueryDataRequest, dsInfo *es.Da
 		return &backend.QueryDataResponse{}, err
 	}
 	query := newElasticsearchDataQuery(ctx, client, req, logger)
+	if dsInfo.MaxCompositeBuckets > 0 {
+		query.maxCompositeBuckets = dsInfo.MaxCompositeBuckets
+	}
 	return query.execute()
 }
 
@@ -151,6 +154,21 @@ func newInstanceSettings(httpClientProvider *httpclient.Provider) datasource.Ins
 			maxConcurrentShardRequests = defaultMaxConcurrentShardRequests
 		}
 
+		var maxCompositeBuckets int
+
+		switch v := jsonData["maxCompositeBuckets"].(type) {
+		case float64:
+			maxCompositeBuckets = int(v)
+		case string:
+			maxCompositeBuckets = stringToIntWithDefaultValue(v, defaultCompositeMaxBuckets)
+		default:
+			maxCompositeBuckets = defaultCompositeMaxBuckets
+		}
+
+		if maxCompositeBuckets <= 0 {
+			maxCompositeBuckets = defaultCompositeMaxBuckets
+		}
+
 		includeFrozen, ok := jsonData["includeFrozen"].(bool)
 		if !ok {
 			includeFrozen = false
@@ -168,6 +186,7 @@ func newInstanceSettings(httpClientProvider *httpclient.Provider) datasource.Ins
 			HTTPClient:                 httpCli,
 			Database:                   index,
 			MaxConcurrentShardRequests: maxConcurrentShardRequests,
+			MaxCompositeBuckets:        maxCompositeBuckets,
 			ConfiguredFields:           configuredFields,
 			Interval:                   interval,
 			IncludeFrozen:              includeFrozen,
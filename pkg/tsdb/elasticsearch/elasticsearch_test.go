@@ -15,6 +15,7 @@ import (
 type datasourceInfo struct {
 	TimeField                  any    `json:"timeField"`
 	MaxConcurrentShardRequests any    `json:"maxConcurrentShardRequests,omitempty"`
+	MaxCompositeBuckets        any    `json:"maxCompositeBuckets,omitempty"`
 	Interval                   string `json:"interval"`
 }
 
@@ -193,6 +194,34 @@ func TestNewInstanceSettings(t *testing.T) {
 	})
 }
 
+func TestNewInstanceSettingsMaxCompositeBuckets(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected int
+	}{
+		{name: "no maxCompositeBuckets", value: nil, expected: defaultCompositeMaxBuckets},
+		{name: "number maxCompositeBuckets", value: 500, expected: 500},
+		{name: "string maxCompositeBuckets", value: "500", expected: 500},
+		{name: "negative maxCompositeBuckets", value: -10, expected: defaultCompositeMaxBuckets},
+		{name: "invalid maxCompositeBuckets", value: "invalid", expected: defaultCompositeMaxBuckets},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settingsJSON, err := json.Marshal(datasourceInfo{TimeField: "@timestamp", MaxCompositeBuckets: tt.value})
+			require.NoError(t, err)
+
+			dsSettings := backend.DataSourceInstanceSettings{
+				JSONData: json.RawMessage(settingsJSON),
+			}
+
+			instance, err := newInstanceSettings(httpclient.NewProvider())(context.Background(), dsSettings)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, instance.(es.DatasourceInfo).MaxCompositeBuckets)
+		})
+	}
+}
+
 func TestCreateElasticsearchURL(t *testing.T) {
 	tt := []struct {
 		name     string
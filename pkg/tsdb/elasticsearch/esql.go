@@ -0,0 +1,255 @@
+package elasticsearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/grafana/grafana-plugin-sdk-go/experimental/errorsource"
+
+	es "github.com/grafana/grafana/pkg/tsdb/elasticsearch/client"
+)
+
+const esqlQueryType = "esql"
+
+var esqlMacroRegexp = regexp.MustCompile(`\$__(timeFilter|timeFrom|timeTo|interval_ms|interval)\b(?:\(([^)]*)\))?`)
+
+func (e *elasticsearchDataQuery) executeEsqlQuery(q *Query) backend.DataResponse {
+	timeField := e.client.GetConfiguredFields().TimeField
+	query, err := interpolateEsqlMacros(q, timeField)
+	if err != nil {
+		return errorsource.Response(errorsource.DownstreamError(err, false))
+	}
+	if strings.TrimSpace(query) == "" {
+		return errorsource.Response(errorsource.DownstreamError(fmt.Errorf("ES|QL query is empty"), false))
+	}
+
+	res, err := e.client.ExecuteEsql(&es.EsqlRequest{Query: query, Columnar: true, TimeRange: q.TimeRange})
+	if err != nil {
+		return errorsource.Response(err)
+	}
+
+	frame, err := esqlResponseToFrame(res, timeField)
+	if err != nil {
+		e.logger.Error("Failed to convert ES|QL response", "error", err, "stage", es.StageParseResponse)
+		return backend.DataResponse{Error: err}
+	}
+	frame.RefID = q.RefID
+	if frame.Meta == nil {
+		frame.Meta = &data.FrameMeta{}
+	}
+	frame.Meta.ExecutedQueryString = query
+	return backend.DataResponse{Frames: data.Frames{frame}}
+}
+
+// interpolateEsqlMacros replaces the time range and interval macros in an ES|QL query.
+// $__timeFilter filters the configured time field unless a field is passed as argument.
+func interpolateEsqlMacros(q *Query, timeField string) (string, error) {
+	from := esqlDatetime(q.TimeRange.From)
+	to := esqlDatetime(q.TimeRange.To)
+	interval := q.Interval
+	if q.IntervalMs > 0 {
+		interval = time.Duration(q.IntervalMs) * time.Millisecond
+	}
+
+	var macroErr error
+	query := esqlMacroRegexp.ReplaceAllStringFunc(q.RawQuery, func(match string) string {
+		groups := esqlMacroRegexp.FindStringSubmatch(match)
+		arg := strings.TrimSpace(groups[2])
+		switch groups[1] {
+		case "timeFilter":
+			field := timeField
+			if arg != "" {
+				field = arg
+			}
+			if field == "" {
+				macroErr = fmt.Errorf("$__timeFilter needs a time field argument when no time field is configured")
+				return match
+			}
+			return fmt.Sprintf("%s >= %s AND %s <= %s", field, from, field, to)
+		case "timeFrom":
+			return from
+		case "timeTo":
+			return to
+		case "interval_ms":
+			return strconv.FormatInt(interval.Milliseconds(), 10)
+		default:
+			return fmt.Sprintf("%d milliseconds", interval.Milliseconds())
+		}
+	})
+	return query, macroErr
+}
+
+func esqlDatetime(t time.Time) string {
+	return fmt.Sprintf(`TO_DATETIME("%s")`, t.UTC().Format("2006-01-02T15:04:05.000Z"))
+}
+
+// esqlResponseToFrame converts a columnar ES|QL response to a frame. Results with a single
+// date column and at least one numeric column are returned as a wide time series sorted by
+// time, all other results as a table.
+func esqlResponseToFrame(res *es.EsqlResponse, timeField string) (*data.Frame, error) {
+	if len(res.Values) != len(res.Columns) {
+		return nil, fmt.Errorf("invalid ES|QL response: got %d columns and %d value columns", len(res.Columns), len(res.Values))
+	}
+
+	fields := make([]*data.Field, len(res.Columns))
+	timeIndex, dateColumns, numericColumns := -1, 0, 0
+	for i, col := range res.Columns {
+		field, err := esqlField(col, res.Values[i])
+		if err != nil {
+			return nil, err
+		}
+		fields[i] = field
+		switch field.Type() {
+		case data.FieldTypeNullableTime:
+			dateColumns++
+			if timeIndex == -1 || col.Name == timeField {
+				timeIndex = i
+			}
+		case data.FieldTypeNullableInt64, data.FieldTypeNullableFloat64:
+			numericColumns++
+		}
+	}
+
+	frame := data.NewFrame("", fields...)
+	if dateColumns != 1 || numericColumns == 0 {
+		frame.Meta = &data.FrameMeta{PreferredVisualization: data.VisTypeTable}
+		return frame, nil
+	}
+
+	frame, err := esqlTimeSeriesFrame(frame, timeIndex)
+	if err != nil {
+		return nil, err
+	}
+	frame.Meta = &data.FrameMeta{PreferredVisualization: data.VisTypeGraph}
+	return frame, nil
+}
+
+// esqlTimeSeriesFrame sorts the rows by time, drops rows without a time and converts the
+// frame to the wide format, using string and boolean columns as labels.
+func esqlTimeSeriesFrame(frame *data.Frame, timeIndex int) (*data.Frame, error) {
+	timeValues := frame.Fields[timeIndex]
+	rows := make([]int, 0, timeValues.Len())
+	for i := 0; i < timeValues.Len(); i++ {
+		if timeValues.At(i).(*time.Time) != nil {
+			rows = append(rows, i)
+		}
+	}
+	sort.SliceStable(rows, func(a, b int) bool {
+		return timeValues.At(rows[a]).(*time.Time).Before(*timeValues.At(rows[b]).(*time.Time))
+	})
+
+	sorted := make([]*data.Field, len(frame.Fields))
+	for i, f := range frame.Fields {
+		if i == timeIndex {
+			times := make([]time.Time, len(rows))
+			for j, row := range rows {
+				times[j] = *f.At(row).(*time.Time)
+			}
+			sorted[i] = data.NewField(f.Name, nil, times)
+			continue
+		}
+		sorted[i] = data.NewFieldFromFieldType(f.Type(), len(rows))
+		sorted[i].Name = f.Name
+		for j, row := range rows {
+			sorted[i].Set(j, f.CopyAt(row))
+		}
+	}
+	frame = data.NewFrame(frame.Name, sorted...)
+
+	if frame.TimeSeriesSchema().Type == data.TimeSeriesTypeLong {
+		return data.LongToWide(frame, nil)
+	}
+	return frame, nil
+}
+
+func esqlField(col es.EsqlColumn, values []any) (*data.Field, error) {
+	switch col.Type {
+	case "long", "integer", "short", "byte", "counter_long", "counter_integer":
+		out := make([]*int64, len(values))
+		for i, v := range values {
+			if v == nil {
+				continue
+			}
+			n, err := esqlNumber(v).Int64()
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for column %q: %w", col.Name, err)
+			}
+			out[i] = &n
+		}
+		return data.NewField(col.Name, nil, out), nil
+	case "double", "float", "half_float", "scaled_float", "unsigned_long", "counter_double":
+		out := make([]*float64, len(values))
+		for i, v := range values {
+			if v == nil {
+				continue
+			}
+			n, err := esqlNumber(v).Float64()
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for column %q: %w", col.Name, err)
+			}
+			out[i] = &n
+		}
+		return data.NewField(col.Name, nil, out), nil
+	case "date", "date_nanos":
+		out := make([]*time.Time, len(values))
+		for i, v := range values {
+			if v == nil {
+				continue
+			}
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid value for column %q: expected a date string", col.Name)
+			}
+			t, err := time.Parse(time.RFC3339Nano, s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for column %q: %w", col.Name, err)
+			}
+			out[i] = &t
+		}
+		return data.NewField(col.Name, nil, out), nil
+	case "boolean":
+		out := make([]*bool, len(values))
+		for i, v := range values {
+			if b, ok := v.(bool); ok {
+				out[i] = &b
+			}
+		}
+		return data.NewField(col.Name, nil, out), nil
+	default:
+		// keyword, text, ip, version, geo types and multi-valued columns are returned as strings
+		out := make([]*string, len(values))
+		for i, v := range values {
+			switch v := v.(type) {
+			case nil:
+			case string:
+				out[i] = &v
+			default:
+				b, err := json.Marshal(v)
+				if err != nil {
+					return nil, err
+				}
+				s := string(b)
+				out[i] = &s
+			}
+		}
+		return data.NewField(col.Name, nil, out), nil
+	}
+}
+
+func esqlNumber(v any) json.Number {
+	switch v := v.(type) {
+	case json.Number:
+		return v
+	case float64:
+		return json.Number(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		return json.Number(fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,178 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	es "github.com/grafana/grafana/pkg/tsdb/elasticsearch/client"
+)
+
+func TestInterpolateEsqlMacros(t *testing.T) {
+	q := &Query{
+		TimeRange: backend.TimeRange{
+			From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC),
+		},
+		Interval: time.Minute,
+	}
+
+	t.Run("interpolates time filter with the configured time field", func(t *testing.T) {
+		q.RawQuery = "FROM logs | WHERE $__timeFilter | STATS count = COUNT(*) BY BUCKET(@timestamp, $__interval)"
+		query, err := interpolateEsqlMacros(q, "@timestamp")
+		require.NoError(t, err)
+		require.Equal(t, `FROM logs | WHERE @timestamp >= TO_DATETIME("2024-01-01T00:00:00.000Z") AND @timestamp <= TO_DATETIME("2024-01-01T01:00:00.000Z") | STATS count = COUNT(*) BY BUCKET(@timestamp, 60000 milliseconds)`, query)
+	})
+
+	t.Run("interpolates time filter with an explicit field", func(t *testing.T) {
+		q.RawQuery = "FROM logs | WHERE $__timeFilter(event.created)"
+		query, err := interpolateEsqlMacros(q, "@timestamp")
+		require.NoError(t, err)
+		require.Equal(t, `FROM logs | WHERE event.created >= TO_DATETIME("2024-01-01T00:00:00.000Z") AND event.created <= TO_DATETIME("2024-01-01T01:00:00.000Z")`, query)
+	})
+
+	t.Run("interpolates time range and interval macros", func(t *testing.T) {
+		q.RawQuery = "$__timeFrom $__timeTo $__interval_ms"
+		query, err := interpolateEsqlMacros(q, "@timestamp")
+		require.NoError(t, err)
+		require.Equal(t, `TO_DATETIME("2024-01-01T00:00:00.000Z") TO_DATETIME("2024-01-01T01:00:00.000Z") 60000`, query)
+	})
+
+	t.Run("fails without a time field", func(t *testing.T) {
+		q.RawQuery = "FROM logs | WHERE $__timeFilter"
+		_, err := interpolateEsqlMacros(q, "")
+		require.Error(t, err)
+	})
+}
+
+func esqlResponse(t *testing.T, body string) *es.EsqlResponse {
+	t.Helper()
+	var res es.EsqlResponse
+	dec := json.NewDecoder(strings.NewReader(body))
+	dec.UseNumber()
+	require.NoError(t, dec.Decode(&res))
+	return &res
+}
+
+func TestEsqlResponseToFrame(t *testing.T) {
+	t.Run("converts columns to typed fields", func(t *testing.T) {
+		res := esqlResponse(t, `{
+			"columns": [
+				{"name": "host", "type": "keyword"},
+				{"name": "bytes", "type": "long"},
+				{"name": "ratio", "type": "double"},
+				{"name": "ok", "type": "boolean"},
+				{"name": "tags", "type": "keyword"}
+			],
+			"values": [
+				["a", null],
+				[9007199254740993, 2],
+				[0.5, null],
+				[true, false],
+				[["x", "y"], "z"]
+			]
+		}`)
+		frame, err := esqlResponseToFrame(res, "@timestamp")
+		require.NoError(t, err)
+		require.Equal(t, data.VisType(data.VisTypeTable), frame.Meta.PreferredVisualization)
+		require.Equal(t, data.FieldTypeNullableString, frame.Fields[0].Type())
+		require.Nil(t, frame.Fields[0].At(1))
+		require.Equal(t, int64(9007199254740993), *frame.Fields[1].At(0).(*int64))
+		require.Equal(t, data.FieldTypeNullableFloat64, frame.Fields[2].Type())
+		require.Equal(t, data.FieldTypeNullableBool, frame.Fields[3].Type())
+		require.Equal(t, `["x","y"]`, *frame.Fields[4].At(0).(*string))
+	})
+
+	t.Run("returns sorted time series for date and numeric columns", func(t *testing.T) {
+		res := esqlResponse(t, `{
+			"columns": [{"name": "count", "type": "long"}, {"name": "bucket", "type": "date"}],
+			"values": [[2, 1, 3], ["2024-01-01T00:01:00.000Z", "2024-01-01T00:00:00.000Z", null]]
+		}`)
+		frame, err := esqlResponseToFrame(res, "@timestamp")
+		require.NoError(t, err)
+		require.Equal(t, data.VisTypeGraph, frame.Meta.PreferredVisualization)
+		require.Equal(t, data.TimeSeriesTypeWide, frame.TimeSeriesSchema().Type)
+		require.Equal(t, 2, frame.Rows())
+		require.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), frame.Fields[1].At(0))
+		require.Equal(t, int64(1), *frame.Fields[0].At(0).(*int64))
+	})
+
+	t.Run("converts string columns to labels", func(t *testing.T) {
+		res := esqlResponse(t, `{
+			"columns": [{"name": "@timestamp", "type": "date"}, {"name": "host", "type": "keyword"}, {"name": "avg", "type": "double"}],
+			"values": [
+				["2024-01-01T00:00:00.000Z", "2024-01-01T00:00:00.000Z", "2024-01-01T00:01:00.000Z"],
+				["a", "b", "a"],
+				[1, 2, 3]
+			]
+		}`)
+		frame, err := esqlResponseToFrame(res, "@timestamp")
+		require.NoError(t, err)
+		require.Equal(t, data.TimeSeriesTypeWide, frame.TimeSeriesSchema().Type)
+		require.Len(t, frame.Fields, 3)
+		require.Equal(t, data.Labels{"host": "a"}, frame.Fields[1].Labels)
+		require.Equal(t, data.Labels{"host": "b"}, frame.Fields[2].Labels)
+	})
+
+	t.Run("fails on mismatched columns", func(t *testing.T) {
+		_, err := esqlResponseToFrame(&es.EsqlResponse{Columns: []es.EsqlColumn{{Name: "a", Type: "long"}}}, "")
+		require.Error(t, err)
+	})
+}
+
+func TestExecuteEsqlQuery(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	t.Run("sends ES|QL queries to the query endpoint", func(t *testing.T) {
+		c := newFakeClient()
+		c.esqlResponse = esqlResponse(t, `{"columns": [{"name": "count", "type": "long"}], "values": [[4]]}`)
+
+		res, err := executeElasticsearchDataQuery(c, `{"queryType": "esql", "query": "FROM logs | WHERE $__timeFilter | STATS count = COUNT(*)"}`, from, to)
+		require.NoError(t, err)
+		require.Empty(t, c.multisearchRequests)
+		require.Len(t, c.esqlRequests, 1)
+		require.True(t, c.esqlRequests[0].Columnar)
+		require.Contains(t, c.esqlRequests[0].Query, `@timestamp >= TO_DATETIME("2024-01-01T00:00:00.000Z")`)
+
+		dr := res.Responses["A"]
+		require.NoError(t, dr.Error)
+		require.Len(t, dr.Frames, 1)
+		require.Equal(t, "A", dr.Frames[0].RefID)
+		require.Equal(t, c.esqlRequests[0].Query, dr.Frames[0].Meta.ExecutedQueryString)
+	})
+
+	t.Run("returns downstream errors", func(t *testing.T) {
+		c := newFakeClient()
+		c.esqlError = errors.New("verification_exception: Unknown column [nope]")
+
+		res, err := executeElasticsearchDataQuery(c, `{"queryType": "esql", "query": "FROM logs | KEEP nope"}`, from, to)
+		require.NoError(t, err)
+		require.ErrorContains(t, res.Responses["A"].Error, "Unknown column")
+	})
+
+	t.Run("runs ES|QL and search queries in the same request", func(t *testing.T) {
+		c := newFakeClient()
+		c.esqlResponse = esqlResponse(t, `{"columns": [{"name": "count", "type": "long"}], "values": [[4]]}`)
+		c.multiSearchResponse = &es.MultiSearchResponse{Responses: []*es.SearchResponse{{Aggregations: map[string]any{}}}}
+
+		req := backend.QueryDataRequest{Queries: []backend.DataQuery{
+			{RefID: "A", TimeRange: backend.TimeRange{From: from, To: to}, JSON: []byte(`{"queryType": "esql", "query": "FROM logs | STATS count = COUNT(*)"}`)},
+			{RefID: "B", TimeRange: backend.TimeRange{From: from, To: to}, JSON: []byte(`{"metrics": [{"type": "count", "id": "1"}], "bucketAggs": [{"type": "date_histogram", "id": "2", "field": "@timestamp"}]}`)},
+		}}
+		res, err := newElasticsearchDataQuery(context.Background(), c, &req, log.New()).execute()
+		require.NoError(t, err)
+		require.Len(t, c.multisearchRequests, 1)
+		require.Len(t, c.multisearchRequests[0].Requests, 1)
+		require.Contains(t, res.Responses, "A")
+		require.Contains(t, res.Responses, "B")
+	})
+}
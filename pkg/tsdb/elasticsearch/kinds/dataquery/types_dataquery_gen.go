@@ -223,6 +223,16 @@ type ElasticsearchDataQuery struct {
 	// Alias pattern
 	Alias *string `json:"alias,omitempty"`
 
+	// Field mappings of annotation queries
+	Annotation *struct {
+		Limit        *int64  `json:"limit,omitempty"`
+		TagsField    *string `json:"tagsField,omitempty"`
+		TextField    *string `json:"textField,omitempty"`
+		TimeEndField *string `json:"timeEndField,omitempty"`
+		TimeField    *string `json:"timeField,omitempty"`
+		TitleField   *string `json:"titleField,omitempty"`
+	} `json:"annotation,omitempty"`
+
 	// List of bucket aggregations
 	BucketAggs []any `json:"bucketAggs,omitempty"`
 
@@ -564,6 +574,8 @@ type TermsOrder string
 
 // TermsSettings defines model for TermsSettings.
 type TermsSettings struct {
+	Composite   *bool       `json:"composite,omitempty"`
+	MaxBuckets  *string     `json:"maxBuckets,omitempty"`
 	MinDocCount *string     `json:"min_doc_count,omitempty"`
 	Missing     *string     `json:"missing,omitempty"`
 	Order       *TermsOrder `json:"order,omitempty"`
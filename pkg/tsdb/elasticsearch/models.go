@@ -9,6 +9,7 @@ import (
 
 // Query represents the time series query model of the datasource
 type Query struct {
+	QueryType     string       `json:"queryType"`
 	RawQuery      string       `json:"query"`
 	BucketAggs    []*BucketAgg `json:"bucketAggs"`
 	Metrics       []*MetricAgg `json:"metrics"`
@@ -18,6 +19,23 @@ type Query struct {
 	RefID         string
 	MaxDataPoints int64
 	TimeRange     backend.TimeRange
+
+	Annotation *AnnotationSettings
+
+	// compositeAfter is the key of the last bucket of the previous page of a composite terms aggregation
+	compositeAfter map[string]any
+	// compositeMaxBuckets is the bucket limit of a composite terms aggregation
+	compositeMaxBuckets int
+}
+
+// AnnotationSettings represents the document fields read by an annotation query
+type AnnotationSettings struct {
+	TimeField    string
+	TimeEndField string
+	TextField    string
+	TagsField    string
+	TitleField   string
+	Limit        int
 }
 
 // BucketAgg represents a bucket aggregation of the time series query model of the datasource
@@ -18,6 +18,7 @@ func parseQuery(tsdbQuery []backend.DataQuery, logger log.Logger) ([]*Query, err
 		// we had a string-field named `timeField` in the past. we do not use it anymore.
 		// please do not create a new field with that name, to avoid potential problems with old, persisted queries.
 
+		queryType := model.Get("queryType").MustString()
 		rawQuery := model.Get("query").MustString()
 		bucketAggs, err := parseBucketAggs(model)
 		if err != nil {
@@ -33,7 +34,13 @@ func parseQuery(tsdbQuery []backend.DataQuery, logger log.Logger) ([]*Query, err
 		intervalMs := model.Get("intervalMs").MustInt64(0)
 		interval := q.Interval
 
+		var annotation *AnnotationSettings
+		if queryType == annotationQueryType {
+			annotation = parseAnnotationSettings(model.Get("annotation"))
+		}
+
 		queries = append(queries, &Query{
+			QueryType:     queryType,
 			RawQuery:      rawQuery,
 			BucketAggs:    bucketAggs,
 			Metrics:       metrics,
@@ -43,12 +50,26 @@ func parseQuery(tsdbQuery []backend.DataQuery, logger log.Logger) ([]*Query, err
 			RefID:         q.RefID,
 			MaxDataPoints: q.MaxDataPoints,
 			TimeRange:     q.TimeRange,
+			Annotation:    annotation,
 		})
 	}
 
 	return queries, nil
 }
 
+// parseAnnotationSettings parses the fields of an annotation query. They are nested in the
+// `annotation` object because `timeField` at the top level is reserved for old queries.
+func parseAnnotationSettings(model *simplejson.Json) *AnnotationSettings {
+	return &AnnotationSettings{
+		TimeField:    model.Get("timeField").MustString(),
+		TimeEndField: model.Get("timeEndField").MustString(),
+		TextField:    model.Get("textField").MustString(),
+		TagsField:    model.Get("tagsField").MustString(),
+		TitleField:   model.Get("titleField").MustString(),
+		Limit:        model.Get("limit").MustInt(0),
+	}
+}
+
 func parseBucketAggs(model *simplejson.Json) ([]*BucketAgg, error) {
 	var err error
 	bucketAggs := model.Get("bucketAggs").MustArray()
@@ -19,6 +19,9 @@ import (
 	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/grafana/grafana-plugin-sdk-go/data/sqlutil"
+
+	"github.com/grafana/grafana/pkg/tsdb/sqlparams"
+	"github.com/grafana/grafana/pkg/tsdb/sqlstream"
 )
 
 // MetaKeyExecutedQueryString is the key where the executed query should get stored
@@ -76,6 +79,12 @@ type DataPluginConfiguration struct {
 	TimeColumnNames   []string
 	MetricColumnTypes []string
 	RowLimit          int64
+	// StreamRowLimit is the maximum number of rows of a streamed table query. Defaults to
+	// sqlstream.DefaultRowLimit.
+	StreamRowLimit int64
+	// ParamPlaceholder returns the driver placeholder for the 1-based index of a bound
+	// parameter. Defaults to sqlparams.QuestionMark.
+	ParamPlaceholder sqlparams.Placeholder
 }
 
 type DataSourceHandler struct {
@@ -87,7 +96,9 @@ type DataSourceHandler struct {
 	log                    log.Logger
 	dsInfo                 DataSourceInfo
 	rowLimit               int64
+	stream                 *sqlstream.Handler
 	userError              string
+	paramPlaceholder       sqlparams.Placeholder
 }
 
 type QueryJson struct {
@@ -97,6 +108,9 @@ type QueryJson struct {
 	FillMode     string  `json:"fillMode"`
 	FillValue    float64 `json:"fillValue"`
 	Format       string  `json:"format"`
+	// Params holds the values of the template variables referenced with $__param(name).
+	// They are bound as query arguments instead of being interpolated into the SQL.
+	Params map[string]sqlparams.Param `json:"params,omitempty"`
 }
 
 func (e *DataSourceHandler) TransformQueryError(logger log.Logger, err error) error {
@@ -123,6 +137,7 @@ func NewQueryDataHandler(userFacingDefaultError string, db *sql.DB, config DataP
 		dsInfo:                 config.DSInfo,
 		rowLimit:               config.RowLimit,
 		userError:              userFacingDefaultError,
+		paramPlaceholder:       sqlparams.QuestionMark,
 	}
 
 	if len(config.TimeColumnNames) > 0 {
@@ -133,6 +148,12 @@ func NewQueryDataHandler(userFacingDefaultError string, db *sql.DB, config DataP
 		queryDataHandler.metricColumnTypes = config.MetricColumnTypes
 	}
 
+	queryDataHandler.stream = sqlstream.NewHandler(&queryDataHandler, config.StreamRowLimit, log)
+
+	if config.ParamPlaceholder != nil {
+		queryDataHandler.paramPlaceholder = config.ParamPlaceholder
+	}
+
 	queryDataHandler.db = db
 	return &queryDataHandler, nil
 }
@@ -240,14 +261,21 @@ func (e *DataSourceHandler) executeQuery(query backend.DataQuery, wg *sync.WaitG
 	// global substitutions
 	interpolatedQuery := Interpolate(query, timeRange, e.dsInfo.JsonData.TimeInterval, queryJson.RawSql)
 
+	// bound parameters, replaced before the data source macros so they never see $__param
+	interpolatedQuery, args, err := sqlparams.Bind(interpolatedQuery, queryJson.Params, e.paramPlaceholder)
+	if err != nil {
+		errAppendDebug("parameter binding failed", err, interpolatedQuery)
+		return
+	}
+
 	// data source specific substitutions
-	interpolatedQuery, err := e.macroEngine.Interpolate(&query, timeRange, interpolatedQuery)
+	interpolatedQuery, err = e.macroEngine.Interpolate(&query, timeRange, interpolatedQuery)
 	if err != nil {
 		errAppendDebug("interpolation failed", e.TransformQueryError(logger, err), interpolatedQuery)
 		return
 	}
 
-	rows, err := e.db.QueryContext(queryContext, interpolatedQuery)
+	rows, err := e.db.QueryContext(queryContext, interpolatedQuery, args...)
 	if err != nil {
 		errAppendDebug("db query error", e.TransformQueryError(logger, err), interpolatedQuery)
 		return
@@ -76,6 +76,7 @@ const (
 	TestDataQueryTypeRandomWalkTable              TestDataQueryType = "random_walk_table"
 	TestDataQueryTypeRandomWalkWithError          TestDataQueryType = "random_walk_with_error"
 	TestDataQueryTypeRawFrame                     TestDataQueryType = "raw_frame"
+	TestDataQueryTypeReplay                       TestDataQueryType = "replay"
 	TestDataQueryTypeServerError500               TestDataQueryType = "server_error_500"
 	TestDataQueryTypeSimulation                   TestDataQueryType = "simulation"
 	TestDataQueryTypeSlowQuery                    TestDataQueryType = "slow_query"
@@ -122,6 +123,7 @@ type TestDataQuery struct {
 
 	Nodes     *NodesQuery      `json:"nodes,omitempty"`
 	PulseWave *PulseWaveQuery  `json:"pulseWave,omitempty"`
+	Replay    *ReplayQuery     `json:"replay,omitempty"`
 	Sim       *SimulationQuery `json:"sim,omitempty"`
 	Stream    *StreamingQuery  `json:"stream,omitempty"`
 	Usa       *USAQuery        `json:"usa,omitempty"`
@@ -151,6 +153,26 @@ type PulseWaveQuery struct {
 	TimeStep int64   `json:"timeStep,omitempty"`
 }
 
+// ReplayQuery defines model for ReplayQuery.
+type ReplayQuery struct {
+	// Name of the captured fixture
+	Fixture string     `json:"fixture,omitempty"`
+	Mode    ReplayMode `json:"mode,omitempty"`
+	// Seed of the noise added with the noise parameter
+	Seed int64 `json:"seed,omitempty"`
+}
+
+// ReplayMode defines how the timestamps of a fixture are fitted to the query time range.
+// +enum
+type ReplayMode string
+
+// Defines values for ReplayMode.
+const (
+	ReplayModeShift   ReplayMode = "shift"
+	ReplayModeLoop    ReplayMode = "loop"
+	ReplayModeStretch ReplayMode = "stretch"
+)
+
 // SimulationQuery defines model for SimulationQuery.
 type SimulationQuery struct {
 	Config map[string]any `json:"config,omitempty"`
@@ -0,0 +1,383 @@
+package testdatasource
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/tsdb/grafana-testdata-datasource/kinds"
+)
+
+//go:embed data/fixtures/*.json
+var embeddedFixtures embed.FS
+
+var validFixtureName = regexp.MustCompile(`^[\w-]+$`)
+
+var (
+	errFixtureNotFound        = errors.New("fixture not found")
+	errFixtureExists          = errors.New("fixture already exists")
+	errFixtureCaptureDisabled = errors.New("fixture capture is not enabled")
+)
+
+// fixtureStore stores captured query responses as JSON files in a directory per organization, so that
+// the admins of one organization cannot replace the fixtures of another. Fixtures are read from the
+// directory of the organization first and fall back to the fixtures embedded in the plugin.
+type fixtureStore struct {
+	dir string
+	// captureEnabled allows fixtures to be written to the fixtures directory
+	captureEnabled bool
+}
+
+func (f *fixtureStore) canCapture() bool {
+	return f.captureEnabled && f.dir != ""
+}
+
+// orgDir returns the directory of the fixtures captured by the organization.
+func (f *fixtureStore) orgDir(orgID int64) string {
+	return filepath.Join(f.dir, strconv.FormatInt(orgID, 10))
+}
+
+func (f *fixtureStore) path(orgID int64, name string) (string, error) {
+	if !validFixtureName.MatchString(name) {
+		return "", fmt.Errorf("invalid fixture name: %q", name)
+	}
+	return filepath.Join(f.orgDir(orgID), name+".json"), nil
+}
+
+// save writes a fixture. Existing fixtures are only replaced when overwrite is set.
+func (f *fixtureStore) save(orgID int64, name string, res *backend.QueryDataResponse, overwrite bool) error {
+	if !f.canCapture() {
+		return errFixtureCaptureDisabled
+	}
+	path, err := f.path(orgID, name)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(f.orgDir(orgID), 0o750); err != nil {
+		return err
+	}
+
+	flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !overwrite {
+		flag |= os.O_EXCL
+	}
+	// nolint:gosec
+	// We can ignore the gosec G304 warning since the fixture name is validated above
+	file, err := os.OpenFile(path, flag, 0o600)
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("%w: %q", errFixtureExists, name)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(b); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (f *fixtureStore) load(orgID int64, name string) (*backend.QueryDataResponse, error) {
+	path, err := f.path(orgID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var b []byte
+	if f.dir != "" {
+		// nolint:gosec
+		// We can ignore the gosec G304 warning since the fixture name is validated above
+		b, err = os.ReadFile(path)
+	}
+	if f.dir == "" || errors.Is(err, fs.ErrNotExist) {
+		b, err = embeddedFixtures.ReadFile("data/fixtures/" + name + ".json")
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %q", errFixtureNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := decodeFixture(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %q: %w", name, err)
+	}
+	return res, nil
+}
+
+// decodeFixture parses a query data response. The frame decoder panics on fields without
+// type information, which is turned into an error since fixtures are uploaded by users.
+func decodeFixture(b []byte) (res *backend.QueryDataResponse, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	res = &backend.QueryDataResponse{}
+	if err := json.Unmarshal(b, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// list returns the names of the fixtures captured by the organization and the embedded fixtures.
+func (f *fixtureStore) list(orgID int64) ([]string, error) {
+	names := map[string]struct{}{}
+	embedded, err := embeddedFixtures.ReadDir("data/fixtures")
+	if err != nil {
+		return nil, err
+	}
+	entries := embedded
+	if f.dir != "" {
+		captured, err := os.ReadDir(f.orgDir(orgID))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		entries = append(entries, captured...)
+	}
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".json"); ok && !entry.IsDir() {
+			names[name] = struct{}{}
+		}
+	}
+
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func (s *Service) handleReplayScenario(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
+	resp := backend.NewQueryDataResponse()
+
+	for _, q := range req.Queries {
+		model, err := GetJSONModel(q.JSON)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse query json: %v", err)
+		}
+		if model.Replay == nil || model.Replay.Fixture == "" {
+			continue
+		}
+
+		fixture, err := s.fixtures.load(req.PluginContext.OrgID, model.Replay.Fixture)
+		if err != nil {
+			resp.Responses[q.RefID] = backend.ErrDataResponse(backend.StatusBadRequest, err.Error())
+			continue
+		}
+
+		frames := replayFrames(fixture, q.TimeRange, model)
+		respD := resp.Responses[q.RefID]
+		respD.Frames = append(respD.Frames, frames...)
+		resp.Responses[q.RefID] = respD
+	}
+
+	return resp, nil
+}
+
+// replayFrames returns the frames of all responses of the fixture, ordered by refID, with their
+// timestamps fitted to the time range. All frames are moved by the same amount, so that series
+// captured together stay aligned.
+func replayFrames(fixture *backend.QueryDataResponse, timeRange backend.TimeRange, model kinds.TestDataQuery) data.Frames {
+	refIDs := make([]string, 0, len(fixture.Responses))
+	for refID := range fixture.Responses {
+		refIDs = append(refIDs, refID)
+	}
+	sort.Strings(refIDs)
+
+	frames := data.Frames{}
+	for _, refID := range refIDs {
+		frames = append(frames, fixture.Responses[refID].Frames...)
+	}
+
+	first, last, ok := framesTimeRange(frames)
+	if ok {
+		switch model.Replay.Mode {
+		case kinds.ReplayModeLoop:
+			for i, frame := range frames {
+				frames[i] = loopFrame(frame, first, last, timeRange)
+			}
+		case kinds.ReplayModeStretch:
+			scale := 0.0
+			if last.After(first) {
+				scale = float64(timeRange.To.Sub(timeRange.From)) / float64(last.Sub(first))
+			}
+			mapFrameTimes(frames, func(t time.Time) time.Time {
+				return timeRange.From.Add(time.Duration(float64(t.Sub(first)) * scale))
+			})
+		default:
+			offset := timeRange.To.Sub(last)
+			mapFrameTimes(frames, func(t time.Time) time.Time {
+				return t.Add(offset)
+			})
+		}
+	}
+
+	if model.Noise > 0 {
+		rand := rand.New(rand.NewSource(model.Replay.Seed))
+		for _, frame := range frames {
+			addNoise(frame, model.Noise, rand)
+		}
+	}
+
+	if model.Alias != "" {
+		for _, frame := range frames {
+			frame.Name = model.Alias
+		}
+	}
+
+	return frames
+}
+
+// framesTimeRange returns the first and last timestamp of all time fields of the frames.
+func framesTimeRange(frames data.Frames) (time.Time, time.Time, bool) {
+	var first, last time.Time
+	found := false
+	for _, frame := range frames {
+		for _, field := range frame.Fields {
+			for i := 0; i < field.Len(); i++ {
+				t, ok := timeAt(field, i)
+				if !ok {
+					continue
+				}
+				if !found || t.Before(first) {
+					first = t
+				}
+				if !found || t.After(last) {
+					last = t
+				}
+				found = true
+			}
+		}
+	}
+	return first, last, found
+}
+
+func timeAt(field *data.Field, i int) (time.Time, bool) {
+	switch field.Type() {
+	case data.FieldTypeTime:
+		return field.At(i).(time.Time), true
+	case data.FieldTypeNullableTime:
+		if t := field.At(i).(*time.Time); t != nil {
+			return *t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func mapFrameTimes(frames data.Frames, fn func(time.Time) time.Time) {
+	for _, frame := range frames {
+		for _, field := range frame.Fields {
+			for i := 0; i < field.Len(); i++ {
+				if t, ok := timeAt(field, i); ok {
+					setTime(field, i, fn(t))
+				}
+			}
+		}
+	}
+}
+
+func setTime(field *data.Field, i int, t time.Time) {
+	if field.Type() == data.FieldTypeNullableTime {
+		field.Set(i, &t)
+		return
+	}
+	field.Set(i, t)
+}
+
+// loopFrame repeats the rows of the frame back from the end of the time range until the start of
+// the range is covered. The period of the loop is the captured duration plus the average step
+// between timestamps, so that the end of one repetition does not overlap the start of the next.
+func loopFrame(frame *data.Frame, first, last time.Time, timeRange backend.TimeRange) *data.Frame {
+	timeIndex := -1
+	for i, field := range frame.Fields {
+		if field.Type().Time() {
+			timeIndex = i
+			break
+		}
+	}
+	rows, err := frame.RowLen()
+	if timeIndex < 0 || err != nil || rows == 0 {
+		return frame
+	}
+
+	period := last.Sub(first)
+	if rows > 1 {
+		period += period / time.Duration(rows-1)
+	}
+	if period <= 0 {
+		period = timeRange.To.Sub(timeRange.From)
+	}
+	repetitions := int(timeRange.To.Sub(timeRange.From)/period) + 1
+
+	looped := frame.EmptyCopy()
+	for r := repetitions - 1; r >= 0; r-- {
+		offset := timeRange.To.Sub(last) - time.Duration(r)*period
+		for i := 0; i < rows; i++ {
+			t, ok := timeAt(frame.Fields[timeIndex], i)
+			if !ok || t.Add(offset).Before(timeRange.From) {
+				continue
+			}
+			row := frame.RowCopy(i)
+			looped.AppendRow(row...)
+			for _, field := range looped.Fields {
+				if t, ok := timeAt(field, looped.Rows()-1); ok {
+					setTime(field, looped.Rows()-1, t.Add(offset))
+				}
+			}
+		}
+	}
+	return looped
+}
+
+// addNoise adds a random value between -noise and noise to every number of the frame.
+func addNoise(frame *data.Frame, noise float64, rand *rand.Rand) {
+	for i, field := range frame.Fields {
+		if !field.Type().Numeric() {
+			continue
+		}
+		nullable := field.Type().Nullable()
+		noisy := data.NewFieldFromFieldType(data.FieldTypeFloat64, field.Len())
+		if nullable {
+			noisy = data.NewFieldFromFieldType(data.FieldTypeNullableFloat64, field.Len())
+		}
+		noisy.Name = field.Name
+		noisy.Labels = field.Labels
+		noisy.Config = field.Config
+
+		for j := 0; j < field.Len(); j++ {
+			v, err := field.NullableFloatAt(j)
+			if err != nil || v == nil {
+				continue
+			}
+			value := *v + (rand.Float64()*2-1)*noise
+			if nullable {
+				noisy.Set(j, &value)
+			} else {
+				noisy.Set(j, value)
+			}
+		}
+		frame.Fields[i] = noisy
+	}
+}
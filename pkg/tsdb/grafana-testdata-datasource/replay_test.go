@@ -0,0 +1,198 @@
+package testdatasource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayScenario(t *testing.T) {
+	s := &Service{fixtures: &fixtureStore{}}
+	from := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	replay := func(t *testing.T, to time.Time, model string) data.Frames {
+		t.Helper()
+		query := backend.DataQuery{
+			RefID:     "A",
+			TimeRange: backend.TimeRange{From: from, To: to},
+			JSON:      []byte(model),
+		}
+		resp, err := s.handleReplayScenario(context.Background(), &backend.QueryDataRequest{Queries: []backend.DataQuery{query}})
+		require.NoError(t, err)
+		require.NoError(t, resp.Responses["A"].Error)
+		return resp.Responses["A"].Frames
+	}
+
+	t.Run("shifts the fixture to the end of the time range", func(t *testing.T) {
+		frames := replay(t, from.Add(time.Hour), `{"replay": {"fixture": "node_cpu_usage"}}`)
+		require.Len(t, frames, 2)
+		require.Equal(t, 60, frames[0].Rows())
+		require.Equal(t, from.Add(time.Hour), frames[0].Fields[0].At(59))
+		require.Equal(t, from.Add(time.Minute), frames[0].Fields[0].At(0))
+		require.Equal(t, data.Labels{"instance": "web-2:9100", "job": "node"}, frames[1].Fields[1].Labels)
+	})
+
+	t.Run("stretches the fixture over the time range", func(t *testing.T) {
+		frames := replay(t, from.Add(59*time.Minute*2), `{"replay": {"fixture": "node_cpu_usage", "mode": "stretch"}}`)
+		require.Equal(t, 60, frames[0].Rows())
+		require.Equal(t, from, frames[0].Fields[0].At(0))
+		require.Equal(t, from.Add(2*time.Minute), frames[0].Fields[0].At(1))
+		require.Equal(t, from.Add(59*time.Minute*2), frames[0].Fields[0].At(59))
+	})
+
+	t.Run("loops the fixture to cover the time range", func(t *testing.T) {
+		frames := replay(t, from.Add(3*time.Hour), `{"replay": {"fixture": "node_cpu_usage", "mode": "loop"}}`)
+		require.Equal(t, 181, frames[0].Rows())
+		require.Equal(t, from, frames[0].Fields[0].At(0))
+		require.Equal(t, from.Add(3*time.Hour), frames[0].Fields[0].At(180))
+		// every repetition replays the same values
+		require.Equal(t, frames[0].Fields[1].At(180), frames[0].Fields[1].At(120))
+	})
+
+	t.Run("adds the same noise for the same seed", func(t *testing.T) {
+		original := replay(t, from.Add(time.Hour), `{"replay": {"fixture": "node_cpu_usage"}}`)
+		first := replay(t, from.Add(time.Hour), `{"noise": 2, "replay": {"fixture": "node_cpu_usage", "seed": 7}}`)
+		second := replay(t, from.Add(time.Hour), `{"noise": 2, "replay": {"fixture": "node_cpu_usage", "seed": 7}}`)
+
+		require.Equal(t, first[0].Fields[1].At(10), second[0].Fields[1].At(10))
+		require.NotEqual(t, original[0].Fields[1].At(10), first[0].Fields[1].At(10))
+		require.InDelta(t, original[0].Fields[1].At(10), first[0].Fields[1].At(10), 2)
+	})
+
+	t.Run("returns an error for unknown fixtures", func(t *testing.T) {
+		query := backend.DataQuery{RefID: "A", JSON: []byte(`{"replay": {"fixture": "missing"}}`)}
+		resp, err := s.handleReplayScenario(context.Background(), &backend.QueryDataRequest{Queries: []backend.DataQuery{query}})
+		require.NoError(t, err)
+		require.ErrorContains(t, resp.Responses["A"].Error, "fixture not found")
+	})
+}
+
+const capturedFixture = `{"results": {"A": {"frames": [{
+	"schema": {"fields": [{"name": "Time", "type": "time", "typeInfo": {"frame": "time.Time"}}, {"name": "Value", "type": "number", "typeInfo": {"frame": "float64"}}]},
+	"data": {"values": [[1717243200000], [1.5]]}
+}]}}}`
+
+func TestFixtureResources(t *testing.T) {
+	s := &Service{logger: log.New(), fixtures: &fixtureStore{dir: t.TempDir(), captureEnabled: true}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/fixtures", s.getFixturesHandler)
+	mux.HandleFunc("/fixtures/", s.fixtureHandler)
+
+	serveIn := func(orgID int64, role, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req = req.WithContext(backend.WithPluginContext(req.Context(), backend.PluginContext{OrgID: orgID, User: &backend.User{Role: role}}))
+		rw := httptest.NewRecorder()
+		mux.ServeHTTP(rw, req)
+		return rw
+	}
+	serveAs := func(role, method, path, body string) *httptest.ResponseRecorder {
+		return serveIn(1, role, method, path, body)
+	}
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		return serveAs("Admin", method, path, body)
+	}
+
+	t.Run("captures a query data response", func(t *testing.T) {
+		rw := serve(http.MethodPost, "/fixtures/captured", capturedFixture)
+		require.Equal(t, http.StatusNoContent, rw.Code)
+
+		res, err := s.fixtures.load(1, "captured")
+		require.NoError(t, err)
+		require.Equal(t, 1.5, res.Responses["A"].Frames[0].Fields[1].At(0))
+
+		rw = serve(http.MethodGet, "/fixtures", "")
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.JSONEq(t, `["captured", "node_cpu_usage"]`, rw.Body.String())
+	})
+
+	t.Run("replaces existing fixtures only on PUT", func(t *testing.T) {
+		rw := serve(http.MethodPost, "/fixtures/replaced", capturedFixture)
+		require.Equal(t, http.StatusNoContent, rw.Code)
+
+		rw = serve(http.MethodPost, "/fixtures/replaced", `{"results": {}}`)
+		require.Equal(t, http.StatusConflict, rw.Code)
+
+		rw = serve(http.MethodPut, "/fixtures/replaced", `{"results": {}}`)
+		require.Equal(t, http.StatusNoContent, rw.Code)
+		res, err := s.fixtures.load(1, "replaced")
+		require.NoError(t, err)
+		require.Empty(t, res.Responses)
+	})
+
+	t.Run("requires the Admin role to capture fixtures", func(t *testing.T) {
+		rw := serveAs("Viewer", http.MethodPost, "/fixtures/viewer", capturedFixture)
+		require.Equal(t, http.StatusForbidden, rw.Code)
+
+		rw = serveAs("Editor", http.MethodPut, "/fixtures/captured", `{"results": {}}`)
+		require.Equal(t, http.StatusForbidden, rw.Code)
+
+		rw = serveAs("Viewer", http.MethodGet, "/fixtures/captured", "")
+		require.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("stores fixtures per organization", func(t *testing.T) {
+		rw := serve(http.MethodPost, "/fixtures/shared", capturedFixture)
+		require.Equal(t, http.StatusNoContent, rw.Code)
+
+		rw = serveIn(2, "Admin", http.MethodGet, "/fixtures/shared", "")
+		require.Equal(t, http.StatusNotFound, rw.Code)
+		rw = serveIn(2, "Admin", http.MethodGet, "/fixtures", "")
+		require.JSONEq(t, `["node_cpu_usage"]`, rw.Body.String())
+
+		rw = serveIn(2, "Admin", http.MethodPost, "/fixtures/shared", `{"results": {}}`)
+		require.Equal(t, http.StatusNoContent, rw.Code)
+		res, err := s.fixtures.load(1, "shared")
+		require.NoError(t, err)
+		require.Len(t, res.Responses, 1)
+
+		query := backend.DataQuery{RefID: "A", JSON: []byte(`{"replay": {"fixture": "shared"}}`)}
+		resp, err := s.handleReplayScenario(context.Background(), &backend.QueryDataRequest{
+			PluginContext: backend.PluginContext{OrgID: 2},
+			Queries:       []backend.DataQuery{query},
+		})
+		require.NoError(t, err)
+		require.NoError(t, resp.Responses["A"].Error)
+		require.Empty(t, resp.Responses["A"].Frames)
+	})
+
+	t.Run("rejects fixtures larger than the maximum size", func(t *testing.T) {
+		rw := serve(http.MethodPost, "/fixtures/large", `{"results": {}, "padding": "`+strings.Repeat("a", maxFixtureSize)+`"}`)
+		require.Equal(t, http.StatusRequestEntityTooLarge, rw.Code)
+	})
+
+	t.Run("rejects invalid responses", func(t *testing.T) {
+		rw := serve(http.MethodPost, "/fixtures/invalid", `{"results": {"A": {"frames": [{"schema": {"fields": [{"name": "Value"}]}, "data": {"values": [[1]]}}]}}}`)
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+	})
+
+	t.Run("rejects invalid fixture names", func(t *testing.T) {
+		rw := serve(http.MethodPost, "/fixtures/..%2Fescape", `{"results": {}}`)
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+	})
+
+	t.Run("returns not found for unknown fixtures", func(t *testing.T) {
+		rw := serve(http.MethodGet, "/fixtures/missing", "")
+		require.Equal(t, http.StatusNotFound, rw.Code)
+	})
+}
+
+func TestFixtureCaptureDisabled(t *testing.T) {
+	s := &Service{logger: log.New(), fixtures: &fixtureStore{dir: t.TempDir()}}
+
+	req := httptest.NewRequest(http.MethodPost, "/fixtures/captured", strings.NewReader(capturedFixture))
+	req = req.WithContext(backend.WithPluginContext(req.Context(), backend.PluginContext{User: &backend.User{Role: "Admin"}}))
+	rw := httptest.NewRecorder()
+	s.fixtureHandler(rw, req)
+
+	require.Equal(t, http.StatusForbidden, rw.Code)
+	_, err := s.fixtures.load(1, "captured")
+	require.ErrorIs(t, err, errFixtureNotFound)
+}
@@ -2,13 +2,16 @@ package testdatasource
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
 
 	"github.com/grafana/grafana/pkg/tsdb/grafana-testdata-datasource/kinds"
@@ -24,6 +27,8 @@ func (s *Service) registerRoutes() *http.ServeMux {
 	mux.HandleFunc("/boom", s.testPanicHandler)
 	mux.HandleFunc("/sims", s.sims.GetSimulationHandler)
 	mux.HandleFunc("/sim/", s.sims.GetSimulationHandler)
+	mux.HandleFunc("/fixtures", s.getFixturesHandler)
+	mux.HandleFunc("/fixtures/", s.fixtureHandler)
 	return mux
 }
 
@@ -159,3 +164,106 @@ func createJSONHandler(logger log.Logger) http.Handler {
 func (s *Service) testPanicHandler(rw http.ResponseWriter, req *http.Request) {
 	panic("BOOM")
 }
+
+func (s *Service) getFixturesHandler(rw http.ResponseWriter, req *http.Request) {
+	ctxLogger := s.logger.FromContext(req.Context())
+
+	orgID := backend.PluginConfigFromContext(req.Context()).OrgID
+	names, err := s.fixtures.list(orgID)
+	if err != nil {
+		ctxLogger.Error("Failed to list fixtures", "error", err)
+		http.Error(rw, "failed to list fixtures", http.StatusInternalServerError)
+		return
+	}
+
+	bytes, err := json.Marshal(names)
+	if err != nil {
+		ctxLogger.Error("Failed to marshal response body to JSON", "error", err)
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	if _, err := rw.Write(bytes); err != nil {
+		ctxLogger.Error("Failed to write response", "error", err)
+	}
+}
+
+const (
+	// maxFixtureSize is the maximum size of a captured query data response.
+	maxFixtureSize = 10 << 20
+	// adminRole is the organization role of the user in the plugin context required to capture fixtures.
+	adminRole = "Admin"
+)
+
+// fixtureHandler returns a fixture on GET and captures the query data response in the request
+// body as a fixture on POST, or replaces a fixture on PUT. Capturing requires fixture capture to be
+// enabled in the configuration and the Admin role, since fixtures are written to the server disk.
+// Fixtures are captured for the organization of the request.
+func (s *Service) fixtureHandler(rw http.ResponseWriter, req *http.Request) {
+	ctxLogger := s.logger.FromContext(req.Context())
+	ctxLogger.Debug("Received resource call", "url", req.URL.String(), "method", req.Method)
+	name := strings.TrimPrefix(req.URL.Path, "/fixtures/")
+	pluginCtx := backend.PluginConfigFromContext(req.Context())
+
+	switch req.Method {
+	case http.MethodGet:
+		res, err := s.fixtures.load(pluginCtx.OrgID, name)
+		if errors.Is(err, errFixtureNotFound) {
+			http.Error(rw, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		bytes, err := json.Marshal(res)
+		if err != nil {
+			ctxLogger.Error("Failed to marshal response body to JSON", "error", err)
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(http.StatusOK)
+		if _, err := rw.Write(bytes); err != nil {
+			ctxLogger.Error("Failed to write response", "error", err)
+		}
+	case http.MethodPost, http.MethodPut:
+		if !s.fixtures.canCapture() {
+			http.Error(rw, errFixtureCaptureDisabled.Error(), http.StatusForbidden)
+			return
+		}
+		if user := pluginCtx.User; user == nil || user.Role != adminRole {
+			http.Error(rw, "capturing fixtures requires the Admin role", http.StatusForbidden)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(rw, req.Body, maxFixtureSize))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(rw, fmt.Sprintf("fixtures cannot be larger than %d bytes", maxFixtureSize), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		res, err := decodeFixture(body)
+		if err != nil {
+			http.Error(rw, fmt.Sprintf("invalid query data response: %v", err), http.StatusBadRequest)
+			return
+		}
+		err = s.fixtures.save(pluginCtx.OrgID, name, res, req.Method == http.MethodPut)
+		if errors.Is(err, errFixtureExists) {
+			http.Error(rw, err.Error(), http.StatusConflict)
+			return
+		}
+		if err != nil {
+			ctxLogger.Error("Failed to save fixture", "error", err, "fixture", name, "orgId", pluginCtx.OrgID)
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		rw.WriteHeader(http.StatusNoContent)
+	default:
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
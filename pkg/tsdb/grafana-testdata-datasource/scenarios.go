@@ -182,6 +182,13 @@ Timestamps will line up evenly on timeStepSeconds (For example, 60 seconds means
 		Name: "Raw Frames",
 	})
 
+	s.registerScenario(&Scenario{
+		ID:          kinds.TestDataQueryTypeReplay,
+		Name:        "Replay",
+		handler:     s.handleReplayScenario,
+		Description: "Replays frames captured from another data source, moved to the query time range",
+	})
+
 	s.registerScenario(&Scenario{
 		ID:      kinds.TestDataQueryTypeCsvFile,
 		Name:    "CSV File",
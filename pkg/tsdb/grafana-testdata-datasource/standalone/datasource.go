@@ -16,7 +16,7 @@ var (
 
 func NewDatasource(context.Context, backend.DataSourceInstanceSettings) (instancemgmt.Instance, error) {
 	return &Datasource{
-		Service: testdatasource.ProvideService(),
+		Service: testdatasource.NewService(""),
 	}, nil
 }
 
@@ -2,6 +2,8 @@ package testdatasource
 
 import (
 	"context"
+	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
@@ -10,14 +12,26 @@ import (
 	"github.com/grafana/grafana-plugin-sdk-go/backend/resource/httpadapter"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 
+	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/tsdb/grafana-testdata-datasource/kinds"
 	"github.com/grafana/grafana/pkg/tsdb/grafana-testdata-datasource/sims"
 )
 
+const pluginID = "grafana-testdata-datasource"
+
 // ensures that testdata implements all client functions
 // var _ plugins.Client = &Service{}
 
-func ProvideService() *Service {
+// ProvideService creates the testdata service. Fixture capture is disabled unless the fixture_capture_enabled
+// setting of the [plugin.grafana-testdata-datasource] section is set.
+func ProvideService(cfg *setting.Cfg) *Service {
+	s := NewService(filepath.Join(cfg.DataPath, "testdata", "fixtures"))
+	s.fixtures.captureEnabled, _ = strconv.ParseBool(cfg.PluginSettings[pluginID]["fixture_capture_enabled"])
+	return s
+}
+
+// NewService creates the testdata service with fixture capture disabled. Captured fixtures are read from fixturesDir.
+func NewService(fixturesDir string) *Service {
 	s := &Service{
 		queryMux:  datasource.NewQueryTypeMux(),
 		scenarios: map[kinds.TestDataQueryType]*Scenario{},
@@ -32,7 +46,8 @@ func ProvideService() *Service {
 			data.NewField("Time", nil, make([]time.Time, 1)),
 			data.NewField("Value", nil, make([]float64, 1)),
 		),
-		logger: backend.NewLoggerWith("logger", "tsdb.testdata"),
+		logger:   backend.NewLoggerWith("logger", "tsdb.testdata"),
+		fixtures: &fixtureStore{dir: fixturesDir},
 	}
 
 	var err error
@@ -62,6 +77,7 @@ type Service struct {
 	queryMux        *datasource.QueryTypeMux
 	resourceHandler backend.CallResourceHandler
 	sims            *sims.SimulationEngine
+	fixtures        *fixtureStore
 }
 
 func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
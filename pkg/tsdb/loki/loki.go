@@ -12,6 +12,7 @@ import (
 	"github.com/grafana/dskit/concurrency"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/datasource"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/instancemgmt"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
@@ -63,6 +64,11 @@ type datasourceInfo struct {
 	HTTPClient *http.Client
 	URL        string
 
+	// long range queries are split into shards of SplitDuration, at most
+	// SplitConcurrency shards of a query run at the same time
+	SplitDuration    time.Duration
+	SplitConcurrency int
+
 	// open streams
 	streams   map[string]data.FrameJSONCache
 	streamsMu sync.RWMutex
@@ -72,6 +78,7 @@ type QueryJSONModel struct {
 	dataquery.LokiDataQuery
 	Direction           *string `json:"direction,omitempty"`
 	SupportingQueryType *string `json:"supportingQueryType"`
+	SplitDuration       *string `json:"splitDuration,omitempty"`
 }
 
 type ResponseOpts struct {
@@ -98,10 +105,30 @@ func newInstanceSettings(httpClientProvider *httpclient.Provider) datasource.Ins
 			return nil, err
 		}
 
+		jsonData := struct {
+			SplitDuration    string `json:"splitDuration"`
+			SplitConcurrency int    `json:"splitConcurrency"`
+		}{}
+		if len(settings.JSONData) > 0 {
+			if err := json.Unmarshal(settings.JSONData, &jsonData); err != nil {
+				return nil, fmt.Errorf("error reading settings: %w", err)
+			}
+		}
+
+		var splitDuration time.Duration
+		if jsonData.SplitDuration != "" {
+			splitDuration, err = gtime.ParseIntervalStringToTimeDuration(jsonData.SplitDuration)
+			if err != nil {
+				return nil, fmt.Errorf("invalid split duration %q: %w", jsonData.SplitDuration, err)
+			}
+		}
+
 		model := &datasourceInfo{
-			HTTPClient: client,
-			URL:        settings.URL,
-			streams:    make(map[string]data.FrameJSONCache),
+			HTTPClient:       client,
+			URL:              settings.URL,
+			SplitDuration:    splitDuration,
+			SplitConcurrency: jsonData.SplitConcurrency,
+			streams:          make(map[string]data.FrameJSONCache),
 		}
 		return model, nil
 	}
@@ -217,7 +244,7 @@ func queryData(ctx context.Context, req *backend.QueryDataRequest, dsInfo *datas
 		resultLock := sync.Mutex{}
 		err = concurrency.ForEachJob(ctx, len(queries), 10, func(ctx context.Context, idx int) error {
 			query := queries[idx]
-			queryRes := executeQuery(ctx, query, req, runInParallel, api, responseOpts, tracer, plog)
+			queryRes := executeQuery(ctx, query, req, runInParallel, api, dsInfo, responseOpts, tracer, plog)
 
 			resultLock.Lock()
 			defer resultLock.Unlock()
@@ -226,7 +253,7 @@ func queryData(ctx context.Context, req *backend.QueryDataRequest, dsInfo *datas
 		})
 	} else {
 		for _, query := range queries {
-			queryRes := executeQuery(ctx, query, req, runInParallel, api, responseOpts, tracer, plog)
+			queryRes := executeQuery(ctx, query, req, runInParallel, api, dsInfo, responseOpts, tracer, plog)
 			result.Responses[query.RefID] = queryRes
 		}
 	}
@@ -234,7 +261,7 @@ func queryData(ctx context.Context, req *backend.QueryDataRequest, dsInfo *datas
 	return result, err
 }
 
-func executeQuery(ctx context.Context, query *lokiQuery, req *backend.QueryDataRequest, runInParallel bool, api *LokiAPI, responseOpts ResponseOpts, tracer tracing.Tracer, plog log.Logger) backend.DataResponse {
+func executeQuery(ctx context.Context, query *lokiQuery, req *backend.QueryDataRequest, runInParallel bool, api *LokiAPI, dsInfo *datasourceInfo, responseOpts ResponseOpts, tracer tracing.Tracer, plog log.Logger) backend.DataResponse {
 	ctx, span := tracer.Start(ctx, "datasource.loki.queryData.runQueries.runQuery", trace.WithAttributes(
 		attribute.Bool("runInParallel", runInParallel),
 		attribute.String("expr", query.Expr),
@@ -247,7 +274,11 @@ func executeQuery(ctx context.Context, query *lokiQuery, req *backend.QueryDataR
 
 	defer span.End()
 
-	queryRes, err := runQuery(ctx, api, query, responseOpts, plog)
+	if query.SplitDuration == 0 {
+		query.SplitDuration = dsInfo.SplitDuration
+	}
+	_, fromAlert := req.Headers[ngalertmodels.FromAlertHeaderName]
+	queryRes, err := runSplitQuery(ctx, api, query, responseOpts, dsInfo.SplitConcurrency, fromAlert, plog)
 	if queryRes == nil {
 		// we always want to return a backend.DataResponse object, even if we received just an error
 		queryRes = &backend.DataResponse{}
@@ -173,6 +173,14 @@ func parseQuery(queryContext *backend.QueryDataRequest) ([]*lokiQuery, error) {
 
 		supportingQueryType := parseSupportingQueryType(model.SupportingQueryType)
 
+		var splitDuration time.Duration
+		if model.SplitDuration != nil && *model.SplitDuration != "" {
+			splitDuration, err = gtime.ParseIntervalStringToTimeDuration(*model.SplitDuration)
+			if err != nil {
+				return nil, fmt.Errorf("invalid splitDuration: %w", err)
+			}
+		}
+
 		qs = append(qs, &lokiQuery{
 			Expr:                expr,
 			QueryType:           queryType,
@@ -184,6 +192,7 @@ func parseQuery(queryContext *backend.QueryDataRequest) ([]*lokiQuery, error) {
 			End:                 end,
 			RefID:               query.RefID,
 			SupportingQueryType: supportingQueryType,
+			SplitDuration:       splitDuration,
 		})
 	}
 
@@ -36,6 +36,31 @@ func TestParseQuery(t *testing.T) {
 		require.Equal(t, "go_goroutines 15s 15000 3000s 3000 3000000", models[0].Expr)
 	})
 
+	t.Run("parsing query model with split duration", func(t *testing.T) {
+		queryContext := &backend.QueryDataRequest{
+			Queries: []backend.DataQuery{
+				{
+					JSON: []byte(`
+					{
+						"expr": "{app=\"x\"}",
+						"refId": "A",
+						"splitDuration": "1d"
+					}`,
+					),
+					TimeRange: backend.TimeRange{
+						From: time.Now().Add(-3000 * time.Second),
+						To:   time.Now(),
+					},
+					Interval:      time.Second * 15,
+					MaxDataPoints: 200,
+				},
+			},
+		}
+		models, err := parseQuery(queryContext)
+		require.NoError(t, err)
+		require.Equal(t, 24*time.Hour, models[0].SplitDuration)
+	})
+
 	t.Run("parsing query model with logsVolume supporting query type", func(t *testing.T) {
 		queryContext := &backend.QueryDataRequest{
 			Queries: []backend.DataQuery{
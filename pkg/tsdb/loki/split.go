@@ -0,0 +1,398 @@
+package loki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/grafana/dskit/concurrency"
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/grafana/loki/v3/pkg/logql/syntax"
+)
+
+const defaultSplitConcurrency = 4
+
+type timeShard struct {
+	Start time.Time
+	End   time.Time
+}
+
+// parseQueryKind reports whether the expression selects log lines or computes a metric, from
+// the parsed expression. ok is false when the expression can't be parsed.
+func parseQueryKind(expr string) (logs bool, ok bool) {
+	syntaxTree, err := syntax.ParseExprWithoutValidation(expr)
+	if err != nil {
+		return false, false
+	}
+	_, logs = syntaxTree.(syntax.LogSelectorExpr)
+	return logs, true
+}
+
+// isLogsQuery reports whether the expression selects log lines.
+func isLogsQuery(expr string) bool {
+	logs, _ := parseQueryKind(expr)
+	return logs
+}
+
+// splitMetricTimeRange splits the range into chunks of roughly idealDuration. Every chunk
+// starts at start plus a multiple of the step, so that the chunks evaluate exactly the
+// timestamps the full query would. Chunks do not overlap: each one ends a step before the
+// next one starts.
+func splitMetricTimeRange(start, end time.Time, step, idealDuration time.Duration) []timeShard {
+	if step <= 0 || idealDuration < step {
+		return []timeShard{{Start: start, End: end}}
+	}
+
+	alignedDuration := idealDuration / step * step
+
+	shards := []timeShard{}
+	for s := start; !s.After(end); s = s.Add(alignedDuration) {
+		e := s.Add(alignedDuration - step)
+		if e.After(end) {
+			e = end
+		}
+		shards = append(shards, timeShard{Start: s, End: e})
+	}
+	return shards
+}
+
+// splitLogsTimeRange splits the range into contiguous chunks of idealDuration, walking
+// backwards from the end so that the shortest chunk is the oldest one.
+func splitLogsTimeRange(start, end time.Time, idealDuration time.Duration) []timeShard {
+	if idealDuration <= 0 {
+		return []timeShard{{Start: start, End: end}}
+	}
+
+	shards := []timeShard{}
+	for e := end; e.After(start); e = e.Add(-idealDuration) {
+		s := e.Add(-idealDuration)
+		if s.Before(start) {
+			s = start
+		}
+		shards = append([]timeShard{{Start: s, End: e}}, shards...)
+	}
+	return shards
+}
+
+func splitQuery(query *lokiQuery) []*lokiQuery {
+	if query.QueryType != QueryTypeRange || query.SplitDuration <= 0 || query.End.Sub(query.Start) <= query.SplitDuration {
+		return []*lokiQuery{query}
+	}
+
+	// expressions that can't be parsed run unsplit, so that Loki reports the error once
+	logs, ok := parseQueryKind(query.Expr)
+	if !ok {
+		return []*lokiQuery{query}
+	}
+
+	var shards []timeShard
+	if logs {
+		shards = splitLogsTimeRange(query.Start, query.End, query.SplitDuration)
+	} else {
+		shards = splitMetricTimeRange(query.Start, query.End, query.Step, query.SplitDuration)
+	}
+
+	queries := make([]*lokiQuery, 0, len(shards))
+	for _, shard := range shards {
+		q := *query
+		q.Start = shard.Start
+		q.End = shard.End
+		queries = append(queries, &q)
+	}
+	return queries
+}
+
+// runSplitQuery runs the query in time shards when it spans more than its split duration and
+// merges the shard results. When only some of the shards fail the merged frames are returned
+// with a warning notice, unless the query is evaluated by an alert rule: alert rules must not
+// evaluate partial results, so any failing shard fails the query. When all shards fail the
+// first error is returned.
+func runSplitQuery(ctx context.Context, api *LokiAPI, query *lokiQuery, responseOpts ResponseOpts, concurrencyLimit int, fromAlert bool, plog log.Logger) (*backend.DataResponse, error) {
+	shards := splitQuery(query)
+	if len(shards) == 1 {
+		return runQuery(ctx, api, query, responseOpts, plog)
+	}
+
+	plog.Debug("Splitting Loki query into time shards", "shards", len(shards), "splitDuration", query.SplitDuration)
+
+	var responses []*backend.DataResponse
+	var errs []error
+	if isLogsQuery(query.Expr) {
+		responses, errs = runLogsShards(ctx, api, query, shards, responseOpts, plog)
+	} else {
+		var err error
+		responses, errs, err = runMetricShards(ctx, api, shards, responseOpts, concurrencyLimit, plog)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return mergeShardResponses(query, responses, errs, fromAlert), nil
+}
+
+// runMetricShards runs the shards of a metric query concurrently, the results are ordered oldest first.
+func runMetricShards(ctx context.Context, api *LokiAPI, shards []*lokiQuery, responseOpts ResponseOpts, concurrencyLimit int, plog log.Logger) ([]*backend.DataResponse, []error, error) {
+	if concurrencyLimit <= 0 {
+		concurrencyLimit = defaultSplitConcurrency
+	}
+
+	responses := make([]*backend.DataResponse, len(shards))
+	errs := make([]error, len(shards))
+	err := concurrency.ForEachJob(ctx, len(shards), concurrencyLimit, func(ctx context.Context, idx int) error {
+		responses[idx], errs[idx] = runShard(ctx, api, shards[idx], responseOpts, plog)
+		return nil // errors are saved per-shard, always return nil
+	})
+	return responses, errs, err
+}
+
+// runLogsShards runs the shards of a logs query one after another in query direction, newest first
+// unless the direction is forward, and stops once the shards returned enough lines. The results are
+// ordered in query direction.
+func runLogsShards(ctx context.Context, api *LokiAPI, query *lokiQuery, shards []*lokiQuery, responseOpts ResponseOpts, plog log.Logger) ([]*backend.DataResponse, []error) {
+	if query.Direction != DirectionForward {
+		reversed := make([]*lokiQuery, len(shards))
+		for i, shard := range shards {
+			reversed[len(shards)-1-i] = shard
+		}
+		shards = reversed
+	}
+
+	responses := make([]*backend.DataResponse, 0, len(shards))
+	errs := make([]error, 0, len(shards))
+	for _, shard := range shards {
+		res, err := runShard(ctx, api, shard, responseOpts, plog)
+		responses, errs = append(responses, res), append(errs, err)
+		if query.MaxLines <= 0 || err != nil {
+			continue
+		}
+		succeeded := make([]data.Frames, 0, len(responses))
+		for i, res := range responses {
+			if errs[i] == nil && res != nil {
+				succeeded = append(succeeded, res.Frames)
+			}
+		}
+		if merged := mergeLogsFrames(succeeded, query.MaxLines); len(merged) > 0 && merged[0].Rows() >= query.MaxLines {
+			plog.Debug("Stopped running Loki query shards after reaching the line limit", "shards", len(shards), "ran", len(responses))
+			break
+		}
+	}
+	return responses, errs
+}
+
+func runShard(ctx context.Context, api *LokiAPI, shard *lokiQuery, responseOpts ResponseOpts, plog log.Logger) (*backend.DataResponse, error) {
+	res, err := runQuery(ctx, api, shard, responseOpts, plog)
+	if err == nil && res != nil && res.Error != nil {
+		// loki errors are reported in the response
+		err = res.Error
+	}
+	return res, err
+}
+
+// mergeShardResponses merges the responses of the shards that ran, ordered oldest first for
+// metric queries and in query direction for logs queries.
+func mergeShardResponses(query *lokiQuery, responses []*backend.DataResponse, errs []error, fromAlert bool) *backend.DataResponse {
+	var failed []error
+	var firstFailed *backend.DataResponse
+	succeeded := make([]data.Frames, 0, len(responses))
+	for i, res := range responses {
+		if errs[i] != nil {
+			if firstFailed == nil {
+				firstFailed = res
+				if firstFailed == nil {
+					firstFailed = &backend.DataResponse{}
+				}
+				firstFailed.Error = errs[i]
+			}
+			failed = append(failed, errs[i])
+			continue
+		}
+		if res != nil {
+			succeeded = append(succeeded, res.Frames)
+		}
+	}
+
+	if len(failed) == len(responses) || (fromAlert && len(failed) > 0) {
+		return firstFailed
+	}
+
+	var frames data.Frames
+	if isLogsQuery(query.Expr) {
+		frames = mergeLogsFrames(succeeded, query.MaxLines)
+	} else {
+		frames = mergeMetricFrames(succeeded)
+	}
+
+	if len(failed) > 0 {
+		if len(frames) == 0 {
+			frames = data.Frames{data.NewFrame("")}
+		}
+		notice := data.Notice{
+			Severity: data.NoticeSeverityWarning,
+			Text:     fmt.Sprintf("Partial results: %d of %d time shards failed: %s", len(failed), len(responses), errors.Join(failed...)),
+		}
+		frames[0].AppendNotices(notice)
+	}
+
+	return &backend.DataResponse{Frames: frames}
+}
+
+// mergeMetricFrames concatenates the series returned by every shard. Shards are ordered by
+// time, so appending the rows keeps every series sorted.
+func mergeMetricFrames(shardFrames []data.Frames) data.Frames {
+	merged := data.Frames{}
+	byKey := map[string]*data.Frame{}
+	for _, frames := range shardFrames {
+		for _, frame := range frames {
+			key := seriesKey(frame)
+			existing, ok := byKey[key]
+			if !ok || !sameFieldTypes(existing, frame) {
+				byKey[key] = frame
+				merged = append(merged, frame)
+				continue
+			}
+			appendRows(existing, frame, nil)
+		}
+	}
+	return merged
+}
+
+func seriesKey(frame *data.Frame) string {
+	key := frame.Name
+	for _, field := range frame.Fields {
+		if field.Labels != nil {
+			key += field.Labels.String()
+		}
+	}
+	return key
+}
+
+// mergeLogsFrames combines the log lines of every shard into the first frame, dropping lines
+// returned by more than one shard and keeping at most maxLines lines. The shards must be
+// ordered in query direction. The stats of the shards are added up.
+func mergeLogsFrames(shardFrames []data.Frames, maxLines int) data.Frames {
+	var merged *data.Frame
+	seen := map[string]struct{}{}
+	for _, frames := range shardFrames {
+		for _, frame := range frames {
+			if merged == nil {
+				merged = data.NewFrame(frame.Name)
+				merged.Meta = frame.Meta
+				for _, field := range frame.Fields {
+					f := data.NewFieldFromFieldType(field.Type(), 0)
+					f.Name = field.Name
+					f.Labels = field.Labels
+					f.Config = field.Config
+					merged.Fields = append(merged.Fields, f)
+				}
+			}
+			if !sameFieldTypes(merged, frame) {
+				continue
+			}
+			appendRows(merged, frame, func(row int) bool {
+				if maxLines > 0 && merged.Rows() >= maxLines {
+					return false
+				}
+				id, ok := logLineID(frame, row)
+				if !ok {
+					return true
+				}
+				if _, dup := seen[id]; dup {
+					return false
+				}
+				seen[id] = struct{}{}
+				return true
+			})
+		}
+	}
+
+	if merged == nil {
+		return data.Frames{}
+	}
+
+	shardStats := make([][]data.QueryStat, 0, len(shardFrames))
+	for _, frames := range shardFrames {
+		// every frame of a shard carries the stats of the whole shard
+		if len(frames) > 0 && frames[0].Meta != nil {
+			shardStats = append(shardStats, frames[0].Meta.Stats)
+		}
+	}
+	meta := data.FrameMeta{}
+	if merged.Meta != nil {
+		meta = *merged.Meta
+	}
+	meta.Stats = mergeStats(shardStats)
+	merged.Meta = &meta
+	return data.Frames{merged}
+}
+
+// mergeStats adds up the stats of the shards. The rates are computed again from the added up
+// totals and execution time, since the shards run one after another.
+func mergeStats(shardStats [][]data.QueryStat) []data.QueryStat {
+	var merged []data.QueryStat
+	index := map[string]int{}
+	for _, stats := range shardStats {
+		for _, stat := range stats {
+			i, ok := index[stat.DisplayName]
+			if !ok {
+				index[stat.DisplayName] = len(merged)
+				merged = append(merged, stat)
+				continue
+			}
+			merged[i].Value += stat.Value
+		}
+	}
+
+	value := func(name string) float64 {
+		if i, ok := index[name]; ok {
+			return merged[i].Value
+		}
+		return 0
+	}
+	execTime := value("Summary: exec time")
+	rates := map[string]string{
+		"Summary: bytes processed per second": "Summary: total bytes processed",
+		"Summary: lines processed per second": "Summary: total lines processed",
+	}
+	for rate, total := range rates {
+		if i, ok := index[rate]; ok && execTime > 0 {
+			merged[i].Value = value(total) / execTime
+		}
+	}
+	return merged
+}
+
+func logLineID(frame *data.Frame, row int) (string, bool) {
+	field, idx := frame.FieldByName("id")
+	if idx == -1 || field.Type() != data.FieldTypeString {
+		return "", false
+	}
+	return field.At(row).(string), true
+}
+
+func sameFieldTypes(a, b *data.Frame) bool {
+	if len(a.Fields) != len(b.Fields) {
+		return false
+	}
+	for i := range a.Fields {
+		if a.Fields[i].Type() != b.Fields[i].Type() {
+			return false
+		}
+	}
+	return true
+}
+
+// appendRows appends the rows of src to dst, skipping the rows for which keep returns false.
+func appendRows(dst, src *data.Frame, keep func(row int) bool) {
+	rows := src.Rows()
+	for row := 0; row < rows; row++ {
+		if keep != nil && !keep(row) {
+			continue
+		}
+		for i, field := range src.Fields {
+			dst.Fields[i].Append(field.At(row))
+		}
+	}
+}
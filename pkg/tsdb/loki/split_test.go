@@ -0,0 +1,290 @@
+package loki
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/tracing"
+)
+
+func TestSplitMetricTimeRange(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 30, 0, time.UTC)
+	end := time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)
+
+	t.Run("aligns shards to the query start", func(t *testing.T) {
+		shards := splitMetricTimeRange(start, end, time.Minute, time.Hour)
+		require.Equal(t, []timeShard{
+			{Start: start, End: time.Date(2024, 1, 1, 0, 59, 30, 0, time.UTC)},
+			{Start: time.Date(2024, 1, 1, 1, 0, 30, 0, time.UTC), End: time.Date(2024, 1, 1, 1, 59, 30, 0, time.UTC)},
+			{Start: time.Date(2024, 1, 1, 2, 0, 30, 0, time.UTC), End: time.Date(2024, 1, 1, 2, 59, 30, 0, time.UTC)},
+		}, shards)
+	})
+
+	t.Run("evaluates the same timestamps as the unsplit query", func(t *testing.T) {
+		for _, step := range []time.Duration{time.Minute, 7 * time.Minute, 13 * time.Second} {
+			for _, end := range []time.Time{end, end.Add(-step), end.Add(step / 2)} {
+				var unsplit []time.Time
+				for ts := start; !ts.After(end); ts = ts.Add(step) {
+					unsplit = append(unsplit, ts)
+				}
+
+				var split []time.Time
+				for _, shard := range splitMetricTimeRange(start, end, step, time.Hour) {
+					require.False(t, shard.Start.Before(start))
+					require.False(t, shard.End.After(end))
+					for ts := shard.Start; !ts.After(shard.End); ts = ts.Add(step) {
+						split = append(split, ts)
+					}
+				}
+				require.Equal(t, unsplit, split, "step %s, end %s", step, end)
+			}
+		}
+	})
+
+	t.Run("rounds the shard duration down to a multiple of the step", func(t *testing.T) {
+		shards := splitMetricTimeRange(start, end, 7*time.Minute, time.Hour)
+		require.Equal(t, 56*time.Minute, shards[1].Start.Sub(shards[0].Start))
+	})
+
+	t.Run("does not split when the step is larger than the shard duration", func(t *testing.T) {
+		shards := splitMetricTimeRange(start, end, 2*time.Hour, time.Hour)
+		require.Equal(t, []timeShard{{Start: start, End: end}}, shards)
+	})
+}
+
+func TestSplitLogsTimeRange(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)
+
+	shards := splitLogsTimeRange(start, end, time.Hour)
+	require.Equal(t, []timeShard{
+		{Start: start, End: time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)},
+		{Start: time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)},
+		{Start: time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC), End: end},
+	}, shards)
+}
+
+func TestSplitQuery(t *testing.T) {
+	query := &lokiQuery{
+		Expr:          `rate({app="x"}[1m])`,
+		QueryType:     QueryTypeRange,
+		Step:          time.Minute,
+		Start:         time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:           time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC),
+		SplitDuration: time.Hour,
+	}
+
+	t.Run("splits range queries", func(t *testing.T) {
+		// the end of the range is evaluated by a last shard of a single step
+		require.Len(t, splitQuery(query), 4)
+	})
+
+	t.Run("does not split instant queries", func(t *testing.T) {
+		q := *query
+		q.QueryType = QueryTypeInstant
+		require.Equal(t, []*lokiQuery{&q}, splitQuery(&q))
+	})
+
+	t.Run("does not split when splitting is disabled or the range is short", func(t *testing.T) {
+		q := *query
+		q.SplitDuration = 0
+		require.Len(t, splitQuery(&q), 1)
+
+		q.SplitDuration = 3 * time.Hour
+		require.Len(t, splitQuery(&q), 1)
+	})
+}
+
+func TestIsLogsQuery(t *testing.T) {
+	require.True(t, isLogsQuery(`{app="x"}`))
+	require.True(t, isLogsQuery(` {app="x"} |= "error" | json`))
+	require.False(t, isLogsQuery(`rate({app="x"}[1m])`))
+	require.False(t, isLogsQuery(`sum by (app) (count_over_time({app="x"}[1m]))`))
+	require.False(t, isLogsQuery(`{app="x"`))
+}
+
+func TestMergeLogsFrames(t *testing.T) {
+	shard := func(id string, totalBytes, execTime float64) data.Frames {
+		frame := data.NewFrame("", data.NewField("id", nil, []string{id}))
+		frame.Meta = &data.FrameMeta{Stats: []data.QueryStat{
+			{FieldConfig: data.FieldConfig{DisplayName: "Summary: bytes processed per second"}, Value: totalBytes / execTime},
+			{FieldConfig: data.FieldConfig{DisplayName: "Summary: total bytes processed"}, Value: totalBytes},
+			{FieldConfig: data.FieldConfig{DisplayName: "Summary: exec time"}, Value: execTime},
+		}}
+		return data.Frames{frame}
+	}
+
+	frames := mergeLogsFrames([]data.Frames{shard("a", 100, 1), shard("b", 300, 3)}, 0)
+	require.Len(t, frames, 1)
+	require.Equal(t, 2, frames[0].Rows())
+	require.Equal(t, []float64{100, 400, 4}, []float64{frames[0].Meta.Stats[0].Value, frames[0].Meta.Stats[1].Value, frames[0].Meta.Stats[2].Value})
+}
+
+// shardRoundTripper answers every request with the response returned by respond for the
+// requested time range.
+type shardRoundTripper struct {
+	respond func(start, end time.Time) (int, string)
+}
+
+func (rt *shardRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	parse := func(name string) time.Time {
+		ns, _ := strconv.ParseInt(req.URL.Query().Get(name), 10, 64)
+		return time.Unix(0, ns).UTC()
+	}
+	status, body := rt.respond(parse("start"), parse("end"))
+	header := http.Header{}
+	header.Add("Content-Type", "application/json")
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+	}, nil
+}
+
+func makeShardedAPI(respond func(start, end time.Time) (int, string)) *LokiAPI {
+	client := http.Client{Transport: &shardRoundTripper{respond: respond}}
+	return newLokiAPI(&client, "http://localhost:9999", backend.NewLoggerWith("logger", "test"), tracing.InitializeTracerForTest(), false)
+}
+
+func matrixResponse(start, end time.Time) string {
+	var values []string
+	for ts := start; !ts.After(end); ts = ts.Add(time.Hour) {
+		values = append(values, fmt.Sprintf(`[%d,"1"]`, ts.Unix()))
+	}
+	return fmt.Sprintf(`{"status":"success","data":{"resultType":"matrix","result":[{"metric":{"app":"x"},"values":[%s]}]}}`, join(values))
+}
+
+func streamsResponse(lines map[time.Time]string, start, end time.Time) string {
+	var values []string
+	for ts := end; !ts.Before(start); ts = ts.Add(-time.Minute) {
+		if line, ok := lines[ts]; ok {
+			values = append(values, fmt.Sprintf(`["%d","%s"]`, ts.UnixNano(), line))
+		}
+	}
+	return fmt.Sprintf(`{"status":"success","data":{"resultType":"streams","result":[{"stream":{"app":"x"},"values":[%s]}]}}`, join(values))
+}
+
+func join(values []string) string {
+	var buf bytes.Buffer
+	for i, v := range values {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString(v)
+	}
+	return buf.String()
+}
+
+func TestRunSplitQuery(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC)
+	logger := backend.NewLoggerWith("logger", "test")
+
+	t.Run("concatenates metric series", func(t *testing.T) {
+		api := makeShardedAPI(func(start, end time.Time) (int, string) {
+			return http.StatusOK, matrixResponse(start, end)
+		})
+		query := &lokiQuery{Expr: `rate({app="x"}[1m])`, QueryType: QueryTypeRange, Step: time.Hour, Start: start, End: end, SplitDuration: 2 * time.Hour}
+
+		res, err := runSplitQuery(context.Background(), api, query, ResponseOpts{}, 2, false, logger)
+		require.NoError(t, err)
+		require.Len(t, res.Frames, 1)
+		require.Equal(t, 7, res.Frames[0].Rows())
+		require.Equal(t, start, res.Frames[0].Fields[0].At(0))
+		require.Equal(t, end, res.Frames[0].Fields[0].At(6))
+	})
+
+	t.Run("deduplicates log lines and keeps the newest lines", func(t *testing.T) {
+		lines := map[time.Time]string{}
+		for i := 0; i <= 6; i++ {
+			ts := start.Add(time.Duration(i) * time.Hour)
+			lines[ts] = fmt.Sprintf("line %d", i)
+		}
+		api := makeShardedAPI(func(start, end time.Time) (int, string) {
+			return http.StatusOK, streamsResponse(lines, start, end)
+		})
+		query := &lokiQuery{Expr: `{app="x"}`, QueryType: QueryTypeRange, Direction: DirectionBackward, MaxLines: 5, Step: time.Minute, Start: start, End: end, SplitDuration: 2 * time.Hour}
+
+		res, err := runSplitQuery(context.Background(), api, query, ResponseOpts{}, 2, false, logger)
+		require.NoError(t, err)
+		require.Len(t, res.Frames, 1)
+		lineField, _ := res.Frames[0].FieldByName("Line")
+		require.Equal(t, 5, lineField.Len())
+		for i := 0; i < 5; i++ {
+			require.Equal(t, fmt.Sprintf("line %d", 6-i), lineField.At(i))
+		}
+	})
+
+	t.Run("returns partial results with a warning when some shards fail", func(t *testing.T) {
+		api := makeShardedAPI(func(s, e time.Time) (int, string) {
+			if s.Equal(start) {
+				return http.StatusBadRequest, `{"status":"error","error":"query too large"}`
+			}
+			return http.StatusOK, matrixResponse(s, e)
+		})
+		query := &lokiQuery{Expr: `rate({app="x"}[1m])`, QueryType: QueryTypeRange, Step: time.Hour, Start: start, End: end, SplitDuration: 2 * time.Hour}
+
+		res, err := runSplitQuery(context.Background(), api, query, ResponseOpts{}, 2, false, logger)
+		require.NoError(t, err)
+		require.NoError(t, res.Error)
+		require.Len(t, res.Frames, 1)
+		require.Equal(t, 5, res.Frames[0].Rows())
+		require.Len(t, res.Frames[0].Meta.Notices, 1)
+		require.Equal(t, data.NoticeSeverityWarning, res.Frames[0].Meta.Notices[0].Severity)
+		require.Contains(t, res.Frames[0].Meta.Notices[0].Text, "1 of 4 time shards failed")
+	})
+
+	t.Run("runs log shards newest first and stops at the line limit", func(t *testing.T) {
+		lines := map[time.Time]string{}
+		for i := 0; i <= 6; i++ {
+			ts := start.Add(time.Duration(i) * time.Hour)
+			lines[ts] = fmt.Sprintf("line %d", i)
+		}
+		var requested []time.Time
+		api := makeShardedAPI(func(start, end time.Time) (int, string) {
+			requested = append(requested, end)
+			return http.StatusOK, streamsResponse(lines, start, end)
+		})
+		query := &lokiQuery{Expr: `{app="x"}`, QueryType: QueryTypeRange, Direction: DirectionBackward, MaxLines: 5, Step: time.Minute, Start: start, End: end, SplitDuration: 2 * time.Hour}
+
+		_, err := runSplitQuery(context.Background(), api, query, ResponseOpts{}, 2, false, logger)
+		require.NoError(t, err)
+		require.Equal(t, []time.Time{end, end.Add(-2 * time.Hour)}, requested)
+	})
+
+	t.Run("returns an error when a shard of an alert query fails", func(t *testing.T) {
+		api := makeShardedAPI(func(s, e time.Time) (int, string) {
+			if s.Equal(start) {
+				return http.StatusBadRequest, `{"status":"error","error":"query too large"}`
+			}
+			return http.StatusOK, matrixResponse(s, e)
+		})
+		query := &lokiQuery{Expr: `rate({app="x"}[1m])`, QueryType: QueryTypeRange, Step: time.Hour, Start: start, End: end, SplitDuration: 2 * time.Hour}
+
+		res, err := runSplitQuery(context.Background(), api, query, ResponseOpts{}, 2, true, logger)
+		require.NoError(t, err)
+		require.ErrorContains(t, res.Error, "query too large")
+		require.Empty(t, res.Frames)
+	})
+
+	t.Run("returns an error when all shards fail", func(t *testing.T) {
+		api := makeShardedAPI(func(time.Time, time.Time) (int, string) {
+			return http.StatusBadRequest, `{"status":"error","error":"query too large"}`
+		})
+		query := &lokiQuery{Expr: `rate({app="x"}[1m])`, QueryType: QueryTypeRange, Step: time.Hour, Start: start, End: end, SplitDuration: 2 * time.Hour}
+
+		res, err := runSplitQuery(context.Background(), api, query, ResponseOpts{}, 2, false, logger)
+		require.NoError(t, err)
+		require.ErrorContains(t, res.Error, "query too large")
+	})
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
 	"github.com/grafana/grafana/pkg/tsdb/mysql/sqleng"
+	"github.com/grafana/grafana/pkg/tsdb/sqlstream"
 )
 
 const (
@@ -134,6 +135,7 @@ func NewInstanceSettings(logger log.Logger) datasource.InstanceFactoryFunc {
 			TimeColumnNames:   []string{"time", "time_sec"},
 			MetricColumnTypes: []string{"CHAR", "VARCHAR", "TINYTEXT", "TEXT", "MEDIUMTEXT", "LONGTEXT"},
 			RowLimit:          sqlCfg.RowLimit,
+			StreamRowLimit:    sqlstream.RowLimit(cfg),
 		}
 
 		userFacingDefaultError, err := cfg.UserFacingDefaultError()
@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMacroEngine(t *testing.T) {
+	engine := newSQLiteMacroEngine()
+	query := &backend.DataQuery{}
+
+	from := time.Date(2018, 4, 12, 18, 0, 0, 0, time.UTC)
+	to := from.Add(5 * time.Minute)
+	timeRange := backend.TimeRange{From: from, To: to}
+
+	t.Run("interpolate __time function", func(t *testing.T) {
+		sql, err := engine.Interpolate(query, timeRange, "select $__time(time_column)")
+		require.Nil(t, err)
+
+		require.Equal(t, "select unixepoch(time_column, 'auto') AS time", sql)
+	})
+
+	t.Run("interpolate __timeGroup function", func(t *testing.T) {
+		sql, err := engine.Interpolate(query, timeRange, "GROUP BY $__timeGroup(time_column,'5m')")
+		require.Nil(t, err)
+		sql2, err := engine.Interpolate(query, timeRange, "GROUP BY $__timeGroupAlias(time_column , '5m')")
+		require.Nil(t, err)
+
+		require.Equal(t, "GROUP BY unixepoch(time_column, 'auto') / 300 * 300", sql)
+		require.Equal(t, sql+" AS \"time\"", sql2)
+	})
+
+	t.Run("interpolate __timeGroup function with a sub-second interval returns an error", func(t *testing.T) {
+		_, err := engine.Interpolate(query, timeRange, "GROUP BY $__timeGroup(time_column,'500ms')")
+		require.ErrorContains(t, err, "must be at least 1s")
+	})
+
+	t.Run("interpolate __timeGroup function with fill", func(t *testing.T) {
+		q := &backend.DataQuery{JSON: []byte("{}")}
+		_, err := engine.Interpolate(q, timeRange, "GROUP BY $__timeGroup(time_column,'5m', previous)")
+		require.Nil(t, err)
+
+		require.Contains(t, string(q.JSON), `"fillMode":"previous"`)
+	})
+
+	t.Run("interpolate __timeFilter function", func(t *testing.T) {
+		sql, err := engine.Interpolate(query, timeRange, "WHERE $__timeFilter(time_column)")
+		require.Nil(t, err)
+
+		require.Equal(t, fmt.Sprintf("WHERE unixepoch(time_column, 'auto') BETWEEN %d AND %d", from.Unix(), to.Unix()), sql)
+	})
+
+	t.Run("interpolate __timeFrom and __timeTo functions", func(t *testing.T) {
+		sql, err := engine.Interpolate(query, timeRange, "select $__timeFrom(), $__timeTo()")
+		require.Nil(t, err)
+
+		require.Equal(t, fmt.Sprintf("select datetime(%d, 'unixepoch'), datetime(%d, 'unixepoch')", from.Unix(), to.Unix()), sql)
+	})
+
+	t.Run("interpolate __unixEpochFilter function", func(t *testing.T) {
+		sql, err := engine.Interpolate(query, timeRange, "select $__unixEpochFilter(time)")
+		require.Nil(t, err)
+
+		require.Equal(t, fmt.Sprintf("select time >= %d AND time <= %d", from.Unix(), to.Unix()), sql)
+	})
+
+	t.Run("interpolate __unixEpochGroup function", func(t *testing.T) {
+		sql, err := engine.Interpolate(query, timeRange, "SELECT $__unixEpochGroupAlias(time_column,'5m')")
+		require.Nil(t, err)
+
+		require.Equal(t, "SELECT CAST(time_column / 300 AS INTEGER) * 300 AS \"time\"", sql)
+	})
+
+	t.Run("unknown macro returns an error", func(t *testing.T) {
+		_, err := engine.Interpolate(query, timeRange, "select $__nope(a)")
+		require.ErrorContains(t, err, "unknown macro __nope")
+	})
+}
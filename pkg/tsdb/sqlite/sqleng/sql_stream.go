@@ -0,0 +1,72 @@
+package sqleng
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/tsdb/sqlparams"
+	"github.com/grafana/grafana/pkg/tsdb/sqlstream"
+)
+
+func (e *DataSourceHandler) SubscribeStream(ctx context.Context, req *backend.SubscribeStreamRequest) (*backend.SubscribeStreamResponse, error) {
+	return e.stream.SubscribeStream(ctx, req)
+}
+
+func (e *DataSourceHandler) PublishStream(ctx context.Context, req *backend.PublishStreamRequest) (*backend.PublishStreamResponse, error) {
+	return e.stream.PublishStream(ctx, req)
+}
+
+func (e *DataSourceHandler) RunStream(ctx context.Context, req *backend.RunStreamRequest, sender *backend.StreamSender) error {
+	return e.stream.RunStream(ctx, req, sender)
+}
+
+// QueryStream interpolates and executes the SQL of a streamed table query.
+func (e *DataSourceHandler) QueryStream(ctx context.Context, query backend.DataQuery) (*sqlstream.Stream, string, error) {
+	logger := e.log.FromContext(ctx)
+
+	queryJson := QueryJson{}
+	if err := json.Unmarshal(query.JSON, &queryJson); err != nil {
+		return nil, "", fmt.Errorf("error unmarshal query json: %w", err)
+	}
+
+	interpolatedQuery := Interpolate(query, query.TimeRange, e.dsInfo.JsonData.TimeInterval, queryJson.RawSql)
+	interpolatedQuery, args, err := sqlparams.Bind(interpolatedQuery, queryJson.Params, e.paramPlaceholder)
+	if err != nil {
+		return nil, interpolatedQuery, fmt.Errorf("parameter binding failed: %w", err)
+	}
+	interpolatedQuery, err = e.macroEngine.Interpolate(&query, query.TimeRange, interpolatedQuery)
+	if err != nil {
+		return nil, interpolatedQuery, fmt.Errorf("interpolation failed: %w", e.TransformQueryError(logger, err))
+	}
+
+	rows, err := e.db.QueryContext(ctx, interpolatedQuery, args...)
+	if err != nil {
+		return nil, interpolatedQuery, fmt.Errorf("db query error: %w", e.TransformQueryError(logger, err))
+	}
+
+	qm, err := e.newProcessCfg(query, ctx, rows, interpolatedQuery)
+	if err != nil {
+		if err := rows.Close(); err != nil {
+			logger.Warn("Failed to close rows", "err", err)
+		}
+		return nil, interpolatedQuery, fmt.Errorf("failed to get configurations: %w", err)
+	}
+
+	return &sqlstream.Stream{
+		Rows:       rows,
+		Converters: e.converters(),
+		ConvertFrame: func(frame *data.Frame) error {
+			if err := convertSQLTimeColumnsToEpochMS(frame, qm); err != nil {
+				return fmt.Errorf("converting time columns failed: %w", err)
+			}
+			return nil
+		},
+		TransformError: func(err error) error {
+			return e.TransformQueryError(logger, err)
+		},
+	}, interpolatedQuery, nil
+}
@@ -0,0 +1,240 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/datasource"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/instancemgmt"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
+	"github.com/grafana/grafana-plugin-sdk-go/data/sqlutil"
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/tsdb/sqlite/sqleng"
+	"github.com/grafana/grafana/pkg/tsdb/sqlstream"
+)
+
+// driverName is the database/sql driver used by the data source. It is registered separately
+// from the plain sqlite3 driver used by the Grafana database so that every connection gets the
+// authorizer installed by denyAttach.
+const driverName = "sqlite3_datasource"
+
+var (
+	ErrNoAllowedPaths  = errors.New("the SQLite data source is disabled, configure allowed_paths in the [sqlite_datasource] section to enable it")
+	ErrMissingPath     = errors.New("database path is required")
+	ErrPathNotAllowed  = errors.New("database path is not inside an allowed directory")
+	ErrDatabaseMissing = errors.New("database file does not exist")
+)
+
+func init() {
+	sql.Register(driverName, &sqlite3.SQLiteDriver{ConnectHook: denyAttach})
+}
+
+// denyAttach prevents queries from attaching other database files, which would
+// otherwise allow reading files outside the allowed directories.
+func denyAttach(conn *sqlite3.SQLiteConn) error {
+	conn.RegisterAuthorizer(func(op int, _, _, _ string) int {
+		if op == sqlite3.SQLITE_ATTACH {
+			return sqlite3.SQLITE_DENY
+		}
+		return sqlite3.SQLITE_OK
+	})
+	return nil
+}
+
+type Service struct {
+	im     instancemgmt.InstanceManager
+	logger log.Logger
+}
+
+func ProvideService(cfg *setting.Cfg) *Service {
+	logger := backend.NewLoggerWith("logger", "tsdb.sqlite")
+	return &Service{
+		im:     datasource.NewInstanceManager(NewInstanceSettings(cfg, logger)),
+		logger: logger,
+	}
+}
+
+func (s *Service) getDataSourceHandler(ctx context.Context, pluginCtx backend.PluginContext) (*sqleng.DataSourceHandler, error) {
+	i, err := s.im.Get(ctx, pluginCtx)
+	if err != nil {
+		return nil, err
+	}
+	instance := i.(*sqleng.DataSourceHandler)
+	return instance, nil
+}
+
+// CheckHealth pings the SQLite database
+func (s *Service) CheckHealth(ctx context.Context, req *backend.CheckHealthRequest) (*backend.CheckHealthResult, error) {
+	dsHandler, err := s.getDataSourceHandler(ctx, req.PluginContext)
+	if err != nil {
+		return &backend.CheckHealthResult{Status: backend.HealthStatusError, Message: err.Error()}, nil
+	}
+
+	return dsHandler.CheckHealth(ctx, req)
+}
+
+func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
+	dsHandler, err := s.getDataSourceHandler(ctx, req.PluginContext)
+	if err != nil {
+		return nil, err
+	}
+	return dsHandler.QueryData(ctx, req)
+}
+
+func (s *Service) SubscribeStream(ctx context.Context, req *backend.SubscribeStreamRequest) (*backend.SubscribeStreamResponse, error) {
+	dsHandler, err := s.getDataSourceHandler(ctx, req.PluginContext)
+	if err != nil {
+		return &backend.SubscribeStreamResponse{Status: backend.SubscribeStreamStatusNotFound}, err
+	}
+	return dsHandler.SubscribeStream(ctx, req)
+}
+
+func (s *Service) PublishStream(ctx context.Context, req *backend.PublishStreamRequest) (*backend.PublishStreamResponse, error) {
+	dsHandler, err := s.getDataSourceHandler(ctx, req.PluginContext)
+	if err != nil {
+		return &backend.PublishStreamResponse{Status: backend.PublishStreamStatusNotFound}, err
+	}
+	return dsHandler.PublishStream(ctx, req)
+}
+
+func (s *Service) RunStream(ctx context.Context, req *backend.RunStreamRequest, sender *backend.StreamSender) error {
+	dsHandler, err := s.getDataSourceHandler(ctx, req.PluginContext)
+	if err != nil {
+		return err
+	}
+	return dsHandler.RunStream(ctx, req, sender)
+}
+
+func NewInstanceSettings(cfg *setting.Cfg, logger log.Logger) datasource.InstanceFactoryFunc {
+	return func(ctx context.Context, settings backend.DataSourceInstanceSettings) (instancemgmt.Instance, error) {
+		grafCfg := backend.GrafanaConfigFromContext(ctx)
+		sqlCfg, err := grafCfg.SQL()
+		if err != nil {
+			return nil, err
+		}
+		jsonData := sqleng.JsonData{
+			MaxOpenConns:    sqlCfg.DefaultMaxOpenConns,
+			MaxIdleConns:    sqlCfg.DefaultMaxIdleConns,
+			ConnMaxLifetime: sqlCfg.DefaultMaxConnLifetimeSeconds,
+		}
+
+		err = json.Unmarshal(settings.JSONData, &jsonData)
+		if err != nil {
+			return nil, fmt.Errorf("error reading settings: %w", err)
+		}
+
+		path := jsonData.Path
+		if path == "" {
+			path = settings.Database
+		}
+		resolved, err := resolveDatabasePath(cfg.SQLiteDatasourceAllowedPaths, path)
+		if err != nil {
+			return nil, err
+		}
+
+		dsInfo := sqleng.DataSourceInfo{
+			JsonData: jsonData,
+			Path:     resolved,
+			ID:       settings.ID,
+			Updated:  settings.Updated,
+			UID:      settings.UID,
+		}
+
+		userFacingDefaultError, err := grafCfg.UserFacingDefaultError()
+		if err != nil {
+			return nil, err
+		}
+
+		db, err := sql.Open(driverName, connectionString(resolved, cfg.SQLiteDatasourceReadOnly))
+		if err != nil {
+			return nil, err
+		}
+
+		db.SetMaxOpenConns(jsonData.MaxOpenConns)
+		db.SetMaxIdleConns(jsonData.MaxIdleConns)
+		db.SetConnMaxLifetime(time.Duration(jsonData.ConnMaxLifetime) * time.Second)
+
+		config := sqleng.DataPluginConfiguration{
+			DSInfo:            dsInfo,
+			MetricColumnTypes: []string{"TEXT", "VARCHAR", "CHAR", "NVARCHAR", "NCHAR", "CLOB"},
+			RowLimit:          sqlCfg.RowLimit,
+			StreamRowLimit:    sqlstream.RowLimit(grafCfg),
+		}
+
+		handler, err := sqleng.NewQueryDataHandler(userFacingDefaultError, db, config, &sqliteQueryResultTransformer{},
+			newSQLiteMacroEngine(), logger)
+		if err != nil {
+			logger.Error("Failed opening SQLite database", "err", err)
+			return nil, err
+		}
+
+		logger.Debug("Successfully opened SQLite database", "path", resolved)
+		return handler, nil
+	}
+}
+
+// resolveDatabasePath resolves the configured database path, following symlinks, and
+// makes sure the resulting file is inside one of the allowed directories. Relative
+// paths are resolved against the first allowed directory.
+func resolveDatabasePath(allowedPaths []string, path string) (string, error) {
+	if len(allowedPaths) == 0 {
+		return "", ErrNoAllowedPaths
+	}
+	if strings.TrimSpace(path) == "" {
+		return "", ErrMissingPath
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(allowedPaths[0], path)
+	}
+	resolved, err := filepath.EvalSymlinks(filepath.Clean(path))
+	if err != nil {
+		return "", ErrDatabaseMissing
+	}
+
+	for _, allowed := range allowedPaths {
+		allowedDir, err := filepath.EvalSymlinks(filepath.Clean(allowed))
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(allowedDir, resolved)
+		if err != nil {
+			continue
+		}
+		if rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return resolved, nil
+		}
+	}
+
+	return "", ErrPathNotAllowed
+}
+
+// connectionString builds a URI filename for the database. In read-only mode the file is opened
+// read-only and the connection additionally refuses any statement that would modify it.
+func connectionString(path string, readOnly bool) string {
+	cnnstr := "file:" + (&url.URL{Path: path}).EscapedPath()
+	if readOnly {
+		return cnnstr + "?mode=ro&_query_only=true"
+	}
+	return cnnstr + "?mode=rw"
+}
+
+type sqliteQueryResultTransformer struct{}
+
+func (t *sqliteQueryResultTransformer) TransformQueryError(_ log.Logger, err error) error {
+	return err
+}
+
+func (t *sqliteQueryResultTransformer) GetConverterList() []sqlutil.StringConverter {
+	return nil
+}
@@ -0,0 +1,174 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestResolveDatabasePath(t *testing.T) {
+	allowed := t.TempDir()
+	outside := t.TempDir()
+	for _, p := range []string{filepath.Join(allowed, "metrics.db"), filepath.Join(outside, "secret.db")} {
+		require.NoError(t, os.WriteFile(p, nil, 0o600))
+	}
+	require.NoError(t, os.Symlink(filepath.Join(outside, "secret.db"), filepath.Join(allowed, "link.db")))
+
+	t.Run("resolves relative paths against the first allowed directory", func(t *testing.T) {
+		resolved, err := resolveDatabasePath([]string{allowed}, "metrics.db")
+		require.NoError(t, err)
+		require.Equal(t, "metrics.db", filepath.Base(resolved))
+	})
+
+	t.Run("accepts absolute paths inside an allowed directory", func(t *testing.T) {
+		_, err := resolveDatabasePath([]string{outside, allowed}, filepath.Join(allowed, "metrics.db"))
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects paths outside the allowed directories", func(t *testing.T) {
+		_, err := resolveDatabasePath([]string{allowed}, filepath.Join(outside, "secret.db"))
+		require.ErrorIs(t, err, ErrPathNotAllowed)
+
+		_, err = resolveDatabasePath([]string{allowed}, "../"+filepath.Base(outside)+"/secret.db")
+		require.ErrorIs(t, err, ErrPathNotAllowed)
+	})
+
+	t.Run("rejects symlinks pointing outside the allowed directories", func(t *testing.T) {
+		_, err := resolveDatabasePath([]string{allowed}, "link.db")
+		require.ErrorIs(t, err, ErrPathNotAllowed)
+	})
+
+	t.Run("rejects missing files and empty configuration", func(t *testing.T) {
+		_, err := resolveDatabasePath([]string{allowed}, "missing.db")
+		require.ErrorIs(t, err, ErrDatabaseMissing)
+
+		_, err = resolveDatabasePath([]string{allowed}, "")
+		require.ErrorIs(t, err, ErrMissingPath)
+
+		_, err = resolveDatabasePath(nil, "metrics.db")
+		require.ErrorIs(t, err, ErrNoAllowedPaths)
+	})
+}
+
+func TestQueryData(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "metrics.db")
+	setupDB, err := sql.Open("sqlite3", dbPath)
+	require.NoError(t, err)
+	_, err = setupDB.Exec(`
+		CREATE TABLE metrics (ts TEXT, epoch INTEGER, host TEXT, value REAL);
+		INSERT INTO metrics VALUES
+			('2024-01-01 00:00:00', 1704067200, 'a', 1),
+			('2024-01-01T00:01:00Z', 1704067260, 'b', 2),
+			('2024-01-01 00:02:00', 1704067320, 'a', 3),
+			('2024-01-01 00:10:00', 1704067800, 'a', 4);`)
+	require.NoError(t, err)
+	require.NoError(t, setupDB.Close())
+
+	s := ProvideService(&setting.Cfg{SQLiteDatasourceAllowedPaths: []string{dir}, SQLiteDatasourceReadOnly: true})
+	ctx := backend.WithGrafanaConfig(context.Background(), backend.NewGrafanaCfg(map[string]string{
+		backend.SQLMaxOpenConnsDefault:           "10",
+		backend.SQLMaxIdleConnsDefault:           "10",
+		backend.SQLMaxConnLifetimeSecondsDefault: "60",
+		backend.SQLRowLimit:                      "1000",
+		backend.UserFacingDefaultError:           "error",
+	}))
+	pluginCtx := backend.PluginContext{DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{
+		UID:      "sqlite",
+		JSONData: []byte(`{"path":"metrics.db"}`),
+	}}
+	timeRange := backend.TimeRange{
+		From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, 1, 1, 0, 5, 0, 0, time.UTC),
+	}
+	query := func(t *testing.T, format, rawSQL string) backend.DataResponse {
+		t.Helper()
+		resp, err := s.QueryData(ctx, &backend.QueryDataRequest{
+			PluginContext: pluginCtx,
+			Queries: []backend.DataQuery{{
+				RefID:     "A",
+				TimeRange: timeRange,
+				JSON:      []byte(`{"format":"` + format + `","rawSql":` + quote(rawSQL) + `}`),
+			}},
+		})
+		require.NoError(t, err)
+		return resp.Responses["A"]
+	}
+
+	t.Run("check health pings the database", func(t *testing.T) {
+		res, err := s.CheckHealth(ctx, &backend.CheckHealthRequest{PluginContext: pluginCtx})
+		require.NoError(t, err)
+		require.Equal(t, backend.HealthStatusOk, res.Status)
+	})
+
+	t.Run("filters and groups ISO-8601 text timestamps", func(t *testing.T) {
+		res := query(t, "time_series", "SELECT $__timeGroupAlias(ts, '1m'), host AS metric, value FROM metrics WHERE $__timeFilter(ts) ORDER BY 1")
+		require.NoError(t, res.Error)
+		require.Len(t, res.Frames, 1)
+		frame := res.Frames[0]
+		require.Equal(t, 3, frame.Rows())
+		require.Equal(t, data.TimeSeriesTimeFieldName, frame.Fields[0].Name)
+		require.Equal(t, time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC), frame.Fields[0].At(1).(time.Time).UTC())
+		require.Len(t, frame.Fields, 3)
+	})
+
+	t.Run("filters epoch timestamps", func(t *testing.T) {
+		res := query(t, "time_series", "SELECT $__time(epoch), value FROM metrics WHERE $__timeFilter(epoch) ORDER BY 1")
+		require.NoError(t, res.Error)
+		require.Equal(t, 3, res.Frames[0].Rows())
+	})
+
+	t.Run("converts text time columns in table queries", func(t *testing.T) {
+		res := query(t, "table", "SELECT ts AS time, value FROM metrics ORDER BY epoch LIMIT 1")
+		require.NoError(t, res.Error)
+		require.Equal(t, data.FieldTypeNullableTime, res.Frames[0].Fields[0].Type())
+		require.Equal(t, timeRange.From, (*res.Frames[0].Fields[0].At(0).(*time.Time)).UTC())
+	})
+
+	t.Run("binds query parameters", func(t *testing.T) {
+		resp, err := s.QueryData(ctx, &backend.QueryDataRequest{
+			PluginContext: pluginCtx,
+			Queries: []backend.DataQuery{{
+				RefID:     "A",
+				TimeRange: timeRange,
+				JSON: []byte(`{"format":"table","rawSql":"SELECT value FROM metrics WHERE host IN ($__param(hosts)) AND value > $__param(min) ORDER BY value",` +
+					`"params":{"hosts":["a","x' OR 1=1 --"],"min":1}}`),
+			}},
+		})
+		require.NoError(t, err)
+		res := resp.Responses["A"]
+		require.NoError(t, res.Error)
+		require.Equal(t, 2, res.Frames[0].Rows())
+		require.Equal(t, "SELECT value FROM metrics WHERE host IN (?, ?) AND value > ? ORDER BY value", res.Frames[0].Meta.ExecutedQueryString)
+	})
+
+	t.Run("rejects writes in read-only mode", func(t *testing.T) {
+		res := query(t, "table", "DELETE FROM metrics")
+		require.Error(t, res.Error)
+	})
+
+	t.Run("rejects attaching other databases", func(t *testing.T) {
+		res := query(t, "table", "ATTACH DATABASE '"+filepath.Join(t.TempDir(), "other.db")+"' AS other")
+		require.ErrorContains(t, res.Error, "not authorized")
+	})
+}
+
+func TestConnectionString(t *testing.T) {
+	require.Equal(t, "file:/data/my%20metrics.db?mode=ro&_query_only=true", connectionString("/data/my metrics.db", true))
+	require.Equal(t, "file:/data/metrics.db?mode=rw", connectionString("/data/metrics.db", false))
+}
+
+func quote(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
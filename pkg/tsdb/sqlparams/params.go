@@ -0,0 +1,141 @@
+// Package sqlparams binds the template variables of SQL data source queries as query parameters.
+package sqlparams
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Placeholder returns the driver placeholder for the 1-based index of a bound parameter.
+type Placeholder func(index int) string
+
+// QuestionMark is the placeholder of drivers with positional parameters, such as MySQL and SQLite.
+func QuestionMark(int) string { return "?" }
+
+// Dollar is the placeholder of PostgreSQL.
+func Dollar(index int) string { return fmt.Sprintf("$%d", index) }
+
+// AtP is the placeholder of Microsoft SQL Server.
+func AtP(index int) string { return fmt.Sprintf("@p%d", index) }
+
+// Param is the value of a bound template variable: a single value, or a list of
+// values for multi-value variables.
+type Param struct {
+	Values []any
+	Multi  bool
+}
+
+func (p *Param) UnmarshalJSON(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var raw any
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+
+	list, multi := raw.([]any)
+	if !multi {
+		list = []any{raw}
+	}
+	values := make([]any, 0, len(list))
+	for _, v := range list {
+		value, err := paramValue(v)
+		if err != nil {
+			return err
+		}
+		values = append(values, value)
+	}
+
+	p.Values = values
+	p.Multi = multi
+	return nil
+}
+
+func paramValue(v any) (any, error) {
+	switch t := v.(type) {
+	case nil, string, bool:
+		return t, nil
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i, nil
+		}
+		return t.Float64()
+	default:
+		return nil, fmt.Errorf("unsupported query parameter value of type %T", v)
+	}
+}
+
+var paramMacroRegexp = regexp.MustCompile(`\$__param\(\s*([_a-zA-Z0-9]+)\s*\)`)
+
+// Bind replaces $__param(name) macros with driver placeholders and returns the
+// values to bind in placeholder order. Multi-value parameters expand to a comma separated
+// list of placeholders for use in IN clauses; an empty list expands to NULL, which matches nothing.
+// Macros inside quoted strings, quoted identifiers and comments are left as they are.
+func Bind(sql string, params map[string]Param, placeholder Placeholder) (string, []any, error) {
+	var args []any
+	var bindErr error
+
+	bind := func(match string) string {
+		name := paramMacroRegexp.FindStringSubmatch(match)[1]
+		param, ok := params[name]
+		if !ok {
+			if bindErr == nil {
+				bindErr = fmt.Errorf("query parameter %q is not defined", name)
+			}
+			return match
+		}
+		if len(param.Values) == 0 {
+			return "NULL"
+		}
+
+		placeholders := make([]string, 0, len(param.Values))
+		for _, v := range param.Values {
+			args = append(args, v)
+			placeholders = append(placeholders, placeholder(len(args)))
+		}
+		return strings.Join(placeholders, ", ")
+	}
+
+	var sb strings.Builder
+	for sql != "" {
+		start, end := nextLiteral(sql)
+		sb.WriteString(paramMacroRegexp.ReplaceAllStringFunc(sql[:start], bind))
+		sb.WriteString(sql[start:end])
+		sql = sql[end:]
+	}
+
+	if bindErr != nil {
+		return "", nil, bindErr
+	}
+	return sb.String(), args, nil
+}
+
+// nextLiteral returns the bounds of the first quoted string, quoted identifier or comment
+// of the query, or an empty range at the end of the query when there is none. Quotes are
+// escaped by doubling them, which reads as two adjacent literals. Unterminated literals
+// extend to the end of the query.
+func nextLiteral(sql string) (int, int) {
+	for i := 0; i < len(sql); i++ {
+		var open, closing string
+		switch {
+		case sql[i] == '\'' || sql[i] == '"' || sql[i] == '`':
+			open, closing = sql[i:i+1], sql[i:i+1]
+		case strings.HasPrefix(sql[i:], "--"):
+			open, closing = "--", "\n"
+		case strings.HasPrefix(sql[i:], "/*"):
+			open, closing = "/*", "*/"
+		default:
+			continue
+		}
+
+		j := strings.Index(sql[i+len(open):], closing)
+		if j == -1 {
+			return i, len(sql)
+		}
+		return i, i + len(open) + j + len(closing)
+	}
+	return len(sql), len(sql)
+}
@@ -0,0 +1,67 @@
+package sqlparams
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBind(t *testing.T) {
+	var q struct {
+		Params map[string]Param `json:"params"`
+	}
+	err := json.Unmarshal([]byte(`{"params":{"host":"web'01","ids":[1,2.5],"none":[],"on":true}}`), &q)
+	require.NoError(t, err)
+	require.Equal(t, Param{Values: []any{"web'01"}}, q.Params["host"])
+	require.Equal(t, Param{Values: []any{int64(1), 2.5}, Multi: true}, q.Params["ids"])
+
+	t.Run("binds single and multi-value parameters in placeholder order", func(t *testing.T) {
+		sql, args, err := Bind("SELECT * FROM t WHERE host = $__param(host) AND id IN ($__param( ids )) AND on = $__param(on)", q.Params, Dollar)
+		require.NoError(t, err)
+		require.Equal(t, "SELECT * FROM t WHERE host = $1 AND id IN ($2, $3) AND on = $4", sql)
+		require.Equal(t, []any{"web'01", int64(1), 2.5, true}, args)
+	})
+
+	t.Run("uses the placeholders of the driver", func(t *testing.T) {
+		sql, _, err := Bind("SELECT $__param(ids)", q.Params, QuestionMark)
+		require.NoError(t, err)
+		require.Equal(t, "SELECT ?, ?", sql)
+
+		sql, _, err = Bind("SELECT $__param(ids)", q.Params, AtP)
+		require.NoError(t, err)
+		require.Equal(t, "SELECT @p1, @p2", sql)
+	})
+
+	t.Run("empty multi-value parameters expand to NULL", func(t *testing.T) {
+		sql, args, err := Bind("SELECT * FROM t WHERE id IN ($__param(none))", q.Params, QuestionMark)
+		require.NoError(t, err)
+		require.Equal(t, "SELECT * FROM t WHERE id IN (NULL)", sql)
+		require.Empty(t, args)
+	})
+
+	t.Run("undefined parameters return an error", func(t *testing.T) {
+		_, _, err := Bind("SELECT $__param(missing)", q.Params, QuestionMark)
+		require.ErrorContains(t, err, `query parameter "missing" is not defined`)
+	})
+
+	t.Run("macros in quoted strings and identifiers are not bound", func(t *testing.T) {
+		sql, args, err := Bind(`SELECT '$__param(host)', 'it''s $__param(missing)', "$__param(on)", `+"`$__param(none)`"+` FROM t WHERE host = $__param(host)`, q.Params, QuestionMark)
+		require.NoError(t, err)
+		require.Equal(t, `SELECT '$__param(host)', 'it''s $__param(missing)', "$__param(on)", `+"`$__param(none)`"+` FROM t WHERE host = ?`, sql)
+		require.Equal(t, []any{"web'01"}, args)
+	})
+
+	t.Run("macros in comments are not bound", func(t *testing.T) {
+		sql, args, err := Bind("SELECT * FROM t -- AND id IN ($__param(missing))\nWHERE /* $__param(host) */ id IN ($__param(ids)) /* $__param(on)", q.Params, Dollar)
+		require.NoError(t, err)
+		require.Equal(t, "SELECT * FROM t -- AND id IN ($__param(missing))\nWHERE /* $__param(host) */ id IN ($1, $2) /* $__param(on)", sql)
+		require.Equal(t, []any{int64(1), 2.5}, args)
+	})
+
+	t.Run("nested values are rejected", func(t *testing.T) {
+		var p Param
+		err := json.Unmarshal([]byte(`[[1]]`), &p)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,191 @@
+package sqlstream
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/grafana/grafana-plugin-sdk-go/data/converters"
+	"github.com/grafana/grafana-plugin-sdk-go/data/sqlutil"
+)
+
+const (
+	// chunkRows is the maximum number of rows sent in each frame of a streamed query
+	chunkRows int64 = 10000
+	// chunkBytes is the maximum estimated size of the values sent in each frame of a streamed
+	// query. Grafana Live drops subscribers whose queue exceeds 4MB, so frames are kept well
+	// below that to leave room for the encoding overhead and for a few queued frames.
+	chunkBytes = 1 << 20
+	// valueBytes is the estimated encoded size of numbers, booleans and times
+	valueBytes = 24
+)
+
+// rowChunker reads the rows of a query into frames with a limited number of rows and size.
+// Unlike sqlutil.FrameFromRows it does not read past the limit, so that every frame continues
+// where the previous one stopped. With the dynamic converter, the field types are inferred from
+// the values of the first frame and kept for the following ones.
+type rowChunker struct {
+	rows    *sql.Rows
+	columns []string
+	scanRow *sqlutil.RowConverter
+	// dynamic is set when the field types are inferred from the values
+	dynamic    bool
+	converters []*data.FieldConverter
+	// maxBytes is the maximum estimated size of the values of a frame
+	maxBytes int
+	// exhausted is set once all rows are read
+	exhausted bool
+}
+
+func newRowChunker(rows *sql.Rows, converters ...sqlutil.Converter) (*rowChunker, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range converters {
+		if c.Dynamic {
+			return &rowChunker{rows: rows, columns: columns, dynamic: true, maxBytes: chunkBytes}, nil
+		}
+	}
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	scanRow, err := sqlutil.MakeScanRow(types, columns, converters...)
+	if err != nil {
+		return nil, err
+	}
+	return &rowChunker{rows: rows, columns: columns, scanRow: scanRow, maxBytes: chunkBytes}, nil
+}
+
+// nextRow advances to the next row unless the frame is full, and marks the chunker as
+// exhausted after the last row. Every frame holds at least one row.
+func (c *rowChunker) nextRow(rows, size int64, bytes int) bool {
+	if rows >= size || (rows > 0 && bytes >= c.maxBytes) {
+		return false
+	}
+	if !c.rows.Next() {
+		c.exhausted = true
+		return false
+	}
+	return true
+}
+
+// next returns a frame with the next size rows, or fewer when the rows are exhausted or the
+// frame reached the maximum size.
+func (c *rowChunker) next(size int64) (*data.Frame, error) {
+	if c.dynamic {
+		return c.nextDynamic(size)
+	}
+	frame := sqlutil.NewFrame(c.columns, c.scanRow.Converters...)
+	bytes := 0
+	for i := int64(0); c.nextRow(i, size, bytes); i++ {
+		r := c.scanRow.NewScannableRow()
+		if err := c.rows.Scan(r...); err != nil {
+			return nil, err
+		}
+		if err := sqlutil.Append(frame, r, c.scanRow.Converters...); err != nil {
+			return nil, err
+		}
+		row := frame.Rows() - 1
+		for _, field := range frame.Fields {
+			bytes += valueSize(field.At(row))
+		}
+	}
+	return frame, c.rows.Err()
+}
+
+func (c *rowChunker) nextDynamic(size int64) (*data.Frame, error) {
+	var values [][]any
+	bytes := 0
+	for i := int64(0); c.nextRow(i, size, bytes); i++ {
+		row := make([]any, len(c.columns))
+		dest := make([]any, len(row))
+		for j := range row {
+			dest[j] = &row[j]
+		}
+		if err := c.rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		for _, v := range row {
+			bytes += valueSize(v)
+		}
+		values = append(values, row)
+	}
+	if err := c.rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if c.converters == nil {
+		c.converters = dynamicConverters(len(c.columns), values)
+	}
+
+	fields := make([]*data.Field, len(c.columns))
+	for j, converter := range c.converters {
+		fields[j] = data.NewFieldFromFieldType(converter.OutputFieldType, len(values))
+		fields[j].Name = c.columns[j]
+	}
+	for i, row := range values {
+		for j, v := range row {
+			converted, err := c.converters[j].Converter(v)
+			if err != nil {
+				return nil, fmt.Errorf("column %q: %w", c.columns[j], err)
+			}
+			fields[j].Set(i, converted)
+		}
+	}
+	return data.NewFrame("", fields...), nil
+}
+
+// valueSize estimates the size of a value in the JSON encoding of a frame, including its
+// separator.
+func valueSize(v any) int {
+	switch v := v.(type) {
+	case nil:
+		return 5
+	case string:
+		return len(v) + 3
+	case *string:
+		if v == nil {
+			return 5
+		}
+		return len(*v) + 3
+	case []byte:
+		return len(v) + 3
+	case json.RawMessage:
+		return len(v) + 1
+	case *json.RawMessage:
+		if v == nil {
+			return 5
+		}
+		return len(*v) + 1
+	default:
+		return valueBytes
+	}
+}
+
+// dynamicConverters returns the converter of every column based on its first non-null value.
+// Columns without values are strings.
+func dynamicConverters(columns int, values [][]any) []*data.FieldConverter {
+	result := make([]*data.FieldConverter, columns)
+	for j := range result {
+		result[j] = &converters.AnyToNullableString
+	rows:
+		for _, row := range values {
+			switch row[j].(type) {
+			case nil:
+				continue
+			case time.Time:
+				result[j] = &sqlutil.TimeToNullableTime
+			case int64, float64:
+				result[j] = &sqlutil.IntOrFloatToNullableFloat64
+			case []byte:
+				result[j] = &converters.Uint8ArrayToNullableString
+			}
+			break rows
+		}
+	}
+	return result
+}
@@ -0,0 +1,240 @@
+// Package sqlstream streams the rows of SQL data source table queries to Grafana Live subscribers.
+package sqlstream
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/grafana/grafana-plugin-sdk-go/data/sqlutil"
+)
+
+const (
+	// PathPrefix is the prefix of the Grafana Live channel paths of streamed table queries
+	PathPrefix = "query/"
+	// RowLimitKey is the Grafana config key of the maximum number of rows of a streamed query
+	RowLimitKey = "GF_SQL_STREAM_ROW_LIMIT"
+	// DefaultRowLimit is used when no stream row limit is configured
+	DefaultRowLimit int64 = 10000000
+)
+
+// RowLimit returns the configured maximum number of rows of a streamed query.
+func RowLimit(cfg *backend.GrafanaCfg) int64 {
+	if limit, err := strconv.ParseInt(cfg.Get(RowLimitKey), 10, 64); err == nil && limit > 0 {
+		return limit
+	}
+	return DefaultRowLimit
+}
+
+// Stream holds the rows of an executed streamed query.
+type Stream struct {
+	// Rows are closed when the stream ends.
+	Rows *sql.Rows
+	// Converters convert the columns to fields. With a dynamic converter, the field types
+	// are inferred from the values.
+	Converters []sqlutil.Converter
+	// ConvertFrame is called with every frame before it is sent, for example to convert
+	// the time columns.
+	ConvertFrame func(frame *data.Frame) error
+	// TransformError turns the errors of reading the rows into user facing errors.
+	TransformError func(err error) error
+}
+
+// Querier executes the query of a stream. Besides the stream it returns the executed SQL,
+// which is sent to the subscribers with the error when the query fails.
+type Querier interface {
+	QueryStream(ctx context.Context, query backend.DataQuery) (*Stream, string, error)
+}
+
+// Handler implements the stream handler of a SQL data source.
+type Handler struct {
+	querier  Querier
+	rowLimit int64
+	logger   log.Logger
+}
+
+// NewHandler returns a stream handler that runs the queries with querier. A rowLimit of
+// zero or less defaults to DefaultRowLimit.
+func NewHandler(querier Querier, rowLimit int64, logger log.Logger) *Handler {
+	if rowLimit <= 0 {
+		rowLimit = DefaultRowLimit
+	}
+	return &Handler{querier: querier, rowLimit: rowLimit, logger: logger}
+}
+
+// progress is sent in the custom metadata of every frame of a streamed query, so that
+// clients know when the stream is complete.
+type progress struct {
+	Rows  int64  `json:"rows"`
+	Done  bool   `json:"done"`
+	Error string `json:"error,omitempty"`
+}
+
+// request is the data of a stream subscription: a table query with its time range in
+// epoch milliseconds.
+type request struct {
+	RefID        string  `json:"refId"`
+	RawSql       string  `json:"rawSql"`
+	Format       string  `json:"format"`
+	Fill         bool    `json:"fill"`
+	FillInterval float64 `json:"fillInterval"`
+	FillMode     string  `json:"fillMode"`
+	FillValue    float64 `json:"fillValue"`
+	IntervalMs   float64 `json:"intervalMs"`
+	TimeRange    struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	} `json:"timeRange"`
+}
+
+func parseRequest(raw json.RawMessage) (backend.DataQuery, error) {
+	req := request{}
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return backend.DataQuery{}, fmt.Errorf("error unmarshal query json: %w", err)
+	}
+	if req.Format != "table" {
+		return backend.DataQuery{}, errors.New("only table queries can be streamed")
+	}
+	if req.Fill || req.FillInterval != 0.0 || req.FillMode != "" || req.FillValue != 0.0 {
+		return backend.DataQuery{}, errors.New("query fill-parameters not supported")
+	}
+	if req.RawSql == "" {
+		return backend.DataQuery{}, errors.New("query model property rawSql should not be empty")
+	}
+
+	from, err := strconv.ParseInt(req.TimeRange.From, 10, 64)
+	if err != nil {
+		return backend.DataQuery{}, fmt.Errorf("invalid time range start: %w", err)
+	}
+	to, err := strconv.ParseInt(req.TimeRange.To, 10, 64)
+	if err != nil {
+		return backend.DataQuery{}, fmt.Errorf("invalid time range end: %w", err)
+	}
+
+	return backend.DataQuery{
+		RefID:     req.RefID,
+		Interval:  time.Duration(req.IntervalMs * float64(time.Millisecond)),
+		TimeRange: backend.TimeRange{From: time.UnixMilli(from), To: time.UnixMilli(to)},
+		JSON:      raw,
+	}, nil
+}
+
+func (h *Handler) SubscribeStream(_ context.Context, req *backend.SubscribeStreamRequest) (*backend.SubscribeStreamResponse, error) {
+	if !strings.HasPrefix(req.Path, PathPrefix) {
+		return &backend.SubscribeStreamResponse{
+			Status: backend.SubscribeStreamStatusNotFound,
+		}, fmt.Errorf("expected %s in channel path", PathPrefix)
+	}
+	if _, err := parseRequest(req.Data); err != nil {
+		return &backend.SubscribeStreamResponse{
+			Status: backend.SubscribeStreamStatusNotFound,
+		}, err
+	}
+	return &backend.SubscribeStreamResponse{
+		Status: backend.SubscribeStreamStatusOK,
+	}, nil
+}
+
+func (h *Handler) PublishStream(_ context.Context, _ *backend.PublishStreamRequest) (*backend.PublishStreamResponse, error) {
+	return &backend.PublishStreamResponse{
+		Status: backend.PublishStreamStatusPermissionDenied,
+	}, nil
+}
+
+// RunStream executes a table query and sends its rows in frames of at most chunkRows rows and
+// chunkBytes bytes, up to the stream row limit. The context is canceled when the last subscriber
+// leaves the channel, which cancels the query. Errors are sent to the subscribers in the progress
+// of a last frame.
+func (h *Handler) RunStream(ctx context.Context, req *backend.RunStreamRequest, sender *backend.StreamSender) error {
+	logger := h.logger.FromContext(ctx)
+
+	query, err := parseRequest(req.Data)
+	if err != nil {
+		return err
+	}
+
+	p := progress{}
+	sendError := func(err error, sql string) error {
+		p.Done = true
+		p.Error = err.Error()
+		frame := data.NewFrame("")
+		frame.RefID = query.RefID
+		frame.SetMeta(&data.FrameMeta{ExecutedQueryString: sql, Custom: p})
+		return sender.SendFrame(frame, data.IncludeAll)
+	}
+
+	stream, sql, err := h.querier.QueryStream(ctx, query)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return sendError(err, sql)
+	}
+	defer func() {
+		if err := stream.Rows.Close(); err != nil {
+			logger.Warn("Failed to close rows", "err", err)
+		}
+	}()
+
+	chunker, err := newRowChunker(stream.Rows, stream.Converters...)
+	if err != nil {
+		return sendError(fmt.Errorf("convert frame from rows error: %w", err), sql)
+	}
+
+	for !p.Done {
+		frame, err := chunker.next(min(chunkRows, h.rowLimit-p.Rows))
+		if err != nil {
+			if ctx.Err() != nil {
+				logger.Debug("Stopped streaming query (context canceled)", "rows", p.Rows)
+				return nil
+			}
+			if stream.TransformError != nil {
+				err = stream.TransformError(err)
+			}
+			return sendError(fmt.Errorf("convert frame from rows error: %w", err), sql)
+		}
+		if stream.ConvertFrame != nil {
+			if err := stream.ConvertFrame(frame); err != nil {
+				return sendError(err, sql)
+			}
+		}
+
+		p.Rows += int64(frame.Rows())
+		p.Done = chunker.exhausted || p.Rows >= h.rowLimit
+
+		frame.RefID = query.RefID
+		frame.SetMeta(&data.FrameMeta{ExecutedQueryString: sql, Custom: p})
+		if !chunker.exhausted && p.Rows >= h.rowLimit && stream.Rows.Next() {
+			frame.AppendNotices(data.Notice{
+				Severity: data.NoticeSeverityWarning,
+				Text:     fmt.Sprintf("Results have been limited to %v because the SQL stream row limit was reached", h.rowLimit),
+			})
+		} else {
+			frame.AppendNotices(data.Notice{
+				Severity: data.NoticeSeverityInfo,
+				Text:     progressText(p),
+			})
+		}
+
+		if err := sender.SendFrame(frame, data.IncludeAll); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func progressText(p progress) string {
+	if p.Done {
+		return fmt.Sprintf("Streamed %d rows", p.Rows)
+	}
+	return fmt.Sprintf("Streaming results, %d rows received", p.Rows)
+}
@@ -0,0 +1,194 @@
+package sqlstream
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/grafana/grafana-plugin-sdk-go/data/sqlutil"
+	"github.com/stretchr/testify/require"
+)
+
+type testQuerier struct {
+	db         *sql.DB
+	converters []sqlutil.Converter
+	converted  int
+}
+
+func (q *testQuerier) QueryStream(ctx context.Context, _ backend.DataQuery) (*Stream, string, error) {
+	query := "SELECT time, value FROM metrics"
+	rows, err := q.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, query, fmt.Errorf("db query error: %w", err)
+	}
+	return &Stream{
+		Rows:       rows,
+		Converters: q.converters,
+		ConvertFrame: func(*data.Frame) error {
+			q.converted++
+			return nil
+		},
+	}, query, nil
+}
+
+type testStreamPacketSender struct {
+	frames []*data.Frame
+}
+
+func (s *testStreamPacketSender) Send(packet *backend.StreamPacket) error {
+	frame := &data.Frame{}
+	if err := json.Unmarshal(packet.Data, frame); err != nil {
+		return err
+	}
+	s.frames = append(s.frames, frame)
+	return nil
+}
+
+func TestRunStream(t *testing.T) {
+	streamData := json.RawMessage(`{"refId": "A", "format": "table", "rawSql": "SELECT time, value FROM metrics", "timeRange": {"from": "1704067200000", "to": "1704070800000"}}`)
+
+	runWith := func(t *testing.T, ctx context.Context, rowLimit int64, converters []sqlutil.Converter, expect func(mock sqlmock.Sqlmock)) ([]*data.Frame, *testQuerier) {
+		t.Helper()
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		expect(mock)
+
+		querier := &testQuerier{db: db, converters: converters}
+		sender := &testStreamPacketSender{}
+		err = NewHandler(querier, rowLimit, log.New()).RunStream(ctx, &backend.RunStreamRequest{Path: "query/1", Data: streamData}, backend.NewStreamSender(sender))
+		require.NoError(t, err)
+		return sender.frames, querier
+	}
+	run := func(t *testing.T, ctx context.Context, rowLimit int64, expect func(mock sqlmock.Sqlmock)) []*data.Frame {
+		t.Helper()
+		frames, _ := runWith(t, ctx, rowLimit, nil, expect)
+		return frames
+	}
+
+	metricRows := func(mock sqlmock.Sqlmock, count int) *sqlmock.Rows {
+		rows := sqlmock.NewRowsWithColumnDefinition(
+			mock.NewColumn("time").OfType("BIGINT", int64(0)),
+			mock.NewColumn("value").OfType("DOUBLE", float64(0)),
+		)
+		for i := 0; i < count; i++ {
+			rows.AddRow(int64(1704067200+i), float64(i))
+		}
+		return rows
+	}
+
+	t.Run("sends the rows in chunks with the progress", func(t *testing.T) {
+		frames, querier := runWith(t, context.Background(), 0, nil, func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("SELECT time, value FROM metrics").WillReturnRows(metricRows(mock, 25000))
+		})
+
+		require.Len(t, frames, 3)
+		require.Equal(t, 3, querier.converted)
+		require.Equal(t, []int{10000, 10000, 5000}, []int{frames[0].Rows(), frames[1].Rows(), frames[2].Rows()})
+		require.Equal(t, "A", frames[0].RefID)
+		require.Equal(t, int64(1704067200+10000), *frames[1].Fields[0].At(0).(*int64))
+		require.Equal(t, 24999.0, *frames[2].Fields[1].At(4999).(*float64))
+
+		require.Equal(t, map[string]any{"rows": 10000.0, "done": false}, frames[0].Meta.Custom)
+		require.Equal(t, "Streaming results, 10000 rows received", frames[0].Meta.Notices[0].Text)
+		require.Equal(t, map[string]any{"rows": 25000.0, "done": true}, frames[2].Meta.Custom)
+		require.Equal(t, "Streamed 25000 rows", frames[2].Meta.Notices[0].Text)
+		require.Equal(t, "SELECT time, value FROM metrics", frames[2].Meta.ExecutedQueryString)
+	})
+
+	t.Run("limits the size of the chunks", func(t *testing.T) {
+		value := strings.Repeat("x", chunkBytes/4)
+		frames := run(t, context.Background(), 0, func(mock sqlmock.Sqlmock) {
+			rows := sqlmock.NewRowsWithColumnDefinition(mock.NewColumn("message").OfType("TEXT", ""))
+			for i := 0; i < 10; i++ {
+				rows.AddRow(value)
+			}
+			mock.ExpectQuery("SELECT").WillReturnRows(rows)
+		})
+
+		require.Len(t, frames, 3)
+		require.Equal(t, []int{4, 4, 2}, []int{frames[0].Rows(), frames[1].Rows(), frames[2].Rows()})
+		require.Equal(t, map[string]any{"rows": 10.0, "done": true}, frames[2].Meta.Custom)
+		for _, frame := range frames {
+			encoded, err := json.Marshal(frame)
+			require.NoError(t, err)
+			require.Less(t, len(encoded), 4*1024*1024)
+		}
+	})
+
+	t.Run("stops at the stream row limit", func(t *testing.T) {
+		frames := run(t, context.Background(), 15, func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("SELECT").WillReturnRows(metricRows(mock, 20))
+		})
+
+		require.Len(t, frames, 1)
+		require.Equal(t, 15, frames[0].Rows())
+		require.Equal(t, map[string]any{"rows": 15.0, "done": true}, frames[0].Meta.Custom)
+		require.Equal(t, data.NoticeSeverityWarning, frames[0].Meta.Notices[0].Severity)
+		require.Contains(t, frames[0].Meta.Notices[0].Text, "limited to 15")
+	})
+
+	t.Run("infers the field types from the first values that are not null with the dynamic converter", func(t *testing.T) {
+		frames, _ := runWith(t, context.Background(), 0, []sqlutil.Converter{{Dynamic: true}}, func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"time", "host", "value"}).
+				AddRow(int64(1704067200), nil, nil).
+				AddRow(int64(1704067260), "web-1", []byte("1.5")))
+		})
+
+		require.Len(t, frames, 1)
+		require.Equal(t, data.FieldTypeNullableFloat64, frames[0].Fields[0].Type())
+		require.Equal(t, data.FieldTypeNullableString, frames[0].Fields[1].Type())
+		require.Equal(t, "web-1", *frames[0].Fields[1].At(1).(*string))
+		require.Equal(t, "1.5", *frames[0].Fields[2].At(1).(*string))
+	})
+
+	t.Run("sends query errors in the progress", func(t *testing.T) {
+		frames := run(t, context.Background(), 0, func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("SELECT").WillReturnError(errors.New("Error 1146 (42S02): Table 'grafana.metrics' doesn't exist"))
+		})
+
+		require.Len(t, frames, 1)
+		require.Equal(t, map[string]any{"rows": 0.0, "done": true, "error": "db query error: Error 1146 (42S02): Table 'grafana.metrics' doesn't exist"}, frames[0].Meta.Custom)
+		require.Equal(t, "SELECT time, value FROM metrics", frames[0].Meta.ExecutedQueryString)
+	})
+
+	t.Run("stops when the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		frames := run(t, ctx, 0, func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("SELECT").WillReturnRows(metricRows(mock, 10))
+		})
+
+		require.Empty(t, frames)
+	})
+}
+
+func TestSubscribeStream(t *testing.T) {
+	handler := NewHandler(&testQuerier{}, 0, log.New())
+	subscribe := func(path, query string) (*backend.SubscribeStreamResponse, error) {
+		return handler.SubscribeStream(context.Background(), &backend.SubscribeStreamRequest{Path: path, Data: json.RawMessage(query)})
+	}
+
+	res, err := subscribe("query/1", `{"format": "table", "rawSql": "SELECT 1", "timeRange": {"from": "0", "to": "1000"}}`)
+	require.NoError(t, err)
+	require.Equal(t, backend.SubscribeStreamStatusOK, res.Status)
+
+	res, err = subscribe("query/1", `{"format": "time_series", "rawSql": "SELECT 1", "timeRange": {"from": "0", "to": "1000"}}`)
+	require.ErrorContains(t, err, "only table queries can be streamed")
+	require.Equal(t, backend.SubscribeStreamStatusNotFound, res.Status)
+
+	res, err = subscribe("query/1", `{"format": "table", "rawSql": "SELECT 1", "fill": true, "timeRange": {"from": "0", "to": "1000"}}`)
+	require.ErrorContains(t, err, "fill-parameters not supported")
+	require.Equal(t, backend.SubscribeStreamStatusNotFound, res.Status)
+
+	res, err = subscribe("tail/1", `{"format": "table", "rawSql": "SELECT 1", "timeRange": {"from": "0", "to": "1000"}}`)
+	require.Error(t, err)
+	require.Equal(t, backend.SubscribeStreamStatusNotFound, res.Status)
+}
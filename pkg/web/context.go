@@ -95,6 +95,46 @@ func RemoteAddr(req *http.Request) string {
 	return addr
 }
 
+// ClientIP returns the address of the connection, or the last address of the X-Forwarded-For header that is not a
+// trusted proxy when the connection comes from a trusted proxy. The addresses before it were added by the client or
+// by untrusted proxies and can be forged. Trusted proxies are IP addresses or CIDR ranges.
+func ClientIP(req *http.Request, trustedProxies []string) string {
+	ip := req.RemoteAddr
+	if host, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+		ip = host
+	}
+	if len(trustedProxies) == 0 {
+		return ip
+	}
+
+	var forwarded []string
+	for _, header := range req.Header.Values("X-Forwarded-For") {
+		forwarded = append(forwarded, strings.Split(header, ",")...)
+	}
+	for i := len(forwarded) - 1; i >= 0 && containsIP(trustedProxies, ip); i-- {
+		ip = strings.TrimSpace(forwarded[i])
+	}
+	return ip
+}
+
+// containsIP returns true if the address matches one of the IP addresses or CIDR ranges.
+func containsIP(networks []string, addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, n := range networks {
+		if _, network, err := net.ParseCIDR(n); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+		} else if other := net.ParseIP(n); other != nil && other.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 const (
 	headerContentType = "Content-Type"
 	contentTypeJSON   = "application/json; charset=UTF-8"
@@ -111,3 +111,50 @@ func TestContext_noHandler(t *testing.T) {
 
 	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
 }
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name           string
+		remoteAddr     string
+		forwardedFor   string
+		trustedProxies []string
+		want           string
+	}{
+		{
+			name:         "should ignore the forwarded addresses without trusted proxies",
+			remoteAddr:   "192.168.1.5:3000",
+			forwardedFor: "10.0.0.5",
+			want:         "192.168.1.5",
+		},
+		{
+			name:           "should ignore the forwarded addresses of connections that are not trusted proxies",
+			remoteAddr:     "192.168.2.5:3000",
+			forwardedFor:   "10.0.0.5",
+			trustedProxies: []string{"192.168.1.0/24"},
+			want:           "192.168.2.5",
+		},
+		{
+			name:           "should return the last forwarded address that is not a trusted proxy",
+			remoteAddr:     "192.168.1.5:3000",
+			forwardedFor:   "203.0.113.7, 10.0.0.5, 192.168.1.6",
+			trustedProxies: []string{"192.168.1.0/24"},
+			want:           "10.0.0.5",
+		},
+		{
+			name:           "should match trusted proxies by address",
+			remoteAddr:     "192.168.1.5:3000",
+			forwardedFor:   "10.0.0.5",
+			trustedProxies: []string{"192.168.1.5"},
+			want:           "10.0.0.5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			assert.Equal(t, tt.want, ClientIP(req, tt.trustedProxies))
+		})
+	}
+}
@@ -0,0 +1,44 @@
+import { render, screen } from 'test/test-utils';
+
+import { RuleEvaluationCost } from 'app/types/unified-alerting-dto';
+
+import { mockPromAlertingRule } from '../../mocks';
+
+import { RuleEvaluationCostBadges } from './RuleEvaluationCostBadges';
+
+const cost: RuleEvaluationCost = {
+  queryTime: 1.5,
+  expressionTime: 0.5,
+  series: 12000,
+  missedEvaluations: 3,
+  lateEvaluations: 1,
+  slow: false,
+  highCardinality: false,
+};
+
+describe('RuleEvaluationCostBadges', () => {
+  it('should not render badges for rules without evaluation cost', () => {
+    render(<RuleEvaluationCostBadges rule={mockPromAlertingRule()} />);
+
+    expect(screen.queryByText('Slow')).not.toBeInTheDocument();
+    expect(screen.queryByText('High cardinality')).not.toBeInTheDocument();
+  });
+
+  it('should not render badges for rules below the thresholds', () => {
+    render(<RuleEvaluationCostBadges rule={mockPromAlertingRule({ evaluationCost: cost })} />);
+
+    expect(screen.queryByText('Slow')).not.toBeInTheDocument();
+    expect(screen.queryByText('High cardinality')).not.toBeInTheDocument();
+  });
+
+  it('should render badges for slow and high cardinality rules', () => {
+    render(
+      <RuleEvaluationCostBadges
+        rule={mockPromAlertingRule({ evaluationCost: { ...cost, slow: true, highCardinality: true } })}
+      />
+    );
+
+    expect(screen.getByText('Slow')).toBeInTheDocument();
+    expect(screen.getByText('High cardinality')).toBeInTheDocument();
+  });
+});
@@ -0,0 +1,41 @@
+import { Badge, Stack } from '@grafana/ui';
+import { Rule } from 'app/types/unified-alerting';
+
+interface Props {
+  rule: Rule;
+}
+
+/**
+ * Flags Grafana rules whose last evaluation exceeded the slow rule or high cardinality threshold of the scheduler
+ */
+export const RuleEvaluationCostBadges = ({ rule }: Props) => {
+  const cost = rule.evaluationCost;
+  if (!cost || (!cost.slow && !cost.highCardinality)) {
+    return null;
+  }
+
+  const evaluationTime = (rule.evaluationTime ?? 0).toFixed(2);
+  const queryTime = cost.queryTime.toFixed(2);
+  const expressionTime = cost.expressionTime.toFixed(2);
+
+  return (
+    <Stack direction="row" gap={0.5} wrap="wrap">
+      {cost.slow && (
+        <Badge
+          text="Slow"
+          color="orange"
+          icon="stopwatch-slash"
+          tooltip={`The last evaluation took ${evaluationTime}s (queries ${queryTime}s, expressions ${expressionTime}s). Missed evaluations: ${cost.missedEvaluations}, late evaluations: ${cost.lateEvaluations}.`}
+        />
+      )}
+      {cost.highCardinality && (
+        <Badge
+          text="High cardinality"
+          color="orange"
+          icon="layer-group"
+          tooltip={`The last evaluation returned ${cost.series} series.`}
+        />
+      )}
+    </Stack>
+  );
+};
@@ -3,7 +3,7 @@ import { useEffect, useMemo } from 'react';
 import Skeleton from 'react-loading-skeleton';
 
 import { GrafanaTheme2 } from '@grafana/data';
-import { LoadingPlaceholder, Pagination, Tooltip, useStyles2 } from '@grafana/ui';
+import { LoadingPlaceholder, Pagination, Stack, Tooltip, useStyles2 } from '@grafana/ui';
 import { CombinedRule } from 'app/types/unified-alerting';
 
 import { DEFAULT_PER_PAGE_PAGINATION } from '../../../../../core/constants';
@@ -28,6 +28,7 @@ import { Tokenize } from '../Tokenize';
 import { RuleActionsButtons } from './RuleActionsButtons';
 import { RuleConfigStatus } from './RuleConfigStatus';
 import { RuleDetails } from './RuleDetails';
+import { RuleEvaluationCostBadges } from './RuleEvaluationCostBadges';
 import { RuleHealth } from './RuleHealth';
 import { RuleState } from './RuleState';
 
@@ -227,9 +228,14 @@ function useColumns(
           }
 
           const provenance = rulerRule.grafana_alert.provenance;
-          return provenance ? <ProvisioningBadge /> : null;
+          return (
+            <Stack direction="row" gap={0.5} wrap="wrap">
+              {provenance && <ProvisioningBadge />}
+              {rule.promRule && <RuleEvaluationCostBadges rule={rule.promRule} />}
+            </Stack>
+          );
         },
-        size: '100px',
+        size: '140px',
       },
       {
         id: 'warnings',
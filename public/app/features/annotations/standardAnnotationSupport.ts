@@ -255,7 +255,6 @@ export function getAnnotationsFromData(
 const legacyRunner = [
   'prometheus',
   'loki',
-  'elasticsearch',
   'grafana-opensearch-datasource', // external
 ];
 
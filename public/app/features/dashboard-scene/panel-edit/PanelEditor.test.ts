@@ -243,6 +243,19 @@ describe('PanelEditor', () => {
       expect((gridItem.state.body as VizPanel).state.title).toBe('changed title');
     });
 
+    it('does not save a library panel pinned to a version', async () => {
+      const libPanelBehavior = new LibraryPanelBehavior({
+        isLoaded: true,
+        title: 'title',
+        uid: 'uid',
+        name: 'libraryPanelName',
+        pinnedVersion: 1,
+      });
+      const panel = new VizPanel({ key: 'panel-1', pluginId: 'text', $behaviors: [libPanelBehavior] });
+
+      await expect(libAPI.saveLibPanel(panel)).rejects.toThrow(libAPI.pinnedLibraryPanelSaveError);
+    });
+
     it('unlinks library panel', () => {
       const libraryPanelModel = {
         title: 'title',
@@ -1,8 +1,8 @@
 import { useCallback, useState } from 'react';
 import { useAsync, useDebounce } from 'react-use';
 
-import { Button, Icon, Input, Modal, useStyles2 } from '@grafana/ui';
-import { getConnectedDashboards } from 'app/features/library-panels/state/api';
+import { Alert, Button, Icon, Input, Modal, useStyles2 } from '@grafana/ui';
+import { getConnectedDashboards, pinnedLibraryPanelSaveError } from 'app/features/library-panels/state/api';
 import { getModalStyles } from 'app/features/library-panels/styles';
 
 import { LibraryPanelBehavior } from '../scene/LibraryPanelBehavior';
@@ -46,11 +46,17 @@ export const SaveLibraryVizPanelModal = ({ libraryPanel, isUnsavedPrompt, onDism
     onDiscard();
   }, [onDiscard]);
 
+  const isPinned = Boolean(libraryPanel.state.pinnedVersion);
   const title = isUnsavedPrompt ? 'Unsaved library panel changes' : 'Save library panel';
 
   return (
     <Modal title={title} icon="save" onDismiss={onDismiss} isOpen={true}>
       <div>
+        {isPinned ? (
+          <Alert severity="warning" title="Pinned library panel">
+            {pinnedLibraryPanelSaveError}
+          </Alert>
+        ) : null}
         <p className={styles.textInfo}>
           {'This update will affect '}
           <strong>
@@ -94,6 +100,7 @@ export const SaveLibraryVizPanelModal = ({ libraryPanel, isUnsavedPrompt, onDism
             </Button>
           )}
           <Button
+            disabled={isPinned}
             onClick={() => {
               onConfirm();
             }}
@@ -16,6 +16,8 @@ export interface LibraryPanelBehaviorState extends SceneObjectState {
   title?: string;
   uid: string;
   name: string;
+  // Version of the library panel the dashboard is pinned to, the latest version is used when not set.
+  pinnedVersion?: number;
   isLoaded?: boolean;
   _loadedPanel?: LibraryPanel;
 }
@@ -104,7 +106,7 @@ export class LibraryPanelBehavior extends SceneObjectBase<LibraryPanelBehaviorSt
     }
 
     try {
-      const libPanel = await getLibraryPanel(this.state.uid, true);
+      const libPanel = await getLibraryPanel(this.state.uid, true, this.state.pinnedVersion);
       this.setPanelFromLibPanel(libPanel);
     } catch (err) {
       vizPanel.setState({
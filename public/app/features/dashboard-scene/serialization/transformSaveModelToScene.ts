@@ -21,6 +21,7 @@ import {
   UserActionEvent,
 } from '@grafana/scenes';
 import { DashboardModel, PanelModel } from 'app/features/dashboard/state';
+import { getLibraryPanelPinnedVersion } from 'app/features/dashboard/state/PanelModel';
 import { DashboardDTO, DashboardDataDTO } from 'app/types';
 
 import { addPanelsOnLoadBehavior } from '../addToDashboard/addPanelsOnLoadBehavior';
@@ -324,7 +325,11 @@ export function buildGridItemForPanel(panel: PanelModel): DashboardGridItem {
 
   if (panel.libraryPanel) {
     vizPanelState.$behaviors!.push(
-      new LibraryPanelBehavior({ uid: panel.libraryPanel.uid, name: panel.libraryPanel.name })
+      new LibraryPanelBehavior({
+        uid: panel.libraryPanel.uid,
+        name: panel.libraryPanel.name,
+        pinnedVersion: getLibraryPanelPinnedVersion(panel.libraryPanel),
+      })
     );
     vizPanelState.pluginId = LibraryPanelBehavior.LOADING_VIZ_PANEL_PLUGIN_ID;
     vizPanelState.$data = undefined;
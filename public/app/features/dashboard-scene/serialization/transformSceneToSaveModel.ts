@@ -185,6 +185,7 @@ export function vizPanelToPanel(
       libraryPanel: {
         name: libPanel!.state.name,
         uid: libPanel!.state.uid,
+        pinnedVersion: libPanel!.state.pinnedVersion,
       },
     } as Panel;
 
@@ -26,6 +26,11 @@ export interface PublicDashboard extends PublicDashboardSettings {
   dashboardUid: string;
   timeSettings?: object;
   recipients?: Array<{ uid: string; recipient: string }>;
+  expiresAt?: string | null;
+  allowedIps?: string[] | null;
+  queryRateLimit?: number;
+  viewCount?: number;
+  queryCount?: number;
 }
 
 export interface SessionDashboard {
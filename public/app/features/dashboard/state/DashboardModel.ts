@@ -44,7 +44,7 @@ import { getTimeSrv } from '../services/TimeSrv';
 import { mergePanels, PanelMergeInfo } from '../utils/panelMerge';
 
 import { DashboardMigrator } from './DashboardMigrator';
-import { explicitlyControlledMigrationPanels, PanelModel } from './PanelModel';
+import { explicitlyControlledMigrationPanels, getLibraryPanelPinnedVersion, PanelModel } from './PanelModel';
 import { TimeModel } from './TimeModel';
 import { deleteScopeVars, isOnTheSameGridRow } from './utils';
 
@@ -301,6 +301,7 @@ export class DashboardModel implements TimeModel {
             libraryPanel: {
               uid: libraryPanel.uid,
               name: libraryPanel.name,
+              pinnedVersion: getLibraryPanelPinnedVersion(libraryPanel),
             },
           };
         }
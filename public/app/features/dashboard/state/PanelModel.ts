@@ -338,6 +338,7 @@ export class PanelModel implements DataConfigSource, IPanelModel {
             libraryPanel: {
               uid: libraryPanel.uid,
               name: libraryPanel.name,
+              pinnedVersion: getLibraryPanelPinnedVersion(libraryPanel),
             },
           };
         }
@@ -696,7 +697,8 @@ export class PanelModel implements DataConfigSource, IPanelModel {
       }
       (this as any)[key] = val; // :grimmice:
     }
-    this.libraryPanel = libPanel;
+    const pinnedVersion = getLibraryPanelPinnedVersion(this.libraryPanel);
+    this.libraryPanel = pinnedVersion ? { ...libPanel, pinnedVersion } : libPanel;
   }
 
   unlinkLibraryPanel() {
@@ -706,6 +708,14 @@ export class PanelModel implements DataConfigSource, IPanelModel {
   }
 }
 
+/**
+ * Returns the version of the library panel the panel is pinned to, or undefined when the panel
+ * follows the latest version of the library panel.
+ */
+export function getLibraryPanelPinnedVersion(libraryPanel?: LibraryPanelRef | LibraryPanel): number | undefined {
+  return libraryPanel && 'pinnedVersion' in libraryPanel ? libraryPanel.pinnedVersion : undefined;
+}
+
 export function getPluginVersion(plugin: PanelPlugin): string {
   return plugin && plugin.meta.info.version ? plugin.meta.info.version : config.buildInfo.version;
 }
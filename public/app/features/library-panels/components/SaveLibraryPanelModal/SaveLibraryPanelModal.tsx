@@ -1,9 +1,10 @@
 import { useCallback, useState } from 'react';
 import { useAsync, useDebounce } from 'react-use';
 
-import { Button, Icon, Input, Modal, useStyles2 } from '@grafana/ui';
+import { Alert, Button, Icon, Input, Modal, useStyles2 } from '@grafana/ui';
+import { getLibraryPanelPinnedVersion } from 'app/features/dashboard/state/PanelModel';
 
-import { getConnectedDashboards } from '../../state/api';
+import { getConnectedDashboards, pinnedLibraryPanelSaveError } from '../../state/api';
 import { getModalStyles } from '../../styles';
 import { PanelModelWithLibraryPanel } from '../../types';
 import { usePanelSave } from '../../utils/usePanelSave';
@@ -56,11 +57,17 @@ export const SaveLibraryPanelModal = ({
     onDiscard();
   }, [onDiscard]);
 
+  const isPinned = Boolean(getLibraryPanelPinnedVersion(panel.libraryPanel));
   const title = isUnsavedPrompt ? 'Unsaved library panel changes' : 'Save library panel';
 
   return (
     <Modal title={title} icon="save" onDismiss={onDismiss} isOpen={true}>
       <div>
+        {isPinned ? (
+          <Alert severity="warning" title="Pinned library panel">
+            {pinnedLibraryPanelSaveError}
+          </Alert>
+        ) : null}
         <p className={styles.textInfo}>
           {'This update will affect '}
           <strong>
@@ -104,6 +111,7 @@ export const SaveLibraryPanelModal = ({
             </Button>
           )}
           <Button
+            disabled={isPinned}
             onClick={() => {
               saveLibraryPanel(panel, folderUid).then(() => {
                 onConfirm();
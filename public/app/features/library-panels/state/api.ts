@@ -3,6 +3,7 @@ import { lastValueFrom } from 'rxjs';
 import { VizPanel } from '@grafana/scenes';
 import { LibraryPanel, defaultDashboard } from '@grafana/schema';
 import { DashboardModel } from 'app/features/dashboard/state';
+import { getLibraryPanelPinnedVersion } from 'app/features/dashboard/state/PanelModel';
 import { DashboardGridItem } from 'app/features/dashboard-scene/scene/layout-default/DashboardGridItem';
 import { vizPanelToPanel } from 'app/features/dashboard-scene/serialization/transformSceneToSaveModel';
 import { getLibraryPanelBehavior } from 'app/features/dashboard-scene/utils/utils';
@@ -14,6 +15,7 @@ import {
   LibraryElementDTO,
   LibraryElementKind,
   LibraryElementsSearchResult,
+  LibraryElementVersionDTO,
   PanelModelWithLibraryPanel,
 } from '../types';
 
@@ -52,7 +54,15 @@ export async function getLibraryPanels({
   return result;
 }
 
-export async function getLibraryPanel(uid: string, isHandled = false): Promise<LibraryElementDTO> {
+/**
+ * Gets a library panel. When a pinned version is given, the name and model of that version are returned
+ * instead of the ones of the latest version.
+ */
+export async function getLibraryPanel(
+  uid: string,
+  isHandled = false,
+  pinnedVersion?: number
+): Promise<LibraryElementDTO> {
   const response = await lastValueFrom(
     getBackendSrv().fetch<{ result: LibraryElementDTO }>({
       method: 'GET',
@@ -62,7 +72,11 @@ export async function getLibraryPanel(uid: string, isHandled = false): Promise<L
     })
   );
   // kinda heavy weight migration process!!!
-  const { result } = response.data;
+  let { result } = response.data;
+  if (pinnedVersion) {
+    const { name, model } = await getLibraryPanelVersion(uid, pinnedVersion, isHandled);
+    result = { ...result, name, model };
+  }
   const dash = new DashboardModel({
     ...defaultDashboard,
     schemaVersion: 35, // should be saved in the library panel
@@ -82,6 +96,22 @@ export async function getLibraryPanel(uid: string, isHandled = false): Promise<L
   };
 }
 
+export async function getLibraryPanelVersion(
+  uid: string,
+  version: number,
+  isHandled = false
+): Promise<LibraryElementVersionDTO> {
+  const response = await lastValueFrom(
+    getBackendSrv().fetch<{ result: LibraryElementVersionDTO }>({
+      method: 'GET',
+      url: `/api/library-elements/${uid}/versions/${version}`,
+      showSuccessAlert: !isHandled,
+      showErrorAlert: !isHandled,
+    })
+  );
+  return response.data.result;
+}
+
 export async function getLibraryPanelByName(name: string): Promise<LibraryElementDTO[]> {
   const { result } = await getBackendSrv().get<{ result: LibraryElementDTO[] }>(`/api/library-elements/name/${name}`);
   return result;
@@ -100,8 +130,18 @@ export async function addLibraryPanel(
   return result;
 }
 
+/**
+ * Panels pinned to a version of a library panel show the name and model of that version, saving them to the
+ * library would replace the latest version with the pinned one. They have to be unpinned before they can be saved.
+ */
+export const pinnedLibraryPanelSaveError =
+  'This panel is pinned to a version of the library panel. Remove the pinned version from the dashboard JSON model before saving changes to the library panel.';
+
 export async function updateLibraryPanel(panelSaveModel: PanelModelWithLibraryPanel): Promise<LibraryElementDTO> {
   const { libraryPanel, ...model } = panelSaveModel;
+  if (getLibraryPanelPinnedVersion(libraryPanel)) {
+    throw new Error(pinnedLibraryPanelSaveError);
+  }
   const { uid, name, version, folderUid } = libraryPanel;
   const kind = LibraryElementKind.Panel;
   const { result } = await getBackendSrv().patch(`/api/library-elements/${uid}`, {
@@ -174,6 +214,10 @@ export function libraryVizPanelToSaveModel(vizPanel: VizPanel) {
 }
 
 export async function updateLibraryVizPanel(vizPanel: VizPanel): Promise<LibraryPanel> {
+  if (getLibraryPanelBehavior(vizPanel)?.state.pinnedVersion) {
+    throw new Error(pinnedLibraryPanelSaveError);
+  }
+
   const { uid, folderUid, name, model, version, kind } = libraryVizPanelToSaveModel(vizPanel);
 
   const { result } = await getBackendSrv().patch(`/api/library-elements/${uid}`, {
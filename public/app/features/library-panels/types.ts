@@ -23,6 +23,19 @@ export interface LibraryElementConnectionDTO {
   elementId: number;
   connectionId: number;
   connectionUid: string;
+  /** Version of the library panel the dashboard is pinned to, 0 when it follows the latest version. */
+  pinnedVersion: number;
+  created: string;
+  createdBy: LibraryElementDTOMetaUser;
+}
+
+export interface LibraryElementVersionDTO {
+  id: number;
+  elementUid: string;
+  version: number;
+  restoredFrom: number;
+  name: string;
+  model: LibraryPanel['model'];
   created: string;
   createdBy: LibraryElementDTOMetaUser;
 }
@@ -2,7 +2,7 @@ import { uniqueId } from 'lodash';
 import { useRef } from 'react';
 
 import { SelectableValue } from '@grafana/data';
-import { InlineField, Select, Input } from '@grafana/ui';
+import { InlineField, Select, Input, InlineSwitch } from '@grafana/ui';
 
 import { useDispatch } from '../../../../hooks/useStatelessReducer';
 import { MetricAggregation, Percentiles, ExtendedStatMetaType, ExtendedStats, Terms } from '../../../../types';
@@ -20,8 +20,10 @@ interface Props {
 }
 
 export const TermsSettingsEditor = ({ bucketAgg }: Props) => {
-  const { metrics } = useQuery();
+  const { metrics, bucketAggs } = useQuery();
   const orderBy = createOrderByOptions(metrics);
+  // composite aggregations can't be nested in other bucket aggregations
+  const canPaginate = bucketAggs?.[0]?.id === bucketAgg.id;
   const { current: baseId } = useRef(uniqueId('es-terms-'));
 
   const dispatch = useDispatch();
@@ -87,6 +89,43 @@ export const TermsSettingsEditor = ({ bucketAgg }: Props) => {
           defaultValue={bucketAgg.settings?.missing || bucketAggregationConfig.terms.defaultSettings?.missing}
         />
       </InlineField>
+
+      {canPaginate && (
+        <InlineField
+          label="Paginate"
+          tooltip="Fetch all terms with a composite aggregation, then keep the top terms limited by size."
+          {...inlineFieldProps}
+        >
+          <InlineSwitch
+            id={`${baseId}-composite`}
+            onChange={(e: React.ChangeEvent<HTMLInputElement>) =>
+              dispatch(
+                changeBucketAggregationSetting({ bucketAgg, settingName: 'composite', newValue: e.target.checked })
+              )
+            }
+            checked={!!bucketAgg.settings?.composite}
+          />
+        </InlineField>
+      )}
+
+      {canPaginate && bucketAgg.settings?.composite && (
+        <InlineField
+          label="Bucket limit"
+          tooltip="Maximum number of terms to fetch, up to the limit of the data source. Results over the limit are truncated with a warning."
+          {...inlineFieldProps}
+        >
+          <Input
+            id={`${baseId}-max_buckets`}
+            placeholder="10000"
+            onBlur={(e) =>
+              dispatch(
+                changeBucketAggregationSetting({ bucketAgg, settingName: 'maxBuckets', newValue: e.target.value })
+              )
+            }
+            defaultValue={bucketAgg.settings?.maxBuckets}
+          />
+        </InlineField>
+      )}
     </>
   );
 };
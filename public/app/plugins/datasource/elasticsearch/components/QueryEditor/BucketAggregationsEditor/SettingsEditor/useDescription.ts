@@ -46,6 +46,10 @@ export const useDescription = (bucketAgg: BucketAggregation): string => {
       if (size === '0') {
         description += ` (${order})`;
       }
+
+      if (bucketAgg.settings?.composite) {
+        description += ', Paginated';
+      }
       return description;
     }
 
@@ -7,12 +7,14 @@ import { MetricAggregation, QueryType } from '../../types';
 import { useQuery } from './ElasticsearchQueryContext';
 import { changeMetricType } from './MetricAggregationsEditor/state/actions';
 import { metricAggregationConfig } from './MetricAggregationsEditor/utils';
+import { changeQueryType } from './state';
 
 const OPTIONS: Array<SelectableValue<QueryType>> = [
   { value: 'metrics', label: 'Metrics' },
   { value: 'logs', label: 'Logs' },
   { value: 'raw_data', label: 'Raw Data' },
   { value: 'raw_document', label: 'Raw Document' },
+  { value: 'esql', label: 'ES|QL' },
 ];
 
 function queryTypeToMetricType(type: QueryType): MetricAggregation['type'] {
@@ -22,6 +24,7 @@ function queryTypeToMetricType(type: QueryType): MetricAggregation['type'] {
     case 'raw_document':
       return type;
     case 'metrics':
+    case 'esql':
       return 'count';
     default:
       // should never happen
@@ -40,9 +43,13 @@ export const QueryTypeSelector = () => {
     return null;
   }
 
-  const queryType = metricAggregationConfig[firstMetric.type].impliedQueryType;
+  const queryType = query.queryType === 'esql' ? 'esql' : metricAggregationConfig[firstMetric.type].impliedQueryType;
 
   const onChange = (newQueryType: QueryType) => {
+    if (newQueryType === 'esql') {
+      dispatch(changeQueryType('esql'));
+      return;
+    }
     dispatch(changeMetricType({ id: firstMetric.id, type: queryTypeToMetricType(newQueryType) }));
   };
 
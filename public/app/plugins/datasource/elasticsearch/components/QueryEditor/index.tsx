@@ -77,12 +77,20 @@ interface Props {
   value: ElasticsearchQuery;
 }
 
-export const ElasticSearchQueryField = ({ value, onChange }: { value?: string; onChange: (v: string) => void }) => {
+export const ElasticSearchQueryField = ({
+  value,
+  onChange,
+  placeholder = 'Enter a lucene query',
+}: {
+  value?: string;
+  onChange: (v: string) => void;
+  placeholder?: string;
+}) => {
   const styles = useStyles2(getStyles);
 
   return (
     <div className={styles.queryItem}>
-      <QueryField query={value} onChange={onChange} placeholder="Enter a lucene query" portalOrigin="elasticsearch" />
+      <QueryField query={value} onChange={onChange} placeholder={placeholder} portalOrigin="elasticsearch" />
     </div>
   );
 };
@@ -95,6 +103,32 @@ const QueryEditorForm = ({ value }: Props) => {
 
   const isTimeSeries = isTimeSeriesQuery(value);
 
+  if (value.queryType === 'esql') {
+    return (
+      <>
+        <div className={styles.root}>
+          <InlineLabel width={17}>Query type</InlineLabel>
+          <div className={styles.queryItem}>
+            <QueryTypeSelector />
+          </div>
+        </div>
+        <div className={styles.root}>
+          <InlineLabel
+            width={17}
+            tooltip="ES|QL query sent to the _query endpoint. Use $__timeFilter to filter on the configured time field."
+          >
+            ES|QL Query
+          </InlineLabel>
+          <ElasticSearchQueryField
+            onChange={(query) => dispatch(changeQuery(query))}
+            value={value?.query}
+            placeholder="FROM logs-* | WHERE $__timeFilter | STATS count = COUNT(*) BY BUCKET(@timestamp, $__interval)"
+          />
+        </div>
+      </>
+    );
+  }
+
   const showBucketAggregationsEditor = value.metrics?.every(
     (metric) => metricAggregationConfig[metric.type].impliedQueryType === 'metrics'
   );
@@ -2,6 +2,8 @@ import { Action, createAction } from '@reduxjs/toolkit';
 
 import { ElasticsearchQuery } from '../../types';
 
+import { changeMetricType } from './MetricAggregationsEditor/state/actions';
+
 /**
  * When the `initQuery` Action is dispatched, the query gets populated with default values where values are not present.
  * This means it won't override any existing value in place, but just ensure the query is in a "runnable" state.
@@ -10,6 +12,8 @@ export const initQuery = createAction('init');
 
 export const changeQuery = createAction<ElasticsearchQuery['query']>('change_query');
 
+export const changeQueryType = createAction<ElasticsearchQuery['queryType']>('change_query_type');
+
 export const changeAliasPattern = createAction<ElasticsearchQuery['alias']>('change_alias_pattern');
 
 export const queryReducer = (prevQuery: ElasticsearchQuery['query'], action: Action) => {
@@ -35,3 +39,16 @@ export const aliasPatternReducer = (prevAliasPattern: ElasticsearchQuery['alias'
 
   return prevAliasPattern;
 };
+
+export const queryTypeReducer = (prevQueryType: ElasticsearchQuery['queryType'], action: Action) => {
+  if (changeQueryType.match(action)) {
+    return action.payload;
+  }
+
+  // picking a metric type switches the query back to the query builder
+  if (changeMetricType.match(action)) {
+    return undefined;
+  }
+
+  return prevQueryType;
+};
@@ -94,6 +94,21 @@ export const ElasticDetails = ({ value, onChange }: Props) => {
         />
       </InlineField>
 
+      <InlineField
+        label="Max paginated buckets"
+        htmlFor="es_config_maxCompositeBuckets"
+        labelWidth={29}
+        tooltip="Maximum number of buckets a paginated terms aggregation can fetch. The bucket limit of queries can't exceed it. Defaults to 10000."
+      >
+        <Input
+          id="es_config_maxCompositeBuckets"
+          value={value.jsonData.maxCompositeBuckets || ''}
+          onChange={jsonDataChangeHandler('maxCompositeBuckets', value, onChange)}
+          width={24}
+          placeholder="10000"
+        />
+      </InlineField>
+
       <InlineField
         label="Min time interval"
         htmlFor="es_config_minTimeInterval"
@@ -37,6 +37,15 @@ composableKinds: DataQuery: {
 				bucketAggs?: [...#BucketAggregation]
 				// List of metric aggregations
 				metrics?: [...#MetricAggregation]
+				// Field mappings of annotation queries
+				annotation?: {
+					timeField?:    string
+					timeEndField?: string
+					textField?:    string
+					tagsField?:    string
+					titleField?:   string
+					limit?:        int64
+				}
 
 				#BucketAggregation: #DateHistogram | #Histogram | #Terms | #Filters | #GeoHashGrid | #Nested @cuetsy(kind="type")
 				#MetricAggregation: #Count | #PipelineMetricAggregation | #MetricAggregationWithSettings     @cuetsy(kind="type")
@@ -99,6 +108,8 @@ composableKinds: DataQuery: {
 					min_doc_count?: string
 					orderBy?:       string
 					missing?:       string
+					composite?:     bool
+					maxBuckets?:    string
 				} @cuetsy(kind="interface")
 
 				#Filters: {
@@ -72,11 +72,15 @@ export interface Terms extends BucketAggregationWithField {
     min_doc_count?: string;
     orderBy?: string;
     missing?: string;
+    composite?: boolean;
+    maxBuckets?: string;
   };
   type: 'terms';
 }
 
 export interface TermsSettings {
+  composite?: boolean;
+  maxBuckets?: string;
   min_doc_count?: string;
   missing?: string;
   order?: TermsOrder;
@@ -381,6 +385,17 @@ export interface ElasticsearchDataQuery extends common.DataQuery {
    * Alias pattern
    */
   alias?: string;
+  /**
+   * Field mappings of annotation queries
+   */
+  annotation?: {
+    timeField?: string;
+    timeEndField?: string;
+    textField?: string;
+    tagsField?: string;
+    titleField?: string;
+    limit?: number;
+  };
   /**
    * List of bucket aggregations
    */
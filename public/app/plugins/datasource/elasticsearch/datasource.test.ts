@@ -801,203 +801,76 @@ describe('ElasticDatasource', () => {
     });
   });
 
-  describe('annotationQuery', () => {
-    describe('results processing', () => {
-      it('should return simple annotations using defaults', async () => {
-        ds.postResourceRequest = jest.fn().mockResolvedValue({
-          responses: [
-            {
-              hits: {
-                hits: [
-                  { _source: { '@timestamp': 1, '@test_tags': 'foo', text: 'abc' } },
-                  { _source: { '@timestamp': 3, '@test_tags': 'bar', text: 'def' } },
-                ],
-              },
-            },
-          ],
-        });
-
-        const annotations = await ds.annotationQuery({
-          annotation: {},
-          dashboard: {
-            getVariables: () => [],
-          },
-          range: {
-            from: 1,
-            to: 2,
-          },
-        });
-
-        expect(annotations).toHaveLength(2);
-        expect(annotations[0].time).toBe(1);
-        expect(annotations[1].time).toBe(3);
+  describe('prepareAnnotationQuery', () => {
+    it('should build an annotation query using defaults', () => {
+      const query = ds.prepareAnnotationQuery({
+        name: 'foo',
+        enable: true,
+        iconColor: 'red',
+        target: { refId: 'annotation_query', query: 'abc' },
       });
 
-      it('should return annotation events using options', async () => {
-        ds.postResourceRequest = jest.fn().mockResolvedValue({
-          responses: [
-            {
-              hits: {
-                hits: [
-                  { _source: { '@test_time': 1, '@test_tags': 'foo', text: 'abc' } },
-                  { _source: { '@test_time': 3, '@test_tags': 'bar', text: 'def' } },
-                ],
-              },
-            },
-          ],
-        });
-
-        const annotations = await ds.annotationQuery({
-          annotation: {
-            timeField: '@test_time',
-            name: 'foo',
-            query: 'abc',
-            tagsField: '@test_tags',
-            textField: 'text',
-          },
-          dashboard: {
-            getVariables: () => [],
-          },
-          range: {
-            from: 1,
-            to: 2,
-          },
-        });
-        expect(annotations).toHaveLength(2);
-        expect(annotations[0].time).toBe(1);
-        expect(annotations[0].tags?.[0]).toBe('foo');
-        expect(annotations[0].text).toBe('abc');
-
-        expect(annotations[1].time).toBe(3);
-        expect(annotations[1].tags?.[0]).toBe('bar');
-        expect(annotations[1].text).toBe('def');
+      expect(query).toEqual({
+        refId: 'annotation_query',
+        queryType: 'annotations',
+        query: 'abc',
+        annotation: {
+          timeField: undefined,
+          timeEndField: undefined,
+          textField: undefined,
+          tagsField: undefined,
+          titleField: undefined,
+        },
       });
     });
 
-    describe('request processing', () => {
-      it('should process annotation request using options', async () => {
-        const postResourceRequestMock = jest.spyOn(ds, 'postResourceRequest').mockResolvedValue({
-          responses: [
-            {
-              hits: {
-                hits: [
-                  { _source: { '@test_time': 1, '@test_tags': 'foo', text: 'abc' } },
-                  { _source: { '@test_time': 3, '@test_tags': 'bar', text: 'def' } },
-                ],
-              },
-            },
-          ],
-        });
-
-        await ds.annotationQuery({
-          annotation: {
-            timeField: '@test_time',
-            timeEndField: '@time_end_field',
-            name: 'foo',
-            query: 'abc',
-            tagsField: '@test_tags',
-            textField: 'text',
-          },
-          dashboard: {
-            getVariables: () => [],
-          },
-          range: {
-            from: dateTime(1683291160012),
-            to: dateTime(1683291460012),
-          },
-        });
-        expect(postResourceRequestMock).toHaveBeenCalledWith(
-          '_msearch',
-          '{"search_type":"query_then_fetch","ignore_unavailable":true,"index":"[test-]YYYY.MM.DD"}\n{"query":{"bool":{"filter":[{"bool":{"should":[{"range":{"@test_time":{"from":1683291160012,"to":1683291460012,"format":"epoch_millis"}}},{"range":{"@time_end_field":{"from":1683291160012,"to":1683291460012,"format":"epoch_millis"}}}],"minimum_should_match":1}},{"query_string":{"query":"abc"}}]}},"size":10000}\n'
-        );
+    it('should build an annotation query using the field mappings', () => {
+      const query = ds.prepareAnnotationQuery({
+        name: 'foo',
+        enable: true,
+        iconColor: 'red',
+        timeField: '@test_time',
+        timeEndField: '@time_end_field',
+        tagsField: '@test_tags',
+        textField: 'text',
+        titleField: 'title',
+        target: { refId: 'annotation_query', query: 'abc' },
       });
 
-      it('should process annotation request using defaults', async () => {
-        const postResourceRequestMock = jest.spyOn(ds, 'postResourceRequest').mockResolvedValue({
-          responses: [
-            {
-              hits: {
-                hits: [
-                  { _source: { '@test_time': 1, '@test_tags': 'foo', text: 'abc' } },
-                  { _source: { '@test_time': 3, '@test_tags': 'bar', text: 'def' } },
-                ],
-              },
-            },
-          ],
-        });
+      expect(query.annotation).toEqual({
+        timeField: '@test_time',
+        timeEndField: '@time_end_field',
+        textField: 'text',
+        tagsField: '@test_tags',
+        titleField: 'title',
+      });
+    });
 
-        await ds.annotationQuery({
-          annotation: {},
-          dashboard: {
-            getVariables: () => [],
-          },
-          range: {
-            from: dateTime(1683291160012),
-            to: dateTime(1683291460012),
-          },
-        });
-        expect(postResourceRequestMock).toHaveBeenCalledWith(
-          '_msearch',
-          '{"search_type":"query_then_fetch","ignore_unavailable":true,"index":"[test-]YYYY.MM.DD"}\n{"query":{"bool":{"filter":[{"bool":{"should":[{"range":{"@timestamp":{"from":1683291160012,"to":1683291460012,"format":"epoch_millis"}}}],"minimum_should_match":1}}]}},"size":10000}\n'
-        );
+    it('should give priority to the legacy query location', () => {
+      const query = ds.prepareAnnotationQuery({
+        name: 'foo',
+        enable: true,
+        iconColor: 'red',
+        query: 'legacy',
+        target: { refId: 'annotation_query', query: 'abc' },
       });
 
-      it('should process annotation request using dashboard adhoc variables', async () => {
-        const ds = createElasticDatasource();
-        const postResourceRequestMock = jest.spyOn(ds, 'postResourceRequest').mockResolvedValue({
-          responses: [
-            {
-              hits: {
-                hits: [
-                  { _source: { '@test_time': 1, '@test_tags': 'foo', text: 'abc' } },
-                  { _source: { '@test_time': 3, '@test_tags': 'bar', text: 'def' } },
-                ],
-              },
-            },
-          ],
-        });
+      expect(query.query).toBe('legacy');
+    });
 
-        await ds.annotationQuery({
-          annotation: {
-            timeField: '@test_time',
-            timeEndField: '@time_end_field',
-            name: 'foo',
-            query: 'abc',
-            tagsField: '@test_tags',
-            textField: 'text',
-            datasource: {
-              type: 'elasticsearch',
-              uid: 'gdev-elasticsearch',
-            },
-          },
-          dashboard: {
-            getVariables: () => [
-              {
-                type: 'adhoc',
-                datasource: {
-                  type: 'elasticsearch',
-                  uid: 'gdev-elasticsearch',
-                },
-                filters: [
-                  {
-                    key: 'abc_key',
-                    operator: '=',
-                    value: 'abc_value',
-                  },
-                ],
-              },
-            ],
-          },
-          range: {
-            from: dateTime(1683291160012),
-            to: dateTime(1683291460012),
-          },
-        });
-        expect(postResourceRequestMock).toHaveBeenCalledWith(
-          '_msearch',
-          '{"search_type":"query_then_fetch","ignore_unavailable":true,"index":"[test-]YYYY.MM.DD"}\n{"query":{"bool":{"filter":[{"bool":{"should":[{"range":{"@test_time":{"from":1683291160012,"to":1683291460012,"format":"epoch_millis"}}},{"range":{"@time_end_field":{"from":1683291160012,"to":1683291460012,"format":"epoch_millis"}}}],"minimum_should_match":1}},{"query_string":{"query":"abc AND abc_key:\\"abc_value\\""}}]}},"size":10000}\n'
-        );
+    it('should interpolate the query and add ad hoc filters', () => {
+      const query = ds.prepareAnnotationQuery({
+        name: 'foo',
+        enable: true,
+        iconColor: 'red',
+        target: { refId: 'annotation_query', query: 'abc' },
       });
+
+      const interpolated = ds.applyTemplateVariables(query, {}, [
+        { key: 'abc_key', operator: '=', value: 'abc_value' },
+      ]);
+      expect(interpolated.queryType).toBe('annotations');
+      expect(interpolated.query).toBe('abc AND abc_key:"abc_value"');
     });
   });
 
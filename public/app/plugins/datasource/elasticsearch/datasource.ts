@@ -1,4 +1,4 @@
-import { cloneDeep, first as _first, isNumber, isObject, isString, map as _map, find } from 'lodash';
+import { cloneDeep, first as _first, isObject, isString, map as _map, find } from 'lodash';
 import { from, generate, lastValueFrom, Observable, of } from 'rxjs';
 import { catchError, first, map, mergeMap, skipWhile, throwIfEmpty, tap } from 'rxjs/operators';
 import { SemVer } from 'semver';
@@ -29,16 +29,13 @@ import {
   LogRowContextQueryDirection,
   LogRowContextOptions,
   SupplementaryQueryOptions,
-  toUtc,
-  AnnotationEvent,
+  AnnotationQuery,
   DataSourceWithToggleableQueryFiltersSupport,
   QueryFilterOptions,
   ToggleFilterAction,
   DataSourceGetTagValuesOptions,
   AdHocVariableFilter,
   DataSourceWithQueryModificationSupport,
-  AdHocVariableModel,
-  TypedVariableModel,
 } from '@grafana/data';
 import {
   DataSourceWithBackend,
@@ -77,10 +74,7 @@ import {
   TermsQuery,
   Interval,
   ElasticsearchAnnotationQuery,
-  RangeMap,
   isElasticsearchResponseWithAggregations,
-  isElasticsearchResponseWithHits,
-  ElasticsearchHits,
 } from './types';
 import { getScriptValue, isSupportedVersion, isTimeSeriesQuery, unsupportedVersionMessage } from './utils';
 
@@ -156,6 +150,7 @@ export class ElasticDatasource
     this.databaseVersion = null;
     this.annotations = {
       QueryEditor: ElasticsearchAnnotationsQueryEditor,
+      prepareQuery: (annotation) => this.prepareAnnotationQuery(annotation),
     };
 
     if (this.logLevelField === '') {
@@ -236,46 +231,13 @@ export class ElasticDatasource
   }
 
   /**
-   * Implemented as part of the DataSourceAPI. It allows the datasource to serve as a source of annotations for a dashboard.
-   * @returns A promise that resolves to an array of AnnotationEvent objects representing the annotations for the dashboard.
-   * @todo This is deprecated and it is recommended to use the `AnnotationSupport` feature for annotations.
+   * Converts an annotation to a query that is run by the backend. The documents are read with the
+   * field mappings of the annotation and returned as time, timeEnd, text and tags fields.
    */
-  annotationQuery(options: any): Promise<AnnotationEvent[]> {
-    const payload = this.prepareAnnotationRequest(options);
-    trackAnnotationQuery(options.annotation);
-    // TODO: We should migrate this to use query and not resource call
-    // The plan is to look at this when we start to work on raw query editor for ES
-    // as we will have to explore how to handle any query
-    const annotationObservable = from(this.postResourceRequest('_msearch', payload));
-    return lastValueFrom(
-      annotationObservable.pipe(
-        map((res: unknown) => {
-          if (!isElasticsearchResponseWithHits(res)) {
-            return [];
-          }
-          const hits = res?.responses[0].hits?.hits ?? [];
-          return this.processHitsToAnnotationEvents(options.annotation, hits);
-        })
-      )
-    );
-  }
-
-  // Private method used in the `annotationQuery` to prepare the payload for the Elasticsearch annotation request
-  private prepareAnnotationRequest(options: {
-    annotation: ElasticsearchAnnotationQuery;
-    // Should be DashboardModel but cannot import that here from the main app. This is a temporary solution as we need to move from deprecated annotations.
-    dashboard: { getVariables: () => TypedVariableModel[] };
-    range: TimeRange;
-  }) {
-    const annotation = options.annotation;
-    const timeField = annotation.timeField || '@timestamp';
-    const timeEndField = annotation.timeEndField || null;
-    const dashboard = options.dashboard;
-
+  prepareAnnotationQuery(annotation: AnnotationQuery<ElasticsearchQuery>): ElasticsearchQuery {
     // eslint-disable-next-line @typescript-eslint/consistent-type-assertions
-    const adhocVariables = dashboard.getVariables().filter((v) => v.type === 'adhoc') as AdHocVariableModel[];
-    const annotationRelatedVariables = adhocVariables.filter((v) => v.datasource?.uid === annotation.datasource.uid);
-    const filters = annotationRelatedVariables.map((v) => v.filters).flat();
+    const legacyAnnotation = annotation as unknown as ElasticsearchAnnotationQuery;
+    trackAnnotationQuery(legacyAnnotation);
 
     // the `target.query` is the "new" location for the query.
     // normally we would write this code as
@@ -286,141 +248,20 @@ export class ElasticDatasource
     // both the old and the new place are set,
     // and in that scenario the old place needs
     // to have priority.
-    const queryString = annotation.query ?? annotation.target?.query ?? '';
-
-    const dateRanges = [];
-    const rangeStart: RangeMap = {};
-    rangeStart[timeField] = {
-      from: options.range.from.valueOf(),
-      to: options.range.to.valueOf(),
-      format: 'epoch_millis',
-    };
-    dateRanges.push({ range: rangeStart });
-
-    if (timeEndField) {
-      const rangeEnd: RangeMap = {};
-      rangeEnd[timeEndField] = {
-        from: options.range.from.valueOf(),
-        to: options.range.to.valueOf(),
-        format: 'epoch_millis',
-      };
-      dateRanges.push({ range: rangeEnd });
-    }
+    const query = legacyAnnotation.query ?? legacyAnnotation.target?.query ?? '';
 
-    const queryInterpolated = this.interpolateLuceneQuery(queryString);
-    const finalQuery = this.addAdHocFilters(queryInterpolated, filters);
-
-    const query: {
-      bool: { filter: Array<Record<string, Record<string, string | number | Array<{ range: RangeMap }>>>> };
-    } = {
-      bool: {
-        filter: [
-          {
-            bool: {
-              should: dateRanges,
-              minimum_should_match: 1,
-            },
-          },
-        ],
-      },
-    };
-
-    if (finalQuery) {
-      query.bool.filter.push({
-        query_string: {
-          query: finalQuery,
-        },
-      });
-    }
-    const data = {
+    return {
+      refId: annotation.target?.refId ?? 'annotation_query',
+      queryType: 'annotations',
       query,
-      size: 10000,
-    };
-
-    const header: Record<string, string | string[] | boolean> = {
-      search_type: 'query_then_fetch',
-      ignore_unavailable: true,
-    };
-
-    // @deprecated
-    // Field annotation.index is deprecated and will be removed in the future
-    if (annotation.index) {
-      header.index = annotation.index;
-    } else {
-      header.index = this.indexPattern.getIndexList(options.range.from, options.range.to);
-    }
-
-    const payload = JSON.stringify(header) + '\n' + JSON.stringify(data) + '\n';
-    return payload;
-  }
-
-  // Private method used in the `annotationQuery` to process Elasticsearch hits into AnnotationEvents
-  private processHitsToAnnotationEvents(annotation: ElasticsearchAnnotationQuery, hits: ElasticsearchHits) {
-    const timeField = annotation.timeField || '@timestamp';
-    const timeEndField = annotation.timeEndField || null;
-    const textField = annotation.textField || 'tags';
-    const tagsField = annotation.tagsField || null;
-    const list: AnnotationEvent[] = [];
-
-    const getFieldFromSource = (source: any, fieldName: string | null) => {
-      if (!fieldName) {
-        return;
-      }
-
-      const fieldNames = fieldName.split('.');
-      let fieldValue = source;
-
-      for (let i = 0; i < fieldNames.length; i++) {
-        fieldValue = fieldValue[fieldNames[i]];
-        if (!fieldValue) {
-          return '';
-        }
-      }
-
-      return fieldValue;
+      annotation: {
+        timeField: legacyAnnotation.timeField,
+        timeEndField: legacyAnnotation.timeEndField,
+        textField: legacyAnnotation.textField,
+        tagsField: legacyAnnotation.tagsField,
+        titleField: legacyAnnotation.titleField,
+      },
     };
-
-    for (let i = 0; i < hits.length; i++) {
-      const source = hits[i]._source;
-      let time = getFieldFromSource(source, timeField);
-      if (typeof hits[i].fields !== 'undefined') {
-        const fields = hits[i].fields;
-        if (typeof fields === 'object' && (isString(fields[timeField]) || isNumber(fields[timeField]))) {
-          time = fields[timeField];
-        }
-      }
-
-      const event: AnnotationEvent = {
-        annotation: annotation,
-        time: toUtc(time).valueOf(),
-        text: getFieldFromSource(source, textField),
-      };
-
-      if (timeEndField) {
-        const timeEnd = getFieldFromSource(source, timeEndField);
-        if (timeEnd) {
-          event.timeEnd = toUtc(timeEnd).valueOf();
-        }
-      }
-
-      // legacy support for title field
-      if (annotation.titleField) {
-        const title = getFieldFromSource(source, annotation.titleField);
-        if (title) {
-          event.text = title + '\n' + event.text;
-        }
-      }
-
-      const tags = getFieldFromSource(source, tagsField);
-      if (typeof tags === 'string') {
-        event.tags = tags.split(',');
-      } else {
-        event.tags = tags;
-      }
-
-      list.push(event);
-    }
-    return list;
   }
 
   // Replaces variables in a Lucene query string
@@ -1080,6 +921,16 @@ export class ElasticDatasource
     scopedVars: ScopedVars,
     filters?: AdHocVariableFilter[]
   ): ElasticsearchQuery {
+    if (query.queryType === 'esql') {
+      // the interval macros are expanded in the backend to ES|QL time spans
+      const { __interval, __interval_ms, ...esqlScopedVars } = scopedVars;
+      return {
+        ...query,
+        datasource: this.getRef(),
+        query: this.templateSrv.replace(query.query || '', esqlScopedVars),
+      };
+    }
+
     // We need a separate interpolation format for lucene queries, therefore we first interpolate any
     // lucene query string and then everything else
     const interpolateBucketAgg = (bucketAgg: BucketAggregation): BucketAggregation => {
@@ -59,6 +59,7 @@ export interface ElasticsearchOptions extends DataSourceJsonData {
   interval?: Interval;
   timeInterval: string;
   maxConcurrentShardRequests?: number;
+  maxCompositeBuckets?: number;
   logMessageField?: string;
   logLevelField?: string;
   dataLinks?: DataLinkConfig[];
@@ -68,7 +69,7 @@ export interface ElasticsearchOptions extends DataSourceJsonData {
   oauthPassThru?: boolean;
 }
 
-export type QueryType = 'metrics' | 'logs' | 'raw_data' | 'raw_document';
+export type QueryType = 'metrics' | 'logs' | 'raw_data' | 'raw_document' | 'esql';
 
 interface MetricConfiguration<T extends MetricAggregationType> {
   label: string;
@@ -138,8 +139,6 @@ export interface ElasticsearchAnnotationQuery {
   index?: string;
 }
 
-export type RangeMap = Record<string, { from: number; to: number; format: string }>;
-
 export type ElasticsearchResponse = ElasticsearchResponseWithHits | ElasticsearchResponseWithAggregations;
 
 export type ElasticsearchResponseWithHits = {
@@ -15,6 +15,7 @@ import { GrafanaLiveEditor } from './components/GrafanaLiveEditor';
 import { NodeGraphEditor } from './components/NodeGraphEditor';
 import { PredictablePulseEditor } from './components/PredictablePulseEditor';
 import { RawFrameEditor } from './components/RawFrameEditor';
+import { ReplayEditor } from './components/ReplayEditor';
 import { SimulationQueryEditor } from './components/SimulationQueryEditor';
 import { USAQueryEditor, usaQueryModes } from './components/USAQueryEditor';
 import { defaultCSVWaveQuery, defaultPulseQuery, defaultQuery } from './constants';
@@ -124,6 +125,9 @@ export const QueryEditor = ({ query, datasource, onChange, onRunQuery }: Props)
         break;
       case TestDataQueryType.ErrorWithSource:
         update.errorSource = 'plugin';
+        break;
+      case TestDataQueryType.Replay:
+        update.replay = { mode: 'shift' };
     }
 
     onUpdate(update);
@@ -290,6 +294,7 @@ export const QueryEditor = ({ query, datasource, onChange, onRunQuery }: Props)
         <RawFrameEditor onChange={onUpdate} query={query} ds={datasource} />
       )}
       {scenarioId === TestDataQueryType.CSVFile && <CSVFileEditor onChange={onUpdate} query={query} ds={datasource} />}
+      {scenarioId === TestDataQueryType.Replay && <ReplayEditor onChange={onUpdate} query={query} ds={datasource} />}
       {scenarioId === TestDataQueryType.CSVContent && (
         <CSVContentEditor onChange={onUpdate} query={query} ds={datasource} />
       )}
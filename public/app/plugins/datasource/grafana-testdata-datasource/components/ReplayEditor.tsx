@@ -0,0 +1,84 @@
+import { FormEvent } from 'react';
+import { useAsync } from 'react-use';
+
+import { SelectableValue } from '@grafana/data';
+import { InlineField, InlineFieldRow, Input, Select } from '@grafana/ui';
+
+import { EditorProps } from '../QueryEditor';
+import { ReplayQuery } from '../dataquery';
+
+const modes: Array<SelectableValue<ReplayQuery['mode']>> = [
+  { label: 'Shift', value: 'shift', description: 'Move the captured data to the end of the time range' },
+  { label: 'Loop', value: 'loop', description: 'Repeat the captured data to cover the time range' },
+  { label: 'Stretch', value: 'stretch', description: 'Scale the captured data to the time range' },
+];
+
+export const ReplayEditor = ({ onChange, query, ds }: EditorProps) => {
+  const replay = query.replay ?? {};
+
+  const fixtures = useAsync(async () => {
+    const names = await ds.getResource<string[]>('fixtures');
+    return names.map((name) => ({ label: name, value: name }));
+  }, [ds]);
+
+  const onReplayChange = (update: Partial<ReplayQuery>) => {
+    onChange({ ...query, replay: { ...replay, ...update } });
+  };
+
+  const onNumberChange = (e: FormEvent<HTMLInputElement>) => {
+    const { name, value } = e.currentTarget;
+    const number = value === '' ? undefined : Number(value);
+    if (name === 'noise') {
+      onChange({ ...query, noise: number });
+    } else {
+      onReplayChange({ seed: number });
+    }
+  };
+
+  return (
+    <InlineFieldRow>
+      <InlineField label="Fixture" labelWidth={14} tooltip="Captured query response to replay">
+        <Select
+          width={32}
+          isLoading={fixtures.loading}
+          options={fixtures.value ?? []}
+          value={replay.fixture ?? null}
+          allowCustomValue
+          placeholder="Select fixture"
+          onChange={(v) => onReplayChange({ fixture: v.value })}
+        />
+      </InlineField>
+      <InlineField label="Mode" labelWidth={14}>
+        <Select
+          width={16}
+          options={modes}
+          value={replay.mode ?? 'shift'}
+          onChange={(v) => onReplayChange({ mode: v.value })}
+        />
+      </InlineField>
+      <InlineField label="Noise" labelWidth={14} tooltip="Add a random value between -noise and noise to every number">
+        <Input
+          width={10}
+          type="number"
+          name="noise"
+          min={0}
+          step={0.1}
+          placeholder="0"
+          value={query.noise ?? ''}
+          onChange={onNumberChange}
+        />
+      </InlineField>
+      <InlineField label="Seed" labelWidth={14} tooltip="The same seed always adds the same noise">
+        <Input
+          width={10}
+          type="number"
+          name="seed"
+          step={1}
+          placeholder="0"
+          value={replay.seed ?? ''}
+          onChange={onNumberChange}
+        />
+      </InlineField>
+    </InlineFieldRow>
+  );
+};
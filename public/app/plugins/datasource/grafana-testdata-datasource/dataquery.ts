@@ -25,6 +25,7 @@ export enum TestDataQueryType {
   RandomWalkTable = 'random_walk_table',
   RandomWalkWithError = 'random_walk_with_error',
   RawFrame = 'raw_frame',
+  Replay = 'replay',
   ServerError500 = 'server_error_500',
   Simulation = 'simulation',
   SlowQuery = 'slow_query',
@@ -53,6 +54,18 @@ export interface PulseWaveQuery {
   timeStep?: number;
 }
 
+export interface ReplayQuery {
+  /**
+   * Name of the captured fixture
+   */
+  fixture?: string;
+  mode?: 'shift' | 'loop' | 'stretch';
+  /**
+   * Seed of the noise added with the noise parameter
+   */
+  seed?: number;
+}
+
 export interface SimulationQuery {
   config?: Record<string, unknown>;
   key: {
@@ -116,9 +129,11 @@ export interface TestDataDataQuery extends common.DataQuery {
   levelColumn?: boolean;
   lines?: number;
   nodes?: NodesQuery;
+  noise?: number;
   points?: Array<Array<string | number>>;
   pulseWave?: PulseWaveQuery;
   rawFrameContent?: string;
+  replay?: ReplayQuery;
   scenarioId?: TestDataQueryType;
   seriesCount?: number;
   sim?: SimulationQuery;
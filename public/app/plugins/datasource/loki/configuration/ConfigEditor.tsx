@@ -35,6 +35,8 @@ const makeJsonUpdater =
 const setMaxLines = makeJsonUpdater('maxLines');
 const setPredefinedOperations = makeJsonUpdater('predefinedOperations');
 const setDerivedFields = makeJsonUpdater('derivedFields');
+const setSplitDuration = makeJsonUpdater('splitDuration');
+const setSplitConcurrency = makeJsonUpdater('splitConcurrency');
 
 export const ConfigEditor = (props: Props) => {
   const { options, onOptionsChange } = props;
@@ -81,6 +83,12 @@ export const ConfigEditor = (props: Props) => {
             onMaxLinedChange={(value) => onOptionsChange(setMaxLines(options, value))}
             predefinedOperations={options.jsonData.predefinedOperations || ''}
             onPredefinedOperationsChange={updatePredefinedOperations}
+            splitDuration={options.jsonData.splitDuration || ''}
+            onSplitDurationChange={(value) => onOptionsChange(setSplitDuration(options, value))}
+            splitConcurrency={options.jsonData.splitConcurrency?.toString() || ''}
+            onSplitConcurrencyChange={(value) =>
+              onOptionsChange(setSplitConcurrency(options, value ? parseInt(value, 10) : undefined))
+            }
           />
           <DerivedFields
             fields={options.jsonData.derivedFields}
@@ -9,10 +9,23 @@ type Props = {
   onMaxLinedChange: (value: string) => void;
   predefinedOperations: string;
   onPredefinedOperationsChange: (value: string) => void;
+  splitDuration: string;
+  onSplitDurationChange: (value: string) => void;
+  splitConcurrency: string;
+  onSplitConcurrencyChange: (value: string) => void;
 };
 
 export const QuerySettings = (props: Props) => {
-  const { maxLines, onMaxLinedChange, predefinedOperations, onPredefinedOperationsChange } = props;
+  const {
+    maxLines,
+    onMaxLinedChange,
+    predefinedOperations,
+    onPredefinedOperationsChange,
+    splitDuration,
+    onSplitDurationChange,
+    splitConcurrency,
+    onSplitConcurrencyChange,
+  } = props;
   return (
     <ConfigSubSection
       title="Queries"
@@ -47,6 +60,44 @@ export const QuerySettings = (props: Props) => {
         />
       </InlineField>
 
+      <InlineField
+        label="Split duration"
+        htmlFor="loki_config_splitDuration"
+        labelWidth={22}
+        tooltip={
+          <>
+            Queries run by the backend, such as alert rules, public dashboards and reports, are split into time shards
+            of this duration when they span a longer time range. Leave empty to disable splitting.
+          </>
+        }
+      >
+        <Input
+          id="loki_config_splitDuration"
+          value={splitDuration}
+          onChange={(event: React.FormEvent<HTMLInputElement>) => onSplitDurationChange(event.currentTarget.value)}
+          width={16}
+          placeholder="1d"
+          spellCheck={false}
+        />
+      </InlineField>
+
+      <InlineField
+        label="Split concurrency"
+        htmlFor="loki_config_splitConcurrency"
+        labelWidth={22}
+        tooltip={<>Maximum number of time shards of a single metric query that are sent to Loki at the same time.</>}
+      >
+        <Input
+          type="number"
+          id="loki_config_splitConcurrency"
+          value={splitConcurrency}
+          onChange={(event: React.FormEvent<HTMLInputElement>) => onSplitConcurrencyChange(event.currentTarget.value)}
+          width={16}
+          placeholder="4"
+          spellCheck={false}
+        />
+      </InlineField>
+
       {config.featureToggles.lokiPredefinedOperations && (
         <InlineFieldRow>
           <InlineField
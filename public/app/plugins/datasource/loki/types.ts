@@ -44,6 +44,8 @@ export interface LokiOptions extends DataSourceJsonData {
   alertmanager?: string;
   keepCookies?: string[];
   predefinedOperations?: string;
+  splitDuration?: string;
+  splitConcurrency?: number;
 }
 
 export interface LokiStreamResult {
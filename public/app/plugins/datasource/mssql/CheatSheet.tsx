@@ -54,6 +54,11 @@ export function CheatSheet() {
         </li>
         <li>$__unixEpochGroup(column,&apos;5m&apos;) -&gt; FLOOR(column/300)*300</li>
         <li>$__unixEpochGroupAlias(column,&apos;5m&apos;) -&gt; FLOOR(column/300)*300 AS [time]</li>
+        <li>$__param(name) -&gt; @p1, bound to the value of the template variable name</li>
+        <li>
+          $__param(multi) -&gt; @p1, @p2, bound to the selected values of a multi-value variable, for use in
+          IN (...) lists
+        </li>
       </ul>
       <p>Example of group by and order by with $__timeGroup:</p>
       <pre>
@@ -406,6 +406,29 @@ describe('MSSQLDatasource', () => {
     });
   });
 
+  describe('When applying template variables to a query with $__param macros', () => {
+    it('should send the variable values as bound parameters', () => {
+      const templateSrv = new TemplateSrv();
+      templateSrv.init([
+        { type: 'query', name: 'host', multi: true, current: { value: ['a', "b' OR 1=1"] } },
+        { type: 'query', name: 'region', current: { value: 'eu' } },
+      ]);
+      const ds = new MssqlDatasource(instanceSettings);
+      Reflect.set(ds, 'templateSrv', templateSrv);
+
+      const query: SQLQuery = {
+        refId: 'A',
+        rawSql: 'SELECT * FROM t WHERE host IN ($__param(host)) AND region = $__param(region) AND n > $__param(min)',
+        params: { min: 5, region: 'us' },
+      };
+
+      expect(ds.applyTemplateVariables(query, {})).toMatchObject({
+        rawSql: query.rawSql,
+        params: { host: ['a', "b' OR 1=1"], region: 'eu', min: 5 },
+      });
+    });
+  });
+
   describe('targetContainsTemplate', () => {
     it('given query that contains template variable it should return true', () => {
       const templateSrv = new TemplateSrv();
@@ -51,6 +51,11 @@ export function CheatSheet() {
         </li>
         <li>$__unixEpochGroup(column,&apos;5m&apos;) -&gt; column DIV 300 * 300</li>
         <li>$__unixEpochGroupAlias(column,&apos;5m&apos;) -&gt; column DIV 300 * 300 AS &quot;time&quot;</li>
+        <li>$__param(name) -&gt; ?, bound to the value of the template variable name</li>
+        <li>
+          $__param(multi) -&gt; ?, ?, bound to the selected values of a multi-value variable, for use in
+          IN (...) lists
+        </li>
       </ul>
       <p>Example of group by and order by with $__timeGroup:</p>
       <pre>
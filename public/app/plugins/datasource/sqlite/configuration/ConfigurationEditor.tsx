@@ -0,0 +1,65 @@
+import { DataSourcePluginOptionsEditorProps, onUpdateDatasourceJsonDataOption } from '@grafana/data';
+import { ConfigSection, DataSourceDescription } from '@grafana/experimental';
+import { ConnectionLimits, Divider } from '@grafana/sql';
+import { Alert, Field, Input } from '@grafana/ui';
+
+import { SQLiteOptions } from '../types';
+
+export const ConfigurationEditor = (props: DataSourcePluginOptionsEditorProps<SQLiteOptions>) => {
+  const { options, onOptionsChange } = props;
+  const jsonData = options.jsonData;
+
+  const WIDTH_LONG = 40;
+
+  return (
+    <>
+      <DataSourceDescription
+        dataSourceName="SQLite"
+        docsLink="https://grafana.com/docs/grafana/latest/datasources/"
+        hasRequiredFields={true}
+      />
+
+      <Divider />
+
+      <Alert severity="info" title="Database files">
+        The database file must be inside one of the directories listed in <code>allowed_paths</code> of the{' '}
+        <code>[sqlite_datasource]</code> section of the Grafana configuration. Files are opened read-only unless{' '}
+        <code>read_only</code> is disabled there.
+      </Alert>
+
+      <ConfigSection title="Connection">
+        <Field
+          label="Database path"
+          description="Absolute path, or a path relative to the first allowed directory."
+          required
+        >
+          <Input
+            width={WIDTH_LONG}
+            name="path"
+            value={jsonData.path || ''}
+            placeholder="metrics.db"
+            onChange={onUpdateDatasourceJsonDataOption(props, 'path')}
+          />
+        </Field>
+      </ConfigSection>
+
+      <Divider />
+
+      <ConfigSection title="Additional settings" isCollapsible>
+        <Field
+          label="Min time interval"
+          description="A lower limit for the auto group by time interval. Recommended to be set to write frequency, for example 1m if your data is written every minute."
+        >
+          <Input
+            width={WIDTH_LONG}
+            placeholder="1m"
+            value={jsonData.timeInterval || ''}
+            onChange={onUpdateDatasourceJsonDataOption(props, 'timeInterval')}
+          />
+        </Field>
+
+        <ConnectionLimits options={options} onOptionsChange={onOptionsChange} />
+      </ConfigSection>
+    </>
+  );
+};
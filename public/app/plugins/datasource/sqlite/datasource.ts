@@ -0,0 +1,92 @@
+import { v4 as uuidv4 } from 'uuid';
+
+import { DataSourceInstanceSettings, TimeRange } from '@grafana/data';
+import { LanguageDefinition, getStandardSQLCompletionProvider } from '@grafana/experimental';
+import { COMMON_FNS, DB, RAQBFieldTypes, SQLQuery, SQLSelectableValue, SqlDatasource, formatSQL } from '@grafana/sql';
+
+import { buildColumnQuery, quoteIdentifierIfNecessary, quoteLiteral, showTables, toRawSql } from './sqlUtil';
+import { SQLiteOptions } from './types';
+
+export class SQLiteDatasource extends SqlDatasource {
+  sqlLanguageDefinition: LanguageDefinition | undefined;
+
+  constructor(instanceSettings: DataSourceInstanceSettings<SQLiteOptions>) {
+    super(instanceSettings);
+    // A SQLite database file has a single schema, so there is no dataset to pick.
+    this.preconfiguredDatabase = 'main';
+  }
+
+  getQueryModel() {
+    return { quoteLiteral };
+  }
+
+  getSqlLanguageDefinition(): LanguageDefinition {
+    if (this.sqlLanguageDefinition !== undefined) {
+      return this.sqlLanguageDefinition;
+    }
+
+    this.sqlLanguageDefinition = {
+      id: 'sql',
+      completionProvider: (monaco, language) => ({
+        ...(language && getStandardSQLCompletionProvider(monaco, language)),
+      }),
+      formatter: formatSQL,
+    };
+
+    return this.sqlLanguageDefinition;
+  }
+
+  async fetchTables(): Promise<string[]> {
+    const tables = await this.runSql<string[]>(showTables(), { refId: 'tables' });
+    return tables.map((t) => quoteIdentifierIfNecessary(t[0]));
+  }
+
+  async fetchFields(query: Partial<SQLQuery>): Promise<SQLSelectableValue[]> {
+    if (!query.table) {
+      return [];
+    }
+    const frame = await this.runSql<string[]>(buildColumnQuery(query.table), { refId: `fields-${uuidv4()}` });
+    return frame.map((f) => ({
+      name: f[0],
+      text: f[0],
+      value: quoteIdentifierIfNecessary(f[0]),
+      type: f[1],
+      label: f[0],
+      raqbFieldType: toRaqbFieldType(f[1]),
+    }));
+  }
+
+  getDB(): DB {
+    if (this.db !== undefined) {
+      return this.db;
+    }
+
+    return {
+      datasets: () => Promise.resolve(['main']),
+      tables: () => this.fetchTables(),
+      fields: (query: SQLQuery) => this.fetchFields(query),
+      validateQuery: (query: SQLQuery, _range?: TimeRange) =>
+        Promise.resolve({ query, error: '', isError: false, isValid: true }),
+      dsID: () => this.id,
+      toRawSql,
+      functions: () => COMMON_FNS,
+      getEditorLanguageDefinition: () => this.getSqlLanguageDefinition(),
+    };
+  }
+}
+
+// toRaqbFieldType maps a declared column type to a query builder field type using
+// SQLite's type affinity rules.
+function toRaqbFieldType(declaredType = ''): RAQBFieldTypes {
+  const type = declaredType.toUpperCase();
+  if (type.includes('INT') || type.includes('REAL') || type.includes('FLOA') || type.includes('DOUB')) {
+    return 'number';
+  }
+  if (type.includes('BOOL')) {
+    return 'boolean';
+  }
+  if (type.includes('DATE') || type.includes('TIME')) {
+    return 'datetime';
+  }
+  return 'text';
+}
@@ -115,11 +115,25 @@ export interface AlertmanagerApiFeatures {
   lazyConfigInit: boolean;
 }
 
+/**
+ * Cost of the last evaluation of a Grafana rule, times are in seconds
+ */
+export interface RuleEvaluationCost {
+  queryTime: number;
+  expressionTime: number;
+  series: number;
+  missedEvaluations: number;
+  lateEvaluations: number;
+  slow: boolean;
+  highCardinality: boolean;
+}
+
 interface PromRuleDTOBase {
   health: string;
   name: string;
   query: string; // expr
   evaluationTime?: number;
+  evaluationCost?: RuleEvaluationCost;
   lastEvaluation?: string;
   lastError?: string;
 }
@@ -12,6 +12,7 @@ import {
   mapStateWithReasonToBaseState,
   PromAlertingRuleState,
   PromRuleType,
+  RuleEvaluationCost,
   RulerRuleDTO,
   RulerRuleGroupDTO,
 } from './unified-alerting-dto';
@@ -37,6 +38,7 @@ interface RuleBase {
   query: string;
   lastEvaluation?: string;
   evaluationTime?: number;
+  evaluationCost?: RuleEvaluationCost;
   lastError?: string;
 }
 